@@ -8,6 +8,8 @@ import (
 
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/rpc"
+	"github.com/kukrer/savannahnode/version"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
 )
 
 var _ Client = &client{}
@@ -15,7 +17,7 @@ var _ Client = &client{}
 // Client interface for an Info API Client
 type Client interface {
 	GetNodeVersion(context.Context, ...rpc.Option) (*GetNodeVersionReply, error)
-	GetNodeID(context.Context, ...rpc.Option) (ids.NodeID, error)
+	GetNodeID(context.Context, ...rpc.Option) (ids.NodeID, *signer.ProofOfPossession, error)
 	GetNodeIP(context.Context, ...rpc.Option) (string, error)
 	GetNetworkID(context.Context, ...rpc.Option) (uint32, error)
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
@@ -23,6 +25,7 @@ type Client interface {
 	Peers(context.Context, ...rpc.Option) ([]Peer, error)
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
+	GetUpgrades(context.Context, ...rpc.Option) (*version.Upgrades, error)
 	Uptime(context.Context, ...rpc.Option) (*UptimeResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
 }
@@ -46,10 +49,10 @@ func (c *client) GetNodeVersion(ctx context.Context, options ...rpc.Option) (*Ge
 	return res, err
 }
 
-func (c *client) GetNodeID(ctx context.Context, options ...rpc.Option) (ids.NodeID, error) {
+func (c *client) GetNodeID(ctx context.Context, options ...rpc.Option) (ids.NodeID, *signer.ProofOfPossession, error) {
 	res := &GetNodeIDReply{}
 	err := c.requester.SendRequest(ctx, "getNodeID", struct{}{}, res, options...)
-	return res.NodeID, err
+	return res.NodeID, res.NodePOP, err
 }
 
 func (c *client) GetNodeIP(ctx context.Context, options ...rpc.Option) (string, error) {
@@ -98,6 +101,12 @@ func (c *client) GetTxFee(ctx context.Context, options ...rpc.Option) (*GetTxFee
 	return res, err
 }
 
+func (c *client) GetUpgrades(ctx context.Context, options ...rpc.Option) (*version.Upgrades, error) {
+	res := &version.Upgrades{}
+	err := c.requester.SendRequest(ctx, "getUpgrades", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) Uptime(ctx context.Context, options ...rpc.Option) (*UptimeResponse, error) {
 	res := &UptimeResponse{}
 	err := c.requester.SendRequest(ctx, "uptime", struct{}{}, res, options...)
@@ -23,6 +23,7 @@ import (
 	"github.com/kukrer/savannahnode/utils/logging"
 	"github.com/kukrer/savannahnode/version"
 	"github.com/kukrer/savannahnode/vms"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
 )
 
 var (
@@ -45,12 +46,14 @@ type Info struct {
 type Parameters struct {
 	Version               *version.Application
 	NodeID                ids.NodeID
+	NodePOP               *signer.ProofOfPossession
 	NetworkID             uint32
 	TxFee                 uint64
 	CreateAssetTxFee      uint64
 	CreateSubnetTxFee     uint64
 	CreateBlockchainTxFee uint64
 	VMManager             vms.Manager
+	Upgrades              *version.Upgrades
 }
 
 // NewService returns a new admin API service
@@ -109,14 +112,17 @@ func (service *Info) GetNodeVersion(_ *http.Request, _ *struct{}, reply *GetNode
 
 // GetNodeIDReply are the results from calling GetNodeID
 type GetNodeIDReply struct {
-	NodeID ids.NodeID `json:"nodeID"`
+	NodeID  ids.NodeID                `json:"nodeID"`
+	NodePOP *signer.ProofOfPossession `json:"nodePOP"`
 }
 
-// GetNodeID returns the node ID of this node
+// GetNodeID returns the node ID of this node and the BLS key and proof of
+// possession it would register as a primary network validator
 func (service *Info) GetNodeID(_ *http.Request, _ *struct{}, reply *GetNodeIDReply) error {
 	service.log.Debug("Info: GetNodeID called")
 
 	reply.NodeID = service.NodeID
+	reply.NodePOP = service.NodePOP
 	return nil
 }
 
@@ -293,6 +299,14 @@ func (service *Info) GetTxFee(_ *http.Request, args *struct{}, reply *GetTxFeeRe
 	return nil
 }
 
+// GetUpgrades returns the network upgrade schedule of the node
+func (service *Info) GetUpgrades(_ *http.Request, _ *struct{}, reply *version.Upgrades) error {
+	service.log.Debug("Info: GetUpgrades called")
+
+	*reply = *service.Upgrades
+	return nil
+}
+
 // GetVMsReply contains the response metadata for GetVMs
 type GetVMsReply struct {
 	VMs map[ids.ID][]string `json:"vms"`
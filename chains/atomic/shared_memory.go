@@ -9,7 +9,10 @@ import (
 	"github.com/kukrer/savannahnode/ids"
 )
 
-var _ SharedMemory = &sharedMemory{}
+var (
+	_ SharedMemory = &sharedMemory{}
+	_ Lister       = &sharedMemory{}
+)
 
 type Requests struct {
 	RemoveRequests [][]byte   `serialize:"true"`
@@ -54,6 +57,18 @@ type SharedMemory interface {
 	Apply(requests map[ids.ID]*Requests, batches ...database.Batch) error
 }
 
+// Lister lists the values pending in shared memory between a blockchain and a
+// peer blockchain. It's only implemented by the shared memory of the
+// blockchains running in the node process.
+type Lister interface {
+	// Inbound returns the values that have been sent from [peerChainID] and
+	// that haven't been removed yet
+	Inbound(peerChainID ids.ID) ([][]byte, error)
+	// Outbound returns the values that have been sent to [peerChainID] and
+	// that [peerChainID] hasn't removed yet
+	Outbound(peerChainID ids.ID) ([][]byte, error)
+}
+
 // sharedMemory provides the API for a blockchain to interact with shared memory
 // of another blockchain
 type sharedMemory struct {
@@ -111,6 +126,27 @@ func (sm *sharedMemory) Indexed(
 	return values, lastTrait, lastKey, nil
 }
 
+func (sm *sharedMemory) Inbound(peerChainID ids.ID) ([][]byte, error) {
+	return sm.values(&inbound, peerChainID)
+}
+
+func (sm *sharedMemory) Outbound(peerChainID ids.ID) ([][]byte, error) {
+	return sm.values(&outbound, peerChainID)
+}
+
+// values returns the values of the [p] side of the shared memory with
+// [peerChainID] that haven't been removed
+func (sm *sharedMemory) values(p *prefixes, peerChainID ids.ID) ([][]byte, error) {
+	sharedID := sm.m.sharedID(peerChainID, sm.thisChainID)
+	db := sm.m.GetSharedDatabase(sm.m.db, sharedID)
+	defer sm.m.ReleaseSharedDatabase(sharedID)
+
+	s := state{
+		valueDB: p.getValueDB(sm.thisChainID, peerChainID, db),
+	}
+	return s.Values()
+}
+
 func (sm *sharedMemory) Apply(requests map[ids.ID]*Requests, batches ...database.Batch) error {
 	// Sorting here introduces an ordering over the locks to prevent any
 	// deadlocks
@@ -6,6 +6,8 @@ package atomic
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/kukrer/savannahnode/database/memdb"
 	"github.com/kukrer/savannahnode/database/prefixdb"
 	"github.com/kukrer/savannahnode/ids"
@@ -29,3 +31,52 @@ func TestSharedMemory(t *testing.T) {
 		test(t, chainID0, chainID1, sm0, sm1, testDB)
 	}
 }
+
+func TestSharedMemoryLister(t *testing.T) {
+	require := require.New(t)
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+
+	m := NewMemory(memdb.New())
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+	lister0 := sm0.(Lister)
+	lister1 := sm1.(Lister)
+
+	values, err := lister0.Outbound(chainID1)
+	require.NoError(err)
+	require.Empty(values)
+
+	// Removing a value that wasn't sent yet leaves a removal marker, which
+	// isn't pending
+	require.NoError(sm1.Apply(map[ids.ID]*Requests{chainID0: {
+		RemoveRequests: [][]byte{{2}},
+	}}))
+	require.NoError(sm0.Apply(map[ids.ID]*Requests{chainID1: {
+		PutRequests: []*Element{
+			{Key: []byte{0}, Value: []byte{10}},
+			{Key: []byte{1}, Value: []byte{11}},
+		},
+	}}))
+
+	values, err = lister0.Outbound(chainID1)
+	require.NoError(err)
+	require.Equal([][]byte{{10}, {11}}, values)
+
+	values, err = lister1.Inbound(chainID0)
+	require.NoError(err)
+	require.Equal([][]byte{{10}, {11}}, values)
+
+	values, err = lister0.Inbound(chainID1)
+	require.NoError(err)
+	require.Empty(values)
+
+	require.NoError(sm1.Apply(map[ids.ID]*Requests{chainID0: {
+		RemoveRequests: [][]byte{{0}},
+	}}))
+
+	values, err = lister0.Outbound(chainID1)
+	require.NoError(err)
+	require.Equal([][]byte{{11}}, values)
+}
@@ -173,6 +173,25 @@ func (s *state) RemoveValue(key []byte) error {
 	return s.valueDB.Delete(key)
 }
 
+// Values returns the values of the elements in the state that haven't been
+// removed.
+func (s *state) Values() ([][]byte, error) {
+	it := s.valueDB.NewIterator()
+	defer it.Release()
+
+	var values [][]byte
+	for it.Next() {
+		value := dbElement{}
+		if _, err := codecManager.Unmarshal(it.Value(), &value); err != nil {
+			return nil, err
+		}
+		if value.Present {
+			values = append(values, value.Value)
+		}
+	}
+	return values, it.Error()
+}
+
 // loadValue retrieves the dbElement corresponding to [key] from the value
 // database.
 func (s *state) loadValue(key []byte) (*dbElement, error) {
@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/snow/engine/avalanche/vertex"
+	"github.com/kukrer/savannahnode/snow/engine/common"
+
+	dbManager "github.com/kukrer/savannahnode/database/manager"
+)
+
+var _ vertex.LinearizableVM = &initializeOnLinearizeVM{}
+
+// initializeOnLinearizeVM transforms the consensus engine's call to
+// Initialize into a call to Linearize. The VM is expected to have already
+// been initialized when the chain was created as a DAG.
+type initializeOnLinearizeVM struct {
+	vertex.LinearizableVM
+
+	stopVertexID ids.ID
+}
+
+func (vm *initializeOnLinearizeVM) Initialize(
+	_ *snow.Context,
+	_ dbManager.Manager,
+	_ []byte,
+	_ []byte,
+	_ []byte,
+	toEngine chan<- common.Message,
+	_ []*common.Fx,
+	_ common.AppSender,
+) error {
+	return vm.Linearize(vm.stopVertexID, toEngine)
+}
@@ -36,13 +36,15 @@ import (
 	"github.com/kukrer/savannahnode/snow/networking/handler"
 	"github.com/kukrer/savannahnode/snow/networking/router"
 	"github.com/kukrer/savannahnode/snow/networking/sender"
+	"github.com/kukrer/savannahnode/snow/networking/signatures"
 	"github.com/kukrer/savannahnode/snow/networking/timeout"
 	"github.com/kukrer/savannahnode/snow/validators"
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
 	"github.com/kukrer/savannahnode/utils/logging"
-	"github.com/kukrer/savannahnode/version"
 	"github.com/kukrer/savannahnode/vms"
 	"github.com/kukrer/savannahnode/vms/metervm"
+	"github.com/kukrer/savannahnode/vms/platformvm/warp"
 	"github.com/kukrer/savannahnode/vms/proposervm"
 
 	dbManager "github.com/kukrer/savannahnode/database/manager"
@@ -141,6 +143,7 @@ type ChainConfig struct {
 type ManagerConfig struct {
 	StakingEnabled              bool            // True iff the network has staking enabled
 	StakingCert                 tls.Certificate // needed to sign snowman++ blocks
+	StakingBLSKey               *bls.SecretKey  // needed to sign warp messages
 	Log                         logging.Logger
 	LogFactory                  logging.Factory
 	VMManager                   vms.Manager // Manage mappings from vm ID --> vm
@@ -187,6 +190,7 @@ type ManagerConfig struct {
 
 	ApricotPhase4Time            time.Time
 	ApricotPhase4MinPChainHeight uint64
+	XChainMigrationTime          time.Time
 
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
@@ -365,6 +369,12 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		return nil, fmt.Errorf("error while registering chain's metrics %w", err)
 	}
 
+	avalancheConsensusMetrics := prometheus.NewRegistry()
+	avalancheNamespace := fmt.Sprintf("%s_avalanche", chainNamespace)
+	if err := m.Metrics.Register(avalancheNamespace, avalancheConsensusMetrics); err != nil {
+		return nil, fmt.Errorf("error while registering chain's avalanche metrics %w", err)
+	}
+
 	vmMetrics := metrics.NewOptionalGatherer()
 	vmNamespace := fmt.Sprintf("%s_vm", chainNamespace)
 	if err := m.Metrics.Register(vmNamespace, vmMetrics); err != nil {
@@ -391,10 +401,13 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 			ValidatorState:    m.validatorState,
 			StakingCertLeaf:   m.StakingCert.Leaf,
 			StakingLeafSigner: m.StakingCert.PrivateKey.(crypto.Signer),
+
+			WarpSigner: warp.NewSigner(m.StakingBLSKey, chainParams.ID),
 		},
-		DecisionAcceptor:  m.DecisionAcceptorGroup,
-		ConsensusAcceptor: m.ConsensusAcceptorGroup,
-		Registerer:        consensusMetrics,
+		DecisionAcceptor:    m.DecisionAcceptorGroup,
+		ConsensusAcceptor:   m.ConsensusAcceptorGroup,
+		Registerer:          consensusMetrics,
+		AvalancheRegisterer: avalancheConsensusMetrics,
 	}
 	// We set the state to Initializing here because failing to set the state
 	// before it's first access would cause a panic.
@@ -544,12 +557,13 @@ func (m *manager) createAvalancheChain(
 	vertexDB := prefixdb.New([]byte("vertex"), db.Database)
 	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db.Database)
 	txBootstrappingDB := prefixdb.New([]byte("tx_bs"), db.Database)
+	linearizedBootstrappingDB := prefixdb.New([]byte("bs"), db.Database)
 
-	vtxBlocker, err := queue.NewWithMissing(vertexBootstrappingDB, "vtx", ctx.Registerer)
+	vtxBlocker, err := queue.NewWithMissing(vertexBootstrappingDB, "vtx", ctx.AvalancheRegisterer)
 	if err != nil {
 		return nil, err
 	}
-	txBlocker, err := queue.New(txBootstrappingDB, "tx", ctx.Registerer)
+	txBlocker, err := queue.New(txBootstrappingDB, "tx", ctx.AvalancheRegisterer)
 	if err != nil {
 		return nil, err
 	}
@@ -576,6 +590,10 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
 	}
 
+	// Serves and fetches the signatures of warp messages
+	signatureClient := signatures.NewClient(ctx.Log, ctx.WarpSigner, sender)
+	ctx.WarpClient = signatureClient
+
 	if err := m.ConsensusAcceptorGroup.RegisterAcceptor(ctx.ChainID, "gossip", sender, false); err != nil { // Set up the event dipatcher
 		return nil, fmt.Errorf("problem initializing event dispatcher: %w", err)
 	}
@@ -585,6 +603,9 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("error while fetching chain config: %w", err)
 	}
 
+	// The VM is moved onto Snowman consensus once the stop vertex is accepted
+	linearizableVM, linearizable := vm.(vertex.LinearizableVM)
+
 	if m.MeterVMEnabled {
 		vm = metervm.NewVertexVM(vm)
 	}
@@ -597,7 +618,7 @@ func (m *manager) createAvalancheChain(
 			VM:                  vm,
 			DB:                  vertexDB,
 			Log:                 ctx.Log,
-			XChainMigrationTime: version.GetXChainMigrationTime(ctx.NetworkID),
+			XChainMigrationTime: m.XChainMigrationTime,
 		},
 	)
 	if err := vm.Initialize(
@@ -631,6 +652,7 @@ func (m *manager) createAvalancheChain(
 	if err != nil {
 		return nil, fmt.Errorf("error initializing network handler: %w", err)
 	}
+	handler.SetSignatureHandler(signatureClient)
 
 	connectedPeers := tracker.NewPeers()
 	startupTracker := tracker.NewStartup(connectedPeers, (3*bootstrapWeight+3)/4)
@@ -668,9 +690,119 @@ func (m *manager) createAvalancheChain(
 		Manager:       vtxManager,
 		VM:            vm,
 	}
+	// The VM registered its metrics when it was initialized as a DAG VM, so
+	// the linearized VM registers its metrics separately.
+	snowmanVMMetrics := metrics.NewOptionalGatherer()
+	if linearizable && m.MeterVMEnabled {
+		snowmanVMNamespace := fmt.Sprintf("%s_%s_snowman_vm", constants.PlatformName, m.PrimaryAliasOrDefault(ctx.ChainID))
+		if err := m.Metrics.Register(snowmanVMNamespace, snowmanVMMetrics); err != nil {
+			return nil, fmt.Errorf("error while registering linearized vm's metrics %w", err)
+		}
+	}
+
+	// linearize moves the chain onto Snowman consensus by replacing the
+	// Avalanche gear of the handler with a Snowman gear running on top of the
+	// stop vertex. The returned engines aren't started.
+	linearize := func() (common.BootstrapableEngine, common.Engine, error) {
+		edge := vtxManager.Edge()
+		if len(edge) != 1 {
+			return nil, nil, fmt.Errorf("expected the stop vertex to be the only vertex of the edge but got %d vertices", len(edge))
+		}
+		stopVertexID := edge[0]
+
+		ctx.SetLinearized()
+
+		blocked, err := queue.NewWithMissing(linearizedBootstrappingDB, "block", ctx.Registerer)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// enable ProposerVM on the linearized VM
+		var snowmanVM block.ChainVM = proposervm.New(
+			&initializeOnLinearizeVM{
+				LinearizableVM: linearizableVM,
+				stopVertexID:   stopVertexID,
+			},
+			m.ApricotPhase4Time,
+			m.ApricotPhase4MinPChainHeight,
+		)
+
+		dagVMMetrics := ctx.Metrics
+		if m.MeterVMEnabled {
+			snowmanVM = metervm.NewBlockVM(snowmanVM)
+			// The meter registers into the gatherer of the context, which
+			// already holds the metrics of the DAG VM.
+			ctx.Metrics = snowmanVMMetrics
+		}
+		err = snowmanVM.Initialize(
+			ctx.Context,
+			vmDBManager,
+			genesisData,
+			chainConfig.Upgrade,
+			chainConfig.Config,
+			msgChan,
+			fxs,
+			sender,
+		)
+		ctx.Metrics = dagVMMetrics
+		if err != nil {
+			return nil, nil, fmt.Errorf("error during linearized vm's Initialize: %w", err)
+		}
+
+		snowmanCfg := commonCfg
+		snowmanCfg.SharedCfg = &common.SharedConfig{}
+
+		snowGetHandler, err := snowgetter.New(snowmanVM, snowmanCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't initialize snow base message handler: %w", err)
+		}
+
+		snowmanEngine, err := smeng.New(smeng.Config{
+			Ctx:           snowmanCfg.Ctx,
+			AllGetsServer: snowGetHandler,
+			VM:            snowmanVM,
+			Sender:        snowmanCfg.Sender,
+			Validators:    vdrs,
+			Params:        consensusParams.Parameters,
+			Consensus:     &smcon.Topological{},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("error initializing snowman engine: %w", err)
+		}
+		handler.SetConsensus(snowmanEngine)
+
+		snowmanBootstrapper, err := smbootstrap.New(
+			smbootstrap.Config{
+				Config:        snowmanCfg,
+				AllGetsServer: snowGetHandler,
+				Blocked:       blocked,
+				VM:            snowmanVM,
+			},
+			snowmanEngine.Start,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error initializing snowman bootstrapper: %w", err)
+		}
+		handler.SetBootstrapper(snowmanBootstrapper)
+		return snowmanBootstrapper, snowmanEngine, nil
+	}
+
 	bootstrapper, err := avbootstrap.New(
 		bootstrapperConfig,
 		func(lastReqID uint32) error {
+			if linearizable {
+				stopped, err := vtxManager.StopVertexAccepted()
+				if err != nil {
+					return err
+				}
+				if stopped {
+					snowmanBootstrapper, _, err := linearize()
+					if err != nil {
+						return err
+					}
+					return snowmanBootstrapper.Start(lastReqID + 1)
+				}
+			}
 			return handler.Consensus().Start(lastReqID + 1)
 		},
 	)
@@ -690,12 +822,37 @@ func (m *manager) createAvalancheChain(
 		Params:        consensusParams,
 		Consensus:     &avcon.Topological{},
 	}
+	if linearizable {
+		// The stop vertex is accepted while the engine is running, so the
+		// Snowman engine can be started right away.
+		engineConfig.Linearize = func(lastReqID uint32) error {
+			_, snowmanEngine, err := linearize()
+			if err != nil {
+				return err
+			}
+			return snowmanEngine.Start(lastReqID + 1)
+		}
+	}
 	engine, err := aveng.New(engineConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing avalanche engine: %w", err)
 	}
 	handler.SetConsensus(engine)
 
+	// If the chain was linearized in a previous run, the Avalanche gear must
+	// not be started.
+	if linearizable {
+		stopped, err := vtxManager.StopVertexAccepted()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get whether the stop vertex was accepted: %w", err)
+		}
+		if stopped {
+			if _, _, err := linearize(); err != nil {
+				return nil, fmt.Errorf("couldn't linearize the chain: %w", err)
+			}
+		}
+	}
+
 	// Register health check for this chain
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
 
@@ -762,6 +919,10 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
 	}
 
+	// Serves and fetches the signatures of warp messages
+	signatureClient := signatures.NewClient(ctx.Log, ctx.WarpSigner, sender)
+	ctx.WarpClient = signatureClient
+
 	if err := m.ConsensusAcceptorGroup.RegisterAcceptor(ctx.ChainID, "gossip", sender, false); err != nil { // Set up the event dipatcher
 		return nil, fmt.Errorf("problem initializing event dispatcher: %w", err)
 	}
@@ -832,6 +993,7 @@ func (m *manager) createSnowmanChain(
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize message handler: %w", err)
 	}
+	handler.SetSignatureHandler(signatureClient)
 
 	connectedPeers := tracker.NewPeers()
 	startupTracker := tracker.NewStartup(connectedPeers, (3*bootstrapWeight+3)/4)
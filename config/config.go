@@ -36,15 +36,17 @@ import (
 	"github.com/kukrer/savannahnode/snow/networking/tracker"
 	"github.com/kukrer/savannahnode/staking"
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
 	"github.com/kukrer/savannahnode/utils/dynamicip"
 	"github.com/kukrer/savannahnode/utils/ips"
 	"github.com/kukrer/savannahnode/utils/logging"
 	"github.com/kukrer/savannahnode/utils/password"
+	"github.com/kukrer/savannahnode/utils/perms"
 	"github.com/kukrer/savannahnode/utils/profiler"
 	"github.com/kukrer/savannahnode/utils/storage"
 	"github.com/kukrer/savannahnode/utils/timer"
+	"github.com/kukrer/savannahnode/version"
 	"github.com/kukrer/savannahnode/vms"
-	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 )
 
 const (
@@ -63,14 +65,6 @@ var (
 	errInvalidStakerWeights          = errors.New("staking weights must be positive")
 	errStakingDisableOnPublicNetwork = errors.New("staking disabled on public network")
 	errAuthPasswordTooWeak           = errors.New("API auth password is not strong enough")
-	errInvalidUptimeRequirement      = errors.New("uptime requirement must be in the range [0, 1]")
-	errMinValidatorStakeAboveMax     = errors.New("minimum validator stake can't be greater than maximum validator stake")
-	errInvalidDelegationFee          = errors.New("delegation fee must be in the range [0, 1,000,000]")
-	errInvalidMinStakeDuration       = errors.New("min stake duration must be > 0")
-	errMinStakeDurationAboveMax      = errors.New("max stake duration can't be less than min stake duration")
-	errStakeMaxConsumptionTooLarge   = fmt.Errorf("max stake consumption must be less than or equal to %d", reward.PercentDenominator)
-	errStakeMaxConsumptionBelowMin   = errors.New("stake max consumption can't be less than min stake consumption")
-	errStakeMintingPeriodBelowMin    = errors.New("stake minting period can't be less than max stake duration")
 	errCannotWhitelistPrimaryNetwork = errors.New("cannot whitelist primary network")
 	errStakingKeyContentUnset        = fmt.Errorf("%s key not set but %s set", StakingKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset       = fmt.Errorf("%s key set but %s not set", StakingKeyContentKey, StakingCertContentKey)
@@ -718,12 +712,72 @@ func getStakingTLSCert(v *viper.Viper) (tls.Certificate, error) {
 	}
 }
 
+func getStakingSigner(v *viper.Viper) (*bls.SecretKey, error) {
+	if v.GetBool(StakingEphemeralSignerEnabledKey) {
+		key, err := bls.NewSecretKey()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate ephemeral signing key: %w", err)
+		}
+		return key, nil
+	}
+
+	if v.IsSet(StakingSignerKeyContentKey) {
+		signerKeyRawContent := v.GetString(StakingSignerKeyContentKey)
+		signerKeyContent, err := base64.StdEncoding.DecodeString(signerKeyRawContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+		key, err := bls.SecretKeyFromBytes(signerKeyContent)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse signing key: %w", err)
+		}
+		return key, nil
+	}
+
+	signingKeyPath := GetExpandedArg(v, StakingSignerKeyPathKey)
+	if _, err := os.Stat(signingKeyPath); !os.IsNotExist(err) {
+		signingKeyBytes, err := os.ReadFile(signingKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		key, err := bls.SecretKeyFromBytes(signingKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse signing key: %w", err)
+		}
+		return key, nil
+	}
+
+	// If the signing key location is specified but not found, error
+	if v.IsSet(StakingSignerKeyPathKey) {
+		return nil, fmt.Errorf("couldn't find staking signing key at %s", signingKeyPath)
+	}
+
+	key, err := bls.NewSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate new signing key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(signingKeyPath), perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("couldn't create path for signing key at %s: %w", signingKeyPath, err)
+	}
+
+	keyBytes := bls.SecretKeyToBytes(key)
+	if err := os.WriteFile(signingKeyPath, keyBytes, perms.ReadWrite); err != nil {
+		return nil, fmt.Errorf("couldn't write new signing key to %s: %w", signingKeyPath, err)
+	}
+	if err := os.Chmod(signingKeyPath, perms.ReadOnly); err != nil {
+		return nil, fmt.Errorf("couldn't restrict permissions on new signing key at %s: %w", signingKeyPath, err)
+	}
+	return key, nil
+}
+
 func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, error) {
 	config := node.StakingConfig{
 		EnableStaking:         v.GetBool(StakingEnabledKey),
 		DisabledStakingWeight: v.GetUint64(StakingDisabledWeightKey),
 		StakingKeyPath:        GetExpandedArg(v, StakingKeyPathKey),
 		StakingCertPath:       GetExpandedArg(v, StakingCertPathKey),
+		StakingSignerPath:     GetExpandedArg(v, StakingSignerKeyPathKey),
 	}
 	if !config.EnableStaking && config.DisabledStakingWeight == 0 {
 		return node.StakingConfig{}, errInvalidStakerWeights
@@ -738,6 +792,10 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 	if err != nil {
 		return node.StakingConfig{}, err
 	}
+	config.StakingSigningKey, err = getStakingSigner(v)
+	if err != nil {
+		return node.StakingConfig{}, err
+	}
 	if networkID != constants.MainnetID && networkID != constants.FujiID && networkID != constants.SavannahID && networkID != constants.MarulaID {
 		config.UptimeRequirement = v.GetFloat64(UptimeRequirementKey)
 		config.MinValidatorStake = v.GetUint64(MinValidatorStakeKey)
@@ -750,30 +808,56 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 		config.RewardConfig.MintingPeriod = v.GetDuration(StakeMintingPeriodKey)
 		config.RewardConfig.SupplyCap = v.GetUint64(StakeSupplyCapKey)
 		config.MinDelegationFee = v.GetUint32(MinDelegatorFeeKey)
-		switch {
-		case config.UptimeRequirement < 0 || config.UptimeRequirement > 1:
-			return node.StakingConfig{}, errInvalidUptimeRequirement
-		case config.MinValidatorStake > config.MaxValidatorStake:
-			return node.StakingConfig{}, errMinValidatorStakeAboveMax
-		case config.MinDelegationFee > 1_000_000:
-			return node.StakingConfig{}, errInvalidDelegationFee
-		case config.MinStakeDuration <= 0:
-			return node.StakingConfig{}, errInvalidMinStakeDuration
-		case config.MaxStakeDuration < config.MinStakeDuration:
-			return node.StakingConfig{}, errMinStakeDurationAboveMax
-		case config.RewardConfig.MaxConsumptionRate > reward.PercentDenominator:
-			return node.StakingConfig{}, errStakeMaxConsumptionTooLarge
-		case config.RewardConfig.MaxConsumptionRate < config.RewardConfig.MinConsumptionRate:
-			return node.StakingConfig{}, errStakeMaxConsumptionBelowMin
-		case config.RewardConfig.MintingPeriod < config.MaxStakeDuration:
-			return node.StakingConfig{}, errStakeMintingPeriodBelowMin
+		if err := config.StakingConfig.Verify(); err != nil {
+			return node.StakingConfig{}, err
+		}
+		config.StakingUpgrades, err = getStakingUpgrades(v, config.StakingConfig)
+		if err != nil {
+			return node.StakingConfig{}, err
 		}
 	} else {
 		config.StakingConfig = genesis.GetStakingConfig(networkID)
+		config.StakingUpgrades = genesis.GetStakingUpgrades(networkID)
 	}
 	return config, nil
 }
 
+// getStakingUpgrades returns the staking upgrades that change [config] over
+// time, if any are specified
+func getStakingUpgrades(v *viper.Viper, config genesis.StakingConfig) ([]genesis.StakingUpgrade, error) {
+	var (
+		upgradesBytes []byte
+		err           error
+	)
+	switch {
+	case v.IsSet(StakingUpgradesContentKey):
+		upgradesContent := v.GetString(StakingUpgradesContentKey)
+		upgradesBytes, err = base64.StdEncoding.DecodeString(upgradesContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(StakingUpgradesFileKey):
+		path := GetExpandedArg(v, StakingUpgradesFileKey)
+		upgradesBytes, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	upgrades, err := genesis.ParseStakingUpgrades(config, upgradesBytes)
+	if err != nil {
+		return nil, err
+	}
+	for _, upgrade := range upgrades {
+		if err := upgrade.StakingConfig.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid staking upgrade at %s: %w", upgrade.Time, err)
+		}
+	}
+	return upgrades, nil
+}
+
 func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
 	if networkID != constants.MainnetID && networkID != constants.FujiID && networkID != constants.SavannahID && networkID != constants.MarulaID {
 		return genesis.TxFeeConfig{
@@ -781,6 +865,7 @@ func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
 			CreateAssetTxFee:      v.GetUint64(CreateAssetTxFeeKey),
 			CreateSubnetTxFee:     v.GetUint64(CreateSubnetTxFeeKey),
 			CreateBlockchainTxFee: v.GetUint64(CreateBlockchainTxFeeKey),
+			DynamicFeeConfig:      genesis.LocalParams.DynamicFeeConfig,
 		}
 	}
 	return genesis.GetTxFeeConfig(networkID)
@@ -804,6 +889,30 @@ func getGenesisData(v *viper.Viper, networkID uint32) ([]byte, ids.ID, error) {
 	return genesis.FromConfig(config)
 }
 
+func getUpgrades(v *viper.Viper, networkID uint32) (*version.Upgrades, error) {
+	var (
+		upgradesBytes []byte
+		err           error
+	)
+	switch {
+	case v.IsSet(UpgradeConfigContentKey):
+		upgradesContent := v.GetString(UpgradeConfigContentKey)
+		upgradesBytes, err = base64.StdEncoding.DecodeString(upgradesContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(UpgradeConfigFileKey):
+		path := GetExpandedArg(v, UpgradeConfigFileKey)
+		upgradesBytes, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return version.GetUpgrades(networkID), nil
+	}
+	return version.ParseUpgrades(networkID, upgradesBytes)
+}
+
 func getWhitelistedSubnets(v *viper.Viper) (ids.Set, error) {
 	whitelistedSubnetIDs := ids.Set{}
 	for _, subnet := range strings.Split(v.GetString(WhitelistedSubnetsKey), ",") {
@@ -1259,6 +1368,12 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	// Tx Fee
 	nodeConfig.TxFeeConfig = getTxFeeConfig(v, nodeConfig.NetworkID)
 
+	// Network Upgrades
+	nodeConfig.Upgrades, err = getUpgrades(v, nodeConfig.NetworkID)
+	if err != nil {
+		return node.Config{}, fmt.Errorf("unable to load network upgrades: %w", err)
+	}
+
 	// Genesis Data
 	nodeConfig.GenesisBytes, nodeConfig.AvaxAssetID, err = getGenesisData(v, nodeConfig.NetworkID)
 	if err != nil {
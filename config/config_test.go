@@ -19,6 +19,7 @@ import (
 
 	"github.com/kukrer/savannahnode/chains"
 	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -592,6 +593,48 @@ func setupFile(t *testing.T, path string, fileName string, value string) {
 	require.NoError(t, os.WriteFile(filePath, []byte(value), 0o600))
 }
 
+func TestGetStakingSigner(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	skBytes := bls.SecretKeyToBytes(sk)
+
+	// The key is read from the content flag
+	v := setupViperFlags()
+	v.Set(StakingSignerKeyContentKey, base64.StdEncoding.EncodeToString(skBytes))
+	signer, err := getStakingSigner(v)
+	require.NoError(err)
+	require.Equal(skBytes, bls.SecretKeyToBytes(signer))
+
+	// An explicitly provided key file must exist
+	root := t.TempDir()
+	keyPath := filepath.Join(root, "signer.key")
+	v = setupViperFlags()
+	v.Set(StakingSignerKeyPathKey, keyPath)
+	_, err = getStakingSigner(v)
+	require.Error(err)
+
+	// The key is read from the key file
+	setupFile(t, root, "signer.key", string(skBytes))
+	signer, err = getStakingSigner(v)
+	require.NoError(err)
+	require.Equal(skBytes, bls.SecretKeyToBytes(signer))
+
+	// A key is generated and persisted at the default path
+	oldDefaultStakingSignerKeyPath := defaultStakingSignerKeyPath
+	t.Cleanup(func() {
+		defaultStakingSignerKeyPath = oldDefaultStakingSignerKeyPath
+	})
+	defaultStakingSignerKeyPath = filepath.Join(root, "default", "signer.key")
+	v = setupViperFlags()
+	signer, err = getStakingSigner(v)
+	require.NoError(err)
+	persistedBytes, err := os.ReadFile(defaultStakingSignerKeyPath)
+	require.NoError(err)
+	require.Equal(bls.SecretKeyToBytes(signer), persistedBytes)
+}
+
 func setupViperFlags() *viper.Viper {
 	v := viper.New()
 	fs := BuildFlagSet()
@@ -34,18 +34,19 @@ const (
 
 var (
 	// [defaultUnexpandedDataDir] will be expanded when reading the flags
-	defaultDataDir         = filepath.Join("$HOME", ".savannahnode")
-	defaultDBDir           = filepath.Join(defaultUnexpandedDataDir, "db")
-	defaultLogDir          = filepath.Join(defaultUnexpandedDataDir, "logs")
-	defaultProfileDir      = filepath.Join(defaultUnexpandedDataDir, "profiles")
-	defaultStakingPath     = filepath.Join(defaultUnexpandedDataDir, "staking")
-	defaultStakingKeyPath  = filepath.Join(defaultStakingPath, "staker.key")
-	defaultStakingCertPath = filepath.Join(defaultStakingPath, "staker.crt")
-	defaultConfigDir       = filepath.Join(defaultUnexpandedDataDir, "configs")
-	defaultChainConfigDir  = filepath.Join(defaultConfigDir, "chains")
-	defaultVMConfigDir     = filepath.Join(defaultConfigDir, "vms")
-	defaultVMAliasFilePath = filepath.Join(defaultVMConfigDir, "aliases.json")
-	defaultSubnetConfigDir = filepath.Join(defaultConfigDir, "subnets")
+	defaultDataDir              = filepath.Join("$HOME", ".savannahnode")
+	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
+	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
+	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
+	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
+	defaultStakingKeyPath       = filepath.Join(defaultStakingPath, "staker.key")
+	defaultStakingCertPath      = filepath.Join(defaultStakingPath, "staker.crt")
+	defaultStakingSignerKeyPath = filepath.Join(defaultStakingPath, "signer.key")
+	defaultConfigDir            = filepath.Join(defaultUnexpandedDataDir, "configs")
+	defaultChainConfigDir       = filepath.Join(defaultConfigDir, "chains")
+	defaultVMConfigDir          = filepath.Join(defaultConfigDir, "vms")
+	defaultVMAliasFilePath      = filepath.Join(defaultVMConfigDir, "aliases.json")
+	defaultSubnetConfigDir      = filepath.Join(defaultConfigDir, "subnets")
 
 	// Places to look for the build directory
 	defaultBuildDirs = []string{}
@@ -95,6 +96,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 		GenesisConfigContentKey))
 	fs.String(GenesisConfigContentKey, "", "Specifies base64 encoded genesis content")
 
+	// Network Upgrades
+	fs.String(UpgradeConfigFileKey, "", fmt.Sprintf("Specifies a network upgrade config file, which overrides the upgrade times of the network (not allowed on public networks). Ignored if %s is specified", UpgradeConfigContentKey))
+	fs.String(UpgradeConfigContentKey, "", "Specifies base64 encoded network upgrade config content")
+
 	// Network ID
 	fs.String(NetworkNameKey, constants.MainnetName, "Network ID this node will connect to")
 
@@ -253,6 +258,9 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(StakingKeyContentKey, "", "Specifies base64 encoded TLS private key for staking")
 	fs.String(StakingCertPathKey, defaultStakingCertPath, fmt.Sprintf("Path to the TLS certificate for staking. Ignored if %s is specified", StakingCertContentKey))
 	fs.String(StakingCertContentKey, "", "Specifies base64 encoded TLS certificate for staking")
+	fs.Bool(StakingEphemeralSignerEnabledKey, false, "If true, the node uses an ephemeral staking signer key")
+	fs.String(StakingSignerKeyPathKey, defaultStakingSignerKeyPath, fmt.Sprintf("Path to the signer private key for staking. Ignored if %s is specified", StakingSignerKeyContentKey))
+	fs.String(StakingSignerKeyContentKey, "", "Specifies base64 encoded signer private key for staking")
 	fs.Uint64(StakingDisabledWeightKey, 100, "Weight to provide to each peer when staking is disabled")
 	// Uptime Requirement
 	fs.Float64(UptimeRequirementKey, genesis.LocalParams.UptimeRequirement, "Fraction of time a validator must be online to receive rewards")
@@ -272,6 +280,9 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(StakeMinConsumptionRateKey, genesis.LocalParams.RewardConfig.MinConsumptionRate, "Minimum consumption rate of the remaining tokens to mint in the staking function")
 	fs.Duration(StakeMintingPeriodKey, genesis.LocalParams.RewardConfig.MintingPeriod, "Consumption period of the staking function")
 	fs.Uint64(StakeSupplyCapKey, genesis.LocalParams.RewardConfig.SupplyCap, "Supply cap of the staking function")
+	// Staking Upgrades
+	fs.String(StakingUpgradesFileKey, "", fmt.Sprintf("Specifies a staking upgrades file, which schedules changes of the staking parameters (ignored on public networks). Ignored if %s is specified", StakingUpgradesContentKey))
+	fs.String(StakingUpgradesContentKey, "", "Specifies base64 encoded staking upgrades content")
 	// Subnets
 	fs.String(WhitelistedSubnetsKey, "", "Whitelist of subnets to validate")
 
@@ -12,6 +12,8 @@ const (
 	VersionKey                                         = "version"
 	GenesisConfigFileKey                               = "genesis"
 	GenesisConfigContentKey                            = "genesis-content"
+	UpgradeConfigFileKey                               = "upgrade-config-file"
+	UpgradeConfigContentKey                            = "upgrade-config-file-content"
 	NetworkNameKey                                     = "network-id"
 	TxFeeKey                                           = "tx-fee"
 	CreateAssetTxFeeKey                                = "create-asset-tx-fee"
@@ -28,6 +30,8 @@ const (
 	StakeMinConsumptionRateKey                         = "stake-min-consumption-rate"
 	StakeMintingPeriodKey                              = "stake-minting-period"
 	StakeSupplyCapKey                                  = "stake-supply-cap"
+	StakingUpgradesFileKey                             = "staking-upgrades-file"
+	StakingUpgradesContentKey                          = "staking-upgrades-file-content"
 	AssertionsEnabledKey                               = "assertions-enabled"
 	SignatureVerificationEnabledKey                    = "signature-verification-enabled"
 	DBTypeKey                                          = "db-type"
@@ -67,6 +71,9 @@ const (
 	StakingKeyContentKey                               = "staking-tls-key-file-content"
 	StakingCertPathKey                                 = "staking-tls-cert-file"
 	StakingCertContentKey                              = "staking-tls-cert-file-content"
+	StakingEphemeralSignerEnabledKey                   = "staking-ephemeral-signer-enabled"
+	StakingSignerKeyPathKey                            = "staking-signer-key-file"
+	StakingSignerKeyContentKey                         = "staking-signer-key-file-content"
 	StakingDisabledWeightKey                           = "staking-disabled-weight"
 	NetworkInitialTimeoutKey                           = "network-initial-timeout"
 	NetworkMinimumTimeoutKey                           = "network-minimum-timeout"
@@ -8,9 +8,11 @@ import (
 
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
 	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
@@ -75,5 +77,7 @@ func GetVMAliases() map[ids.ID][]string {
 		secp256k1fx.ID:         {"secp256k1fx"},
 		nftfx.ID:               {"nftfx"},
 		propertyfx.ID:          {"propertyfx"},
+		htlcfx.ID:              {"htlcfx"},
+		policyfx.ID:            {"policyfx"},
 	}
 }
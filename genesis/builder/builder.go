@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package builder builds the genesis of a custom network from the allocations,
+// initial stakers, staking parameters and X-chain assets of the network.
+package builder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kukrer/savannahnode/genesis"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/staking"
+	"github.com/kukrer/savannahnode/utils/constants"
+)
+
+// StakingKey is the staking key and certificate of an initial staker
+type StakingKey struct {
+	NodeID ids.NodeID
+	// Key and Cert are PEM encoded
+	Key  []byte
+	Cert []byte
+}
+
+// Genesis is the genesis of a custom network
+type Genesis struct {
+	// Config is the genesis config to start the nodes of the network with
+	Config genesis.UnparsedConfig
+	// StakingConfig is the staking config to start the nodes of the network
+	// with
+	StakingConfig genesis.StakingConfig
+	// StakingKeys are the keys generated for the initial stakers
+	StakingKeys []StakingKey
+
+	Bytes       []byte
+	AVAXAssetID ids.ID
+	// ChainIDs are the IDs of the primary network chains, by chain alias
+	ChainIDs map[string]ids.ID
+}
+
+// Build builds the genesis of [config], for a network staking with
+// [stakingConfig]. A staking key and certificate are generated for every
+// initial staker without a node ID, and the start time of the network defaults
+// to [now] if it isn't set.
+//
+// The genesis and the staking config are validated as they would be when a
+// node starts with them.
+func Build(config genesis.UnparsedConfig, stakingConfig genesis.StakingConfig, now time.Time) (*Genesis, error) {
+	if err := stakingConfig.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid staking config: %w", err)
+	}
+	if config.StartTime == 0 {
+		config.StartTime = uint64(now.Unix())
+	}
+
+	// The stakers are copied so that [config] isn't modified.
+	config.InitialStakers = append([]genesis.UnparsedStaker(nil), config.InitialStakers...)
+	g := &Genesis{
+		StakingConfig: stakingConfig,
+	}
+	for i, staker := range config.InitialStakers {
+		if staker.NodeID != ids.EmptyNodeID {
+			continue
+		}
+
+		certBytes, keyBytes, err := staking.NewCertAndKeyBytes()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate staking key of initial staker %d: %w", i, err)
+		}
+		cert, err := staking.LoadTLSCertFromBytes(keyBytes, certBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load staking key of initial staker %d: %w", i, err)
+		}
+		nodeID := ids.NodeIDFromCert(cert.Leaf)
+		config.InitialStakers[i].NodeID = nodeID
+		g.StakingKeys = append(g.StakingKeys, StakingKey{
+			NodeID: nodeID,
+			Key:    keyBytes,
+			Cert:   certBytes,
+		})
+	}
+	g.Config = config
+
+	parsedConfig, err := config.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse genesis config: %w", err)
+	}
+	if err := genesis.ValidateConfig(config.NetworkID, &parsedConfig); err != nil {
+		return nil, fmt.Errorf("genesis config validation failed: %w", err)
+	}
+	g.Bytes, g.AVAXAssetID, err = genesis.FromConfig(&parsedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build genesis: %w", err)
+	}
+
+	g.ChainIDs = map[string]ids.ID{
+		"P": constants.PlatformChainID,
+	}
+	for alias, vmID := range map[string]ids.ID{
+		"X": constants.AVMID,
+		"C": constants.EVMID,
+	} {
+		tx, err := genesis.VMGenesis(g.Bytes, vmID)
+		if err != nil {
+			return nil, err
+		}
+		g.ChainIDs[alias] = tx.ID()
+	}
+	return g, nil
+}
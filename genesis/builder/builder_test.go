@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/genesis"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/staking"
+	"github.com/kukrer/savannahnode/utils/units"
+)
+
+const testNetworkID = 12345
+
+func testConfig(require *require.Assertions) genesis.UnparsedConfig {
+	config, err := genesis.LocalConfig.Unparse()
+	require.NoError(err)
+
+	config.NetworkID = testNetworkID
+	config.StartTime = 0
+	config.InitialStakers = []genesis.UnparsedStaker{{
+		RewardAddress: config.InitialStakers[0].RewardAddress,
+		DelegationFee: config.InitialStakers[0].DelegationFee,
+	}}
+	return config
+}
+
+func TestBuild(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig(require)
+	now := time.Now()
+	g, err := Build(config, genesis.LocalParams.StakingConfig, now)
+	require.NoError(err)
+
+	require.Equal(uint64(now.Unix()), g.Config.StartTime)
+	require.Len(g.StakingKeys, 1)
+	stakingKey := g.StakingKeys[0]
+	require.Equal(stakingKey.NodeID, g.Config.InitialStakers[0].NodeID)
+	// [config] isn't modified
+	require.Equal(ids.EmptyNodeID, config.InitialStakers[0].NodeID)
+
+	cert, err := staking.LoadTLSCertFromBytes(stakingKey.Key, stakingKey.Cert)
+	require.NoError(err)
+	require.Equal(stakingKey.NodeID, ids.NodeIDFromCert(cert.Leaf))
+
+	require.Len(g.ChainIDs, 3)
+	for _, alias := range []string{"P", "X", "C"} {
+		require.Contains(g.ChainIDs, alias)
+	}
+
+	// The built config is the config the nodes start with
+	parsedConfig, err := g.Config.Parse()
+	require.NoError(err)
+	genesisBytes, avaxAssetID, err := genesis.FromConfig(&parsedConfig)
+	require.NoError(err)
+	require.Equal(g.Bytes, genesisBytes)
+	require.Equal(g.AVAXAssetID, avaxAssetID)
+
+	// The nodes of the initial stakers keep their node IDs
+	rebuilt, err := Build(g.Config, genesis.LocalParams.StakingConfig, now.Add(time.Hour))
+	require.NoError(err)
+	require.Empty(rebuilt.StakingKeys)
+	require.Equal(g.Bytes, rebuilt.Bytes)
+}
+
+func TestBuildStakingConfig(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig(require)
+	stakingConfig := genesis.LocalParams.StakingConfig
+	stakingConfig.MinStakeDuration = time.Hour
+	stakingConfig.MinDelegationFee = 30000
+	g, err := Build(config, stakingConfig, time.Now())
+	require.NoError(err)
+	require.Equal(stakingConfig, g.StakingConfig)
+
+	stakingConfig.MinValidatorStake = stakingConfig.MaxValidatorStake + 1
+	_, err = Build(config, stakingConfig, time.Now())
+	require.Error(err)
+
+	stakingConfig = genesis.LocalParams.StakingConfig
+	stakingConfig.MaxStakeDuration = stakingConfig.RewardConfig.MintingPeriod + 1
+	_, err = Build(config, stakingConfig, time.Now())
+	require.Error(err)
+}
+
+func TestBuildXChainAssets(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig(require)
+	withoutAssets, err := Build(config, genesis.LocalParams.StakingConfig, time.Now())
+	require.NoError(err)
+
+	holder := config.Allocations[0].AVAXAddr
+	config.InitialStakers = withoutAssets.Config.InitialStakers
+	config.StartTime = withoutAssets.Config.StartTime
+	config.XChainAssets = []genesis.UnparsedAsset{{
+		Name:         "Acacia",
+		Symbol:       "ACA",
+		Denomination: 9,
+		Holders: []genesis.UnparsedAssetHolder{{
+			Address: holder,
+			Amount:  units.KiloAvax,
+		}},
+	}}
+	withAssets, err := Build(config, genesis.LocalParams.StakingConfig, time.Now())
+	require.NoError(err)
+
+	// AVAX isn't the first asset of the X-chain genesis anymore, but keeps its
+	// ID
+	require.Equal(withoutAssets.AVAXAssetID, withAssets.AVAXAssetID)
+	require.NotEqual(withoutAssets.ChainIDs["X"], withAssets.ChainIDs["X"])
+
+	config.XChainAssets[0].Symbol = "FUEL"
+	_, err = Build(config, genesis.LocalParams.StakingConfig, time.Now())
+	require.Error(err)
+
+	config.XChainAssets[0].Symbol = "ACA"
+	config.XChainAssets[0].Holders[0].Amount = 0
+	_, err = Build(config, genesis.LocalParams.StakingConfig, time.Now())
+	require.Error(err)
+}
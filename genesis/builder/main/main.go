@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/kukrer/savannahnode/genesis"
+	"github.com/kukrer/savannahnode/genesis/builder"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/formatting"
+	"github.com/kukrer/savannahnode/utils/perms"
+)
+
+const (
+	configKey    = "config"
+	outputDirKey = "output-dir"
+
+	// The staking parameters are given with the flags the nodes are started
+	// with
+	uptimeRequirementKey       = "uptime-requirement"
+	minValidatorStakeKey       = "min-validator-stake"
+	maxValidatorStakeKey       = "max-validator-stake"
+	minDelegatorStakeKey       = "min-delegator-stake"
+	minDelegatorFeeKey         = "min-delegation-fee"
+	minStakeDurationKey        = "min-stake-duration"
+	maxStakeDurationKey        = "max-stake-duration"
+	stakeMaxConsumptionRateKey = "stake-max-consumption-rate"
+	stakeMinConsumptionRateKey = "stake-min-consumption-rate"
+	stakeMintingPeriodKey      = "stake-minting-period"
+	stakeSupplyCapKey          = "stake-supply-cap"
+
+	genesisFileName     = "genesis.json"
+	stakingFileName     = "staking.json"
+	stakingKeyFileName  = "staker.key"
+	stakingCertFileName = "staker.crt"
+)
+
+// output is printed once the genesis is built
+type output struct {
+	GenesisFile  string                 `json:"genesisFile"`
+	GenesisBytes string                 `json:"genesisBytes"`
+	AVAXAssetID  ids.ID                 `json:"avaxAssetID"`
+	ChainIDs     map[string]ids.ID      `json:"chainIDs"`
+	StakingFile  string                 `json:"stakingFile"`
+	StakingFlags map[string]interface{} `json:"stakingFlags"`
+	StakingKeys  map[ids.NodeID]string  `json:"stakingKeys"`
+}
+
+// The genesis builder reads a genesis config, in which the initial stakers may
+// omit their node IDs, and the staking parameters of the network. It writes the
+// genesis config to start the network with, the staking flags to start its
+// nodes with and the staking keys generated for the initial stakers.
+func main() {
+	fs := pflag.NewFlagSet("genesis-builder", pflag.ContinueOnError)
+	configPath := fs.String(configKey, "", "Path to the genesis config to build. Initial stakers without a node ID are given a new staking key")
+	outputDir := fs.String(outputDirKey, ".", "Directory the genesis config, the staking flags and the staking keys are written into")
+
+	// Staking parameters. Nodes of the public networks ignore the staking flags.
+	stakingConfig := genesis.LocalParams.StakingConfig
+	fs.Float64Var(&stakingConfig.UptimeRequirement, uptimeRequirementKey, stakingConfig.UptimeRequirement, "Fraction of time a validator must be online to receive rewards")
+	fs.Uint64Var(&stakingConfig.MinValidatorStake, minValidatorStakeKey, stakingConfig.MinValidatorStake, "Minimum stake, in nFUEL, required to validate the primary network")
+	fs.Uint64Var(&stakingConfig.MaxValidatorStake, maxValidatorStakeKey, stakingConfig.MaxValidatorStake, "Maximum stake, in nFUEL, that can be placed on a validator on the primary network")
+	fs.Uint64Var(&stakingConfig.MinDelegatorStake, minDelegatorStakeKey, stakingConfig.MinDelegatorStake, "Minimum stake, in nFUEL, that can be delegated on the primary network")
+	fs.Uint32Var(&stakingConfig.MinDelegationFee, minDelegatorFeeKey, stakingConfig.MinDelegationFee, "Minimum delegation fee, in the range [0, 1000000], that can be charged for delegation on the primary network")
+	fs.DurationVar(&stakingConfig.MinStakeDuration, minStakeDurationKey, stakingConfig.MinStakeDuration, "Minimum staking duration")
+	fs.DurationVar(&stakingConfig.MaxStakeDuration, maxStakeDurationKey, stakingConfig.MaxStakeDuration, "Maximum staking duration")
+	fs.Uint64Var(&stakingConfig.RewardConfig.MaxConsumptionRate, stakeMaxConsumptionRateKey, stakingConfig.RewardConfig.MaxConsumptionRate, "Maximum consumption rate of the remaining tokens to mint in the staking function")
+	fs.Uint64Var(&stakingConfig.RewardConfig.MinConsumptionRate, stakeMinConsumptionRateKey, stakingConfig.RewardConfig.MinConsumptionRate, "Minimum consumption rate of the remaining tokens to mint in the staking function")
+	fs.DurationVar(&stakingConfig.RewardConfig.MintingPeriod, stakeMintingPeriodKey, stakingConfig.RewardConfig.MintingPeriod, "Consumption period of the staking function")
+	fs.Uint64Var(&stakingConfig.RewardConfig.SupplyCap, stakeSupplyCapKey, stakingConfig.RewardConfig.SupplyCap, "Supply cap of the staking function")
+
+	err := fs.Parse(os.Args[1:])
+	if errors.Is(err, pflag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		os.Exit(1)
+	}
+	if *configPath == "" {
+		fmt.Printf("--%s must be specified\n", configKey)
+		os.Exit(1)
+	}
+
+	if err := run(*configPath, stakingConfig, *outputDir); err != nil {
+		fmt.Printf("couldn't build genesis: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string, stakingConfig genesis.StakingConfig, outputDir string) error {
+	configBytes, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	config := genesis.UnparsedConfig{}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return fmt.Errorf("couldn't parse genesis config: %w", err)
+	}
+
+	g, err := builder.Build(config, stakingConfig, time.Now())
+	if err != nil {
+		return err
+	}
+
+	out := output{
+		GenesisFile:  filepath.Join(outputDir, genesisFileName),
+		AVAXAssetID:  g.AVAXAssetID,
+		ChainIDs:     g.ChainIDs,
+		StakingFile:  filepath.Join(outputDir, stakingFileName),
+		StakingFlags: stakingFlags(g.StakingConfig),
+		StakingKeys:  make(map[ids.NodeID]string, len(g.StakingKeys)),
+	}
+	out.GenesisBytes, err = formatting.Encode(formatting.Hex, g.Bytes)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+	genesisBytes, err := json.MarshalIndent(g.Config, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out.GenesisFile, genesisBytes, perms.ReadWrite); err != nil {
+		return err
+	}
+	// The staking flags are written as a config file of the nodes
+	stakingBytes, err := json.MarshalIndent(out.StakingFlags, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out.StakingFile, stakingBytes, perms.ReadWrite); err != nil {
+		return err
+	}
+	for _, key := range g.StakingKeys {
+		keyDir := filepath.Join(outputDir, key.NodeID.String())
+		if err := os.MkdirAll(keyDir, perms.ReadWriteExecute); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(keyDir, stakingKeyFileName), key.Key, perms.ReadOnly); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(keyDir, stakingCertFileName), key.Cert, perms.ReadOnly); err != nil {
+			return err
+		}
+		out.StakingKeys[key.NodeID] = keyDir
+	}
+
+	outBytes, err := json.MarshalIndent(out, "", "\t")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(outBytes))
+	return nil
+}
+
+// stakingFlags returns the values of the staking flags to start the nodes with
+// for them to stake with [config]
+func stakingFlags(config genesis.StakingConfig) map[string]interface{} {
+	return map[string]interface{}{
+		uptimeRequirementKey:       config.UptimeRequirement,
+		minValidatorStakeKey:       config.MinValidatorStake,
+		maxValidatorStakeKey:       config.MaxValidatorStake,
+		minDelegatorStakeKey:       config.MinDelegatorStake,
+		minDelegatorFeeKey:         config.MinDelegationFee,
+		minStakeDurationKey:        config.MinStakeDuration.String(),
+		maxStakeDurationKey:        config.MaxStakeDuration.String(),
+		stakeMaxConsumptionRateKey: config.RewardConfig.MaxConsumptionRate,
+		stakeMinConsumptionRateKey: config.RewardConfig.MinConsumptionRate,
+		stakeMintingPeriodKey:      config.RewardConfig.MintingPeriod.String(),
+		stakeSupplyCapKey:          config.RewardConfig.SupplyCap,
+	}
+}
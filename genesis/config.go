@@ -65,6 +65,43 @@ func (s Staker) Unparse(networkID uint32) (UnparsedStaker, error) {
 	}, err
 }
 
+// Asset is an X-chain asset, other than AVAX, created at genesis
+type Asset struct {
+	Name         string        `json:"name"`
+	Symbol       string        `json:"symbol"`
+	Denomination byte          `json:"denomination"`
+	Holders      []AssetHolder `json:"holders"`
+}
+
+type AssetHolder struct {
+	Address ids.ShortID `json:"address"`
+	Amount  uint64      `json:"amount"`
+}
+
+func (a Asset) Unparse(networkID uint32) (UnparsedAsset, error) {
+	ua := UnparsedAsset{
+		Name:         a.Name,
+		Symbol:       a.Symbol,
+		Denomination: a.Denomination,
+		Holders:      make([]UnparsedAssetHolder, len(a.Holders)),
+	}
+	for i, holder := range a.Holders {
+		addr, err := address.Format(
+			"X",
+			constants.GetHRP(networkID),
+			holder.Address.Bytes(),
+		)
+		if err != nil {
+			return ua, err
+		}
+		ua.Holders[i] = UnparsedAssetHolder{
+			Address: addr,
+			Amount:  holder.Amount,
+		}
+	}
+	return ua, nil
+}
+
 // Config contains the genesis addresses used to construct a genesis
 type Config struct {
 	NetworkID uint32 `json:"networkID"`
@@ -79,6 +116,12 @@ type Config struct {
 
 	CChainGenesis string `json:"cChainGenesis"`
 
+	// XChainAssets are created at genesis in addition to AVAX
+	XChainAssets []Asset `json:"xChainAssets"`
+	// XChainFxIDs are the optional fxs enabled on the X-chain in addition to
+	// the default ones
+	XChainFxIDs []ids.ID `json:"xChainFxIDs"`
+
 	Message string `json:"message"`
 }
 
@@ -92,6 +135,8 @@ func (c Config) Unparse() (UnparsedConfig, error) {
 		InitialStakedFunds:         make([]string, len(c.InitialStakedFunds)),
 		InitialStakers:             make([]UnparsedStaker, len(c.InitialStakers)),
 		CChainGenesis:              c.CChainGenesis,
+		XChainAssets:               make([]UnparsedAsset, len(c.XChainAssets)),
+		XChainFxIDs:                c.XChainFxIDs,
 		Message:                    c.Message,
 	}
 	for i, a := range c.Allocations {
@@ -119,6 +164,13 @@ func (c Config) Unparse() (UnparsedConfig, error) {
 		}
 		uc.InitialStakers[i] = uis
 	}
+	for i, a := range c.XChainAssets {
+		ua, err := a.Unparse(c.NetworkID)
+		if err != nil {
+			return uc, err
+		}
+		uc.XChainAssets[i] = ua
+	}
 
 	return uc, nil
 }
@@ -17,9 +17,11 @@ import (
 	"github.com/kukrer/savannahnode/utils/json"
 	"github.com/kukrer/savannahnode/vms/avm"
 	"github.com/kukrer/savannahnode/vms/avm/fxs"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
 	"github.com/kukrer/savannahnode/vms/platformvm/api"
 	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 
@@ -30,6 +32,7 @@ import (
 const (
 	defaultEncoding    = formatting.Hex
 	configChainIDAlias = "X"
+	avaxAssetAlias     = "FUEL"
 )
 
 var (
@@ -39,6 +42,18 @@ var (
 	errNoStakers              = errors.New("initial stakers must be > 0")
 	errNoCChainGenesis        = errors.New("C-Chain genesis cannot be empty")
 	errNoTxs                  = errors.New("genesis creates no transactions")
+	errNoAssetHolders         = errors.New("asset must have holders")
+	errNoAssetAmount          = errors.New("asset holder amount must be > 0")
+	errDuplicateAssetSymbol   = errors.New("duplicate asset symbol")
+	errUnknownXChainFx        = errors.New("unknown optional X-chain fx")
+	errDuplicateXChainFx      = errors.New("duplicate optional X-chain fx")
+
+	// optionalXChainFxIDs are the fxs a network can enable on the X-chain in
+	// addition to the secp256k1fx, the nftfx and the propertyfx
+	optionalXChainFxIDs = []ids.ID{
+		htlcfx.ID,
+		policyfx.ID,
+	}
 )
 
 // validateInitialStakedFunds ensures all staked
@@ -104,9 +119,52 @@ func validateInitialStakedFunds(config *Config) error {
 	return nil
 }
 
-// validateConfig returns an error if the provided
+// validateXChainAssets ensures that every asset created at genesis, other than
+// AVAX, has a unique symbol and is held in positive amounts.
+func validateXChainAssets(config *Config) error {
+	symbols := map[string]struct{}{
+		avaxAssetAlias: {},
+	}
+	for _, asset := range config.XChainAssets {
+		if _, exists := symbols[asset.Symbol]; exists {
+			return fmt.Errorf("%w: %q", errDuplicateAssetSymbol, asset.Symbol)
+		}
+		symbols[asset.Symbol] = struct{}{}
+
+		if len(asset.Holders) == 0 {
+			return fmt.Errorf("%w: %q", errNoAssetHolders, asset.Symbol)
+		}
+		for _, holder := range asset.Holders {
+			if holder.Amount == 0 {
+				return fmt.Errorf("%w: %q", errNoAssetAmount, asset.Symbol)
+			}
+		}
+	}
+	return nil
+}
+
+// validateXChainFxIDs ensures that every fx enabled on the X-chain at genesis
+// is an optional fx that is enabled once.
+func validateXChainFxIDs(config *Config) error {
+	optionalFxIDs := ids.NewSet(len(optionalXChainFxIDs))
+	optionalFxIDs.Add(optionalXChainFxIDs...)
+
+	fxIDs := ids.NewSet(len(config.XChainFxIDs))
+	for _, fxID := range config.XChainFxIDs {
+		if !optionalFxIDs.Contains(fxID) {
+			return fmt.Errorf("%w: %s", errUnknownXChainFx, fxID)
+		}
+		if fxIDs.Contains(fxID) {
+			return fmt.Errorf("%w: %s", errDuplicateXChainFx, fxID)
+		}
+		fxIDs.Add(fxID)
+	}
+	return nil
+}
+
+// ValidateConfig returns an error if the provided
 // *Config is not considered valid.
-func validateConfig(networkID uint32, config *Config) error {
+func ValidateConfig(networkID uint32, config *Config) error {
 	if networkID != config.NetworkID {
 		return fmt.Errorf(
 			"networkID %d specified but genesis config contains networkID %d",
@@ -161,6 +219,14 @@ func validateConfig(networkID uint32, config *Config) error {
 		return errNoCChainGenesis
 	}
 
+	if err := validateXChainAssets(config); err != nil {
+		return fmt.Errorf("X-chain assets validation failed: %w", err)
+	}
+
+	if err := validateXChainFxIDs(config); err != nil {
+		return fmt.Errorf("X-chain fxs validation failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -198,7 +264,7 @@ func FromFile(networkID uint32, filepath string) ([]byte, ids.ID, error) {
 		return nil, ids.ID{}, fmt.Errorf("unable to load provided genesis config at %s: %w", filepath, err)
 	}
 
-	if err := validateConfig(networkID, config); err != nil {
+	if err := ValidateConfig(networkID, config); err != nil {
 		return nil, ids.ID{}, fmt.Errorf("genesis config validation failed: %w", err)
 	}
 
@@ -239,7 +305,7 @@ func FromFlag(networkID uint32, genesisContent string) ([]byte, ids.ID, error) {
 		return nil, ids.ID{}, fmt.Errorf("unable to load genesis content from flag: %w", err)
 	}
 
-	if err := validateConfig(networkID, customConfig); err != nil {
+	if err := ValidateConfig(networkID, customConfig); err != nil {
 		return nil, ids.ID{}, fmt.Errorf("genesis config validation failed: %w", err)
 	}
 
@@ -296,9 +362,34 @@ func FromConfig(config *Config) ([]byte, ids.ID, error) {
 			return nil, ids.Empty, fmt.Errorf("couldn't parse memo bytes to string: %w", err)
 		}
 		avmArgs.GenesisData = map[string]avm.AssetDefinition{
-			"FUEL": avax, // The AVM starts out with one asset: AVAX
+			avaxAssetAlias: avax, // The AVM starts out with AVAX
 		}
 	}
+	for _, asset := range config.XChainAssets {
+		definition := avm.AssetDefinition{
+			Name:         asset.Name,
+			Symbol:       asset.Symbol,
+			Denomination: json.Uint8(asset.Denomination),
+			InitialState: map[string][]interface{}{},
+		}
+		for _, holder := range asset.Holders {
+			addr, err := address.FormatBech32(hrp, holder.Address.Bytes())
+			if err != nil {
+				return nil, ids.ID{}, err
+			}
+			definition.InitialState["fixedCap"] = append(definition.InitialState["fixedCap"], avm.Holder{
+				Amount:  json.Uint64(holder.Amount),
+				Address: addr,
+			})
+		}
+
+		var err error
+		definition.Memo, err = formatting.Encode(defaultEncoding, nil)
+		if err != nil {
+			return nil, ids.Empty, fmt.Errorf("couldn't encode memo bytes: %w", err)
+		}
+		avmArgs.GenesisData[asset.Symbol] = definition
+	}
 	avmReply := avm.BuildGenesisReply{}
 
 	avmSS := avm.CreateStaticService()
@@ -426,11 +517,14 @@ func FromConfig(config *Config) ([]byte, ids.ID, error) {
 			GenesisData: avmReply.Bytes,
 			SubnetID:    constants.PrimaryNetworkID,
 			VMID:        constants.AVMID,
-			FxIDs: []ids.ID{
-				secp256k1fx.ID,
-				nftfx.ID,
-				propertyfx.ID,
-			},
+			FxIDs: append(
+				[]ids.ID{
+					secp256k1fx.ID,
+					nftfx.ID,
+					propertyfx.ID,
+				},
+				config.XChainFxIDs...,
+			),
 			Name: "X-Chain",
 		},
 		{
@@ -549,7 +643,15 @@ func AVAXAssetID(avmGenesisBytes []byte) (ids.ID, error) {
 	if len(genesis.Txs) == 0 {
 		return ids.Empty, errNoTxs
 	}
+	// The genesis txs are sorted by alias, so AVAX is only the first asset if
+	// no other asset is created at genesis.
 	genesisTx := genesis.Txs[0]
+	for _, tx := range genesis.Txs {
+		if tx.Alias == avaxAssetAlias {
+			genesisTx = tx
+			break
+		}
+	}
 
 	tx := xchaintxs.Tx{Unsigned: &genesisTx.CreateAssetTx}
 	if err := parser.InitializeGenesisTx(&tx); err != nil {
@@ -9,6 +9,7 @@ import (
 	_ "embed"
 
 	"github.com/kukrer/savannahnode/utils/units"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 )
 
@@ -23,6 +24,14 @@ var (
 			CreateAssetTxFee:      10 * units.MilliAvax,
 			CreateSubnetTxFee:     100 * units.MilliAvax,
 			CreateBlockchainTxFee: 100 * units.MilliAvax,
+			DynamicFeeConfig: fees.Config{
+				BytesWeight:              1,
+				InputsWeight:             1_000,
+				SignaturesWeight:         1_000,
+				MinBaseFee:               1,
+				TargetGasPerBlock:        250_000,
+				BaseFeeChangeDenominator: 8,
+			},
 		},
 		StakingConfig: StakingConfig{
 			UptimeRequirement: .8, // 80%
@@ -18,7 +18,10 @@ import (
 	"github.com/kukrer/savannahnode/utils/constants"
 	"github.com/kukrer/savannahnode/utils/hashing"
 	"github.com/kukrer/savannahnode/utils/perms"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
+	"github.com/kukrer/savannahnode/vms/policyfx"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
 
 var (
@@ -141,6 +144,40 @@ func TestValidateConfig(t *testing.T) {
 			}(),
 			err: "C-Chain genesis cannot be empty",
 		},
+		"optional X-chain fx": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.XChainFxIDs = []ids.ID{htlcfx.ID}
+				return &thisConfig
+			}(),
+		},
+		"multiple optional X-chain fxs": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.XChainFxIDs = []ids.ID{htlcfx.ID, policyfx.ID}
+				return &thisConfig
+			}(),
+		},
+		"unknown X-chain fx": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.XChainFxIDs = []ids.ID{secp256k1fx.ID}
+				return &thisConfig
+			}(),
+			err: "unknown optional X-chain fx",
+		},
+		"duplicate X-chain fx": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.XChainFxIDs = []ids.ID{htlcfx.ID, htlcfx.ID}
+				return &thisConfig
+			}(),
+			err: "duplicate optional X-chain fx",
+		},
 		"empty message": {
 			networkID: 12345,
 			config: func() *Config {
@@ -155,7 +192,7 @@ func TestValidateConfig(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			require := require.New(t)
 
-			err := validateConfig(test.networkID, test.config)
+			err := ValidateConfig(test.networkID, test.config)
 			if len(test.err) > 0 {
 				require.Error(err)
 				require.Contains(err.Error(), test.err)
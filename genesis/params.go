@@ -4,9 +4,13 @@
 package genesis
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 )
 
@@ -33,6 +37,50 @@ type StakingConfig struct {
 	RewardConfig reward.Config `json:"rewardConfig"`
 }
 
+var (
+	errStakingUpgradesOutOfOrder   = errors.New("staking upgrades must be sorted by strictly increasing time")
+	errInvalidUptimeRequirement    = errors.New("uptime requirement must be in the range [0, 1]")
+	errMinValidatorStakeAboveMax   = errors.New("minimum validator stake can't be greater than maximum validator stake")
+	errInvalidDelegationFee        = errors.New("delegation fee must be in the range [0, 1,000,000]")
+	errInvalidMinStakeDuration     = errors.New("min stake duration must be > 0")
+	errMinStakeDurationAboveMax    = errors.New("max stake duration can't be less than min stake duration")
+	errStakeMaxConsumptionTooLarge = fmt.Errorf("max stake consumption must be less than or equal to %d", reward.PercentDenominator)
+	errStakeMaxConsumptionBelowMin = errors.New("stake max consumption can't be less than min stake consumption")
+	errStakeMintingPeriodBelowMin  = errors.New("stake minting period can't be less than max stake duration")
+)
+
+// Verify returns an error if the staking parameters of [c] are inconsistent
+func (c *StakingConfig) Verify() error {
+	switch {
+	case c.UptimeRequirement < 0 || c.UptimeRequirement > 1:
+		return errInvalidUptimeRequirement
+	case c.MinValidatorStake > c.MaxValidatorStake:
+		return errMinValidatorStakeAboveMax
+	case c.MinDelegationFee > 1_000_000:
+		return errInvalidDelegationFee
+	case c.MinStakeDuration <= 0:
+		return errInvalidMinStakeDuration
+	case c.MaxStakeDuration < c.MinStakeDuration:
+		return errMinStakeDurationAboveMax
+	case c.RewardConfig.MaxConsumptionRate > reward.PercentDenominator:
+		return errStakeMaxConsumptionTooLarge
+	case c.RewardConfig.MaxConsumptionRate < c.RewardConfig.MinConsumptionRate:
+		return errStakeMaxConsumptionBelowMin
+	case c.RewardConfig.MintingPeriod < c.MaxStakeDuration:
+		return errStakeMintingPeriodBelowMin
+	default:
+		return nil
+	}
+}
+
+// StakingUpgrade replaces the staking config of the primary network with
+// [StakingConfig] from [Time] on. Stakers remain subject to the staking config
+// in effect at their start time for their entire staking period.
+type StakingUpgrade struct {
+	Time time.Time `json:"time"`
+	StakingConfig
+}
+
 type TxFeeConfig struct {
 	// Transaction fee
 	TxFee uint64 `json:"txFee"`
@@ -42,11 +90,15 @@ type TxFeeConfig struct {
 	CreateSubnetTxFee uint64 `json:"createSubnetTxFee"`
 	// Transaction fee for create blockchain transactions
 	CreateBlockchainTxFee uint64 `json:"createBlockchainTxFee"`
+	// DynamicFeeConfig is the config for the complexity-based P-chain fees.
+	DynamicFeeConfig fees.Config `json:"dynamicFeeConfig"`
 }
 
 type Params struct {
 	StakingConfig
 	TxFeeConfig
+	// StakingUpgrades are the scheduled changes of [StakingConfig]
+	StakingUpgrades []StakingUpgrade
 }
 
 func GetTxFeeConfig(networkID uint32) TxFeeConfig {
@@ -82,3 +134,46 @@ func GetStakingConfig(networkID uint32) StakingConfig {
 		return LocalParams.StakingConfig
 	}
 }
+
+func GetStakingUpgrades(networkID uint32) []StakingUpgrade {
+	switch networkID {
+	case constants.MainnetID:
+		return MainnetParams.StakingUpgrades
+	case constants.FujiID:
+		return FujiParams.StakingUpgrades
+	case constants.SavannahID:
+		return SavannahParams.StakingUpgrades
+	case constants.MarulaID:
+		return MarulaParams.StakingUpgrades
+	case constants.LocalID:
+		return LocalParams.StakingUpgrades
+	default:
+		return LocalParams.StakingUpgrades
+	}
+}
+
+// ParseStakingUpgrades parses the JSON list of staking upgrades
+// [upgradesBytes]. Each upgrade only needs to specify the parameters it
+// changes; the other parameters keep their values from the previous upgrade,
+// or from [config] for the first upgrade.
+func ParseStakingUpgrades(config StakingConfig, upgradesBytes []byte) ([]StakingUpgrade, error) {
+	var rawUpgrades []json.RawMessage
+	if err := json.Unmarshal(upgradesBytes, &rawUpgrades); err != nil {
+		return nil, fmt.Errorf("failed to parse staking upgrades: %w", err)
+	}
+
+	upgrades := make([]StakingUpgrade, len(rawUpgrades))
+	for i, rawUpgrade := range rawUpgrades {
+		upgrade := StakingUpgrade{StakingConfig: config}
+		if err := json.Unmarshal(rawUpgrade, &upgrade); err != nil {
+			return nil, fmt.Errorf("failed to parse staking upgrade %d: %w", i, err)
+		}
+		if i > 0 && !upgrade.Time.After(upgrades[i-1].Time) {
+			return nil, fmt.Errorf("%w: upgrade %d (%s) isn't after upgrade %d (%s)",
+				errStakingUpgradesOutOfOrder, i, upgrade.Time, i-1, upgrades[i-1].Time)
+		}
+		upgrades[i] = upgrade
+		config = upgrade.StakingConfig
+	}
+	return upgrades, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+)
+
+func TestParseStakingUpgrades(t *testing.T) {
+	require := require.New(t)
+
+	config := LocalParams.StakingConfig
+	upgrades, err := ParseStakingUpgrades(config, []byte(`[
+		{"time":"2023-01-01T00:00:00Z","rewardConfig":{"maxConsumptionRate":110000}},
+		{"time":"2023-06-01T00:00:00Z","minStakeDuration":1209600000000000}
+	]`))
+	require.NoError(err)
+	require.Len(upgrades, 2)
+
+	// Parameters that aren't specified keep their previous values
+	first := upgrades[0]
+	require.Equal(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), first.Time)
+	require.Equal(uint64(.11*reward.PercentDenominator), first.RewardConfig.MaxConsumptionRate)
+	require.Equal(config.RewardConfig.MinConsumptionRate, first.RewardConfig.MinConsumptionRate)
+	require.Equal(config.MinStakeDuration, first.MinStakeDuration)
+
+	second := upgrades[1]
+	require.Equal(first.RewardConfig, second.RewardConfig)
+	require.Equal(2*7*24*time.Hour, second.MinStakeDuration)
+	require.Equal(config.MaxStakeDuration, second.MaxStakeDuration)
+
+	_, err = ParseStakingUpgrades(config, []byte(`[
+		{"time":"2023-06-01T00:00:00Z"},
+		{"time":"2023-01-01T00:00:00Z"}
+	]`))
+	require.ErrorIs(err, errStakingUpgradesOutOfOrder)
+}
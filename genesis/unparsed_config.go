@@ -77,6 +77,42 @@ func (us UnparsedStaker) Parse() (Staker, error) {
 	return s, nil
 }
 
+type UnparsedAsset struct {
+	Name         string                `json:"name"`
+	Symbol       string                `json:"symbol"`
+	Denomination byte                  `json:"denomination"`
+	Holders      []UnparsedAssetHolder `json:"holders"`
+}
+
+type UnparsedAssetHolder struct {
+	Address string `json:"address"`
+	Amount  uint64 `json:"amount"`
+}
+
+func (ua UnparsedAsset) Parse() (Asset, error) {
+	a := Asset{
+		Name:         ua.Name,
+		Symbol:       ua.Symbol,
+		Denomination: ua.Denomination,
+		Holders:      make([]AssetHolder, len(ua.Holders)),
+	}
+	for i, holder := range ua.Holders {
+		_, _, addrBytes, err := address.Parse(holder.Address)
+		if err != nil {
+			return a, err
+		}
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return a, err
+		}
+		a.Holders[i] = AssetHolder{
+			Address: addr,
+			Amount:  holder.Amount,
+		}
+	}
+	return a, nil
+}
+
 // UnparsedConfig contains the genesis addresses used to construct a genesis
 type UnparsedConfig struct {
 	NetworkID uint32 `json:"networkID"`
@@ -91,6 +127,9 @@ type UnparsedConfig struct {
 
 	CChainGenesis string `json:"cChainGenesis"`
 
+	XChainAssets []UnparsedAsset `json:"xChainAssets"`
+	XChainFxIDs  []ids.ID        `json:"xChainFxIDs"`
+
 	Message string `json:"message"`
 }
 
@@ -104,6 +143,8 @@ func (uc UnparsedConfig) Parse() (Config, error) {
 		InitialStakedFunds:         make([]ids.ShortID, len(uc.InitialStakedFunds)),
 		InitialStakers:             make([]Staker, len(uc.InitialStakers)),
 		CChainGenesis:              uc.CChainGenesis,
+		XChainAssets:               make([]Asset, len(uc.XChainAssets)),
+		XChainFxIDs:                uc.XChainFxIDs,
 		Message:                    uc.Message,
 	}
 	for i, ua := range uc.Allocations {
@@ -131,5 +172,12 @@ func (uc UnparsedConfig) Parse() (Config, error) {
 		}
 		c.InitialStakers[i] = is
 	}
+	for i, ua := range uc.XChainAssets {
+		a, err := ua.Parse()
+		if err != nil {
+			return c, err
+		}
+		c.XChainAssets[i] = a
+	}
 	return c, nil
 }
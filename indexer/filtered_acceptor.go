@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+)
+
+var _ snow.Acceptor = &filteredAcceptor{}
+
+// filteredAcceptor only forwards the accepted containers of the chains for
+// which [shouldAccept] returns true.
+type filteredAcceptor struct {
+	snow.Acceptor
+	shouldAccept func(*snow.ConsensusContext) bool
+}
+
+func (a *filteredAcceptor) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	if !a.shouldAccept(ctx) {
+		return nil
+	}
+	return a.Acceptor.Accept(ctx, containerID, container)
+}
+
+func isLinearized(ctx *snow.ConsensusContext) bool {
+	return ctx.IsLinearized()
+}
+
+func isNotLinearized(ctx *snow.ConsensusContext) bool {
+	return !ctx.IsLinearized()
+}
@@ -263,7 +263,7 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 
 	switch engine.(type) {
 	case snowman.Engine:
-		index, err := i.registerChainHelper(chainID, blockPrefix, name, "block", i.consensusAcceptorGroup)
+		index, err := i.registerChainHelper(chainID, blockPrefix, name, "block", i.consensusAcceptorGroup, nil)
 		if err != nil {
 			i.log.Fatal("failed to create block index",
 				zap.String("chainName", name),
@@ -278,7 +278,9 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		}
 		i.blockIndices[chainID] = index
 	case avalanche.Engine:
-		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusAcceptorGroup)
+		// Once the chain is linearized, the accepted containers are blocks
+		// rather than vertices.
+		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusAcceptorGroup, isNotLinearized)
 		if err != nil {
 			i.log.Fatal("couldn't create vertex index",
 				zap.String("chainName", name),
@@ -293,7 +295,7 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		}
 		i.vtxIndices[chainID] = vtxIndex
 
-		txIndex, err := i.registerChainHelper(chainID, txPrefix, name, "tx", i.decisionAcceptorGroup)
+		txIndex, err := i.registerChainHelper(chainID, txPrefix, name, "tx", i.decisionAcceptorGroup, nil)
 		if err != nil {
 			i.log.Fatal("couldn't create tx index for",
 				zap.String("chainName", name),
@@ -307,6 +309,21 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 			return
 		}
 		i.txIndices[chainID] = txIndex
+
+		blockIndex, err := i.registerChainHelper(chainID, blockPrefix, name, "block", i.consensusAcceptorGroup, isLinearized)
+		if err != nil {
+			i.log.Fatal("couldn't create block index",
+				zap.String("chainName", name),
+				zap.Error(err),
+			)
+			if err := i.close(); err != nil {
+				i.log.Error("failed to close indexer",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+		i.blockIndices[chainID] = blockIndex
 	default:
 		engineType := fmt.Sprintf("%T", engine)
 		i.log.Error("got unexpected engine type",
@@ -326,6 +343,7 @@ func (i *indexer) registerChainHelper(
 	prefixEnd byte,
 	name, endpoint string,
 	acceptorGroup snow.AcceptorGroup,
+	shouldAccept func(*snow.ConsensusContext) bool,
 ) (Index, error) {
 	prefix := make([]byte, hashing.HashLen+wrappers.ByteLen)
 	copy(prefix, chainID[:])
@@ -338,7 +356,14 @@ func (i *indexer) registerChainHelper(
 	}
 
 	// Register index to learn about new accepted vertices
-	if err := acceptorGroup.RegisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID), index, true); err != nil {
+	var acceptor snow.Acceptor = index
+	if shouldAccept != nil {
+		acceptor = &filteredAcceptor{
+			Acceptor:     index,
+			shouldAccept: shouldAccept,
+		}
+	}
+	if err := acceptorGroup.RegisterAcceptor(chainID, acceptorName(chainID, endpoint), acceptor, true); err != nil {
 		_ = index.Close()
 		return nil, err
 	}
@@ -381,19 +406,19 @@ func (i *indexer) close() error {
 	for chainID, txIndex := range i.txIndices {
 		errs.Add(
 			txIndex.Close(),
-			i.decisionAcceptorGroup.DeregisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID)),
+			i.decisionAcceptorGroup.DeregisterAcceptor(chainID, acceptorName(chainID, "tx")),
 		)
 	}
 	for chainID, vtxIndex := range i.vtxIndices {
 		errs.Add(
 			vtxIndex.Close(),
-			i.consensusAcceptorGroup.DeregisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID)),
+			i.consensusAcceptorGroup.DeregisterAcceptor(chainID, acceptorName(chainID, "vtx")),
 		)
 	}
 	for chainID, blockIndex := range i.blockIndices {
 		errs.Add(
 			blockIndex.Close(),
-			i.consensusAcceptorGroup.DeregisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID)),
+			i.consensusAcceptorGroup.DeregisterAcceptor(chainID, acceptorName(chainID, "block")),
 		)
 	}
 	errs.Add(i.db.Close())
@@ -402,6 +427,12 @@ func (i *indexer) close() error {
 	return errs.Err
 }
 
+// acceptorName returns the name of the acceptor of the [endpoint] index of
+// [chainID]
+func acceptorName(chainID ids.ID, endpoint string) string {
+	return fmt.Sprintf("%s%s-%s", indexNamePrefix, chainID, endpoint)
+}
+
 func (i *indexer) markIncomplete(chainID ids.ID) error {
 	key := make([]byte, hashing.HashLen+wrappers.ByteLen)
 	copy(key, chainID[:])
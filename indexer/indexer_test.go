@@ -274,11 +274,11 @@ func TestIndexer(t *testing.T) {
 	idxr.RegisterChain("chain2", dagEngine)
 	require.NoError(err)
 	server = config.APIServer.(*apiServerMock)
-	require.EqualValues(3, server.timesCalled) // block index, vtx index, tx index
+	require.EqualValues(4, server.timesCalled) // block index, vtx index, tx index, block index
 	require.Contains(server.bases, "index/chain2")
 	require.Contains(server.endpoints, "/vtx")
 	require.Contains(server.endpoints, "/tx")
-	require.Len(idxr.blockIndices, 1)
+	require.Len(idxr.blockIndices, 2)
 	require.Len(idxr.txIndices, 1)
 	require.Len(idxr.vtxIndices, 1)
 
@@ -392,6 +392,23 @@ func TestIndexer(t *testing.T) {
 	require.NoError(err)
 	require.EqualValues(blkID, lastAcceptedBlk.ID)
 
+	// The vertex shouldn't have been indexed as a block of the DAG chain
+	dagBlkIdx := idxr.blockIndices[chain2Ctx.ChainID]
+	require.NotNil(dagBlkIdx)
+	_, err = dagBlkIdx.GetLastAccepted()
+	require.Error(err)
+
+	// Once the DAG chain is linearized, accepted containers are blocks
+	chain2Ctx.SetLinearized()
+	dagBlkID, dagBlkBytes := ids.GenerateTestID(), utils.RandomBytes(32)
+	require.NoError(config.ConsensusAcceptorGroup.Accept(chain2Ctx, dagBlkID, dagBlkBytes))
+	lastAcceptedBlk, err = dagBlkIdx.GetLastAccepted()
+	require.NoError(err)
+	require.EqualValues(dagBlkID, lastAcceptedBlk.ID)
+	lastAcceptedVtx, err = vtxIdx.GetLastAccepted()
+	require.NoError(err)
+	require.EqualValues(vtxID, lastAcceptedVtx.ID)
+
 	// Close the indexer again
 	require.NoError(config.DB.(*versiondb.Database).Commit())
 	require.NoError(idxr.Close())
@@ -415,6 +432,9 @@ func TestIndexer(t *testing.T) {
 	lastAcceptedBlk, err = idxr.blockIndices[chain1Ctx.ChainID].GetLastAccepted()
 	require.NoError(err)
 	require.EqualValues(blkID, lastAcceptedBlk.ID)
+	lastAcceptedBlk, err = idxr.blockIndices[chain2Ctx.ChainID].GetLastAccepted()
+	require.NoError(err)
+	require.EqualValues(dagBlkID, lastAcceptedBlk.ID)
 }
 
 // Make sure the indexer doesn't allow incomplete indices unless explicitly allowed
@@ -392,3 +392,44 @@ func TestBuildAppGossipMsg(t *testing.T) {
 		require.Equal(t, chainID[:], parsedMsg.Get(ChainID))
 	}
 }
+
+func TestBuildSignatureRequestMsg(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	msgID := ids.GenerateTestID()
+	deadline := uint64(time.Now().Unix())
+
+	builder := NewOutboundBuilder(TestCodec, false)
+	msg, err := builder.SignatureRequest(chainID, 1, time.Duration(deadline), msgID)
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+	require.Equal(t, SignatureRequest, msg.Op())
+
+	parsedMsg, err := TestCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	require.NoError(t, err)
+	require.NotNil(t, parsedMsg)
+	require.Equal(t, SignatureRequest, parsedMsg.Op())
+	require.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	require.EqualValues(t, 1, parsedMsg.Get(RequestID))
+	require.Equal(t, msgID[:], parsedMsg.Get(ContainerID))
+}
+
+func TestBuildSignatureResponseMsg(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	signature := make([]byte, 96)
+	signature[0] = 1
+	signature[len(signature)-1] = 1
+
+	builder := NewOutboundBuilder(TestCodec, false)
+	msg, err := builder.SignatureResponse(chainID, 1, signature)
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+	require.Equal(t, SignatureResponse, msg.Op())
+
+	parsedMsg, err := TestCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	require.NoError(t, err)
+	require.NotNil(t, parsedMsg)
+	require.Equal(t, SignatureResponse, parsedMsg.Op())
+	require.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	require.EqualValues(t, 1, parsedMsg.Get(RequestID))
+	require.Equal(t, signature, parsedMsg.Get(SigBytes))
+}
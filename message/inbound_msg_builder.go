@@ -127,6 +127,21 @@ type InboundMsgBuilder interface {
 		nodeID ids.NodeID,
 	) InboundMessage
 
+	InboundSignatureRequest(
+		chainID ids.ID,
+		requestID uint32,
+		deadline time.Duration,
+		messageID ids.ID,
+		nodeID ids.NodeID,
+	) InboundMessage
+
+	InboundSignatureResponse(
+		chainID ids.ID,
+		requestID uint32,
+		signature []byte,
+		nodeID ids.NodeID,
+	) InboundMessage
+
 	InboundGet(
 		chainID ids.ID,
 		requestID uint32,
@@ -442,6 +457,44 @@ func (b *inMsgBuilder) InboundAppResponse(
 	}
 }
 
+func (b *inMsgBuilder) InboundSignatureRequest(
+	chainID ids.ID,
+	requestID uint32,
+	deadline time.Duration,
+	messageID ids.ID,
+	nodeID ids.NodeID,
+) InboundMessage {
+	received := b.clock.Time()
+	return &inboundMessage{
+		op: SignatureRequest,
+		fields: map[Field]interface{}{
+			ChainID:     chainID[:],
+			RequestID:   requestID,
+			Deadline:    uint64(deadline),
+			ContainerID: messageID[:],
+		},
+		nodeID:         nodeID,
+		expirationTime: received.Add(deadline),
+	}
+}
+
+func (b *inMsgBuilder) InboundSignatureResponse(
+	chainID ids.ID,
+	requestID uint32,
+	signature []byte,
+	nodeID ids.NodeID,
+) InboundMessage {
+	return &inboundMessage{
+		op: SignatureResponse,
+		fields: map[Field]interface{}{
+			ChainID:   chainID[:],
+			RequestID: requestID,
+			SigBytes:  signature,
+		},
+		nodeID: nodeID,
+	}
+}
+
 func (b *inMsgBuilder) InboundGet(
 	chainID ids.ID,
 	requestID uint32,
@@ -49,6 +49,9 @@ const (
 	AcceptedStateSummary
 	// linearize dag
 	ChitsV2
+	// Warp signatures
+	SignatureRequest
+	SignatureResponse
 
 	// Internal messages (External messages should be added above these):
 	GetAcceptedFrontierFailed
@@ -64,6 +67,7 @@ const (
 	GossipRequest
 	GetStateSummaryFrontierFailed
 	GetAcceptedStateSummaryFailed
+	SignatureRequestFailed
 )
 
 var (
@@ -85,6 +89,7 @@ var (
 		AppRequest,
 		GetStateSummaryFrontier,
 		GetAcceptedStateSummary,
+		SignatureRequest,
 	}
 	ConsensusResponseOps = []Op{
 		AcceptedFrontier,
@@ -96,6 +101,7 @@ var (
 		AppResponse,
 		StateSummaryFrontier,
 		AcceptedStateSummary,
+		SignatureResponse,
 	}
 	// AppGossip is the only message that is sent unrequested without the
 	// expectation of a response
@@ -120,6 +126,7 @@ var (
 		GossipRequest,
 		GetStateSummaryFrontierFailed,
 		GetAcceptedStateSummaryFailed,
+		SignatureRequestFailed,
 	}
 	ConsensusOps = append(ConsensusExternalOps, ConsensusInternalOps...)
 
@@ -160,6 +167,11 @@ var (
 		AppGossip,
 		AppRequestFailed,
 		AppResponse,
+
+		// Warp signatures
+		SignatureRequest,
+		SignatureResponse,
+		SignatureRequestFailed,
 	}
 
 	RequestToResponseOps = map[Op]Op{
@@ -172,6 +184,7 @@ var (
 		AppRequest:              AppResponse,
 		GetStateSummaryFrontier: StateSummaryFrontier,
 		GetAcceptedStateSummary: AcceptedStateSummary,
+		SignatureRequest:        SignatureResponse,
 	}
 	ResponseToFailedOps = map[Op]Op{
 		AcceptedFrontier:     GetAcceptedFrontierFailed,
@@ -183,6 +196,7 @@ var (
 		AppResponse:          AppRequestFailed,
 		StateSummaryFrontier: GetStateSummaryFrontierFailed,
 		AcceptedStateSummary: GetAcceptedStateSummaryFailed,
+		SignatureResponse:    SignatureRequestFailed,
 	}
 	FailedToResponseOps = map[Op]Op{
 		GetAcceptedFrontierFailed: AcceptedFrontier,
@@ -196,6 +210,7 @@ var (
 		AppRequestFailed:              AppResponse,
 		GetStateSummaryFrontierFailed: StateSummaryFrontier,
 		GetAcceptedStateSummaryFailed: AcceptedStateSummary,
+		SignatureRequestFailed:        SignatureResponse,
 	}
 	UnrequestedOps = map[Op]struct{}{
 		GetAcceptedFrontier:     {},
@@ -208,6 +223,7 @@ var (
 		AppGossip:               {},
 		GetStateSummaryFrontier: {},
 		GetAcceptedStateSummary: {},
+		SignatureRequest:        {},
 	}
 
 	// Defines the messages that can be sent/received with this network
@@ -251,6 +267,12 @@ var (
 		StateSummaryFrontier:    {ChainID, RequestID, SummaryBytes},
 		GetAcceptedStateSummary: {ChainID, RequestID, Deadline, SummaryHeights},
 		AcceptedStateSummary:    {ChainID, RequestID, SummaryIDs},
+		// Warp signatures
+		// The "ContainerID" field of a signature request is the ID of the
+		// unsigned warp message and the "SigBytes" field of the response is
+		// the BLS signature of the message.
+		SignatureRequest:  {ChainID, RequestID, Deadline, ContainerID},
+		SignatureResponse: {ChainID, RequestID, SigBytes},
 	}
 )
 
@@ -313,6 +335,10 @@ func (op Op) String() string {
 		return "get_accepted_state_summary"
 	case AcceptedStateSummary:
 		return "accepted_state_summary"
+	case SignatureRequest:
+		return "signature_request"
+	case SignatureResponse:
+		return "signature_response"
 
 	case GetAcceptedFrontierFailed:
 		return "get_accepted_frontier_failed"
@@ -330,6 +356,8 @@ func (op Op) String() string {
 		return "get_state_summary_frontier_failed"
 	case GetAcceptedStateSummaryFailed:
 		return "get_accepted_state_summary_failed"
+	case SignatureRequestFailed:
+		return "signature_request_failed"
 	case Timeout:
 		return "timeout"
 	case Connected:
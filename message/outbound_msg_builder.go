@@ -157,6 +157,19 @@ type OutboundMsgBuilder interface {
 		chainID ids.ID,
 		msg []byte,
 	) (OutboundMessage, error)
+
+	SignatureRequest(
+		chainID ids.ID,
+		requestID uint32,
+		deadline time.Duration,
+		messageID ids.ID,
+	) (OutboundMessage, error)
+
+	SignatureResponse(
+		chainID ids.ID,
+		requestID uint32,
+		signature []byte,
+	) (OutboundMessage, error)
 }
 
 type outMsgBuilder struct {
@@ -582,3 +595,41 @@ func (b *outMsgBuilder) AppGossip(chainID ids.ID, msg []byte) (OutboundMessage,
 		false,
 	)
 }
+
+// Request for a signature of a warp message
+func (b *outMsgBuilder) SignatureRequest(
+	chainID ids.ID,
+	requestID uint32,
+	deadline time.Duration,
+	messageID ids.ID,
+) (OutboundMessage, error) {
+	return b.c.Pack(
+		SignatureRequest,
+		map[Field]interface{}{
+			ChainID:     chainID[:],
+			RequestID:   requestID,
+			Deadline:    uint64(deadline),
+			ContainerID: messageID[:],
+		},
+		SignatureRequest.Compressible(), // SignatureRequest messages can't be compressed
+		false,
+	)
+}
+
+// Signature of a warp message
+func (b *outMsgBuilder) SignatureResponse(
+	chainID ids.ID,
+	requestID uint32,
+	signature []byte,
+) (OutboundMessage, error) {
+	return b.c.Pack(
+		SignatureResponse,
+		map[Field]interface{}{
+			ChainID:   chainID[:],
+			RequestID: requestID,
+			SigBytes:  signature,
+		},
+		SignatureResponse.Compressible(), // SignatureResponse messages can't be compressed
+		false,
+	)
+}
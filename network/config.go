@@ -15,6 +15,7 @@ import (
 	"github.com/kukrer/savannahnode/snow/uptime"
 	"github.com/kukrer/savannahnode/snow/validators"
 	"github.com/kukrer/savannahnode/utils/ips"
+	"github.com/kukrer/savannahnode/version"
 )
 
 // HealthConfig describes parameters for network layer health checks.
@@ -115,6 +116,10 @@ type Config struct {
 	PingFrequency      time.Duration     `json:"pingFrequency"`
 	AllowPrivateIPs    bool              `json:"allowPrivateIPs"`
 
+	// Upgrades is the network upgrade schedule, which determines the versions
+	// of the peers that are compatible with this node.
+	Upgrades *version.Upgrades `json:"upgrades"`
+
 	// CompressionEnabled will compress available outbound messages when set to
 	// true.
 	CompressionEnabled bool `json:"compressionEnabled"`
@@ -104,6 +104,7 @@ var (
 		MaxClockDifference: time.Minute,
 		PingFrequency:      constants.DefaultPingFrequency,
 		AllowPrivateIPs:    true,
+		Upgrades:           version.GetUpgrades(49463),
 
 		CompressionEnabled: true,
 
@@ -89,7 +89,7 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 		MessageCreator:       mc,
 		Log:                  logging.NoLog{},
 		InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
-		VersionCompatibility: version.GetCompatibility(constants.LocalID),
+		VersionCompatibility: version.GetCompatibility(version.GetUpgrades(constants.LocalID)),
 		MySubnets:            ids.Set{},
 		Beacons:              validators.NewSet(),
 		NetworkID:            constants.LocalID,
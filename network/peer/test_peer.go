@@ -116,7 +116,7 @@ func StartTestPeer(
 				100,
 			),
 			Router:               router,
-			VersionCompatibility: version.GetCompatibility(networkID),
+			VersionCompatibility: version.GetCompatibility(version.GetUpgrades(networkID)),
 			MySubnets:            ids.Set{},
 			Beacons:              validators.NewSet(),
 			NetworkID:            networkID,
@@ -17,11 +17,13 @@ import (
 	"github.com/kukrer/savannahnode/snow/networking/router"
 	"github.com/kukrer/savannahnode/snow/networking/sender"
 	"github.com/kukrer/savannahnode/snow/networking/tracker"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
 	"github.com/kukrer/savannahnode/utils/dynamicip"
 	"github.com/kukrer/savannahnode/utils/ips"
 	"github.com/kukrer/savannahnode/utils/logging"
 	"github.com/kukrer/savannahnode/utils/profiler"
 	"github.com/kukrer/savannahnode/utils/timer"
+	"github.com/kukrer/savannahnode/version"
 	"github.com/kukrer/savannahnode/vms"
 )
 
@@ -81,11 +83,14 @@ type IPConfig struct {
 
 type StakingConfig struct {
 	genesis.StakingConfig
-	EnableStaking         bool            `json:"enableStaking"`
-	StakingTLSCert        tls.Certificate `json:"-"`
-	DisabledStakingWeight uint64          `json:"disabledStakingWeight"`
-	StakingKeyPath        string          `json:"stakingKeyPath"`
-	StakingCertPath       string          `json:"stakingCertPath"`
+	StakingUpgrades       []genesis.StakingUpgrade `json:"stakingUpgrades"`
+	EnableStaking         bool                     `json:"enableStaking"`
+	StakingTLSCert        tls.Certificate          `json:"-"`
+	StakingSigningKey     *bls.SecretKey           `json:"-"`
+	DisabledStakingWeight uint64                   `json:"disabledStakingWeight"`
+	StakingKeyPath        string                   `json:"stakingKeyPath"`
+	StakingCertPath       string                   `json:"stakingCertPath"`
+	StakingSignerPath     string                   `json:"stakingSignerPath"`
 }
 
 type StateSyncConfig struct {
@@ -146,6 +151,9 @@ type Config struct {
 	// ID of the network this node should connect to
 	NetworkID uint32 `json:"networkID"`
 
+	// Upgrades is the network upgrade schedule of the network
+	Upgrades *version.Upgrades `json:"upgrades"`
+
 	// Assertions configuration
 	EnableAssertions bool `json:"enableAssertions"`
 
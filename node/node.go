@@ -68,9 +68,13 @@ import (
 	"github.com/kukrer/savannahnode/utils/wrappers"
 	"github.com/kukrer/savannahnode/version"
 	"github.com/kukrer/savannahnode/vms/avm"
+	"github.com/kukrer/savannahnode/vms/components/burn"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
 	"github.com/kukrer/savannahnode/vms/platformvm"
 	"github.com/kukrer/savannahnode/vms/platformvm/config"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/registry"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
@@ -275,6 +279,7 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.MyNodeID = n.ID
 	n.Config.NetworkConfig.MyIPPort = n.Config.IPPort
 	n.Config.NetworkConfig.NetworkID = n.Config.NetworkID
+	n.Config.NetworkConfig.Upgrades = n.Config.Upgrades
 	n.Config.NetworkConfig.Validators = n.vdrs
 	n.Config.NetworkConfig.Beacons = n.beacons
 	n.Config.NetworkConfig.TLSConfig = tlsConfig
@@ -672,6 +677,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	n.chainManager = chains.New(&chains.ManagerConfig{
 		StakingEnabled:                          n.Config.EnableStaking,
 		StakingCert:                             n.Config.StakingTLSCert,
+		StakingBLSKey:                           n.Config.StakingSigningKey,
 		Log:                                     n.Log,
 		LogFactory:                              n.LogFactory,
 		VMManager:                               n.Config.VMManager,
@@ -706,8 +712,9 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 		BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 		BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
-		ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
-		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
+		ApricotPhase4Time:                       n.Config.Upgrades.ApricotPhase4Time,
+		ApricotPhase4MinPChainHeight:            n.Config.Upgrades.ApricotPhase4MinPChainHeight,
+		XChainMigrationTime:                     n.Config.Upgrades.XChainMigrationTime,
 		ResourceTracker:                         n.resourceTracker,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
 	})
@@ -717,6 +724,28 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	return nil
 }
 
+// stakingUpgrades returns the scheduled changes of the staking parameters of
+// the primary network in the format of the platform VM config
+func (n *Node) stakingUpgrades() []config.StakingUpgrade {
+	upgrades := make([]config.StakingUpgrade, len(n.Config.StakingUpgrades))
+	for i, upgrade := range n.Config.StakingUpgrades {
+		upgrades[i] = config.StakingUpgrade{
+			Time: upgrade.Time,
+			StakingConfig: config.StakingConfig{
+				MinValidatorStake: upgrade.MinValidatorStake,
+				MaxValidatorStake: upgrade.MaxValidatorStake,
+				MinDelegatorStake: upgrade.MinDelegatorStake,
+				MinDelegationFee:  upgrade.MinDelegationFee,
+				UptimePercentage:  upgrade.UptimeRequirement,
+				MinStakeDuration:  upgrade.MinStakeDuration,
+				MaxStakeDuration:  upgrade.MaxStakeDuration,
+				RewardConfig:      upgrade.RewardConfig,
+			},
+		}
+	}
+	return upgrades
+}
+
 // initVMs initializes the VMs Avalanche supports + any additional vms installed as plugins.
 func (n *Node) initVMs() error {
 	n.Log.Info("initializing VMs")
@@ -736,6 +765,9 @@ func (n *Node) initVMs() error {
 		VMManager: n.Config.VMManager,
 	})
 
+	// The chains of the node report the AVAX they burn to the P-chain
+	burnRegistry := burn.NewRegistry()
+
 	// Register the VMs that Avalanche supports
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -745,12 +777,15 @@ func (n *Node) initVMs() error {
 				Validators:             vdrs,
 				SubnetTracker:          n.Net,
 				UptimeLockedCalculator: n.uptimeCalculator,
+				BurnRegistry:           burnRegistry,
 				StakingEnabled:         n.Config.EnableStaking,
 				WhitelistedSubnets:     n.Config.WhitelistedSubnets,
+				AdminAPIEnabled:        n.Config.AdminAPIEnabled,
 				TxFee:                  n.Config.TxFee,
 				CreateAssetTxFee:       n.Config.CreateAssetTxFee,
 				CreateSubnetTxFee:      n.Config.CreateSubnetTxFee,
 				CreateBlockchainTxFee:  n.Config.CreateBlockchainTxFee,
+				DynamicFees:            n.Config.DynamicFeeConfig,
 				UptimePercentage:       n.Config.UptimeRequirement,
 				MinValidatorStake:      n.Config.MinValidatorStake,
 				MaxValidatorStake:      n.Config.MaxValidatorStake,
@@ -759,20 +794,28 @@ func (n *Node) initVMs() error {
 				MinStakeDuration:       n.Config.MinStakeDuration,
 				MaxStakeDuration:       n.Config.MaxStakeDuration,
 				RewardConfig:           n.Config.RewardConfig,
-				ApricotPhase3Time:      version.GetApricotPhase3Time(n.Config.NetworkID),
-				ApricotPhase5Time:      version.GetApricotPhase5Time(n.Config.NetworkID),
-				BlueberryTime:          version.GetBlueberryTime(n.Config.NetworkID),
+				StakingUpgrades:        n.stakingUpgrades(),
+				ApricotPhase3Time:      n.Config.Upgrades.ApricotPhase3Time,
+				ApricotPhase5Time:      n.Config.Upgrades.ApricotPhase5Time,
+				BlueberryTime:          n.Config.Upgrades.BlueberryTime,
+				BaobabTime:             n.Config.Upgrades.BaobabTime,
+				DynamicFeesTime:        n.Config.Upgrades.DynamicFeesTime,
 			},
 		}),
 		vmRegisterer.Register(constants.AVMID, &avm.Factory{
-			TxFee:            n.Config.TxFee,
-			CreateAssetTxFee: n.Config.CreateAssetTxFee,
-			BlueberryTime:    version.GetBlueberryTime(n.Config.NetworkID),
+			TxFee:               n.Config.TxFee,
+			CreateAssetTxFee:    n.Config.CreateAssetTxFee,
+			BlueberryTime:       n.Config.Upgrades.BlueberryTime,
+			XChainMigrationTime: n.Config.Upgrades.XChainMigrationTime,
+			BaobabTime:          n.Config.Upgrades.BaobabTime,
+			BurnRegistry:        burnRegistry,
 		}),
 		vmRegisterer.Register(constants.EVMID, &coreth.Factory{}),
 		n.Config.VMManager.RegisterFactory(secp256k1fx.ID, &secp256k1fx.Factory{}),
 		n.Config.VMManager.RegisterFactory(nftfx.ID, &nftfx.Factory{}),
 		n.Config.VMManager.RegisterFactory(propertyfx.ID, &propertyfx.Factory{}),
+		n.Config.VMManager.RegisterFactory(htlcfx.ID, &htlcfx.Factory{}),
+		n.Config.VMManager.RegisterFactory(policyfx.ID, &policyfx.Factory{}),
 	)
 	if errs.Errored() {
 		return errs.Err
@@ -935,12 +978,14 @@ func (n *Node) initInfoAPI() error {
 		info.Parameters{
 			Version:               version.CurrentApp,
 			NodeID:                n.ID,
+			NodePOP:               signer.NewProofOfPossession(n.Config.StakingSigningKey),
 			NetworkID:             n.Config.NetworkID,
 			TxFee:                 n.Config.TxFee,
 			CreateAssetTxFee:      n.Config.CreateAssetTxFee,
 			CreateSubnetTxFee:     n.Config.CreateSubnetTxFee,
 			CreateBlockchainTxFee: n.Config.CreateBlockchainTxFee,
 			VMManager:             n.Config.VMManager,
+			Upgrades:              n.Config.Upgrades,
 		},
 		n.Log,
 		n.chainManager,
@@ -17,6 +17,7 @@ import (
 	"github.com/kukrer/savannahnode/snow/validators"
 	"github.com/kukrer/savannahnode/utils"
 	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/vms/platformvm/warp"
 )
 
 type SubnetLookup interface {
@@ -55,6 +56,10 @@ type Context struct {
 	ValidatorState    validators.State  // interface for P-Chain validators
 	StakingLeafSigner crypto.Signer     // block signer
 	StakingCertLeaf   *x509.Certificate // block certificate
+
+	// Warp messaging attributes
+	WarpSigner warp.Signer          // signs messages sent from this chain
+	WarpClient warp.SignatureGetter // fetches message signatures from peers
 }
 
 // Expose gatherer interface for unit testing.
@@ -68,6 +73,11 @@ type ConsensusContext struct {
 
 	Registerer Registerer
 
+	// AvalancheRegisterer registers the metrics of the Avalanche consensus of a
+	// DAG-based chain, so that they don't collide with the metrics of its
+	// Snowman consensus once the chain is linearized.
+	AvalancheRegisterer Registerer
+
 	// DecisionAcceptor is the callback that will be fired whenever a VM is
 	// notified that their object, either a block in snowman or a transaction
 	// in avalanche, was accepted.
@@ -86,6 +96,9 @@ type ConsensusContext struct {
 
 	// Indicates this chain is available to only validators.
 	validatorOnly utils.AtomicBool
+
+	// Indicates this chain was linearized onto Snowman consensus.
+	linearized utils.AtomicBool
 }
 
 func (ctx *ConsensusContext) SetState(newState State) {
@@ -118,6 +131,18 @@ func (ctx *ConsensusContext) SetValidatorOnly() {
 	ctx.validatorOnly.SetValue(true)
 }
 
+// IsLinearized returns true iff this DAG-based chain was linearized onto
+// Snowman consensus
+func (ctx *ConsensusContext) IsLinearized() bool {
+	return ctx.linearized.GetValue()
+}
+
+// SetLinearized marks this chain as linearized onto Snowman consensus. Once
+// set, the containers accepted by this chain are blocks rather than vertices.
+func (ctx *ConsensusContext) SetLinearized() {
+	ctx.linearized.SetValue(true)
+}
+
 func DefaultContextTest() *Context {
 	return &Context{
 		NetworkID: 0,
@@ -132,9 +157,10 @@ func DefaultContextTest() *Context {
 
 func DefaultConsensusContextTest() *ConsensusContext {
 	return &ConsensusContext{
-		Context:           DefaultContextTest(),
-		Registerer:        prometheus.NewRegistry(),
-		DecisionAcceptor:  noOpAcceptor{},
-		ConsensusAcceptor: noOpAcceptor{},
+		Context:             DefaultContextTest(),
+		Registerer:          prometheus.NewRegistry(),
+		AvalancheRegisterer: prometheus.NewRegistry(),
+		DecisionAcceptor:    noOpAcceptor{},
+		ConsensusAcceptor:   noOpAcceptor{},
 	}
 }
@@ -55,7 +55,7 @@ func New(config Config, onFinished func(lastReqID uint32) error) (common.Bootstr
 		executedStateTransitions: math.MaxInt32,
 	}
 
-	if err := b.metrics.Initialize("bs", config.Ctx.Registerer); err != nil {
+	if err := b.metrics.Initialize("bs", config.Ctx.AvalancheRegisterer); err != nil {
 		return nil, err
 	}
 
@@ -22,4 +22,9 @@ type Config struct {
 
 	Params    avalanche.Parameters
 	Consensus avalanche.Consensus
+
+	// Linearize, if set, is called once the stop vertex has been accepted
+	// with the last request ID sent by the engine, to continue the chain on
+	// Snowman consensus.
+	Linearize func(lastReqID uint32) error
 }
@@ -85,12 +85,12 @@ func newTransitive(config Config) (*Transitive, error) {
 		polls: poll.NewSet(factory,
 			config.Ctx.Log,
 			"",
-			config.Ctx.Registerer,
+			config.Ctx.AvalancheRegisterer,
 		),
 		uniformSampler: sampler.NewUniform(),
 	}
 
-	return t, t.metrics.Initialize("", config.Ctx.Registerer)
+	return t, t.metrics.Initialize("", config.Ctx.AvalancheRegisterer)
 }
 
 func (t *Transitive) Put(nodeID ids.NodeID, requestID uint32, vtxBytes []byte) error {
@@ -674,6 +674,21 @@ func (t *Transitive) issueStopVtx() error {
 	return t.issue(vtx)
 }
 
+// linearizeIfStopped hands the chain over to Snowman consensus if the stop
+// vertex has been accepted. Returns true if the chain was linearized.
+func (t *Transitive) linearizeIfStopped() (bool, error) {
+	if t.Linearize == nil {
+		return false, nil
+	}
+	stopped, err := t.Manager.StopVertexAccepted()
+	if err != nil || !stopped {
+		return false, err
+	}
+
+	t.Ctx.Log.Info("stop vertex accepted, linearizing the chain")
+	return true, t.Linearize(t.RequestID)
+}
+
 // Send a request to [vdr] asking them to send us vertex [vtxID]
 func (t *Transitive) sendRequest(nodeID ids.NodeID, vtxID ids.ID) {
 	if t.outstandingVtxReqs.Contains(vtxID) {
@@ -1754,6 +1754,98 @@ func TestEngineSingleQuery(t *testing.T) {
 	}
 }
 
+func TestEngineLinearizeOnStopVertex(t *testing.T) {
+	_, _, engCfg := DefaultConfig()
+
+	vals := validators.NewSet()
+	engCfg.Validators = vals
+
+	vdr := ids.GenerateTestNodeID()
+	if err := vals.AddWeight(vdr, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	sender := &common.SenderTest{T: t}
+	sender.Default(true)
+	sender.CantSendGetAcceptedFrontier = false
+	engCfg.Sender = sender
+
+	manager := vertex.NewTestManager(t)
+	manager.Default(true)
+	engCfg.Manager = manager
+
+	gVtx := &avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+	stopVtx := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: []avalanche.Vertex{gVtx},
+		HeightV:  1,
+		BytesV:   []byte{0, 1, 2, 3},
+	}
+
+	manager.EdgeF = func() []ids.ID { return []ids.ID{gVtx.ID()} }
+	manager.GetVtxF = func(id ids.ID) (avalanche.Vertex, error) {
+		switch id {
+		case gVtx.ID():
+			return gVtx, nil
+		case stopVtx.ID():
+			return stopVtx, nil
+		}
+		t.Fatalf("Unknown vertex")
+		panic("Should have errored")
+	}
+	manager.StopVertexAcceptedF = func() (bool, error) {
+		return stopVtx.Status() == choices.Accepted, nil
+	}
+
+	linearizedReqID := uint32(0)
+	linearized := false
+	engCfg.Linearize = func(lastReqID uint32) error {
+		linearized = true
+		linearizedReqID = lastReqID
+		return nil
+	}
+
+	te, err := newTransitive(engCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := te.Start(0); err != nil {
+		t.Fatal(err)
+	}
+
+	reqID := new(uint32)
+	sender.SendPushQueryF = func(_ ids.NodeIDSet, requestID uint32, _ ids.ID, _ []byte) {
+		*reqID = requestID
+	}
+
+	if err := te.issue(stopVtx); err != nil {
+		t.Fatal(err)
+	}
+	if linearized {
+		t.Fatalf("linearized before the stop vertex was accepted")
+	}
+
+	if err := te.Chits(vdr, *reqID, []ids.ID{stopVtx.ID()}); err != nil {
+		t.Fatal(err)
+	}
+	if stopVtx.Status() != choices.Accepted {
+		t.Fatalf("stop vertex should have been accepted")
+	}
+	if !linearized {
+		t.Fatalf("should have linearized once the stop vertex was accepted")
+	}
+	if linearizedReqID != te.RequestID {
+		t.Fatalf("linearized with request ID %d but the last request ID is %d", linearizedReqID, te.RequestID)
+	}
+}
+
 func TestEngineParentBlockingInsert(t *testing.T) {
 	_, _, engCfg := DefaultConfig()
 
@@ -2852,7 +2944,7 @@ func TestEngineReBootstrapFails(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	bootCfg.Ctx.Registerer = prometheus.NewRegistry()
+	bootCfg.Ctx.AvalancheRegisterer = prometheus.NewRegistry()
 
 	// re-register the Transitive
 	bootstrapper2, err := bootstrap.New(
@@ -4644,7 +4736,7 @@ func TestHandleChitsV2(t *testing.T) {
 	engCfg.Manager = manager
 
 	reg := prometheus.NewRegistry()
-	engCfg.Ctx.Registerer = reg
+	engCfg.Ctx.AvalancheRegisterer = reg
 
 	te, err := newTransitive(engCfg)
 	require.NoError(err)
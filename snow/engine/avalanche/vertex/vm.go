@@ -7,6 +7,7 @@ import (
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow/consensus/snowstorm"
 	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/snow/engine/snowman/block"
 )
 
 // DAGVM defines the minimum functionality that an avalanche VM must
@@ -22,6 +23,19 @@ type DAGVM interface {
 	ParseTx(tx []byte) (snowstorm.Tx, error)
 }
 
+// LinearizableVM defines the functionality a DAGVM must implement to be run on
+// Snowman consensus once its stop vertex has been accepted.
+type LinearizableVM interface {
+	DAGVM
+	block.ChainVM
+
+	// Linearize is called once the stop vertex [stopVertexID] is accepted, or
+	// when the chain is restarted after it was accepted. Afterwards, the VM is
+	// only used as a block.ChainVM and notifies the Snowman engine through
+	// [toEngine].
+	Linearize(stopVertexID ids.ID, toEngine chan<- common.Message) error
+}
+
 // Getter defines the functionality for fetching a tx/block by its ID.
 type Getter interface {
 	// Retrieve a transaction that was submitted previously
@@ -60,6 +60,13 @@ func (v *voter) Update() {
 		}
 	}
 
+	if linearized, err := v.t.linearizeIfStopped(); err != nil {
+		v.t.errs.Add(err)
+		return
+	} else if linearized {
+		return
+	}
+
 	orphans := v.t.Consensus.Orphans()
 	txs := make([]snowstorm.Tx, 0, orphans.Len())
 	for orphanID := range orphans {
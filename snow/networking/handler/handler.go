@@ -17,6 +17,7 @@ import (
 	"github.com/kukrer/savannahnode/message"
 	"github.com/kukrer/savannahnode/snow"
 	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/snow/networking/signatures"
 	"github.com/kukrer/savannahnode/snow/networking/tracker"
 	"github.com/kukrer/savannahnode/snow/networking/worker"
 	"github.com/kukrer/savannahnode/snow/validators"
@@ -44,6 +45,7 @@ type Handler interface {
 	Bootstrapper() common.BootstrapableEngine
 	SetConsensus(engine common.Engine)
 	Consensus() common.Engine
+	SetSignatureHandler(signatureHandler signatures.Handler)
 
 	SetOnStopped(onStopped func())
 	Start(recoverPanic bool)
@@ -73,6 +75,9 @@ type handler struct {
 	stateSyncer  common.StateSyncer
 	bootstrapper common.BootstrapableEngine
 	engine       common.Engine
+	// signatureHandler handles Warp signature messages. If it is nil, the
+	// messages are dropped.
+	signatureHandler signatures.Handler
 	// onStopped is called in a goroutine when this handler finishes shutting
 	// down. If it is nil then it is skipped.
 	onStopped func()
@@ -157,6 +162,10 @@ func (h *handler) Bootstrapper() common.BootstrapableEngine          { return h.
 func (h *handler) SetConsensus(engine common.Engine) { h.engine = engine }
 func (h *handler) Consensus() common.Engine          { return h.engine }
 
+func (h *handler) SetSignatureHandler(signatureHandler signatures.Handler) {
+	h.signatureHandler = signatureHandler
+}
+
 func (h *handler) SetOnStopped(onStopped func()) { h.onStopped = onStopped }
 
 func (h *handler) selectStartingGear() (common.Engine, error) {
@@ -230,7 +239,8 @@ func (h *handler) HealthCheck() (interface{}, error) {
 // Push the message onto the handler's queue
 func (h *handler) Push(msg message.InboundMessage) {
 	switch msg.Op() {
-	case message.AppRequest, message.AppGossip, message.AppRequestFailed, message.AppResponse:
+	case message.AppRequest, message.AppGossip, message.AppRequestFailed, message.AppResponse,
+		message.SignatureRequest, message.SignatureResponse, message.SignatureRequestFailed:
 		h.asyncMessageQueue.Push(msg)
 	default:
 		h.syncMessageQueue.Push(msg)
@@ -624,6 +634,11 @@ func (h *handler) executeAsyncMsg(msg message.InboundMessage) error {
 		)
 	}()
 
+	switch op {
+	case message.SignatureRequest, message.SignatureResponse, message.SignatureRequestFailed:
+		return h.handleSignatureMsg(msg)
+	}
+
 	engine, err := h.getEngine()
 	if err != nil {
 		return err
@@ -656,6 +671,38 @@ func (h *handler) executeAsyncMsg(msg message.InboundMessage) error {
 	}
 }
 
+func (h *handler) handleSignatureMsg(msg message.InboundMessage) error {
+	if h.signatureHandler == nil {
+		h.ctx.Log.Debug("dropping signature message",
+			zap.String("reason", "no signature handler registered"),
+			zap.Stringer("messageOp", msg.Op()),
+		)
+		return nil
+	}
+
+	nodeID := msg.NodeID()
+	reqID := msg.Get(message.RequestID).(uint32)
+	switch op := msg.Op(); op {
+	case message.SignatureRequest:
+		msgID, err := ids.ToID(msg.Get(message.ContainerID).([]byte))
+		h.ctx.Log.AssertNoError(err)
+		return h.signatureHandler.SignatureRequest(nodeID, reqID, msgID)
+
+	case message.SignatureResponse:
+		signature := msg.Get(message.SigBytes).([]byte)
+		return h.signatureHandler.SignatureResponse(nodeID, reqID, signature)
+
+	case message.SignatureRequestFailed:
+		return h.signatureHandler.SignatureRequestFailed(nodeID, reqID)
+
+	default:
+		return fmt.Errorf(
+			"attempt to submit unhandled signature msg %s from %s",
+			op, nodeID,
+		)
+	}
+}
+
 func (h *handler) handleChanMsg(msg message.InboundMessage) error {
 	h.ctx.Log.Debug("forwarding chan message to consensus",
 		zap.Stringer("message", msg),
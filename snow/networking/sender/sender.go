@@ -19,7 +19,21 @@ import (
 	"github.com/kukrer/savannahnode/utils/constants"
 )
 
-var _ common.Sender = &sender{}
+var _ Sender = &sender{}
+
+// Sender sends consensus messages and Warp signature messages to other
+// validators.
+type Sender interface {
+	common.Sender
+
+	// SendSignatureRequest requests the signature of the Warp message
+	// [msgID] from [nodeID].
+	SendSignatureRequest(nodeID ids.NodeID, requestID uint32, msgID ids.ID)
+
+	// SendSignatureResponse sends the signature of a Warp message to
+	// [nodeID] in response to a signature request.
+	SendSignatureResponse(nodeID ids.NodeID, requestID uint32, signature []byte)
+}
 
 type GossipConfig struct {
 	AcceptedFrontierValidatorSize    uint `json:"gossipAcceptedFrontierValidatorSize" yaml:"gossipAcceptedFrontierValidatorSize"`
@@ -59,7 +73,7 @@ func New(
 	router router.Router,
 	timeouts timeout.Manager,
 	gossipConfig GossipConfig,
-) (common.Sender, error) {
+) (Sender, error) {
 	s := &sender{
 		ctx:              ctx,
 		msgCreator:       msgCreator,
@@ -1092,3 +1106,85 @@ func (s *sender) Accept(ctx *snow.ConsensusContext, containerID ids.ID, containe
 	}
 	return nil
 }
+
+func (s *sender) SendSignatureRequest(nodeID ids.NodeID, requestID uint32, msgID ids.ID) {
+	// Tell the router to expect a response message or a message notifying
+	// that we won't get a response from this node.
+	s.router.RegisterRequest(nodeID, s.ctx.ChainID, requestID, message.SignatureResponse)
+
+	// Note that this timeout duration won't exactly match the one that gets
+	// registered. That's OK.
+	deadline := s.timeouts.TimeoutDuration()
+
+	// Sending a message to myself. No need to send it over the network.
+	// Just put it right into the router. Do so asynchronously to avoid deadlock.
+	if nodeID == s.ctx.NodeID {
+		inMsg := s.msgCreator.InboundSignatureRequest(s.ctx.ChainID, requestID, deadline, msgID, nodeID)
+		go s.router.HandleInbound(inMsg)
+		return
+	}
+
+	// [nodeID] may be benched. That is, they've been unresponsive
+	// so we don't even bother sending requests to them. We just have them immediately fail.
+	if s.timeouts.IsBenched(nodeID, s.ctx.ChainID) {
+		s.failedDueToBench[message.SignatureRequest].Inc() // update metric
+		s.timeouts.RegisterRequestToUnreachableValidator()
+		inMsg := s.msgCreator.InternalFailedRequest(message.SignatureRequestFailed, nodeID, s.ctx.ChainID, requestID)
+		go s.router.HandleInbound(inMsg)
+		return
+	}
+
+	// Create the outbound message.
+	outMsg, err := s.msgCreator.SignatureRequest(s.ctx.ChainID, requestID, deadline, msgID)
+	s.ctx.Log.AssertNoError(err)
+
+	// Send the message over the network.
+	nodeIDs := ids.NewNodeIDSet(1)
+	nodeIDs.Add(nodeID)
+	if sentTo := s.sender.Send(outMsg, nodeIDs, s.ctx.SubnetID, s.ctx.IsValidatorOnly()); sentTo.Len() == 0 {
+		s.ctx.Log.Debug("failed to send message",
+			zap.Stringer("messageOp", outMsg.Op()),
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.Uint32("requestID", requestID),
+			zap.Stringer("msgID", msgID),
+		)
+
+		s.timeouts.RegisterRequestToUnreachableValidator()
+		inMsg := s.msgCreator.InternalFailedRequest(message.SignatureRequestFailed, nodeID, s.ctx.ChainID, requestID)
+		go s.router.HandleInbound(inMsg)
+	}
+}
+
+func (s *sender) SendSignatureResponse(nodeID ids.NodeID, requestID uint32, signature []byte) {
+	if nodeID == s.ctx.NodeID {
+		inMsg := s.msgCreator.InboundSignatureResponse(s.ctx.ChainID, requestID, signature, nodeID)
+		go s.router.HandleInbound(inMsg)
+		return
+	}
+
+	// Create the outbound message.
+	outMsg, err := s.msgCreator.SignatureResponse(s.ctx.ChainID, requestID, signature)
+	if err != nil {
+		s.ctx.Log.Error("failed to build message",
+			zap.Stringer("messageOp", message.SignatureResponse),
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.Uint32("requestID", requestID),
+			zap.Binary("signature", signature),
+			zap.Error(err),
+		)
+		return
+	}
+
+	// Send the message over the network.
+	nodeIDs := ids.NewNodeIDSet(1)
+	nodeIDs.Add(nodeID)
+	if sentTo := s.sender.Send(outMsg, nodeIDs, s.ctx.SubnetID, s.ctx.IsValidatorOnly()); sentTo.Len() == 0 {
+		s.ctx.Log.Debug("failed to send message",
+			zap.Stringer("messageOp", outMsg.Op()),
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.Uint32("requestID", requestID),
+		)
+	}
+}
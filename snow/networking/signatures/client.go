@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signatures
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/vms/platformvm/warp"
+)
+
+var (
+	_ Client = &client{}
+
+	errRequestFailed = errors.New("signature request failed")
+)
+
+// Sender sends Warp signature requests and responses to peers.
+type Sender interface {
+	SendSignatureRequest(nodeID ids.NodeID, requestID uint32, msgID ids.ID)
+	SendSignatureResponse(nodeID ids.NodeID, requestID uint32, signature []byte)
+}
+
+// Handler handles Warp signature messages received from peers.
+type Handler interface {
+	// SignatureRequest is called when [nodeID] requests the signature of the
+	// Warp message [msgID]. The signature is only sent if this node previously
+	// signed the message.
+	SignatureRequest(nodeID ids.NodeID, requestID uint32, msgID ids.ID) error
+
+	// SignatureResponse is called when [nodeID] responds to a previously
+	// sent signature request.
+	SignatureResponse(nodeID ids.NodeID, requestID uint32, signature []byte) error
+
+	// SignatureRequestFailed is called when a previously sent signature
+	// request to [nodeID] will not receive a response.
+	SignatureRequestFailed(nodeID ids.NodeID, requestID uint32) error
+}
+
+// Client serves signatures of the Warp messages signed by this node and
+// fetches the signatures of Warp messages from peers.
+type Client interface {
+	Handler
+	warp.SignatureGetter
+}
+
+type client struct {
+	log    logging.Logger
+	signer warp.Signer
+	sender Sender
+
+	lock sync.Mutex
+	// requestID of the next signature request
+	requestID uint32
+	// requestID -> outstanding request
+	requests map[uint32]*request
+}
+
+type request struct {
+	nodeID ids.NodeID
+	// receives the signature bytes, or nil if the request failed
+	response chan []byte
+}
+
+// NewClient returns a new Client. If [signer] is nil, signature requests from
+// peers are ignored.
+func NewClient(log logging.Logger, signer warp.Signer, sender Sender) Client {
+	return &client{
+		log:      log,
+		signer:   signer,
+		sender:   sender,
+		requests: make(map[uint32]*request),
+	}
+}
+
+func (c *client) GetSignature(ctx context.Context, nodeID ids.NodeID, msg *warp.UnsignedMessage) (*bls.Signature, error) {
+	req := &request{
+		nodeID:   nodeID,
+		response: make(chan []byte, 1),
+	}
+
+	c.lock.Lock()
+	requestID := c.requestID
+	c.requestID++
+	c.requests[requestID] = req
+	c.lock.Unlock()
+
+	c.sender.SendSignatureRequest(nodeID, requestID, msg.ID())
+
+	select {
+	case <-ctx.Done():
+		c.lock.Lock()
+		delete(c.requests, requestID)
+		c.lock.Unlock()
+		return nil, ctx.Err()
+	case sigBytes := <-req.response:
+		if sigBytes == nil {
+			return nil, errRequestFailed
+		}
+		return bls.SignatureFromBytes(sigBytes)
+	}
+}
+
+func (c *client) SignatureRequest(nodeID ids.NodeID, requestID uint32, msgID ids.ID) error {
+	if c.signer == nil {
+		return nil
+	}
+
+	sig, err := c.signer.GetSignature(msgID)
+	if err != nil {
+		c.log.Debug("dropping signature request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Stringer("msgID", msgID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	c.sender.SendSignatureResponse(nodeID, requestID, sig)
+	return nil
+}
+
+func (c *client) SignatureResponse(nodeID ids.NodeID, requestID uint32, signature []byte) error {
+	req, ok := c.popRequest(nodeID, requestID)
+	if !ok {
+		c.log.Debug("dropping unexpected signature response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+
+	// A nil signature is reserved to signal a failed request.
+	if signature == nil {
+		signature = []byte{}
+	}
+	req.response <- signature
+	return nil
+}
+
+func (c *client) SignatureRequestFailed(nodeID ids.NodeID, requestID uint32) error {
+	req, ok := c.popRequest(nodeID, requestID)
+	if !ok {
+		return nil
+	}
+
+	req.response <- nil
+	return nil
+}
+
+// popRequest removes and returns the outstanding request [requestID] if it
+// was sent to [nodeID].
+func (c *client) popRequest(nodeID ids.NodeID, requestID uint32) (*request, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	req, ok := c.requests[requestID]
+	if !ok || req.nodeID != nodeID {
+		return nil, false
+	}
+	delete(c.requests, requestID)
+	return req, true
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signatures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/vms/platformvm/warp"
+)
+
+var _ Sender = &testSender{}
+
+type testSender struct {
+	requestF  func(nodeID ids.NodeID, requestID uint32, msgID ids.ID)
+	responseF func(nodeID ids.NodeID, requestID uint32, signature []byte)
+}
+
+func (s *testSender) SendSignatureRequest(nodeID ids.NodeID, requestID uint32, msgID ids.ID) {
+	s.requestF(nodeID, requestID, msgID)
+}
+
+func (s *testSender) SendSignatureResponse(nodeID ids.NodeID, requestID uint32, signature []byte) {
+	s.responseF(nodeID, requestID, signature)
+}
+
+// newTestNetwork returns a client that signs messages sent from [chainID] and
+// is connected to itself.
+func newTestNetwork(t *testing.T, nodeID ids.NodeID, chainID ids.ID) (Client, warp.Signer, *bls.SecretKey) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	signer := warp.NewSigner(sk, chainID)
+	sender := &testSender{}
+	c := NewClient(logging.NoLog{}, signer, sender)
+	sender.requestF = func(_ ids.NodeID, requestID uint32, msgID ids.ID) {
+		go func() {
+			require.NoError(t, c.SignatureRequest(nodeID, requestID, msgID))
+		}()
+	}
+	sender.responseF = func(_ ids.NodeID, requestID uint32, signature []byte) {
+		require.NoError(t, c.SignatureResponse(nodeID, requestID, signature))
+	}
+	return c, signer, sk
+}
+
+func TestClientGetSignature(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+	c, signer, sk := newTestNetwork(t, nodeID, chainID)
+
+	msg, err := warp.NewUnsignedMessage(chainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	_, err = signer.Sign(msg)
+	require.NoError(err)
+
+	sig, err := c.GetSignature(context.Background(), nodeID, msg)
+	require.NoError(err)
+	require.True(bls.Verify(bls.PublicFromSecretKey(sk), sig, msg.Bytes()))
+}
+
+func TestClientGetSignatureUnknownMessage(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+	c, _, _ := newTestNetwork(t, nodeID, chainID)
+
+	msg, err := warp.NewUnsignedMessage(chainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	// The message was never signed, so no response is sent.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.GetSignature(ctx, nodeID, msg)
+	require.ErrorIs(err, context.Canceled)
+}
+
+func TestClientSignatureRequestFailed(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	var c Client
+	sender := &testSender{
+		requestF: func(nodeID ids.NodeID, requestID uint32, _ ids.ID) {
+			require.NoError(c.SignatureRequestFailed(nodeID, requestID))
+		},
+	}
+	c = NewClient(logging.NoLog{}, nil, sender)
+
+	msg, err := warp.NewUnsignedMessage(ids.GenerateTestID(), ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	_, err = c.GetSignature(context.Background(), nodeID, msg)
+	require.ErrorIs(err, errRequestFailed)
+}
+
+func TestClientDropsUnexpectedResponse(t *testing.T) {
+	require := require.New(t)
+
+	c := NewClient(logging.NoLog{}, nil, &testSender{})
+	require.NoError(c.SignatureResponse(ids.GenerateTestNodeID(), 0, []byte{1}))
+	require.NoError(c.SignatureRequestFailed(ids.GenerateTestNodeID(), 0))
+}
@@ -7,10 +7,21 @@ import (
 	"sync"
 
 	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
 )
 
 var _ State = &lockedState{}
 
+// GetValidatorOutput describes a validator of a subnet at a specific P-chain
+// height.
+type GetValidatorOutput struct {
+	NodeID ids.NodeID
+	// PublicKey is the BLS key registered by the validator on the primary
+	// network. It is nil if the validator didn't register a key.
+	PublicKey *bls.PublicKey
+	Weight    uint64
+}
+
 // State allows the lookup of validator sets on specified subnets at the
 // requested P-chain height.
 type State interface {
@@ -20,10 +31,13 @@ type State interface {
 	// GetCurrentHeight returns the current height of the P-chain.
 	GetCurrentHeight() (uint64, error)
 
-	// GetValidatorSet returns the weights of the nodeIDs for the provided
-	// subnet at the requested P-chain height.
+	// GetSubnetID returns the subnetID of the provided chain.
+	GetSubnetID(chainID ids.ID) (ids.ID, error)
+
+	// GetValidatorSet returns the validators of the provided subnet at the
+	// requested P-chain height.
 	// The returned map should not be modified.
-	GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]uint64, error)
+	GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]*GetValidatorOutput, error)
 }
 
 type lockedState struct {
@@ -52,7 +66,14 @@ func (s *lockedState) GetCurrentHeight() (uint64, error) {
 	return s.s.GetCurrentHeight()
 }
 
-func (s *lockedState) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]uint64, error) {
+func (s *lockedState) GetSubnetID(chainID ids.ID) (ids.ID, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.s.GetSubnetID(chainID)
+}
+
+func (s *lockedState) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]*GetValidatorOutput, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -69,6 +90,6 @@ func NewNoValidatorsState(state State) State {
 	}
 }
 
-func (*noValidators) GetValidatorSet(uint64, ids.ID) (map[ids.NodeID]uint64, error) {
+func (*noValidators) GetValidatorSet(uint64, ids.ID) (map[ids.NodeID]*GetValidatorOutput, error) {
 	return nil, nil
 }
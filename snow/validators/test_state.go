@@ -13,6 +13,7 @@ import (
 var (
 	errMinimumHeight   = errors.New("unexpectedly called GetMinimumHeight")
 	errCurrentHeight   = errors.New("unexpectedly called GetCurrentHeight")
+	errGetSubnetID     = errors.New("unexpectedly called GetSubnetID")
 	errGetValidatorSet = errors.New("unexpectedly called GetValidatorSet")
 )
 
@@ -23,11 +24,13 @@ type TestState struct {
 
 	CantGetMinimumHeight,
 	CantGetCurrentHeight,
+	CantGetSubnetID,
 	CantGetValidatorSet bool
 
 	GetMinimumHeightF func() (uint64, error)
 	GetCurrentHeightF func() (uint64, error)
-	GetValidatorSetF  func(height uint64, subnetID ids.ID) (map[ids.NodeID]uint64, error)
+	GetSubnetIDF      func(chainID ids.ID) (ids.ID, error)
+	GetValidatorSetF  func(height uint64, subnetID ids.ID) (map[ids.NodeID]*GetValidatorOutput, error)
 }
 
 func (vm *TestState) GetMinimumHeight() (uint64, error) {
@@ -50,7 +53,17 @@ func (vm *TestState) GetCurrentHeight() (uint64, error) {
 	return 0, errCurrentHeight
 }
 
-func (vm *TestState) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]uint64, error) {
+func (vm *TestState) GetSubnetID(chainID ids.ID) (ids.ID, error) {
+	if vm.GetSubnetIDF != nil {
+		return vm.GetSubnetIDF(chainID)
+	}
+	if vm.CantGetSubnetID && vm.T != nil {
+		vm.T.Fatal(errGetSubnetID)
+	}
+	return ids.Empty, errGetSubnetID
+}
+
+func (vm *TestState) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]*GetValidatorOutput, error) {
 	if vm.GetValidatorSetF != nil {
 		return vm.GetValidatorSetF(height, subnetID)
 	}
@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/utils"
+)
+
+func TestAggregation(t *testing.T) {
+	type test struct {
+		name                 string
+		setup                func(*require.Assertions) (pks []*PublicKey, sigs []*Signature, msg []byte)
+		expectedPubKeyAggErr error
+		expectedSigAggErr    error
+		expectedValid        bool
+	}
+
+	tests := []test{
+		{
+			name: "valid",
+			setup: func(require *require.Assertions) ([]*PublicKey, []*Signature, []byte) {
+				sk0, err := NewSecretKey()
+				require.NoError(err)
+				sk1, err := NewSecretKey()
+				require.NoError(err)
+				sk2, err := NewSecretKey()
+				require.NoError(err)
+
+				pks := []*PublicKey{
+					PublicFromSecretKey(sk0),
+					PublicFromSecretKey(sk1),
+					PublicFromSecretKey(sk2),
+				}
+
+				msg := utils.RandomBytes(1234)
+
+				sigs := []*Signature{
+					Sign(sk0, msg),
+					Sign(sk1, msg),
+					Sign(sk2, msg),
+				}
+
+				return pks, sigs, msg
+			},
+			expectedValid: true,
+		},
+		{
+			name: "wrong message",
+			setup: func(require *require.Assertions) ([]*PublicKey, []*Signature, []byte) {
+				sk0, err := NewSecretKey()
+				require.NoError(err)
+				sk1, err := NewSecretKey()
+				require.NoError(err)
+
+				pks := []*PublicKey{
+					PublicFromSecretKey(sk0),
+					PublicFromSecretKey(sk1),
+				}
+
+				msg := utils.RandomBytes(1234)
+				wrongMsg := utils.RandomBytes(1234)
+
+				sigs := []*Signature{
+					Sign(sk0, msg),
+					Sign(sk1, wrongMsg),
+				}
+
+				return pks, sigs, msg
+			},
+			expectedValid: false,
+		},
+		{
+			name: "missing signer",
+			setup: func(require *require.Assertions) ([]*PublicKey, []*Signature, []byte) {
+				sk0, err := NewSecretKey()
+				require.NoError(err)
+				sk1, err := NewSecretKey()
+				require.NoError(err)
+
+				pks := []*PublicKey{
+					PublicFromSecretKey(sk0),
+					PublicFromSecretKey(sk1),
+				}
+
+				msg := utils.RandomBytes(1234)
+
+				sigs := []*Signature{
+					Sign(sk0, msg),
+				}
+
+				return pks, sigs, msg
+			},
+			expectedValid: false,
+		},
+		{
+			name: "no public keys",
+			setup: func(require *require.Assertions) ([]*PublicKey, []*Signature, []byte) {
+				sk, err := NewSecretKey()
+				require.NoError(err)
+
+				msg := utils.RandomBytes(1234)
+				return nil, []*Signature{Sign(sk, msg)}, msg
+			},
+			expectedPubKeyAggErr: errNoPublicKeys,
+		},
+		{
+			name: "no signatures",
+			setup: func(require *require.Assertions) ([]*PublicKey, []*Signature, []byte) {
+				sk, err := NewSecretKey()
+				require.NoError(err)
+
+				return []*PublicKey{PublicFromSecretKey(sk)}, nil, utils.RandomBytes(1234)
+			},
+			expectedSigAggErr: errNoSignatures,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			pks, sigs, msg := tt.setup(require)
+
+			aggSig, err := AggregateSignatures(sigs)
+			require.ErrorIs(err, tt.expectedSigAggErr)
+
+			aggPK, err := AggregatePublicKeys(pks)
+			require.ErrorIs(err, tt.expectedPubKeyAggErr)
+
+			if tt.expectedSigAggErr != nil || tt.expectedPubKeyAggErr != nil {
+				return
+			}
+
+			valid := Verify(aggPK, aggSig, msg)
+			require.Equal(tt.expectedValid, valid)
+		})
+	}
+}
+
+func TestProofOfPossession(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := NewSecretKey()
+	require.NoError(err)
+
+	pk := PublicFromSecretKey(sk)
+	msg := PublicKeyToBytes(pk)
+
+	pop := SignProofOfPossession(sk, msg)
+	require.True(VerifyProofOfPossession(pk, pop, msg))
+
+	// Proofs of possession and signatures use different ciphersuites, so they
+	// must not be interchangeable.
+	require.False(Verify(pk, pop, msg))
+	require.False(VerifyProofOfPossession(pk, Sign(sk, msg), msg))
+}
+
+func TestSerialization(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := NewSecretKey()
+	require.NoError(err)
+
+	skBytes := SecretKeyToBytes(sk)
+	require.Len(skBytes, SecretKeyLen)
+	sk2, err := SecretKeyFromBytes(skBytes)
+	require.NoError(err)
+	require.Equal(skBytes, SecretKeyToBytes(sk2))
+
+	pk := PublicFromSecretKey(sk)
+	pkBytes := PublicKeyToBytes(pk)
+	require.Len(pkBytes, PublicKeyLen)
+	pk2, err := PublicKeyFromBytes(pkBytes)
+	require.NoError(err)
+	require.True(pk.Equals(pk2))
+
+	msg := utils.RandomBytes(1234)
+	sig := Sign(sk, msg)
+	sigBytes := SignatureToBytes(sig)
+	require.Len(sigBytes, SignatureLen)
+	sig2, err := SignatureFromBytes(sigBytes)
+	require.NoError(err)
+	require.True(sig.Equals(sig2))
+
+	_, err = PublicKeyFromBytes(utils.RandomBytes(PublicKeyLen))
+	require.Error(err)
+	_, err = SignatureFromBytes(utils.RandomBytes(SignatureLen))
+	require.Error(err)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bls
+
+import (
+	"errors"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+const PublicKeyLen = blst.BLST_P1_COMPRESS_BYTES
+
+var (
+	errFailedPublicKeyDecompress  = errors.New("couldn't decompress public key")
+	errInvalidPublicKey           = errors.New("invalid public key")
+	errNoPublicKeys               = errors.New("no public keys")
+	errFailedPublicKeyAggregation = errors.New("couldn't aggregate public keys")
+)
+
+type (
+	PublicKey          = blst.P1Affine
+	AggregatePublicKey = blst.P1Aggregate
+)
+
+// PublicKeyToBytes returns the compressed big-endian format of the public key.
+func PublicKeyToBytes(pk *PublicKey) []byte {
+	return pk.Compress()
+}
+
+// PublicKeyFromBytes parses the compressed big-endian format of the public key
+// into a public key. The public key is verified to be a valid, non-infinity
+// point in the correct subgroup.
+func PublicKeyFromBytes(pkBytes []byte) (*PublicKey, error) {
+	pk := new(PublicKey).Uncompress(pkBytes)
+	if pk == nil {
+		return nil, errFailedPublicKeyDecompress
+	}
+	if !pk.KeyValidate() {
+		return nil, errInvalidPublicKey
+	}
+	return pk, nil
+}
+
+// AggregatePublicKeys aggregates a non-zero number of public keys into a
+// single aggregated public key.
+// Invariant: all [pks] have been validated.
+func AggregatePublicKeys(pks []*PublicKey) (*PublicKey, error) {
+	if len(pks) == 0 {
+		return nil, errNoPublicKeys
+	}
+
+	var agg AggregatePublicKey
+	if !agg.Aggregate(pks, false) {
+		return nil, errFailedPublicKeyAggregation
+	}
+	return agg.ToAffine(), nil
+}
+
+// Verify the [sig] of [msg] against the [pk].
+// The [sig] and [pk] may have been an aggregation of other signatures and keys.
+// Invariant: [pk] and [sig] have both been validated.
+func Verify(pk *PublicKey, sig *Signature, msg []byte) bool {
+	return sig.Verify(false, pk, false, msg, ciphersuiteSignature)
+}
+
+// VerifyProofOfPossession verifies the possession of the secret pre-image of
+// [pk] by verifying a [sig] of [msg] against the [pk].
+// The [sig] and [pk] may have been an aggregation of other signatures and keys.
+// Invariant: [pk] and [sig] have both been validated.
+func VerifyProofOfPossession(pk *PublicKey, sig *Signature, msg []byte) bool {
+	return sig.Verify(false, pk, false, msg, ciphersuiteProofOfPossession)
+}
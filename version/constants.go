@@ -78,10 +78,34 @@ var (
 
 	// FIXME: update this before release
 	XChainMigrationTimes = map[uint32]time.Time{
-		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
-		constants.FujiID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.MainnetID:  time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:     time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.SavannahID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.MarulaID:   time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	// The X-chain of a network without a scheduled time must not be
+	// linearized, as its history was built on the DAG.
+	XChainMigrationDefaultTime = time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	BaobabTimes = map[uint32]time.Time{
+		constants.MainnetID:  time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:     time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.SavannahID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.MarulaID:   time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
 	}
-	XChainMigrationDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+	BaobabDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	DynamicFeesTimes = map[uint32]time.Time{
+		constants.MainnetID:  time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:     time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.SavannahID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.MarulaID:   time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	// Dynamic fees were never active on any network, so they must not apply
+	// retroactively to the history of a network without a scheduled time.
+	DynamicFeesDefaultTime = time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC)
 )
 
 func GetApricotPhase3Time(networkID uint32) time.Time {
@@ -126,11 +150,33 @@ func GetXChainMigrationTime(networkID uint32) time.Time {
 	return XChainMigrationDefaultTime
 }
 
-func GetCompatibility(networkID uint32) Compatibility {
+// IsXChainMigrationScheduled returns true if [migrationTime] schedules the
+// X-chain migration, rather than leaving it unscheduled.
+func IsXChainMigrationScheduled(migrationTime time.Time) bool {
+	return migrationTime.Before(XChainMigrationDefaultTime)
+}
+
+func GetBaobabTime(networkID uint32) time.Time {
+	if upgradeTime, exists := BaobabTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return BaobabDefaultTime
+}
+
+func GetDynamicFeesTime(networkID uint32) time.Time {
+	if upgradeTime, exists := DynamicFeesTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return DynamicFeesDefaultTime
+}
+
+// GetCompatibility returns the compatibility of this node's version with the
+// versions of its peers under the network upgrade schedule [upgrades]
+func GetCompatibility(upgrades *Upgrades) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
 		MinimumCompatibleVersion,
-		GetApricotPhase5Time(networkID),
+		upgrades.ApricotPhase5Time,
 		PrevMinimumCompatibleVersion,
 	)
 }
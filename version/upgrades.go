@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kukrer/savannahnode/utils/constants"
+)
+
+var (
+	errPublicNetworkUpgrades = errors.New("cannot override the network upgrades of a public network")
+	errUpgradesOutOfOrder    = errors.New("network upgrades are out of order")
+)
+
+// Upgrades is the schedule of the network upgrades of a network
+type Upgrades struct {
+	ApricotPhase3Time            time.Time `json:"apricotPhase3Time"`
+	ApricotPhase4Time            time.Time `json:"apricotPhase4Time"`
+	ApricotPhase4MinPChainHeight uint64    `json:"apricotPhase4MinPChainHeight"`
+	ApricotPhase5Time            time.Time `json:"apricotPhase5Time"`
+	BlueberryTime                time.Time `json:"blueberryTime"`
+	XChainMigrationTime          time.Time `json:"xChainMigrationTime"`
+	BaobabTime                   time.Time `json:"baobabTime"`
+	DynamicFeesTime              time.Time `json:"dynamicFeesTime"`
+}
+
+// GetUpgrades returns the default schedule of the network upgrades of
+// [networkID]
+func GetUpgrades(networkID uint32) *Upgrades {
+	return &Upgrades{
+		ApricotPhase3Time:            GetApricotPhase3Time(networkID),
+		ApricotPhase4Time:            GetApricotPhase4Time(networkID),
+		ApricotPhase4MinPChainHeight: GetApricotPhase4MinPChainHeight(networkID),
+		ApricotPhase5Time:            GetApricotPhase5Time(networkID),
+		BlueberryTime:                GetBlueberryTime(networkID),
+		XChainMigrationTime:          GetXChainMigrationTime(networkID),
+		BaobabTime:                   GetBaobabTime(networkID),
+		DynamicFeesTime:              GetDynamicFeesTime(networkID),
+	}
+}
+
+// ParseUpgrades returns the default schedule of the network upgrades of
+// [networkID], overridden by the upgrade times set in the JSON [upgradesBytes].
+// The schedules of the public networks can't be overridden.
+func ParseUpgrades(networkID uint32, upgradesBytes []byte) (*Upgrades, error) {
+	switch networkID {
+	case constants.MainnetID, constants.TestnetID, constants.SavannahID, constants.MarulaID:
+		return nil, fmt.Errorf("%w: %s (%d)",
+			errPublicNetworkUpgrades,
+			constants.NetworkName(networkID),
+			networkID,
+		)
+	}
+
+	upgrades := GetUpgrades(networkID)
+	if err := json.Unmarshal(upgradesBytes, upgrades); err != nil {
+		return nil, fmt.Errorf("failed to parse network upgrades: %w", err)
+	}
+	if err := upgrades.Verify(); err != nil {
+		return nil, err
+	}
+	return upgrades, nil
+}
+
+// Verify returns an error if an upgrade is scheduled before an upgrade that it
+// depends on
+func (u *Upgrades) Verify() error {
+	ordered := []struct {
+		name string
+		time time.Time
+	}{
+		{name: "apricotPhase3Time", time: u.ApricotPhase3Time},
+		{name: "apricotPhase4Time", time: u.ApricotPhase4Time},
+		{name: "apricotPhase5Time", time: u.ApricotPhase5Time},
+		{name: "blueberryTime", time: u.BlueberryTime},
+		{name: "xChainMigrationTime", time: u.XChainMigrationTime},
+	}
+	for i := 1; i < len(ordered); i++ {
+		prev, next := ordered[i-1], ordered[i]
+		if next.time.Before(prev.time) {
+			return fmt.Errorf("%w: %s (%s) is before %s (%s)",
+				errUpgradesOutOfOrder, next.name, next.time, prev.name, prev.time)
+		}
+	}
+	if u.BaobabTime.Before(u.BlueberryTime) {
+		return fmt.Errorf("%w: baobabTime (%s) is before blueberryTime (%s)",
+			errUpgradesOutOfOrder, u.BaobabTime, u.BlueberryTime)
+	}
+	if u.DynamicFeesTime.Before(u.BlueberryTime) {
+		return fmt.Errorf("%w: dynamicFeesTime (%s) is before blueberryTime (%s)",
+			errUpgradesOutOfOrder, u.DynamicFeesTime, u.BlueberryTime)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/utils/constants"
+)
+
+func TestDefaultUpgradesAreValid(t *testing.T) {
+	for _, networkID := range []uint32{
+		constants.MainnetID,
+		constants.TestnetID,
+		constants.LocalID,
+		constants.SavannahID,
+		constants.MarulaID,
+		12345,
+	} {
+		require.NoError(t, GetUpgrades(networkID).Verify(), networkID)
+	}
+}
+
+func TestDynamicFeesAreNotRetroactive(t *testing.T) {
+	for _, networkID := range []uint32{
+		constants.MainnetID,
+		constants.TestnetID,
+		constants.SavannahID,
+		constants.MarulaID,
+		12345,
+	} {
+		require.True(t, GetDynamicFeesTime(networkID).After(time.Now()), networkID)
+	}
+}
+
+func TestXChainMigrationIsNotScheduledByDefault(t *testing.T) {
+	require := require.New(t)
+
+	for _, networkID := range []uint32{
+		constants.MainnetID,
+		constants.TestnetID,
+		constants.LocalID,
+		constants.SavannahID,
+		constants.MarulaID,
+		12345,
+	} {
+		require.False(IsXChainMigrationScheduled(GetXChainMigrationTime(networkID)), networkID)
+	}
+
+	upgrades, err := ParseUpgrades(constants.LocalID, []byte(`{"xChainMigrationTime":"2022-11-01T00:00:00Z"}`))
+	require.NoError(err)
+	require.True(IsXChainMigrationScheduled(upgrades.XChainMigrationTime))
+}
+
+func TestParseUpgrades(t *testing.T) {
+	tests := []struct {
+		name        string
+		networkID   uint32
+		upgrades    string
+		expectedErr error
+		verify      func(*require.Assertions, *Upgrades)
+	}{
+		{
+			name:        "public network",
+			networkID:   constants.MainnetID,
+			upgrades:    `{}`,
+			expectedErr: errPublicNetworkUpgrades,
+		},
+		{
+			name:        "savannah",
+			networkID:   constants.SavannahID,
+			upgrades:    `{}`,
+			expectedErr: errPublicNetworkUpgrades,
+		},
+		{
+			name:        "marula",
+			networkID:   constants.MarulaID,
+			upgrades:    `{}`,
+			expectedErr: errPublicNetworkUpgrades,
+		},
+		{
+			name:      "overrides",
+			networkID: constants.LocalID,
+			upgrades:  `{"blueberryTime":"2022-10-01T00:00:00Z","xChainMigrationTime":"2022-11-01T00:00:00Z","baobabTime":"2022-11-01T00:00:00Z","dynamicFeesTime":"2022-11-01T00:00:00Z"}`,
+			verify: func(require *require.Assertions, upgrades *Upgrades) {
+				require.Equal(time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), upgrades.BlueberryTime)
+				require.Equal(time.Date(2022, time.November, 1, 0, 0, 0, 0, time.UTC), upgrades.XChainMigrationTime)
+				require.Equal(time.Date(2022, time.November, 1, 0, 0, 0, 0, time.UTC), upgrades.BaobabTime)
+				// Upgrades that aren't overridden keep their default times
+				require.Equal(GetApricotPhase5Time(constants.LocalID), upgrades.ApricotPhase5Time)
+			},
+		},
+		{
+			name:        "x-chain migration before blueberry",
+			networkID:   constants.LocalID,
+			upgrades:    `{"blueberryTime":"2022-10-01T00:00:00Z","xChainMigrationTime":"2022-09-01T00:00:00Z","baobabTime":"2022-10-01T00:00:00Z","dynamicFeesTime":"2022-11-01T00:00:00Z"}`,
+			expectedErr: errUpgradesOutOfOrder,
+		},
+		{
+			name:        "baobab before blueberry",
+			networkID:   constants.LocalID,
+			upgrades:    `{"blueberryTime":"2022-10-01T00:00:00Z","xChainMigrationTime":"2022-10-01T00:00:00Z","baobabTime":"2022-09-01T00:00:00Z","dynamicFeesTime":"2022-11-01T00:00:00Z"}`,
+			expectedErr: errUpgradesOutOfOrder,
+		},
+		{
+			name:        "dynamic fees before blueberry",
+			networkID:   constants.LocalID,
+			upgrades:    `{"blueberryTime":"2022-10-01T00:00:00Z","xChainMigrationTime":"2022-10-01T00:00:00Z","baobabTime":"2022-10-01T00:00:00Z","dynamicFeesTime":"2022-09-01T00:00:00Z"}`,
+			expectedErr: errUpgradesOutOfOrder,
+		},
+		{
+			name:        "out of order",
+			networkID:   constants.LocalID,
+			upgrades:    `{"apricotPhase5Time":"2020-01-01T00:00:00Z"}`,
+			expectedErr: errUpgradesOutOfOrder,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			upgrades, err := ParseUpgrades(test.networkID, []byte(test.upgrades))
+			require.ErrorIs(err, test.expectedErr)
+			if test.verify != nil {
+				test.verify(require, upgrades)
+			}
+		})
+	}
+}
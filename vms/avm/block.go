@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kukrer/savannahnode/chains/atomic"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/snow/consensus/snowman"
+	"github.com/kukrer/savannahnode/vms/avm/blocks"
+)
+
+// maxFutureBlockTime is how far ahead of the local time the timestamp of a
+// block can be
+const maxFutureBlockTime = 10 * time.Second
+
+var (
+	errNoTxs                 = errors.New("block has no transactions")
+	errUnknownParent         = errors.New("parent block is neither processing nor the last accepted block")
+	errIncorrectHeight       = errors.New("block height isn't the height of its parent plus one")
+	errTimestampBeforeParent = errors.New("block timestamp is before the timestamp of its parent")
+	errFutureTimestamp       = errors.New("block timestamp is too far in the future")
+	errTxDecided             = errors.New("transaction was already decided")
+	errDuplicateTx           = errors.New("transaction is already included in the chain")
+	errMissingDependency     = errors.New("transaction depends on a transaction that isn't accepted nor included in the chain")
+	errConflictingTx         = errors.New("transaction consumes an input consumed earlier in the chain")
+
+	_ snowman.Block = &Block{}
+)
+
+// Block is a block of the X-chain once it is linearized onto Snowman
+// consensus
+type Block struct {
+	*blocks.StandardBlock
+
+	vm     *VM
+	status choices.Status
+
+	// txs are the transactions of the block, set once the block is verified
+	txs []*UniqueTx
+}
+
+func (b *Block) Status() choices.Status { return b.status }
+
+func (b *Block) Verify() error {
+	if len(b.Txs()) == 0 {
+		return errNoTxs
+	}
+
+	blkID := b.ID()
+	parent, err := b.vm.getBlock(b.Parent())
+	if err != nil {
+		return fmt.Errorf("%w: %s", errUnknownParent, err)
+	}
+	if expectedHeight := parent.Height() + 1; b.Height() != expectedHeight {
+		return fmt.Errorf("%w: expected %d but got %d", errIncorrectHeight, expectedHeight, b.Height())
+	}
+	timestamp := b.Timestamp()
+	if timestamp.Before(parent.Timestamp()) {
+		return errTimestampBeforeParent
+	}
+	if maxTimestamp := b.vm.clock.Time().Add(maxFutureBlockTime); timestamp.After(maxTimestamp) {
+		return fmt.Errorf("%w: %s is after %s", errFutureTimestamp, timestamp, maxTimestamp)
+	}
+
+	ancestry, err := b.vm.processingAncestry(b.Parent())
+	if err != nil {
+		return err
+	}
+
+	txs := make([]*UniqueTx, len(b.Txs()))
+	for i, rawTx := range b.Txs() {
+		tx, err := b.vm.parseTx(rawTx.Bytes())
+		if err != nil {
+			return fmt.Errorf("couldn't parse tx %d of block %s: %w", i, blkID, err)
+		}
+		if err := b.vm.verifyBlockTx(tx, ancestry, timestamp); err != nil {
+			return fmt.Errorf("tx %s of block %s is invalid: %w", tx.ID(), blkID, err)
+		}
+		txs[i] = tx
+	}
+
+	b.txs = txs
+	b.vm.verifiedBlocks[blkID] = b
+	return nil
+}
+
+func (b *Block) Accept() error {
+	blkID := b.ID()
+	b.vm.ctx.Log.Debug("accepting block",
+		zap.Stringer("blkID", blkID),
+		zap.Uint64("height", b.Height()),
+	)
+
+	defer b.vm.db.Abort()
+
+	// The txs of the block and the block itself are committed atomically
+	requests := make(map[ids.ID]*atomic.Requests)
+	for _, tx := range b.txs {
+		if err := tx.accept(requests); err != nil {
+			return fmt.Errorf("couldn't accept tx %s of block %s: %w", tx.ID(), blkID, err)
+		}
+	}
+	if err := b.vm.state.PutBlock(b.StandardBlock); err != nil {
+		return fmt.Errorf("couldn't put block %s: %w", blkID, err)
+	}
+	if err := b.vm.state.SetLastAccepted(blkID); err != nil {
+		return fmt.Errorf("couldn't set last accepted block to %s: %w", blkID, err)
+	}
+	if err := b.vm.commitWithAtomicRequests(requests); err != nil {
+		return fmt.Errorf("couldn't commit block %s: %w", blkID, err)
+	}
+
+	b.vm.mempool.Remove(txsOf(b.txs))
+	for _, tx := range b.txs {
+		tx.onAccepted()
+	}
+
+	b.status = choices.Accepted
+	b.vm.lastAcceptedID = blkID
+	delete(b.vm.verifiedBlocks, blkID)
+	b.txs = nil
+
+	if b.vm.mempool.Len() > 0 {
+		b.vm.notifyBlockReady()
+	}
+	return b.vm.loadBurnedFees()
+}
+
+// Reject returns the transactions of the block to the mempool, as they may
+// still be included in another block.
+func (b *Block) Reject() error {
+	blkID := b.ID()
+	b.vm.ctx.Log.Debug("rejecting block",
+		zap.Stringer("blkID", blkID),
+		zap.Uint64("height", b.Height()),
+	)
+
+	for _, tx := range b.txs {
+		if tx.Status() != choices.Processing {
+			continue
+		}
+		if err := b.vm.mempool.Add(tx.Tx); err != nil {
+			b.vm.ctx.Log.Debug("failed to return tx to the mempool",
+				zap.Stringer("txID", tx.ID()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	b.status = choices.Rejected
+	delete(b.vm.verifiedBlocks, blkID)
+	b.txs = nil
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package blocks
+
+import (
+	"github.com/kukrer/savannahnode/codec"
+	"github.com/kukrer/savannahnode/codec/linearcodec"
+)
+
+const CodecVersion = 0
+
+// Codec serializes the blocks of the linearized X-chain. The transactions of a
+// block are serialized as their signed bytes, so that the block codec doesn't
+// depend on the fxs the transactions were built with.
+var Codec codec.Manager
+
+func init() {
+	c := linearcodec.NewDefault()
+	Codec = codec.NewDefaultManager()
+	if err := Codec.RegisterCodec(CodecVersion, c); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package blocks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/hashing"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+)
+
+// StandardBlock is a block of the X-chain once it is linearized onto Snowman
+// consensus. The first block of the chain has no transactions, is at height 0
+// and its parent is the stop vertex of the DAG.
+type StandardBlock struct {
+	PrntID ids.ID `serialize:"true" json:"parentID"`
+	Hght   uint64 `serialize:"true" json:"height"`
+	Time   uint64 `serialize:"true" json:"time"`
+	// TxsBytes are the signed bytes of the transactions of this block
+	TxsBytes [][]byte `serialize:"true" json:"txs"`
+
+	id    ids.ID
+	bytes []byte
+	txs   []*txs.Tx
+}
+
+func NewStandardBlock(
+	parentID ids.ID,
+	height uint64,
+	timestamp time.Time,
+	transactions []*txs.Tx,
+) (*StandardBlock, error) {
+	blk := &StandardBlock{
+		PrntID:   parentID,
+		Hght:     height,
+		Time:     uint64(timestamp.Unix()),
+		TxsBytes: make([][]byte, len(transactions)),
+		txs:      transactions,
+	}
+	for i, tx := range transactions {
+		blk.TxsBytes[i] = tx.Bytes()
+	}
+
+	bytes, err := Codec.Marshal(CodecVersion, blk)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal block: %w", err)
+	}
+	blk.initialize(bytes)
+	return blk, nil
+}
+
+// Parse parses the block [b], whose transactions are parsed by [parser]
+func Parse(parser txs.Parser, b []byte) (*StandardBlock, error) {
+	blk := &StandardBlock{}
+	parsedVersion, err := Codec.Unmarshal(b, blk)
+	if err != nil {
+		return nil, err
+	}
+	if parsedVersion != CodecVersion {
+		return nil, fmt.Errorf("expected codec version %d but got %d", CodecVersion, parsedVersion)
+	}
+
+	blk.txs = make([]*txs.Tx, len(blk.TxsBytes))
+	for i, txBytes := range blk.TxsBytes {
+		tx, err := parser.Parse(txBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse tx %d of the block: %w", i, err)
+		}
+		blk.txs[i] = tx
+	}
+	blk.initialize(b)
+	return blk, nil
+}
+
+func (b *StandardBlock) initialize(bytes []byte) {
+	b.id = hashing.ComputeHash256Array(bytes)
+	b.bytes = bytes
+}
+
+func (b *StandardBlock) ID() ids.ID           { return b.id }
+func (b *StandardBlock) Parent() ids.ID       { return b.PrntID }
+func (b *StandardBlock) Height() uint64       { return b.Hght }
+func (b *StandardBlock) Timestamp() time.Time { return time.Unix(int64(b.Time), 0) }
+func (b *StandardBlock) Txs() []*txs.Tx       { return b.txs }
+func (b *StandardBlock) Bytes() []byte        { return b.bytes }
@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package blocks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/vms/avm/fxs"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestStandardBlock(t *testing.T) {
+	require := require.New(t)
+
+	parser, err := txs.NewParser([]fxs.Fx{&secp256k1fx.Fx{}})
+	require.NoError(err)
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: ids.GenerateTestID(),
+		Memo:         []byte{1, 2, 3},
+	}}}
+	require.NoError(parser.InitializeTx(tx))
+
+	parentID := ids.GenerateTestID()
+	timestamp := time.Unix(1_000, 0)
+	blk, err := NewStandardBlock(parentID, 5, timestamp, []*txs.Tx{tx})
+	require.NoError(err)
+
+	parsed, err := Parse(parser, blk.Bytes())
+	require.NoError(err)
+	require.Equal(blk.ID(), parsed.ID())
+	require.Equal(parentID, parsed.Parent())
+	require.EqualValues(5, parsed.Height())
+	require.Equal(timestamp, parsed.Timestamp())
+	require.Len(parsed.Txs(), 1)
+	require.Equal(tx.ID(), parsed.Txs()[0].ID())
+
+	// The first block of the chain has no transactions
+	genesis, err := NewStandardBlock(parentID, 0, timestamp, nil)
+	require.NoError(err)
+	parsed, err = Parse(parser, genesis.Bytes())
+	require.NoError(err)
+	require.Equal(genesis.ID(), parsed.ID())
+	require.Empty(parsed.Txs())
+	require.NotEqual(blk.ID(), genesis.ID())
+}
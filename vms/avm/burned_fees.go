@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"sync/atomic"
+
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/components/burn"
+
+	safemath "github.com/kukrer/savannahnode/utils/math"
+)
+
+var _ burn.Reporter = &VM{}
+
+// BurnedFees returns the AVAX burned as fees by the accepted txs, as of the
+// last commit. It's safe to call without holding the context lock.
+func (vm *VM) BurnedFees() uint64 {
+	return atomic.LoadUint64(&vm.burnedFees)
+}
+
+// loadBurnedFees refreshes the burned fees reported by BurnedFees from the
+// state. It must be called after the acceptance of txs is committed.
+func (vm *VM) loadBurnedFees() error {
+	burnedFees, err := vm.state.GetBurnedFees()
+	if err != nil {
+		return err
+	}
+	atomic.StoreUint64(&vm.burnedFees, burnedFees)
+	return nil
+}
+
+// addBurnedFees adds the AVAX burned by [tx] to the state
+func (vm *VM) addBurnedFees(tx txs.UnsignedTx) error {
+	burned, err := txs.Burned(tx, vm.ctx.AVAXAssetID)
+	if err != nil || burned == 0 {
+		return err
+	}
+	burnedFees, err := vm.state.GetBurnedFees()
+	if err != nil {
+		return err
+	}
+	burnedFees, err = safemath.Add64(burnedFees, burned)
+	if err != nil {
+		return err
+	}
+	return vm.state.SetBurnedFees(burnedFees)
+}
@@ -0,0 +1,347 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/snow/consensus/snowman"
+	"github.com/kukrer/savannahnode/snow/engine/avalanche/vertex"
+	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/snow/engine/snowman/block"
+	"github.com/kukrer/savannahnode/utils/units"
+	"github.com/kukrer/savannahnode/vms/avm/blocks"
+	"github.com/kukrer/savannahnode/vms/avm/states"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+)
+
+// targetBlockSize is the maximum size of the transactions of a built block
+const targetBlockSize = 128 * units.KiB
+
+var (
+	errNotLinearized = errors.New("chain isn't linearized")
+	errNoPendingTxs  = errors.New("no pending transactions")
+
+	_ vertex.LinearizableVM      = &VM{}
+	_ block.HeightIndexedChainVM = &VM{}
+)
+
+/*
+ ******************************************************************************
+ ******************************** Snowman API *********************************
+ ******************************************************************************
+ */
+
+// Linearize moves the chain onto Snowman consensus. The first block of the
+// linear chain is built on top of the stop vertex [stopVertexID] when the
+// chain is first linearized. The transactions that were issued but not yet
+// sent to the DAG remain in the mempool to be included in blocks.
+func (vm *VM) Linearize(stopVertexID ids.ID, toEngine chan<- common.Message) error {
+	lastAcceptedID, err := vm.state.GetLastAccepted()
+	if err == database.ErrNotFound {
+		genesis, err := blocks.NewStandardBlock(stopVertexID, 0, vm.XChainMigrationTime, nil)
+		if err != nil {
+			return err
+		}
+		lastAcceptedID = genesis.ID()
+		if err := vm.state.PutBlock(genesis); err != nil {
+			return err
+		}
+		if err := vm.state.SetLastAccepted(lastAcceptedID); err != nil {
+			return err
+		}
+		if err := vm.db.Commit(); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return fmt.Errorf("couldn't get last accepted block: %w", err)
+	}
+
+	vm.ctx.Log.Info("linearized the chain",
+		zap.Stringer("stopVertexID", stopVertexID),
+		zap.Stringer("lastAcceptedID", lastAcceptedID),
+	)
+
+	vm.linearized = true
+	vm.toEngine = toEngine
+	vm.lastAcceptedID = lastAcceptedID
+	vm.preferred = lastAcceptedID
+	vm.verifiedBlocks = make(map[ids.ID]*Block)
+	vm.timer.Cancel()
+	vm.stopVertexTimer.Cancel()
+	if vm.mempool.Len() > 0 {
+		vm.notifyBlockReady()
+	}
+	return nil
+}
+
+func (vm *VM) GetBlock(blkID ids.ID) (snowman.Block, error) {
+	return vm.getBlock(blkID)
+}
+
+func (vm *VM) ParseBlock(b []byte) (snowman.Block, error) {
+	statelessBlk, err := blocks.Parse(vm.parser, b)
+	if err != nil {
+		return nil, err
+	}
+
+	blkID := statelessBlk.ID()
+	if blk, err := vm.getBlock(blkID); err == nil {
+		return blk, nil
+	}
+	return &Block{
+		StandardBlock: statelessBlk,
+		vm:            vm,
+		status:        choices.Processing,
+	}, nil
+}
+
+// BuildBlock builds a block on top of the preferred block out of the
+// transactions of the mempool. Transactions that are no longer valid are
+// dropped from the mempool and rejected.
+func (vm *VM) BuildBlock() (snowman.Block, error) {
+	if !vm.linearized {
+		return nil, errNotLinearized
+	}
+
+	parent, err := vm.getBlock(vm.preferred)
+	if err != nil {
+		return nil, err
+	}
+	ancestry, err := vm.processingAncestry(vm.preferred)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := vm.clock.Time()
+	if parentTimestamp := parent.Timestamp(); timestamp.Before(parentTimestamp) {
+		timestamp = parentTimestamp
+	}
+
+	var (
+		blkTxs     []*UniqueTx
+		size       int
+		droppedTxs []*txs.Tx
+	)
+	for _, mempoolTx := range vm.mempool.List() {
+		txSize := len(mempoolTx.Bytes())
+		if size+txSize > targetBlockSize {
+			continue
+		}
+
+		tx := vm.uniqueTx(mempoolTx)
+		err := vm.verifyBlockTx(tx, ancestry, timestamp)
+		switch {
+		case err == nil:
+			blkTxs = append(blkTxs, tx)
+			size += txSize
+		case errors.Is(err, errDuplicateTx),
+			errors.Is(err, errMissingDependency),
+			errors.Is(err, errConflictingTx):
+			// The transaction may become valid once the processing blocks
+			// are decided.
+		default:
+			txID := tx.ID()
+			vm.ctx.Log.Debug("dropping invalid tx from the mempool",
+				zap.Stringer("txID", txID),
+				zap.Error(err),
+			)
+			droppedTxs = append(droppedTxs, mempoolTx)
+			vm.mempool.MarkDropped(txID, err.Error())
+			if tx.Status() == choices.Processing {
+				if err := tx.Reject(); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	vm.mempool.Remove(droppedTxs)
+	blkRawTxs := txsOf(blkTxs)
+	vm.mempool.Remove(blkRawTxs)
+	if len(blkTxs) == 0 {
+		return nil, errNoPendingTxs
+	}
+
+	statelessBlk, err := blocks.NewStandardBlock(
+		parent.ID(),
+		parent.Height()+1,
+		timestamp,
+		blkRawTxs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if vm.mempool.Len() > 0 {
+		vm.notifyBlockReady()
+	}
+	return &Block{
+		StandardBlock: statelessBlk,
+		vm:            vm,
+		status:        choices.Processing,
+	}, nil
+}
+
+func (vm *VM) SetPreference(blkID ids.ID) error {
+	vm.preferred = blkID
+	return nil
+}
+
+func (vm *VM) LastAccepted() (ids.ID, error) {
+	if !vm.linearized {
+		return ids.Empty, errNotLinearized
+	}
+	return vm.lastAcceptedID, nil
+}
+
+func (vm *VM) VerifyHeightIndex() error {
+	if !vm.linearized {
+		return block.ErrIndexIncomplete
+	}
+	return nil
+}
+
+func (vm *VM) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	return vm.state.GetBlockIDAtHeight(height)
+}
+
+/*
+ ******************************************************************************
+ ********************************** Helpers ***********************************
+ ******************************************************************************
+ */
+
+// getBlock returns the processing block or the accepted block [blkID]
+func (vm *VM) getBlock(blkID ids.ID) (*Block, error) {
+	if blk, ok := vm.verifiedBlocks[blkID]; ok {
+		return blk, nil
+	}
+	statelessBlk, err := vm.state.GetBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+	return &Block{
+		StandardBlock: statelessBlk,
+		vm:            vm,
+		status:        choices.Accepted,
+	}, nil
+}
+
+// blockAncestry is the state of the chain built by the processing blocks on top of
+// the last accepted block
+type blockAncestry struct {
+	// consumed are the inputs consumed by the processing blocks
+	consumed ids.Set
+	// included are the transactions included by the processing blocks
+	included ids.Set
+	// freezes are the accepted freezes along with the freezes applied by the
+	// processing blocks
+	freezes states.FreezeState
+}
+
+// processingAncestry returns the state of the chain built by the processing
+// blocks from [blkID] down to the last accepted block.
+func (vm *VM) processingAncestry(blkID ids.ID) (*blockAncestry, error) {
+	var processingBlks []*Block
+	for blkID != vm.lastAcceptedID {
+		blk, ok := vm.verifiedBlocks[blkID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errUnknownParent, blkID)
+		}
+		processingBlks = append(processingBlks, blk)
+		blkID = blk.Parent()
+	}
+
+	a := &blockAncestry{
+		consumed: ids.NewSet(0),
+		included: ids.NewSet(0),
+		freezes:  states.NewFreezeDiff(vm.state),
+	}
+	// The freezes are applied from the oldest block to the newest one
+	for i := len(processingBlks) - 1; i >= 0; i-- {
+		for _, tx := range processingBlks[i].txs {
+			a.consumed.Add(tx.InputIDs()...)
+			a.included.Add(tx.ID())
+			if err := applyFreezes(tx.Tx, a.freezes); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return a, nil
+}
+
+// verifyBlockTx verifies that [tx] can be appended, in a block with the
+// timestamp [timestamp], to the chain built by [ancestry]. If [tx] is valid,
+// it is added to [ancestry].
+//
+// The semantic verification of the transaction is always performed again, as
+// the state the transaction was verified against when it was issued may have
+// changed.
+func (vm *VM) verifyBlockTx(tx *UniqueTx, ancestry *blockAncestry, timestamp time.Time) error {
+	txID := tx.ID()
+	if status := tx.Status(); status != choices.Processing {
+		return fmt.Errorf("%w: %s", errTxDecided, status)
+	}
+	if ancestry.included.Contains(txID) {
+		return errDuplicateTx
+	}
+
+	deps, err := tx.Dependencies()
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		depID := dep.ID()
+		if dep.Status() != choices.Accepted && !ancestry.included.Contains(depID) {
+			return fmt.Errorf("%w: %s", errMissingDependency, depID)
+		}
+	}
+
+	inputIDs := tx.InputIDs()
+	for _, inputID := range inputIDs {
+		if ancestry.consumed.Contains(inputID) {
+			return fmt.Errorf("%w: %s", errConflictingTx, inputID)
+		}
+	}
+
+	if err := tx.SyntacticVerify(); err != nil {
+		return err
+	}
+	if err := tx.Unsigned.Visit(&txSemanticVerify{
+		tx:        tx.Tx,
+		vm:        vm,
+		timestamp: timestamp,
+		freezes:   ancestry.freezes,
+	}); err != nil {
+		return err
+	}
+
+	ancestry.consumed.Add(inputIDs...)
+	ancestry.included.Add(txID)
+	return applyFreezes(tx.Tx, ancestry.freezes)
+}
+
+// notifyBlockReady notifies the engine that a block can be built
+func (vm *VM) notifyBlockReady() {
+	select {
+	case vm.toEngine <- common.PendingTxs:
+	default:
+		vm.ctx.Log.Debug("dropping message to engine due to contention")
+	}
+}
+
+func txsOf(uniqueTxs []*UniqueTx) []*txs.Tx {
+	rawTxs := make([]*txs.Tx, len(uniqueTxs))
+	for i, tx := range uniqueTxs {
+		rawTxs[i] = tx.Tx
+	}
+	return rawTxs
+}
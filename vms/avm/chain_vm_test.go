@@ -0,0 +1,247 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/snow/engine/snowman/block"
+	"github.com/kukrer/savannahnode/version"
+	"github.com/kukrer/savannahnode/vms/avm/blocks"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/avm/txs/mempool"
+)
+
+func linearizeTestVM(t *testing.T, vm *VM) (ids.ID, chan common.Message) {
+	require := require.New(t)
+
+	vm.XChainMigrationTime = time.Unix(1607133600, 0)
+	stopVertexID := ids.GenerateTestID()
+	toEngine := make(chan common.Message, 1)
+	require.NoError(vm.Linearize(stopVertexID, toEngine))
+	return stopVertexID, toEngine
+}
+
+func TestStopVertexIssuedAtMigrationTime(t *testing.T) {
+	require := require.New(t)
+
+	_, issuer, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	// The stop vertex isn't issued if the migration isn't scheduled, nor
+	// before the migration time
+	for _, test := range []struct {
+		migrationTime time.Time
+		now           time.Time
+	}{
+		{
+			migrationTime: version.XChainMigrationDefaultTime,
+			now:           version.XChainMigrationDefaultTime.Add(time.Hour),
+		},
+		{
+			migrationTime: time.Now().Add(time.Hour),
+			now:           time.Now(),
+		},
+	} {
+		vm.XChainMigrationTime = test.migrationTime
+		vm.clock.Set(test.now)
+		require.NoError(vm.SetState(snow.Bootstrapping))
+		require.NoError(vm.SetState(snow.NormalOp))
+		ctx.Lock.Unlock()
+		select {
+		case msg := <-issuer:
+			require.FailNow("unexpected message", msg)
+		case <-time.After(100 * time.Millisecond):
+		}
+		ctx.Lock.Lock()
+	}
+	vm.clock.Sync()
+
+	// The stop vertex is issued once the migration time is reached
+	vm.XChainMigrationTime = vm.clock.Time()
+	require.NoError(vm.SetState(snow.Bootstrapping))
+	require.NoError(vm.SetState(snow.NormalOp))
+	ctx.Lock.Unlock()
+	select {
+	case msg := <-issuer:
+		require.Equal(common.StopVertex, msg)
+	case <-time.After(10 * time.Second):
+		require.FailNow("stop vertex wasn't issued")
+	}
+	ctx.Lock.Lock()
+
+	// The stop vertex can't be issued once the chain is linearized
+	linearizeTestVM(t, vm)
+	require.ErrorIs(vm.issueStopVertex(), errAlreadyLinearized)
+}
+
+func TestLinearizeBuildAndAcceptBlock(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	_, err := vm.LastAccepted()
+	require.ErrorIs(err, errNotLinearized)
+	require.ErrorIs(vm.VerifyHeightIndex(), block.ErrIndexIncomplete)
+
+	stopVertexID, toEngine := linearizeTestVM(t, vm)
+	require.NoError(vm.VerifyHeightIndex())
+
+	// The first block of the linear chain is built on top of the stop vertex
+	genesisID, err := vm.LastAccepted()
+	require.NoError(err)
+	genesis, err := vm.GetBlock(genesisID)
+	require.NoError(err)
+	require.Equal(stopVertexID, genesis.Parent())
+	require.EqualValues(0, genesis.Height())
+	require.Equal(vm.XChainMigrationTime, genesis.Timestamp())
+	require.Equal(choices.Accepted, genesis.Status())
+	genesisIDAtHeight, err := vm.GetBlockIDAtHeight(0)
+	require.NoError(err)
+	require.Equal(genesisID, genesisIDAtHeight)
+
+	_, err = vm.BuildBlock()
+	require.ErrorIs(err, errNoPendingTxs)
+
+	newTx := NewTx(t, genesisBytes, vm)
+	txID, err := vm.IssueTx(newTx.Bytes())
+	require.NoError(err)
+	require.Equal(common.PendingTxs, <-toEngine)
+
+	blk, err := vm.BuildBlock()
+	require.NoError(err)
+	require.Equal(genesisID, blk.Parent())
+	require.EqualValues(1, blk.Height())
+	require.Equal(choices.Processing, blk.Status())
+
+	parsedBlk, err := vm.ParseBlock(blk.Bytes())
+	require.NoError(err)
+	require.Equal(blk.ID(), parsedBlk.ID())
+
+	require.NoError(parsedBlk.Verify())
+	require.NoError(vm.SetPreference(parsedBlk.ID()))
+	require.NoError(parsedBlk.Accept())
+	require.Equal(choices.Accepted, parsedBlk.Status())
+
+	lastAcceptedID, err := vm.LastAccepted()
+	require.NoError(err)
+	require.Equal(blk.ID(), lastAcceptedID)
+	blkIDAtHeight, err := vm.GetBlockIDAtHeight(1)
+	require.NoError(err)
+	require.Equal(blk.ID(), blkIDAtHeight)
+
+	tx, err := vm.GetTx(txID)
+	require.NoError(err)
+	require.Equal(choices.Accepted, tx.Status())
+
+	// The accepted block is returned rather than parsed again
+	parsedBlk, err = vm.ParseBlock(blk.Bytes())
+	require.NoError(err)
+	require.Equal(choices.Accepted, parsedBlk.Status())
+
+	_, err = vm.BuildBlock()
+	require.ErrorIs(err, errNoPendingTxs)
+}
+
+func TestLinearizedConflictingTxs(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, ctx, issueTxs := setupIssueTx(t)
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	_, toEngine := linearizeTestVM(t, vm)
+
+	firstTx := issueTxs[1]
+	secondTx := issueTxs[2]
+	_, err := vm.IssueTx(firstTx.Bytes())
+	require.NoError(err)
+	require.Equal(common.PendingTxs, <-toEngine)
+	// The mempool refuses txs conflicting with the txs it contains
+	_, err = vm.IssueTx(secondTx.Bytes())
+	require.ErrorIs(err, mempool.ErrConflictsWithOtherTx)
+
+	blk, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.Len(blk.(*Block).Txs(), 1)
+	require.Equal(firstTx.ID(), blk.(*Block).Txs()[0].ID())
+	require.NoError(vm.SetPreference(blk.ID()))
+
+	// Once the first tx is in a block, the second tx can enter the mempool
+	_, err = vm.IssueTx(secondTx.Bytes())
+	require.NoError(err)
+	_, err = vm.BuildBlock()
+	require.ErrorIs(err, errNoPendingTxs)
+
+	// A child block can't consume the inputs consumed by its ancestry
+	conflictingBlk, err := blocks.NewStandardBlock(
+		blk.ID(),
+		blk.Height()+1,
+		blk.Timestamp(),
+		[]*txs.Tx{secondTx},
+	)
+	require.NoError(err)
+	childBlk, err := vm.ParseBlock(conflictingBlk.Bytes())
+	require.NoError(err)
+	require.ErrorIs(childBlk.Verify(), errConflictingTx)
+
+	// Once the first tx is accepted, the second tx is dropped from the mempool
+	require.NoError(blk.Accept())
+	_, err = vm.BuildBlock()
+	require.ErrorIs(err, errNoPendingTxs)
+
+	tx, err := vm.GetTx(secondTx.ID())
+	require.Error(err)
+	require.Equal(choices.Rejected, tx.Status())
+}
+
+func TestLinearizedRejectedBlockReturnsTxs(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	_, toEngine := linearizeTestVM(t, vm)
+
+	newTx := NewTx(t, genesisBytes, vm)
+	_, err := vm.IssueTx(newTx.Bytes())
+	require.NoError(err)
+	require.Equal(common.PendingTxs, <-toEngine)
+
+	blk, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.NoError(blk.Reject())
+	require.Equal(choices.Rejected, blk.Status())
+
+	// The tx of the rejected block can be included in another block
+	blk, err = vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.Len(blk.(*Block).Txs(), 1)
+	require.Equal(newTx.ID(), blk.(*Block).Txs()[0].ID())
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kukrer/savannahnode/snow"
 	"github.com/kukrer/savannahnode/vms"
+	"github.com/kukrer/savannahnode/vms/components/burn"
 )
 
 var _ vms.Factory = &Factory{}
@@ -18,6 +19,19 @@ type Factory struct {
 
 	// Time of the Blueberry network upgrade
 	BlueberryTime time.Time
+
+	// Time the stop vertex is issued at to linearize the chain onto Snowman
+	// consensus, which is also the timestamp of the first block of the chain.
+	// The stop vertex isn't issued automatically if the migration isn't
+	// scheduled.
+	XChainMigrationTime time.Time
+
+	// Time of the Baobab network upgrade
+	BaobabTime time.Time
+
+	// BurnRegistry, if non-nil, is where the chain reports the AVAX its txs
+	// burned as fees
+	BurnRegistry burn.Registry
 }
 
 func (f *Factory) New(*snow.Context) (interface{}, error) {
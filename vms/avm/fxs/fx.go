@@ -8,7 +8,9 @@ import (
 	"github.com/kukrer/savannahnode/snow"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
@@ -17,6 +19,12 @@ var (
 	_ Fx = &secp256k1fx.Fx{}
 	_ Fx = &nftfx.Fx{}
 	_ Fx = &propertyfx.Fx{}
+	_ Fx = &htlcfx.Fx{}
+	_ Fx = &policyfx.Fx{}
+
+	_ AlignedFx   = &htlcfx.Fx{}
+	_ AlignedFx   = &policyfx.Fx{}
+	_ ExtensionFx = &htlcfx.Fx{}
 )
 
 type ParsedFx struct {
@@ -49,6 +57,24 @@ type Fx interface {
 	VerifyOperation(tx, op, cred interface{}, utxos []interface{}) error
 }
 
+// AlignedFx is an Fx whose types must be registered at fixed type IDs, so that
+// they are serialized identically by the codecs of the other chains.
+type AlignedFx interface {
+	Fx
+
+	// FirstTypeID returns the type ID of the first type registered by this
+	// feature extension.
+	FirstTypeID() uint32
+}
+
+// ExtensionFx is an Fx that extends the secp256k1fx. It can be used with any
+// asset that supports the secp256k1fx.
+type ExtensionFx interface {
+	Fx
+
+	ExtendsSECP256K1Fx()
+}
+
 type FxOperation interface {
 	verify.Verifiable
 	snow.ContextInitializable
@@ -517,7 +517,7 @@ func buildTX(utxoID avax.UTXOID, txAssetID avax.Asset, address ...ids.ShortID) *
 }
 
 func setupTestVM(t *testing.T, ctx *snow.Context, baseDBManager manager.Manager, genesisBytes []byte, issuer chan common.Message, config Config) *VM {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	avmConfigBytes, err := json.Marshal(config)
 	require.NoError(t, err)
 	appSender := &common.SenderTest{T: t}
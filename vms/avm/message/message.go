@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"errors"
+
+	"github.com/kukrer/savannahnode/ids"
+)
+
+var (
+	_ Message = &Tx{}
+	_ Message = &TxRequest{}
+	_ Message = &TxResponse{}
+
+	errUnexpectedCodecVersion = errors.New("unexpected codec version")
+)
+
+type Message interface {
+	// initialize should be called whenever a message is built or parsed
+	initialize([]byte)
+
+	// Bytes returns the binary representation of this message
+	//
+	// Bytes should only be called after being initialized
+	Bytes() []byte
+}
+
+type message []byte
+
+func (m *message) initialize(bytes []byte) { *m = bytes }
+func (m *message) Bytes() []byte           { return *m }
+
+// Tx gossips the transaction [Tx] to the network
+type Tx struct {
+	message
+
+	Tx []byte `serialize:"true"`
+}
+
+// TxRequest requests the processing transaction [TxID] that a gossiped
+// transaction depends on.
+type TxRequest struct {
+	message
+
+	TxID ids.ID `serialize:"true"`
+}
+
+// TxResponse answers a TxRequest. [Tx] is empty if the responder doesn't know
+// the requested transaction.
+type TxResponse struct {
+	message
+
+	Tx []byte `serialize:"true"`
+}
+
+func Parse(bytes []byte) (Message, error) {
+	var msg Message
+	version, err := c.Unmarshal(bytes, &msg)
+	if err != nil {
+		return nil, err
+	}
+	if version != codecVersion {
+		return nil, errUnexpectedCodecVersion
+	}
+	msg.initialize(bytes)
+	return msg, nil
+}
+
+func Build(msg Message) ([]byte, error) {
+	bytes, err := c.Marshal(codecVersion, &msg)
+	msg.initialize(bytes)
+	return bytes, err
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils"
+	"github.com/kukrer/savannahnode/utils/units"
+)
+
+func TestTx(t *testing.T) {
+	require := require.New(t)
+
+	tx := utils.RandomBytes(256 * units.KiB)
+	builtMsg := Tx{
+		Tx: tx,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	parsedMsg, ok := parsedMsgIntf.(*Tx)
+	require.True(ok)
+
+	require.Equal(tx, parsedMsg.Tx)
+}
+
+func TestTxRequest(t *testing.T) {
+	require := require.New(t)
+
+	txID := ids.GenerateTestID()
+	builtMsg := TxRequest{
+		TxID: txID,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	parsedMsg, ok := parsedMsgIntf.(*TxRequest)
+	require.True(ok)
+
+	require.Equal(txID, parsedMsg.TxID)
+}
+
+func TestTxResponse(t *testing.T) {
+	require := require.New(t)
+
+	tx := utils.RandomBytes(32)
+	builtMsg := TxResponse{
+		Tx: tx,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	parsedMsg, ok := parsedMsgIntf.(*TxResponse)
+	require.True(ok)
+
+	require.Equal(tx, parsedMsg.Tx)
+}
+
+func TestParseGibberish(t *testing.T) {
+	require := require.New(t)
+
+	randomBytes := utils.RandomBytes(256 * units.KiB)
+	_, err := Parse(randomBytes)
+	require.Error(err)
+}
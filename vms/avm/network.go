@@ -0,0 +1,312 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/vms/avm/message"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+)
+
+const (
+	// We allow [recentCacheSize] to be fairly large because we only store hashes
+	// in the cache, not entire transactions.
+	recentCacheSize = 512
+
+	// orphanCacheSize is the maximum number of gossiped txs kept while the
+	// txs they depend on are requested
+	orphanCacheSize = 64
+)
+
+var _ Network = &network{}
+
+type Network interface {
+	common.AppHandler
+
+	// GossipTx gossips the transaction to some of the connected peers
+	GossipTx(tx *txs.Tx) error
+}
+
+type network struct {
+	vm *VM
+
+	// gossip related attributes
+	appSender common.AppSender
+	recentTxs *cache.LRU
+
+	// pull related attributes
+	requestID uint32
+	// Key: ID of a request
+	// Value: ID of the requested tx
+	requests map[uint32]ids.ID
+	// IDs of the txs currently requested
+	requestedTxIDs ids.Set
+	// Key: ID of a missing tx
+	// Value: gossiped *txs.Tx that consumes an output of the missing tx
+	orphans *cache.LRU
+}
+
+func newNetwork(vm *VM, appSender common.AppSender) *network {
+	return &network{
+		vm:        vm,
+		appSender: appSender,
+		recentTxs: &cache.LRU{Size: recentCacheSize},
+		requests:  make(map[uint32]ids.ID),
+		orphans:   &cache.LRU{Size: orphanCacheSize},
+	}
+}
+
+// AppRequest serves the processing transactions that a peer is missing to
+// verify a transaction gossiped by this node.
+func (n *network) AppRequest(nodeID ids.NodeID, requestID uint32, deadline time.Time, msgBytes []byte) error {
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		n.vm.ctx.Log.Debug("dropping AppRequest message",
+			zap.String("reason", "failed to parse message"),
+		)
+		return nil
+	}
+
+	msg, ok := msgIntf.(*message.TxRequest)
+	if !ok {
+		n.vm.ctx.Log.Debug("dropping unexpected message",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+
+	n.vm.ctx.Lock.Lock()
+	defer n.vm.ctx.Lock.Unlock()
+
+	response := &message.TxResponse{}
+	if tx := n.vm.getProcessingTx(msg.TxID); tx != nil {
+		response.Tx = tx.Bytes()
+	}
+	responseBytes, err := message.Build(response)
+	if err != nil {
+		return fmt.Errorf("AppRequest: failed to build TxResponse message: %w", err)
+	}
+	return n.appSender.SendAppResponse(nodeID, requestID, responseBytes)
+}
+
+func (n *network) AppRequestFailed(nodeID ids.NodeID, requestID uint32) error {
+	n.vm.ctx.Lock.Lock()
+	defer n.vm.ctx.Lock.Unlock()
+
+	txID, ok := n.requests[requestID]
+	if !ok {
+		return nil
+	}
+	n.removeRequest(requestID, txID)
+	n.orphans.Evict(txID)
+	return nil
+}
+
+func (n *network) AppResponse(nodeID ids.NodeID, requestID uint32, msgBytes []byte) error {
+	n.vm.ctx.Lock.Lock()
+	defer n.vm.ctx.Lock.Unlock()
+
+	txID, ok := n.requests[requestID]
+	if !ok {
+		n.vm.ctx.Log.Debug("dropping unexpected AppResponse message",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	n.removeRequest(requestID, txID)
+
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		n.vm.ctx.Log.Debug("dropping AppResponse message",
+			zap.String("reason", "failed to parse message"),
+		)
+		n.orphans.Evict(txID)
+		return nil
+	}
+
+	msg, ok := msgIntf.(*message.TxResponse)
+	if !ok || len(msg.Tx) == 0 {
+		n.vm.ctx.Log.Debug("peer didn't provide the requested tx",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("txID", txID),
+		)
+		n.orphans.Evict(txID)
+		return nil
+	}
+
+	tx, err := n.vm.parser.Parse(msg.Tx)
+	if err != nil || tx.ID() != txID {
+		n.vm.ctx.Log.Debug("peer provided an invalid tx",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("txID", txID),
+		)
+		n.orphans.Evict(txID)
+		return nil
+	}
+
+	n.issueTx(nodeID, tx)
+	return nil
+}
+
+func (n *network) AppGossip(nodeID ids.NodeID, msgBytes []byte) error {
+	n.vm.ctx.Log.Debug("called AppGossip message handler",
+		zap.Stringer("nodeID", nodeID),
+		zap.Int("messageLen", len(msgBytes)),
+	)
+
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		n.vm.ctx.Log.Debug("dropping AppGossip message",
+			zap.String("reason", "failed to parse message"),
+		)
+		return nil
+	}
+
+	msg, ok := msgIntf.(*message.Tx)
+	if !ok {
+		n.vm.ctx.Log.Debug("dropping unexpected message",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+
+	tx, err := n.vm.parser.Parse(msg.Tx)
+	if err != nil {
+		n.vm.ctx.Log.Verbo("received invalid tx",
+			zap.Stringer("nodeID", nodeID),
+			zap.Binary("tx", msg.Tx),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	// We need to grab the context lock here to avoid racy behavior with
+	// transaction verification + mempool modifications.
+	n.vm.ctx.Lock.Lock()
+	defer n.vm.ctx.Lock.Unlock()
+
+	if !n.vm.bootstrapped {
+		return nil
+	}
+	n.issueTx(nodeID, tx)
+	return nil
+}
+
+func (n *network) GossipTx(tx *txs.Tx) error {
+	txID := tx.ID()
+	// Don't gossip a transaction if it has been recently gossiped.
+	if _, has := n.recentTxs.Get(txID); has {
+		return nil
+	}
+	n.recentTxs.Put(txID, nil)
+
+	n.vm.ctx.Log.Debug("gossiping tx",
+		zap.Stringer("txID", txID),
+	)
+
+	msg := &message.Tx{Tx: tx.Bytes()}
+	msgBytes, err := message.Build(msg)
+	if err != nil {
+		return fmt.Errorf("GossipTx: failed to build Tx message: %w", err)
+	}
+	return n.appSender.SendAppGossip(msgBytes)
+}
+
+// issueTx adds the tx [tx] learned from [nodeID] to the mempool. If [tx]
+// consumes an output of a tx this node doesn't know about, the missing tx is
+// requested from [nodeID].
+//
+// Assumes the context lock is held.
+func (n *network) issueTx(nodeID ids.NodeID, tx *txs.Tx) {
+	txID := tx.ID()
+	if _, dropped := n.vm.mempool.GetDropReason(txID); dropped {
+		// If the tx is being dropped - just ignore it
+		return
+	}
+
+	err := n.vm.issueTx(tx)
+	if err == nil {
+		// The orphan waiting for this tx may now be valid
+		if orphan, ok := n.orphans.Get(txID); ok {
+			n.orphans.Evict(txID)
+			n.issueTx(nodeID, orphan.(*txs.Tx))
+		}
+		return
+	}
+
+	missingTxID, missing := n.vm.missingDependency(tx)
+	if !missing {
+		n.vm.ctx.Log.Debug("tx failed verification",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("txID", txID),
+			zap.Error(err),
+		)
+		n.vm.mempool.MarkDropped(txID, err.Error())
+		return
+	}
+
+	n.orphans.Put(missingTxID, tx)
+	if n.requestedTxIDs.Contains(missingTxID) {
+		return
+	}
+	if err := n.requestTx(nodeID, missingTxID); err != nil {
+		n.vm.ctx.Log.Debug("failed to request tx",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("txID", missingTxID),
+			zap.Error(err),
+		)
+	}
+}
+
+// requestTx requests the tx [txID] from [nodeID]
+func (n *network) requestTx(nodeID ids.NodeID, txID ids.ID) error {
+	msgBytes, err := message.Build(&message.TxRequest{TxID: txID})
+	if err != nil {
+		return fmt.Errorf("requestTx: failed to build TxRequest message: %w", err)
+	}
+
+	n.vm.ctx.Log.Debug("requesting missing tx",
+		zap.Stringer("nodeID", nodeID),
+		zap.Stringer("txID", txID),
+	)
+
+	requestID := n.requestID
+	n.requestID++
+	n.requests[requestID] = txID
+	n.requestedTxIDs.Add(txID)
+
+	nodeIDs := ids.NewNodeIDSet(1)
+	nodeIDs.Add(nodeID)
+	return n.appSender.SendAppRequest(nodeIDs, requestID, msgBytes)
+}
+
+func (n *network) removeRequest(requestID uint32, txID ids.ID) {
+	delete(n.requests, requestID)
+	n.requestedTxIDs.Remove(txID)
+}
+
+// getProcessingTx returns the tx [txID] if it is in the mempool or processing
+// in consensus. Returns nil otherwise.
+func (vm *VM) getProcessingTx(txID ids.ID) *txs.Tx {
+	if tx := vm.mempool.Get(txID); tx != nil {
+		return tx
+	}
+	tx := &UniqueTx{
+		vm:   vm,
+		txID: txID,
+	}
+	if tx.Status() != choices.Processing {
+		return nil
+	}
+	return tx.Tx
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/avm/message"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+// newChildTx returns a tx consuming the first output of [parentTx]
+func newChildTx(t *testing.T, vm *VM, parentTx *txs.Tx) *txs.Tx {
+	out := parentTx.Unsigned.(*txs.BaseTx).Outs[0]
+	childTx := &txs.Tx{Unsigned: &txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{
+					TxID:        parentTx.ID(),
+					OutputIndex: 0,
+				},
+				Asset: out.Asset,
+				In: &secp256k1fx.TransferInput{
+					Amt: out.Out.Amount(),
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}},
+		},
+	}}
+	require.NoError(t, childTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+	return childTx
+}
+
+func TestNetworkAppGossip(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	gossiped := 0
+	sender := &common.SenderTest{T: t}
+	sender.SendAppGossipF = func(b []byte) error {
+		gossiped++
+		return nil
+	}
+	vm.network = newNetwork(vm, sender)
+
+	tx := NewTx(t, genesisBytes, vm)
+	msgBytes, err := message.Build(&message.Tx{Tx: tx.Bytes()})
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	ctx.Lock.Unlock()
+	require.NoError(vm.AppGossip(nodeID, msgBytes))
+	ctx.Lock.Lock()
+
+	// The valid tx is added to the mempool and gossiped to the other peers
+	require.True(vm.mempool.Has(tx.ID()))
+	require.Equal(1, gossiped)
+
+	// A recently gossiped tx isn't gossiped again
+	ctx.Lock.Unlock()
+	require.NoError(vm.AppGossip(nodeID, msgBytes))
+	ctx.Lock.Lock()
+	require.Equal(1, gossiped)
+
+	// Malformed messages are dropped
+	ctx.Lock.Unlock()
+	require.NoError(vm.AppGossip(nodeID, []byte{1, 2, 3}))
+	ctx.Lock.Lock()
+	require.Equal(1, vm.mempool.Len())
+}
+
+func TestNetworkAppRequestFailed(t *testing.T) {
+	require := require.New(t)
+
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	sender := &common.SenderTest{T: t}
+	vm.network = newNetwork(vm, sender)
+
+	// The tx consumes an output of a tx this node doesn't know about
+	missingTxID := ids.GenerateTestID()
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{
+					TxID:        missingTxID,
+					OutputIndex: 0,
+				},
+				Asset: avax.Asset{ID: vm.feeAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt: startBalance,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}},
+		},
+	}}
+	require.NoError(tx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+	msgBytes, err := message.Build(&message.Tx{Tx: tx.Bytes()})
+	require.NoError(err)
+
+	requested := false
+	sender.SendAppRequestF = func(ids.NodeIDSet, uint32, []byte) error {
+		requested = true
+		return nil
+	}
+
+	ctx.Lock.Unlock()
+	require.NoError(vm.AppGossip(ids.GenerateTestNodeID(), msgBytes))
+	ctx.Lock.Lock()
+
+	// The unknown tx is requested
+	require.True(requested)
+	require.False(vm.mempool.Has(tx.ID()))
+
+	// Once the unknown tx can't be provided, the orphan is dropped
+	ctx.Lock.Unlock()
+	require.NoError(vm.AppRequestFailed(ids.GenerateTestNodeID(), 0))
+	ctx.Lock.Lock()
+	_, ok := vm.network.(*network).orphans.Get(missingTxID)
+	require.False(ok)
+	require.Empty(vm.network.(*network).requests)
+}
+
+func TestNetworkRequestMissingTx(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, ctx, issueTxs := setupIssueTx(t)
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	var (
+		requestedNodeIDs ids.NodeIDSet
+		requestID        uint32
+		requestBytes     []byte
+	)
+	sender := &common.SenderTest{T: t}
+	sender.SendAppGossipF = func([]byte) error { return nil }
+	sender.SendAppRequestF = func(nodeIDs ids.NodeIDSet, reqID uint32, b []byte) error {
+		requestedNodeIDs = nodeIDs
+		requestID = reqID
+		requestBytes = b
+		return nil
+	}
+	vm.network = newNetwork(vm, sender)
+
+	parentTx := issueTxs[1]
+	childTx := newChildTx(t, vm, parentTx)
+	msgBytes, err := message.Build(&message.Tx{Tx: childTx.Bytes()})
+	require.NoError(err)
+
+	// The child tx can't be verified without its parent, which is requested
+	// from the peer that gossiped the child tx
+	nodeID := ids.GenerateTestNodeID()
+	ctx.Lock.Unlock()
+	require.NoError(vm.AppGossip(nodeID, msgBytes))
+	ctx.Lock.Lock()
+	require.False(vm.mempool.Has(childTx.ID()))
+	require.True(requestedNodeIDs.Contains(nodeID))
+
+	requestIntf, err := message.Parse(requestBytes)
+	require.NoError(err)
+	request, ok := requestIntf.(*message.TxRequest)
+	require.True(ok)
+	require.Equal(parentTx.ID(), request.TxID)
+
+	// Once the parent tx is provided, both txs are added to the mempool
+	responseBytes, err := message.Build(&message.TxResponse{Tx: parentTx.Bytes()})
+	require.NoError(err)
+	ctx.Lock.Unlock()
+	require.NoError(vm.AppResponse(nodeID, requestID, responseBytes))
+	ctx.Lock.Lock()
+	require.True(vm.mempool.Has(parentTx.ID()))
+	require.True(vm.mempool.Has(childTx.ID()))
+	require.Empty(vm.network.(*network).requests)
+}
+
+func TestNetworkAppRequest(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	var responseBytes []byte
+	sender := &common.SenderTest{T: t}
+	sender.SendAppGossipF = func([]byte) error { return nil }
+	sender.SendAppResponseF = func(_ ids.NodeID, _ uint32, b []byte) error {
+		responseBytes = b
+		return nil
+	}
+	vm.network = newNetwork(vm, sender)
+
+	tx := NewTx(t, genesisBytes, vm)
+	_, err := vm.IssueTx(tx.Bytes())
+	require.NoError(err)
+
+	tests := []struct {
+		name       string
+		txID       ids.ID
+		expectedTx []byte
+	}{
+		{
+			name:       "processing tx",
+			txID:       tx.ID(),
+			expectedTx: tx.Bytes(),
+		},
+		{
+			name:       "unknown tx",
+			txID:       ids.GenerateTestID(),
+			expectedTx: []byte{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requestBytes, err := message.Build(&message.TxRequest{TxID: test.txID})
+			require.NoError(err)
+
+			ctx.Lock.Unlock()
+			err = vm.AppRequest(ids.GenerateTestNodeID(), 0, time.Time{}, requestBytes)
+			ctx.Lock.Lock()
+			require.NoError(err)
+
+			responseIntf, err := message.Parse(responseBytes)
+			require.NoError(err)
+			response, ok := responseIntf.(*message.TxResponse)
+			require.True(ok)
+			require.Equal(test.expectedTx, response.Tx)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/kukrer/savannahnode/vms/components/keystore"
 	"github.com/kukrer/savannahnode/vms/components/verify"
 	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 
 	safemath "github.com/kukrer/savannahnode/utils/math"
@@ -317,12 +318,21 @@ type GetAssetDescriptionArgs struct {
 	AssetID string `json:"assetID"`
 }
 
+// AssetPolicy describes the freeze authority of an asset
+type AssetPolicy struct {
+	FreezeAuthority []string    `json:"freezeAuthority"`
+	Threshold       json.Uint32 `json:"threshold"`
+	Locktime        json.Uint64 `json:"locktime"`
+	Clawback        bool        `json:"clawback"`
+}
+
 // GetAssetDescriptionReply defines the GetAssetDescription replies returned from the API
 type GetAssetDescriptionReply struct {
 	FormattedAssetID
-	Name         string     `json:"name"`
-	Symbol       string     `json:"symbol"`
-	Denomination json.Uint8 `json:"denomination"`
+	Name         string       `json:"name"`
+	Symbol       string       `json:"symbol"`
+	Denomination json.Uint8   `json:"denomination"`
+	Policy       *AssetPolicy `json:"policy,omitempty"`
 }
 
 // GetAssetDescription creates an empty account with the name passed in
@@ -353,6 +363,30 @@ func (service *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescr
 	reply.Symbol = createAssetTx.Symbol
 	reply.Denomination = json.Uint8(createAssetTx.Denomination)
 
+	// The policy of an asset is re-created unchanged by every operation that
+	// consumes it, so the policy of the creation tx is still in effect.
+	for _, state := range createAssetTx.States {
+		for _, out := range state.Outs {
+			policy, ok := out.(*policyfx.PolicyOutput)
+			if !ok {
+				continue
+			}
+
+			authority := make([]string, len(policy.Addrs))
+			for i, addr := range policy.Addrs {
+				authority[i], err = service.vm.FormatLocalAddress(addr)
+				if err != nil {
+					return fmt.Errorf("problem formatting address: %w", err)
+				}
+			}
+			reply.Policy = &AssetPolicy{
+				FreezeAuthority: authority,
+				Threshold:       json.Uint32(policy.Threshold),
+				Locktime:        json.Uint64(policy.Locktime),
+				Clawback:        policy.Clawback,
+			}
+		}
+	}
 	return nil
 }
 
@@ -782,7 +782,7 @@ func TestServiceGetTxJSON_ExportTx(t *testing.T) {
 }
 
 func TestServiceGetTxJSON_CreateAssetTx(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -872,7 +872,7 @@ func TestServiceGetTxJSON_CreateAssetTx(t *testing.T) {
 }
 
 func TestServiceGetTxJSON_OperationTxWithNftxMintOp(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -977,7 +977,7 @@ func TestServiceGetTxJSON_OperationTxWithNftxMintOp(t *testing.T) {
 }
 
 func TestServiceGetTxJSON_OperationTxWithMultipleNftxMintOp(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -1084,7 +1084,7 @@ func TestServiceGetTxJSON_OperationTxWithMultipleNftxMintOp(t *testing.T) {
 }
 
 func TestServiceGetTxJSON_OperationTxWithSecpMintOp(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -1191,7 +1191,7 @@ func TestServiceGetTxJSON_OperationTxWithSecpMintOp(t *testing.T) {
 }
 
 func TestServiceGetTxJSON_OperationTxWithMultipleSecpMintOp(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -1299,7 +1299,7 @@ func TestServiceGetTxJSON_OperationTxWithMultipleSecpMintOp(t *testing.T) {
 }
 
 func TestServiceGetTxJSON_OperationTxWithPropertyFxMintOp(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -1404,7 +1404,7 @@ func TestServiceGetTxJSON_OperationTxWithPropertyFxMintOp(t *testing.T) {
 }
 
 func TestServiceGetTxJSON_OperationTxWithPropertyFxMintOpMultiple(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -2554,7 +2554,7 @@ func TestSend(t *testing.T) {
 		t.Fatalf("expected change address to be %s but got %s", changeAddrStr, reply.ChangeAddr)
 	}
 
-	pendingTxs := vm.txs
+	pendingTxs := vm.mempool.List()
 	if len(pendingTxs) != 1 {
 		t.Fatalf("Expected to find 1 pending tx after send, but found %d", len(pendingTxs))
 	}
@@ -2618,7 +2618,7 @@ func TestSendMultiple(t *testing.T) {
 				t.Fatalf("expected change address to be %s but got %s", changeAddrStr, reply.ChangeAddr)
 			}
 
-			pendingTxs := vm.txs
+			pendingTxs := vm.mempool.List()
 			if len(pendingTxs) != 1 {
 				t.Fatalf("Expected to find 1 pending tx after send, but found %d", len(pendingTxs))
 			}
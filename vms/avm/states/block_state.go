@@ -0,0 +1,121 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/cache/metercacher"
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/prefixdb"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/vms/avm/blocks"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+)
+
+const blockCacheSize = 2048
+
+var (
+	blockIDPrefix   = []byte("blockID")
+	heightPrefix    = []byte("height")
+	lastAcceptedKey = []byte("last accepted")
+
+	_ BlockState = &blockState{}
+)
+
+// BlockState persists the accepted blocks of the X-chain once it is
+// linearized, indexed by their height.
+type BlockState interface {
+	// GetBlock attempts to load an accepted block from storage.
+	GetBlock(blkID ids.ID) (*blocks.StandardBlock, error)
+
+	// PutBlock saves the provided accepted block to storage and indexes it by
+	// its height.
+	PutBlock(blk *blocks.StandardBlock) error
+
+	// GetBlockIDAtHeight returns the ID of the block accepted at [height].
+	GetBlockIDAtHeight(height uint64) (ids.ID, error)
+
+	// GetLastAccepted returns the ID of the last accepted block.
+	// database.ErrNotFound is returned if the chain isn't linearized.
+	GetLastAccepted() (ids.ID, error)
+
+	// SetLastAccepted saves the ID of the last accepted block.
+	SetLastAccepted(blkID ids.ID) error
+}
+
+type blockState struct {
+	parser txs.Parser
+
+	// Caches blkID -> *blocks.StandardBlock. If the block is nil, that means
+	// the block is not in storage.
+	blockCache cache.Cacher
+	blockDB    database.Database
+	heightDB   database.Database
+	db         database.Database
+}
+
+func NewBlockState(db database.Database, parser txs.Parser, metrics prometheus.Registerer) (BlockState, error) {
+	cache, err := metercacher.New(
+		"block_cache",
+		metrics,
+		&cache.LRU{Size: blockCacheSize},
+	)
+	return &blockState{
+		parser: parser,
+
+		blockCache: cache,
+		blockDB:    prefixdb.New(blockIDPrefix, db),
+		heightDB:   prefixdb.New(heightPrefix, db),
+		db:         db,
+	}, err
+}
+
+func (s *blockState) GetBlock(blkID ids.ID) (*blocks.StandardBlock, error) {
+	if blkIntf, found := s.blockCache.Get(blkID); found {
+		if blkIntf == nil {
+			return nil, database.ErrNotFound
+		}
+		return blkIntf.(*blocks.StandardBlock), nil
+	}
+
+	blkBytes, err := s.blockDB.Get(blkID[:])
+	if err == database.ErrNotFound {
+		s.blockCache.Put(blkID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := blocks.Parse(s.parser, blkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	s.blockCache.Put(blkID, blk)
+	return blk, nil
+}
+
+func (s *blockState) PutBlock(blk *blocks.StandardBlock) error {
+	blkID := blk.ID()
+	s.blockCache.Put(blkID, blk)
+	if err := s.blockDB.Put(blkID[:], blk.Bytes()); err != nil {
+		return err
+	}
+	return database.PutID(s.heightDB, database.PackUInt64(blk.Height()), blkID)
+}
+
+func (s *blockState) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	return database.GetID(s.heightDB, database.PackUInt64(height))
+}
+
+func (s *blockState) GetLastAccepted() (ids.ID, error) {
+	return database.GetID(s.db, lastAcceptedKey)
+}
+
+func (s *blockState) SetLastAccepted(blkID ids.ID) error {
+	return database.PutID(s.db, lastAcceptedKey, blkID)
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/memdb"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/vms/avm/blocks"
+	"github.com/kukrer/savannahnode/vms/avm/fxs"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestBlockState(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	parser, err := txs.NewParser([]fxs.Fx{&secp256k1fx.Fx{}})
+	require.NoError(err)
+
+	s, err := NewBlockState(db, parser, prometheus.NewRegistry())
+	require.NoError(err)
+
+	_, err = s.GetLastAccepted()
+	require.Equal(database.ErrNotFound, err)
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+	}}}
+	require.NoError(parser.InitializeTx(tx))
+
+	genesis, err := blocks.NewStandardBlock(ids.GenerateTestID(), 0, time.Unix(0, 0), nil)
+	require.NoError(err)
+	blk, err := blocks.NewStandardBlock(genesis.ID(), 1, time.Unix(1, 0), []*txs.Tx{tx})
+	require.NoError(err)
+
+	_, err = s.GetBlock(blk.ID())
+	require.Equal(database.ErrNotFound, err)
+
+	for _, b := range []*blocks.StandardBlock{genesis, blk} {
+		require.NoError(s.PutBlock(b))
+		require.NoError(s.SetLastAccepted(b.ID()))
+	}
+
+	// Load the blocks from the database rather than the cache
+	s, err = NewBlockState(db, parser, prometheus.NewRegistry())
+	require.NoError(err)
+
+	lastAccepted, err := s.GetLastAccepted()
+	require.NoError(err)
+	require.Equal(blk.ID(), lastAccepted)
+
+	for height, b := range []*blocks.StandardBlock{genesis, blk} {
+		blkID, err := s.GetBlockIDAtHeight(uint64(height))
+		require.NoError(err)
+		require.Equal(b.ID(), blkID)
+
+		loaded, err := s.GetBlock(blkID)
+		require.NoError(err)
+		require.Equal(b.Bytes(), loaded.Bytes())
+	}
+	loaded, err := s.GetBlock(blk.ID())
+	require.NoError(err)
+	require.Equal(tx.ID(), loaded.Txs()[0].ID())
+
+	_, err = s.GetBlockIDAtHeight(2)
+	require.Equal(database.ErrNotFound, err)
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+)
+
+var (
+	burnedFeesKey = []byte("burned fees")
+
+	_ FeeState = &feeState{}
+)
+
+// FeeState persists the AVAX burned as fees by the accepted transactions.
+type FeeState interface {
+	// GetBurnedFees returns the AVAX burned by the accepted transactions. If
+	// the database predates the tracking of the burned fees, the accepted
+	// transactions in storage are indexed first.
+	GetBurnedFees() (uint64, error)
+
+	// SetBurnedFees sets the AVAX burned by the accepted transactions.
+	SetBurnedFees(burnedFees uint64) error
+}
+
+type feeState struct {
+	avaxAssetID ids.ID
+	parser      txs.Parser
+
+	db       database.Database
+	txDB     database.Database
+	statuses avax.StatusState
+}
+
+func NewFeeState(
+	db database.Database,
+	txDB database.Database,
+	statuses avax.StatusState,
+	parser txs.Parser,
+	avaxAssetID ids.ID,
+) FeeState {
+	return &feeState{
+		avaxAssetID: avaxAssetID,
+		parser:      parser,
+		db:          db,
+		txDB:        txDB,
+		statuses:    statuses,
+	}
+}
+
+func (s *feeState) GetBurnedFees() (uint64, error) {
+	burnedFees, err := database.GetUInt64(s.db, burnedFeesKey)
+	if err != database.ErrNotFound {
+		return burnedFees, err
+	}
+
+	burnedFees, err = s.indexBurnedFees()
+	if err != nil {
+		return 0, err
+	}
+	return burnedFees, s.SetBurnedFees(burnedFees)
+}
+
+func (s *feeState) SetBurnedFees(burnedFees uint64) error {
+	return database.PutUInt64(s.db, burnedFeesKey, burnedFees)
+}
+
+// indexBurnedFees sums the AVAX burned by the accepted transactions in storage
+func (s *feeState) indexBurnedFees() (uint64, error) {
+	txIt := s.txDB.NewIterator()
+	defer txIt.Release()
+
+	burnedFees := uint64(0)
+	for txIt.Next() {
+		txID, err := ids.ToID(txIt.Key())
+		if err != nil {
+			return 0, err
+		}
+		status, err := s.statuses.GetStatus(txID)
+		if err != nil {
+			return 0, err
+		}
+		if status != choices.Accepted {
+			continue
+		}
+
+		tx, err := s.parser.ParseGenesis(txIt.Value())
+		if err != nil {
+			return 0, err
+		}
+		burned, err := txs.Burned(tx.Unsigned, s.avaxAssetID)
+		if err != nil {
+			return 0, err
+		}
+		burnedFees, err = math.Add64(burnedFees, burned)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return burnedFees, txIt.Error()
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database/memdb"
+	"github.com/kukrer/savannahnode/database/prefixdb"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/avm/fxs"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestFeeStateIndexesAcceptedTxs(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+	})
+	require.NoError(err)
+
+	txDB := prefixdb.New(txPrefix, db)
+	statuses := avax.NewStatusState(prefixdb.New(statusPrefix, db))
+	txState, err := NewTxState(txDB, parser, prometheus.NewRegistry())
+	require.NoError(err)
+
+	// Each tx burns [burned] of [assetID]
+	putTx := func(burned uint64, status choices.Status) {
+		tx := &txs.Tx{
+			Unsigned: &txs.BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    networkID,
+					BlockchainID: chainID,
+					Ins: []*avax.TransferableInput{{
+						UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+						Asset:  avax.Asset{ID: assetID},
+						In: &secp256k1fx.TransferInput{
+							Amt: burned,
+							Input: secp256k1fx.Input{
+								SigIndices: []uint32{0},
+							},
+						},
+					}},
+				},
+			},
+		}
+		require.NoError(tx.SignSECP256K1Fx(parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+		require.NoError(txState.PutTx(tx.ID(), tx))
+		require.NoError(statuses.PutStatus(tx.ID(), status))
+	}
+	putTx(1, choices.Accepted)
+	putTx(2, choices.Accepted)
+	putTx(4, choices.Processing)
+	putTx(8, choices.Rejected)
+
+	s := NewFeeState(prefixdb.New(feePrefix, db), txDB, statuses, parser, assetID)
+
+	burnedFees, err := s.GetBurnedFees()
+	require.NoError(err)
+	require.EqualValues(3, burnedFees)
+
+	// Once indexed, the burned fees are only changed by SetBurnedFees
+	putTx(16, choices.Accepted)
+	burnedFees, err = s.GetBurnedFees()
+	require.NoError(err)
+	require.EqualValues(3, burnedFees)
+
+	require.NoError(s.SetBurnedFees(19))
+	burnedFees, err = s.GetBurnedFees()
+	require.NoError(err)
+	require.EqualValues(19, burnedFees)
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/kukrer/savannahnode/ids"
+)
+
+var _ FreezeState = &freezeDiff{}
+
+type utxoFreeze struct {
+	assetID ids.ID
+	utxoID  ids.ID
+}
+
+type addressFreeze struct {
+	assetID ids.ID
+	addr    ids.ShortID
+}
+
+// freezeDiff holds in memory the freezes applied on top of [parent], which is
+// never modified.
+type freezeDiff struct {
+	parent    FreezeState
+	utxos     map[utxoFreeze]bool
+	addresses map[addressFreeze]bool
+}
+
+// NewFreezeDiff returns a FreezeState that reads the freezes of [parent]
+// unless they were changed through the returned state. It allows verifying
+// txs against the freezes of processing blocks before they are accepted.
+func NewFreezeDiff(parent FreezeState) FreezeState {
+	return &freezeDiff{
+		parent:    parent,
+		utxos:     make(map[utxoFreeze]bool),
+		addresses: make(map[addressFreeze]bool),
+	}
+}
+
+func (d *freezeDiff) IsUTXOFrozen(assetID ids.ID, utxoID ids.ID) (bool, error) {
+	if frozen, ok := d.utxos[utxoFreeze{assetID: assetID, utxoID: utxoID}]; ok {
+		return frozen, nil
+	}
+	return d.parent.IsUTXOFrozen(assetID, utxoID)
+}
+
+func (d *freezeDiff) SetUTXOFrozen(assetID ids.ID, utxoID ids.ID, frozen bool) error {
+	d.utxos[utxoFreeze{assetID: assetID, utxoID: utxoID}] = frozen
+	return nil
+}
+
+func (d *freezeDiff) IsAddressFrozen(assetID ids.ID, addr ids.ShortID) (bool, error) {
+	if frozen, ok := d.addresses[addressFreeze{assetID: assetID, addr: addr}]; ok {
+		return frozen, nil
+	}
+	return d.parent.IsAddressFrozen(assetID, addr)
+}
+
+func (d *freezeDiff) SetAddressFrozen(assetID ids.ID, addr ids.ShortID, frozen bool) error {
+	d.addresses[addressFreeze{assetID: assetID, addr: addr}] = frozen
+	return nil
+}
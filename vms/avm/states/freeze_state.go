@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/prefixdb"
+	"github.com/kukrer/savannahnode/ids"
+)
+
+var (
+	frozenUTXOPrefix    = []byte("utxo")
+	frozenAddressPrefix = []byte("address")
+
+	_ FreezeState = &freezeState{}
+)
+
+// FreezeState persists the UTXOs and the addresses whose funds of an asset
+// were frozen by the freeze authority of the asset.
+type FreezeState interface {
+	// IsUTXOFrozen returns true if the UTXO [utxoID] of the asset [assetID]
+	// is frozen.
+	IsUTXOFrozen(assetID ids.ID, utxoID ids.ID) (bool, error)
+
+	// SetUTXOFrozen freezes or unfreezes the UTXO [utxoID] of the asset
+	// [assetID].
+	SetUTXOFrozen(assetID ids.ID, utxoID ids.ID, frozen bool) error
+
+	// IsAddressFrozen returns true if the funds of the asset [assetID] owned
+	// by [addr] are frozen.
+	IsAddressFrozen(assetID ids.ID, addr ids.ShortID) (bool, error)
+
+	// SetAddressFrozen freezes or unfreezes the funds of the asset [assetID]
+	// owned by [addr].
+	SetAddressFrozen(assetID ids.ID, addr ids.ShortID, frozen bool) error
+}
+
+type freezeState struct {
+	utxoDB    database.Database
+	addressDB database.Database
+}
+
+func NewFreezeState(db database.Database) FreezeState {
+	return &freezeState{
+		utxoDB:    prefixdb.New(frozenUTXOPrefix, db),
+		addressDB: prefixdb.New(frozenAddressPrefix, db),
+	}
+}
+
+func (s *freezeState) IsUTXOFrozen(assetID ids.ID, utxoID ids.ID) (bool, error) {
+	return s.utxoDB.Has(freezeKey(assetID, utxoID[:]))
+}
+
+func (s *freezeState) SetUTXOFrozen(assetID ids.ID, utxoID ids.ID, frozen bool) error {
+	return setFrozen(s.utxoDB, freezeKey(assetID, utxoID[:]), frozen)
+}
+
+func (s *freezeState) IsAddressFrozen(assetID ids.ID, addr ids.ShortID) (bool, error) {
+	return s.addressDB.Has(freezeKey(assetID, addr[:]))
+}
+
+func (s *freezeState) SetAddressFrozen(assetID ids.ID, addr ids.ShortID, frozen bool) error {
+	return setFrozen(s.addressDB, freezeKey(assetID, addr[:]), frozen)
+}
+
+func freezeKey(assetID ids.ID, suffix []byte) []byte {
+	key := make([]byte, len(assetID)+len(suffix))
+	copy(key, assetID[:])
+	copy(key[len(assetID):], suffix)
+	return key
+}
+
+func setFrozen(db database.Database, key []byte, frozen bool) error {
+	if frozen {
+		return db.Put(key, nil)
+	}
+	return db.Delete(key)
+}
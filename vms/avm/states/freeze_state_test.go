@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database/memdb"
+	"github.com/kukrer/savannahnode/ids"
+)
+
+func TestFreezeState(t *testing.T) {
+	require := require.New(t)
+
+	s := NewFreezeState(memdb.New())
+
+	assetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	utxoID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+
+	frozen, err := s.IsUTXOFrozen(assetID, utxoID)
+	require.NoError(err)
+	require.False(frozen)
+
+	require.NoError(s.SetUTXOFrozen(assetID, utxoID, true))
+
+	frozen, err = s.IsUTXOFrozen(assetID, utxoID)
+	require.NoError(err)
+	require.True(frozen)
+
+	// Freezing is scoped to the asset
+	frozen, err = s.IsUTXOFrozen(otherAssetID, utxoID)
+	require.NoError(err)
+	require.False(frozen)
+
+	// A UTXO and an address are frozen independently
+	frozen, err = s.IsAddressFrozen(assetID, addr)
+	require.NoError(err)
+	require.False(frozen)
+
+	require.NoError(s.SetAddressFrozen(assetID, addr, true))
+
+	frozen, err = s.IsAddressFrozen(assetID, addr)
+	require.NoError(err)
+	require.True(frozen)
+
+	require.NoError(s.SetUTXOFrozen(assetID, utxoID, false))
+	require.NoError(s.SetAddressFrozen(assetID, addr, false))
+
+	frozen, err = s.IsUTXOFrozen(assetID, utxoID)
+	require.NoError(err)
+	require.False(frozen)
+
+	frozen, err = s.IsAddressFrozen(assetID, addr)
+	require.NoError(err)
+	require.False(frozen)
+}
+
+func TestFreezeDiff(t *testing.T) {
+	require := require.New(t)
+
+	parent := NewFreezeState(memdb.New())
+
+	assetID := ids.GenerateTestID()
+	frozenUTXOID := ids.GenerateTestID()
+	utxoID := ids.GenerateTestID()
+	frozenAddr := ids.GenerateTestShortID()
+	addr := ids.GenerateTestShortID()
+	require.NoError(parent.SetUTXOFrozen(assetID, frozenUTXOID, true))
+	require.NoError(parent.SetAddressFrozen(assetID, frozenAddr, true))
+
+	d := NewFreezeDiff(parent)
+
+	// The freezes of the parent are read through the diff
+	frozen, err := d.IsUTXOFrozen(assetID, frozenUTXOID)
+	require.NoError(err)
+	require.True(frozen)
+	frozen, err = d.IsAddressFrozen(assetID, frozenAddr)
+	require.NoError(err)
+	require.True(frozen)
+
+	require.NoError(d.SetUTXOFrozen(assetID, frozenUTXOID, false))
+	require.NoError(d.SetUTXOFrozen(assetID, utxoID, true))
+	require.NoError(d.SetAddressFrozen(assetID, frozenAddr, false))
+	require.NoError(d.SetAddressFrozen(assetID, addr, true))
+
+	frozen, err = d.IsUTXOFrozen(assetID, frozenUTXOID)
+	require.NoError(err)
+	require.False(frozen)
+	frozen, err = d.IsUTXOFrozen(assetID, utxoID)
+	require.NoError(err)
+	require.True(frozen)
+	frozen, err = d.IsAddressFrozen(assetID, frozenAddr)
+	require.NoError(err)
+	require.False(frozen)
+	frozen, err = d.IsAddressFrozen(assetID, addr)
+	require.NoError(err)
+	require.True(frozen)
+
+	// The parent isn't modified by the diff
+	frozen, err = parent.IsUTXOFrozen(assetID, frozenUTXOID)
+	require.NoError(err)
+	require.True(frozen)
+	frozen, err = parent.IsUTXOFrozen(assetID, utxoID)
+	require.NoError(err)
+	require.False(frozen)
+	frozen, err = parent.IsAddressFrozen(assetID, frozenAddr)
+	require.NoError(err)
+	require.True(frozen)
+	frozen, err = parent.IsAddressFrozen(assetID, addr)
+	require.NoError(err)
+	require.False(frozen)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kukrer/savannahnode/database"
 	"github.com/kukrer/savannahnode/database/prefixdb"
+	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/vms/avm/txs"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 )
@@ -17,17 +18,24 @@ var (
 	statusPrefix    = []byte("status")
 	singletonPrefix = []byte("singleton")
 	txPrefix        = []byte("tx")
+	blockPrefix     = []byte("block")
+	freezePrefix    = []byte("freeze")
+	feePrefix       = []byte("fee")
 
 	_ State = &state{}
 )
 
-// State persistently maintains a set of UTXOs, transaction, statuses, and
-// singletons.
+// State persistently maintains a set of UTXOs, transaction, statuses,
+// singletons, frozen funds, burned fees and, once the chain is linearized,
+// blocks.
 type State interface {
 	avax.UTXOState
 	avax.StatusState
 	avax.SingletonState
 	TxState
+	BlockState
+	FreezeState
+	FeeState
 }
 
 type state struct {
@@ -35,13 +43,24 @@ type state struct {
 	avax.StatusState
 	avax.SingletonState
 	TxState
+	BlockState
+	FreezeState
+	FeeState
 }
 
-func New(db database.Database, parser txs.Parser, metrics prometheus.Registerer) (State, error) {
+func New(
+	db database.Database,
+	parser txs.Parser,
+	metrics prometheus.Registerer,
+	avaxAssetID ids.ID,
+) (State, error) {
 	utxoDB := prefixdb.New(utxoPrefix, db)
 	statusDB := prefixdb.New(statusPrefix, db)
 	singletonDB := prefixdb.New(singletonPrefix, db)
 	txDB := prefixdb.New(txPrefix, db)
+	blockDB := prefixdb.New(blockPrefix, db)
+	freezeDB := prefixdb.New(freezePrefix, db)
+	feeDB := prefixdb.New(feePrefix, db)
 
 	utxoState, err := avax.NewMeteredUTXOState(utxoDB, parser.Codec(), metrics)
 	if err != nil {
@@ -54,10 +73,18 @@ func New(db database.Database, parser txs.Parser, metrics prometheus.Registerer)
 	}
 
 	txState, err := NewTxState(txDB, parser, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	blockState, err := NewBlockState(blockDB, parser, metrics)
 	return &state{
 		UTXOState:      utxoState,
 		StatusState:    statusState,
 		SingletonState: avax.NewSingletonState(singletonDB),
 		TxState:        txState,
+		BlockState:     blockState,
+		FreezeState:    NewFreezeState(freezeDB),
+		FeeState:       NewFeeState(feeDB, txDB, statusState, parser, avaxAssetID),
 	}, err
 }
@@ -18,7 +18,9 @@ import (
 	"github.com/kukrer/savannahnode/vms/avm/txs"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
@@ -31,6 +33,8 @@ var (
 	_ avax.TransferableOut = &secp256k1fx.TransferOutput{}
 	_ fxs.FxOperation      = &secp256k1fx.MintOperation{}
 	_ verify.Verifiable    = &secp256k1fx.Credential{}
+	_ avax.TransferableOut = &secp256k1fx.WeightedTransferOutput{}
+	_ verify.State         = &secp256k1fx.WeightedOutputOwners{}
 
 	_ verify.State      = &nftfx.MintOutput{}
 	_ verify.State      = &nftfx.TransferOutput{}
@@ -43,6 +47,15 @@ var (
 	_ fxs.FxOperation   = &propertyfx.MintOperation{}
 	_ fxs.FxOperation   = &propertyfx.BurnOperation{}
 	_ verify.Verifiable = &propertyfx.Credential{}
+
+	_ avax.TransferableIn  = &htlcfx.TransferInput{}
+	_ avax.TransferableOut = &htlcfx.TransferOutput{}
+	_ verify.Verifiable    = &htlcfx.Credential{}
+
+	_ verify.State      = &policyfx.PolicyOutput{}
+	_ fxs.FxOperation   = &policyfx.FreezeOperation{}
+	_ fxs.FxOperation   = &policyfx.ClawbackOperation{}
+	_ verify.Verifiable = &policyfx.Credential{}
 )
 
 // StaticService defines the base service for the asset vm
@@ -5,23 +5,27 @@ package avm
 
 import (
 	"github.com/kukrer/savannahnode/chains/atomic"
-	"github.com/kukrer/savannahnode/database"
 	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/vms/avm/states"
 	"github.com/kukrer/savannahnode/vms/avm/txs"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 )
 
 var _ txs.Visitor = &executeTx{}
 
+// executeTx collects the shared memory requests that must be applied
+// atomically with the acceptance of a tx and applies the freezes of the asset
+// policies to the state.
 type executeTx struct {
-	tx           *txs.Tx
-	batch        database.Batch
-	sharedMemory atomic.SharedMemory
-	parser       txs.Parser
+	tx       *txs.Tx
+	requests map[ids.ID]*atomic.Requests
+	parser   txs.Parser
+	state    states.FreezeState
 }
 
 func (et *executeTx) BaseTx(t *txs.BaseTx) error {
-	return et.batch.Write()
+	return nil
 }
 
 func (et *executeTx) ImportTx(t *txs.ImportTx) error {
@@ -30,14 +34,9 @@ func (et *executeTx) ImportTx(t *txs.ImportTx) error {
 		inputID := in.UTXOID.InputID()
 		utxoIDs[i] = inputID[:]
 	}
-	return et.sharedMemory.Apply(
-		map[ids.ID]*atomic.Requests{
-			t.SourceChain: {
-				RemoveRequests: utxoIDs,
-			},
-		},
-		et.batch,
-	)
+	requests := et.chainRequests(t.SourceChain)
+	requests.RemoveRequests = append(requests.RemoveRequests, utxoIDs...)
+	return nil
 }
 
 func (et *executeTx) ExportTx(t *txs.ExportTx) error {
@@ -72,14 +71,9 @@ func (et *executeTx) ExportTx(t *txs.ExportTx) error {
 		elems[i] = elem
 	}
 
-	return et.sharedMemory.Apply(
-		map[ids.ID]*atomic.Requests{
-			t.DestinationChain: {
-				PutRequests: elems,
-			},
-		},
-		et.batch,
-	)
+	requests := et.chainRequests(t.DestinationChain)
+	requests.PutRequests = append(requests.PutRequests, elems...)
+	return nil
 }
 
 func (et *executeTx) CreateAssetTx(t *txs.CreateAssetTx) error {
@@ -87,5 +81,62 @@ func (et *executeTx) CreateAssetTx(t *txs.CreateAssetTx) error {
 }
 
 func (et *executeTx) OperationTx(t *txs.OperationTx) error {
+	if err := applyFreezes(et.tx, et.state); err != nil {
+		return err
+	}
 	return et.BaseTx(&t.BaseTx)
 }
+
+func (et *executeTx) chainRequests(chainID ids.ID) *atomic.Requests {
+	requests, ok := et.requests[chainID]
+	if !ok {
+		requests = &atomic.Requests{}
+		et.requests[chainID] = requests
+	}
+	return requests
+}
+
+// applyFreezes applies the policy operations of [tx] to [freezes]. The UTXOs
+// consumed by a policy operation are no longer frozen, while the UTXOs
+// reissued by a freeze operation are frozen, or unfrozen, along with the
+// targeted addresses.
+func applyFreezes(tx *txs.Tx, freezes states.FreezeState) error {
+	opTx, ok := tx.Unsigned.(*txs.OperationTx)
+	if !ok {
+		return nil
+	}
+
+	txID := tx.ID()
+	outputIndex := len(opTx.Outs)
+	for _, op := range opTx.Ops {
+		assetID := op.AssetID()
+		freezeOp, isFreeze := op.Op.(*policyfx.FreezeOperation)
+		_, isClawback := op.Op.(*policyfx.ClawbackOperation)
+		if isFreeze || isClawback {
+			for _, utxoID := range op.UTXOIDs {
+				if err := freezes.SetUTXOFrozen(assetID, utxoID.InputID(), false); err != nil {
+					return err
+				}
+			}
+		}
+		if isFreeze {
+			// The reissued UTXOs follow the policy output
+			for i := range freezeOp.Outputs {
+				utxoID := avax.UTXOID{
+					TxID:        txID,
+					OutputIndex: uint32(outputIndex + 1 + i),
+				}
+				if err := freezes.SetUTXOFrozen(assetID, utxoID.InputID(), freezeOp.Freeze); err != nil {
+					return err
+				}
+			}
+			for _, addr := range freezeOp.Addrs {
+				if err := freezes.SetAddressFrozen(assetID, addr, freezeOp.Freeze); err != nil {
+					return err
+				}
+			}
+		}
+		outputIndex += len(op.Op.Outs())
+	}
+	return nil
+}
@@ -4,31 +4,125 @@
 package avm
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/vms/avm/states"
 	"github.com/kukrer/savannahnode/vms/avm/txs"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
 
-var _ txs.Visitor = &txSemanticVerify{}
+var (
+	errMultiplePolicies        = errors.New("asset can't have multiple policies")
+	errUnsupportedBeforeBaobab = errors.New("unsupported before the baobab upgrade")
+
+	_ txs.Visitor = &txSemanticVerify{}
+)
 
 // SemanticVerify that this transaction is well-formed.
 type txSemanticVerify struct {
 	tx *txs.Tx
 	vm *VM
+	// timestamp is the time of the chain the tx is executed at. It is zero
+	// when the tx isn't verified as part of a block, in which case HTLC
+	// outputs can't be spent.
+	timestamp time.Time
+	// freezes are the freezes the tx is verified against. The accepted
+	// freezes are used if it is nil.
+	freezes states.FreezeState
+}
+
+func (t *txSemanticVerify) freezeState() states.FreezeState {
+	if t.freezes == nil {
+		return t.vm.state
+	}
+	return t.freezes
+}
+
+// fxTx returns [utx] as passed to the fxs, along with the chain timestamp
+// to check HTLC deadlines against if it is known.
+func (t *txSemanticVerify) fxTx(utx txs.UnsignedTx) secp256k1fx.UnsignedTx {
+	if t.timestamp.IsZero() {
+		return utx
+	}
+	return &htlcfx.TimestampedTx{
+		UnsignedTx: utx,
+		Timestamp:  uint64(t.timestamp.Unix()),
+	}
+}
+
+// chainTime returns the time of the chain the tx is executed at, or the local
+// time if the tx isn't verified as part of a block.
+func (t *txSemanticVerify) chainTime() time.Time {
+	if t.timestamp.IsZero() {
+		return t.vm.clock.Time()
+	}
+	return t.timestamp
+}
+
+// verifyPreBaobabTypes returns an error if the chain is before the Baobab
+// upgrade and one of [states] is of a type that is only supported after it.
+func (t *txSemanticVerify) verifyPreBaobabTypes(states ...interface{}) error {
+	if !t.chainTime().Before(t.vm.BaobabTime) {
+		return nil
+	}
+	for _, state := range states {
+		switch state.(type) {
+		case *secp256k1fx.WeightedTransferOutput, *secp256k1fx.WeightedOutputOwners:
+			return fmt.Errorf("%w: %T", errUnsupportedBeforeBaobab, state)
+		}
+	}
+	return nil
+}
+
+// verifyPreBaobabUTXOs returns an error if the chain is before the Baobab
+// upgrade and one of the UTXOs [utxoIDs] is of a type that is only supported
+// after it.
+func (t *txSemanticVerify) verifyPreBaobabUTXOs(utxoIDs []*avax.UTXOID) error {
+	if !t.chainTime().Before(t.vm.BaobabTime) {
+		return nil
+	}
+	for _, utxoID := range utxoIDs {
+		utxo, err := t.vm.getUTXO(utxoID)
+		if err != nil {
+			return err
+		}
+		if err := t.verifyPreBaobabTypes(utxo.Out); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (t *txSemanticVerify) BaseTx(tx *txs.BaseTx) error {
+	utxoIDs := make([]*avax.UTXOID, len(tx.Ins))
+	for i, in := range tx.Ins {
+		utxoIDs[i] = &in.UTXOID
+	}
+	if err := t.verifyPreBaobabUTXOs(utxoIDs); err != nil {
+		return err
+	}
+
 	for i, in := range tx.Ins {
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
 		cred := t.tx.Creds[i].Verifiable
-		if err := t.vm.verifyTransfer(t.tx.Unsigned, in, cred); err != nil {
+		if err := t.vm.verifyTransfer(t.freezeState(), t.fxTx(t.tx.Unsigned), in, cred); err != nil {
 			return err
 		}
 	}
 
 	for _, out := range tx.Outs {
+		if err := t.verifyPreBaobabTypes(out.Out); err != nil {
+			return err
+		}
+
 		fxIndex, err := t.vm.getFx(out.Out)
 		if err != nil {
 			return err
@@ -72,11 +166,14 @@ func (t *txSemanticVerify) ImportTx(tx *txs.ImportTx) error {
 		if _, err := codec.Unmarshal(allUTXOBytes[i], &utxo); err != nil {
 			return err
 		}
+		if err := t.verifyPreBaobabTypes(utxo.Out); err != nil {
+			return err
+		}
 
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
 		cred := t.tx.Creds[i+offset].Verifiable
-		if err := t.vm.verifyTransferOfUTXO(tx, in, cred, &utxo); err != nil {
+		if err := t.vm.verifyTransferOfUTXO(t.fxTx(tx), in, cred, &utxo); err != nil {
 			return err
 		}
 	}
@@ -92,6 +189,10 @@ func (t *txSemanticVerify) ExportTx(tx *txs.ExportTx) error {
 
 	now := t.vm.clock.Time()
 	for _, out := range tx.ExportedOuts {
+		if err := t.verifyPreBaobabTypes(out.Out); err != nil {
+			return err
+		}
+
 		fxIndex, err := t.vm.getFx(out.Out)
 		if err != nil {
 			return err
@@ -124,10 +225,19 @@ func (t *txSemanticVerify) OperationTx(tx *txs.OperationTx) error {
 
 	offset := tx.BaseTx.NumCredentials()
 	for i, op := range tx.Ops {
+		if err := t.verifyPreBaobabUTXOs(op.UTXOIDs); err != nil {
+			return err
+		}
+		for _, out := range op.Op.Outs() {
+			if err := t.verifyPreBaobabTypes(out); err != nil {
+				return err
+			}
+		}
+
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
 		cred := t.tx.Creds[i+offset].Verifiable
-		if err := t.vm.verifyOperation(tx, op, cred); err != nil {
+		if err := t.vm.verifyOperation(t.freezeState(), tx, op, cred); err != nil {
 			return err
 		}
 	}
@@ -135,5 +245,20 @@ func (t *txSemanticVerify) OperationTx(tx *txs.OperationTx) error {
 }
 
 func (t *txSemanticVerify) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	// An asset has at most one freeze authority
+	numPolicies := 0
+	for _, state := range tx.States {
+		for _, out := range state.Outs {
+			if err := t.verifyPreBaobabTypes(out); err != nil {
+				return err
+			}
+			if _, ok := out.(*policyfx.PolicyOutput); ok {
+				numPolicies++
+			}
+		}
+	}
+	if numPolicies > 1 {
+		return errMultiplePolicies
+	}
 	return t.BaseTx((&tx.BaseTx))
 }
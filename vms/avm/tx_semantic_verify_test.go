@@ -183,7 +183,7 @@ func TestBaseTxSemanticVerifyWrongAssetID(t *testing.T) {
 
 func TestBaseTxSemanticVerifyUnauthorizedFx(t *testing.T) {
 	ctx := NewContext(t)
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx.Lock.Lock()
 	defer func() {
 		if err := vm.Shutdown(); err != nil {
@@ -217,7 +217,7 @@ func TestBaseTxSemanticVerifyUnauthorizedFx(t *testing.T) {
 				Fx: fx,
 			},
 		},
-		nil,
+		&common.SenderTest{T: t},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -610,7 +610,7 @@ func TestBaseTxSemanticVerifyPendingUnauthorizedFx(t *testing.T) {
 
 	ctx.Lock.Lock()
 
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 
 	fx := &FxTest{}
 	fx.InitializeF = func(vmIntf interface{}) error {
@@ -635,7 +635,7 @@ func TestBaseTxSemanticVerifyPendingUnauthorizedFx(t *testing.T) {
 				Fx: fx,
 			},
 		},
-		nil,
+		&common.SenderTest{T: t},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -757,7 +757,7 @@ func TestBaseTxSemanticVerifyPendingInvalidSignature(t *testing.T) {
 
 	ctx.Lock.Lock()
 
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 
 	fx := &FxTest{}
 	fx.InitializeF = func(vmIntf interface{}) error {
@@ -782,7 +782,7 @@ func TestBaseTxSemanticVerifyPendingInvalidSignature(t *testing.T) {
 				Fx: fx,
 			},
 		},
-		nil,
+		&common.SenderTest{T: t},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -1319,7 +1319,7 @@ func TestExportTxSemanticVerifyInvalidFx(t *testing.T) {
 	avaxID := genesisTx.ID()
 
 	issuer := make(chan common.Message, 1)
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	err = vm.Initialize(
 		ctx,
 		baseDBManager.NewPrefixDBManager([]byte{1}),
@@ -1342,7 +1342,7 @@ func TestExportTxSemanticVerifyInvalidFx(t *testing.T) {
 				},
 			},
 		},
-		nil,
+		&common.SenderTest{T: t},
 	)
 	if err != nil {
 		t.Fatal(err)
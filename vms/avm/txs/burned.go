@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+)
+
+var _ Visitor = &burnCounter{}
+
+// Burned returns the amount of [assetID] that [tx] consumes without producing.
+// The operations of the tx aren't counted, as they don't pay the tx fee.
+func Burned(tx UnsignedTx, assetID ids.ID) (uint64, error) {
+	c := burnCounter{assetID: assetID}
+	if err := tx.Visit(&c); err != nil {
+		return 0, err
+	}
+	return math.Sub64(c.consumed, c.produced)
+}
+
+// burnCounter sums the amount of [assetID] a tx consumes and produces
+type burnCounter struct {
+	assetID  ids.ID
+	consumed uint64
+	produced uint64
+}
+
+func (c *burnCounter) BaseTx(tx *BaseTx) error {
+	if err := c.consume(tx.Ins); err != nil {
+		return err
+	}
+	return c.produce(tx.Outs)
+}
+
+func (c *burnCounter) CreateAssetTx(tx *CreateAssetTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *burnCounter) OperationTx(tx *OperationTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *burnCounter) ImportTx(tx *ImportTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.consume(tx.ImportedIns)
+}
+
+func (c *burnCounter) ExportTx(tx *ExportTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.produce(tx.ExportedOuts)
+}
+
+func (c *burnCounter) consume(ins []*avax.TransferableInput) error {
+	for _, in := range ins {
+		if in.AssetID() != c.assetID {
+			continue
+		}
+		consumed, err := math.Add64(c.consumed, in.Input().Amount())
+		if err != nil {
+			return err
+		}
+		c.consumed = consumed
+	}
+	return nil
+}
+
+func (c *burnCounter) produce(outs []*avax.TransferableOutput) error {
+	for _, out := range outs {
+		if out.AssetID() != c.assetID {
+			continue
+		}
+		produced, err := math.Add64(c.produced, out.Output().Amount())
+		if err != nil {
+			return err
+		}
+		c.produced = produced
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestBurned(t *testing.T) {
+	assetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+
+	in := func(assetID ids.ID, amount uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetID},
+			In:     &secp256k1fx.TransferInput{Amt: amount},
+		}
+	}
+	out := func(assetID ids.ID, amount uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+	baseTx := func(ins []*avax.TransferableInput, outs []*avax.TransferableOutput) BaseTx {
+		return BaseTx{BaseTx: avax.BaseTx{
+			Ins:  ins,
+			Outs: outs,
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		tx          UnsignedTx
+		expected    uint64
+		expectedErr bool
+	}{
+		{
+			name: "base tx",
+			tx: &BaseTx{BaseTx: avax.BaseTx{
+				Ins:  []*avax.TransferableInput{in(assetID, 10), in(otherAssetID, 20)},
+				Outs: []*avax.TransferableOutput{out(assetID, 7), out(otherAssetID, 20)},
+			}},
+			expected: 3,
+		},
+		{
+			name: "create asset tx",
+			tx: &CreateAssetTx{
+				BaseTx: baseTx(
+					[]*avax.TransferableInput{in(assetID, 10)},
+					nil,
+				),
+			},
+			expected: 10,
+		},
+		{
+			name: "operation tx",
+			tx: &OperationTx{
+				BaseTx: baseTx(
+					[]*avax.TransferableInput{in(assetID, 10)},
+					[]*avax.TransferableOutput{out(assetID, 9)},
+				),
+			},
+			expected: 1,
+		},
+		{
+			name: "import tx",
+			tx: &ImportTx{
+				BaseTx: baseTx(
+					nil,
+					[]*avax.TransferableOutput{out(assetID, 9)},
+				),
+				ImportedIns: []*avax.TransferableInput{in(assetID, 10)},
+			},
+			expected: 1,
+		},
+		{
+			name: "export tx",
+			tx: &ExportTx{
+				BaseTx: baseTx(
+					[]*avax.TransferableInput{in(assetID, 10)},
+					[]*avax.TransferableOutput{out(assetID, 4)},
+				),
+				ExportedOuts: []*avax.TransferableOutput{out(assetID, 5)},
+			},
+			expected: 1,
+		},
+		{
+			name: "produces more than consumed",
+			tx: &BaseTx{BaseTx: avax.BaseTx{
+				Outs: []*avax.TransferableOutput{out(assetID, 1)},
+			}},
+			expectedErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			burned, err := Burned(test.tx, assetID)
+			if test.expectedErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expected, burned)
+		})
+	}
+}
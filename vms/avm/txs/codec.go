@@ -22,11 +22,14 @@ type codecRegistry struct {
 	codecs      []codec.Registry
 	index       int
 	typeToIndex map[reflect.Type]int
+	// numTypes is the number of types registered through this registry
+	numTypes uint32
 }
 
 func (cr *codecRegistry) RegisterType(val interface{}) error {
 	valType := reflect.TypeOf(val)
 	cr.typeToIndex[valType] = cr.index
+	cr.numTypes++
 
 	errs := wrappers.Errs{}
 	for _, c := range cr.codecs {
@@ -0,0 +1,229 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/linkedhashmap"
+	"github.com/kukrer/savannahnode/utils/units"
+	"github.com/kukrer/savannahnode/utils/wrappers"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+)
+
+const (
+	// targetTxSize is the maximum number of bytes a transaction can use to be
+	// allowed into the mempool.
+	targetTxSize = 64 * units.KiB
+
+	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to cache
+	droppedTxIDsCacheSize = 64
+
+	initialConsumedUTXOsSize = 512
+
+	// maxMempoolSize is the maximum number of bytes allowed in the mempool
+	maxMempoolSize = 64 * units.MiB
+)
+
+var (
+	_ Mempool = &mempool{}
+
+	ErrDuplicateTx          = errors.New("duplicate tx")
+	ErrTxTooLarge           = errors.New("tx too large")
+	ErrMempoolFull          = errors.New("mempool is full")
+	ErrConflictsWithOtherTx = errors.New("tx conflicts with other tx")
+)
+
+// Mempool contains the transactions that were issued locally or gossiped to
+// this node but aren't yet issued into consensus.
+type Mempool interface {
+	Add(tx *txs.Tx) error
+	Has(txID ids.ID) bool
+	Get(txID ids.ID) *txs.Tx
+	Remove(txs []*txs.Tx)
+
+	// Len returns the number of txs in the mempool
+	Len() int
+	// List returns the txs in the mempool, ordered from the oldest to the
+	// newest.
+	List() []*txs.Tx
+
+	// Note: dropped txs are added to droppedTxIDs but not evicted from the
+	// mempool. This allows previously dropped txs to be possibly reissued.
+	MarkDropped(txID ids.ID, reason string)
+	GetDropReason(txID ids.ID) (string, bool)
+}
+
+type mempool struct {
+	bytesAvailableMetric prometheus.Gauge
+	bytesAvailable       int
+
+	numTxs     prometheus.Gauge
+	droppedTxs prometheus.Counter
+
+	// Key: Tx ID
+	// Value: *txs.Tx
+	unissuedTxs linkedhashmap.LinkedHashmap
+
+	// Key: Tx ID
+	// Value: String repr. of the verification error
+	droppedTxIDs *cache.LRU
+
+	consumedUTXOs ids.Set
+}
+
+func New(
+	namespace string,
+	registerer prometheus.Registerer,
+) (Mempool, error) {
+	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "bytes_available",
+		Help:      "Number of bytes of space currently available in the mempool",
+	})
+	numTxs := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "count",
+		Help:      "Number of transactions in the mempool",
+	})
+	droppedTxs := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dropped_txs",
+		Help:      "Number of transactions dropped from the mempool because they failed verification",
+	})
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(bytesAvailableMetric),
+		registerer.Register(numTxs),
+		registerer.Register(droppedTxs),
+	)
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+
+	bytesAvailableMetric.Set(maxMempoolSize)
+	return &mempool{
+		bytesAvailableMetric: bytesAvailableMetric,
+		bytesAvailable:       maxMempoolSize,
+		numTxs:               numTxs,
+		droppedTxs:           droppedTxs,
+		unissuedTxs:          linkedhashmap.New(),
+		droppedTxIDs:         &cache.LRU{Size: droppedTxIDsCacheSize},
+		consumedUTXOs:        ids.NewSet(initialConsumedUTXOsSize),
+	}, nil
+}
+
+func (m *mempool) Add(tx *txs.Tx) error {
+	// Note: a previously dropped tx can be re-added
+	txID := tx.ID()
+	if m.Has(txID) {
+		return fmt.Errorf("%w: %s", ErrDuplicateTx, txID)
+	}
+
+	txSize := len(tx.Bytes())
+	if txSize > targetTxSize {
+		return fmt.Errorf("%w: %s size (%d) > target size (%d)",
+			ErrTxTooLarge,
+			txID,
+			txSize,
+			targetTxSize,
+		)
+	}
+	if txSize > m.bytesAvailable {
+		return fmt.Errorf("%w: %s size (%d) exceeds available space (%d)",
+			ErrMempoolFull,
+			txID,
+			txSize,
+			m.bytesAvailable,
+		)
+	}
+
+	inputs := inputIDs(tx)
+	if m.consumedUTXOs.Overlaps(inputs) {
+		return fmt.Errorf("%w: %s", ErrConflictsWithOtherTx, txID)
+	}
+
+	m.bytesAvailable -= txSize
+	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
+
+	m.unissuedTxs.Put(txID, tx)
+	m.numTxs.Inc()
+
+	// Mark these UTXOs as consumed in the mempool
+	m.consumedUTXOs.Union(inputs)
+
+	// An explicitly added tx must not be marked as dropped.
+	m.droppedTxIDs.Evict(txID)
+	return nil
+}
+
+func (m *mempool) Has(txID ids.ID) bool {
+	return m.Get(txID) != nil
+}
+
+func (m *mempool) Get(txID ids.ID) *txs.Tx {
+	tx, ok := m.unissuedTxs.Get(txID)
+	if !ok {
+		return nil
+	}
+	return tx.(*txs.Tx)
+}
+
+func (m *mempool) Remove(txsToRemove []*txs.Tx) {
+	for _, tx := range txsToRemove {
+		txID := tx.ID()
+		if _, ok := m.unissuedTxs.Get(txID); !ok {
+			continue
+		}
+		m.unissuedTxs.Delete(txID)
+		m.numTxs.Dec()
+
+		m.bytesAvailable += len(tx.Bytes())
+		m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
+
+		m.consumedUTXOs.Difference(inputIDs(tx))
+	}
+}
+
+func (m *mempool) Len() int {
+	return m.unissuedTxs.Len()
+}
+
+func (m *mempool) List() []*txs.Tx {
+	unissuedTxs := make([]*txs.Tx, 0, m.unissuedTxs.Len())
+	it := m.unissuedTxs.NewIterator()
+	for it.Next() {
+		unissuedTxs = append(unissuedTxs, it.Value().(*txs.Tx))
+	}
+	return unissuedTxs
+}
+
+func (m *mempool) MarkDropped(txID ids.ID, reason string) {
+	m.droppedTxs.Inc()
+	m.droppedTxIDs.Put(txID, reason)
+}
+
+func (m *mempool) GetDropReason(txID ids.ID) (string, bool) {
+	reason, exist := m.droppedTxIDs.Get(txID)
+	if !exist {
+		return "", false
+	}
+	return reason.(string), true
+}
+
+// inputIDs returns the IDs of the UTXOs consumed by [tx]
+func inputIDs(tx *txs.Tx) ids.Set {
+	inputUTXOs := tx.Unsigned.InputUTXOs()
+	inputs := ids.NewSet(len(inputUTXOs))
+	for _, utxoID := range inputUTXOs {
+		inputs.Add(utxoID.InputID())
+	}
+	return inputs
+}
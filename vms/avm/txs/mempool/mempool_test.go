@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/avm/fxs"
+	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/propertyfx"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	keys    = crypto.BuildTestKeys()
+	chainID = ids.ID{5, 4, 3, 2, 1}
+	assetID = ids.ID{1, 2, 3}
+)
+
+func newTestParser(t *testing.T) txs.Parser {
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+	require.NoError(t, err)
+	return parser
+}
+
+// createTestTx returns a tx consuming the UTXO [inputTxID]:[outputIndex]
+func createTestTx(t *testing.T, parser txs.Parser, inputTxID ids.ID, outputIndex uint32) *txs.Tx {
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    10,
+		BlockchainID: chainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        inputTxID,
+				OutputIndex: outputIndex,
+			},
+			Asset: avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: 1000,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+	}}}
+	require.NoError(t, tx.SignSECP256K1Fx(parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+	return tx
+}
+
+func TestAddRemove(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mempoolIntf, err := New("mempool", registerer)
+	require.NoError(err)
+	m := mempoolIntf.(*mempool)
+
+	parser := newTestParser(t)
+	tx0 := createTestTx(t, parser, ids.GenerateTestID(), 0)
+	tx1 := createTestTx(t, parser, ids.GenerateTestID(), 0)
+
+	require.NoError(m.Add(tx0))
+	require.NoError(m.Add(tx1))
+	require.ErrorIs(m.Add(tx0), ErrDuplicateTx)
+
+	require.True(m.Has(tx0.ID()))
+	require.Equal(tx1, m.Get(tx1.ID()))
+	require.Equal(2, m.Len())
+	require.Equal([]*txs.Tx{tx0, tx1}, m.List())
+	require.Equal(maxMempoolSize-len(tx0.Bytes())-len(tx1.Bytes()), m.bytesAvailable)
+
+	m.Remove([]*txs.Tx{tx0})
+	require.False(m.Has(tx0.ID()))
+	require.Nil(m.Get(tx0.ID()))
+	require.Equal([]*txs.Tx{tx1}, m.List())
+	require.Equal(maxMempoolSize-len(tx1.Bytes()), m.bytesAvailable)
+
+	// Removing a tx that isn't in the mempool is a no-op
+	m.Remove([]*txs.Tx{tx0})
+	require.Equal(1, m.Len())
+}
+
+func TestConflictingTxs(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	m, err := New("mempool", registerer)
+	require.NoError(err)
+
+	parser := newTestParser(t)
+	inputTxID := ids.GenerateTestID()
+	tx := createTestTx(t, parser, inputTxID, 0)
+	conflictingTx := createTestTx(t, parser, inputTxID, 0)
+	conflictingTx.Unsigned.(*txs.BaseTx).Memo = []byte{1}
+	require.NoError(conflictingTx.SignSECP256K1Fx(parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+	require.NotEqual(tx.ID(), conflictingTx.ID())
+
+	require.NoError(m.Add(tx))
+	require.ErrorIs(m.Add(conflictingTx), ErrConflictsWithOtherTx)
+
+	// Once the tx is removed, its inputs can be consumed by another tx
+	m.Remove([]*txs.Tx{tx})
+	require.NoError(m.Add(conflictingTx))
+}
+
+func TestMaxMempoolSizeHandling(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mempoolIntf, err := New("mempool", registerer)
+	require.NoError(err)
+	m := mempoolIntf.(*mempool)
+
+	parser := newTestParser(t)
+	tx := createTestTx(t, parser, ids.GenerateTestID(), 0)
+
+	// shortcut to simulated almost filled mempool
+	m.bytesAvailable = len(tx.Bytes()) - 1
+	require.ErrorIs(m.Add(tx), ErrMempoolFull)
+
+	// shortcut to simulated almost filled mempool
+	m.bytesAvailable = len(tx.Bytes())
+	require.NoError(m.Add(tx))
+	require.Zero(m.bytesAvailable)
+}
+
+func TestTxTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	m, err := New("mempool", registerer)
+	require.NoError(err)
+
+	parser := newTestParser(t)
+	tx := createTestTx(t, parser, ids.GenerateTestID(), 0)
+	tx.Unsigned.(*txs.BaseTx).Memo = make([]byte, targetTxSize)
+	require.NoError(tx.SignSECP256K1Fx(parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+
+	require.ErrorIs(m.Add(tx), ErrTxTooLarge)
+}
+
+func TestDroppedTxs(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	m, err := New("mempool", registerer)
+	require.NoError(err)
+
+	parser := newTestParser(t)
+	tx := createTestTx(t, parser, ids.GenerateTestID(), 0)
+	txID := tx.ID()
+
+	_, dropped := m.GetDropReason(txID)
+	require.False(dropped)
+
+	m.MarkDropped(txID, "dropped for testing")
+	reason, dropped := m.GetDropReason(txID)
+	require.True(dropped)
+	require.Equal("dropped for testing", reason)
+
+	// An explicitly added tx must not be marked as dropped.
+	require.NoError(m.Add(tx))
+	_, dropped = m.GetDropReason(txID)
+	require.False(dropped)
+}
@@ -4,6 +4,7 @@
 package txs
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
@@ -15,11 +16,22 @@ import (
 	"github.com/kukrer/savannahnode/utils/timer/mockable"
 	"github.com/kukrer/savannahnode/utils/wrappers"
 	"github.com/kukrer/savannahnode/vms/avm/fxs"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
 
-const CodecVersion = 0
+const (
+	CodecVersion = 0
 
-var _ Parser = &parser{}
+	// numTxTypes is the number of tx types registered before the types of
+	// the fxs
+	numTxTypes = 5
+)
+
+var (
+	_ Parser = &parser{}
+
+	errMisalignedFx = errors.New("fx types can't be registered at the expected type IDs")
+)
 
 type Parser interface {
 	Codec() codec.Manager
@@ -37,12 +49,12 @@ type parser struct {
 	gcm codec.Manager
 }
 
-func NewParser(fxs []fxs.Fx) (Parser, error) {
+func NewParser(fxList []fxs.Fx) (Parser, error) {
 	return NewCustomParser(
 		make(map[reflect.Type]int),
 		&mockable.Clock{},
 		logging.NoLog{},
-		fxs,
+		fxList,
 	)
 }
 
@@ -50,7 +62,7 @@ func NewCustomParser(
 	typeToFxIndex map[reflect.Type]int,
 	clock *mockable.Clock,
 	log logging.Logger,
-	fxs []fxs.Fx,
+	fxList []fxs.Fx,
 ) (Parser, error) {
 	gc := linearcodec.New([]string{reflectcodec.DefaultTagName}, 1<<20)
 	c := linearcodec.NewDefault()
@@ -83,15 +95,77 @@ func NewCustomParser(
 		clock:         clock,
 		log:           log,
 	}
-	for i, fx := range fxs {
-		vm.codecRegistry = &codecRegistry{
+	// secpIndex is the index of the secp256k1fx, whose weighted owners types
+	// are registered at a fixed type ID, in type ID order with the types of
+	// the aligned fxs.
+	secpIndex := -1
+	for i, fx := range fxList {
+		if _, ok := fx.(*secp256k1fx.Fx); ok {
+			secpIndex = i
+			break
+		}
+	}
+
+	nextTypeID := uint32(numTxTypes)
+	skipTo := func(firstTypeID uint32, name string) error {
+		if firstTypeID < nextTypeID {
+			return fmt.Errorf("%w: %s expects type ID %d but type ID %d is already registered",
+				errMisalignedFx,
+				name,
+				firstTypeID,
+				nextTypeID-1,
+			)
+		}
+		gc.SkipRegistrations(int(firstTypeID - nextTypeID))
+		c.SkipRegistrations(int(firstTypeID - nextTypeID))
+		nextTypeID = firstTypeID
+		return nil
+	}
+	registerWeightedTypes := func() error {
+		if err := skipTo(secp256k1fx.WeightedFirstTypeID, "weighted owners"); err != nil {
+			return err
+		}
+		registry := &codecRegistry{
+			codecs:      []codec.Registry{gc, c},
+			index:       secpIndex,
+			typeToIndex: vm.typeToFxIndex,
+		}
+		if err := secp256k1fx.RegisterWeightedTypes(registry); err != nil {
+			return err
+		}
+		nextTypeID += registry.numTypes
+		secpIndex = -1
+		return nil
+	}
+
+	for i, fx := range fxList {
+		if alignedFx, ok := fx.(fxs.AlignedFx); ok {
+			firstTypeID := alignedFx.FirstTypeID()
+			if secpIndex >= 0 && firstTypeID > secp256k1fx.WeightedFirstTypeID {
+				if err := registerWeightedTypes(); err != nil {
+					return nil, err
+				}
+			}
+			if err := skipTo(firstTypeID, fmt.Sprintf("fx %d", i)); err != nil {
+				return nil, err
+			}
+		}
+
+		registry := &codecRegistry{
 			codecs:      []codec.Registry{gc, c},
 			index:       i,
 			typeToIndex: vm.typeToFxIndex,
 		}
+		vm.codecRegistry = registry
 		if err := fx.Initialize(vm); err != nil {
 			return nil, err
 		}
+		nextTypeID += registry.numTypes
+	}
+	if secpIndex >= 0 {
+		if err := registerWeightedTypes(); err != nil {
+			return nil, err
+		}
 	}
 	return &parser{
 		cm:  cm,
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/utils/wrappers"
+	"github.com/kukrer/savannahnode/vms/avm/fxs"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+// misalignedFx expects its types to be registered at the type IDs of the
+// secp256k1fx types
+type misalignedFx struct{ htlcfx.Fx }
+
+func (*misalignedFx) FirstTypeID() uint32 { return numTxTypes }
+
+func TestParserAlignedFx(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&htlcfx.Fx{},
+	})
+	require.NoError(err)
+
+	_, err = NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&misalignedFx{},
+	})
+	require.ErrorIs(err, errMisalignedFx)
+}
+
+// Ensure that the types of the policyfx are registered after the weighted
+// owners types, whether or not the htlcfx is enabled
+func TestParserPolicyFxTypeIDs(t *testing.T) {
+	for _, fxList := range [][]fxs.Fx{
+		{
+			&secp256k1fx.Fx{},
+			&policyfx.Fx{},
+		},
+		{
+			&secp256k1fx.Fx{},
+			&htlcfx.Fx{},
+			&policyfx.Fx{},
+		},
+	} {
+		require := require.New(t)
+
+		parser, err := NewParser(fxList)
+		require.NoError(err)
+
+		for typeID, out := range map[uint32]interface{}{
+			secp256k1fx.WeightedFirstTypeID: &secp256k1fx.WeightedTransferOutput{},
+			policyfx.FirstTypeID:            &policyfx.PolicyOutput{},
+		} {
+			outIntf := out.(verify.State)
+			bytes, err := parser.Codec().Marshal(CodecVersion, &outIntf)
+			require.NoError(err)
+
+			p := wrappers.Packer{Bytes: bytes, Offset: wrappers.ShortLen}
+			require.Equal(typeID, p.UnpackInt())
+		}
+	}
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/kukrer/savannahnode/utils/hashing"
 	"github.com/kukrer/savannahnode/vms/avm/fxs"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
@@ -197,3 +199,61 @@ func (t *Tx) SignNFTFx(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R
 	t.Initialize(unsignedBytes, signedBytes)
 	return nil
 }
+
+func (t *Tx) SignHTLCFx(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) error {
+	unsignedBytes, err := c.Marshal(CodecVersion, &t.Unsigned)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	hash := hashing.ComputeHash256(unsignedBytes)
+	for _, keys := range signers {
+		cred := &htlcfx.Credential{Credential: secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(keys)),
+		}}
+		for i, key := range keys {
+			sig, err := key.SignHash(hash)
+			if err != nil {
+				return fmt.Errorf("problem creating transaction: %w", err)
+			}
+			copy(cred.Sigs[i][:], sig)
+		}
+		t.Creds = append(t.Creds, &fxs.FxCredential{Verifiable: cred})
+	}
+
+	signedBytes, err := c.Marshal(CodecVersion, t)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	t.Initialize(unsignedBytes, signedBytes)
+	return nil
+}
+
+func (t *Tx) SignPolicyFx(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) error {
+	unsignedBytes, err := c.Marshal(CodecVersion, &t.Unsigned)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	hash := hashing.ComputeHash256(unsignedBytes)
+	for _, keys := range signers {
+		cred := &policyfx.Credential{Credential: secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(keys)),
+		}}
+		for i, key := range keys {
+			sig, err := key.SignHash(hash)
+			if err != nil {
+				return fmt.Errorf("problem creating transaction: %w", err)
+			}
+			copy(cred.Sigs[i][:], sig)
+		}
+		t.Creds = append(t.Creds, &fxs.FxCredential{Verifiable: cred})
+	}
+
+	signedBytes, err := c.Marshal(CodecVersion, t)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	t.Initialize(unsignedBytes, signedBytes)
+	return nil
+}
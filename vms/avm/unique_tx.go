@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/chains/atomic"
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow/choices"
 	"github.com/kukrer/savannahnode/snow/consensus/snowstorm"
@@ -118,12 +119,31 @@ func (tx *UniqueTx) Key() interface{} { return tx.txID }
 
 // Accept is called when the transaction was finalized as accepted by consensus
 func (tx *UniqueTx) Accept() error {
+	defer tx.vm.db.Abort()
+
+	requests := make(map[ids.ID]*atomic.Requests)
+	if err := tx.accept(requests); err != nil {
+		return err
+	}
+
+	txID := tx.ID()
+	if err := tx.vm.commitWithAtomicRequests(requests); err != nil {
+		return fmt.Errorf("couldn't commit tx %s: %w", txID, err)
+	}
+
+	tx.onAccepted()
+	return tx.vm.loadBurnedFees()
+}
+
+// accept writes the state changes of the transaction to the database without
+// committing them. The shared memory requests of the transaction are added to
+// [requests].
+func (tx *UniqueTx) accept(requests map[ids.ID]*atomic.Requests) error {
 	if s := tx.Status(); s != choices.Processing {
 		return fmt.Errorf("transaction has invalid status: %s", s)
 	}
 
 	txID := tx.ID()
-	defer tx.vm.db.Abort()
 
 	// Fetch the input UTXOs
 	inputUTXOIDs := tx.InputUTXOs()
@@ -171,26 +191,28 @@ func (tx *UniqueTx) Accept() error {
 		return fmt.Errorf("couldn't set status of tx %s: %w", txID, err)
 	}
 
-	commitBatch, err := tx.vm.db.CommitBatch()
-	if err != nil {
-		return fmt.Errorf("couldn't create commitBatch while processing tx %s: %w", txID, err)
-	}
-
-	err = tx.Tx.Unsigned.Visit(&executeTx{
-		tx:           tx.Tx,
-		batch:        commitBatch,
-		sharedMemory: tx.vm.ctx.SharedMemory,
-		parser:       tx.vm.parser,
+	err := tx.Tx.Unsigned.Visit(&executeTx{
+		tx:       tx.Tx,
+		requests: requests,
+		parser:   tx.vm.parser,
+		state:    tx.vm.state,
 	})
 	if err != nil {
 		return fmt.Errorf("ExecuteWithSideEffects erred while processing tx %s: %w", txID, err)
 	}
+	if err := tx.vm.addBurnedFees(tx.Unsigned); err != nil {
+		return fmt.Errorf("couldn't add the fees burned by tx %s: %w", txID, err)
+	}
+	return nil
+}
 
+// onAccepted notifies the subscribers of the transaction once its acceptance
+// has been committed
+func (tx *UniqueTx) onAccepted() {
 	tx.vm.pubsub.Publish(NewPubSubFilterer(tx.Tx))
-	tx.vm.walletService.decided(txID)
+	tx.vm.walletService.decided(tx.ID())
 
 	tx.deps = nil // Needed to prevent a memory leak
-	return nil
 }
 
 // Reject is called when the transaction was finalized as rejected by consensus
@@ -375,8 +397,14 @@ func (tx *UniqueTx) SemanticVerify() error {
 		return tx.validity
 	}
 
-	return tx.Unsigned.Visit(&txSemanticVerify{
+	verifier := &txSemanticVerify{
 		tx: tx.Tx,
 		vm: tx.vm,
-	})
+	}
+	if tx.vm.linearized {
+		// The tx will be verified again at the timestamp of the block that
+		// includes it.
+		verifier.timestamp = tx.vm.clock.Time()
+	}
+	return tx.Unsigned.Visit(verifier)
 }
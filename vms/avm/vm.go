@@ -19,6 +19,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/chains/atomic"
 	"github.com/kukrer/savannahnode/database"
 	"github.com/kukrer/savannahnode/database/manager"
 	"github.com/kukrer/savannahnode/database/versiondb"
@@ -36,11 +37,13 @@ import (
 	"github.com/kukrer/savannahnode/version"
 	"github.com/kukrer/savannahnode/vms/avm/states"
 	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/avm/txs/mempool"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/index"
 	"github.com/kukrer/savannahnode/vms/components/keystore"
 	"github.com/kukrer/savannahnode/vms/components/verify"
 	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 
 	safemath "github.com/kukrer/savannahnode/utils/math"
@@ -52,6 +55,10 @@ const (
 	batchSize          = 30
 	assetToFxCacheSize = 1024
 	txDeduplicatorSize = 8192
+
+	// stopVertexRetryFrequency is how often the stop vertex is issued again
+	// until the chain is linearized
+	stopVertexRetryFrequency = time.Minute
 )
 
 var (
@@ -60,6 +67,10 @@ var (
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 	errInsufficientFunds         = errors.New("insufficient funds")
+	errFrozenUTXO                = errors.New("utxo is frozen")
+	errNotFrozen                 = errors.New("only frozen utxos can be clawed back")
+	errAlreadyLinearized         = errors.New("chain is already linearized")
+	errPolicyNotLinearized       = errors.New("asset policy operations are only valid once the chain is linearized")
 
 	_ vertex.DAGVM = &VM{}
 )
@@ -96,9 +107,16 @@ type VM struct {
 	// Transaction issuing
 	timer        *timer.Timer
 	batchTimeout time.Duration
-	txs          []snowstorm.Tx
 	toEngine     chan<- common.Message
 
+	// Issues the stop vertex once the chain reaches the X-chain migration
+	// time
+	stopVertexTimer *timer.Timer
+
+	// Transactions that haven't been issued into consensus yet
+	mempool mempool.Mempool
+	network Network
+
 	baseDB database.Database
 	db     *versiondb.Database
 
@@ -110,6 +128,19 @@ type VM struct {
 	addressTxsIndexer index.AddressTxsIndexer
 
 	uniqueTxs cache.Deduplicator
+
+	// Set to true once the chain is linearized onto Snowman consensus
+	linearized bool
+	// ID of the last accepted block
+	lastAcceptedID ids.ID
+	// ID of the preferred block
+	preferred ids.ID
+	// Blocks that have been verified but not yet decided
+	verifiedBlocks map[ids.ID]*Block
+
+	// AVAX burned by the accepted txs, as of the last commit. Accessed
+	// atomically as it's read without holding the context lock.
+	burnedFees uint64
 }
 
 func (vm *VM) Connected(nodeID ids.NodeID, nodeVersion *version.Application) error {
@@ -139,7 +170,7 @@ func (vm *VM) Initialize(
 	configBytes []byte,
 	toEngine chan<- common.Message,
 	fxs []*common.Fx,
-	_ common.AppSender,
+	appSender common.AppSender,
 ) error {
 	avmConfig := Config{}
 	if len(configBytes) > 0 {
@@ -202,7 +233,7 @@ func (vm *VM) Initialize(
 
 	vm.AtomicUTXOManager = avax.NewAtomicUTXOManager(ctx.SharedMemory, vm.parser.Codec())
 
-	state, err := states.New(vm.db, vm.parser, registerer)
+	state, err := states.New(vm.db, vm.parser, registerer, ctx.AVAXAssetID)
 	if err != nil {
 		return err
 	}
@@ -213,6 +244,13 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	if err := vm.loadBurnedFees(); err != nil {
+		return fmt.Errorf("failed to load burned fees: %w", err)
+	}
+	if vm.BurnRegistry != nil {
+		vm.BurnRegistry.Register(ctx.ChainID, vm)
+	}
+
 	vm.timer = timer.NewTimer(func() {
 		ctx.Lock.Lock()
 		defer ctx.Lock.Unlock()
@@ -222,9 +260,24 @@ func (vm *VM) Initialize(
 	go ctx.Log.RecoverAndPanic(vm.timer.Dispatch)
 	vm.batchTimeout = batchTimeout
 
+	vm.stopVertexTimer = timer.NewTimer(func() {
+		ctx.Lock.Lock()
+		defer ctx.Lock.Unlock()
+
+		vm.issueMigrationStopVertex()
+	})
+	go ctx.Log.RecoverAndPanic(vm.stopVertexTimer.Dispatch)
+
 	vm.uniqueTxs = &cache.EvictableLRU{
 		Size: txDeduplicatorSize,
 	}
+
+	vm.mempool, err = mempool.New("mempool", registerer)
+	if err != nil {
+		return fmt.Errorf("failed to create mempool: %w", err)
+	}
+	vm.network = newNetwork(vm, appSender)
+
 	vm.walletService.vm = vm
 	vm.walletService.pendingTxMap = make(map[ids.ID]*list.Element)
 	vm.walletService.pendingTxOrdering = list.New()
@@ -248,6 +301,8 @@ func (vm *VM) Initialize(
 
 // onBootstrapStarted is called by the consensus engine when it starts bootstrapping this chain
 func (vm *VM) onBootstrapStarted() error {
+	// The chain is bootstrapped again when it is linearized
+	vm.bootstrapped = false
 	for _, fx := range vm.fxs {
 		if err := fx.Fx.Bootstrapping(); err != nil {
 			return err
@@ -263,6 +318,13 @@ func (vm *VM) onNormalOperationsStarted() error {
 		}
 	}
 	vm.bootstrapped = true
+
+	// The chain is linearized once the stop vertex is accepted, which is
+	// issued as soon as the chain reaches the X-chain migration time. The stop
+	// vertex is never issued automatically if the migration isn't scheduled.
+	if !vm.linearized && version.IsXChainMigrationScheduled(vm.XChainMigrationTime) {
+		vm.stopVertexTimer.SetTimeoutIn(vm.XChainMigrationTime.Sub(vm.clock.Time()))
+	}
 	return nil
 }
 
@@ -286,6 +348,7 @@ func (vm *VM) Shutdown() error {
 	// So, the lock must be released before stopping the timer.
 	vm.ctx.Lock.Unlock()
 	vm.timer.Stop()
+	vm.stopVertexTimer.Stop()
 	vm.ctx.Lock.Lock()
 
 	return vm.baseDB.Close()
@@ -339,9 +402,18 @@ func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
 func (vm *VM) PendingTxs() []snowstorm.Tx {
 	vm.timer.Cancel()
 
-	txs := vm.txs
-	vm.txs = nil
-	return txs
+	mempoolTxs := vm.mempool.List()
+	vm.mempool.Remove(mempoolTxs)
+
+	pendingTxs := make([]snowstorm.Tx, 0, len(mempoolTxs))
+	for _, tx := range mempoolTxs {
+		uniqueTx := vm.uniqueTx(tx)
+		if uniqueTx.Status() != choices.Processing {
+			continue
+		}
+		pendingTxs = append(pendingTxs, uniqueTx)
+	}
+	return pendingTxs
 }
 
 func (vm *VM) ParseTx(b []byte) (snowstorm.Tx, error) {
@@ -372,18 +444,20 @@ func (vm *VM) IssueTx(b []byte) (ids.ID, error) {
 	if !vm.bootstrapped {
 		return ids.ID{}, errBootstrapping
 	}
-	tx, err := vm.parseTx(b)
+	tx, err := vm.parser.Parse(b)
 	if err != nil {
 		return ids.ID{}, err
 	}
-	if err := tx.verifyWithoutCacheWrites(); err != nil {
+	if err := vm.issueTx(tx); err != nil {
 		return ids.ID{}, err
 	}
-	vm.issueTx(tx)
 	return tx.ID(), nil
 }
 
 func (vm *VM) issueStopVertex() error {
+	if vm.linearized {
+		return errAlreadyLinearized
+	}
 	select {
 	case vm.toEngine <- common.StopVertex:
 	default:
@@ -398,10 +472,23 @@ func (vm *VM) issueStopVertex() error {
  ******************************************************************************
  */
 
+// issueMigrationStopVertex issues the stop vertex once the X-chain migration
+// time is reached. The stop vertex is issued again until it is accepted, as
+// the message to the engine may be dropped or the vertex may not be accepted.
+func (vm *VM) issueMigrationStopVertex() {
+	if vm.linearized {
+		return
+	}
+
+	vm.ctx.Log.Info("reached the X-chain migration time, issuing the stop vertex")
+	_ = vm.issueStopVertex()
+	vm.stopVertexTimer.SetTimeoutIn(stopVertexRetryFrequency)
+}
+
 // FlushTxs into consensus
 func (vm *VM) FlushTxs() {
 	vm.timer.Cancel()
-	if len(vm.txs) != 0 {
+	if vm.mempool.Len() != 0 {
 		select {
 		case vm.toEngine <- common.PendingTxs:
 		default:
@@ -495,38 +582,118 @@ func (vm *VM) parseTx(bytes []byte) (*UniqueTx, error) {
 		return nil, err
 	}
 
-	tx := &UniqueTx{
+	tx := vm.uniqueTx(rawTx)
+	if err := tx.SyntacticVerify(); err != nil {
+		return nil, err
+	}
+	return tx, vm.storeTx(tx)
+}
+
+// uniqueTx wraps [tx] into a de-duplicated UniqueTx
+func (vm *VM) uniqueTx(tx *txs.Tx) *UniqueTx {
+	return &UniqueTx{
 		TxCachedState: &TxCachedState{
-			Tx: rawTx,
+			Tx: tx,
 		},
 		vm:   vm,
-		txID: rawTx.ID(),
+		txID: tx.ID(),
 	}
-	if err := tx.SyntacticVerify(); err != nil {
-		return nil, err
+}
+
+// storeTx persists [tx] as processing if it isn't known yet
+func (vm *VM) storeTx(tx *UniqueTx) error {
+	if tx.Status() != choices.Unknown {
+		return nil
+	}
+	if err := vm.state.PutTx(tx.ID(), tx.Tx); err != nil {
+		return err
+	}
+	if err := tx.setStatus(choices.Processing); err != nil {
+		return err
 	}
+	return vm.db.Commit()
+}
 
-	if tx.Status() == choices.Unknown {
-		if err := vm.state.PutTx(tx.ID(), tx.Tx); err != nil {
-			return nil, err
-		}
-		if err := tx.setStatus(choices.Processing); err != nil {
-			return nil, err
-		}
-		return tx, vm.db.Commit()
+// issueTx verifies [tx], adds it to the mempool and gossips it to the peers.
+// Issuing a tx that is already accepted or in the mempool is a no-op.
+func (vm *VM) issueTx(tx *txs.Tx) error {
+	txID := tx.ID()
+	if vm.mempool.Has(txID) {
+		return nil
 	}
 
-	return tx, nil
-}
+	uniqueTx := vm.uniqueTx(tx)
+	switch uniqueTx.Status() {
+	case choices.Accepted:
+		return nil
+	case choices.Rejected:
+		return errRejectedTx
+	}
+	if err := uniqueTx.SemanticVerify(); err != nil {
+		return err
+	}
+
+	if err := vm.mempool.Add(tx); err != nil {
+		return err
+	}
+	if err := vm.storeTx(uniqueTx); err != nil {
+		vm.mempool.Remove([]*txs.Tx{tx})
+		return err
+	}
+
+	if err := vm.network.GossipTx(tx); err != nil {
+		vm.ctx.Log.Debug("failed to gossip tx",
+			zap.Stringer("txID", txID),
+			zap.Error(err),
+		)
+	}
 
-func (vm *VM) issueTx(tx snowstorm.Tx) {
-	vm.txs = append(vm.txs, tx)
-	switch {
-	case len(vm.txs) == batchSize:
+	if vm.linearized {
+		vm.notifyBlockReady()
+		return nil
+	}
+	switch vm.mempool.Len() {
+	case batchSize:
 		vm.FlushTxs()
-	case len(vm.txs) == 1:
+	case 1:
 		vm.timer.SetTimeoutIn(vm.batchTimeout)
 	}
+	return nil
+}
+
+// missingDependency returns the ID of a tx that [tx] consumes an output of
+// but that isn't known by this node.
+func (vm *VM) missingDependency(tx *txs.Tx) (ids.ID, bool) {
+	for _, utxoID := range tx.Unsigned.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		if _, err := vm.state.GetUTXO(utxoID.InputID()); err == nil {
+			continue
+		}
+		inputTxID, _ := utxoID.InputSource()
+		inputTx := &UniqueTx{
+			vm:   vm,
+			txID: inputTxID,
+		}
+		if inputTx.Status() == choices.Unknown {
+			return inputTxID, true
+		}
+	}
+	return ids.Empty, false
+}
+
+// commitWithAtomicRequests commits the pending state changes along with
+// [requests] to shared memory atomically.
+func (vm *VM) commitWithAtomicRequests(requests map[ids.ID]*atomic.Requests) error {
+	batch, err := vm.db.CommitBatch()
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		return batch.Write()
+	}
+	return vm.ctx.SharedMemory.Apply(requests, batch)
 }
 
 func (vm *VM) getUTXO(utxoID *avax.UTXOID) (*avax.UTXO, error) {
@@ -542,9 +709,16 @@ func (vm *VM) getUTXO(utxoID *avax.UTXOID) (*avax.UTXO, error) {
 		txID: inputTx,
 	}
 
-	if err := parent.verifyWithoutCacheWrites(); err != nil {
-		return nil, errMissingUTXO
-	} else if status := parent.Status(); status.Decided() {
+	// Once linearized, the txs are verified against the blocks that include
+	// them, which already ensure that the parent is accepted or included.
+	// Verifying the parent again here, outside of its block, could give a
+	// different result.
+	if !vm.linearized {
+		if err := parent.verifyWithoutCacheWrites(); err != nil {
+			return nil, errMissingUTXO
+		}
+	}
+	if status := parent.Status(); status != choices.Processing {
 		return nil, errMissingUTXO
 	}
 
@@ -565,10 +739,10 @@ func (vm *VM) getFx(val interface{}) (int, error) {
 }
 
 func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
-	// Check cache to see whether this asset supports this fx
+	// Check cache to see which fxs this asset supports
 	fxIDsIntf, assetInCache := vm.assetToFxCache.Get(assetID)
 	if assetInCache {
-		return fxIDsIntf.(ids.BitSet).Contains(uint(fxID))
+		return vm.supportsFx(fxIDsIntf.(ids.BitSet), fxID)
 	}
 	// Caches doesn't say whether this asset support this fx.
 	// Get the tx that created the asset and check.
@@ -586,16 +760,32 @@ func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
 	}
 	fxIDs := ids.BitSet(0)
 	for _, state := range createAssetTx.States {
-		if state.FxIndex == uint32(fxID) {
-			// Cache that this asset supports this fx
-			fxIDs.Add(uint(fxID))
-		}
+		fxIDs.Add(uint(state.FxIndex))
 	}
+	// Cache the fxs this asset supports
 	vm.assetToFxCache.Put(assetID, fxIDs)
-	return fxIDs.Contains(uint(fxID))
+	return vm.supportsFx(fxIDs, fxID)
+}
+
+// supportsFx returns true if the fx [fxID] can be used with an asset that
+// supports the fxs [fxIDs]. An fx that extends the secp256k1fx can be used with
+// any asset that supports the secp256k1fx.
+func (vm *VM) supportsFx(fxIDs ids.BitSet, fxID int) bool {
+	if fxIDs.Contains(uint(fxID)) {
+		return true
+	}
+	if _, ok := vm.fxs[fxID].Fx.(extensions.ExtensionFx); !ok {
+		return false
+	}
+	for i, fx := range vm.fxs {
+		if _, ok := fx.Fx.(*secp256k1fx.Fx); ok && fxIDs.Contains(uint(i)) {
+			return true
+		}
+	}
+	return false
 }
 
-func (vm *VM) verifyTransferOfUTXO(utx txs.UnsignedTx, in *avax.TransferableInput, cred verify.Verifiable, utxo *avax.UTXO) error {
+func (vm *VM) verifyTransferOfUTXO(utx secp256k1fx.UnsignedTx, in *avax.TransferableInput, cred verify.Verifiable, utxo *avax.UTXO) error {
 	fxIndex, err := vm.getFx(cred)
 	if err != nil {
 		return err
@@ -615,19 +805,36 @@ func (vm *VM) verifyTransferOfUTXO(utx txs.UnsignedTx, in *avax.TransferableInpu
 	return fx.VerifyTransfer(utx, in.In, cred, utxo.Out)
 }
 
-func (vm *VM) verifyTransfer(tx txs.UnsignedTx, in *avax.TransferableInput, cred verify.Verifiable) error {
+func (vm *VM) verifyTransfer(freezes states.FreezeState, tx secp256k1fx.UnsignedTx, in *avax.TransferableInput, cred verify.Verifiable) error {
 	utxo, err := vm.getUTXO(&in.UTXOID)
 	if err != nil {
 		return err
 	}
+
+	frozen, err := vm.isFrozen(freezes, utxo)
+	if err != nil {
+		return err
+	}
+	if frozen {
+		return fmt.Errorf("%w: %s", errFrozenUTXO, in.InputID())
+	}
 	return vm.verifyTransferOfUTXO(tx, in, cred, utxo)
 }
 
-func (vm *VM) verifyOperation(tx *txs.OperationTx, op *txs.Operation, cred verify.Verifiable) error {
+func (vm *VM) verifyOperation(freezes states.FreezeState, tx *txs.OperationTx, op *txs.Operation, cred verify.Verifiable) error {
+	// Freezes are applied in the order of the blocks, which only exists once
+	// the chain is linearized.
+	_, isFreeze := op.Op.(*policyfx.FreezeOperation)
+	_, isClawback := op.Op.(*policyfx.ClawbackOperation)
+	if (isFreeze || isClawback) && !vm.linearized {
+		return errPolicyNotLinearized
+	}
+
 	opAssetID := op.AssetID()
 
 	numUTXOs := len(op.UTXOIDs)
 	utxos := make([]interface{}, numUTXOs)
+	inputUTXOs := make([]*avax.UTXO, numUTXOs)
 	for i, utxoID := range op.UTXOIDs {
 		utxo, err := vm.getUTXO(utxoID)
 		if err != nil {
@@ -639,6 +846,7 @@ func (vm *VM) verifyOperation(tx *txs.OperationTx, op *txs.Operation, cred verif
 			return errAssetIDMismatch
 		}
 		utxos[i] = utxo.Out
+		inputUTXOs[i] = utxo
 	}
 
 	fxIndex, err := vm.getFx(op.Op)
@@ -650,9 +858,73 @@ func (vm *VM) verifyOperation(tx *txs.OperationTx, op *txs.Operation, cred verif
 	if !vm.verifyFxUsage(fxIndex, opAssetID) {
 		return errIncompatibleFx
 	}
+
+	// A freeze may target frozen funds and a clawback may only recover frozen
+	// funds, while any other operation must not consume them.
+	for i, utxoID := range op.UTXOIDs {
+		if _, isPolicy := utxos[i].(*policyfx.PolicyOutput); isPolicy || isFreeze {
+			continue
+		}
+		frozen, err := vm.isFrozen(freezes, inputUTXOs[i])
+		if err != nil {
+			return err
+		}
+		switch {
+		case isClawback && !frozen:
+			return fmt.Errorf("%w: %s", errNotFrozen, utxoID.InputID())
+		case !isClawback && frozen:
+			return fmt.Errorf("%w: %s", errFrozenUTXO, utxoID.InputID())
+		}
+	}
 	return fx.VerifyOperation(tx, op.Op, cred, utxos)
 }
 
+// policyFxIndex returns the index of the policyfx, if it is enabled.
+func (vm *VM) policyFxIndex() (int, bool) {
+	for i, fx := range vm.fxs {
+		if _, ok := fx.Fx.(*policyfx.Fx); ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// isFrozen returns true if, according to [freezes], the freeze authority of
+// the asset of [utxo] froze either the UTXO or one of the addresses that own
+// it.
+func (vm *VM) isFrozen(freezes states.FreezeState, utxo *avax.UTXO) (bool, error) {
+	policyIndex, ok := vm.policyFxIndex()
+	if !ok {
+		return false, nil
+	}
+
+	assetID := utxo.AssetID()
+	if !vm.verifyFxUsage(policyIndex, assetID) {
+		return false, nil
+	}
+
+	frozen, err := freezes.IsUTXOFrozen(assetID, utxo.InputID())
+	if err != nil || frozen {
+		return frozen, err
+	}
+
+	out, ok := utxo.Out.(avax.Addressable)
+	if !ok {
+		return false, nil
+	}
+	for _, addrBytes := range out.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return false, err
+		}
+		frozen, err := freezes.IsAddressFrozen(assetID, addr)
+		if err != nil || frozen {
+			return frozen, err
+		}
+	}
+	return false, nil
+}
+
 // LoadUser returns:
 // 1) The UTXOs that reference one or more addresses controlled by the given user
 // 2) A keychain that contains this user's keys
@@ -1046,24 +1318,20 @@ func (vm *VM) lookupAssetID(asset string) (ids.ID, error) {
 	return ids.ID{}, fmt.Errorf("asset '%s' not found", asset)
 }
 
-// This VM doesn't (currently) have any app-specific messages
 func (vm *VM) AppRequest(nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
-	return nil
+	return vm.network.AppRequest(nodeID, requestID, deadline, request)
 }
 
-// This VM doesn't (currently) have any app-specific messages
 func (vm *VM) AppResponse(nodeID ids.NodeID, requestID uint32, response []byte) error {
-	return nil
+	return vm.network.AppResponse(nodeID, requestID, response)
 }
 
-// This VM doesn't (currently) have any app-specific messages
 func (vm *VM) AppRequestFailed(nodeID ids.NodeID, requestID uint32) error {
-	return nil
+	return vm.network.AppRequestFailed(nodeID, requestID)
 }
 
-// This VM doesn't (currently) have any app-specific messages
 func (vm *VM) AppGossip(nodeID ids.NodeID, msg []byte) error {
-	return nil
+	return vm.network.AppGossip(nodeID, msg)
 }
 
 // UniqueTx de-duplicates the transaction.
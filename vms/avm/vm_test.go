@@ -23,31 +23,39 @@ import (
 	"github.com/kukrer/savannahnode/database/prefixdb"
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/snow/consensus/snowman"
 	"github.com/kukrer/savannahnode/snow/engine/common"
 	"github.com/kukrer/savannahnode/utils/cb58"
 	"github.com/kukrer/savannahnode/utils/constants"
 	"github.com/kukrer/savannahnode/utils/crypto"
 	"github.com/kukrer/savannahnode/utils/formatting"
 	"github.com/kukrer/savannahnode/utils/formatting/address"
+	"github.com/kukrer/savannahnode/utils/hashing"
 	"github.com/kukrer/savannahnode/utils/json"
 	"github.com/kukrer/savannahnode/utils/wrappers"
 	"github.com/kukrer/savannahnode/version"
+	"github.com/kukrer/savannahnode/vms/avm/blocks"
 	"github.com/kukrer/savannahnode/vms/avm/fxs"
 	"github.com/kukrer/savannahnode/vms/avm/states"
 	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/avm/txs/mempool"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
 
 var (
-	networkID         uint32 = 10
-	chainID                  = ids.ID{5, 4, 3, 2, 1}
-	testTxFee                = uint64(1000)
-	testBlueberryTime        = time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC)
-	startBalance             = uint64(50000)
+	networkID               uint32 = 10
+	chainID                        = ids.ID{5, 4, 3, 2, 1}
+	testTxFee                      = uint64(1000)
+	testBlueberryTime              = time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC)
+	testXChainMigrationTime        = time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC)
+	startBalance                   = uint64(50000)
 
 	keys  []*crypto.PrivateKeySECP256K1R
 	addrs []ids.ShortID // addrs[i] corresponds to keys[i]
@@ -303,9 +311,10 @@ func GenesisVMWithArgs(tb testing.TB, additionalFxs []*common.Fx, args *BuildGen
 
 	issuer := make(chan common.Message, 1)
 	vm := &VM{Factory: Factory{
-		TxFee:            testTxFee,
-		CreateAssetTxFee: testTxFee,
-		BlueberryTime:    testBlueberryTime,
+		TxFee:               testTxFee,
+		CreateAssetTxFee:    testTxFee,
+		BlueberryTime:       testBlueberryTime,
+		XChainMigrationTime: testXChainMigrationTime,
 	}}
 	configBytes, err := stdjson.Marshal(Config{IndexTransactions: true})
 	if err != nil {
@@ -331,7 +340,7 @@ func GenesisVMWithArgs(tb testing.TB, additionalFxs []*common.Fx, args *BuildGen
 			},
 			additionalFxs...,
 		),
-		nil,
+		&common.SenderTest{},
 	)
 	if err != nil {
 		tb.Fatal(err)
@@ -462,7 +471,7 @@ func setupIssueTx(t testing.TB) (chan common.Message, *VM, *snow.Context, []*txs
 }
 
 func TestInvalidGenesis(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -488,7 +497,7 @@ func TestInvalidGenesis(t *testing.T) {
 }
 
 func TestInvalidFx(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -517,7 +526,7 @@ func TestInvalidFx(t *testing.T) {
 }
 
 func TestFxInitializationFailure(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -582,8 +591,35 @@ func TestIssueTx(t *testing.T) {
 	}
 }
 
-// Test issuing a transaction that consumes a currently pending UTXO. The
-// transaction should be issued successfully.
+func TestBurnedFees(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	require.Zero(vm.BurnedFees())
+
+	// [newTx] consumes [startBalance] AVAX without producing any
+	newTx := NewTx(t, genesisBytes, vm)
+	parsedTx, err := vm.ParseTx(newTx.Bytes())
+	require.NoError(err)
+	require.NoError(parsedTx.Verify())
+	require.Zero(vm.BurnedFees())
+
+	require.NoError(parsedTx.Accept())
+	require.EqualValues(startBalance, vm.BurnedFees())
+
+	burnedFees, err := vm.state.GetBurnedFees()
+	require.NoError(err)
+	require.EqualValues(startBalance, burnedFees)
+}
+
+// Test issuing a transaction that consumes a UTXO already consumed by a
+// transaction of the mempool. The transaction should be refused.
 func TestIssueDependentTx(t *testing.T) {
 	issuer, vm, ctx, txs := setupIssueTx(t)
 	defer func() {
@@ -600,8 +636,8 @@ func TestIssueDependentTx(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if _, err := vm.IssueTx(secondTx.Bytes()); err != nil {
-		t.Fatal(err)
+	if _, err := vm.IssueTx(secondTx.Bytes()); !errors.Is(err, mempool.ErrConflictsWithOtherTx) {
+		t.Fatalf("Should have failed with %s but got %v", mempool.ErrConflictsWithOtherTx, err)
 	}
 	ctx.Lock.Unlock()
 
@@ -611,14 +647,14 @@ func TestIssueDependentTx(t *testing.T) {
 	}
 	ctx.Lock.Lock()
 
-	if txs := vm.PendingTxs(); len(txs) != 2 {
-		t.Fatalf("Should have returned %d tx(s)", 2)
+	if txs := vm.PendingTxs(); len(txs) != 1 {
+		t.Fatalf("Should have returned %d tx(s)", 1)
 	}
 }
 
 // Test issuing a transaction that creates an NFT family
 func TestIssueNFT(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -647,7 +683,7 @@ func TestIssueNFT(t *testing.T) {
 				Fx: &nftfx.Fx{},
 			},
 		},
-		nil,
+		&common.SenderTest{T: t},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -766,7 +802,7 @@ func TestIssueNFT(t *testing.T) {
 
 // Test issuing a transaction that creates an Property family
 func TestIssueProperty(t *testing.T) {
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx := NewContext(t)
 	ctx.Lock.Lock()
 	defer func() {
@@ -799,7 +835,7 @@ func TestIssueProperty(t *testing.T) {
 				Fx: &propertyfx.Fx{},
 			},
 		},
-		nil,
+		&common.SenderTest{T: t},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -966,6 +1002,599 @@ func setupTxFeeAssets(t *testing.T) ([]byte, chan common.Message, *VM, *atomic.M
 	return genesisBytes, issuer, vm, m
 }
 
+// Test locking AVAX in a hash time-locked output and claiming it with the
+// preimage. The deadline is checked against the timestamp of the block that
+// includes the claim.
+func TestIssueHTLC(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVMWithArgs(
+		t,
+		[]*common.Fx{{
+			ID: htlcfx.ID,
+			Fx: &htlcfx.Fx{},
+		}},
+		nil,
+	)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	_, toEngine := linearizeTestVM(t, vm)
+
+	avaxTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	preimage := []byte("secret")
+	lockedAmt := startBalance - testTxFee
+	deadline := vm.clock.Time().Add(time.Hour)
+
+	lockTx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        avaxTx.ID(),
+				OutputIndex: 2,
+			},
+			Asset: avax.Asset{ID: avaxTx.ID()},
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxTx.ID()},
+			Out: &htlcfx.TransferOutput{
+				Amt:      lockedAmt,
+				Hash:     hashing.ComputeHash256Array(preimage),
+				Deadline: uint64(deadline.Unix()),
+				Recipient: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[1].PublicKey().Address()},
+				},
+				Sender: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+				},
+			},
+		}},
+	}}}
+	require.NoError(lockTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+
+	_, err := vm.IssueTx(lockTx.Bytes())
+	require.NoError(err)
+	require.Equal(common.PendingTxs, <-toEngine)
+	lockBlk, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(lockBlk.Verify())
+	require.NoError(vm.SetPreference(lockBlk.ID()))
+	require.NoError(lockBlk.Accept())
+
+	newClaimTx := func(preimage []byte, key *crypto.PrivateKeySECP256K1R) *txs.Tx {
+		claimTx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{
+					TxID:        lockTx.ID(),
+					OutputIndex: 0,
+				},
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				In: &htlcfx.TransferInput{
+					Amt:      lockedAmt,
+					Preimage: preimage,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: lockedAmt - testTxFee,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[1].PublicKey().Address()},
+					},
+				},
+			}},
+		}}}
+		require.NoError(claimTx.SignHTLCFx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{key}}))
+		return claimTx
+	}
+
+	// The sender can't claim the output before the deadline
+	_, err = vm.IssueTx(newClaimTx(nil, keys[0]).Bytes())
+	require.Error(err, "should have failed to refund the output before the deadline")
+
+	// The recipient must provide the correct preimage
+	_, err = vm.IssueTx(newClaimTx([]byte("wrong"), keys[1]).Bytes())
+	require.Error(err, "should have failed to claim the output with the wrong preimage")
+
+	claimTx := newClaimTx(preimage, keys[1])
+	_, err = vm.IssueTx(claimTx.Bytes())
+	require.NoError(err)
+	require.Equal(common.PendingTxs, <-toEngine)
+	claimBlk, err := vm.BuildBlock()
+	require.NoError(err)
+
+	// Once the deadline passed on the local clock, the claim is still valid
+	// in the block built before it
+	vm.clock.Set(deadline.Add(time.Second))
+	require.NoError(claimBlk.Verify())
+
+	// But not in a block timestamped at the deadline
+	expiredBlk, err := blocks.NewStandardBlock(
+		lockBlk.ID(),
+		lockBlk.Height()+1,
+		deadline,
+		[]*txs.Tx{claimTx},
+	)
+	require.NoError(err)
+	parsedExpiredBlk, err := vm.ParseBlock(expiredBlk.Bytes())
+	require.NoError(err)
+	require.Error(parsedExpiredBlk.Verify())
+
+	require.NoError(vm.SetPreference(claimBlk.ID()))
+	require.NoError(claimBlk.Accept())
+	tx, err := vm.GetTx(claimTx.ID())
+	require.NoError(err)
+	require.Equal(choices.Accepted, tx.Status())
+}
+
+// Test locking AVAX in an output owned by weighted owners and spending it
+func TestIssueWeightedTx(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	avaxTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	lockedAmt := startBalance - testTxFee
+
+	// keys[0] has weight 2, keys[1] and keys[2] have weight 1
+	owners := secp256k1fx.WeightedOutputOwners{
+		Threshold: 3,
+		Addrs: []ids.ShortID{
+			keys[0].PublicKey().Address(),
+			keys[1].PublicKey().Address(),
+			keys[2].PublicKey().Address(),
+		},
+		Weights: []uint64{2, 1, 1},
+	}
+	owners.Sort()
+
+	lockTx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        avaxTx.ID(),
+				OutputIndex: 2,
+			},
+			Asset: avax.Asset{ID: avaxTx.ID()},
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxTx.ID()},
+			Out: &secp256k1fx.WeightedTransferOutput{
+				Amt:                  lockedAmt,
+				WeightedOutputOwners: owners,
+			},
+		}},
+	}}}
+	if err := lockTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := vm.IssueTx(lockTx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	newSpendTx := func(signers ...*crypto.PrivateKeySECP256K1R) *txs.Tx {
+		signerKeys := make(map[ids.ShortID]*crypto.PrivateKeySECP256K1R)
+		for _, signer := range signers {
+			signerKeys[signer.PublicKey().Address()] = signer
+		}
+		// The signatures must be ordered by the indices of the signers
+		sigIndices := []uint32{}
+		sortedSigners := []*crypto.PrivateKeySECP256K1R{}
+		for i, addr := range owners.Addrs {
+			if signer, ok := signerKeys[addr]; ok {
+				sigIndices = append(sigIndices, uint32(i))
+				sortedSigners = append(sortedSigners, signer)
+			}
+		}
+
+		spendTx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{
+					TxID:        lockTx.ID(),
+					OutputIndex: 0,
+				},
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				In: &secp256k1fx.TransferInput{
+					Amt: lockedAmt,
+					Input: secp256k1fx.Input{
+						SigIndices: sigIndices,
+					},
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: lockedAmt - testTxFee,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+					},
+				},
+			}},
+		}}}
+		if err := spendTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{sortedSigners}); err != nil {
+			t.Fatal(err)
+		}
+		return spendTx
+	}
+
+	// keys[0] alone doesn't reach the threshold
+	if _, err := vm.IssueTx(newSpendTx(keys[0]).Bytes()); err == nil {
+		t.Fatal("should have failed to spend the output without reaching the threshold")
+	}
+
+	// keys[2] isn't needed to reach the threshold
+	if _, err := vm.IssueTx(newSpendTx(keys[0], keys[1], keys[2]).Bytes()); err == nil {
+		t.Fatal("should have failed to spend the output with an unnecessary signer")
+	}
+
+	if _, err := vm.IssueTx(newSpendTx(keys[0], keys[1]).Bytes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test that weighted outputs can't be created nor spent before the Baobab
+// upgrade
+func TestIssueWeightedTxBeforeBaobab(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	avaxTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	owners := secp256k1fx.WeightedOutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+		Weights:   []uint64{1},
+	}
+	newTx := func(utxoID avax.UTXOID, amt uint64, out avax.TransferableOut) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: utxoID,
+				Asset:  avax.Asset{ID: avaxTx.ID()},
+				In: &secp256k1fx.TransferInput{
+					Amt: amt,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				Out:   out,
+			}},
+		}}}
+		require.NoError(tx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+		return tx
+	}
+
+	lockTx := newTx(
+		avax.UTXOID{
+			TxID:        avaxTx.ID(),
+			OutputIndex: 2,
+		},
+		startBalance,
+		&secp256k1fx.WeightedTransferOutput{
+			Amt:                  startBalance - testTxFee,
+			WeightedOutputOwners: owners,
+		},
+	)
+
+	// A weighted UTXO, as if it had been imported
+	weightedUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: avax.Asset{ID: avaxTx.ID()},
+		Out: &secp256k1fx.WeightedTransferOutput{
+			Amt:                  startBalance,
+			WeightedOutputOwners: owners,
+		},
+	}
+	require.NoError(vm.state.PutUTXO(weightedUTXO))
+	spendTx := newTx(
+		weightedUTXO.UTXOID,
+		startBalance,
+		&secp256k1fx.TransferOutput{
+			Amt: startBalance - testTxFee,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+			},
+		},
+	)
+
+	vm.BaobabTime = vm.clock.Time().Add(time.Hour)
+	_, err := vm.IssueTx(lockTx.Bytes())
+	require.ErrorIs(err, errUnsupportedBeforeBaobab)
+	_, err = vm.IssueTx(spendTx.Bytes())
+	require.ErrorIs(err, errUnsupportedBeforeBaobab)
+
+	vm.BaobabTime = vm.clock.Time()
+	_, err = vm.IssueTx(lockTx.Bytes())
+	require.NoError(err)
+	_, err = vm.IssueTx(spendTx.Bytes())
+	require.NoError(err)
+}
+
+// Test freezing funds with the asset policy and clawing them back. The freeze
+// is applied in the order of the blocks, before it is accepted.
+func TestIssueFreezeAndClawback(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVMWithArgs(
+		t,
+		[]*common.Fx{{
+			ID: policyfx.ID,
+			Fx: &policyfx.Fx{},
+		}},
+		nil,
+	)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	avaxTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	holder := keys[0]
+	authority := keys[1]
+	policy := policyfx.PolicyOutput{
+		Clawback: true,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{authority.PublicKey().Address()},
+		},
+	}
+	newOutput := func(amt uint64, key *crypto.PrivateKeySECP256K1R) *secp256k1fx.TransferOutput {
+		return &secp256k1fx.TransferOutput{
+			Amt: amt,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{key.PublicKey().Address()},
+			},
+		}
+	}
+	newInput := func(utxoID avax.UTXOID, assetID ids.ID, amt uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{
+			UTXOID: utxoID,
+			Asset:  avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: amt,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}
+	}
+	newBlock := func(parent snowman.Block, tx *txs.Tx) snowman.Block {
+		statelessBlk, err := blocks.NewStandardBlock(
+			parent.ID(),
+			parent.Height()+1,
+			parent.Timestamp(),
+			[]*txs.Tx{tx},
+		)
+		require.NoError(err)
+		blk, err := vm.ParseBlock(statelessBlk.Bytes())
+		require.NoError(err)
+		return blk
+	}
+
+	createAssetTx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{
+				newInput(avax.UTXOID{TxID: avaxTx.ID(), OutputIndex: 2}, avaxTx.ID(), startBalance),
+			},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				Out:   newOutput(startBalance-testTxFee, holder),
+			}},
+		}},
+		Name:         "Regulated",
+		Symbol:       "REG",
+		Denomination: 0,
+		States: []*txs.InitialState{
+			{
+				FxIndex: 0,
+				Outs:    []verify.State{newOutput(1000, holder)},
+			},
+			{
+				FxIndex: 2,
+				Outs:    []verify.State{&policy},
+			},
+		},
+	}}
+	require.NoError(createAssetTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{holder}}))
+	_, err := vm.IssueTx(createAssetTx.Bytes())
+	require.NoError(err)
+	parsedCreateAssetTx, err := vm.ParseTx(createAssetTx.Bytes())
+	require.NoError(err)
+	require.NoError(parsedCreateAssetTx.Accept())
+
+	assetID := createAssetTx.ID()
+	heldUTXOID := avax.UTXOID{TxID: assetID, OutputIndex: 1}
+
+	// Freeze the funds of the holder, which are consumed and reissued
+	freezeTx := &txs.Tx{Unsigned: &txs.OperationTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{
+				newInput(avax.UTXOID{TxID: assetID, OutputIndex: 0}, avaxTx.ID(), startBalance-testTxFee),
+			},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				Out:   newOutput(startBalance-2*testTxFee, holder),
+			}},
+		}},
+		Ops: []*txs.Operation{{
+			Asset: avax.Asset{ID: assetID},
+			UTXOIDs: []*avax.UTXOID{
+				&heldUTXOID,
+				{TxID: assetID, OutputIndex: 2},
+			},
+			Op: &policyfx.FreezeOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+				Freeze:       true,
+				PolicyOutput: policy,
+				Outputs:      []verify.State{newOutput(1000, holder)},
+			},
+		}},
+	}}
+	require.NoError(freezeTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{holder}}))
+	require.NoError(freezeTx.SignPolicyFx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{authority}}))
+
+	// Freezes are only ordered once the chain is linearized
+	_, err = vm.IssueTx(freezeTx.Bytes())
+	require.ErrorIs(err, errPolicyNotLinearized)
+
+	_, toEngine := linearizeTestVM(t, vm)
+
+	_, err = vm.IssueTx(freezeTx.Bytes())
+	require.NoError(err)
+	require.Equal(common.PendingTxs, <-toEngine)
+
+	newSpendTx := func(utxoID avax.UTXOID) *txs.Tx {
+		spendIns := []*avax.TransferableInput{
+			newInput(avax.UTXOID{TxID: freezeTx.ID(), OutputIndex: 0}, avaxTx.ID(), startBalance-2*testTxFee),
+			newInput(utxoID, assetID, 1000),
+		}
+		avax.SortTransferableInputs(spendIns)
+		spendTx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins:          spendIns,
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: assetID},
+				Out:   newOutput(1000, keys[2]),
+			}},
+		}}}
+		require.NoError(spendTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{holder}, {holder}}))
+		return spendTx
+	}
+
+	// The holder can't spend the funds targeted by the pending freeze
+	_, err = vm.IssueTx(newSpendTx(heldUTXOID).Bytes())
+	require.ErrorIs(err, mempool.ErrConflictsWithOtherTx)
+
+	freezeBlk, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(freezeBlk.Verify())
+	require.NoError(vm.SetPreference(freezeBlk.ID()))
+
+	// The freeze applies to the blocks built on top of it before it is
+	// accepted
+	frozenUTXOID := avax.UTXOID{TxID: freezeTx.ID(), OutputIndex: 2}
+	spendBlk := newBlock(freezeBlk, newSpendTx(frozenUTXOID))
+	require.ErrorIs(spendBlk.Verify(), errFrozenUTXO)
+
+	// The authority recovers the frozen funds
+	clawbackTx := &txs.Tx{Unsigned: &txs.OperationTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{
+				newInput(avax.UTXOID{TxID: freezeTx.ID(), OutputIndex: 0}, avaxTx.ID(), startBalance-2*testTxFee),
+			},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				Out:   newOutput(startBalance-3*testTxFee, holder),
+			}},
+		}},
+		Ops: []*txs.Operation{{
+			Asset: avax.Asset{ID: assetID},
+			UTXOIDs: []*avax.UTXOID{
+				{TxID: freezeTx.ID(), OutputIndex: 1},
+				&frozenUTXOID,
+			},
+			Op: &policyfx.ClawbackOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+				PolicyOutput: policy,
+				Outputs:      []*secp256k1fx.TransferOutput{newOutput(1000, authority)},
+			},
+		}},
+	}}
+	require.NoError(clawbackTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{holder}}))
+	require.NoError(clawbackTx.SignPolicyFx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{authority}}))
+
+	// Against the accepted state, the funds aren't frozen yet
+	_, err = vm.IssueTx(clawbackTx.Bytes())
+	require.ErrorIs(err, errNotFrozen)
+
+	clawbackBlk := newBlock(freezeBlk, clawbackTx)
+	require.NoError(clawbackBlk.Verify())
+	require.NoError(vm.SetPreference(clawbackBlk.ID()))
+	require.NoError(freezeBlk.Accept())
+	require.NoError(clawbackBlk.Accept())
+
+	recoveredUTXOID := avax.UTXOID{TxID: clawbackTx.ID(), OutputIndex: 2}
+	recovered, err := vm.state.GetUTXO(recoveredUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(newOutput(1000, authority), recovered.Out)
+
+	// The freeze of the recovered UTXO is dropped
+	frozen, err := vm.state.IsUTXOFrozen(assetID, frozenUTXOID.InputID())
+	require.NoError(err)
+	require.False(frozen)
+
+	// The asset description reports the policy
+	s := &Service{vm: vm}
+	reply := &GetAssetDescriptionReply{}
+	require.NoError(s.GetAssetDescription(nil, &GetAssetDescriptionArgs{AssetID: assetID.String()}, reply))
+	require.NotNil(reply.Policy)
+	require.True(reply.Policy.Clawback)
+	require.Equal(json.Uint32(1), reply.Policy.Threshold)
+
+	authorityAddr, err := vm.FormatLocalAddress(authority.PublicKey().Address())
+	require.NoError(err)
+	require.Equal([]string{authorityAddr}, reply.Policy.FreezeAuthority)
+}
+
 func TestIssueTxWithFeeAsset(t *testing.T) {
 	genesisBytes, issuer, vm, _ := setupTxFeeAssets(t)
 	ctx := vm.ctx
@@ -1117,7 +1746,7 @@ func TestTxCached(t *testing.T) {
 	err = vm.metrics.Initialize("", registerer)
 	require.NoError(t, err)
 
-	vm.state, err = states.New(prefixdb.New([]byte("tx"), db), vm.parser, registerer)
+	vm.state, err = states.New(prefixdb.New([]byte("tx"), db), vm.parser, registerer, vm.ctx.AVAXAssetID)
 	require.NoError(t, err)
 
 	_, err = vm.ParseTx(txBytes)
@@ -1155,7 +1784,7 @@ func TestTxNotCached(t *testing.T) {
 	err = vm.metrics.Initialize("", registerer)
 	require.NoError(t, err)
 
-	vm.state, err = states.New(db, vm.parser, registerer)
+	vm.state, err = states.New(db, vm.parser, registerer, vm.ctx.AVAXAssetID)
 	require.NoError(t, err)
 
 	vm.uniqueTxs.Flush()
@@ -1499,7 +2128,7 @@ func TestIssueImportTx(t *testing.T) {
 
 	avmConfigBytes, err := stdjson.Marshal(avmConfig)
 	require.NoError(t, err)
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	err = vm.Initialize(
 		ctx,
 		baseDBManager.NewPrefixDBManager([]byte{1}),
@@ -1511,7 +2140,7 @@ func TestIssueImportTx(t *testing.T) {
 			ID: ids.Empty,
 			Fx: &secp256k1fx.Fx{},
 		}},
-		nil,
+		&common.SenderTest{T: t},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -1660,7 +2289,7 @@ func TestForceAcceptImportTx(t *testing.T) {
 
 	platformID := ids.Empty.Prefix(0)
 
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	ctx.Lock.Lock()
 	defer func() {
 		if err := vm.Shutdown(); err != nil {
@@ -1679,7 +2308,7 @@ func TestForceAcceptImportTx(t *testing.T) {
 			ID: ids.Empty,
 			Fx: &secp256k1fx.Fx{},
 		}},
-		nil,
+		&common.SenderTest{T: t},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -1771,7 +2400,7 @@ func TestIssueExportTx(t *testing.T) {
 	avaxID := genesisTx.ID()
 
 	ctx.Lock.Lock()
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	if err := vm.Initialize(
 		ctx,
 		baseDBManager.NewPrefixDBManager([]byte{1}),
@@ -1782,7 +2411,7 @@ func TestIssueExportTx(t *testing.T) {
 			ID: ids.Empty,
 			Fx: &secp256k1fx.Fx{},
 		}},
-		nil,
+		&common.SenderTest{T: t},
 	); err != nil {
 		t.Fatal(err)
 	}
@@ -1902,7 +2531,7 @@ func TestClearForceAcceptedExportTx(t *testing.T) {
 	}
 	avmConfigBytes, err := stdjson.Marshal(avmConfig)
 	require.NoError(t, err)
-	vm := &VM{}
+	vm := &VM{Factory: Factory{XChainMigrationTime: testXChainMigrationTime}}
 	err = vm.Initialize(
 		ctx,
 		baseDBManager.NewPrefixDBManager([]byte{1}),
@@ -1914,7 +2543,7 @@ func TestClearForceAcceptedExportTx(t *testing.T) {
 			ID: ids.Empty,
 			Fx: &secp256k1fx.Fx{},
 		}},
-		nil,
+		&common.SenderTest{T: t},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -114,7 +114,7 @@ func TestWalletService_SendMultiple(t *testing.T) {
 				t.Fatalf("expected change address to be %s but got %s", changeAddrStr, reply.ChangeAddr)
 			}
 
-			pendingTxs := vm.txs
+			pendingTxs := vm.mempool.List()
 			if len(pendingTxs) != 1 {
 				t.Fatalf("Expected to find 1 pending tx after send, but found %d", len(pendingTxs))
 			}
@@ -110,6 +110,19 @@ func NewMeteredUTXOState(db database.Database, codec codec.Manager, metrics prom
 	}, err
 }
 
+// NewUTXOIterator returns an iterator, in order of their IDs, over the UTXOs
+// persisted in [db] by a UTXOState. The keys are the UTXO IDs and the values
+// are the serialized UTXOs.
+func NewUTXOIterator(db database.Database) database.Iterator {
+	return prefixdb.New(utxoPrefix, db).NewIterator()
+}
+
+// GetUTXOBytes returns the serialized UTXO [utxoID] persisted in [db] by a
+// UTXOState.
+func GetUTXOBytes(db database.Database, utxoID ids.ID) ([]byte, error) {
+	return prefixdb.New(utxoPrefix, db).Get(utxoID[:])
+}
+
 func (s *utxoState) GetUTXO(utxoID ids.ID) (*UTXO, error) {
 	if utxoIntf, found := s.utxoCache.Get(utxoID); found {
 		if utxoIntf == nil {
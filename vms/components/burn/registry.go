@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package burn
+
+import (
+	"sync"
+
+	"github.com/kukrer/savannahnode/ids"
+)
+
+var _ Registry = &registry{}
+
+// Reporter reports the AVAX burned as fees by the txs a chain accepted
+type Reporter interface {
+	// BurnedFees must be safe to call concurrently with the chain processing
+	// txs, as it is called without holding the lock of the chain.
+	BurnedFees() uint64
+}
+
+// Registry tracks the reporters of the chains of the node that burn AVAX
+type Registry interface {
+	// Register the reporter of [chainID], replacing any previous one
+	Register(chainID ids.ID, reporter Reporter)
+	// BurnedFees returns the AVAX burned by each registered chain
+	BurnedFees() map[ids.ID]uint64
+}
+
+type registry struct {
+	lock      sync.RWMutex
+	reporters map[ids.ID]Reporter
+}
+
+func NewRegistry() Registry {
+	return &registry{
+		reporters: make(map[ids.ID]Reporter),
+	}
+}
+
+func (r *registry) Register(chainID ids.ID, reporter Reporter) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.reporters[chainID] = reporter
+}
+
+func (r *registry) BurnedFees() map[ids.ID]uint64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	burnedFees := make(map[ids.ID]uint64, len(r.reporters))
+	for chainID, reporter := range r.reporters {
+		burnedFees[chainID] = reporter.BurnedFees()
+	}
+	return burnedFees
+}
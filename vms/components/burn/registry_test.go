@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package burn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kukrer/savannahnode/ids"
+)
+
+type testReporter uint64
+
+func (r testReporter) BurnedFees() uint64 { return uint64(r) }
+
+func TestRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewRegistry()
+	assert.Empty(r.BurnedFees())
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	r.Register(chainID0, testReporter(1))
+	r.Register(chainID1, testReporter(2))
+	assert.Equal(map[ids.ID]uint64{
+		chainID0: 1,
+		chainID1: 2,
+	}, r.BurnedFees())
+
+	r.Register(chainID0, testReporter(3))
+	assert.Equal(map[ids.ID]uint64{
+		chainID0: 3,
+		chainID1: 2,
+	}, r.BurnedFees())
+}
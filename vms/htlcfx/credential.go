@@ -0,0 +1,14 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+// Credential holds the signatures of the recipient of an HTLC when it is
+// claimed, or of its sender when it is refunded.
+type Credential struct {
+	secp256k1fx.Credential `serialize:"true"`
+}
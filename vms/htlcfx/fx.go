@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/utils/hashing"
+	"github.com/kukrer/savannahnode/utils/wrappers"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+const (
+	// FirstTypeID is the type ID of the first type registered by this fx. The
+	// types of this fx are registered after the types of the P-chain, so that
+	// the AVM and the P-chain serialize HTLC outputs in shared memory
+	// identically.
+	FirstTypeID = 33
+
+	defaultCacheSize = 256
+)
+
+var (
+	errWrongTxType         = errors.New("wrong tx type")
+	errNoTimestamp         = errors.New("tx isn't executed at a chain timestamp")
+	errWrongUTXOType       = errors.New("wrong utxo type")
+	errWrongCredentialType = errors.New("wrong credential type")
+	errWrongAmount         = errors.New("utxo amount and input amount differ")
+	errWrongPreimage       = errors.New("preimage doesn't match the hash of the output")
+	errExpired             = errors.New("output can't be claimed after its deadline")
+	errNotExpired          = errors.New("output can't be refunded before its deadline")
+	errCantOperate         = errors.New("cant operate with this fx")
+)
+
+// Fx describes the hash time-locked contract feature extension. It extends
+// the secp256k1fx, whose outputs it can transfer as well.
+type Fx struct{ secp256k1fx.Fx }
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing htlc fx")
+
+	fx.SECPFactory = crypto.FactorySECP256K1R{
+		Cache: cache.LRU{Size: defaultCacheSize},
+	}
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&TransferInput{}),
+		c.RegisterType(&TransferOutput{}),
+		c.RegisterType(&Credential{}),
+	)
+	return errs.Err
+}
+
+// FirstTypeID returns the type ID the types of this fx must be registered at
+func (*Fx) FirstTypeID() uint32 { return FirstTypeID }
+
+// ExtendsSECP256K1Fx marks this fx as usable with any asset that supports the
+// secp256k1fx
+func (*Fx) ExtendsSECP256K1Fx() {}
+
+func (fx *Fx) VerifyOperation(interface{}, interface{}, interface{}, []interface{}) error {
+	return errCantOperate
+}
+
+// VerifyTransfer verifies the spending of an HTLC output, which requires the
+// tx to be a *TimestampedTx. Any other transfer is verified by the
+// secp256k1fx.
+func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
+	in, ok := inIntf.(*TransferInput)
+	if !ok {
+		return fx.Fx.VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf)
+	}
+	tx, ok := txIntf.(*TimestampedTx)
+	if !ok {
+		if _, ok := txIntf.(secp256k1fx.UnsignedTx); ok {
+			return errNoTimestamp
+		}
+		return errWrongTxType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+	out, ok := utxoIntf.(*TransferOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+	return fx.VerifySpend(tx, in, cred, out)
+}
+
+// VerifySpend ensures that the HTLC output can be claimed by its recipient
+// with the preimage of its hash before its deadline, or refunded to its sender
+// at or after its deadline, as of the timestamp of [tx].
+func (fx *Fx) VerifySpend(tx *TimestampedTx, in *TransferInput, cred *Credential, utxo *TransferOutput) error {
+	if err := verify.All(utxo, in, cred); err != nil {
+		return err
+	}
+	if utxo.Amt != in.Amt {
+		return fmt.Errorf("%w: %d != %d", errWrongAmount, utxo.Amt, in.Amt)
+	}
+
+	if !in.IsClaim() {
+		if tx.Timestamp < utxo.Deadline {
+			return errNotExpired
+		}
+		return fx.VerifyCredentials(tx, &in.Input, &cred.Credential, &utxo.Sender)
+	}
+
+	if hashing.ComputeHash256Array(in.Preimage) != utxo.Hash {
+		return errWrongPreimage
+	}
+	if tx.Timestamp >= utxo.Deadline {
+		return errExpired
+	}
+	return fx.VerifyCredentials(tx, &in.Input, &cred.Credential, &utxo.Recipient)
+}
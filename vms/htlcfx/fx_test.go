@@ -0,0 +1,256 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/codec/linearcodec"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/utils/hashing"
+	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	sigBytes = [crypto.SECP256K1RSigLen]byte{
+		0x0e, 0x33, 0x4e, 0xbc, 0x67, 0xa7, 0x3f, 0xe8,
+		0x24, 0x33, 0xac, 0xa3, 0x47, 0x88, 0xa6, 0x3d,
+		0x58, 0xe5, 0x8e, 0xf0, 0x3a, 0xd5, 0x84, 0xf1,
+		0xbc, 0xa3, 0xb2, 0xd2, 0x5d, 0x51, 0xd6, 0x9b,
+		0x0f, 0x28, 0x5d, 0xcd, 0x3f, 0x71, 0x17, 0x0a,
+		0xf9, 0xbf, 0x2d, 0xb1, 0x10, 0x26, 0x5c, 0xe9,
+		0xdc, 0xc3, 0x9d, 0x7a, 0x01, 0x50, 0x9d, 0xe8,
+		0x35, 0xbd, 0xcb, 0x29, 0x3a, 0xd1, 0x49, 0x32,
+		0x00,
+	}
+	addr = [hashing.AddrLen]byte{
+		0x01, 0x5c, 0xce, 0x6c, 0x55, 0xd6, 0xb5, 0x09,
+		0x84, 0x5c, 0x8c, 0x4e, 0x30, 0xbe, 0xd9, 0x8d,
+		0x39, 0x1a, 0xe7, 0xf0,
+	}
+	otherAddr = ids.ShortID{1}
+
+	preimage = []byte("secret")
+	deadline = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func newTestFx(t *testing.T) *Fx {
+	vm := &secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+
+	fx := &Fx{}
+	require.NoError(t, fx.Initialize(vm))
+	require.NoError(t, fx.Bootstrapped())
+	return fx
+}
+
+func newTestTx(timestamp time.Time) *TimestampedTx {
+	return &TimestampedTx{
+		UnsignedTx: &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+		Timestamp:  uint64(timestamp.Unix()),
+	}
+}
+
+// newTestOutput returns an HTLC output that [recipient] can claim before
+// [deadline] and that [sender] can refund after it
+func newTestOutput(recipient, sender ids.ShortID) *TransferOutput {
+	return &TransferOutput{
+		Amt:      1,
+		Hash:     hashing.ComputeHash256Array(preimage),
+		Deadline: uint64(deadline.Unix()),
+		Recipient: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{recipient},
+		},
+		Sender: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{sender},
+		},
+	}
+}
+
+func newTestInput(preimage []byte) *TransferInput {
+	return &TransferInput{
+		Amt:      1,
+		Preimage: preimage,
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+	}
+}
+
+func TestFxInitialize(t *testing.T) {
+	newTestFx(t)
+}
+
+func TestFxInitializeInvalid(t *testing.T) {
+	fx := Fx{}
+	require.Error(t, fx.Initialize(nil))
+}
+
+func TestFxVerifyTransfer(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		in   *TransferInput
+		out  *TransferOutput
+		// expectedErr is nil when the signatures are expected to be rejected
+		// by the secp256k1fx, which has no dedicated error for them
+		shouldErr   bool
+		expectedErr error
+	}{
+		{
+			name: "claim before deadline",
+			now:  deadline.Add(-time.Second),
+			in:   newTestInput(preimage),
+			out:  newTestOutput(addr, otherAddr),
+		},
+		{
+			name:        "claim at deadline",
+			now:         deadline,
+			in:          newTestInput(preimage),
+			out:         newTestOutput(addr, otherAddr),
+			shouldErr:   true,
+			expectedErr: errExpired,
+		},
+		{
+			name:        "claim after deadline",
+			now:         deadline.Add(time.Second),
+			in:          newTestInput(preimage),
+			out:         newTestOutput(addr, otherAddr),
+			shouldErr:   true,
+			expectedErr: errExpired,
+		},
+		{
+			name:        "claim with wrong preimage",
+			now:         deadline.Add(-time.Second),
+			in:          newTestInput([]byte("wrong secret")),
+			out:         newTestOutput(addr, otherAddr),
+			shouldErr:   true,
+			expectedErr: errWrongPreimage,
+		},
+		{
+			name:      "claim signed by sender",
+			now:       deadline.Add(-time.Second),
+			in:        newTestInput(preimage),
+			out:       newTestOutput(otherAddr, addr),
+			shouldErr: true,
+		},
+		{
+			name: "refund at deadline",
+			now:  deadline,
+			in:   newTestInput(nil),
+			out:  newTestOutput(otherAddr, addr),
+		},
+		{
+			name:        "refund before deadline",
+			now:         deadline.Add(-time.Second),
+			in:          newTestInput(nil),
+			out:         newTestOutput(otherAddr, addr),
+			shouldErr:   true,
+			expectedErr: errNotExpired,
+		},
+		{
+			name:      "refund signed by recipient",
+			now:       deadline,
+			in:        newTestInput(nil),
+			out:       newTestOutput(addr, otherAddr),
+			shouldErr: true,
+		},
+		{
+			name: "wrong amount",
+			now:  deadline.Add(-time.Second),
+			in: &TransferInput{
+				Amt:      2,
+				Preimage: preimage,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+			out:         newTestOutput(addr, otherAddr),
+			shouldErr:   true,
+			expectedErr: errWrongAmount,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			fx := newTestFx(t)
+			tx := newTestTx(test.now)
+			cred := &Credential{Credential: secp256k1fx.Credential{
+				Sigs: [][crypto.SECP256K1RSigLen]byte{sigBytes},
+			}}
+			err := fx.VerifyTransfer(tx, test.in, cred, test.out)
+			switch {
+			case test.expectedErr != nil:
+				require.ErrorIs(err, test.expectedErr)
+			case test.shouldErr:
+				require.Error(err)
+			default:
+				require.NoError(err)
+			}
+		})
+	}
+}
+
+func TestFxVerifyTransferWrongTypes(t *testing.T) {
+	require := require.New(t)
+
+	fx := newTestFx(t)
+	tx := newTestTx(deadline.Add(-time.Second))
+	in := newTestInput(preimage)
+	cred := &Credential{Credential: secp256k1fx.Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{sigBytes},
+	}}
+	out := newTestOutput(addr, otherAddr)
+
+	require.ErrorIs(fx.VerifyTransfer(nil, in, cred, out), errWrongTxType)
+	// The deadline can't be checked without the timestamp of the chain
+	require.ErrorIs(fx.VerifyTransfer(tx.UnsignedTx, in, cred, out), errNoTimestamp)
+	require.ErrorIs(fx.VerifyTransfer(tx, in, &cred.Credential, out), errWrongCredentialType)
+	require.ErrorIs(fx.VerifyTransfer(tx, in, cred, &secp256k1fx.TransferOutput{}), errWrongUTXOType)
+}
+
+func TestFxVerifyTransferSECP256K1Fx(t *testing.T) {
+	require := require.New(t)
+
+	// Transfers of secp256k1fx outputs are verified by the secp256k1fx
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in := &secp256k1fx.TransferInput{
+		Amt: 1,
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+	}
+	cred := &secp256k1fx.Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{sigBytes},
+	}
+	out := &secp256k1fx.TransferOutput{
+		Amt: 1,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		},
+	}
+	require.NoError(fx.VerifyTransfer(tx, in, cred, out))
+	require.NoError(fx.VerifyTransfer(newTestTx(deadline), in, cred, out))
+
+	// An HTLC output can't be spent by a secp256k1fx input
+	require.Error(fx.VerifyTransfer(tx, in, cred, newTestOutput(addr, otherAddr)))
+}
+
+func TestFxVerifyOperation(t *testing.T) {
+	fx := newTestFx(t)
+	require.ErrorIs(t, fx.VerifyOperation(nil, nil, nil, nil), errCantOperate)
+}
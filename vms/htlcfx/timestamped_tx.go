@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+// TimestampedTx is a tx along with the timestamp of the chain it is executed
+// on. The deadlines of the HTLC outputs the tx spends are checked against this
+// timestamp rather than the local clock, so that every node, including one
+// replaying the chain, agrees on whether a deadline passed.
+type TimestampedTx struct {
+	secp256k1fx.UnsignedTx
+	// Timestamp is the unix time of the chain the tx is executed on
+	Timestamp uint64
+}
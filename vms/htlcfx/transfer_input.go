@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"errors"
+
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+// MaxPreimageLen is the maximum length of the preimage revealed to claim an
+// HTLC
+const MaxPreimageLen = 256
+
+var (
+	errNilInput         = errors.New("nil input")
+	errNoValueInput     = errors.New("input has no value")
+	errPreimageTooLarge = errors.New("preimage is too large")
+)
+
+// TransferInput spends a TransferOutput. If [Preimage] is provided, the input
+// claims the output on behalf of its recipient. Otherwise, the input refunds
+// the output to its sender.
+type TransferInput struct {
+	Amt      uint64 `serialize:"true" json:"amount"`
+	Preimage []byte `serialize:"true" json:"preimage"`
+
+	secp256k1fx.Input `serialize:"true"`
+}
+
+func (in *TransferInput) InitCtx(*snow.Context) {}
+
+// Amount returns the quantity of the asset this input produces
+func (in *TransferInput) Amount() uint64 { return in.Amt }
+
+// IsClaim returns true if this input claims the output it consumes rather
+// than refunding it
+func (in *TransferInput) IsClaim() bool { return len(in.Preimage) > 0 }
+
+// Verify this input is syntactically valid
+func (in *TransferInput) Verify() error {
+	switch {
+	case in == nil:
+		return errNilInput
+	case in.Amt == 0:
+		return errNoValueInput
+	case len(in.Preimage) > MaxPreimageLen:
+		return errPreimageTooLarge
+	default:
+		return in.Input.Verify()
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestInputVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          *TransferInput
+		expectedErr error
+	}{
+		{
+			name: "claim",
+			in:   newTestInput(preimage),
+		},
+		{
+			name: "refund",
+			in:   newTestInput(nil),
+		},
+		{
+			name:        "nil",
+			in:          nil,
+			expectedErr: errNilInput,
+		},
+		{
+			name: "no value",
+			in: &TransferInput{
+				Preimage: preimage,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+			expectedErr: errNoValueInput,
+		},
+		{
+			name:        "preimage too large",
+			in:          newTestInput(make([]byte, MaxPreimageLen+1)),
+			expectedErr: errPreimageTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.in.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestInputIsClaim(t *testing.T) {
+	require := require.New(t)
+	require.True(newTestInput(preimage).IsClaim())
+	require.False(newTestInput(nil).IsClaim())
+}
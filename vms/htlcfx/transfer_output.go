@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/formatting"
+	"github.com/kukrer/savannahnode/utils/hashing"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	_ verify.State         = &TransferOutput{}
+	_ avax.Addressable     = &TransferOutput{}
+	_ avax.TransferableOut = &TransferOutput{}
+
+	errNilOutput     = errors.New("nil output")
+	errNoValueOutput = errors.New("output has no value")
+	errNoDeadline    = errors.New("output has no deadline")
+	errBadRecipient  = errors.New("invalid recipient")
+	errBadSender     = errors.New("invalid sender")
+)
+
+// TransferOutput is a hash time-locked output. Before [Deadline], it can be
+// claimed by [Recipient] by revealing a preimage of [Hash]. At or after
+// [Deadline], it can be refunded to [Sender].
+type TransferOutput struct {
+	Amt uint64 `serialize:"true" json:"amount"`
+
+	// Hash is the SHA-256 hash of the preimage that claims this output
+	Hash [hashing.HashLen]byte `serialize:"true" json:"hash"`
+	// Deadline is the unix time at which this output can no longer be
+	// claimed and can be refunded instead
+	Deadline uint64 `serialize:"true" json:"deadline"`
+
+	Recipient secp256k1fx.OutputOwners `serialize:"true" json:"recipient"`
+	Sender    secp256k1fx.OutputOwners `serialize:"true" json:"sender"`
+}
+
+// InitCtx initializes both owners of the output, which is required to marshal
+// it to JSON
+func (out *TransferOutput) InitCtx(ctx *snow.Context) {
+	out.Recipient.InitCtx(ctx)
+	out.Sender.InitCtx(ctx)
+}
+
+// MarshalJSON marshals the output into a JSON readable format, with the hash
+// encoded in hex and the owners' addresses formatted for the chain
+func (out *TransferOutput) MarshalJSON() ([]byte, error) {
+	recipient, err := out.Recipient.Fields()
+	if err != nil {
+		return nil, err
+	}
+	sender, err := out.Sender.Fields()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := formatting.Encode(formatting.HexNC, out.Hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't convert hash to string: %w", err)
+	}
+	return json.Marshal(map[string]interface{}{
+		"amount":    out.Amt,
+		"hash":      hash,
+		"deadline":  out.Deadline,
+		"recipient": recipient,
+		"sender":    sender,
+	})
+}
+
+// Amount returns the quantity of the asset this output consumes
+func (out *TransferOutput) Amount() uint64 { return out.Amt }
+
+// Addresses returns the addresses of both the recipient and the sender, so
+// that the output is indexed for both of them
+func (out *TransferOutput) Addresses() [][]byte {
+	addrs := ids.NewShortSet(len(out.Recipient.Addrs) + len(out.Sender.Addrs))
+	addrs.Add(out.Recipient.Addrs...)
+	addrs.Add(out.Sender.Addrs...)
+
+	addrList := addrs.List()
+	ids.SortShortIDs(addrList)
+	addrsBytes := make([][]byte, len(addrList))
+	for i, addr := range addrList {
+		addrsBytes[i] = addr.Bytes()
+	}
+	return addrsBytes
+}
+
+func (out *TransferOutput) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	case out.Amt == 0:
+		return errNoValueOutput
+	case out.Deadline == 0:
+		return errNoDeadline
+	}
+	if err := out.Recipient.Verify(); err != nil {
+		return fmt.Errorf("%w: %v", errBadRecipient, err)
+	}
+	if err := out.Sender.Verify(); err != nil {
+		return fmt.Errorf("%w: %v", errBadSender, err)
+	}
+	return nil
+}
+
+func (out *TransferOutput) VerifyState() error { return out.Verify() }
@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/formatting/address"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+)
+
+func TestOutputState(t *testing.T) {
+	intf := interface{}(&TransferOutput{})
+	_, ok := intf.(verify.State)
+	require.True(t, ok)
+}
+
+func TestOutputVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		out         *TransferOutput
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			out:  newTestOutput(addr, otherAddr),
+		},
+		{
+			name:        "nil",
+			out:         nil,
+			expectedErr: errNilOutput,
+		},
+		{
+			name: "no value",
+			out: func() *TransferOutput {
+				out := newTestOutput(addr, otherAddr)
+				out.Amt = 0
+				return out
+			}(),
+			expectedErr: errNoValueOutput,
+		},
+		{
+			name: "no deadline",
+			out: func() *TransferOutput {
+				out := newTestOutput(addr, otherAddr)
+				out.Deadline = 0
+				return out
+			}(),
+			expectedErr: errNoDeadline,
+		},
+		{
+			name: "unspendable recipient",
+			out: func() *TransferOutput {
+				out := newTestOutput(addr, otherAddr)
+				out.Recipient.Threshold = 2
+				return out
+			}(),
+			expectedErr: errBadRecipient,
+		},
+		{
+			name: "unspendable sender",
+			out: func() *TransferOutput {
+				out := newTestOutput(addr, otherAddr)
+				out.Sender.Threshold = 2
+				return out
+			}(),
+			expectedErr: errBadSender,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.out.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestOutputAddresses(t *testing.T) {
+	require := require.New(t)
+
+	out := newTestOutput(addr, otherAddr)
+	out.Recipient.Addrs = []ids.ShortID{otherAddr, addr}
+	out.Recipient.Threshold = 2
+
+	// Each address is returned once, whether it is a recipient, a sender or
+	// both
+	addrs := out.Addresses()
+	require.Len(addrs, 2)
+	require.ElementsMatch([][]byte{addr[:], otherAddr[:]}, addrs)
+}
+
+func TestOutputMarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultContextTest()
+	ctx.NetworkID = constants.UnitTestID
+	require.NoError(ctx.BCLookup.(ids.Aliaser).Alias(ctx.ChainID, "X"))
+
+	out := newTestOutput(addr, otherAddr)
+	out.InitCtx(ctx)
+	outBytes, err := json.Marshal(out)
+	require.NoError(err)
+
+	hrp := constants.GetHRP(ctx.NetworkID)
+	recipientAddr, err := address.Format("X", hrp, addr[:])
+	require.NoError(err)
+	senderAddr, err := address.Format("X", hrp, otherAddr[:])
+	require.NoError(err)
+
+	var jsonOut map[string]interface{}
+	require.NoError(json.Unmarshal(outBytes, &jsonOut))
+	require.Equal(float64(out.Amt), jsonOut["amount"])
+	require.Equal("0x2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", jsonOut["hash"])
+	require.Equal(float64(out.Deadline), jsonOut["deadline"])
+	require.Equal([]interface{}{recipientAddr}, jsonOut["recipient"].(map[string]interface{})["addresses"])
+	require.Equal([]interface{}{senderAddr}, jsonOut["sender"].(map[string]interface{})["addresses"])
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+
+	"github.com/kukrer/savannahnode/api"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/rpc"
+)
+
+var _ AdminClient = &adminClient{}
+
+// AdminClient for interacting with the P-chain admin API
+type AdminClient interface {
+	// EvictMempoolTx removes the tx corresponding to [txID] from the mempool
+	EvictMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error
+}
+
+// adminClient implementation for interacting with the P-chain admin API
+type adminClient struct {
+	requester rpc.EndpointRequester
+}
+
+// NewAdminClient returns a client to interact with the P-chain admin API
+func NewAdminClient(uri string) AdminClient {
+	return &adminClient{requester: rpc.NewEndpointRequester(
+		uri+"/ext/P/admin",
+		"platform",
+	)}
+}
+
+func (c *adminClient) EvictMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "evictMempoolTx", &api.JSONTxID{
+		TxID: txID,
+	}, &api.EmptyReply{}, options...)
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/kukrer/savannahnode/api"
+)
+
+// droppedByAdminReason is reported as the drop reason of evicted txs
+const droppedByAdminReason = "evicted from the mempool by an admin"
+
+// AdminService defines the P-chain API calls that should only be exposed to
+// the node operator
+type AdminService struct {
+	vm *VM
+}
+
+// EvictMempoolTx removes a tx from the mempool. The tx is marked as dropped,
+// so it isn't re-added from gossip unless it is re-issued locally.
+func (service *AdminService) EvictMempoolTx(_ *http.Request, args *api.JSONTxID, _ *api.EmptyReply) error {
+	service.vm.ctx.Log.Debug("Platform: EvictMempoolTx called",
+		zap.Stringer("txID", args.TxID),
+	)
+
+	if tx := service.vm.Builder.Evict(args.TxID); tx == nil {
+		return fmt.Errorf("%w: %s", errTxNotInMempool, args.TxID)
+	}
+	service.vm.Builder.MarkDropped(args.TxID, droppedByAdminReason)
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/kukrer/savannahnode/utils/math"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
 	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs/txheap"
@@ -34,6 +35,8 @@ var (
 	errUTXOHasNoValue       = errors.New("genesis UTXO has no value")
 	errValidatorAddsNoValue = errors.New("validator would have already unstaked")
 	errStakeOverflow        = errors.New("validator stake exceeds limit")
+	errWrongVestingAmount   = errors.New("genesis UTXO tranches don't add up to its amount")
+	errLocktimeAndTranches  = errors.New("genesis UTXO can't have both a locktime and tranches")
 )
 
 // StaticService defines the static API methods exposed by the platform VM
@@ -45,6 +48,15 @@ type UTXO struct {
 	Amount   json.Uint64 `json:"amount"`
 	Address  string      `json:"address"`
 	Message  string      `json:"message"`
+	// Tranches, if provided, is the schedule [Amount] vests with. It can't be
+	// used along with [Locktime].
+	Tranches []Tranche `json:"tranches,omitempty"`
+}
+
+// Tranche is a part of a genesis UTXO that vests at [Locktime]
+type Tranche struct {
+	Locktime json.Uint64 `json:"locktime"`
+	Amount   json.Uint64 `json:"amount"`
 }
 
 // TODO: refactor APIStaker, APIValidators and merge them together for SubnetValidators + PrimaryValidators
@@ -82,6 +94,8 @@ type PrimaryValidator struct {
 	Uptime             *json.Float32 `json:"uptime,omitempty"`
 	Connected          bool          `json:"connected"`
 	Staked             []UTXO        `json:"staked,omitempty"`
+	// The BLS key and proof of possession registered by this validator, if any
+	Signer *signer.ProofOfPossession `json:"signer,omitempty"`
 	// The delegators delegating to this validator
 	Delegators []PrimaryDelegator `json:"delegators"`
 }
@@ -194,6 +208,15 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 				TransferableOut: utxo.Out.(avax.TransferableOut),
 			}
 		}
+		if len(apiUTXO.Tranches) > 0 {
+			vestingOut, err := newVestingOut(apiUTXO, args.Time, utxo.Out.(avax.TransferableOut))
+			if err != nil {
+				return err
+			}
+			if vestingOut != nil {
+				utxo.Out = vestingOut
+			}
+		}
 		messageBytes, err := formatting.Decode(args.Encoding, apiUTXO.Message)
 		if err != nil {
 			return fmt.Errorf("problem decoding UTXO message bytes: %w", err)
@@ -340,6 +363,40 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 	return nil
 }
 
+// newVestingOut returns [out] vesting with the schedule of [apiUTXO]. If the
+// whole amount vested before [genesisTime], nil is returned.
+func newVestingOut(apiUTXO UTXO, genesisTime json.Uint64, out avax.TransferableOut) (*stakeable.VestingOut, error) {
+	if apiUTXO.Locktime != 0 {
+		return nil, errLocktimeAndTranches
+	}
+
+	tranches := make([]stakeable.Tranche, len(apiUTXO.Tranches))
+	total := uint64(0)
+	for i, tranche := range apiUTXO.Tranches {
+		tranches[i] = stakeable.Tranche{
+			Locktime: uint64(tranche.Locktime),
+			Amount:   uint64(tranche.Amount),
+		}
+		newTotal, err := math.Add64(total, uint64(tranche.Amount))
+		if err != nil {
+			return nil, err
+		}
+		total = newTotal
+	}
+	if total != uint64(apiUTXO.Amount) {
+		return nil, errWrongVestingAmount
+	}
+	if stakeable.LockedAmount(tranches, uint64(genesisTime)) == 0 {
+		return nil, nil
+	}
+
+	vestingOut := &stakeable.VestingOut{
+		Tranches:        tranches,
+		TransferableOut: out,
+	}
+	return vestingOut, vestingOut.Verify()
+}
+
 type innerSortUTXO []UTXO
 
 func (s innerSortUTXO) Less(i, j int) bool {
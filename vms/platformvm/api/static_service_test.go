@@ -12,6 +12,7 @@ import (
 	"github.com/kukrer/savannahnode/utils/formatting/address"
 	"github.com/kukrer/savannahnode/utils/json"
 	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
 )
 
 const testNetworkID = 10 // To be used in tests
@@ -250,3 +251,116 @@ func TestBuildGenesisReturnsSortedValidators(t *testing.T) {
 		t.Fatal("Validators should contain 3 validators")
 	}
 }
+
+func TestBuildGenesisVestingUTXO(t *testing.T) {
+	nodeID := ids.NodeID{1}
+	hrp := constants.NetworkIDToHRP[testNetworkID]
+	addr, err := address.FormatBech32(hrp, nodeID.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		utxo        UTXO
+		expectedErr error
+		vesting     bool
+	}{
+		{
+			name: "vesting",
+			utxo: UTXO{
+				Address: addr,
+				Amount:  300,
+				Tranches: []Tranche{
+					{Locktime: 4, Amount: 100},
+					{Locktime: 10, Amount: 200},
+				},
+			},
+			vesting: true,
+		},
+		{
+			name: "vested before genesis",
+			utxo: UTXO{
+				Address: addr,
+				Amount:  300,
+				Tranches: []Tranche{
+					{Locktime: 1, Amount: 100},
+					{Locktime: 2, Amount: 200},
+				},
+			},
+		},
+		{
+			name: "wrong tranche sum",
+			utxo: UTXO{
+				Address: addr,
+				Amount:  300,
+				Tranches: []Tranche{
+					{Locktime: 10, Amount: 100},
+				},
+			},
+			expectedErr: errWrongVestingAmount,
+		},
+		{
+			name: "locktime and tranches",
+			utxo: UTXO{
+				Address:  addr,
+				Amount:   300,
+				Locktime: 10,
+				Tranches: []Tranche{
+					{Locktime: 10, Amount: 300},
+				},
+			},
+			expectedErr: errLocktimeAndTranches,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := BuildGenesisArgs{
+				AvaxAssetID: ids.ID{'d', 'u', 'm', 'm', 'y', ' ', 'I', 'D'},
+				UTXOs:       []UTXO{test.utxo},
+				Validators: []PrimaryValidator{{
+					Staker: Staker{
+						EndTime: 15,
+						NodeID:  nodeID,
+					},
+					RewardOwner: &Owner{
+						Threshold: 1,
+						Addresses: []string{addr},
+					},
+					Staked: []UTXO{{
+						Amount:  987654321,
+						Address: addr,
+					}},
+				}},
+				Time:     5,
+				Encoding: formatting.Hex,
+			}
+			reply := BuildGenesisReply{}
+
+			ss := StaticService{}
+			err := ss.BuildGenesis(nil, &args, &reply)
+			if err != test.expectedErr {
+				t.Fatalf("expected error %v but got %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			genesisBytes, err := formatting.Decode(reply.Encoding, reply.Bytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			genesis, err := genesis.Parse(genesisBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(genesis.UTXOs) != 1 {
+				t.Fatal("genesis should contain 1 UTXO")
+			}
+			_, isVesting := genesis.UTXOs[0].Out.(*stakeable.VestingOut)
+			if isVesting != test.vesting {
+				t.Fatalf("expected vesting output: %v", test.vesting)
+			}
+		})
+	}
+}
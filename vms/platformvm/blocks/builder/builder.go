@@ -293,12 +293,10 @@ func (b *builder) getNextStakerToReward(preferredState state.Chain) (ids.ID, boo
 
 	for currentStakerIterator.Next() {
 		currentStaker := currentStakerIterator.Value()
-		priority := currentStaker.Priority
-		// If the staker is a primary network staker (not a subnet validator),
-		// it's the next staker we will want to remove with a RewardValidatorTx
+		// If the staker is rewarded (not a permissioned subnet validator), it's
+		// the next staker we will want to remove with a RewardValidatorTx
 		// rather than an AdvanceTimeTx.
-		if priority == state.PrimaryNetworkDelegatorCurrentPriority ||
-			priority == state.PrimaryNetworkValidatorCurrentPriority {
+		if currentStaker.Priority.IsRewarded() {
 			return currentStaker.TxID, currentChainTimestamp.Equal(currentStaker.EndTime), nil
 		}
 	}
@@ -42,6 +42,7 @@ import (
 	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/metrics"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakinghistory"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/status"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
@@ -143,7 +144,7 @@ func newEnvironment(t *testing.T) *environment {
 
 	res.atomicUTXOs = avax.NewAtomicUTXOManager(res.ctx.SharedMemory, txs.Codec)
 	res.uptimes = uptime.NewManager(res.state)
-	res.utxosHandler = utxo.NewHandler(res.ctx, res.clk, res.state, res.fx)
+	res.utxosHandler = utxo.NewHandler(res.ctx, res.config, res.clk, res.state, res.fx)
 
 	res.txBuilder = txbuilder.New(
 		res.ctx,
@@ -164,7 +165,6 @@ func newEnvironment(t *testing.T) *environment {
 		Fx:           res.fx,
 		FlowChecker:  res.utxosHandler,
 		Uptimes:      res.uptimes,
-		Rewards:      rewardsCalc,
 	}
 
 	registerer := prometheus.NewRegistry()
@@ -192,6 +192,10 @@ func newEnvironment(t *testing.T) *environment {
 		res.state,
 		&res.backend,
 		window,
+		stakinghistory.NewNoIndexer(),
+		nil,
+		nil,
+		nil,
 	)
 
 	res.Builder = New(
@@ -8,10 +8,20 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/versiondb"
+	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow/choices"
 	"github.com/kukrer/savannahnode/utils/window"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/components/index"
 	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/metrics"
+	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakinghistory"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 )
 
 var _ blocks.Visitor = &acceptor{}
@@ -23,6 +33,15 @@ type acceptor struct {
 	*backend
 	metrics          metrics.Metrics
 	recentlyAccepted *window.Window
+	stakingHistory   stakinghistory.Indexer
+	// addressTxsIndexer is nil if address indexing is disabled
+	addressTxsIndexer index.AddressTxsIndexer
+	// indexDB is the database the indexes are written to. Its changes are
+	// written along with the state, so that the indexes never include a block
+	// whose acceptance wasn't persisted. It is nil if nothing is indexed.
+	indexDB *versiondb.Database
+	// commitListener is nil if state commits aren't reported
+	commitListener CommitListener
 }
 
 // Note that:
@@ -71,11 +90,16 @@ func (a *acceptor) AtomicBlock(b *blocks.AtomicBlock) error {
 		return fmt.Errorf("couldn't find state of block %s", blkID)
 	}
 
+	// The consumed UTXOs must be read before [onAcceptState] is applied.
+	if err := a.indexAddressTxs([]*txs.Tx{b.Tx}, blkState.onAcceptState); err != nil {
+		return fmt.Errorf("failed to index block %s by address: %w", blkID, err)
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	blkState.onAcceptState.Apply(a.state)
 
-	defer a.state.Abort()
-	batch, err := a.state.CommitBatch()
+	defer a.abort()
+	batch, err := a.commitBatch()
 	if err != nil {
 		return fmt.Errorf(
 			"failed to commit VM's database for block %s: %w",
@@ -93,7 +117,7 @@ func (a *acceptor) AtomicBlock(b *blocks.AtomicBlock) error {
 			err,
 		)
 	}
-	return nil
+	return a.stateCommitted(b, b)
 }
 
 func (a *acceptor) StandardBlock(b *blocks.StandardBlock) error {
@@ -117,11 +141,16 @@ func (a *acceptor) StandardBlock(b *blocks.StandardBlock) error {
 		return fmt.Errorf("couldn't find state of block %s", blkID)
 	}
 
+	// The consumed UTXOs must be read before [onAcceptState] is applied.
+	if err := a.indexAddressTxs(b.Transactions, blkState.onAcceptState); err != nil {
+		return fmt.Errorf("failed to index block %s by address: %w", blkID, err)
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	blkState.onAcceptState.Apply(a.state)
 
-	defer a.state.Abort()
-	batch, err := a.state.CommitBatch()
+	defer a.abort()
+	batch, err := a.commitBatch()
 	if err != nil {
 		return fmt.Errorf(
 			"failed to commit VM's database for block %s: %w",
@@ -138,7 +167,7 @@ func (a *acceptor) StandardBlock(b *blocks.StandardBlock) error {
 	if onAcceptFunc := blkState.onAcceptFunc; onAcceptFunc != nil {
 		onAcceptFunc()
 	}
-	return nil
+	return a.stateCommitted(b, b)
 }
 
 func (a *acceptor) CommitBlock(b *blocks.CommitBlock) error {
@@ -221,9 +250,218 @@ func (a *acceptor) acceptOptionBlock(b blocks.Block, parent blocks.Block) error
 		return fmt.Errorf("couldn't find state of block %s", blkID)
 	}
 
+	// The removed staker and the consumed UTXOs must be read before
+	// [onAcceptState] is applied.
+	if err := a.indexStakingPeriod(b, parent); err != nil {
+		return fmt.Errorf("failed to index staking period: %w", err)
+	}
+	if proposalBlk, ok := parent.(*blocks.ProposalBlock); ok {
+		// The stake of a staker being removed is returned even if the
+		// proposal is aborted.
+		_, committed := b.(*blocks.CommitBlock)
+		_, isReward := proposalBlk.Tx.Unsigned.(*txs.RewardValidatorTx)
+		if committed || isReward {
+			if err := a.indexAddressTxs([]*txs.Tx{proposalBlk.Tx}, blkState.onAcceptState); err != nil {
+				return fmt.Errorf("failed to index block %s by address: %w", parentID, err)
+			}
+		}
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	blkState.onAcceptState.Apply(a.state)
-	return a.state.Commit()
+	if err := a.commit(); err != nil {
+		return err
+	}
+	// The state of the parent proposal block is committed along with the
+	// option.
+	return a.stateCommitted(b, parent)
+}
+
+// indexStakingPeriod records, in the staking history index, the staking period
+// ended by [parent] if it is a proposal block removing a staker. [b] is the
+// accepted option of [parent].
+func (a *acceptor) indexStakingPeriod(b blocks.Block, parent blocks.Block) error {
+	if a.stakingHistory == nil {
+		return nil
+	}
+	proposalBlk, ok := parent.(*blocks.ProposalBlock)
+	if !ok {
+		return nil
+	}
+	rewardTx, ok := proposalBlk.Tx.Unsigned.(*txs.RewardValidatorTx)
+	if !ok {
+		return nil
+	}
+	parentState, ok := a.blkIDToState[parent.ID()]
+	if !ok {
+		return fmt.Errorf("couldn't find state of block %s", parent.ID())
+	}
+
+	// The staker being removed is the first current staker of the last
+	// accepted state.
+	stakerIterator, err := a.state.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	defer stakerIterator.Release()
+	if !stakerIterator.Next() {
+		return fmt.Errorf("couldn't find staker %s", rewardTx.TxID)
+	}
+	staker := stakerIterator.Value()
+	if staker.TxID != rewardTx.TxID {
+		return fmt.Errorf("expected to remove staker %s but found %s", rewardTx.TxID, staker.TxID)
+	}
+
+	stakerTx, _, err := a.state.GetTx(staker.TxID)
+	if err != nil {
+		return err
+	}
+
+	_, committed := b.(*blocks.CommitBlock)
+	period := &stakinghistory.Period{
+		TxID:      staker.TxID,
+		NodeID:    staker.NodeID,
+		SubnetID:  staker.SubnetID,
+		StartTime: uint64(staker.StartTime.Unix()),
+		EndTime:   uint64(staker.EndTime.Unix()),
+		Stake:     staker.Weight,
+		Uptime:    uint64(parentState.uptime * reward.PercentDenominator),
+		Rewarded:  committed,
+	}
+
+	var rewardsOwner fx.Owner
+	switch uStakerTx := stakerTx.Unsigned.(type) {
+	case *txs.AddValidatorTx:
+		rewardsOwner = uStakerTx.RewardsOwner
+		if committed {
+			period.Reward = staker.PotentialReward
+		}
+	case *txs.AddPermissionlessValidatorTx:
+		rewardsOwner = uStakerTx.ValidatorRewardsOwner
+		if committed {
+			period.Reward = staker.PotentialReward
+		}
+	case *txs.AddDelegatorTx:
+		rewardsOwner = uStakerTx.RewardsOwner
+		period.Delegator = true
+	case *txs.AddPermissionlessDelegatorTx:
+		rewardsOwner = uStakerTx.DelegationRewardsOwner
+		period.Delegator = true
+	default:
+		// Stakers that aren't rewarded, such as permissioned subnet
+		// validators, aren't indexed.
+		return nil
+	}
+
+	if period.Delegator && committed {
+		vdrStaker, err := a.state.GetCurrentValidator(staker.SubnetID, staker.NodeID)
+		if err != nil {
+			return err
+		}
+		vdrTx, _, err := a.state.GetTx(vdrStaker.TxID)
+		if err != nil {
+			return err
+		}
+		var vdrShares uint32
+		switch uVdrTx := vdrTx.Unsigned.(type) {
+		case *txs.AddValidatorTx:
+			vdrShares = uVdrTx.Shares
+		case *txs.AddPermissionlessValidatorTx:
+			vdrShares = uVdrTx.DelegationShares
+		default:
+			return fmt.Errorf("unexpected validator tx type %T", uVdrTx)
+		}
+
+		delegatorReward, delegateeReward := reward.Split(staker.PotentialReward, vdrShares)
+		period.Reward = delegatorReward
+		if err := a.stakingHistory.AddDelegationFee(vdrStaker.TxID, staker.TxID, delegateeReward); err != nil {
+			return err
+		}
+	}
+
+	if addressable, ok := rewardsOwner.(avax.Addressable); ok {
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return err
+			}
+			period.RewardAddresses = append(period.RewardAddresses, addr)
+		}
+	}
+	return a.stakingHistory.AddPeriod(period)
+}
+
+// indexAddressTxs records, in the address index, the addresses affected by
+// [acceptedTxs]. [onAcceptState] is the state after they are accepted.
+func (a *acceptor) indexAddressTxs(acceptedTxs []*txs.Tx, onAcceptState state.Chain) error {
+	if a.addressTxsIndexer == nil {
+		return nil
+	}
+
+	produced := make(map[ids.ID]*avax.UTXO)
+	for _, tx := range acceptedTxs {
+		txID := tx.ID()
+		visitor := &addressTxs{
+			backend:       a.backend,
+			onAcceptState: onAcceptState,
+			produced:      produced,
+			txID:          txID,
+		}
+		if err := tx.Unsigned.Visit(visitor); err != nil {
+			return err
+		}
+		if err := a.addressTxsIndexer.Accept(txID, visitor.inputs, visitor.outputs); err != nil {
+			return err
+		}
+		for _, utxo := range tx.UTXOs() {
+			produced[utxo.InputID()] = utxo
+		}
+	}
+	return nil
+}
+
+// commitBatch returns a batch that writes the changes to the state and to the
+// indexes
+func (a *acceptor) commitBatch() (database.Batch, error) {
+	batch, err := a.state.CommitBatch()
+	if err != nil {
+		return nil, err
+	}
+	if a.indexDB == nil {
+		return batch, nil
+	}
+	indexBatch, err := a.indexDB.CommitBatch()
+	if err != nil {
+		return nil, err
+	}
+	return batch, indexBatch.Replay(batch)
+}
+
+// commit writes the changes to the state and to the indexes
+func (a *acceptor) commit() error {
+	defer a.abort()
+	batch, err := a.commitBatch()
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// abort discards the uncommitted changes to the state and to the indexes
+func (a *acceptor) abort() {
+	a.state.Abort()
+	if a.indexDB != nil {
+		a.indexDB.Abort()
+	}
+}
+
+// stateCommitted reports the commit of the state of the blocks from [first] to
+// [last].
+func (a *acceptor) stateCommitted(last blocks.Block, first blocks.Block) error {
+	if a.commitListener == nil {
+		return nil
+	}
+	return a.commitListener.StateCommitted(last, first.Height()-1)
 }
 
 func (a *acceptor) commonAccept(b blocks.Block) error {
@@ -4,6 +4,7 @@
 package executor
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -13,6 +14,8 @@ import (
 
 	"github.com/kukrer/savannahnode/chains/atomic"
 	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/memdb"
+	"github.com/kukrer/savannahnode/database/versiondb"
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow"
 	"github.com/kukrer/savannahnode/snow/choices"
@@ -328,6 +331,7 @@ func TestAcceptorVisitCommitBlock(t *testing.T) {
 
 	// Set expected calls on dependencies.
 	// Make sure the parent is accepted first.
+	batch := database.NewMockBatch(ctrl)
 	gomock.InOrder(
 		parentStatelessBlk.EXPECT().ID().Return(parentID).Times(1),
 		s.EXPECT().SetLastAccepted(parentID).Times(1),
@@ -340,7 +344,9 @@ func TestAcceptorVisitCommitBlock(t *testing.T) {
 		s.EXPECT().AddStatelessBlock(blk, choices.Accepted).Times(1),
 
 		onAcceptState.EXPECT().Apply(s).Times(1),
-		s.EXPECT().Commit().Return(nil).Times(1),
+		s.EXPECT().CommitBatch().Return(batch, nil).Times(1),
+		batch.EXPECT().Write().Return(nil).Times(1),
+		s.EXPECT().Abort().Times(1),
 	)
 
 	err = acceptor.CommitBlock(blk)
@@ -421,6 +427,7 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 
 	// Set expected calls on dependencies.
 	// Make sure the parent is accepted first.
+	batch := database.NewMockBatch(ctrl)
 	gomock.InOrder(
 		parentStatelessBlk.EXPECT().ID().Return(parentID).Times(1),
 		s.EXPECT().SetLastAccepted(parentID).Times(1),
@@ -433,10 +440,120 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 		s.EXPECT().AddStatelessBlock(blk, choices.Accepted).Times(1),
 
 		onAcceptState.EXPECT().Apply(s).Times(1),
-		s.EXPECT().Commit().Return(nil).Times(1),
+		s.EXPECT().CommitBatch().Return(batch, nil).Times(1),
+		batch.EXPECT().Write().Return(nil).Times(1),
+		s.EXPECT().Abort().Times(1),
 	)
 
 	err = acceptor.AbortBlock(blk)
 	require.NoError(err)
 	require.Equal(blk.ID(), acceptor.backend.lastAccepted)
 }
+
+type testCommitListener struct {
+	blk        blocks.Block
+	prevHeight uint64
+}
+
+func (l *testCommitListener) StateCommitted(blk blocks.Block, prevHeight uint64) error {
+	l.blk = blk
+	l.prevHeight = prevHeight
+	return nil
+}
+
+func TestAcceptorStateCommitted(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := state.NewMockState(ctrl)
+	sharedMemory := atomic.NewMockSharedMemory(ctrl)
+	listener := &testCommitListener{}
+
+	parentID := ids.GenerateTestID()
+	acceptor := &acceptor{
+		backend: &backend{
+			lastAccepted: parentID,
+			blkIDToState: make(map[ids.ID]*blockState),
+			state:        s,
+			ctx: &snow.Context{
+				Log:          logging.NoLog{},
+				SharedMemory: sharedMemory,
+			},
+		},
+		metrics: metrics.Noop,
+		recentlyAccepted: window.New(window.Config{
+			Clock:   &mockable.Clock{},
+			MaxSize: 1,
+			TTL:     time.Hour,
+		}),
+		commitListener: listener,
+	}
+
+	blk, err := blocks.NewStandardBlock(parentID, 10, nil)
+	require.NoError(err)
+
+	onAcceptState := state.NewMockDiff(ctrl)
+	atomicRequests := map[ids.ID]*atomic.Requests{}
+	acceptor.backend.blkIDToState[blk.ID()] = &blockState{
+		onAcceptState:  onAcceptState,
+		atomicRequests: atomicRequests,
+	}
+
+	s.EXPECT().SetLastAccepted(blk.ID()).Times(1)
+	s.EXPECT().SetHeight(blk.Height()).Times(1)
+	s.EXPECT().AddStatelessBlock(blk, choices.Accepted).Times(1)
+	batch := database.NewMockBatch(ctrl)
+	s.EXPECT().CommitBatch().Return(batch, nil).Times(1)
+	s.EXPECT().Abort().Times(1)
+	onAcceptState.EXPECT().Apply(s).Times(1)
+	sharedMemory.EXPECT().Apply(atomicRequests, batch).Return(nil).Times(1)
+
+	require.NoError(acceptor.StandardBlock(blk))
+	require.Equal(blk, listener.blk)
+	require.Equal(uint64(9), listener.prevHeight)
+}
+
+func TestAcceptorCommitsIndexesWithState(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	baseDB := memdb.New()
+	indexDB := versiondb.New(baseDB)
+
+	s := state.NewMockState(ctrl)
+	acceptor := &acceptor{
+		backend: &backend{
+			state: s,
+		},
+		indexDB: indexDB,
+	}
+
+	key := []byte("key")
+	value := []byte("value")
+
+	// The index isn't written if the state can't be committed
+	require.NoError(indexDB.Put(key, value))
+	errCommit := errors.New("failed to commit")
+	s.EXPECT().CommitBatch().Return(nil, errCommit).Times(1)
+	s.EXPECT().Abort().Times(1)
+	require.ErrorIs(acceptor.commit(), errCommit)
+
+	has, err := baseDB.Has(key)
+	require.NoError(err)
+	require.False(has)
+	has, err = indexDB.Has(key)
+	require.NoError(err)
+	require.False(has)
+
+	// The index is written in the batch of the state
+	require.NoError(indexDB.Put(key, value))
+	s.EXPECT().CommitBatch().Return(baseDB.NewBatch(), nil).Times(1)
+	s.EXPECT().Abort().Times(1)
+	require.NoError(acceptor.commit())
+
+	gotValue, err := baseDB.Get(key)
+	require.NoError(err)
+	require.Equal(value, gotValue)
+}
@@ -0,0 +1,231 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var _ txs.Visitor = &addressTxs{}
+
+// addressTxs collects the UTXOs whose owners are affected by an accepted tx,
+// so the tx can be indexed by address. [inputs] are the UTXOs the tx consumes.
+// [outputs] are the UTXOs the tx produces, its stake and exported outputs, its
+// reward owners and the rewards it pays.
+type addressTxs struct {
+	// inputs, to be filled before visitor methods are called
+	*backend
+	// onAcceptState is the state after the tx is accepted
+	onAcceptState state.Chain
+	// produced are the UTXOs produced by the txs previously accepted in the
+	// same block
+	produced map[ids.ID]*avax.UTXO
+	txID     ids.ID
+
+	// outputs of visitor execution
+	inputs  []*avax.UTXO
+	outputs []*avax.UTXO
+}
+
+func (*addressTxs) AdvanceTimeTx(*txs.AdvanceTimeTx) error { return nil }
+
+func (a *addressTxs) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	if err := a.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	a.addOutputs(len(tx.Outs), tx.Stake)
+	a.addOwner(a.ctx.AVAXAssetID, tx.RewardsOwner)
+	return nil
+}
+
+func (a *addressTxs) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	return a.baseTx(&tx.BaseTx)
+}
+
+func (a *addressTxs) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	if err := a.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	a.addOutputs(len(tx.Outs), tx.Stake)
+	a.addOwner(a.ctx.AVAXAssetID, tx.RewardsOwner)
+	return nil
+}
+
+func (a *addressTxs) CreateChainTx(tx *txs.CreateChainTx) error {
+	return a.baseTx(&tx.BaseTx)
+}
+
+func (a *addressTxs) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return a.baseTx(&tx.BaseTx)
+}
+
+func (a *addressTxs) ImportTx(tx *txs.ImportTx) error {
+	if err := a.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+
+	utxoIDs := make([][]byte, len(tx.ImportedInputs))
+	for i, in := range tx.ImportedInputs {
+		utxoID := in.UTXOID.InputID()
+		utxoIDs[i] = utxoID[:]
+	}
+	allUTXOBytes, err := a.ctx.SharedMemory.Get(tx.SourceChain, utxoIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get shared memory: %w", err)
+	}
+	for _, utxoBytes := range allUTXOBytes {
+		utxo := &avax.UTXO{}
+		if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+			return fmt.Errorf("failed to unmarshal UTXO: %w", err)
+		}
+		a.inputs = append(a.inputs, utxo)
+	}
+	return nil
+}
+
+func (a *addressTxs) ExportTx(tx *txs.ExportTx) error {
+	if err := a.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	a.addOutputs(len(tx.Outs), tx.ExportedOutputs)
+	return nil
+}
+
+func (a *addressTxs) RewardValidatorTx(tx *txs.RewardValidatorTx) error {
+	stakerTx, _, err := a.state.GetTx(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get staker tx %s: %w", tx.TxID, err)
+	}
+
+	// The stake is returned whether or not the staker is rewarded
+	switch uStakerTx := stakerTx.Unsigned.(type) {
+	case *txs.AddValidatorTx:
+		a.addOutputs(len(uStakerTx.Outs), uStakerTx.Stake)
+	case *txs.AddDelegatorTx:
+		a.addOutputs(len(uStakerTx.Outs), uStakerTx.Stake)
+	case *txs.AddPermissionlessValidatorTx:
+		a.addOutputs(len(uStakerTx.Outs), uStakerTx.StakeOuts)
+	case *txs.AddPermissionlessDelegatorTx:
+		a.addOutputs(len(uStakerTx.Outs), uStakerTx.StakeOuts)
+	}
+
+	// So is the stake added by top ups of the staker
+	topUps, err := a.state.GetValidatorTopUps(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get top ups of %s: %w", tx.TxID, err)
+	}
+	for _, topUpTx := range topUps {
+		if topUp, ok := topUpTx.Unsigned.(*txs.TopUpValidatorTx); ok {
+			a.addOutputs(len(topUp.Outs), topUp.Stake)
+		}
+	}
+
+	rewardUTXOs, err := a.onAcceptState.GetRewardUTXOs(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get reward UTXOs of %s: %w", tx.TxID, err)
+	}
+	a.outputs = append(a.outputs, rewardUTXOs...)
+	return nil
+}
+
+func (a *addressTxs) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return a.baseTx(&tx.BaseTx)
+}
+
+func (a *addressTxs) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return a.baseTx(&tx.BaseTx)
+}
+
+func (a *addressTxs) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	if err := a.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	a.addOwner(a.ctx.AVAXAssetID, tx.Owner)
+	return nil
+}
+
+func (a *addressTxs) TopUpValidatorTx(tx *txs.TopUpValidatorTx) error {
+	if err := a.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	a.addOutputs(len(tx.Outs), tx.Stake)
+	return nil
+}
+
+func (a *addressTxs) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	if err := a.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	a.addOutputs(len(tx.Outs), tx.StakeOuts)
+	if len(tx.StakeOuts) > 0 {
+		assetID := tx.StakeOuts[0].AssetID()
+		a.addOwner(assetID, tx.ValidatorRewardsOwner)
+		a.addOwner(assetID, tx.DelegatorRewardsOwner)
+	}
+	return nil
+}
+
+func (a *addressTxs) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	if err := a.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	a.addOutputs(len(tx.Outs), tx.StakeOuts)
+	if len(tx.StakeOuts) > 0 {
+		a.addOwner(tx.StakeOuts[0].AssetID(), tx.DelegationRewardsOwner)
+	}
+	return nil
+}
+
+func (a *addressTxs) baseTx(tx *txs.BaseTx) error {
+	for _, in := range tx.Ins {
+		utxoID := in.InputID()
+		utxo, ok := a.produced[utxoID]
+		if !ok {
+			var err error
+			utxo, err = a.state.GetUTXO(utxoID)
+			if err != nil {
+				return fmt.Errorf("failed to get UTXO %s: %w", &in.UTXOID, err)
+			}
+		}
+		a.inputs = append(a.inputs, utxo)
+	}
+	a.addOutputs(0, tx.Outs)
+	return nil
+}
+
+// addOutputs adds [outs] to the outputs, as if they were UTXOs of the tx
+// starting at [offset].
+func (a *addressTxs) addOutputs(offset int, outs []*avax.TransferableOutput) {
+	for i, out := range outs {
+		a.outputs = append(a.outputs, &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        a.txID,
+				OutputIndex: uint32(offset + i),
+			},
+			Asset: out.Asset,
+			Out:   out.Output(),
+		})
+	}
+}
+
+// addOwner adds [owner] to the outputs, as if it owned a UTXO of [assetID]
+// produced by the tx.
+func (a *addressTxs) addOwner(assetID ids.ID, owner fx.Owner) {
+	outputOwners, ok := owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return
+	}
+	a.outputs = append(a.outputs, &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: a.txID},
+		Asset:  avax.Asset{ID: assetID},
+		Out:    &secp256k1fx.TransferOutput{OutputOwners: *outputOwners},
+	})
+}
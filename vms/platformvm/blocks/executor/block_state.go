@@ -21,6 +21,8 @@ type proposalBlockState struct {
 	initiallyPreferCommit bool
 	onCommitState         state.Diff
 	onAbortState          state.Diff
+	// uptime of the staker removed by the block's RewardValidatorTx
+	uptime float64
 }
 
 // The state of a block.
@@ -4,11 +4,14 @@
 package executor
 
 import (
+	"github.com/kukrer/savannahnode/database/versiondb"
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow/consensus/snowman"
 	"github.com/kukrer/savannahnode/utils/window"
+	"github.com/kukrer/savannahnode/vms/components/index"
 	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
 	"github.com/kukrer/savannahnode/vms/platformvm/metrics"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakinghistory"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs/executor"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs/mempool"
@@ -23,14 +26,35 @@ type Manager interface {
 	LastAccepted() ids.ID
 	GetBlock(blkID ids.ID) (snowman.Block, error)
 	NewBlock(blocks.Block) snowman.Block
+
+	// SetLastAccepted marks [blkID] as the most recently accepted block after
+	// the state was replaced without accepting the blocks before it, as done
+	// by state sync.
+	SetLastAccepted(blkID ids.ID)
+}
+
+// CommitListener is notified every time the state is committed after accepting
+// blocks.
+type CommitListener interface {
+	// StateCommitted is called after the state of the blocks with heights in
+	// (prevHeight, blk.Height()] was committed, where [blk] is the last
+	// accepted block.
+	StateCommitted(blk blocks.Block, prevHeight uint64) error
 }
 
+// NewManager returns a block manager. [addressTxsIndexer] may be nil if
+// accepted txs shouldn't be indexed by address and [commitListener] may be nil
+// if the state commits shouldn't be reported.
 func NewManager(
 	mempool mempool.Mempool,
 	metrics metrics.Metrics,
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	recentlyAccepted *window.Window,
+	stakingHistory stakinghistory.Indexer,
+	addressTxsIndexer index.AddressTxsIndexer,
+	indexDB *versiondb.Database,
+	commitListener CommitListener,
 ) Manager {
 	backend := &backend{
 		Mempool:      mempool,
@@ -48,9 +72,13 @@ func NewManager(
 			txExecutorBackend: txExecutorBackend,
 		},
 		acceptor: &acceptor{
-			backend:          backend,
-			metrics:          metrics,
-			recentlyAccepted: recentlyAccepted,
+			backend:           backend,
+			metrics:           metrics,
+			recentlyAccepted:  recentlyAccepted,
+			stakingHistory:    stakingHistory,
+			addressTxsIndexer: addressTxsIndexer,
+			indexDB:           indexDB,
+			commitListener:    commitListener,
 		},
 		rejector: &rejector{backend: backend},
 	}
@@ -77,3 +105,7 @@ func (m *manager) NewBlock(blk blocks.Block) snowman.Block {
 		Block:   blk,
 	}
 }
+
+func (m *manager) SetLastAccepted(blkID ids.ID) {
+	m.backend.lastAccepted = blkID
+}
@@ -9,7 +9,9 @@ import (
 
 	"github.com/kukrer/savannahnode/chains/atomic"
 	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/math"
 	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/status"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
@@ -59,12 +61,18 @@ func (v *verifier) ProposalBlock(b *blocks.ProposalBlock) error {
 	onAbortState := txExecutor.OnAbort
 	onAbortState.AddTx(b.Tx, status.Aborted)
 
+	// [onAbortState] has the same timestamp and base fee as the parent block.
+	if err := v.updateBaseFee(onAbortState, []*txs.Tx{b.Tx}, onCommitState, onAbortState); err != nil {
+		return err
+	}
+
 	v.blkIDToState[blkID] = &blockState{
 		statelessBlock: b,
 		proposalBlockState: proposalBlockState{
 			onCommitState:         onCommitState,
 			onAbortState:          onAbortState,
 			initiallyPreferCommit: txExecutor.PrefersCommit,
+			uptime:                txExecutor.Uptime,
 		},
 
 		// It is safe to use [pb.onAbortState] here because the timestamp will
@@ -119,6 +127,10 @@ func (v *verifier) AtomicBlock(b *blocks.AtomicBlock) error {
 
 	atomicExecutor.OnAccept.AddTx(b.Tx, status.Committed)
 
+	if err := v.updateBaseFee(parentState, []*txs.Tx{b.Tx}, atomicExecutor.OnAccept); err != nil {
+		return err
+	}
+
 	if err := v.verifyUniqueInputs(b, atomicExecutor.Inputs); err != nil {
 		return err
 	}
@@ -199,6 +211,12 @@ func (v *verifier) StandardBlock(b *blocks.StandardBlock) error {
 		return err
 	}
 
+	// Standard txs modify neither the timestamp nor the base fee, so
+	// [onAcceptState] still has the values of the parent block.
+	if err := v.updateBaseFee(onAcceptState, b.Transactions, onAcceptState); err != nil {
+		return err
+	}
+
 	if numFuncs := len(funcs); numFuncs == 1 {
 		blkState.onAcceptFunc = funcs[0]
 	} else if numFuncs > 1 {
@@ -286,6 +304,38 @@ func (v *verifier) verifyCommonBlock(b *blocks.CommonBlock) error {
 	return nil
 }
 
+// updateBaseFee sets the base fee of [onAcceptStates] based on the gas consumed
+// by [transactions]. [parentState] must have the timestamp and base fee that
+// [transactions] were executed with.
+func (v *verifier) updateBaseFee(parentState state.Chain, transactions []*txs.Tx, onAcceptStates ...state.Chain) error {
+	cfg := v.txExecutorBackend.Config
+	if !cfg.IsDynamicFeesActivated(parentState.GetTimestamp()) {
+		return nil
+	}
+
+	gasUsed := uint64(0)
+	for _, tx := range transactions {
+		complexity, err := fees.TxComplexity(tx)
+		if err != nil {
+			return err
+		}
+		gas, err := cfg.DynamicFees.Gas(complexity)
+		if err != nil {
+			return err
+		}
+		gasUsed, err = math.Add64(gasUsed, gas)
+		if err != nil {
+			return err
+		}
+	}
+
+	baseFee := cfg.DynamicFees.NextBaseFee(parentState.GetBaseFee(), gasUsed)
+	for _, onAcceptState := range onAcceptStates {
+		onAcceptState.SetBaseFee(baseFee)
+	}
+	return nil
+}
+
 // verifyUniqueInputs verifies that the inputs of the given block are not
 // duplicated in any of the parent blocks pinned in memory.
 func (v *verifier) verifyUniqueInputs(block blocks.Block, inputs ids.Set) error {
@@ -15,6 +15,7 @@ import (
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow"
 	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/utils/timer/mockable"
 	"github.com/kukrer/savannahnode/vms/components/verify"
 	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
 	"github.com/kukrer/savannahnode/vms/platformvm/config"
@@ -35,7 +36,11 @@ func TestVerifierVisitProposalBlock(t *testing.T) {
 	parentID := ids.GenerateTestID()
 	parentStatelessBlk := blocks.NewMockBlock(ctrl)
 	verifier := &verifier{
-		txExecutorBackend: &executor.Backend{},
+		txExecutorBackend: &executor.Backend{
+			Config: &config.Config{
+				DynamicFeesTime: mockable.MaxTime,
+			},
+		},
 		backend: &backend{
 			lastAccepted: parentID,
 			blkIDToState: map[ids.ID]*blockState{
@@ -84,7 +89,7 @@ func TestVerifierVisitProposalBlock(t *testing.T) {
 	mempool.EXPECT().RemoveProposalTx(blk.Tx).Times(1)
 	onCommitState.EXPECT().AddTx(blk.Tx, status.Committed).Times(1)
 	onAbortState.EXPECT().AddTx(blk.Tx, status.Aborted).Times(1)
-	onAbortState.EXPECT().GetTimestamp().Return(timestamp).Times(1)
+	onAbortState.EXPECT().GetTimestamp().Return(timestamp).Times(2)
 
 	// Visit the block
 	err = verifier.ProposalBlock(blk)
@@ -117,6 +122,7 @@ func TestVerifierVisitAtomicBlock(t *testing.T) {
 		txExecutorBackend: &executor.Backend{
 			Config: &config.Config{
 				ApricotPhase5Time: time.Now().Add(time.Hour),
+				DynamicFeesTime:   mockable.MaxTime,
 			},
 		},
 		backend: &backend{
@@ -162,7 +168,7 @@ func TestVerifierVisitAtomicBlock(t *testing.T) {
 
 	// Set expectations for dependencies.
 	timestamp := time.Now()
-	parentState.EXPECT().GetTimestamp().Return(timestamp).Times(1)
+	parentState.EXPECT().GetTimestamp().Return(timestamp).Times(2)
 	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
 	parentStatelessBlk.EXPECT().Parent().Return(grandparentID).Times(1)
 	mempool.EXPECT().RemoveDecisionTxs([]*txs.Tx{blk.Tx}).Times(1)
@@ -199,6 +205,7 @@ func TestVerifierVisitStandardBlock(t *testing.T) {
 		txExecutorBackend: &executor.Backend{
 			Config: &config.Config{
 				ApricotPhase5Time: time.Now().Add(time.Hour),
+				DynamicFeesTime:   mockable.MaxTime,
 			},
 		},
 		backend: &backend{
@@ -260,6 +267,7 @@ func TestVerifierVisitStandardBlock(t *testing.T) {
 	timestamp := time.Now()
 	parentState.EXPECT().GetTimestamp().Return(timestamp).Times(1)
 	parentState.EXPECT().GetCurrentSupply().Return(uint64(10000)).Times(1)
+	parentState.EXPECT().GetBaseFee().Return(uint64(0)).Times(1)
 	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
 	mempool.EXPECT().RemoveDecisionTxs(blk.Transactions).Times(1)
 
@@ -421,6 +429,7 @@ func TestVerifierVisitStandardBlockWithDuplicateInputs(t *testing.T) {
 		txExecutorBackend: &executor.Backend{
 			Config: &config.Config{
 				ApricotPhase5Time: time.Now().Add(time.Hour),
+				DynamicFeesTime:   mockable.MaxTime,
 			},
 		},
 		backend: &backend{
@@ -490,6 +499,7 @@ func TestVerifierVisitStandardBlockWithDuplicateInputs(t *testing.T) {
 	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
 	parentState.EXPECT().GetTimestamp().Return(timestamp).Times(1)
 	parentState.EXPECT().GetCurrentSupply().Return(uint64(10000)).Times(1)
+	parentState.EXPECT().GetBaseFee().Return(uint64(0)).Times(1)
 	parentStatelessBlk.EXPECT().Parent().Return(grandParentID).Times(1)
 
 	err = verifier.StandardBlock(blk)
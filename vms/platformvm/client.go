@@ -60,6 +60,9 @@ type Client interface {
 	) ([][]byte, ids.ShortID, ids.ID, error)
 	// GetSubnets returns information about the specified subnets
 	GetSubnets(context.Context, []ids.ID, ...rpc.Option) ([]ClientSubnet, error)
+	// GetSubnet returns the current owner of [subnetID] and every owner it
+	// has had, oldest first
+	GetSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*GetSubnetReply, error)
 	// GetStakingAssetID returns the assetID of the asset used for staking on
 	// subnet corresponding to [subnetID]
 	GetStakingAssetID(context.Context, ids.ID, ...rpc.Option) (ids.ID, error)
@@ -67,8 +70,11 @@ type Client interface {
 	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ClientPrimaryValidator, error)
 	// GetPendingValidators returns the list of pending validators for subnet with ID [subnetID]
 	GetPendingValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]interface{}, []interface{}, error)
-	// GetCurrentSupply returns an upper bound on the supply of AVAX in the system
-	GetCurrentSupply(ctx context.Context, options ...rpc.Option) (uint64, error)
+	// GetCurrentSupply returns an upper bound on the supply of the staking
+	// asset of the subnet with ID [subnetID]
+	GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, error)
+	// GetSupplyBreakdown returns where the AVAX of the P-chain went
+	GetSupplyBreakdown(ctx context.Context, options ...rpc.Option) (*GetSupplyBreakdownReply, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
 	SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error)
 	// AddValidator issues a transaction to add a validator to the primary network
@@ -114,6 +120,17 @@ type Client interface {
 		endTime uint64,
 		options ...rpc.Option,
 	) (ids.ID, error)
+	// RemoveSubnetValidator issues a transaction to remove validator [nodeID]
+	// from subnet with ID [subnetID] and returns the txID
+	RemoveSubnetValidator(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		subnetID ids.ID,
+		nodeID ids.NodeID,
+		options ...rpc.Option,
+	) (ids.ID, error)
 	// CreateSubnet issues a transaction to create [subnet] and returns the txID
 	CreateSubnet(
 		ctx context.Context,
@@ -168,10 +185,21 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// SimulateTx verifies the transaction against the preferred state without
+	// issuing it
+	SimulateTx(ctx context.Context, tx []byte, options ...rpc.Option) (*SimulateTxReply, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetTxStatusResponse, error)
+	// GetMempool returns up to [limit] of the txs in the mempool, starting at
+	// the [startIndex]th oldest tx
+	GetMempool(ctx context.Context, startIndex uint64, limit uint32, options ...rpc.Option) (*GetMempoolReply, error)
+	// GetMempoolTx returns the byte representation of the mempool tx
+	// corresponding to [txID] and when it was added to the mempool
+	GetMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, *MempoolTx, error)
+	// GetDroppedTxReason returns the reason [txID] was dropped from the mempool
+	GetDroppedTxReason(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetDroppedTxReasonReply, error)
 	// AwaitTxDecided polls [GetTxStatus] until a status is returned that
 	// implies the tx may be decided.
 	AwaitTxDecided(
@@ -198,13 +226,57 @@ type Client interface {
 		endTime uint64,
 		options ...rpc.Option,
 	) (uint64, error)
+	// EstimateReward returns the reward of staking [amount] for [duration]
+	// seconds, either as a validator or as a delegator of [nodeID]
+	EstimateReward(
+		ctx context.Context,
+		subnetID ids.ID,
+		nodeID ids.NodeID,
+		amount uint64,
+		duration uint64,
+		delegator bool,
+		options ...rpc.Option,
+	) (*EstimateRewardReply, error)
+	// GetStakingHistory returns up to [limit] staking periods of [nodeID] or,
+	// if [nodeID] is empty, of the reward address [addr], skipping the first
+	// [cursor] periods. The cursor of the next page is returned.
+	GetStakingHistory(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		addr string,
+		cursor uint64,
+		limit uint32,
+		options ...rpc.Option,
+	) ([]StakingPeriod, uint64, error)
+	// GetAddressTxs returns up to [pageSize] IDs of txs that affected [addr]'s
+	// balance of [assetID], starting at [cursor]. If [txTypes] is provided,
+	// only txs of these types are returned. The cursor of the next page is
+	// returned.
+	GetAddressTxs(
+		ctx context.Context,
+		addr ids.ShortID,
+		assetID ids.ID,
+		txTypes []string,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) ([]ids.ID, uint64, error)
 	// GetRewardUTXOs returns the reward UTXOs for a transaction
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
+	// GetFeeState returns the parameters needed to calculate the
+	// complexity-based fee of a tx
+	GetFeeState(ctx context.Context, options ...rpc.Option) (*GetFeeStateReply, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided subnet
 	// at the specified height.
 	GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (map[ids.NodeID]uint64, error)
+	// GetValidatorSetChanges returns the changes made to the validator set of
+	// the provided subnet between [startHeight] and [endHeight], inclusive.
+	GetValidatorSetChanges(ctx context.Context, subnetID ids.ID, startHeight, endHeight uint64, options ...rpc.Option) (*GetValidatorSetChangesReply, error)
+	// GetRetainedRange returns the range of heights whose blocks, txs and
+	// validator sets weren't pruned.
+	GetRetainedRange(ctx context.Context, options ...rpc.Option) (*GetRetainedRangeReply, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 }
@@ -361,6 +433,14 @@ func (c *client) GetSubnets(ctx context.Context, ids []ids.ID, options ...rpc.Op
 	return subnets, nil
 }
 
+func (c *client) GetSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*GetSubnetReply, error) {
+	res := &GetSubnetReply{}
+	err := c.requester.SendRequest(ctx, "getSubnet", &GetSubnetArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error) {
 	res := &GetStakingAssetIDResponse{}
 	err := c.requester.SendRequest(ctx, "getStakingAssetID", &GetStakingAssetIDArgs{
@@ -400,12 +480,20 @@ func (c *client) GetPendingValidators(
 	return res.Validators, res.Delegators, err
 }
 
-func (c *client) GetCurrentSupply(ctx context.Context, options ...rpc.Option) (uint64, error) {
+func (c *client) GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, error) {
 	res := &GetCurrentSupplyReply{}
-	err := c.requester.SendRequest(ctx, "getCurrentSupply", struct{}{}, res, options...)
+	err := c.requester.SendRequest(ctx, "getCurrentSupply", &GetCurrentSupplyArgs{
+		SubnetID: subnetID,
+	}, res, options...)
 	return uint64(res.Supply), err
 }
 
+func (c *client) GetSupplyBreakdown(ctx context.Context, options ...rpc.Option) (*GetSupplyBreakdownReply, error) {
+	res := &GetSupplyBreakdownReply{}
+	err := c.requester.SendRequest(ctx, "getSupplyBreakdown", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error) {
 	res := &SampleValidatorsReply{}
 	err := c.requester.SendRequest(ctx, "sampleValidators", &SampleValidatorsArgs{
@@ -510,6 +598,28 @@ func (c *client) AddSubnetValidator(
 	return res.TxID, err
 }
 
+func (c *client) RemoveSubnetValidator(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "removeSubnetValidator", &RemoveSubnetValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		NodeID:   nodeID,
+		SubnetID: subnetID.String(),
+	}, res, options...)
+	return res.TxID, err
+}
+
 func (c *client) CreateSubnet(
 	ctx context.Context,
 	user api.UserPass,
@@ -658,6 +768,20 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) SimulateTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest(ctx, "simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "getTx", &api.GetTxArgs{
@@ -684,6 +808,39 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res, err
 }
 
+func (c *client) GetMempool(ctx context.Context, startIndex uint64, limit uint32, options ...rpc.Option) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "getMempool", &GetMempoolArgs{
+		StartIndex: json.Uint64(startIndex),
+		Limit:      json.Uint32(limit),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, *MempoolTx, error) {
+	res := &struct {
+		MempoolTx
+		api.FormattedTx
+	}{}
+	err := c.requester.SendRequest(ctx, "getMempoolTx", &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	txBytes, err := formatting.Decode(res.Encoding, res.Tx)
+	return txBytes, &res.MempoolTx, err
+}
+
+func (c *client) GetDroppedTxReason(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetDroppedTxReasonReply, error) {
+	res := &GetDroppedTxReasonReply{}
+	err := c.requester.SendRequest(ctx, "getDroppedTxReason", &api.JSONTxID{
+		TxID: txID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) AwaitTxDecided(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (*GetTxStatusResponse, error) {
 	ticker := time.NewTicker(freq)
 	defer ticker.Stop()
@@ -759,6 +916,41 @@ func (c *client) GetMaxStakeAmount(ctx context.Context, subnetID ids.ID, nodeID
 	return uint64(res.Amount), err
 }
 
+func (c *client) EstimateReward(ctx context.Context, subnetID ids.ID, nodeID ids.NodeID, amount, duration uint64, delegator bool, options ...rpc.Option) (*EstimateRewardReply, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest(ctx, "estimateReward", &EstimateRewardArgs{
+		SubnetID:  subnetID,
+		NodeID:    nodeID,
+		Amount:    json.Uint64(amount),
+		Duration:  json.Uint64(duration),
+		Delegator: delegator,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetStakingHistory(ctx context.Context, nodeID ids.NodeID, addr string, cursor uint64, limit uint32, options ...rpc.Option) ([]StakingPeriod, uint64, error) {
+	res := &GetStakingHistoryReply{}
+	err := c.requester.SendRequest(ctx, "getStakingHistory", &GetStakingHistoryArgs{
+		NodeID:  nodeID,
+		Address: addr,
+		Cursor:  json.Uint64(cursor),
+		Limit:   json.Uint32(limit),
+	}, res, options...)
+	return res.Periods, uint64(res.EndCursor), err
+}
+
+func (c *client) GetAddressTxs(ctx context.Context, addr ids.ShortID, assetID ids.ID, txTypes []string, cursor, pageSize uint64, options ...rpc.Option) ([]ids.ID, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest(ctx, "getAddressTxs", &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+		AssetID:     assetID,
+		TxTypes:     txTypes,
+		Cursor:      json.Uint64(cursor),
+		PageSize:    json.Uint64(pageSize),
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
 func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, options ...rpc.Option) ([][]byte, error) {
 	res := &GetRewardUTXOsReply{}
 	err := c.requester.SendRequest(ctx, "getRewardUTXOs", args, res, options...)
@@ -782,6 +974,12 @@ func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.
 	return res.Timestamp, err
 }
 
+func (c *client) GetFeeState(ctx context.Context, options ...rpc.Option) (*GetFeeStateReply, error) {
+	res := &GetFeeStateReply{}
+	err := c.requester.SendRequest(ctx, "getFeeState", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (map[ids.NodeID]uint64, error) {
 	res := &GetValidatorsAtReply{}
 	err := c.requester.SendRequest(ctx, "getValidatorsAt", &GetValidatorsAtArgs{
@@ -791,6 +989,22 @@ func (c *client) GetValidatorsAt(ctx context.Context, subnetID ids.ID, height ui
 	return res.Validators, err
 }
 
+func (c *client) GetValidatorSetChanges(ctx context.Context, subnetID ids.ID, startHeight, endHeight uint64, options ...rpc.Option) (*GetValidatorSetChangesReply, error) {
+	res := &GetValidatorSetChangesReply{}
+	err := c.requester.SendRequest(ctx, "getValidatorSetChanges", &GetValidatorSetChangesArgs{
+		SubnetID:    subnetID,
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetRetainedRange(ctx context.Context, options ...rpc.Option) (*GetRetainedRangeReply, error) {
+	res := &GetRetainedRangeReply{}
+	err := c.requester.SendRequest(ctx, "getRetainedRange", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	response := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "getBlock", &api.GetBlockArgs{
@@ -9,6 +9,7 @@ import (
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/formatting/address"
 	"github.com/kukrer/savannahnode/vms/platformvm/api"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
 )
 
 // ClientStaker is the representation of a staker sent via client.
@@ -43,6 +44,7 @@ type ClientPrimaryValidator struct {
 	DelegationFee   float32
 	Uptime          *float32
 	Connected       *bool
+	Signer          *signer.ProofOfPossession
 	// The delegators delegating to this validator
 	Delegators []ClientPrimaryDelegator
 }
@@ -118,6 +120,7 @@ func getClientPrimaryValidators(validatorsSliceIntf []interface{}) ([]ClientPrim
 			DelegationFee:   float32(apiValidator.DelegationFee),
 			Uptime:          (*float32)(apiValidator.Uptime),
 			Connected:       &apiValidator.Connected,
+			Signer:          apiValidator.Signer,
 			Delegators:      clientDelegators,
 		}
 	}
@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+// ChainConfig is the node-local configuration of the platform chain, parsed
+// from the chain config file
+type ChainConfig struct {
+	// IndexStakingHistory enables the index of ended staking periods served by
+	// platform.getStakingHistory
+	IndexStakingHistory bool `json:"index-staking-history"`
+	// IndexTransactions enables the index of accepted txs by the addresses
+	// they affect, served by platform.getAddressTxs
+	IndexTransactions bool `json:"index-transactions"`
+	// IndexAllowIncomplete allows the address index to be enabled after txs
+	// were accepted without it, or to be disabled after it was enabled
+	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
+	// StateSyncEnabled allows the platform chain to state sync, rather than
+	// executing every block, when the node starts without any accepted block
+	StateSyncEnabled bool `json:"state-sync-enabled"`
+	// StateSyncServerEnabled snapshots the state periodically and serves the
+	// snapshots to the nodes that state sync
+	StateSyncServerEnabled bool `json:"state-sync-server-enabled"`
+	// PruningEnabled removes the accepted blocks, the txs only they reference
+	// and the validator set diffs that are older than PruningRetention heights
+	PruningEnabled bool `json:"pruning-enabled"`
+	// PruningRetention is the number of heights, ending at the last accepted
+	// height, that aren't pruned. Defaults to 65536 if not set
+	PruningRetention uint64 `json:"pruning-retention"`
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/kukrer/savannahnode/snow/uptime"
 	"github.com/kukrer/savannahnode/snow/validators"
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/vms/components/burn"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 )
@@ -30,12 +32,19 @@ type Config struct {
 	// Provides access to the uptime manager as a thread safe data structure
 	UptimeLockedCalculator uptime.LockedCalculator
 
+	// Provides access to the AVAX burned as fees by the other chains of the
+	// node. May be nil.
+	BurnRegistry burn.Registry
+
 	// True if the node is being run with staking enabled
 	StakingEnabled bool
 
 	// Set of subnets that this node is validating
 	WhitelistedSubnets ids.Set
 
+	// True if the node exposes the P-chain admin API
+	AdminAPIEnabled bool
+
 	// Fee that must be burned by every create staker transaction
 	AddStakerTxFee uint64
 
@@ -51,6 +60,9 @@ type Config struct {
 	// Fee that must be burned by every blockchain creating transaction after AP3
 	CreateBlockchainTxFee uint64
 
+	// Config for the complexity-based fees charged after [DynamicFeesTime]
+	DynamicFees fees.Config
+
 	// The minimum amount of tokens one must bond to be a validator
 	MinValidatorStake uint64
 
@@ -75,6 +87,11 @@ type Config struct {
 	// Config for the minting function
 	RewardConfig reward.Config
 
+	// StakingUpgrades replace the staking parameters above at their
+	// activation times. They must be sorted by activation time. The genesis
+	// validators are always rewarded with [RewardConfig].
+	StakingUpgrades []StakingUpgrade
+
 	// Time of the AP3 network upgrade
 	ApricotPhase3Time time.Time
 
@@ -83,6 +100,72 @@ type Config struct {
 
 	// Time of the Blueberry network upgrade
 	BlueberryTime time.Time
+
+	// Time of the Baobab network upgrade
+	BaobabTime time.Time
+
+	// Time after which transactions must additionally burn a fee based on
+	// their complexity and the current base fee
+	DynamicFeesTime time.Time
+}
+
+// StakingConfig is the set of parameters that primary network stakers must
+// respect and that their rewards are computed with
+type StakingConfig struct {
+	MinValidatorStake uint64
+	MaxValidatorStake uint64
+	MinDelegatorStake uint64
+	MinDelegationFee  uint32
+	UptimePercentage  float64
+	MinStakeDuration  time.Duration
+	MaxStakeDuration  time.Duration
+	RewardConfig      reward.Config
+}
+
+// StakingUpgrade is a change of the staking parameters of the primary network
+// that is activated at [Time]
+type StakingUpgrade struct {
+	Time time.Time
+	StakingConfig
+}
+
+// GetStakingConfig returns the staking parameters in effect at [t].
+//
+// A primary network staker is subject to the parameters in effect at its start
+// time for its entire staking period, so stakers that start before and after a
+// staking upgrade are handled deterministically regardless of when their
+// transactions are issued.
+func (c *Config) GetStakingConfig(t time.Time) StakingConfig {
+	for i := len(c.StakingUpgrades) - 1; i >= 0; i-- {
+		upgrade := c.StakingUpgrades[i]
+		if !t.Before(upgrade.Time) {
+			return upgrade.StakingConfig
+		}
+	}
+	return StakingConfig{
+		MinValidatorStake: c.MinValidatorStake,
+		MaxValidatorStake: c.MaxValidatorStake,
+		MinDelegatorStake: c.MinDelegatorStake,
+		MinDelegationFee:  c.MinDelegationFee,
+		UptimePercentage:  c.UptimePercentage,
+		MinStakeDuration:  c.MinStakeDuration,
+		MaxStakeDuration:  c.MaxStakeDuration,
+		RewardConfig:      c.RewardConfig,
+	}
+}
+
+// GetRewardCalculator returns the calculator of the rewards of the stakers
+// starting at [t]
+func (c *Config) GetRewardCalculator(t time.Time) reward.Calculator {
+	return reward.NewCalculator(c.GetStakingConfig(t).RewardConfig)
+}
+
+func (c *Config) IsBaobabActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.BaobabTime)
+}
+
+func (c *Config) IsDynamicFeesActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.DynamicFeesTime)
 }
 
 func (c *Config) GetCreateBlockchainTxFee(t time.Time) uint64 {
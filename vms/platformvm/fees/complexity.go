@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	errUninitializedTx       = errors.New("tx has not been initialized")
+	errUnknownCredentialType = errors.New("unknown credential type")
+)
+
+// Complexity describes the resources a tx consumes when it is verified and
+// accepted.
+type Complexity struct {
+	// Bytes is the size of the unsigned tx. Credentials are excluded so that
+	// the complexity can be calculated before the tx is signed.
+	Bytes uint64 `json:"bytes"`
+	// Inputs is the number of UTXOs consumed by the tx.
+	Inputs uint64 `json:"inputs"`
+	// Signatures is the number of signatures that must be verified.
+	Signatures uint64 `json:"signatures"`
+}
+
+// Add returns the sum of [c] and [other].
+func (c Complexity) Add(other Complexity) (Complexity, error) {
+	bytes, err := math.Add64(c.Bytes, other.Bytes)
+	if err != nil {
+		return Complexity{}, err
+	}
+	inputs, err := math.Add64(c.Inputs, other.Inputs)
+	if err != nil {
+		return Complexity{}, err
+	}
+	signatures, err := math.Add64(c.Signatures, other.Signatures)
+	if err != nil {
+		return Complexity{}, err
+	}
+	return Complexity{
+		Bytes:      bytes,
+		Inputs:     inputs,
+		Signatures: signatures,
+	}, nil
+}
+
+// TxComplexity returns the complexity of the signed [tx].
+func TxComplexity(tx *txs.Tx) (Complexity, error) {
+	unsignedBytes := tx.Unsigned.Bytes()
+	if len(unsignedBytes) == 0 {
+		return Complexity{}, errUninitializedTx
+	}
+
+	complexity := Complexity{
+		Bytes:  uint64(len(unsignedBytes)),
+		Inputs: uint64(tx.Unsigned.InputIDs().Len()),
+	}
+	for _, credIntf := range tx.Creds {
+		switch cred := credIntf.(type) {
+		case *secp256k1fx.Credential:
+			complexity.Signatures += uint64(len(cred.Sigs))
+		case *htlcfx.Credential:
+			complexity.Signatures += uint64(len(cred.Sigs))
+		default:
+			return Complexity{}, fmt.Errorf("%w: %T", errUnknownCredentialType, credIntf)
+		}
+	}
+	return complexity, nil
+}
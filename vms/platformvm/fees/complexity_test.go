@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestTxComplexity(t *testing.T) {
+	require := require.New(t)
+
+	factory := crypto.FactorySECP256K1R{}
+	key, err := factory.NewPrivateKey()
+	require.NoError(err)
+
+	assetID := ids.GenerateTestID()
+	utx := &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    1,
+			BlockchainID: ids.GenerateTestID(),
+			Ins: []*avax.TransferableInput{
+				{
+					UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+					Asset:  avax.Asset{ID: assetID},
+					In: &secp256k1fx.TransferInput{
+						Amt:   1,
+						Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+					},
+				},
+				{
+					UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+					Asset:  avax.Asset{ID: assetID},
+					In: &secp256k1fx.TransferInput{
+						Amt:   1,
+						Input: secp256k1fx.Input{SigIndices: []uint32{0, 1}},
+					},
+				},
+			},
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	}
+	avax.SortTransferableInputs(utx.Ins)
+
+	keys := make([][]*crypto.PrivateKeySECP256K1R, len(utx.Ins))
+	for i, in := range utx.Ins {
+		for range in.In.(*secp256k1fx.TransferInput).SigIndices {
+			keys[i] = append(keys[i], key.(*crypto.PrivateKeySECP256K1R))
+		}
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, keys)
+	require.NoError(err)
+
+	complexity, err := TxComplexity(tx)
+	require.NoError(err)
+	require.Equal(Complexity{
+		Bytes:      uint64(len(utx.Bytes())),
+		Inputs:     2,
+		Signatures: 3,
+	}, complexity)
+	require.Less(complexity.Bytes, uint64(len(tx.Bytes())))
+
+	// HTLC credentials pay for their signatures as well
+	for i, credIntf := range tx.Creds {
+		tx.Creds[i] = &htlcfx.Credential{Credential: *credIntf.(*secp256k1fx.Credential)}
+	}
+	htlcComplexity, err := TxComplexity(tx)
+	require.NoError(err)
+	require.Equal(complexity, htlcComplexity)
+
+	_, err = TxComplexity(&txs.Tx{Unsigned: &txs.CreateSubnetTx{}})
+	require.ErrorIs(err, errUninitializedTx)
+}
+
+func TestComplexityAdd(t *testing.T) {
+	require := require.New(t)
+
+	sum, err := Complexity{
+		Bytes:      1,
+		Inputs:     2,
+		Signatures: 3,
+	}.Add(Complexity{
+		Bytes:      4,
+		Inputs:     5,
+		Signatures: 6,
+	})
+	require.NoError(err)
+	require.Equal(Complexity{
+		Bytes:      5,
+		Inputs:     7,
+		Signatures: 9,
+	}, sum)
+
+	_, err = Complexity{Signatures: math.MaxUint64}.Add(Complexity{Signatures: 1})
+	require.Error(err)
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	stdmath "math"
+	"math/big"
+
+	"github.com/kukrer/savannahnode/utils/math"
+)
+
+type Config struct {
+	// BytesWeight is the amount of gas charged per byte of the unsigned tx
+	BytesWeight uint64 `json:"bytesWeight"`
+
+	// InputsWeight is the amount of gas charged per consumed UTXO
+	InputsWeight uint64 `json:"inputsWeight"`
+
+	// SignaturesWeight is the amount of gas charged per signature that must be
+	// verified
+	SignaturesWeight uint64 `json:"signaturesWeight"`
+
+	// MinBaseFee is the lowest price, in nAVAX per unit of gas, that the base
+	// fee can fall to.
+	MinBaseFee uint64 `json:"minBaseFee"`
+
+	// TargetGasPerBlock is the amount of gas a block should consume for the
+	// base fee to remain unchanged. If a block consumes more gas the base fee
+	// increases, if it consumes less the base fee decreases.
+	TargetGasPerBlock uint64 `json:"targetGasPerBlock"`
+
+	// BaseFeeChangeDenominator bounds the relative change of the base fee
+	// between two consecutive blocks. A block consuming twice the target gas
+	// increases the base fee by 1/BaseFeeChangeDenominator.
+	BaseFeeChangeDenominator uint64 `json:"baseFeeChangeDenominator"`
+}
+
+// Gas returns the amount of gas consumed by a tx with [complexity].
+func (c *Config) Gas(complexity Complexity) (uint64, error) {
+	bytesGas, err := math.Mul64(complexity.Bytes, c.BytesWeight)
+	if err != nil {
+		return 0, err
+	}
+	inputsGas, err := math.Mul64(complexity.Inputs, c.InputsWeight)
+	if err != nil {
+		return 0, err
+	}
+	signaturesGas, err := math.Mul64(complexity.Signatures, c.SignaturesWeight)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := math.Add64(bytesGas, inputsGas)
+	if err != nil {
+		return 0, err
+	}
+	return math.Add64(gas, signaturesGas)
+}
+
+// Fee returns the amount of nAVAX that must be burned by a tx with
+// [complexity] when the current base fee is [baseFee].
+func (c *Config) Fee(complexity Complexity, baseFee uint64) (uint64, error) {
+	gas, err := c.Gas(complexity)
+	if err != nil {
+		return 0, err
+	}
+	return math.Mul64(gas, math.Max64(baseFee, c.MinBaseFee))
+}
+
+// NextBaseFee returns the base fee that should be used after a block
+// consumed [gasUsed] gas while the base fee was [baseFee].
+func (c *Config) NextBaseFee(baseFee uint64, gasUsed uint64) uint64 {
+	baseFee = math.Max64(baseFee, c.MinBaseFee)
+	if c.TargetGasPerBlock == 0 || c.BaseFeeChangeDenominator == 0 || gasUsed == c.TargetGasPerBlock {
+		return baseFee
+	}
+
+	// delta = baseFee * |gasUsed - target| / target / denominator
+	gasDelta := new(big.Int)
+	if gasUsed > c.TargetGasPerBlock {
+		gasDelta.SetUint64(gasUsed - c.TargetGasPerBlock)
+	} else {
+		gasDelta.SetUint64(c.TargetGasPerBlock - gasUsed)
+	}
+	delta := new(big.Int).SetUint64(baseFee)
+	delta.Mul(delta, gasDelta)
+	delta.Div(delta, new(big.Int).SetUint64(c.TargetGasPerBlock))
+	delta.Div(delta, new(big.Int).SetUint64(c.BaseFeeChangeDenominator))
+
+	if gasUsed > c.TargetGasPerBlock {
+		// Always increase the base fee when the block was over target so that
+		// a base fee of 1 is able to grow.
+		if delta.Sign() == 0 {
+			delta.SetUint64(1)
+		}
+		if !delta.IsUint64() {
+			return stdmath.MaxUint64
+		}
+		newBaseFee, err := math.Add64(baseFee, delta.Uint64())
+		if err != nil {
+			return stdmath.MaxUint64
+		}
+		return newBaseFee
+	}
+
+	// [delta] is at most [baseFee] here, so it fits into a uint64.
+	return math.Max64(baseFee-delta.Uint64(), c.MinBaseFee)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	BytesWeight:              1,
+	InputsWeight:             1_000,
+	SignaturesWeight:         1_000,
+	MinBaseFee:               10,
+	TargetGasPerBlock:        100_000,
+	BaseFeeChangeDenominator: 8,
+}
+
+func TestGas(t *testing.T) {
+	require := require.New(t)
+
+	gas, err := testConfig.Gas(Complexity{
+		Bytes:      250,
+		Inputs:     2,
+		Signatures: 3,
+	})
+	require.NoError(err)
+	require.EqualValues(5_250, gas)
+
+	_, err = testConfig.Gas(Complexity{
+		Inputs: math.MaxUint64,
+	})
+	require.Error(err)
+}
+
+func TestFee(t *testing.T) {
+	require := require.New(t)
+
+	complexity := Complexity{
+		Bytes:      250,
+		Inputs:     2,
+		Signatures: 3,
+	}
+
+	// The base fee can't be lower than the minimum base fee.
+	fee, err := testConfig.Fee(complexity, 0)
+	require.NoError(err)
+	require.EqualValues(52_500, fee)
+
+	fee, err = testConfig.Fee(complexity, 100)
+	require.NoError(err)
+	require.EqualValues(525_000, fee)
+
+	_, err = testConfig.Fee(complexity, math.MaxUint64)
+	require.Error(err)
+
+	// The zero config never charges a fee.
+	fee, err = (&Config{}).Fee(complexity, math.MaxUint64)
+	require.NoError(err)
+	require.Zero(fee)
+}
+
+func TestNextBaseFee(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          Config
+		baseFee         uint64
+		gasUsed         uint64
+		expectedBaseFee uint64
+	}{
+		{
+			name:            "at target",
+			config:          testConfig,
+			baseFee:         800,
+			gasUsed:         100_000,
+			expectedBaseFee: 800,
+		},
+		{
+			name:            "double target",
+			config:          testConfig,
+			baseFee:         800,
+			gasUsed:         200_000,
+			expectedBaseFee: 900,
+		},
+		{
+			name:            "empty block",
+			config:          testConfig,
+			baseFee:         800,
+			gasUsed:         0,
+			expectedBaseFee: 700,
+		},
+		{
+			name:            "below minimum",
+			config:          testConfig,
+			baseFee:         0,
+			gasUsed:         100_000,
+			expectedBaseFee: 10,
+		},
+		{
+			name:            "decrease clamped to minimum",
+			config:          testConfig,
+			baseFee:         11,
+			gasUsed:         0,
+			expectedBaseFee: 10,
+		},
+		{
+			name: "small base fee always increases",
+			config: Config{
+				TargetGasPerBlock:        100_000,
+				BaseFeeChangeDenominator: 8,
+			},
+			baseFee:         1,
+			gasUsed:         100_001,
+			expectedBaseFee: 2,
+		},
+		{
+			name:            "saturates",
+			config:          testConfig,
+			baseFee:         math.MaxUint64 - 1,
+			gasUsed:         math.MaxUint64,
+			expectedBaseFee: math.MaxUint64,
+		},
+		{
+			name: "no target",
+			config: Config{
+				MinBaseFee:               10,
+				BaseFeeChangeDenominator: 8,
+			},
+			baseFee:         800,
+			gasUsed:         math.MaxUint64,
+			expectedBaseFee: 800,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedBaseFee, test.config.NextBaseFee(test.baseFee, test.gasUsed))
+		})
+	}
+}
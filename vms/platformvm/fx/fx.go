@@ -6,10 +6,14 @@ package fx
 import (
 	"github.com/kukrer/savannahnode/snow"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
 
-var _ Fx = &secp256k1fx.Fx{}
+var (
+	_ Fx = &secp256k1fx.Fx{}
+	_ Fx = &htlcfx.Fx{}
+)
 
 // Fx is the interface a feature extension must implement to support the
 // Platform Chain.
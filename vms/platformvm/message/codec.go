@@ -26,6 +26,8 @@ func init() {
 	errs := wrappers.Errs{}
 	errs.Add(
 		lc.RegisterType(&Tx{}),
+		lc.RegisterType(&StateRequest{}),
+		lc.RegisterType(&StateResponse{}),
 		c.RegisterCodec(codecVersion, lc),
 	)
 	if errs.Errored() {
@@ -14,6 +14,8 @@ var _ Handler = NoopHandler{}
 
 type Handler interface {
 	HandleTx(nodeID ids.NodeID, requestID uint32, msg *Tx) error
+	HandleStateRequest(nodeID ids.NodeID, requestID uint32, msg *StateRequest) error
+	HandleStateResponse(nodeID ids.NodeID, requestID uint32, msg *StateResponse) error
 }
 
 type NoopHandler struct {
@@ -27,3 +29,19 @@ func (h NoopHandler) HandleTx(nodeID ids.NodeID, requestID uint32, _ *Tx) error
 	)
 	return nil
 }
+
+func (h NoopHandler) HandleStateRequest(nodeID ids.NodeID, requestID uint32, _ *StateRequest) error {
+	h.Log.Debug("dropping unexpected StateRequest message",
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+	)
+	return nil
+}
+
+func (h NoopHandler) HandleStateResponse(nodeID ids.NodeID, requestID uint32, _ *StateResponse) error {
+	h.Log.Debug("dropping unexpected StateResponse message",
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+	)
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 
 var (
 	_ Message = &Tx{}
+	_ Message = &StateRequest{}
+	_ Message = &StateResponse{}
 
 	errUnexpectedCodecVersion = errors.New("unexpected codec version")
 )
@@ -43,6 +45,37 @@ func (msg *Tx) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) erro
 	return handler.HandleTx(nodeID, requestID, msg)
 }
 
+// StateRequest requests the state records committed to by the state summary
+// at [Height], starting at the record keyed by [Start].
+type StateRequest struct {
+	message
+
+	Height uint64 `serialize:"true"`
+	Start  []byte `serialize:"true"`
+}
+
+func (msg *StateRequest) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) error {
+	return handler.HandleStateRequest(nodeID, requestID, msg)
+}
+
+// StateResponse contains consecutive state records, in order of their keys,
+// answering a StateRequest.
+type StateResponse struct {
+	message
+
+	// Unavailable is true if the responder doesn't serve the requested state
+	// summary
+	Unavailable bool     `serialize:"true"`
+	Keys        [][]byte `serialize:"true"`
+	Values      [][]byte `serialize:"true"`
+	// More is true if there are records after the last returned record
+	More bool `serialize:"true"`
+}
+
+func (msg *StateResponse) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) error {
+	return handler.HandleStateResponse(nodeID, requestID, msg)
+}
+
 func Parse(bytes []byte) (Message, error) {
 	var msg Message
 	version, err := c.Unmarshal(bytes, &msg)
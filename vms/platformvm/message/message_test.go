@@ -33,6 +33,58 @@ func TestTx(t *testing.T) {
 	require.Equal(tx, parsedMsg.Tx)
 }
 
+func TestStateRequest(t *testing.T) {
+	require := require.New(t)
+
+	start := utils.RandomBytes(32)
+	builtMsg := StateRequest{
+		Height: 1024,
+		Start:  start,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	parsedMsg, ok := parsedMsgIntf.(*StateRequest)
+	require.True(ok)
+
+	require.Equal(uint64(1024), parsedMsg.Height)
+	require.Equal(start, parsedMsg.Start)
+}
+
+func TestStateResponse(t *testing.T) {
+	require := require.New(t)
+
+	keys := [][]byte{utils.RandomBytes(33), utils.RandomBytes(33)}
+	values := [][]byte{utils.RandomBytes(64 * units.KiB), nil}
+	builtMsg := StateResponse{
+		Keys:   keys,
+		Values: values,
+		More:   true,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	parsedMsg, ok := parsedMsgIntf.(*StateResponse)
+	require.True(ok)
+
+	require.False(parsedMsg.Unavailable)
+	require.Equal(keys, parsedMsg.Keys)
+	require.Len(parsedMsg.Values, 2)
+	require.Equal(values[0], parsedMsg.Values[0])
+	require.Empty(parsedMsg.Values[1])
+	require.True(parsedMsg.More)
+}
+
 func TestParseGibberish(t *testing.T) {
 	require := require.New(t)
 
@@ -24,8 +24,12 @@ type txMetrics struct {
 	numExportTxs,
 	numImportTxs,
 	numRewardValidatorTxs,
+	numRemoveSubnetValidatorTxs,
 	numTransformSubnetTxs,
-	numAddGeneralValidatorTxs prometheus.Counter
+	numAddPermissionlessValidatorTxs,
+	numAddPermissionlessDelegatorTxs,
+	numTransferSubnetOwnershipTxs,
+	numTopUpValidatorTxs prometheus.Counter
 }
 
 func newTxMetrics(
@@ -34,17 +38,21 @@ func newTxMetrics(
 ) (*txMetrics, error) {
 	errs := wrappers.Errs{}
 	m := &txMetrics{
-		numAddDelegatorTxs:        newTxMetric(namespace, "add_delegator", registerer, &errs),
-		numAddSubnetValidatorTxs:  newTxMetric(namespace, "add_subnet_validator", registerer, &errs),
-		numAddValidatorTxs:        newTxMetric(namespace, "add_validator", registerer, &errs),
-		numAdvanceTimeTxs:         newTxMetric(namespace, "advance_time", registerer, &errs),
-		numCreateChainTxs:         newTxMetric(namespace, "create_chain", registerer, &errs),
-		numCreateSubnetTxs:        newTxMetric(namespace, "create_subnet", registerer, &errs),
-		numExportTxs:              newTxMetric(namespace, "export", registerer, &errs),
-		numImportTxs:              newTxMetric(namespace, "import", registerer, &errs),
-		numRewardValidatorTxs:     newTxMetric(namespace, "reward_validator", registerer, &errs),
-		numTransformSubnetTxs:     newTxMetric(namespace, "transform_subnet", registerer, &errs),
-		numAddGeneralValidatorTxs: newTxMetric(namespace, "add_general_validator", registerer, &errs),
+		numAddDelegatorTxs:               newTxMetric(namespace, "add_delegator", registerer, &errs),
+		numAddSubnetValidatorTxs:         newTxMetric(namespace, "add_subnet_validator", registerer, &errs),
+		numAddValidatorTxs:               newTxMetric(namespace, "add_validator", registerer, &errs),
+		numAdvanceTimeTxs:                newTxMetric(namespace, "advance_time", registerer, &errs),
+		numCreateChainTxs:                newTxMetric(namespace, "create_chain", registerer, &errs),
+		numCreateSubnetTxs:               newTxMetric(namespace, "create_subnet", registerer, &errs),
+		numExportTxs:                     newTxMetric(namespace, "export", registerer, &errs),
+		numImportTxs:                     newTxMetric(namespace, "import", registerer, &errs),
+		numRewardValidatorTxs:            newTxMetric(namespace, "reward_validator", registerer, &errs),
+		numRemoveSubnetValidatorTxs:      newTxMetric(namespace, "remove_subnet_validator", registerer, &errs),
+		numTransformSubnetTxs:            newTxMetric(namespace, "transform_subnet", registerer, &errs),
+		numAddPermissionlessValidatorTxs: newTxMetric(namespace, "add_permissionless_validator", registerer, &errs),
+		numAddPermissionlessDelegatorTxs: newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
+		numTransferSubnetOwnershipTxs:    newTxMetric(namespace, "transfer_subnet_ownership", registerer, &errs),
+		numTopUpValidatorTxs:             newTxMetric(namespace, "top_up_validator", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -108,3 +116,33 @@ func (m *txMetrics) RewardValidatorTx(*txs.RewardValidatorTx) error {
 	m.numRewardValidatorTxs.Inc()
 	return nil
 }
+
+func (m *txMetrics) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
+	m.numRemoveSubnetValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) TransformSubnetTx(*txs.TransformSubnetTx) error {
+	m.numTransformSubnetTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) AddPermissionlessValidatorTx(*txs.AddPermissionlessValidatorTx) error {
+	m.numAddPermissionlessValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDelegatorTx) error {
+	m.numAddPermissionlessDelegatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	m.numTransferSubnetOwnershipTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) TopUpValidatorTx(*txs.TopUpValidatorTx) error {
+	m.numTopUpValidatorTxs.Inc()
+	return nil
+}
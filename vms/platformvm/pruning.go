@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/statesync"
+
+	blockexecutor "github.com/kukrer/savannahnode/vms/platformvm/blocks/executor"
+)
+
+const (
+	defaultPruningRetention = 1 << 16
+
+	// minPruningRetention keeps the validator set diffs that are written into
+	// the state summaries.
+	minPruningRetention = statesync.SummaryFrequency
+
+	// pruneFrequency is the minimum number of heights pruned at once.
+	pruneFrequency = 256
+)
+
+var (
+	_ blockexecutor.CommitListener = &VM{}
+
+	errPruningRetentionTooLow = errors.New("pruning retention is too low")
+	errPrunedHeight           = errors.New("height was pruned")
+)
+
+// StateCommitted snapshots the state for the state sync server, if it is
+// enabled, and prunes the heights that are no longer retained, if pruning is
+// enabled.
+func (vm *VM) StateCommitted(blk blocks.Block, prevHeight uint64) error {
+	if vm.stateSyncServerEnabled {
+		if err := vm.stateSyncServer.StateCommitted(blk, prevHeight); err != nil {
+			return err
+		}
+	}
+	if vm.pruningRetention == 0 {
+		return nil
+	}
+	return vm.prune(blk.Height())
+}
+
+// prune prunes the heights that are more than [vm.pruningRetention] heights
+// older than [height]. The validator sets that the proposervm may still ask
+// for, down to GetMinimumHeight, are never pruned.
+func (vm *VM) prune(height uint64) error {
+	if height <= vm.pruningRetention {
+		return nil
+	}
+	pruneHeight := height - vm.pruningRetention
+	minHeight, err := vm.GetMinimumHeight()
+	if err != nil {
+		return err
+	}
+	if minHeight < pruneHeight {
+		pruneHeight = minHeight
+	}
+
+	prunedHeight := vm.state.GetPrunedHeight()
+	if pruneHeight < prunedHeight+pruneFrequency {
+		return nil
+	}
+	if err := vm.state.Prune(pruneHeight); err != nil {
+		return fmt.Errorf("failed to prune up to height %d: %w", pruneHeight, err)
+	}
+	vm.ctx.Log.Debug("pruned heights",
+		zap.Uint64("from", prunedHeight+1),
+		zap.Uint64("to", vm.state.GetPrunedHeight()),
+	)
+	return nil
+}
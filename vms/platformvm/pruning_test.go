@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto"
+)
+
+func TestPruning(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVMWithChainConfig([]byte(`{"pruning-enabled":true}`))
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	require.Equal(uint64(defaultPruningRetention), vm.pruningRetention)
+
+	service := &Service{vm: vm}
+	reply := GetRetainedRangeReply{}
+	require.NoError(service.GetRetainedRange(nil, nil, &reply))
+	require.True(reply.PruningEnabled)
+	require.Zero(reply.MinBlockHeight)
+	require.Zero(reply.MinValidatorSetHeight)
+	require.EqualValues(1, reply.LastAcceptedHeight)
+
+	// Nothing is pruned within the retention
+	require.NoError(vm.prune(1))
+	require.Zero(vm.state.GetPrunedHeight())
+
+	tx, err := vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	require.NoError(vm.Builder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.NoError(blk.Accept())
+
+	// The last accepted block is never pruned
+	require.NoError(vm.state.Prune(blk.Height()))
+	require.Equal(blk.Height()-1, vm.state.GetPrunedHeight())
+
+	_, err = vm.GetValidatorSet(0, constants.PrimaryNetworkID)
+	require.ErrorIs(err, errPrunedHeight)
+	_, err = vm.GetValidatorSet(1, constants.PrimaryNetworkID)
+	require.NoError(err)
+
+	require.NoError(service.GetRetainedRange(nil, nil, &reply))
+	require.EqualValues(2, reply.MinBlockHeight)
+	require.EqualValues(1, reply.MinValidatorSetHeight)
+	require.EqualValues(2, reply.LastAcceptedHeight)
+}
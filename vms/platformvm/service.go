@@ -7,11 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/kukrer/savannahnode/api"
+	"github.com/kukrer/savannahnode/chains/atomic"
 	"github.com/kukrer/savannahnode/database"
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/constants"
@@ -24,8 +26,13 @@ import (
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/keystore"
 	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
 	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakinghistory"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/status"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs/builder"
@@ -42,6 +49,9 @@ const (
 	// Max number of addresses that can be passed in as argument to GetStake
 	maxGetStakeAddrs = 256
 
+	// Max number of heights inspected by a call to GetValidatorSetChanges
+	maxValidatorSetChangesHeights = 1024
+
 	// Minimum amount of delay to allow a transaction to be issued through the
 	// API
 	minAddStakerDelay = 2 * executor.SyncBound
@@ -72,6 +82,14 @@ var (
 	errMissingPrivateKey          = errors.New("argument 'privateKey' not given")
 	errStartAfterEndTime          = errors.New("start time must be before end time")
 	errStartTimeInThePast         = errors.New("start time in the past")
+	errTxNotInMempool             = errors.New("tx not in mempool")
+	errStartAfterEndHeight        = errors.New("start height must not be after end height")
+	errHeightNotAccepted          = errors.New("height hasn't been accepted yet")
+	errInvalidStakeDuration       = errors.New("invalid stake duration")
+	errNotValidator               = errors.New("node isn't a validator")
+	errNotDelegatable             = errors.New("validator doesn't accept delegations")
+	errNodeIDXorAddress           = errors.New("exactly one of 'nodeID' and 'address' must be provided")
+	errPrimaryNetworkHasNoOwner   = errors.New("the primary network has no owner")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -260,6 +278,36 @@ utxoFor:
 				}
 				lockedStakeable = newBalance
 			}
+		case *stakeable.VestingOut:
+			innerOut, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
+			if !ok {
+				service.vm.ctx.Log.Warn("unexpected output type in UTXO",
+					zap.String("type", fmt.Sprintf("%T", out.TransferableOut)),
+				)
+				continue utxoFor
+			}
+			if innerOut.Locktime > currentTime {
+				newBalance, err := math.Add64(lockedNotStakeable, out.Amount())
+				if err != nil {
+					return errLockedNotStakeableOverflow
+				}
+				lockedNotStakeable = newBalance
+				break
+			}
+
+			// The tranches that vested are unlocked, the others can only be
+			// staked
+			lockedAmount := out.LockedAmount(currentTime)
+			newBalance, err := math.Add64(unlocked, out.Amount()-lockedAmount)
+			if err != nil {
+				return errUnlockedOverflow
+			}
+			unlocked = newBalance
+			newBalance, err = math.Add64(lockedStakeable, lockedAmount)
+			if err != nil {
+				return errUnlockedStakeableOverflow
+			}
+			lockedStakeable = newBalance
 		default:
 			continue utxoFor
 		}
@@ -477,8 +525,14 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 
 		response.Subnets = make([]APISubnet, len(subnets)+1)
 		for i, subnet := range subnets {
-			unsignedTx := subnet.Unsigned.(*txs.CreateSubnetTx)
-			owner := unsignedTx.Owner.(*secp256k1fx.OutputOwners)
+			subnetOwner, err := service.vm.state.GetSubnetOwner(subnet.ID())
+			if err != nil {
+				return err
+			}
+			owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
+			if !ok {
+				return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnetOwner)
+			}
 			controlAddrs := []string{}
 			for _, controlKeyID := range owner.Addrs {
 				addr, err := service.addrManager.FormatLocalAddress(controlKeyID)
@@ -528,13 +582,16 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 			return err
 		}
 
-		subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
-		if !ok {
+		if _, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx); !ok {
 			return fmt.Errorf("expected tx type *txs.CreateSubnetTx but got %T", subnetTx.Unsigned)
 		}
-		owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+		subnetOwner, err := service.vm.state.GetSubnetOwner(subnetID)
+		if err != nil {
+			return err
+		}
+		owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
 		if !ok {
-			return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnet.Owner)
+			return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnetOwner)
 		}
 
 		controlAddrs := make([]string, len(owner.Addrs))
@@ -557,6 +614,76 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 	return nil
 }
 
+// GetSubnetArgs are the arguments to GetSubnet
+type GetSubnetArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// SubnetOwnership is an owner a subnet had, along with the tx that set it
+type SubnetOwnership struct {
+	TxID  ids.ID             `json:"txID"`
+	Owner *platformapi.Owner `json:"owner"`
+}
+
+// GetSubnetReply is the response from calling GetSubnet
+type GetSubnetReply struct {
+	// Owner is the current owner of the subnet
+	Owner *platformapi.Owner `json:"owner"`
+	// OwnershipHistory lists every owner the subnet has had, oldest first. The
+	// first entry was set by the tx that created the subnet.
+	OwnershipHistory []SubnetOwnership `json:"ownershipHistory"`
+}
+
+// GetSubnet returns the current owner of the subnet [args.SubnetID] and the
+// history of its ownership transfers
+func (service *Service) GetSubnet(_ *http.Request, args *GetSubnetArgs, response *GetSubnetReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetSubnet called",
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errPrimaryNetworkHasNoOwner
+	}
+
+	subnetTx, _, err := service.vm.state.GetTx(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get subnet %s: %w", args.SubnetID, err)
+	}
+	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return fmt.Errorf("expected tx type *txs.CreateSubnetTx but got %T", subnetTx.Unsigned)
+	}
+	owner, err := service.getAPIOwner(subnet.Owner)
+	if err != nil {
+		return fmt.Errorf("problem formatting owner: %w", err)
+	}
+	response.OwnershipHistory = []SubnetOwnership{{
+		TxID:  args.SubnetID,
+		Owner: owner,
+	}}
+
+	transfers, err := service.vm.state.GetSubnetOwnershipTransfers(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get ownership transfers of subnet %s: %w", args.SubnetID, err)
+	}
+	for _, transferTx := range transfers {
+		transfer, ok := transferTx.Unsigned.(*txs.TransferSubnetOwnershipTx)
+		if !ok {
+			return fmt.Errorf("expected tx type *txs.TransferSubnetOwnershipTx but got %T", transferTx.Unsigned)
+		}
+		owner, err := service.getAPIOwner(transfer.Owner)
+		if err != nil {
+			return fmt.Errorf("problem formatting owner: %w", err)
+		}
+		response.OwnershipHistory = append(response.OwnershipHistory, SubnetOwnership{
+			TxID:  transferTx.ID(),
+			Owner: owner,
+		})
+	}
+	response.Owner = response.OwnershipHistory[len(response.OwnershipHistory)-1].Owner
+	return nil
+}
+
 // GetStakingAssetIDArgs are the arguments to GetStakingAssetID
 type GetStakingAssetIDArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -572,11 +699,17 @@ type GetStakingAssetIDResponse struct {
 func (service *Service) GetStakingAssetID(_ *http.Request, args *GetStakingAssetIDArgs, response *GetStakingAssetIDResponse) error {
 	service.vm.ctx.Log.Debug("Platform: GetStakingAssetID called")
 
-	if args.SubnetID != constants.PrimaryNetworkID {
-		return fmt.Errorf("subnet %s doesn't have a valid staking token", args.SubnetID)
+	if args.SubnetID == constants.PrimaryNetworkID {
+		response.AssetID = service.vm.ctx.AVAXAssetID
+		return nil
+	}
+
+	transformSubnetTx, err := executor.GetTransformSubnetTx(service.vm.state, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("subnet %s doesn't have a valid staking token: %w", args.SubnetID, err)
 	}
 
-	response.AssetID = service.vm.ctx.AVAXAssetID
+	response.AssetID = transformSubnetTx.AssetID
 	return nil
 }
 
@@ -648,20 +781,27 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 
 		switch staker := tx.Unsigned.(type) {
 		case *txs.AddDelegatorTx:
-			var rewardOwner *platformapi.Owner
-			owner, ok := staker.RewardsOwner.(*secp256k1fx.OutputOwners)
-			if ok {
-				rewardOwner = &platformapi.Owner{
-					Locktime:  json.Uint64(owner.Locktime),
-					Threshold: json.Uint32(owner.Threshold),
-				}
-				for _, addr := range owner.Addrs {
-					addrStr, err := service.addrManager.FormatLocalAddress(addr)
-					if err != nil {
-						return err
-					}
-					rewardOwner.Addresses = append(rewardOwner.Addresses, addrStr)
-				}
+			rewardOwner, err := service.getAPIOwner(staker.RewardsOwner)
+			if err != nil {
+				return err
+			}
+
+			delegator := platformapi.PrimaryDelegator{
+				Staker: platformapi.Staker{
+					TxID:        txID,
+					StartTime:   startTime,
+					EndTime:     endTime,
+					StakeAmount: &weight,
+					NodeID:      nodeID,
+				},
+				RewardOwner:     rewardOwner,
+				PotentialReward: &potentialReward,
+			}
+			vdrToDelegators[delegator.NodeID] = append(vdrToDelegators[delegator.NodeID], delegator)
+		case *txs.AddPermissionlessDelegatorTx:
+			rewardOwner, err := service.getAPIOwner(staker.DelegationRewardsOwner)
+			if err != nil {
+				return err
 			}
 
 			delegator := platformapi.PrimaryDelegator{
@@ -686,20 +826,9 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 
 			connected := service.vm.uptimeManager.IsConnected(nodeID)
 
-			var rewardOwner *platformapi.Owner
-			owner, ok := staker.RewardsOwner.(*secp256k1fx.OutputOwners)
-			if ok {
-				rewardOwner = &platformapi.Owner{
-					Locktime:  json.Uint64(owner.Locktime),
-					Threshold: json.Uint32(owner.Threshold),
-				}
-				for _, addr := range owner.Addrs {
-					addrStr, err := service.addrManager.FormatLocalAddress(addr)
-					if err != nil {
-						return err
-					}
-					rewardOwner.Addresses = append(rewardOwner.Addresses, addrStr)
-				}
+			rewardOwner, err := service.getAPIOwner(staker.RewardsOwner)
+			if err != nil {
+				return err
 			}
 
 			reply.Validators = append(reply.Validators, platformapi.PrimaryValidator{
@@ -716,6 +845,42 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 				RewardOwner:     rewardOwner,
 				DelegationFee:   delegationFee,
 			})
+		case *txs.AddPermissionlessValidatorTx:
+			delegationFee := json.Float32(100 * float32(staker.DelegationShares) / float32(reward.PercentDenominator))
+			// Permissionless subnet validators are rewarded based on their
+			// primary network uptime.
+			rawUptime, err := service.vm.uptimeManager.CalculateUptimePercentFrom(nodeID, staker.StartTime())
+			if err != nil {
+				return err
+			}
+			uptime := json.Float32(rawUptime)
+
+			connected := service.vm.uptimeManager.IsConnected(nodeID)
+			tracksSubnet := service.vm.SubnetTracker.TracksSubnet(nodeID, args.SubnetID)
+
+			rewardOwner, err := service.getAPIOwner(staker.ValidatorRewardsOwner)
+			if err != nil {
+				return err
+			}
+
+			// Only primary network validators register a BLS key.
+			proofOfPossession, _ := staker.Signer.(*signer.ProofOfPossession)
+
+			reply.Validators = append(reply.Validators, platformapi.PrimaryValidator{
+				Staker: platformapi.Staker{
+					TxID:        txID,
+					NodeID:      nodeID,
+					StartTime:   startTime,
+					EndTime:     endTime,
+					StakeAmount: &weight,
+				},
+				Uptime:          &uptime,
+				Connected:       connected && tracksSubnet,
+				PotentialReward: &potentialReward,
+				RewardOwner:     rewardOwner,
+				DelegationFee:   delegationFee,
+				Signer:          proofOfPossession,
+			})
 		case *txs.AddSubnetValidatorTx:
 			connected := service.vm.uptimeManager.IsConnected(nodeID)
 			tracksSubnet := service.vm.SubnetTracker.TracksSubnet(nodeID, args.SubnetID)
@@ -827,6 +992,32 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 				DelegationFee: delegationFee,
 				Connected:     connected,
 			})
+		case *txs.AddPermissionlessDelegatorTx:
+			reply.Delegators = append(reply.Delegators, platformapi.Staker{
+				TxID:        txID,
+				NodeID:      nodeID,
+				StartTime:   startTime,
+				EndTime:     endTime,
+				StakeAmount: &weight,
+			})
+		case *txs.AddPermissionlessValidatorTx:
+			delegationFee := json.Float32(100 * float32(staker.DelegationShares) / float32(reward.PercentDenominator))
+
+			connected := service.vm.uptimeManager.IsConnected(nodeID)
+			tracksSubnet := service.vm.SubnetTracker.TracksSubnet(nodeID, args.SubnetID)
+			proofOfPossession, _ := staker.Signer.(*signer.ProofOfPossession)
+			reply.Validators = append(reply.Validators, platformapi.PrimaryValidator{
+				Staker: platformapi.Staker{
+					TxID:        txID,
+					NodeID:      nodeID,
+					StartTime:   startTime,
+					EndTime:     endTime,
+					StakeAmount: &weight,
+				},
+				DelegationFee: delegationFee,
+				Connected:     connected && tracksSubnet,
+				Signer:        proofOfPossession,
+			})
 		case *txs.AddSubnetValidatorTx:
 			connected := service.vm.uptimeManager.IsConnected(nodeID)
 			tracksSubnet := service.vm.SubnetTracker.TracksSubnet(nodeID, args.SubnetID)
@@ -847,19 +1038,194 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 	return nil
 }
 
+// GetCurrentSupplyArgs are the arguments for calling GetCurrentSupply
+type GetCurrentSupplyArgs struct {
+	// Subnet whose staking asset supply is returned
+	// If omitted, defaults to the primary network
+	SubnetID ids.ID `json:"subnetID"`
+}
+
 // GetCurrentSupplyReply are the results from calling GetCurrentSupply
 type GetCurrentSupplyReply struct {
 	Supply json.Uint64 `json:"supply"`
 }
 
-// GetCurrentSupply returns an upper bound on the supply of AVAX in the system
-func (service *Service) GetCurrentSupply(_ *http.Request, _ *struct{}, reply *GetCurrentSupplyReply) error {
+// GetCurrentSupply returns an upper bound on the supply of the staking asset
+// of the provided subnet
+func (service *Service) GetCurrentSupply(_ *http.Request, args *GetCurrentSupplyArgs, reply *GetCurrentSupplyReply) error {
 	service.vm.ctx.Log.Debug("Platform: GetCurrentSupply called")
 
+	if args.SubnetID == constants.PrimaryNetworkID {
+		reply.Supply = json.Uint64(service.vm.state.GetCurrentSupply())
+		return nil
+	}
+
+	supply, err := service.vm.state.GetSubnetCurrentSupply(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the current supply of subnet %s: %w", args.SubnetID, err)
+	}
+	reply.Supply = json.Uint64(supply)
+	return nil
+}
+
+// ChainBurnedFees is the AVAX burned as fees by the txs of a chain, as
+// reported by the queried node
+type ChainBurnedFees struct {
+	ChainID    ids.ID      `json:"chainID"`
+	BurnedFees json.Uint64 `json:"burnedFees"`
+}
+
+// AtomicFlow is the AVAX moved between the P-chain and another chain
+type AtomicFlow struct {
+	ChainID ids.ID `json:"chainID"`
+	// Exported is the AVAX the P-chain exported to the chain
+	Exported json.Uint64 `json:"exported"`
+	// Imported is the AVAX the P-chain imported from the chain
+	Imported json.Uint64 `json:"imported"`
+	// PendingExport is the AVAX the P-chain exported to the chain that is
+	// still in shared memory, as the chain hasn't imported it yet
+	PendingExport json.Uint64 `json:"pendingExport"`
+	// PendingImport is the AVAX the chain exported to the P-chain that is
+	// still in shared memory, as the P-chain hasn't imported it yet
+	PendingImport json.Uint64 `json:"pendingImport"`
+}
+
+// GetSupplyBreakdownReply are the results from calling GetSupplyBreakdown
+type GetSupplyBreakdownReply struct {
+	// Supply is the upper bound on the AVAX supply returned by
+	// GetCurrentSupply
+	Supply json.Uint64 `json:"supply"`
+	// RewardsMinted is the AVAX minted to reward the primary network stakers
+	RewardsMinted json.Uint64 `json:"rewardsMinted"`
+	// BurnedFees is the AVAX burned as fees by the P-chain txs
+	BurnedFees json.Uint64 `json:"burnedFees"`
+	// LocallyReportedBurnedFees are the AVAX burned as fees by the other
+	// chains the queried node runs whose VM reports them, sorted by chain ID.
+	// Unlike the other amounts, they aren't tracked by the P-chain state: only
+	// the X-chain reports its fees, the C-chain is never included, and the
+	// chains that are listed depend on the chains the queried node runs.
+	LocallyReportedBurnedFees []ChainBurnedFees `json:"locallyReportedBurnedFees"`
+	// ValidatorStake and DelegatorStake are the AVAX staked by the current
+	// primary network validators and delegators
+	ValidatorStake json.Uint64 `json:"validatorStake"`
+	DelegatorStake json.Uint64 `json:"delegatorStake"`
+	// StakeableLocked is the AVAX held in stakeable locked and vesting UTXOs
+	// that is still locked at the chain timestamp
+	StakeableLocked json.Uint64 `json:"stakeableLocked"`
+	// AtomicFlows are the AVAX moved through shared memory between the
+	// P-chain and the X-chain or any chain the P-chain exchanged AVAX with,
+	// sorted by chain ID. The pending amounts are only reported when the
+	// shared memory of the P-chain can be listed.
+	AtomicFlows []AtomicFlow `json:"atomicFlows"`
+}
+
+// GetSupplyBreakdown returns where the AVAX of the P-chain went, as of the last
+// accepted block
+func (service *Service) GetSupplyBreakdown(_ *http.Request, _ *struct{}, reply *GetSupplyBreakdownReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetSupplyBreakdown called")
+
+	breakdown, err := service.vm.state.GetSupplyBreakdown()
+	if err != nil {
+		return fmt.Errorf("couldn't get the supply breakdown: %w", err)
+	}
+
 	reply.Supply = json.Uint64(service.vm.state.GetCurrentSupply())
+	reply.RewardsMinted = json.Uint64(breakdown.RewardsMinted)
+	reply.ValidatorStake = json.Uint64(breakdown.ValidatorStake)
+	reply.DelegatorStake = json.Uint64(breakdown.DelegatorStake)
+	reply.StakeableLocked = json.Uint64(breakdown.StakeableLocked)
+
+	reply.BurnedFees = json.Uint64(breakdown.BurnedFees)
+
+	burnedFees := map[ids.ID]uint64{}
+	if service.vm.BurnRegistry != nil {
+		burnedFees = service.vm.BurnRegistry.BurnedFees()
+	}
+	burningChainIDs := make([]ids.ID, 0, len(burnedFees))
+	for chainID := range burnedFees {
+		burningChainIDs = append(burningChainIDs, chainID)
+	}
+	ids.SortIDs(burningChainIDs)
+	reply.LocallyReportedBurnedFees = make([]ChainBurnedFees, len(burningChainIDs))
+	for i, chainID := range burningChainIDs {
+		reply.LocallyReportedBurnedFees[i] = ChainBurnedFees{
+			ChainID:    chainID,
+			BurnedFees: json.Uint64(burnedFees[chainID]),
+		}
+	}
+
+	// The X-chain may have AVAX pending in shared memory even if the P-chain
+	// never imported or exported any
+	flows := make(map[ids.ID]*AtomicFlow, len(breakdown.AtomicFlows)+1)
+	flows[service.vm.ctx.XChainID] = &AtomicFlow{ChainID: service.vm.ctx.XChainID}
+	for _, flow := range breakdown.AtomicFlows {
+		flows[flow.ChainID] = &AtomicFlow{
+			ChainID:  flow.ChainID,
+			Exported: json.Uint64(flow.Exported),
+			Imported: json.Uint64(flow.Imported),
+		}
+	}
+	if lister, ok := service.vm.ctx.SharedMemory.(atomic.Lister); ok {
+		for chainID, flow := range flows {
+			outbound, err := lister.Outbound(chainID)
+			if err != nil {
+				return fmt.Errorf("couldn't list the values exported to %s: %w", chainID, err)
+			}
+			pendingExport, err := service.pendingAVAX(outbound)
+			if err != nil {
+				return err
+			}
+			inbound, err := lister.Inbound(chainID)
+			if err != nil {
+				return fmt.Errorf("couldn't list the values exported from %s: %w", chainID, err)
+			}
+			pendingImport, err := service.pendingAVAX(inbound)
+			if err != nil {
+				return err
+			}
+			flow.PendingExport = json.Uint64(pendingExport)
+			flow.PendingImport = json.Uint64(pendingImport)
+		}
+	}
+
+	flowChainIDs := make([]ids.ID, 0, len(flows))
+	for chainID, flow := range flows {
+		if flow.Exported == 0 && flow.Imported == 0 && flow.PendingExport == 0 && flow.PendingImport == 0 {
+			continue
+		}
+		flowChainIDs = append(flowChainIDs, chainID)
+	}
+	ids.SortIDs(flowChainIDs)
+	reply.AtomicFlows = make([]AtomicFlow, len(flowChainIDs))
+	for i, chainID := range flowChainIDs {
+		reply.AtomicFlows[i] = *flows[chainID]
+	}
 	return nil
 }
 
+// pendingAVAX returns the AVAX held by the UTXOs of [utxosBytes]. The values
+// that aren't UTXOs the P-chain can parse aren't counted, as the P-chain
+// can't import them.
+func (service *Service) pendingAVAX(utxosBytes [][]byte) (uint64, error) {
+	pending := uint64(0)
+	for _, utxoBytes := range utxosBytes {
+		utxo := &avax.UTXO{}
+		if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+			continue
+		}
+		out, ok := utxo.Out.(avax.Amounter)
+		if !ok || utxo.AssetID() != service.vm.ctx.AVAXAssetID {
+			continue
+		}
+		newPending, err := math.Add64(pending, out.Amount())
+		if err != nil {
+			return 0, err
+		}
+		pending = newPending
+	}
+	return pending, nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -1213,6 +1579,86 @@ func (service *Service) AddSubnetValidator(_ *http.Request, args *AddSubnetValid
 	return errs.Err
 }
 
+// RemoveSubnetValidatorArgs are the arguments to RemoveSubnetValidator
+type RemoveSubnetValidatorArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of the node to remove from the subnet
+	NodeID ids.NodeID `json:"nodeID"`
+	// ID of the subnet to remove the validator from
+	SubnetID string `json:"subnetID"`
+}
+
+// RemoveSubnetValidator creates and signs and issues a transaction to remove a
+// current or pending validator from a subnet other than the primary network
+func (service *Service) RemoveSubnetValidator(_ *http.Request, args *RemoveSubnetValidatorArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: RemoveSubnetValidator called")
+
+	if args.SubnetID == "" {
+		return errNoSubnetID
+	}
+
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+	if subnetID == constants.PrimaryNetworkID {
+		return errNamedSubnetCantBePrimary
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := avax.ParseServiceAddresses(service.addrManager, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	keys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address.
+	if len(keys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = avax.ParseServiceAddress(service.addrManager, args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.txBuilder.NewRemoveSubnetValidatorTx(
+		args.NodeID, // Node ID
+		subnetID,    // Subnet ID
+		keys.Keys,
+		changeAddr,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.addrManager.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.Builder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
 // CreateSubnetArgs are the arguments to CreateSubnet
 type CreateSubnetArgs struct {
 	// User, password, from addrs, change addr
@@ -1860,11 +2306,133 @@ func (service *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response
 	return nil
 }
 
-// GetTx gets a tx
-func (service *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
-	service.vm.ctx.Log.Debug("Platform: GetTx called")
-
-	tx, _, err := service.vm.state.GetTx(args.TxID)
+// SimulateTxReply is the response from SimulateTx
+type SimulateTxReply struct {
+	TxID ids.ID `json:"txID"`
+	// Valid is true if the tx would currently be accepted into the mempool
+	Valid bool `json:"valid"`
+	// Reason is the verification error if the tx is invalid
+	Reason string `json:"reason,omitempty"`
+	// ConsumedUTXOs are the UTXOs spent by the tx, including the UTXOs it
+	// imports from shared memory
+	ConsumedUTXOs []string `json:"consumedUTXOs"`
+	// ProducedUTXOs are the UTXOs added to the P-chain UTXO set when the tx
+	// is accepted
+	ProducedUTXOs []string `json:"producedUTXOs"`
+	// Burned is the amount of AVAX burned by the tx
+	Burned   json.Uint64         `json:"burned"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// SimulateTx executes the semantic verification of a tx against the state of
+// the preferred block, without adding it to the mempool. The consumed UTXOs,
+// produced UTXOs and burned amount are only reported for valid txs.
+func (service *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, response *SimulateTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: SimulateTx called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.Encoding = args.Encoding
+	response.ConsumedUTXOs = []string{}
+	response.ProducedUTXOs = []string{}
+
+	preferred, err := service.vm.Builder.Preferred()
+	if err != nil {
+		return fmt.Errorf("couldn't get preferred block: %w", err)
+	}
+	preferredID := preferred.ID()
+
+	verifier := executor.MempoolTxVerifier{
+		Backend:       service.vm.txExecutorBackend,
+		ParentID:      preferredID,
+		StateVersions: service.vm.manager,
+		Tx:            tx,
+	}
+	if err := tx.Unsigned.Visit(&verifier); err != nil {
+		response.Reason = err.Error()
+		return nil
+	}
+	response.Valid = true
+
+	flow := txFlow{}
+	if err := tx.Unsigned.Visit(&flow); err != nil {
+		return err
+	}
+
+	burned, err := flow.burned(service.vm.ctx.AVAXAssetID)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate burned amount: %w", err)
+	}
+	response.Burned = json.Uint64(burned)
+
+	preferredState, err := state.NewDiff(preferredID, service.vm.manager)
+	if err != nil {
+		return fmt.Errorf("couldn't get preferred state: %w", err)
+	}
+
+	consumed := make([]*avax.UTXO, 0, len(flow.ins)+len(flow.importedIns))
+	for _, in := range flow.ins {
+		utxo, err := preferredState.GetUTXO(in.InputID())
+		if err != nil {
+			return fmt.Errorf("couldn't get UTXO %s: %w", in.InputID(), err)
+		}
+		consumed = append(consumed, utxo)
+	}
+	if len(flow.importedIns) > 0 {
+		utxoIDs := make([][]byte, len(flow.importedIns))
+		for i, in := range flow.importedIns {
+			utxoID := in.InputID()
+			utxoIDs[i] = utxoID[:]
+		}
+		allUTXOBytes, err := service.vm.ctx.SharedMemory.Get(flow.sourceChain, utxoIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get shared memory: %w", err)
+		}
+		for _, utxoBytes := range allUTXOBytes {
+			utxo := &avax.UTXO{}
+			if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+				return fmt.Errorf("failed to unmarshal UTXO: %w", err)
+			}
+			consumed = append(consumed, utxo)
+		}
+	}
+
+	response.ConsumedUTXOs, err = encodeUTXOs(args.Encoding, consumed)
+	if err != nil {
+		return err
+	}
+	response.ProducedUTXOs, err = encodeUTXOs(args.Encoding, tx.UTXOs())
+	return err
+}
+
+func encodeUTXOs(encoding formatting.Encoding, utxos []*avax.UTXO) ([]string, error) {
+	utxoStrs := make([]string, len(utxos))
+	for i, utxo := range utxos {
+		utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't serialize UTXO %q: %w", utxo.InputID(), err)
+		}
+		utxoStrs[i], err = formatting.Encode(encoding, utxoBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode UTXO %s as string: %w", utxo.InputID(), err)
+		}
+	}
+	return utxoStrs, nil
+}
+
+// GetTx gets a tx
+func (service *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetTx called")
+
+	tx, _, err := service.vm.state.GetTx(args.TxID)
 	if err != nil {
 		return fmt.Errorf("couldn't get tx: %w", err)
 	}
@@ -1964,6 +2532,125 @@ func (service *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, resp
 	return nil
 }
 
+// MempoolTx describes a tx in the mempool
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Size is the number of bytes of the tx
+	Size json.Uint64 `json:"size"`
+	// AddedTime is the unix time the tx was added to the mempool
+	AddedTime json.Uint64 `json:"addedTime"`
+	// Age is the number of seconds the tx has been in the mempool
+	Age json.Uint64 `json:"age"`
+}
+
+// GetMempoolArgs are the arguments for GetMempool
+type GetMempoolArgs struct {
+	// StartIndex is the position, in the mempool, of the first tx to return
+	StartIndex json.Uint64 `json:"startIndex"`
+	// Limit is the maximum number of txs to return
+	Limit json.Uint32 `json:"limit"`
+}
+
+// GetMempoolReply is the response from GetMempool
+type GetMempoolReply struct {
+	// NumTxs is the number of txs in the mempool
+	NumTxs json.Uint64 `json:"numTxs"`
+	// Txs are ordered from the oldest to the newest
+	Txs []MempoolTx `json:"txs"`
+	// EndIndex is the StartIndex to use to fetch the next page
+	EndIndex json.Uint64 `json:"endIndex"`
+}
+
+// GetMempool returns the txs waiting in the mempool to be put into a block.
+func (service *Service) GetMempool(_ *http.Request, args *GetMempoolArgs, response *GetMempoolReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetMempool called")
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+
+	mempoolTxs := service.vm.Builder.List()
+	numTxs := uint64(len(mempoolTxs))
+	startIndex := math.Min64(uint64(args.StartIndex), numTxs)
+	endIndex := math.Min64(startIndex+uint64(limit), numTxs)
+
+	response.NumTxs = json.Uint64(numTxs)
+	response.Txs = make([]MempoolTx, 0, endIndex-startIndex)
+	for _, tx := range mempoolTxs[startIndex:endIndex] {
+		response.Txs = append(response.Txs, service.mempoolTx(tx))
+	}
+	response.EndIndex = json.Uint64(endIndex)
+	return nil
+}
+
+// GetMempoolTxReply is the response from GetMempoolTx
+type GetMempoolTxReply struct {
+	MempoolTx
+	api.GetTxReply
+}
+
+// GetMempoolTx returns a tx waiting in the mempool
+func (service *Service) GetMempoolTx(_ *http.Request, args *api.GetTxArgs, response *GetMempoolTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetMempoolTx called",
+		zap.Stringer("txID", args.TxID),
+	)
+
+	tx := service.vm.Builder.Get(args.TxID)
+	if tx == nil {
+		return fmt.Errorf("%w: %s", errTxNotInMempool, args.TxID)
+	}
+	response.MempoolTx = service.mempoolTx(tx)
+	response.Encoding = args.Encoding
+
+	if args.Encoding == formatting.JSON {
+		tx.Unsigned.InitCtx(service.vm.ctx)
+		response.Tx = tx
+		return nil
+	}
+
+	var err error
+	response.Tx, err = formatting.Encode(args.Encoding, tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as a string: %w", err)
+	}
+	return nil
+}
+
+func (service *Service) mempoolTx(tx *txs.Tx) MempoolTx {
+	txID := tx.ID()
+	addedTime, _ := service.vm.Builder.GetAddedTime(txID)
+	age := time.Since(addedTime)
+	if age < 0 {
+		age = 0
+	}
+	return MempoolTx{
+		TxID:      txID,
+		Size:      json.Uint64(len(tx.Bytes())),
+		AddedTime: json.Uint64(addedTime.Unix()),
+		Age:       json.Uint64(age / time.Second),
+	}
+}
+
+// GetDroppedTxReasonReply is the response from GetDroppedTxReason
+type GetDroppedTxReasonReply struct {
+	// Dropped is true if the tx was recently dropped from the mempool
+	Dropped bool `json:"dropped"`
+	// Reason is the reason the tx was dropped
+	Reason string `json:"reason,omitempty"`
+}
+
+// GetDroppedTxReason returns the reason a tx was dropped from the mempool.
+// Only the most recently dropped txs are remembered.
+func (service *Service) GetDroppedTxReason(_ *http.Request, args *api.JSONTxID, response *GetDroppedTxReasonReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetDroppedTxReason called",
+		zap.Stringer("txID", args.TxID),
+	)
+
+	response.Reason, response.Dropped = service.vm.Builder.GetDropReason(args.TxID)
+	return nil
+}
+
 type GetStakeArgs struct {
 	api.JSONAddresses
 	Encoding formatting.Encoding `json:"encoding"`
@@ -1990,10 +2677,14 @@ func (service *Service) getStakeHelper(tx *txs.Tx, addrs ids.ShortSet) (uint64,
 		outs = staker.Stake
 	case *txs.AddValidatorTx:
 		outs = staker.Stake
+	case *txs.AddPermissionlessValidatorTx:
+		outs = staker.StakeOuts
+	case *txs.AddPermissionlessDelegatorTx:
+		outs = staker.StakeOuts
 	case *txs.AddSubnetValidatorTx:
 		return 0, nil, nil
 	default:
-		err := fmt.Errorf("expected *txs.AddDelegatorTx, *txs.AddValidatorTx or *txs.AddSubnetValidatorTx but got %T", tx.Unsigned)
+		err := fmt.Errorf("expected a staker tx but got %T", tx.Unsigned)
 		service.vm.ctx.Log.Error("invalid tx type provided from validator set",
 			zap.Error(err),
 		)
@@ -2012,9 +2703,13 @@ func (service *Service) getStakeHelper(tx *txs.Tx, addrs ids.ShortSet) (uint64,
 			continue
 		}
 		out := stake.Out
-		if lockedOut, ok := out.(*stakeable.LockOut); ok {
+		switch lockedOut := out.(type) {
+		case *stakeable.LockOut:
 			// This output can only be used for staking until [stakeOnlyUntil]
 			out = lockedOut.TransferableOut
+		case *stakeable.VestingOut:
+			// This output can only be used for staking until it vests
+			out = lockedOut.TransferableOut
 		}
 		secpOut, ok := out.(*secp256k1fx.TransferOutput)
 		if !ok {
@@ -2143,10 +2838,12 @@ type GetMinStakeReply struct {
 	MinDelegatorStake json.Uint64 `json:"minDelegatorStake"`
 }
 
-// GetMinStake returns the minimum staking amount in nAVAX.
+// GetMinStake returns the minimum staking amount in nAVAX of the stakers
+// starting at the current chain time.
 func (service *Service) GetMinStake(_ *http.Request, _ *struct{}, reply *GetMinStakeReply) error {
-	reply.MinValidatorStake = json.Uint64(service.vm.MinValidatorStake)
-	reply.MinDelegatorStake = json.Uint64(service.vm.MinDelegatorStake)
+	stakingConfig := service.vm.GetStakingConfig(service.vm.state.GetTimestamp())
+	reply.MinValidatorStake = json.Uint64(stakingConfig.MinValidatorStake)
+	reply.MinDelegatorStake = json.Uint64(stakingConfig.MinDelegatorStake)
 	return nil
 }
 
@@ -2225,6 +2922,321 @@ func (service *Service) GetMaxStakeAmount(_ *http.Request, args *GetMaxStakeAmou
 	return err
 }
 
+// EstimateRewardArgs are the arguments for calling EstimateReward.
+type EstimateRewardArgs struct {
+	// SubnetID is either the primary network or a permissionless subnet
+	SubnetID ids.ID `json:"subnetID"`
+	// NodeID is the validator being delegated to. Only required for
+	// delegators.
+	NodeID ids.NodeID `json:"nodeID"`
+	// Amount is the amount of the staking asset that is staked
+	Amount json.Uint64 `json:"amount"`
+	// Duration is the number of seconds the amount is staked for
+	Duration json.Uint64 `json:"duration"`
+	// Delegator is true if the amount is delegated to [NodeID] rather than
+	// used to validate
+	Delegator bool `json:"delegator"`
+}
+
+// EstimateRewardReply is the response from calling EstimateReward.
+type EstimateRewardReply struct {
+	// PotentialReward is the reward minted if the staker is rewarded
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// DelegationFee is the percentage of a delegator's reward paid to the
+	// validator
+	DelegationFee json.Float32 `json:"delegationFee"`
+	// StakerReward is the portion of the reward received by the staker
+	StakerReward json.Uint64 `json:"stakerReward"`
+	// ValidatorFee is the portion of a delegator's reward received by the
+	// validator
+	ValidatorFee json.Uint64 `json:"validatorFee"`
+}
+
+// EstimateReward returns the reward a staker would receive if it started
+// staking on top of the last accepted state.
+func (service *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	service.vm.ctx.Log.Debug("Platform: EstimateReward called",
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	if args.Amount == 0 {
+		return errNoAmount
+	}
+
+	stakingConfig := service.vm.GetStakingConfig(service.vm.state.GetTimestamp())
+	var (
+		rewardConfig     = stakingConfig.RewardConfig
+		minStakeDuration = stakingConfig.MinStakeDuration
+		maxStakeDuration = stakingConfig.MaxStakeDuration
+		currentSupply    = service.vm.state.GetCurrentSupply()
+	)
+	if args.SubnetID != constants.PrimaryNetworkID {
+		transformation, err := executor.GetTransformSubnetTx(service.vm.state, args.SubnetID)
+		if err != nil {
+			return err
+		}
+		rewardConfig = transformation.RewardConfig(rewardConfig)
+		minStakeDuration = time.Duration(transformation.MinStakeDuration) * time.Second
+		maxStakeDuration = time.Duration(transformation.MaxStakeDuration) * time.Second
+
+		currentSupply, err = service.vm.state.GetSubnetCurrentSupply(args.SubnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get subnet supply: %w", err)
+		}
+	}
+
+	duration := time.Duration(args.Duration) * time.Second
+	if duration < minStakeDuration || duration > maxStakeDuration {
+		return fmt.Errorf("%w: duration %s must be between %s and %s",
+			errInvalidStakeDuration,
+			duration,
+			minStakeDuration,
+			maxStakeDuration,
+		)
+	}
+
+	calculator := reward.NewCalculator(rewardConfig)
+	potentialReward := calculator.Calculate(duration, uint64(args.Amount), currentSupply)
+	reply.PotentialReward = json.Uint64(potentialReward)
+	if !args.Delegator {
+		reply.StakerReward = json.Uint64(potentialReward)
+		return nil
+	}
+
+	validator, err := executor.GetValidator(service.vm.state, args.SubnetID, args.NodeID)
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%w: %s", errNotValidator, args.NodeID)
+	}
+	if err != nil {
+		return err
+	}
+	validatorTx, _, err := service.vm.state.GetTx(validator.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get validator tx %s: %w", validator.TxID, err)
+	}
+
+	var shares uint32
+	switch tx := validatorTx.Unsigned.(type) {
+	case *txs.AddValidatorTx:
+		shares = tx.Shares
+	case *txs.AddPermissionlessValidatorTx:
+		shares = tx.DelegationShares
+	default:
+		return fmt.Errorf("%w: %s", errNotDelegatable, args.NodeID)
+	}
+
+	stakerReward, validatorFee := reward.Split(potentialReward, shares)
+	reply.DelegationFee = json.Float32(100 * float32(shares) / float32(reward.PercentDenominator))
+	reply.StakerReward = json.Uint64(stakerReward)
+	reply.ValidatorFee = json.Uint64(validatorFee)
+	return nil
+}
+
+// GetStakingHistoryArgs are the arguments for calling GetStakingHistory.
+// Exactly one of [NodeID] and [Address] must be provided.
+type GetStakingHistoryArgs struct {
+	// NodeID is the node that the stake was validated by or delegated to
+	NodeID ids.NodeID `json:"nodeID"`
+	// Address is an address the rewards were sent to
+	Address string `json:"address"`
+	// Cursor is the number of staking periods to skip
+	Cursor json.Uint64 `json:"cursor"`
+	// Limit is the maximum number of staking periods to return
+	Limit json.Uint32 `json:"limit"`
+}
+
+// StakingPeriod is the API representation of a staking period that has ended
+type StakingPeriod struct {
+	TxID      ids.ID      `json:"txID"`
+	NodeID    ids.NodeID  `json:"nodeID"`
+	SubnetID  ids.ID      `json:"subnetID"`
+	Delegator bool        `json:"delegator"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	Stake     json.Uint64 `json:"stakeAmount"`
+	// Uptime of the node when the staker was removed, as measured by this
+	// node, between 0 and 1
+	Uptime          json.Float32 `json:"uptime"`
+	Rewarded        bool         `json:"rewarded"`
+	Reward          json.Uint64  `json:"reward"`
+	DelegationFees  json.Uint64  `json:"delegationFees"`
+	RewardAddresses []string     `json:"rewardAddresses"`
+}
+
+// GetStakingHistoryReply is the response from calling GetStakingHistory.
+type GetStakingHistoryReply struct {
+	Periods []StakingPeriod `json:"periods"`
+	// Cursor to pass to get the next page of staking periods
+	EndCursor json.Uint64 `json:"endCursor"`
+}
+
+// GetStakingHistory returns the staking periods of a node or reward address
+// that ended while the staking history index was enabled, in the order they
+// ended.
+func (service *Service) GetStakingHistory(_ *http.Request, args *GetStakingHistoryArgs, reply *GetStakingHistoryReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetStakingHistory called",
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("address", args.Address),
+	)
+
+	hasNodeID := args.NodeID != ids.EmptyNodeID
+	hasAddress := args.Address != ""
+	if hasNodeID == hasAddress {
+		return errNodeIDXorAddress
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+
+	var (
+		periods []*stakinghistory.Period
+		err     error
+	)
+	if hasNodeID {
+		periods, err = service.vm.stakingHistory.GetNodePeriods(args.NodeID, uint64(args.Cursor), uint64(limit))
+	} else {
+		addr, parseErr := avax.ParseServiceAddress(service.addrManager, args.Address)
+		if parseErr != nil {
+			return fmt.Errorf("couldn't parse address %q: %w", args.Address, parseErr)
+		}
+		periods, err = service.vm.stakingHistory.GetAddressPeriods(addr, uint64(args.Cursor), uint64(limit))
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get staking history: %w", err)
+	}
+
+	reply.Periods = make([]StakingPeriod, len(periods))
+	for i, period := range periods {
+		rewardAddrs := make([]string, len(period.RewardAddresses))
+		for j, addr := range period.RewardAddresses {
+			rewardAddrs[j], err = service.addrManager.FormatLocalAddress(addr)
+			if err != nil {
+				return err
+			}
+		}
+		reply.Periods[i] = StakingPeriod{
+			TxID:            period.TxID,
+			NodeID:          period.NodeID,
+			SubnetID:        period.SubnetID,
+			Delegator:       period.Delegator,
+			StartTime:       json.Uint64(period.StartTime),
+			EndTime:         json.Uint64(period.EndTime),
+			Stake:           json.Uint64(period.Stake),
+			Uptime:          json.Float32(float64(period.Uptime) / reward.PercentDenominator),
+			Rewarded:        period.Rewarded,
+			Reward:          json.Uint64(period.Reward),
+			DelegationFees:  json.Uint64(period.DelegationFees),
+			RewardAddresses: rewardAddrs,
+		}
+	}
+	reply.EndCursor = args.Cursor + json.Uint64(len(periods))
+	return nil
+}
+
+// GetAddressTxsArgs are the arguments for calling GetAddressTxs.
+type GetAddressTxsArgs struct {
+	api.JSONAddress
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+	// AssetID defaulted to AVAX if omitted or left blank
+	AssetID ids.ID `json:"assetID"`
+	// TxTypes, if provided, restricts the returned txs to these types, such
+	// as "AddValidatorTx" or "ImportTx"
+	TxTypes []string `json:"txTypes"`
+}
+
+// GetAddressTxsReply is the response from calling GetAddressTxs.
+type GetAddressTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns the accepted txs that affected [args.Address]'s
+// balance of [args.AssetID], in the order they were accepted. Reward owners of
+// staking txs are considered to be affected.
+func (service *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	service.vm.ctx.Log.Debug("Platform: GetAddressTxs called",
+		logging.UserString("address", args.Address),
+		zap.Stringer("assetID", args.AssetID),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	if pageSize > builder.MaxPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", builder.MaxPageSize)
+	} else if pageSize == 0 {
+		pageSize = builder.MaxPageSize
+	}
+
+	address, err := avax.ParseServiceAddress(service.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	assetID := args.AssetID
+	if assetID == ids.Empty {
+		assetID = service.vm.ctx.AVAXAssetID
+	}
+
+	txTypes := make(map[string]struct{}, len(args.TxTypes))
+	for _, txType := range args.TxTypes {
+		txTypes[txType] = struct{}{}
+	}
+
+	// The cursor counts every indexed tx, including those that are filtered
+	// out, so that pages are stable. At most [builder.MaxPageSize] indexed txs
+	// are scanned per call, so a filter that rarely matches may return fewer
+	// than [pageSize] txs even though more remain after the returned cursor.
+	reply.TxIDs = []ids.ID{}
+	scanned := uint64(0)
+	for uint64(len(reply.TxIDs)) < pageSize && scanned < builder.MaxPageSize {
+		txIDs, err := service.vm.addressTxsIndexer.Read(address[:], assetID, cursor, pageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, txID := range txIDs {
+			if uint64(len(reply.TxIDs)) == pageSize || scanned == builder.MaxPageSize {
+				break
+			}
+			cursor++
+			scanned++
+
+			if len(txTypes) == 0 {
+				reply.TxIDs = append(reply.TxIDs, txID)
+				continue
+			}
+			tx, _, err := service.vm.state.GetTx(txID)
+			if err != nil {
+				return fmt.Errorf("couldn't get tx %s: %w", txID, err)
+			}
+			if _, ok := txTypes[txTypeName(tx.Unsigned)]; ok {
+				reply.TxIDs = append(reply.TxIDs, txID)
+			}
+		}
+
+		if uint64(len(txIDs)) < pageSize {
+			// There are no more indexed txs
+			break
+		}
+	}
+	reply.Cursor = json.Uint64(cursor)
+	return nil
+}
+
+// txTypeName returns the name of the type of [tx], such as "AddValidatorTx"
+func txTypeName(tx txs.UnsignedTx) string {
+	return reflect.TypeOf(tx).Elem().Name()
+}
+
 // GetRewardUTXOsReply defines the GetRewardUTXOs replies returned from the API
 type GetRewardUTXOsReply struct {
 	// Number of UTXOs returned
@@ -2277,6 +3289,30 @@ func (service *Service) GetTimestamp(_ *http.Request, args *struct{}, reply *Get
 	return nil
 }
 
+// GetFeeStateReply is the response from GetFeeState
+type GetFeeStateReply struct {
+	// Active is true if txs must burn a complexity-based fee in addition to
+	// their fixed fee
+	Active bool `json:"active"`
+	// BaseFee is the price, in nAVAX per unit of gas, of the next block
+	BaseFee json.Uint64 `json:"baseFee"`
+	// Config describes how the gas consumed by a tx is calculated and how the
+	// base fee changes between blocks
+	Config fees.Config `json:"config"`
+}
+
+// GetFeeState returns the parameters needed to calculate the complexity-based
+// fee of a tx issued on top of the last accepted block.
+func (service *Service) GetFeeState(_ *http.Request, _ *struct{}, reply *GetFeeStateReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetFeeState called")
+
+	cfg := &service.vm.Config
+	reply.Active = cfg.IsDynamicFeesActivated(service.vm.state.GetTimestamp())
+	reply.BaseFee = json.Uint64(math.Max64(service.vm.state.GetBaseFee(), cfg.DynamicFees.MinBaseFee))
+	reply.Config = cfg.DynamicFees
+	return nil
+}
+
 // GetValidatorsAtArgs is the response from GetValidatorsAt
 type GetValidatorsAtArgs struct {
 	Height   json.Uint64 `json:"height"`
@@ -2297,11 +3333,199 @@ func (service *Service) GetValidatorsAt(_ *http.Request, args *GetValidatorsAtAr
 		zap.Stringer("subnetID", args.SubnetID),
 	)
 
-	var err error
-	reply.Validators, err = service.vm.GetValidatorSet(height, args.SubnetID)
+	vdrs, err := service.vm.GetValidatorSet(height, args.SubnetID)
 	if err != nil {
 		return fmt.Errorf("couldn't get validator set: %w", err)
 	}
+	reply.Validators = make(map[ids.NodeID]uint64, len(vdrs))
+	for nodeID, vdr := range vdrs {
+		reply.Validators[nodeID] = vdr.Weight
+	}
+	return nil
+}
+
+// GetValidatorSetChangesArgs are the arguments for GetValidatorSetChanges
+type GetValidatorSetChangesArgs struct {
+	SubnetID    ids.ID      `json:"subnetID"`
+	StartHeight json.Uint64 `json:"startHeight"`
+	// EndHeight is inclusive
+	EndHeight json.Uint64 `json:"endHeight"`
+}
+
+// ValidatorChange describes how the weight of a validator changed
+type ValidatorChange struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// PreviousWeight is the weight of the validator before the change
+	PreviousWeight json.Uint64 `json:"previousWeight"`
+	// Weight is the weight of the validator after the change
+	Weight json.Uint64 `json:"weight"`
+}
+
+// ValidatorSetChanges are the changes made to a validator set by the block at
+// [Height]
+type ValidatorSetChanges struct {
+	Height json.Uint64 `json:"height"`
+	// Added are the validators that joined the set
+	Added []ValidatorChange `json:"added"`
+	// Removed are the validators that left the set
+	Removed []ValidatorChange `json:"removed"`
+	// Updated are the validators that remained in the set with a new weight
+	Updated []ValidatorChange `json:"updated"`
+}
+
+// GetValidatorSetChangesReply is the response from GetValidatorSetChanges
+type GetValidatorSetChangesReply struct {
+	// Changes are ordered by height. Heights at which the validator set didn't
+	// change are omitted.
+	Changes []ValidatorSetChanges `json:"changes"`
+	// EndHeight is the last height that was inspected. If it is lower than the
+	// requested end height, the next page starts at [EndHeight] + 1.
+	EndHeight json.Uint64 `json:"endHeight"`
+}
+
+// GetValidatorSetChanges returns the changes made to the validator set of a
+// subnet between two heights, inclusive. At most [maxValidatorSetChangesHeights]
+// heights are inspected per call.
+func (service *Service) GetValidatorSetChanges(_ *http.Request, args *GetValidatorSetChangesArgs, reply *GetValidatorSetChangesReply) error {
+	startHeight := uint64(args.StartHeight)
+	endHeight := uint64(args.EndHeight)
+	service.vm.ctx.Log.Debug("Platform: GetValidatorSetChanges called",
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Uint64("startHeight", startHeight),
+		zap.Uint64("endHeight", endHeight),
+	)
+
+	if endHeight < startHeight {
+		return errStartAfterEndHeight
+	}
+	lastAcceptedHeight, err := service.vm.GetCurrentHeight()
+	if err != nil {
+		return fmt.Errorf("couldn't get current height: %w", err)
+	}
+	if lastAcceptedHeight < startHeight {
+		return fmt.Errorf("%w: start height %d > last accepted height %d", errHeightNotAccepted, startHeight, lastAcceptedHeight)
+	}
+	if prunedHeight := service.vm.state.GetPrunedHeight(); prunedHeight > 0 && startHeight <= prunedHeight {
+		return fmt.Errorf("%w: start height %d <= pruned height %d", errPrunedHeight, startHeight, prunedHeight)
+	}
+	if lastAcceptedHeight < endHeight {
+		endHeight = lastAcceptedHeight
+	}
+	if endHeight-startHeight >= maxValidatorSetChangesHeights {
+		endHeight = startHeight + maxValidatorSetChangesHeights - 1
+	}
+
+	// Weights of the validators before the block at [height] was accepted
+	weights := make(map[ids.NodeID]uint64)
+	if startHeight > 0 {
+		vdrs, err := service.vm.GetValidatorSet(startHeight-1, args.SubnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get validator set: %w", err)
+		}
+		for nodeID, vdr := range vdrs {
+			weights[nodeID] = vdr.Weight
+		}
+	}
+
+	reply.Changes = []ValidatorSetChanges{}
+	for height := startHeight; height <= endHeight; height++ {
+		diffs, err := service.vm.state.GetValidatorWeightDiffs(height, args.SubnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get validator weight diffs at height %d: %w", height, err)
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+
+		nodeIDs := make([]ids.NodeID, 0, len(diffs))
+		for nodeID := range diffs {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+		ids.SortNodeIDs(nodeIDs)
+
+		changes := ValidatorSetChanges{
+			Height:  json.Uint64(height),
+			Added:   []ValidatorChange{},
+			Removed: []ValidatorChange{},
+			Updated: []ValidatorChange{},
+		}
+		for _, nodeID := range nodeIDs {
+			diff := diffs[nodeID]
+			previousWeight := weights[nodeID]
+
+			var newWeight uint64
+			if diff.Decrease {
+				newWeight, err = math.Sub64(previousWeight, diff.Amount)
+			} else {
+				newWeight, err = math.Add64(previousWeight, diff.Amount)
+			}
+			if err != nil {
+				return fmt.Errorf("couldn't apply weight diff of %s at height %d: %w", nodeID, height, err)
+			}
+
+			change := ValidatorChange{
+				NodeID:         nodeID,
+				PreviousWeight: json.Uint64(previousWeight),
+				Weight:         json.Uint64(newWeight),
+			}
+			switch {
+			case previousWeight == newWeight:
+				continue
+			case previousWeight == 0:
+				changes.Added = append(changes.Added, change)
+			case newWeight == 0:
+				changes.Removed = append(changes.Removed, change)
+			default:
+				changes.Updated = append(changes.Updated, change)
+			}
+
+			if newWeight == 0 {
+				delete(weights, nodeID)
+			} else {
+				weights[nodeID] = newWeight
+			}
+		}
+		if len(changes.Added)+len(changes.Removed)+len(changes.Updated) > 0 {
+			reply.Changes = append(reply.Changes, changes)
+		}
+	}
+	reply.EndHeight = json.Uint64(endHeight)
+	return nil
+}
+
+// GetRetainedRangeReply is the response from GetRetainedRange
+type GetRetainedRangeReply struct {
+	// PruningEnabled is true if the heights older than the retained range
+	// are pruned
+	PruningEnabled bool `json:"pruningEnabled"`
+	// MinBlockHeight is the height of the oldest accepted block that is
+	// retained, other than the genesis block
+	MinBlockHeight json.Uint64 `json:"minBlockHeight"`
+	// MinValidatorSetHeight is the lowest height whose validator sets can be
+	// fetched
+	MinValidatorSetHeight json.Uint64 `json:"minValidatorSetHeight"`
+	// LastAcceptedHeight is the height of the last accepted block
+	LastAcceptedHeight json.Uint64 `json:"lastAcceptedHeight"`
+}
+
+// GetRetainedRange returns the range of heights whose blocks, txs and
+// validator sets weren't pruned.
+func (service *Service) GetRetainedRange(_ *http.Request, _ *struct{}, reply *GetRetainedRangeReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetRetainedRange called")
+
+	lastAcceptedHeight, err := service.vm.GetCurrentHeight()
+	if err != nil {
+		return fmt.Errorf("couldn't get current height: %w", err)
+	}
+	prunedHeight := service.vm.state.GetPrunedHeight()
+
+	reply.PruningEnabled = service.vm.pruningRetention != 0
+	reply.MinBlockHeight = json.Uint64(prunedHeight + 1)
+	if prunedHeight == 0 {
+		reply.MinBlockHeight = 0
+	}
+	reply.MinValidatorSetHeight = json.Uint64(prunedHeight)
+	reply.LastAcceptedHeight = json.Uint64(lastAcceptedHeight)
 	return nil
 }
 
@@ -2329,3 +3553,25 @@ func (service *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, respon
 
 	return nil
 }
+
+// getAPIOwner returns the API representation of [owner]. If [owner] isn't a
+// *secp256k1fx.OutputOwners, nil is returned.
+func (service *Service) getAPIOwner(owner fx.Owner) (*platformapi.Owner, error) {
+	outputOwners, ok := owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, nil
+	}
+
+	apiOwner := &platformapi.Owner{
+		Locktime:  json.Uint64(outputOwners.Locktime),
+		Threshold: json.Uint32(outputOwners.Threshold),
+	}
+	for _, addr := range outputOwners.Addrs {
+		addrStr, err := service.addrManager.FormatLocalAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		apiOwner.Addresses = append(apiOwner.Addresses, addrStr)
+	}
+	return apiOwner, nil
+}
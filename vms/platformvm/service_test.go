@@ -29,7 +29,12 @@ import (
 	"github.com/kukrer/savannahnode/utils/logging"
 	"github.com/kukrer/savannahnode/version"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/components/burn"
 	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
+	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakinghistory"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/status"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
@@ -286,6 +291,133 @@ func TestGetTxStatus(t *testing.T) {
 	}
 }
 
+func TestSimulateTx(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	tx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+	args := &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}
+
+	reply := SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, args, &reply))
+	require.Equal(tx.ID(), reply.TxID)
+	require.True(reply.Valid)
+	require.Empty(reply.Reason)
+	require.EqualValues(service.vm.TxFee, reply.Burned)
+	require.Len(reply.ConsumedUTXOs, len(tx.Unsigned.InputIDs()))
+	require.Len(reply.ProducedUTXOs, len(tx.UTXOs()))
+
+	for i, utxoStr := range reply.ConsumedUTXOs {
+		utxoBytes, err := formatting.Decode(formatting.Hex, utxoStr)
+		require.NoError(err)
+		utxo := &avax.UTXO{}
+		_, err = txs.Codec.Unmarshal(utxoBytes, utxo)
+		require.NoError(err)
+		require.Equal(tx.Unsigned.(*txs.ExportTx).Ins[i].InputID(), utxo.InputID())
+	}
+
+	// Simulating must not add the tx to the mempool
+	require.False(service.vm.Builder.Has(tx.ID()))
+
+	require.NoError(service.vm.Builder.AddUnverifiedTx(tx))
+	blk, err := service.vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.NoError(blk.Accept())
+
+	// The inputs of the tx have now been consumed
+	reply = SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, args, &reply))
+	require.False(reply.Valid)
+	require.NotEmpty(reply.Reason)
+	require.Empty(reply.ConsumedUTXOs)
+	require.Empty(reply.ProducedUTXOs)
+}
+
+func TestMempoolAPI(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	mempoolTxs := make([]*txs.Tx, 2)
+	for i := range mempoolTxs {
+		tx, err := service.vm.txBuilder.NewExportTx(
+			100,
+			service.vm.ctx.XChainID,
+			ids.GenerateTestShortID(),
+			[]*crypto.PrivateKeySECP256K1R{keys[i]},
+			keys[i].PublicKey().Address(), // change addr
+		)
+		require.NoError(err)
+		require.NoError(service.vm.Builder.AddUnverifiedTx(tx))
+		mempoolTxs[i] = tx
+	}
+
+	reply := GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{Limit: 1}, &reply))
+	require.EqualValues(2, reply.NumTxs)
+	require.Len(reply.Txs, 1)
+	require.Equal(mempoolTxs[0].ID(), reply.Txs[0].TxID)
+	require.EqualValues(len(mempoolTxs[0].Bytes()), reply.Txs[0].Size)
+	require.EqualValues(1, reply.EndIndex)
+
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{StartIndex: reply.EndIndex}, &reply))
+	require.Len(reply.Txs, 1)
+	require.Equal(mempoolTxs[1].ID(), reply.Txs[0].TxID)
+	require.EqualValues(2, reply.EndIndex)
+
+	txReply := GetMempoolTxReply{}
+	require.NoError(service.GetMempoolTx(nil, &api.GetTxArgs{
+		TxID:     mempoolTxs[1].ID(),
+		Encoding: formatting.Hex,
+	}, &txReply))
+	require.Equal(mempoolTxs[1].ID(), txReply.TxID)
+	txBytes, err := formatting.Decode(formatting.Hex, txReply.Tx.(string))
+	require.NoError(err)
+	require.Equal(mempoolTxs[1].Bytes(), txBytes)
+
+	adminService := AdminService{vm: service.vm}
+	txID := &api.JSONTxID{TxID: mempoolTxs[0].ID()}
+	require.NoError(adminService.EvictMempoolTx(nil, txID, &api.EmptyReply{}))
+	err = adminService.EvictMempoolTx(nil, txID, &api.EmptyReply{})
+	require.ErrorIs(err, errTxNotInMempool)
+
+	err = service.GetMempoolTx(nil, &api.GetTxArgs{TxID: mempoolTxs[0].ID()}, &txReply)
+	require.ErrorIs(err, errTxNotInMempool)
+
+	droppedReply := GetDroppedTxReasonReply{}
+	require.NoError(service.GetDroppedTxReason(nil, txID, &droppedReply))
+	require.True(droppedReply.Dropped)
+	require.Equal(droppedByAdminReason, droppedReply.Reason)
+
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{}, &reply))
+	require.EqualValues(1, reply.NumTxs)
+	require.Len(reply.Txs, 1)
+	require.Equal(mempoolTxs[1].ID(), reply.Txs[0].TxID)
+}
+
 // Test issuing and then retrieving a transaction
 func TestGetTx(t *testing.T) {
 	type test struct {
@@ -454,6 +586,59 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestGetBalanceVesting(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	now := uint64(service.vm.clock.Unix())
+	addr := ids.GenerateTestShortID()
+	service.vm.state.AddUTXO(&avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+		Out: &stakeable.VestingOut{
+			Tranches: []stakeable.Tranche{
+				{
+					Locktime: now - 1,
+					Amount:   1,
+				},
+				{
+					Locktime: now + 1,
+					Amount:   2,
+				},
+				{
+					Locktime: now + 2,
+					Amount:   3,
+				},
+			},
+			TransferableOut: &secp256k1fx.TransferOutput{
+				Amt: 6,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		},
+	})
+	require.NoError(service.vm.state.Commit())
+
+	addrStr, err := service.addrManager.FormatLocalAddress(addr)
+	require.NoError(err)
+	reply := GetBalanceResponse{}
+	require.NoError(service.GetBalance(nil, &GetBalanceRequest{
+		Addresses: []string{addrStr},
+	}, &reply))
+	require.EqualValues(6, reply.Balance)
+	require.EqualValues(1, reply.Unlocked)
+	require.EqualValues(5, reply.LockedStakeable)
+	require.EqualValues(0, reply.LockedNotStakeable)
+	require.Len(reply.UTXOIDs, 1)
+}
+
 func TestGetStake(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
@@ -765,6 +950,177 @@ func TestGetTimestamp(t *testing.T) {
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetFeeState(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.Config.DynamicFees = fees.Config{
+		BytesWeight: 1,
+		MinBaseFee:  10,
+	}
+	service.vm.Config.DynamicFeesTime = service.vm.state.GetTimestamp().Add(time.Second)
+
+	reply := GetFeeStateReply{}
+	require.NoError(service.GetFeeState(nil, nil, &reply))
+	require.False(reply.Active)
+	require.EqualValues(10, reply.BaseFee)
+	require.Equal(service.vm.Config.DynamicFees, reply.Config)
+
+	service.vm.state.SetTimestamp(service.vm.Config.DynamicFeesTime)
+	service.vm.state.SetBaseFee(25)
+
+	require.NoError(service.GetFeeState(nil, nil, &reply))
+	require.True(reply.Active)
+	require.EqualValues(25, reply.BaseFee)
+}
+
+func TestGetValidatorSetChanges(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+	vm := service.vm
+
+	startTime := defaultGenesisTime.Add(txexecutor.SyncBound).Add(1 * time.Second)
+	endTime := startTime.Add(defaultMaxStakingDuration)
+	nodeID := ids.GenerateTestNodeID()
+
+	addValidatorTx, err := vm.txBuilder.NewAddValidatorTx(
+		vm.MaxValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.GenerateTestShortID(),
+	)
+	require.NoError(err)
+
+	preferred, err := vm.Preferred()
+	require.NoError(err)
+	statelessBlk, err := blocks.NewProposalBlock(
+		preferred.ID(),
+		preferred.Height()+1,
+		addValidatorTx,
+	)
+	require.NoError(err)
+	verifyAndAcceptProposalCommitment(require, vm, vm.manager.NewBlock(statelessBlk))
+
+	// Move the validator from the pending set into the current set at height 5
+	vm.clock.Set(startTime)
+	advanceTimeTx, err := vm.txBuilder.NewAdvanceTimeTx(startTime)
+	require.NoError(err)
+
+	preferred, err = vm.Preferred()
+	require.NoError(err)
+	statelessBlk, err = blocks.NewProposalBlock(
+		preferred.ID(),
+		preferred.Height()+1,
+		advanceTimeTx,
+	)
+	require.NoError(err)
+	verifyAndAcceptProposalCommitment(require, vm, vm.manager.NewBlock(statelessBlk))
+
+	args := GetValidatorSetChangesArgs{
+		SubnetID:    constants.PrimaryNetworkID,
+		StartHeight: 1,
+		EndHeight:   100,
+	}
+	reply := GetValidatorSetChangesReply{}
+	require.NoError(service.GetValidatorSetChanges(nil, &args, &reply))
+	require.EqualValues(5, reply.EndHeight)
+	require.Equal([]ValidatorSetChanges{{
+		Height: 5,
+		Added: []ValidatorChange{{
+			NodeID: nodeID,
+			Weight: json.Uint64(vm.MaxValidatorStake),
+		}},
+		Removed: []ValidatorChange{},
+		Updated: []ValidatorChange{},
+	}}, reply.Changes)
+
+	args.EndHeight = 4
+	require.NoError(service.GetValidatorSetChanges(nil, &args, &reply))
+	require.EqualValues(4, reply.EndHeight)
+	require.Empty(reply.Changes)
+
+	args.StartHeight = 5
+	err = service.GetValidatorSetChanges(nil, &args, &reply)
+	require.ErrorIs(err, errStartAfterEndHeight)
+
+	args.StartHeight = 6
+	args.EndHeight = 6
+	err = service.GetValidatorSetChanges(nil, &args, &reply)
+	require.ErrorIs(err, errHeightNotAccepted)
+}
+
+func TestEstimateReward(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+	vm := service.vm
+
+	calculator := reward.NewCalculator(vm.RewardConfig)
+	expectedReward := calculator.Calculate(
+		defaultMinStakingDuration,
+		vm.MinDelegatorStake,
+		vm.state.GetCurrentSupply(),
+	)
+
+	args := EstimateRewardArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		Amount:   json.Uint64(vm.MinDelegatorStake),
+		Duration: json.Uint64(defaultMinStakingDuration / time.Second),
+	}
+	reply := EstimateRewardReply{}
+	require.NoError(service.EstimateReward(nil, &args, &reply))
+	require.EqualValues(expectedReward, reply.PotentialReward)
+	require.EqualValues(expectedReward, reply.StakerReward)
+	require.Zero(reply.ValidatorFee)
+
+	nodeID := ids.NodeID(keys[0].PublicKey().Address())
+	validator, err := vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	validatorTx, _, err := vm.state.GetTx(validator.TxID)
+	require.NoError(err)
+	shares := validatorTx.Unsigned.(*txs.AddValidatorTx).Shares
+	expectedStakerReward, expectedValidatorFee := reward.Split(expectedReward, shares)
+
+	args.NodeID = nodeID
+	args.Delegator = true
+	reply = EstimateRewardReply{}
+	require.NoError(service.EstimateReward(nil, &args, &reply))
+	require.EqualValues(expectedReward, reply.PotentialReward)
+	require.EqualValues(expectedStakerReward, reply.StakerReward)
+	require.EqualValues(expectedValidatorFee, reply.ValidatorFee)
+	require.EqualValues(100*float32(shares)/float32(reward.PercentDenominator), reply.DelegationFee)
+
+	args.NodeID = ids.GenerateTestNodeID()
+	err = service.EstimateReward(nil, &args, &reply)
+	require.ErrorIs(err, errNotValidator)
+
+	args.Duration = json.Uint64((defaultMaxStakingDuration + time.Second) / time.Second)
+	err = service.EstimateReward(nil, &args, &reply)
+	require.ErrorIs(err, errInvalidStakeDuration)
+
+	args.Amount = 0
+	err = service.EstimateReward(nil, &args, &reply)
+	require.ErrorIs(err, errNoAmount)
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -840,3 +1196,370 @@ func TestGetBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestGetStakingHistory(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVMWithChainConfig([]byte(`{"index-staking-history":true}`))
+	service := &Service{
+		vm:          vm,
+		addrManager: avax.NewAddressManager(vm.ctx),
+	}
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// acceptCommit builds a proposal block and accepts its commit option
+	acceptCommit := func() {
+		blk, err := vm.BuildBlock()
+		require.NoError(err)
+		require.NoError(blk.Verify())
+		options, err := blk.(snowman.OracleBlock).Options()
+		require.NoError(err)
+		commit := options[0].(*blockexecutor.Block)
+		_, ok := commit.Block.(*blocks.CommitBlock)
+		require.True(ok)
+
+		require.NoError(blk.Accept())
+		require.NoError(commit.Verify())
+		require.NoError(commit.Accept())
+		require.NoError(vm.SetPreference(vm.manager.LastAccepted()))
+	}
+
+	// Advance the chain time to the end of the genesis validators and then
+	// reward the first of them
+	vm.clock.Set(defaultValidateEndTime)
+	acceptCommit()
+
+	stakerIterator, err := vm.state.GetCurrentStakerIterator()
+	require.NoError(err)
+	require.True(stakerIterator.Next())
+	staker := stakerIterator.Value()
+	stakerIterator.Release()
+
+	acceptCommit()
+
+	stakerTx, _, err := vm.state.GetTx(staker.TxID)
+	require.NoError(err)
+	rewardAddr := stakerTx.Unsigned.(*txs.AddValidatorTx).RewardsOwner.(*secp256k1fx.OutputOwners).Addrs[0]
+	rewardAddrStr, err := service.addrManager.FormatLocalAddress(rewardAddr)
+	require.NoError(err)
+
+	reply := GetStakingHistoryReply{}
+	require.NoError(service.GetStakingHistory(nil, &GetStakingHistoryArgs{
+		NodeID: staker.NodeID,
+	}, &reply))
+	require.Len(reply.Periods, 1)
+	require.EqualValues(1, reply.EndCursor)
+
+	period := reply.Periods[0]
+	require.Equal(staker.TxID, period.TxID)
+	require.Equal(staker.NodeID, period.NodeID)
+	require.False(period.Delegator)
+	require.EqualValues(staker.StartTime.Unix(), period.StartTime)
+	require.EqualValues(staker.EndTime.Unix(), period.EndTime)
+	require.EqualValues(staker.Weight, period.Stake)
+	require.True(period.Rewarded)
+	require.EqualValues(staker.PotentialReward, period.Reward)
+	require.Equal([]string{rewardAddrStr}, period.RewardAddresses)
+
+	// The same period is indexed by reward address
+	addrReply := GetStakingHistoryReply{}
+	require.NoError(service.GetStakingHistory(nil, &GetStakingHistoryArgs{
+		Address: rewardAddrStr,
+	}, &addrReply))
+	require.Equal(reply.Periods, addrReply.Periods)
+
+	// Paginating past the last period returns nothing
+	require.NoError(service.GetStakingHistory(nil, &GetStakingHistoryArgs{
+		NodeID: staker.NodeID,
+		Cursor: reply.EndCursor,
+	}, &reply))
+	require.Empty(reply.Periods)
+	require.EqualValues(1, reply.EndCursor)
+
+	err = service.GetStakingHistory(nil, &GetStakingHistoryArgs{}, &reply)
+	require.ErrorIs(err, errNodeIDXorAddress)
+}
+
+func TestGetStakingHistoryDisabled(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetStakingHistoryReply{}
+	err := service.GetStakingHistory(nil, &GetStakingHistoryArgs{
+		NodeID: ids.GenerateTestNodeID(),
+	}, &reply)
+	require.ErrorIs(err, stakinghistory.ErrIndexDisabled)
+}
+
+func TestGetAddressTxs(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVMWithChainConfig([]byte(`{"index-transactions":true}`))
+	service := &Service{
+		vm:          vm,
+		addrManager: avax.NewAddressManager(vm.ctx),
+	}
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	recipient := ids.GenerateTestShortID()
+	tx, err := vm.txBuilder.NewExportTx(
+		100,
+		vm.ctx.XChainID,
+		recipient,
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		keys[1].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+	require.NoError(vm.Builder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.NoError(blk.Accept())
+
+	// The recipient of the exported funds is affected by the tx
+	reply := GetAddressTxsReply{}
+	require.NoError(service.GetAddressTxs(nil, &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: recipient.String()},
+	}, &reply))
+	require.Equal([]ids.ID{tx.ID()}, reply.TxIDs)
+	require.EqualValues(1, reply.Cursor)
+
+	// So is the sender
+	sender := keys[1].PublicKey().Address().String()
+	require.NoError(service.GetAddressTxs(nil, &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: sender},
+		TxTypes:     []string{"ExportTx"},
+	}, &reply))
+	require.Equal([]ids.ID{tx.ID()}, reply.TxIDs)
+
+	// Filtered out txs still advance the cursor
+	require.NoError(service.GetAddressTxs(nil, &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: sender},
+		TxTypes:     []string{"ImportTx"},
+	}, &reply))
+	require.Empty(reply.TxIDs)
+	require.EqualValues(1, reply.Cursor)
+
+	// Nothing was indexed for other assets
+	require.NoError(service.GetAddressTxs(nil, &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: sender},
+		AssetID:     ids.GenerateTestID(),
+	}, &reply))
+	require.Empty(reply.TxIDs)
+
+	// Rewarding a genesis validator affects its reward address
+	require.NoError(vm.SetPreference(vm.manager.LastAccepted()))
+	vm.clock.Set(defaultValidateEndTime)
+	var rewardTxID ids.ID
+	for i := 0; i < 2; i++ {
+		blk, err := vm.BuildBlock()
+		require.NoError(err)
+		require.NoError(blk.Verify())
+		options, err := blk.(snowman.OracleBlock).Options()
+		require.NoError(err)
+		commit := options[0].(*blockexecutor.Block)
+
+		require.NoError(blk.Accept())
+		require.NoError(commit.Verify())
+		require.NoError(commit.Accept())
+		require.NoError(vm.SetPreference(vm.manager.LastAccepted()))
+		rewardTxID = blk.(blocks.Block).Txs()[0].ID()
+	}
+
+	rewardTx, _, err := vm.state.GetTx(rewardTxID)
+	require.NoError(err)
+	stakerTx, _, err := vm.state.GetTx(rewardTx.Unsigned.(*txs.RewardValidatorTx).TxID)
+	require.NoError(err)
+	rewardAddr := stakerTx.Unsigned.(*txs.AddValidatorTx).RewardsOwner.(*secp256k1fx.OutputOwners).Addrs[0]
+
+	require.NoError(service.GetAddressTxs(nil, &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: rewardAddr.String()},
+		TxTypes:     []string{"RewardValidatorTx"},
+	}, &reply))
+	require.Equal([]ids.ID{rewardTxID}, reply.TxIDs)
+}
+
+func TestGetSupplyBreakdown(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetSupplyBreakdownReply{}
+	require.NoError(service.GetSupplyBreakdown(nil, nil, &reply))
+	require.EqualValues(service.vm.state.GetCurrentSupply(), reply.Supply)
+	require.EqualValues(len(keys)*defaultWeight, reply.ValidatorStake)
+	require.Zero(reply.DelegatorStake)
+	require.Zero(reply.RewardsMinted)
+	require.Zero(reply.BurnedFees)
+	require.Empty(reply.LocallyReportedBurnedFees)
+	require.Empty(reply.AtomicFlows)
+
+	tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.AddUnverifiedTx(tx))
+	blk, err := service.vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.NoError(blk.Accept())
+
+	utx := tx.Unsigned.(*txs.CreateSubnetTx)
+	fee := uint64(0)
+	for _, in := range utx.Ins {
+		fee += in.In.Amount()
+	}
+	for _, out := range utx.Outs {
+		fee -= out.Out.Amount()
+	}
+
+	newReply := GetSupplyBreakdownReply{}
+	require.NoError(service.GetSupplyBreakdown(nil, nil, &newReply))
+	require.EqualValues(fee, newReply.BurnedFees)
+	require.Equal(reply.ValidatorStake, newReply.ValidatorStake)
+}
+
+func TestGetSupplyBreakdownOtherChains(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// The X-chain reports its burned fees through the registry
+	service.vm.BurnRegistry = burn.NewRegistry()
+	service.vm.BurnRegistry.Register(xChainID, testBurnReporter(5))
+
+	m := atomic.NewMemory(prefixdb.New([]byte{}, service.vm.dbManager.Current().Database))
+	service.vm.ctx.SharedMemory = m.NewSharedMemory(service.vm.ctx.ChainID)
+	xSharedMemory := m.NewSharedMemory(xChainID)
+
+	utxoElement := func(assetID ids.ID, amount uint64) *atomic.Element {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+					Threshold: 1,
+				},
+			},
+		}
+		utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+		require.NoError(err)
+		inputID := utxo.InputID()
+		return &atomic.Element{
+			Key:   inputID[:],
+			Value: utxoBytes,
+		}
+	}
+
+	// The X-chain sent AVAX and another asset to the P-chain, and the P-chain
+	// sent AVAX to the X-chain, none of which were imported yet
+	require.NoError(xSharedMemory.Apply(map[ids.ID]*atomic.Requests{service.vm.ctx.ChainID: {
+		PutRequests: []*atomic.Element{
+			utxoElement(avaxAssetID, 3),
+			utxoElement(avaxAssetID, 4),
+			utxoElement(ids.GenerateTestID(), 100),
+		},
+	}}))
+	require.NoError(service.vm.ctx.SharedMemory.Apply(map[ids.ID]*atomic.Requests{xChainID: {
+		PutRequests: []*atomic.Element{
+			utxoElement(avaxAssetID, 2),
+		},
+	}}))
+
+	reply := GetSupplyBreakdownReply{}
+	require.NoError(service.GetSupplyBreakdown(nil, nil, &reply))
+	require.Zero(reply.BurnedFees)
+	require.Equal([]ChainBurnedFees{{
+		ChainID:    xChainID,
+		BurnedFees: 5,
+	}}, reply.LocallyReportedBurnedFees)
+	require.Equal([]AtomicFlow{{
+		ChainID:       xChainID,
+		PendingExport: 2,
+		PendingImport: 7,
+	}}, reply.AtomicFlows)
+}
+
+type testBurnReporter uint64
+
+func (r testBurnReporter) BurnedFees() uint64 { return uint64(r) }
+
+func TestGetSubnet(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	subnetID := testSubnet1.ID()
+	newOwnerAddr := keys[3].PublicKey().Address()
+	tx, err := service.vm.txBuilder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		1,
+		[]ids.ShortID{newOwnerAddr},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.AddUnverifiedTx(tx))
+	blk, err := service.vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.NoError(blk.Accept())
+
+	newOwner, err := service.addrManager.FormatLocalAddress(newOwnerAddr)
+	require.NoError(err)
+
+	reply := GetSubnetReply{}
+	require.NoError(service.GetSubnet(nil, &GetSubnetArgs{
+		SubnetID: subnetID,
+	}, &reply))
+	require.EqualValues(1, reply.Owner.Threshold)
+	require.Equal([]string{newOwner}, reply.Owner.Addresses)
+	require.Len(reply.OwnershipHistory, 2)
+	require.Equal(subnetID, reply.OwnershipHistory[0].TxID)
+	require.EqualValues(2, reply.OwnershipHistory[0].Owner.Threshold)
+	require.Len(reply.OwnershipHistory[0].Owner.Addresses, 3)
+	require.Equal(tx.ID(), reply.OwnershipHistory[1].TxID)
+	require.Equal(reply.Owner, reply.OwnershipHistory[1].Owner)
+
+	// getSubnets reports the current owner
+	subnetsReply := GetSubnetsResponse{}
+	require.NoError(service.GetSubnets(nil, &GetSubnetsArgs{
+		IDs: []ids.ID{subnetID},
+	}, &subnetsReply))
+	require.Len(subnetsReply.Subnets, 1)
+	require.EqualValues(1, subnetsReply.Subnets[0].Threshold)
+	require.Equal([]string{newOwner}, subnetsReply.Subnets[0].ControlKeys)
+
+	err = service.GetSubnet(nil, &GetSubnetArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply)
+	require.ErrorIs(err, errPrimaryNetworkHasNoOwner)
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+)
+
+func TestProofOfPossession(t *testing.T) {
+	require := require.New(t)
+
+	blsPOP, err := newProofOfPossession()
+	require.NoError(err)
+	require.NoError(blsPOP.Verify())
+	require.NotNil(blsPOP.Key())
+
+	blsPOP, err = newProofOfPossession()
+	require.NoError(err)
+	blsPOP.ProofOfPossession = [bls.SignatureLen]byte{}
+	require.Error(blsPOP.Verify())
+
+	blsPOP, err = newProofOfPossession()
+	require.NoError(err)
+	blsPOP.PublicKey = [bls.PublicKeyLen]byte{}
+	require.Error(blsPOP.Verify())
+
+	newBLSPOP, err := newProofOfPossession()
+	require.NoError(err)
+	newBLSPOP.ProofOfPossession = blsPOP.ProofOfPossession
+	require.ErrorIs(newBLSPOP.Verify(), errInvalidProofOfPossession)
+}
+
+func TestProofOfPossessionJSON(t *testing.T) {
+	require := require.New(t)
+
+	blsPOP, err := newProofOfPossession()
+	require.NoError(err)
+
+	blsPOPBytes, err := json.Marshal(blsPOP)
+	require.NoError(err)
+
+	parsedPOP := &ProofOfPossession{}
+	require.NoError(json.Unmarshal(blsPOPBytes, parsedPOP))
+	require.Equal(blsPOP.PublicKey, parsedPOP.PublicKey)
+	require.Equal(blsPOP.ProofOfPossession, parsedPOP.ProofOfPossession)
+	require.NoError(parsedPOP.Verify())
+}
+
+func newProofOfPossession() (*ProofOfPossession, error) {
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		return nil, err
+	}
+	return NewProofOfPossession(sk), nil
+}
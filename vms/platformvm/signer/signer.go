@@ -0,0 +1,19 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+)
+
+// Signer optionally registers a BLS public key for a validator.
+type Signer interface {
+	verify.Verifiable
+
+	// Key returns the public BLS key if it exists.
+	// Note: [nil] will be returned if the key does not exist.
+	// Invariant: Only called after [Verify] returns [nil].
+	Key() *bls.PublicKey
+}
@@ -30,8 +30,11 @@ func (s *LockOut) Verify() error {
 	if s.Locktime == 0 {
 		return errInvalidLocktime
 	}
-	if _, nested := s.TransferableOut.(*LockOut); nested {
+	switch s.TransferableOut.(type) {
+	case *LockOut:
 		return errNestedStakeableLocks
+	case *VestingOut:
+		return errNestedVestingOutput
 	}
 	return s.TransferableOut.Verify()
 }
@@ -45,8 +48,11 @@ func (s *LockIn) Verify() error {
 	if s.Locktime == 0 {
 		return errInvalidLocktime
 	}
-	if _, nested := s.TransferableIn.(*LockIn); nested {
+	switch s.TransferableIn.(type) {
+	case *LockIn:
 		return errNestedStakeableLocks
+	case *VestingIn:
+		return errNestedVestingOutput
 	}
 	return s.TransferableIn.Verify()
 }
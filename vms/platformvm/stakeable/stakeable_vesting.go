@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package stakeable
+
+import (
+	"errors"
+
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+)
+
+// MaxTranches is the maximum number of tranches a vesting schedule can have
+const MaxTranches = 256
+
+var (
+	errNoTranches          = errors.New("vesting schedule has no tranches")
+	errTooManyTranches     = errors.New("vesting schedule has too many tranches")
+	errTrancheHasNoValue   = errors.New("tranche has no value")
+	errTranchesNotSorted   = errors.New("tranches not sorted by strictly increasing locktime")
+	errWrongVestingAmount  = errors.New("tranches don't add up to the vested amount")
+	errNestedVestingOutput = errors.New("shouldn't nest vesting outputs")
+	errInvalidTrancheCount = errors.New("tranche count must be > 0")
+)
+
+// Tranche is a part of a vested amount that stays locked until [Locktime]
+type Tranche struct {
+	Locktime uint64 `serialize:"true" json:"locktime"`
+	Amount   uint64 `serialize:"true" json:"amount"`
+}
+
+// LinearTranches returns the schedule that vests [amount] in [count] equal
+// tranches, the first of which unlocks at [start] and the following ones every
+// [interval] seconds after it. The rounding remainder unlocks with the last
+// tranche.
+func LinearTranches(amount, start, interval uint64, count uint32) ([]Tranche, error) {
+	if count == 0 {
+		return nil, errInvalidTrancheCount
+	}
+	if uint64(count) > amount {
+		return nil, errTrancheHasNoValue
+	}
+
+	trancheAmount := amount / uint64(count)
+	tranches := make([]Tranche, count)
+	for i := range tranches {
+		offset, err := math.Mul64(uint64(i), interval)
+		if err != nil {
+			return nil, err
+		}
+		locktime, err := math.Add64(start, offset)
+		if err != nil {
+			return nil, err
+		}
+		tranches[i] = Tranche{
+			Locktime: locktime,
+			Amount:   trancheAmount,
+		}
+	}
+	tranches[count-1].Amount += amount % uint64(count)
+	return tranches, nil
+}
+
+// LockedAmount returns the amount of [tranches] still locked at [time]
+func LockedAmount(tranches []Tranche, time uint64) uint64 {
+	locked := uint64(0)
+	for _, tranche := range tranches {
+		if tranche.Locktime > time {
+			// Can't overflow because verified tranches add up to a uint64
+			locked += tranche.Amount
+		}
+	}
+	return locked
+}
+
+// LockedTranches returns the tranches of [tranches] still locked at [time]
+func LockedTranches(tranches []Tranche, time uint64) []Tranche {
+	for i, tranche := range tranches {
+		if tranche.Locktime > time {
+			return tranches[i:]
+		}
+	}
+	return nil
+}
+
+// SplitTranches splits [amount] off of the tranches that unlock last. It
+// returns the tranches that remain and the tranches that were split off, both
+// sorted by locktime.
+//
+// Invariant: [amount] is at most the sum of [tranches].
+func SplitTranches(tranches []Tranche, amount uint64) ([]Tranche, []Tranche) {
+	remaining := make([]Tranche, len(tranches))
+	copy(remaining, tranches)
+
+	split := []Tranche(nil)
+	for amount > 0 && len(remaining) > 0 {
+		last := &remaining[len(remaining)-1]
+		taken := math.Min64(amount, last.Amount)
+		split = append(split, Tranche{
+			Locktime: last.Locktime,
+			Amount:   taken,
+		})
+		amount -= taken
+		last.Amount -= taken
+		if last.Amount == 0 {
+			remaining = remaining[:len(remaining)-1]
+		}
+	}
+
+	// [split] was built from the last tranche backwards
+	for i, j := 0, len(split)-1; i < j; i, j = i+1, j-1 {
+		split[i], split[j] = split[j], split[i]
+	}
+	return remaining, split
+}
+
+func verifyTranches(tranches []Tranche, amount uint64) error {
+	switch {
+	case len(tranches) == 0:
+		return errNoTranches
+	case len(tranches) > MaxTranches:
+		return errTooManyTranches
+	}
+
+	total := uint64(0)
+	prevLocktime := uint64(0)
+	for _, tranche := range tranches {
+		if tranche.Amount == 0 {
+			return errTrancheHasNoValue
+		}
+		if tranche.Locktime <= prevLocktime {
+			return errTranchesNotSorted
+		}
+		prevLocktime = tranche.Locktime
+
+		newTotal, err := math.Add64(total, tranche.Amount)
+		if err != nil {
+			return err
+		}
+		total = newTotal
+	}
+	if total != amount {
+		return errWrongVestingAmount
+	}
+	return nil
+}
+
+// VestingOut is an output whose amount unlocks in [Tranches]. Like a
+// [LockOut], the locked part of the output can be staked.
+type VestingOut struct {
+	Tranches             []Tranche `serialize:"true" json:"tranches"`
+	avax.TransferableOut `serialize:"true" json:"output"`
+}
+
+func (s *VestingOut) Addresses() [][]byte {
+	if addressable, ok := s.TransferableOut.(avax.Addressable); ok {
+		return addressable.Addresses()
+	}
+	return nil
+}
+
+// LockedAmount returns the amount of this output still locked at [time]
+func (s *VestingOut) LockedAmount(time uint64) uint64 {
+	return LockedAmount(s.Tranches, time)
+}
+
+func (s *VestingOut) Verify() error {
+	switch s.TransferableOut.(type) {
+	case *LockOut:
+		return errNestedStakeableLocks
+	case *VestingOut:
+		return errNestedVestingOutput
+	}
+	if err := s.TransferableOut.Verify(); err != nil {
+		return err
+	}
+	return verifyTranches(s.Tranches, s.TransferableOut.Amount())
+}
+
+// VestingIn consumes a [VestingOut]. [Tranches] must match the schedule of the
+// consumed output.
+type VestingIn struct {
+	Tranches            []Tranche `serialize:"true" json:"tranches"`
+	avax.TransferableIn `serialize:"true" json:"input"`
+}
+
+func (s *VestingIn) Verify() error {
+	switch s.TransferableIn.(type) {
+	case *LockIn:
+		return errNestedStakeableLocks
+	case *VestingIn:
+		return errNestedVestingOutput
+	}
+	if err := s.TransferableIn.Verify(); err != nil {
+		return err
+	}
+	return verifyTranches(s.Tranches, s.TransferableIn.Amount())
+}
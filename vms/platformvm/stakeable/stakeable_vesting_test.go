@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package stakeable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestVestingOutVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		tranches    []Tranche
+		amount      uint64
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			tranches: []Tranche{
+				{Locktime: 1, Amount: 1},
+				{Locktime: 2, Amount: 2},
+			},
+			amount: 3,
+		},
+		{
+			name:        "no tranches",
+			amount:      3,
+			expectedErr: errNoTranches,
+		},
+		{
+			name:        "too many tranches",
+			tranches:    make([]Tranche, MaxTranches+1),
+			amount:      3,
+			expectedErr: errTooManyTranches,
+		},
+		{
+			name: "empty tranche",
+			tranches: []Tranche{
+				{Locktime: 1, Amount: 0},
+				{Locktime: 2, Amount: 3},
+			},
+			amount:      3,
+			expectedErr: errTrancheHasNoValue,
+		},
+		{
+			name: "unsorted tranches",
+			tranches: []Tranche{
+				{Locktime: 2, Amount: 1},
+				{Locktime: 1, Amount: 2},
+			},
+			amount:      3,
+			expectedErr: errTranchesNotSorted,
+		},
+		{
+			name: "duplicated locktime",
+			tranches: []Tranche{
+				{Locktime: 1, Amount: 1},
+				{Locktime: 1, Amount: 2},
+			},
+			amount:      3,
+			expectedErr: errTranchesNotSorted,
+		},
+		{
+			name: "wrong amount",
+			tranches: []Tranche{
+				{Locktime: 1, Amount: 1},
+				{Locktime: 2, Amount: 1},
+			},
+			amount:      3,
+			expectedErr: errWrongVestingAmount,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := &VestingOut{
+				Tranches: test.tranches,
+				TransferableOut: &secp256k1fx.TransferOutput{
+					Amt: test.amount,
+				},
+			}
+			require.ErrorIs(t, out.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestVestingOutNested(t *testing.T) {
+	require := require.New(t)
+
+	tranches := []Tranche{{Locktime: 1, Amount: 1}}
+	out := &VestingOut{
+		Tranches: tranches,
+		TransferableOut: &LockOut{
+			Locktime: 1,
+			TransferableOut: &secp256k1fx.TransferOutput{
+				Amt: 1,
+			},
+		},
+	}
+	require.ErrorIs(out.Verify(), errNestedStakeableLocks)
+
+	lockOut := &LockOut{
+		Locktime: 1,
+		TransferableOut: &VestingOut{
+			Tranches: tranches,
+			TransferableOut: &secp256k1fx.TransferOutput{
+				Amt: 1,
+			},
+		},
+	}
+	require.ErrorIs(lockOut.Verify(), errNestedVestingOutput)
+}
+
+func TestLinearTranches(t *testing.T) {
+	require := require.New(t)
+
+	tranches, err := LinearTranches(10, 100, 30, 3)
+	require.NoError(err)
+	require.Equal([]Tranche{
+		{Locktime: 100, Amount: 3},
+		{Locktime: 130, Amount: 3},
+		{Locktime: 160, Amount: 4},
+	}, tranches)
+
+	require.EqualValues(10, LockedAmount(tranches, 99))
+	require.EqualValues(7, LockedAmount(tranches, 100))
+	require.EqualValues(4, LockedAmount(tranches, 159))
+	require.EqualValues(0, LockedAmount(tranches, 160))
+	require.Equal(tranches[1:], LockedTranches(tranches, 129))
+	require.Empty(LockedTranches(tranches, 160))
+
+	_, err = LinearTranches(10, 100, 30, 0)
+	require.ErrorIs(err, errInvalidTrancheCount)
+
+	_, err = LinearTranches(2, 100, 30, 3)
+	require.ErrorIs(err, errTrancheHasNoValue)
+}
+
+func TestSplitTranches(t *testing.T) {
+	require := require.New(t)
+
+	tranches := []Tranche{
+		{Locktime: 1, Amount: 1},
+		{Locktime: 2, Amount: 2},
+		{Locktime: 3, Amount: 3},
+	}
+
+	remaining, split := SplitTranches(tranches, 4)
+	require.Equal([]Tranche{
+		{Locktime: 1, Amount: 1},
+		{Locktime: 2, Amount: 1},
+	}, remaining)
+	require.Equal([]Tranche{
+		{Locktime: 2, Amount: 1},
+		{Locktime: 3, Amount: 3},
+	}, split)
+
+	// [tranches] isn't modified
+	require.EqualValues(2, tranches[1].Amount)
+
+	remaining, split = SplitTranches(tranches, 6)
+	require.Empty(remaining)
+	require.Equal(tranches, split)
+
+	remaining, split = SplitTranches(tranches, 0)
+	require.Equal(tranches, remaining)
+	require.Empty(split)
+}
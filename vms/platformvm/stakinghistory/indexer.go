@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package stakinghistory
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/kukrer/savannahnode/codec"
+	"github.com/kukrer/savannahnode/codec/linearcodec"
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/prefixdb"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/utils/wrappers"
+)
+
+const codecVersion = 0
+
+var (
+	_ Indexer = &indexer{}
+	_ Indexer = &noIndexer{}
+
+	periodsPrefix   = []byte("periods")
+	feesPrefix      = []byte("fees")
+	nodeIDsPrefix   = []byte("nodeIDs")
+	addressesPrefix = []byte("addresses")
+
+	// idxKey is, for each node ID and address, the index at which the next
+	// staking period will be written. It is shorter than any index, so it
+	// can't collide with one.
+	idxKey = []byte("idx")
+
+	ErrIndexDisabled = errors.New("staking history index is disabled")
+
+	c codec.Manager
+)
+
+func init() {
+	c = codec.NewDefaultManager()
+	if err := c.RegisterCodec(codecVersion, linearcodec.NewDefault()); err != nil {
+		panic(err)
+	}
+}
+
+// Period describes a staking period that has ended
+type Period struct {
+	// TxID is the ID of the tx that added the staker
+	TxID     ids.ID     `serialize:"true"`
+	NodeID   ids.NodeID `serialize:"true"`
+	SubnetID ids.ID     `serialize:"true"`
+	// Delegator is true if the stake was delegated to [NodeID]
+	Delegator bool `serialize:"true"`
+	// StartTime and EndTime are unix timestamps
+	StartTime uint64 `serialize:"true"`
+	EndTime   uint64 `serialize:"true"`
+	Stake     uint64 `serialize:"true"`
+	// Uptime of [NodeID] when the staker was removed, as measured by this
+	// node, in units of [reward.PercentDenominator]
+	Uptime uint64 `serialize:"true"`
+	// Rewarded is true if the staker received its reward
+	Rewarded bool `serialize:"true"`
+	// Reward is the amount paid to the staker. For delegators, this excludes
+	// the delegation fee.
+	Reward uint64 `serialize:"true"`
+	// DelegationFees are the fees a validator earned from its delegators
+	// during this period
+	DelegationFees uint64 `serialize:"true"`
+	// RewardAddresses are the addresses the rewards were sent to
+	RewardAddresses []ids.ShortID `serialize:"true"`
+}
+
+// Indexer records the staking periods that ended while it was enabled, so
+// they can be looked up by node ID and by reward address.
+type Indexer interface {
+	// AddDelegationFee records that the validator added by [validatorTxID]
+	// earned [fee] when the delegator added by [delegatorTxID] was rewarded.
+	AddDelegationFee(validatorTxID, delegatorTxID ids.ID, fee uint64) error
+
+	// AddPeriod records [period]. The delegation fees previously recorded for
+	// the period are added to it. Adding a period that was already added is
+	// a no-op.
+	AddPeriod(period *Period) error
+
+	// GetNodePeriods returns up to [pageSize] periods of stake validated by,
+	// or delegated to, [nodeID], in the order they ended. The first [cursor]
+	// periods are skipped.
+	GetNodePeriods(nodeID ids.NodeID, cursor, pageSize uint64) ([]*Period, error)
+
+	// GetAddressPeriods returns up to [pageSize] periods whose rewards were
+	// sent to [addr], in the order they ended. The first [cursor] periods are
+	// skipped.
+	GetAddressPeriods(addr ids.ShortID, cursor, pageSize uint64) ([]*Period, error)
+}
+
+// The database structure is:
+// "periods"
+// |  [txID] => period
+// "fees"
+// |  [validatorTxID][delegatorTxID] => fee
+// "nodeIDs"
+// |  [nodeID]
+// |  |  "idx" => 2   Index of the next period
+// |  |  0     => txID1
+// |  |  1     => txID2
+// "addresses"
+// |  [address]
+// |  |  "idx" => 1
+// |  |  0     => txID1
+type indexer struct {
+	periodsDB   database.Database
+	feesDB      database.Database
+	nodeIDsDB   database.Database
+	addressesDB database.Database
+}
+
+func NewIndexer(db database.Database) Indexer {
+	return &indexer{
+		periodsDB:   prefixdb.New(periodsPrefix, db),
+		feesDB:      prefixdb.New(feesPrefix, db),
+		nodeIDsDB:   prefixdb.New(nodeIDsPrefix, db),
+		addressesDB: prefixdb.New(addressesPrefix, db),
+	}
+}
+
+func (i *indexer) AddDelegationFee(validatorTxID, delegatorTxID ids.ID, fee uint64) error {
+	feesDB := prefixdb.New(validatorTxID[:], i.feesDB)
+	return database.PutUInt64(feesDB, delegatorTxID[:], fee)
+}
+
+func (i *indexer) AddPeriod(period *Period) error {
+	has, err := i.periodsDB.Has(period.TxID[:])
+	if err != nil || has {
+		return err
+	}
+
+	if !period.Delegator {
+		fees, err := i.popDelegationFees(period.TxID)
+		if err != nil {
+			return err
+		}
+		period.DelegationFees, err = math.Add64(period.DelegationFees, fees)
+		if err != nil {
+			return err
+		}
+	}
+
+	periodBytes, err := c.Marshal(codecVersion, period)
+	if err != nil {
+		return fmt.Errorf("failed to marshal staking period %s: %w", period.TxID, err)
+	}
+	if err := i.periodsDB.Put(period.TxID[:], periodBytes); err != nil {
+		return err
+	}
+
+	if err := appendTxID(prefixdb.New(period.NodeID[:], i.nodeIDsDB), period.TxID); err != nil {
+		return err
+	}
+	for _, addr := range period.RewardAddresses {
+		if err := appendTxID(prefixdb.New(addr[:], i.addressesDB), period.TxID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *indexer) GetNodePeriods(nodeID ids.NodeID, cursor, pageSize uint64) ([]*Period, error) {
+	return i.getPeriods(prefixdb.New(nodeID[:], i.nodeIDsDB), cursor, pageSize)
+}
+
+func (i *indexer) GetAddressPeriods(addr ids.ShortID, cursor, pageSize uint64) ([]*Period, error) {
+	return i.getPeriods(prefixdb.New(addr[:], i.addressesDB), cursor, pageSize)
+}
+
+// popDelegationFees returns the sum of the delegation fees recorded for
+// [validatorTxID] and removes them.
+func (i *indexer) popDelegationFees(validatorTxID ids.ID) (uint64, error) {
+	feesDB := prefixdb.New(validatorTxID[:], i.feesDB)
+	iter := feesDB.NewIterator()
+	defer iter.Release()
+
+	fees := uint64(0)
+	for iter.Next() {
+		fee, err := database.ParseUInt64(iter.Value())
+		if err != nil {
+			return 0, err
+		}
+		fees, err = math.Add64(fees, fee)
+		if err != nil {
+			return 0, err
+		}
+		if err := feesDB.Delete(iter.Key()); err != nil {
+			return 0, err
+		}
+	}
+	return fees, iter.Error()
+}
+
+func (i *indexer) getPeriods(db database.Database, cursor, pageSize uint64) ([]*Period, error) {
+	cursorBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(cursorBytes, cursor)
+
+	iter := db.NewIteratorWithStart(cursorBytes)
+	defer iter.Release()
+
+	var periods []*Period
+	for uint64(len(periods)) < pageSize && iter.Next() {
+		if len(iter.Key()) != wrappers.LongLen {
+			// This is [idxKey]
+			continue
+		}
+
+		txIDBytes := iter.Value()
+		periodBytes, err := i.periodsDB.Get(txIDBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get staking period %x: %w", txIDBytes, err)
+		}
+
+		period := &Period{}
+		if _, err := c.Unmarshal(periodBytes, period); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal staking period %x: %w", txIDBytes, err)
+		}
+		periods = append(periods, period)
+	}
+	return periods, iter.Error()
+}
+
+// appendTxID writes [txID] at the next index of [db]
+func appendTxID(db database.Database, txID ids.ID) error {
+	idx, err := database.GetUInt64(db, idxKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+
+	idxBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(idxBytes, idx)
+	if err := db.Put(idxBytes, txID[:]); err != nil {
+		return err
+	}
+	return database.PutUInt64(db, idxKey, idx+1)
+}
+
+type noIndexer struct{}
+
+// NewNoIndexer returns an Indexer that doesn't record anything
+func NewNoIndexer() Indexer {
+	return &noIndexer{}
+}
+
+func (*noIndexer) AddDelegationFee(ids.ID, ids.ID, uint64) error { return nil }
+
+func (*noIndexer) AddPeriod(*Period) error { return nil }
+
+func (*noIndexer) GetNodePeriods(ids.NodeID, uint64, uint64) ([]*Period, error) {
+	return nil, ErrIndexDisabled
+}
+
+func (*noIndexer) GetAddressPeriods(ids.ShortID, uint64, uint64) ([]*Period, error) {
+	return nil, ErrIndexDisabled
+}
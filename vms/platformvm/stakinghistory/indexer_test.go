@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package stakinghistory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database/memdb"
+	"github.com/kukrer/savannahnode/ids"
+)
+
+func TestIndexer(t *testing.T) {
+	require := require.New(t)
+
+	i := NewIndexer(memdb.New())
+
+	nodeID := ids.GenerateTestNodeID()
+	validatorAddr := ids.GenerateTestShortID()
+	delegatorAddr := ids.GenerateTestShortID()
+
+	delegatorPeriod := &Period{
+		TxID:            ids.GenerateTestID(),
+		NodeID:          nodeID,
+		Delegator:       true,
+		StartTime:       10,
+		EndTime:         20,
+		Stake:           5,
+		Uptime:          900_000,
+		Rewarded:        true,
+		Reward:          8,
+		RewardAddresses: []ids.ShortID{delegatorAddr},
+	}
+	validatorPeriod := &Period{
+		TxID:            ids.GenerateTestID(),
+		NodeID:          nodeID,
+		StartTime:       5,
+		EndTime:         30,
+		Stake:           10,
+		Uptime:          950_000,
+		Rewarded:        true,
+		Reward:          20,
+		RewardAddresses: []ids.ShortID{validatorAddr},
+	}
+
+	require.NoError(i.AddDelegationFee(validatorPeriod.TxID, delegatorPeriod.TxID, 2))
+	require.NoError(i.AddPeriod(delegatorPeriod))
+	require.NoError(i.AddDelegationFee(validatorPeriod.TxID, ids.GenerateTestID(), 3))
+	require.NoError(i.AddPeriod(validatorPeriod))
+	require.EqualValues(5, validatorPeriod.DelegationFees)
+
+	// Re-adding a period must not duplicate it
+	require.NoError(i.AddPeriod(validatorPeriod))
+
+	periods, err := i.GetNodePeriods(nodeID, 0, 10)
+	require.NoError(err)
+	require.Equal([]*Period{delegatorPeriod, validatorPeriod}, periods)
+
+	periods, err = i.GetNodePeriods(nodeID, 1, 10)
+	require.NoError(err)
+	require.Equal([]*Period{validatorPeriod}, periods)
+
+	periods, err = i.GetNodePeriods(nodeID, 0, 1)
+	require.NoError(err)
+	require.Equal([]*Period{delegatorPeriod}, periods)
+
+	periods, err = i.GetAddressPeriods(validatorAddr, 0, 10)
+	require.NoError(err)
+	require.Equal([]*Period{validatorPeriod}, periods)
+
+	periods, err = i.GetAddressPeriods(delegatorAddr, 0, 10)
+	require.NoError(err)
+	require.Equal([]*Period{delegatorPeriod}, periods)
+
+	periods, err = i.GetNodePeriods(ids.GenerateTestNodeID(), 0, 10)
+	require.NoError(err)
+	require.Empty(periods)
+}
+
+func TestNoIndexer(t *testing.T) {
+	require := require.New(t)
+
+	i := NewNoIndexer()
+	require.NoError(i.AddPeriod(&Period{}))
+
+	_, err := i.GetNodePeriods(ids.GenerateTestNodeID(), 0, 10)
+	require.ErrorIs(err, ErrIndexDisabled)
+
+	_, err = i.GetAddressPeriods(ids.GenerateTestShortID(), 0, 10)
+	require.ErrorIs(err, ErrIndexDisabled)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/kukrer/savannahnode/database"
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/status"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 )
@@ -35,12 +36,29 @@ type diff struct {
 
 	currentSupply uint64
 
+	baseFee uint64
+
+	// map of subnetID -> current supply
+	subnetSupplies map[ids.ID]uint64
+
 	currentStakerDiffs diffStakers
 	pendingStakerDiffs diffStakers
 
 	addedSubnets  []*txs.Tx
 	cachedSubnets []*txs.Tx
 
+	// map of subnetID -> transformSubnetTx
+	transformedSubnets map[ids.ID]*txs.Tx
+
+	// map of subnetID -> the accepted transferSubnetOwnershipTxs
+	addedSubnetOwnershipTransfers map[ids.ID][]*txs.Tx
+
+	// map of subnetID -> owner
+	subnetOwners map[ids.ID]fx.Owner
+
+	// map of validator txID -> the accepted topUpValidatorTxs
+	addedValidatorTopUps map[ids.ID][]*txs.Tx
+
 	addedChains  map[ids.ID][]*txs.Tx
 	cachedChains map[ids.ID][]*txs.Tx
 
@@ -72,6 +90,7 @@ func NewDiff(
 		stateVersions: stateVersions,
 		timestamp:     parentState.GetTimestamp(),
 		currentSupply: parentState.GetCurrentSupply(),
+		baseFee:       parentState.GetBaseFee(),
 	}, nil
 }
 
@@ -91,6 +110,36 @@ func (d *diff) SetCurrentSupply(currentSupply uint64) {
 	d.currentSupply = currentSupply
 }
 
+func (d *diff) GetBaseFee() uint64 {
+	return d.baseFee
+}
+
+func (d *diff) SetBaseFee(baseFee uint64) {
+	d.baseFee = baseFee
+}
+
+func (d *diff) GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error) {
+	if currentSupply, exists := d.subnetSupplies[subnetID]; exists {
+		return currentSupply, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetSubnetCurrentSupply(subnetID)
+}
+
+func (d *diff) SetSubnetCurrentSupply(subnetID ids.ID, currentSupply uint64) {
+	if d.subnetSupplies == nil {
+		d.subnetSupplies = map[ids.ID]uint64{
+			subnetID: currentSupply,
+		}
+	} else {
+		d.subnetSupplies[subnetID] = currentSupply
+	}
+}
+
 func (d *diff) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	// If the validator was modified in this diff, return the modified
 	// validator.
@@ -118,6 +167,15 @@ func (d *diff) DeleteCurrentValidator(staker *Staker) {
 	d.currentStakerDiffs.DeleteValidator(staker)
 }
 
+func (d *diff) UpdateCurrentValidator(staker *Staker) error {
+	oldStaker, err := d.GetCurrentValidator(staker.SubnetID, staker.NodeID)
+	if err != nil {
+		return err
+	}
+	d.currentStakerDiffs.UpdateValidator(oldStaker, staker)
+	return nil
+}
+
 func (d *diff) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	parentState, ok := d.stateVersions.GetState(d.parentID)
 	if !ok {
@@ -254,6 +312,105 @@ func (d *diff) AddSubnet(createSubnetTx *txs.Tx) {
 	}
 }
 
+func (d *diff) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
+	if tx, exists := d.transformedSubnets[subnetID]; exists {
+		return tx, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetSubnetTransformation(subnetID)
+}
+
+func (d *diff) AddSubnetTransformation(transformSubnetTxIntf *txs.Tx) {
+	transformSubnetTx := transformSubnetTxIntf.Unsigned.(*txs.TransformSubnetTx)
+	if d.transformedSubnets == nil {
+		d.transformedSubnets = map[ids.ID]*txs.Tx{
+			transformSubnetTx.Subnet: transformSubnetTxIntf,
+		}
+	} else {
+		d.transformedSubnets[transformSubnetTx.Subnet] = transformSubnetTxIntf
+	}
+}
+
+func (d *diff) GetSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	transfers, err := parentState.GetSubnetOwnershipTransfers(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	addedTransfers := d.addedSubnetOwnershipTransfers[subnetID]
+	if len(addedTransfers) == 0 {
+		return transfers, nil
+	}
+	newTransfers := make([]*txs.Tx, len(transfers), len(transfers)+len(addedTransfers))
+	copy(newTransfers, transfers)
+	return append(newTransfers, addedTransfers...), nil
+}
+
+func (d *diff) AddSubnetOwnershipTransfer(transferSubnetOwnershipTxIntf *txs.Tx) {
+	transferSubnetOwnershipTx := transferSubnetOwnershipTxIntf.Unsigned.(*txs.TransferSubnetOwnershipTx)
+	subnetID := transferSubnetOwnershipTx.Subnet
+	if d.addedSubnetOwnershipTransfers == nil {
+		d.addedSubnetOwnershipTransfers = make(map[ids.ID][]*txs.Tx)
+	}
+	d.addedSubnetOwnershipTransfers[subnetID] = append(d.addedSubnetOwnershipTransfers[subnetID], transferSubnetOwnershipTxIntf)
+}
+
+func (d *diff) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	if owner, exists := d.subnetOwners[subnetID]; exists {
+		return owner, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetSubnetOwner(subnetID)
+}
+
+func (d *diff) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	if d.subnetOwners == nil {
+		d.subnetOwners = map[ids.ID]fx.Owner{
+			subnetID: owner,
+		}
+	} else {
+		d.subnetOwners[subnetID] = owner
+	}
+}
+
+func (d *diff) GetValidatorTopUps(validatorTxID ids.ID) ([]*txs.Tx, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	topUps, err := parentState.GetValidatorTopUps(validatorTxID)
+	if err != nil {
+		return nil, err
+	}
+
+	addedTopUps := d.addedValidatorTopUps[validatorTxID]
+	if len(addedTopUps) == 0 {
+		return topUps, nil
+	}
+	newTopUps := make([]*txs.Tx, len(topUps), len(topUps)+len(addedTopUps))
+	copy(newTopUps, topUps)
+	return append(newTopUps, addedTopUps...), nil
+}
+
+func (d *diff) AddValidatorTopUp(validatorTxID ids.ID, topUpValidatorTx *txs.Tx) {
+	if d.addedValidatorTopUps == nil {
+		d.addedValidatorTopUps = make(map[ids.ID][]*txs.Tx)
+	}
+	d.addedValidatorTopUps[validatorTxID] = append(d.addedValidatorTopUps[validatorTxID], topUpValidatorTx)
+}
+
 func (d *diff) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
 	addedChains := d.addedChains[subnetID]
 	if len(addedChains) == 0 {
@@ -404,12 +561,24 @@ func (d *diff) DeleteUTXO(utxoID ids.ID) {
 func (d *diff) Apply(baseState State) {
 	baseState.SetTimestamp(d.timestamp)
 	baseState.SetCurrentSupply(d.currentSupply)
+	baseState.SetBaseFee(d.baseFee)
+	for subnetID, supply := range d.subnetSupplies {
+		baseState.SetSubnetCurrentSupply(subnetID, supply)
+	}
 	for _, subnetValidatorDiffs := range d.currentStakerDiffs.validatorDiffs {
 		for _, validatorDiff := range subnetValidatorDiffs {
 			if validatorDiff.validatorModified {
-				if validatorDiff.validatorDeleted {
+				switch {
+				case validatorDiff.validatorDeleted && validatorDiff.replacedValidator != nil:
+					// The base state still holds the validator as it was
+					// before it was updated.
+					baseState.DeleteCurrentValidator(validatorDiff.replacedValidator)
+				case validatorDiff.validatorDeleted:
 					baseState.DeleteCurrentValidator(validatorDiff.validator)
-				} else {
+				case validatorDiff.replacedValidator != nil:
+					// The validator is known to exist in the base state.
+					_ = baseState.UpdateCurrentValidator(validatorDiff.validator)
+				default:
 					baseState.PutCurrentValidator(validatorDiff.validator)
 				}
 			}
@@ -449,6 +618,22 @@ func (d *diff) Apply(baseState State) {
 	for _, subnet := range d.addedSubnets {
 		baseState.AddSubnet(subnet)
 	}
+	for _, tx := range d.transformedSubnets {
+		baseState.AddSubnetTransformation(tx)
+	}
+	for _, transfers := range d.addedSubnetOwnershipTransfers {
+		for _, tx := range transfers {
+			baseState.AddSubnetOwnershipTransfer(tx)
+		}
+	}
+	for subnetID, owner := range d.subnetOwners {
+		baseState.SetSubnetOwner(subnetID, owner)
+	}
+	for validatorTxID, topUps := range d.addedValidatorTopUps {
+		for _, tx := range topUps {
+			baseState.AddValidatorTopUp(validatorTxID, tx)
+		}
+	}
 	for _, chains := range d.addedChains {
 		for _, chain := range chains {
 			baseState.AddChain(chain)
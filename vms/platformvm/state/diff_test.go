@@ -68,6 +68,29 @@ func TestDiffCurrentSupply(t *testing.T) {
 	require.Equal(initialCurrentSupply, state.GetCurrentSupply())
 }
 
+func TestDiffBaseFee(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lastAcceptedID := ids.GenerateTestID()
+	state, _ := newInitializedState(require)
+	versions := NewMockVersions(ctrl)
+	versions.EXPECT().GetState(lastAcceptedID).AnyTimes().Return(state, true)
+
+	d, err := NewDiff(lastAcceptedID, versions)
+	require.NoError(err)
+
+	initialBaseFee := d.GetBaseFee()
+	newBaseFee := initialBaseFee + 1
+	d.SetBaseFee(newBaseFee)
+	require.Equal(newBaseFee, d.GetBaseFee())
+	require.Equal(initialBaseFee, state.GetBaseFee())
+
+	d.Apply(state)
+	require.Equal(newBaseFee, state.GetBaseFee())
+}
+
 func TestDiffCurrentValidator(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -78,6 +101,7 @@ func TestDiffCurrentValidator(t *testing.T) {
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetCurrentSupply().Return(uint64(1337)).Times(1)
+	state.EXPECT().GetBaseFee().Return(uint64(1)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	states.EXPECT().GetState(lastAcceptedID).Return(state, true).AnyTimes()
@@ -116,6 +140,7 @@ func TestDiffPendingValidator(t *testing.T) {
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetCurrentSupply().Return(uint64(1337)).Times(1)
+	state.EXPECT().GetBaseFee().Return(uint64(1)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	states.EXPECT().GetState(lastAcceptedID).Return(state, true).AnyTimes()
@@ -159,6 +184,7 @@ func TestDiffCurrentDelegator(t *testing.T) {
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetCurrentSupply().Return(uint64(1337)).Times(1)
+	state.EXPECT().GetBaseFee().Return(uint64(1)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -210,6 +236,7 @@ func TestDiffPendingDelegator(t *testing.T) {
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetCurrentSupply().Return(uint64(1337)).Times(1)
+	state.EXPECT().GetBaseFee().Return(uint64(1)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -255,6 +282,7 @@ func TestDiffSubnet(t *testing.T) {
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetCurrentSupply().Return(uint64(1337)).Times(1)
+	state.EXPECT().GetBaseFee().Return(uint64(1)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -287,6 +315,7 @@ func TestDiffChain(t *testing.T) {
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetCurrentSupply().Return(uint64(1337)).Times(1)
+	state.EXPECT().GetBaseFee().Return(uint64(1)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -328,6 +357,7 @@ func TestDiffTx(t *testing.T) {
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetCurrentSupply().Return(uint64(1337)).Times(1)
+	state.EXPECT().GetBaseFee().Return(uint64(1)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -380,6 +410,7 @@ func TestDiffRewardUTXO(t *testing.T) {
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetCurrentSupply().Return(uint64(1337)).Times(1)
+	state.EXPECT().GetBaseFee().Return(uint64(1)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -427,6 +458,7 @@ func TestDiffUTXO(t *testing.T) {
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetCurrentSupply().Return(uint64(1337)).Times(1)
+	state.EXPECT().GetBaseFee().Return(uint64(1)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -489,6 +521,7 @@ func assertChainsEqual(t *testing.T, expected, actual Chain) {
 
 	require.Equal(t, expected.GetTimestamp(), actual.GetTimestamp())
 	require.Equal(t, expected.GetCurrentSupply(), actual.GetCurrentSupply())
+	require.Equal(t, expected.GetBaseFee(), actual.GetBaseFee())
 
 	expectedSubnets, expectedErr := expected.GetSubnets()
 	actualSubnets, actualErr := actual.GetSubnets()
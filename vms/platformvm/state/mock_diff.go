@@ -10,6 +10,7 @@ import (
 
 	ids "github.com/kukrer/savannahnode/ids"
 	avax "github.com/kukrer/savannahnode/vms/components/avax"
+	fx "github.com/kukrer/savannahnode/vms/platformvm/fx"
 	status "github.com/kukrer/savannahnode/vms/platformvm/status"
 	txs "github.com/kukrer/savannahnode/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -74,6 +75,30 @@ func (mr *MockDiffMockRecorder) AddSubnet(createSubnetTx interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnet", reflect.TypeOf((*MockDiff)(nil).AddSubnet), createSubnetTx)
 }
 
+// AddSubnetOwnershipTransfer mocks base method.
+func (m *MockDiff) AddSubnetOwnershipTransfer(transferSubnetOwnershipTx *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubnetOwnershipTransfer", transferSubnetOwnershipTx)
+}
+
+// AddSubnetOwnershipTransfer indicates an expected call of AddSubnetOwnershipTransfer.
+func (mr *MockDiffMockRecorder) AddSubnetOwnershipTransfer(transferSubnetOwnershipTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnetOwnershipTransfer", reflect.TypeOf((*MockDiff)(nil).AddSubnetOwnershipTransfer), transferSubnetOwnershipTx)
+}
+
+// AddSubnetTransformation mocks base method.
+func (m *MockDiff) AddSubnetTransformation(transformSubnetTx *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubnetTransformation", transformSubnetTx)
+}
+
+// AddSubnetTransformation indicates an expected call of AddSubnetTransformation.
+func (mr *MockDiffMockRecorder) AddSubnetTransformation(transformSubnetTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnetTransformation", reflect.TypeOf((*MockDiff)(nil).AddSubnetTransformation), transformSubnetTx)
+}
+
 // AddTx mocks base method.
 func (m *MockDiff) AddTx(tx *txs.Tx, status status.Status) {
 	m.ctrl.T.Helper()
@@ -98,6 +123,18 @@ func (mr *MockDiffMockRecorder) AddUTXO(utxo interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUTXO", reflect.TypeOf((*MockDiff)(nil).AddUTXO), utxo)
 }
 
+// AddValidatorTopUp mocks base method.
+func (m *MockDiff) AddValidatorTopUp(validatorTxID ids.ID, topUpValidatorTx *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddValidatorTopUp", validatorTxID, topUpValidatorTx)
+}
+
+// AddValidatorTopUp indicates an expected call of AddValidatorTopUp.
+func (mr *MockDiffMockRecorder) AddValidatorTopUp(validatorTxID interface{}, topUpValidatorTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddValidatorTopUp", reflect.TypeOf((*MockDiff)(nil).AddValidatorTopUp), validatorTxID, topUpValidatorTx)
+}
+
 // Apply mocks base method.
 func (m *MockDiff) Apply(arg0 State) {
 	m.ctrl.T.Helper()
@@ -170,6 +207,20 @@ func (mr *MockDiffMockRecorder) DeleteUTXO(utxoID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockDiff)(nil).DeleteUTXO), utxoID)
 }
 
+// GetBaseFee mocks base method.
+func (m *MockDiff) GetBaseFee() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBaseFee")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetBaseFee indicates an expected call of GetBaseFee.
+func (mr *MockDiffMockRecorder) GetBaseFee() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseFee", reflect.TypeOf((*MockDiff)(nil).GetBaseFee))
+}
+
 // GetChains mocks base method.
 func (m *MockDiff) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -304,6 +355,66 @@ func (mr *MockDiffMockRecorder) GetRewardUTXOs(txID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockDiff)(nil).GetRewardUTXOs), txID)
 }
 
+// GetSubnetCurrentSupply mocks base method.
+func (m *MockDiff) GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetCurrentSupply", subnetID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetCurrentSupply indicates an expected call of GetSubnetCurrentSupply.
+func (mr *MockDiffMockRecorder) GetSubnetCurrentSupply(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetCurrentSupply", reflect.TypeOf((*MockDiff)(nil).GetSubnetCurrentSupply), subnetID)
+}
+
+// GetSubnetOwner mocks base method.
+func (m *MockDiff) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwner", subnetID)
+	ret0, _ := ret[0].(fx.Owner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwner indicates an expected call of GetSubnetOwner.
+func (mr *MockDiffMockRecorder) GetSubnetOwner(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockDiff)(nil).GetSubnetOwner), subnetID)
+}
+
+// GetSubnetOwnershipTransfers mocks base method.
+func (m *MockDiff) GetSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwnershipTransfers", subnetID)
+	ret0, _ := ret[0].([]*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwnershipTransfers indicates an expected call of GetSubnetOwnershipTransfers.
+func (mr *MockDiffMockRecorder) GetSubnetOwnershipTransfers(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwnershipTransfers", reflect.TypeOf((*MockDiff)(nil).GetSubnetOwnershipTransfers), subnetID)
+}
+
+// GetSubnetTransformation mocks base method.
+func (m *MockDiff) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetTransformation", subnetID)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetTransformation indicates an expected call of GetSubnetTransformation.
+func (mr *MockDiffMockRecorder) GetSubnetTransformation(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockDiff)(nil).GetSubnetTransformation), subnetID)
+}
+
 // GetSubnets mocks base method.
 func (m *MockDiff) GetSubnets() ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -364,6 +475,21 @@ func (mr *MockDiffMockRecorder) GetUTXO(utxoID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockDiff)(nil).GetUTXO), utxoID)
 }
 
+// GetValidatorTopUps mocks base method.
+func (m *MockDiff) GetValidatorTopUps(validatorTxID ids.ID) ([]*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorTopUps", validatorTxID)
+	ret0, _ := ret[0].([]*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorTopUps indicates an expected call of GetValidatorTopUps.
+func (mr *MockDiffMockRecorder) GetValidatorTopUps(validatorTxID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorTopUps", reflect.TypeOf((*MockDiff)(nil).GetValidatorTopUps), validatorTxID)
+}
+
 // PutCurrentDelegator mocks base method.
 func (m *MockDiff) PutCurrentDelegator(staker *Staker) {
 	m.ctrl.T.Helper()
@@ -412,6 +538,18 @@ func (mr *MockDiffMockRecorder) PutPendingValidator(staker interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingValidator", reflect.TypeOf((*MockDiff)(nil).PutPendingValidator), staker)
 }
 
+// SetBaseFee mocks base method.
+func (m *MockDiff) SetBaseFee(baseFee uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBaseFee", baseFee)
+}
+
+// SetBaseFee indicates an expected call of SetBaseFee.
+func (mr *MockDiffMockRecorder) SetBaseFee(baseFee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBaseFee", reflect.TypeOf((*MockDiff)(nil).SetBaseFee), baseFee)
+}
+
 // SetCurrentSupply mocks base method.
 func (m *MockDiff) SetCurrentSupply(cs uint64) {
 	m.ctrl.T.Helper()
@@ -424,6 +562,30 @@ func (mr *MockDiffMockRecorder) SetCurrentSupply(cs interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockDiff)(nil).SetCurrentSupply), cs)
 }
 
+// SetSubnetCurrentSupply mocks base method.
+func (m *MockDiff) SetSubnetCurrentSupply(subnetID ids.ID, cs uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetCurrentSupply", subnetID, cs)
+}
+
+// SetSubnetCurrentSupply indicates an expected call of SetSubnetCurrentSupply.
+func (mr *MockDiffMockRecorder) SetSubnetCurrentSupply(subnetID interface{}, cs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetCurrentSupply", reflect.TypeOf((*MockDiff)(nil).SetSubnetCurrentSupply), subnetID, cs)
+}
+
+// SetSubnetOwner mocks base method.
+func (m *MockDiff) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetOwner", subnetID, owner)
+}
+
+// SetSubnetOwner indicates an expected call of SetSubnetOwner.
+func (mr *MockDiffMockRecorder) SetSubnetOwner(subnetID, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockDiff)(nil).SetSubnetOwner), subnetID, owner)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockDiff) SetTimestamp(tm time.Time) {
 	m.ctrl.T.Helper()
@@ -435,3 +597,17 @@ func (mr *MockDiffMockRecorder) SetTimestamp(tm interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockDiff)(nil).SetTimestamp), tm)
 }
+
+// UpdateCurrentValidator mocks base method.
+func (m *MockDiff) UpdateCurrentValidator(staker *Staker) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurrentValidator", staker)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockDiffMockRecorder) UpdateCurrentValidator(staker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockDiff)(nil).UpdateCurrentValidator), staker)
+}
@@ -12,8 +12,10 @@ import (
 	ids "github.com/kukrer/savannahnode/ids"
 	choices "github.com/kukrer/savannahnode/snow/choices"
 	validators "github.com/kukrer/savannahnode/snow/validators"
+	bls "github.com/kukrer/savannahnode/utils/crypto/bls"
 	avax "github.com/kukrer/savannahnode/vms/components/avax"
 	blocks "github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	fx "github.com/kukrer/savannahnode/vms/platformvm/fx"
 	status "github.com/kukrer/savannahnode/vms/platformvm/status"
 	txs "github.com/kukrer/savannahnode/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -78,6 +80,30 @@ func (mr *MockChainMockRecorder) AddSubnet(createSubnetTx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnet", reflect.TypeOf((*MockChain)(nil).AddSubnet), createSubnetTx)
 }
 
+// AddSubnetOwnershipTransfer mocks base method.
+func (m *MockChain) AddSubnetOwnershipTransfer(transferSubnetOwnershipTx *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubnetOwnershipTransfer", transferSubnetOwnershipTx)
+}
+
+// AddSubnetOwnershipTransfer indicates an expected call of AddSubnetOwnershipTransfer.
+func (mr *MockChainMockRecorder) AddSubnetOwnershipTransfer(transferSubnetOwnershipTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnetOwnershipTransfer", reflect.TypeOf((*MockChain)(nil).AddSubnetOwnershipTransfer), transferSubnetOwnershipTx)
+}
+
+// AddSubnetTransformation mocks base method.
+func (m *MockChain) AddSubnetTransformation(transformSubnetTx *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubnetTransformation", transformSubnetTx)
+}
+
+// AddSubnetTransformation indicates an expected call of AddSubnetTransformation.
+func (mr *MockChainMockRecorder) AddSubnetTransformation(transformSubnetTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnetTransformation", reflect.TypeOf((*MockChain)(nil).AddSubnetTransformation), transformSubnetTx)
+}
+
 // AddTx mocks base method.
 func (m *MockChain) AddTx(tx *txs.Tx, status status.Status) {
 	m.ctrl.T.Helper()
@@ -102,6 +128,18 @@ func (mr *MockChainMockRecorder) AddUTXO(utxo interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUTXO", reflect.TypeOf((*MockChain)(nil).AddUTXO), utxo)
 }
 
+// AddValidatorTopUp mocks base method.
+func (m *MockChain) AddValidatorTopUp(validatorTxID ids.ID, topUpValidatorTx *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddValidatorTopUp", validatorTxID, topUpValidatorTx)
+}
+
+// AddValidatorTopUp indicates an expected call of AddValidatorTopUp.
+func (mr *MockChainMockRecorder) AddValidatorTopUp(validatorTxID interface{}, topUpValidatorTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddValidatorTopUp", reflect.TypeOf((*MockChain)(nil).AddValidatorTopUp), validatorTxID, topUpValidatorTx)
+}
+
 // DeleteCurrentDelegator mocks base method.
 func (m *MockChain) DeleteCurrentDelegator(staker *Staker) {
 	m.ctrl.T.Helper()
@@ -162,6 +200,20 @@ func (mr *MockChainMockRecorder) DeleteUTXO(utxoID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockChain)(nil).DeleteUTXO), utxoID)
 }
 
+// GetBaseFee mocks base method.
+func (m *MockChain) GetBaseFee() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBaseFee")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetBaseFee indicates an expected call of GetBaseFee.
+func (mr *MockChainMockRecorder) GetBaseFee() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseFee", reflect.TypeOf((*MockChain)(nil).GetBaseFee))
+}
+
 // GetChains mocks base method.
 func (m *MockChain) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -296,6 +348,66 @@ func (mr *MockChainMockRecorder) GetRewardUTXOs(txID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockChain)(nil).GetRewardUTXOs), txID)
 }
 
+// GetSubnetCurrentSupply mocks base method.
+func (m *MockChain) GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetCurrentSupply", subnetID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetCurrentSupply indicates an expected call of GetSubnetCurrentSupply.
+func (mr *MockChainMockRecorder) GetSubnetCurrentSupply(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetCurrentSupply", reflect.TypeOf((*MockChain)(nil).GetSubnetCurrentSupply), subnetID)
+}
+
+// GetSubnetOwner mocks base method.
+func (m *MockChain) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwner", subnetID)
+	ret0, _ := ret[0].(fx.Owner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwner indicates an expected call of GetSubnetOwner.
+func (mr *MockChainMockRecorder) GetSubnetOwner(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockChain)(nil).GetSubnetOwner), subnetID)
+}
+
+// GetSubnetOwnershipTransfers mocks base method.
+func (m *MockChain) GetSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwnershipTransfers", subnetID)
+	ret0, _ := ret[0].([]*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwnershipTransfers indicates an expected call of GetSubnetOwnershipTransfers.
+func (mr *MockChainMockRecorder) GetSubnetOwnershipTransfers(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwnershipTransfers", reflect.TypeOf((*MockChain)(nil).GetSubnetOwnershipTransfers), subnetID)
+}
+
+// GetSubnetTransformation mocks base method.
+func (m *MockChain) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetTransformation", subnetID)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetTransformation indicates an expected call of GetSubnetTransformation.
+func (mr *MockChainMockRecorder) GetSubnetTransformation(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockChain)(nil).GetSubnetTransformation), subnetID)
+}
+
 // GetSubnets mocks base method.
 func (m *MockChain) GetSubnets() ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -356,6 +468,21 @@ func (mr *MockChainMockRecorder) GetUTXO(utxoID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockChain)(nil).GetUTXO), utxoID)
 }
 
+// GetValidatorTopUps mocks base method.
+func (m *MockChain) GetValidatorTopUps(validatorTxID ids.ID) ([]*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorTopUps", validatorTxID)
+	ret0, _ := ret[0].([]*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorTopUps indicates an expected call of GetValidatorTopUps.
+func (mr *MockChainMockRecorder) GetValidatorTopUps(validatorTxID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorTopUps", reflect.TypeOf((*MockChain)(nil).GetValidatorTopUps), validatorTxID)
+}
+
 // PutCurrentDelegator mocks base method.
 func (m *MockChain) PutCurrentDelegator(staker *Staker) {
 	m.ctrl.T.Helper()
@@ -404,6 +531,18 @@ func (mr *MockChainMockRecorder) PutPendingValidator(staker interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingValidator", reflect.TypeOf((*MockChain)(nil).PutPendingValidator), staker)
 }
 
+// SetBaseFee mocks base method.
+func (m *MockChain) SetBaseFee(baseFee uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBaseFee", baseFee)
+}
+
+// SetBaseFee indicates an expected call of SetBaseFee.
+func (mr *MockChainMockRecorder) SetBaseFee(baseFee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBaseFee", reflect.TypeOf((*MockChain)(nil).SetBaseFee), baseFee)
+}
+
 // SetCurrentSupply mocks base method.
 func (m *MockChain) SetCurrentSupply(cs uint64) {
 	m.ctrl.T.Helper()
@@ -416,6 +555,30 @@ func (mr *MockChainMockRecorder) SetCurrentSupply(cs interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockChain)(nil).SetCurrentSupply), cs)
 }
 
+// SetSubnetCurrentSupply mocks base method.
+func (m *MockChain) SetSubnetCurrentSupply(subnetID ids.ID, cs uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetCurrentSupply", subnetID, cs)
+}
+
+// SetSubnetCurrentSupply indicates an expected call of SetSubnetCurrentSupply.
+func (mr *MockChainMockRecorder) SetSubnetCurrentSupply(subnetID interface{}, cs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetCurrentSupply", reflect.TypeOf((*MockChain)(nil).SetSubnetCurrentSupply), subnetID, cs)
+}
+
+// SetSubnetOwner mocks base method.
+func (m *MockChain) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetOwner", subnetID, owner)
+}
+
+// SetSubnetOwner indicates an expected call of SetSubnetOwner.
+func (mr *MockChainMockRecorder) SetSubnetOwner(subnetID, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockChain)(nil).SetSubnetOwner), subnetID, owner)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockChain) SetTimestamp(tm time.Time) {
 	m.ctrl.T.Helper()
@@ -428,6 +591,20 @@ func (mr *MockChainMockRecorder) SetTimestamp(tm interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockChain)(nil).SetTimestamp), tm)
 }
 
+// UpdateCurrentValidator mocks base method.
+func (m *MockChain) UpdateCurrentValidator(staker *Staker) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurrentValidator", staker)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockChainMockRecorder) UpdateCurrentValidator(staker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockChain)(nil).UpdateCurrentValidator), staker)
+}
+
 // MockLastAccepteder is a mock of LastAccepteder interface.
 type MockLastAccepteder struct {
 	ctrl     *gomock.Controller
@@ -611,6 +788,30 @@ func (mr *MockStateMockRecorder) AddSubnet(createSubnetTx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnet", reflect.TypeOf((*MockState)(nil).AddSubnet), createSubnetTx)
 }
 
+// AddSubnetOwnershipTransfer mocks base method.
+func (m *MockState) AddSubnetOwnershipTransfer(transferSubnetOwnershipTx *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubnetOwnershipTransfer", transferSubnetOwnershipTx)
+}
+
+// AddSubnetOwnershipTransfer indicates an expected call of AddSubnetOwnershipTransfer.
+func (mr *MockStateMockRecorder) AddSubnetOwnershipTransfer(transferSubnetOwnershipTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnetOwnershipTransfer", reflect.TypeOf((*MockState)(nil).AddSubnetOwnershipTransfer), transferSubnetOwnershipTx)
+}
+
+// AddSubnetTransformation mocks base method.
+func (m *MockState) AddSubnetTransformation(transformSubnetTx *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubnetTransformation", transformSubnetTx)
+}
+
+// AddSubnetTransformation indicates an expected call of AddSubnetTransformation.
+func (mr *MockStateMockRecorder) AddSubnetTransformation(transformSubnetTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnetTransformation", reflect.TypeOf((*MockState)(nil).AddSubnetTransformation), transformSubnetTx)
+}
+
 // AddTx mocks base method.
 func (m *MockState) AddTx(tx *txs.Tx, status status.Status) {
 	m.ctrl.T.Helper()
@@ -635,6 +836,32 @@ func (mr *MockStateMockRecorder) AddUTXO(utxo interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUTXO", reflect.TypeOf((*MockState)(nil).AddUTXO), utxo)
 }
 
+// AddValidatorTopUp mocks base method.
+func (m *MockState) AddValidatorTopUp(validatorTxID ids.ID, topUpValidatorTx *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddValidatorTopUp", validatorTxID, topUpValidatorTx)
+}
+
+// AddValidatorTopUp indicates an expected call of AddValidatorTopUp.
+func (mr *MockStateMockRecorder) AddValidatorTopUp(validatorTxID interface{}, topUpValidatorTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddValidatorTopUp", reflect.TypeOf((*MockState)(nil).AddValidatorTopUp), validatorTxID, topUpValidatorTx)
+}
+
+// ApplySyncRecords mocks base method.
+func (m *MockState) ApplySyncRecords(arg0 database.Iterator, arg1 blocks.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySyncRecords", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplySyncRecords indicates an expected call of ApplySyncRecords.
+func (mr *MockStateMockRecorder) ApplySyncRecords(arg0 interface{}, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySyncRecords", reflect.TypeOf((*MockState)(nil).ApplySyncRecords), arg0, arg1)
+}
+
 // Close mocks base method.
 func (m *MockState) Close() error {
 	m.ctrl.T.Helper()
@@ -738,6 +965,20 @@ func (mr *MockStateMockRecorder) DeleteUTXO(utxoID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), utxoID)
 }
 
+// GetBaseFee mocks base method.
+func (m *MockState) GetBaseFee() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBaseFee")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetBaseFee indicates an expected call of GetBaseFee.
+func (mr *MockStateMockRecorder) GetBaseFee() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseFee", reflect.TypeOf((*MockState)(nil).GetBaseFee))
+}
+
 // GetChains mocks base method.
 func (m *MockState) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -871,6 +1112,20 @@ func (mr *MockStateMockRecorder) GetPendingValidator(subnetID, nodeID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockState)(nil).GetPendingValidator), subnetID, nodeID)
 }
 
+// GetPrunedHeight mocks base method.
+func (m *MockState) GetPrunedHeight() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrunedHeight")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetPrunedHeight indicates an expected call of GetPrunedHeight.
+func (mr *MockStateMockRecorder) GetPrunedHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrunedHeight", reflect.TypeOf((*MockState)(nil).GetPrunedHeight))
+}
+
 // GetRewardUTXOs mocks base method.
 func (m *MockState) GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error) {
 	m.ctrl.T.Helper()
@@ -917,6 +1172,66 @@ func (mr *MockStateMockRecorder) GetStatelessBlock(blockID interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatelessBlock", reflect.TypeOf((*MockState)(nil).GetStatelessBlock), blockID)
 }
 
+// GetSubnetCurrentSupply mocks base method.
+func (m *MockState) GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetCurrentSupply", subnetID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetCurrentSupply indicates an expected call of GetSubnetCurrentSupply.
+func (mr *MockStateMockRecorder) GetSubnetCurrentSupply(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetCurrentSupply", reflect.TypeOf((*MockState)(nil).GetSubnetCurrentSupply), subnetID)
+}
+
+// GetSubnetOwner mocks base method.
+func (m *MockState) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwner", subnetID)
+	ret0, _ := ret[0].(fx.Owner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwner indicates an expected call of GetSubnetOwner.
+func (mr *MockStateMockRecorder) GetSubnetOwner(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockState)(nil).GetSubnetOwner), subnetID)
+}
+
+// GetSubnetOwnershipTransfers mocks base method.
+func (m *MockState) GetSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwnershipTransfers", subnetID)
+	ret0, _ := ret[0].([]*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwnershipTransfers indicates an expected call of GetSubnetOwnershipTransfers.
+func (mr *MockStateMockRecorder) GetSubnetOwnershipTransfers(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwnershipTransfers", reflect.TypeOf((*MockState)(nil).GetSubnetOwnershipTransfers), subnetID)
+}
+
+// GetSubnetTransformation mocks base method.
+func (m *MockState) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetTransformation", subnetID)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetTransformation indicates an expected call of GetSubnetTransformation.
+func (mr *MockStateMockRecorder) GetSubnetTransformation(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockState)(nil).GetSubnetTransformation), subnetID)
+}
+
 // GetSubnets mocks base method.
 func (m *MockState) GetSubnets() ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -932,6 +1247,21 @@ func (mr *MockStateMockRecorder) GetSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnets", reflect.TypeOf((*MockState)(nil).GetSubnets))
 }
 
+// GetSupplyBreakdown mocks base method.
+func (m *MockState) GetSupplyBreakdown() (SupplyBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplyBreakdown")
+	ret0, _ := ret[0].(SupplyBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplyBreakdown indicates an expected call of GetSupplyBreakdown.
+func (mr *MockStateMockRecorder) GetSupplyBreakdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplyBreakdown", reflect.TypeOf((*MockState)(nil).GetSupplyBreakdown))
+}
+
 // GetTimestamp mocks base method.
 func (m *MockState) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -993,6 +1323,36 @@ func (mr *MockStateMockRecorder) GetUptime(nodeID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptime", reflect.TypeOf((*MockState)(nil).GetUptime), nodeID)
 }
 
+// GetValidatorPublicKeyDiffs mocks base method.
+func (m *MockState) GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorPublicKeyDiffs", height)
+	ret0, _ := ret[0].(map[ids.NodeID]*bls.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorPublicKeyDiffs indicates an expected call of GetValidatorPublicKeyDiffs.
+func (mr *MockStateMockRecorder) GetValidatorPublicKeyDiffs(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorPublicKeyDiffs", reflect.TypeOf((*MockState)(nil).GetValidatorPublicKeyDiffs), height)
+}
+
+// GetValidatorTopUps mocks base method.
+func (m *MockState) GetValidatorTopUps(validatorTxID ids.ID) ([]*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorTopUps", validatorTxID)
+	ret0, _ := ret[0].([]*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorTopUps indicates an expected call of GetValidatorTopUps.
+func (mr *MockStateMockRecorder) GetValidatorTopUps(validatorTxID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorTopUps", reflect.TypeOf((*MockState)(nil).GetValidatorTopUps), validatorTxID)
+}
+
 // GetValidatorWeightDiffs mocks base method.
 func (m *MockState) GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids.NodeID]*ValidatorWeightDiff, error) {
 	m.ctrl.T.Helper()
@@ -1008,6 +1368,35 @@ func (mr *MockStateMockRecorder) GetValidatorWeightDiffs(height, subnetID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorWeightDiffs", reflect.TypeOf((*MockState)(nil).GetValidatorWeightDiffs), height, subnetID)
 }
 
+// NewSyncSnapshot mocks base method.
+func (m *MockState) NewSyncSnapshot() (SyncSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewSyncSnapshot")
+	ret0, _ := ret[0].(SyncSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewSyncSnapshot indicates an expected call of NewSyncSnapshot.
+func (mr *MockStateMockRecorder) NewSyncSnapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSyncSnapshot", reflect.TypeOf((*MockState)(nil).NewSyncSnapshot))
+}
+
+// Prune mocks base method.
+func (m *MockState) Prune(arg0 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockStateMockRecorder) Prune(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockState)(nil).Prune), arg0)
+}
+
 // PutCurrentDelegator mocks base method.
 func (m *MockState) PutCurrentDelegator(staker *Staker) {
 	m.ctrl.T.Helper()
@@ -1056,6 +1445,18 @@ func (mr *MockStateMockRecorder) PutPendingValidator(staker interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingValidator", reflect.TypeOf((*MockState)(nil).PutPendingValidator), staker)
 }
 
+// SetBaseFee mocks base method.
+func (m *MockState) SetBaseFee(baseFee uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBaseFee", baseFee)
+}
+
+// SetBaseFee indicates an expected call of SetBaseFee.
+func (mr *MockStateMockRecorder) SetBaseFee(baseFee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBaseFee", reflect.TypeOf((*MockState)(nil).SetBaseFee), baseFee)
+}
+
 // SetCurrentSupply mocks base method.
 func (m *MockState) SetCurrentSupply(cs uint64) {
 	m.ctrl.T.Helper()
@@ -1092,6 +1493,30 @@ func (mr *MockStateMockRecorder) SetLastAccepted(blkID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAccepted", reflect.TypeOf((*MockState)(nil).SetLastAccepted), blkID)
 }
 
+// SetSubnetCurrentSupply mocks base method.
+func (m *MockState) SetSubnetCurrentSupply(subnetID ids.ID, cs uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetCurrentSupply", subnetID, cs)
+}
+
+// SetSubnetCurrentSupply indicates an expected call of SetSubnetCurrentSupply.
+func (mr *MockStateMockRecorder) SetSubnetCurrentSupply(subnetID interface{}, cs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetCurrentSupply", reflect.TypeOf((*MockState)(nil).SetSubnetCurrentSupply), subnetID, cs)
+}
+
+// SetSubnetOwner mocks base method.
+func (m *MockState) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetOwner", subnetID, owner)
+}
+
+// SetSubnetOwner indicates an expected call of SetSubnetOwner.
+func (mr *MockStateMockRecorder) SetSubnetOwner(subnetID, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockState)(nil).SetSubnetOwner), subnetID, owner)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockState) SetTimestamp(tm time.Time) {
 	m.ctrl.T.Helper()
@@ -1133,6 +1558,20 @@ func (mr *MockStateMockRecorder) UTXOIDs(addr, previous, limit interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTXOIDs", reflect.TypeOf((*MockState)(nil).UTXOIDs), addr, previous, limit)
 }
 
+// UpdateCurrentValidator mocks base method.
+func (m *MockState) UpdateCurrentValidator(staker *Staker) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurrentValidator", staker)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockStateMockRecorder) UpdateCurrentValidator(staker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockState)(nil).UpdateCurrentValidator), staker)
+}
+
 // ValidatorSet mocks base method.
 func (m *MockState) ValidatorSet(subnetID ids.ID) (validators.Set, error) {
 	m.ctrl.T.Helper()
@@ -1147,3 +1586,17 @@ func (mr *MockStateMockRecorder) ValidatorSet(subnetID interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorSet", reflect.TypeOf((*MockState)(nil).ValidatorSet), subnetID)
 }
+
+// WriteSyncRecords mocks base method.
+func (m *MockState) WriteSyncRecords(arg0 database.KeyValueWriter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSyncRecords", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteSyncRecords indicates an expected call of WriteSyncRecords.
+func (mr *MockStateMockRecorder) WriteSyncRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSyncRecords", reflect.TypeOf((*MockState)(nil).WriteSyncRecords), arg0)
+}
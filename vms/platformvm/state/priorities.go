@@ -4,10 +4,16 @@
 package state
 
 const (
-	// First subnet delegators are removed from the current validator set,
-	SubnetDelegatorCurrentPriority Priority = iota + 1
-	// then subnet validators,
-	SubnetValidatorCurrentPriority
+	// First permissioned subnet validators are removed from the current
+	// validator set,
+	// Invariant: All permissioned stakers must be removed first because they
+	//            are removed by the advancement of time. Permissionless stakers
+	//            are removed with a RewardValidatorTx after time has advanced.
+	SubnetValidatorCurrentPriority Priority = iota + 1
+	// then permissionless subnet delegators,
+	SubnetPermissionlessDelegatorCurrentPriority
+	// then permissionless subnet validators,
+	SubnetPermissionlessValidatorCurrentPriority
 	// then primary network delegators,
 	PrimaryNetworkDelegatorCurrentPriority
 	// then primary network validators.
@@ -20,17 +26,35 @@ const (
 	PrimaryNetworkDelegatorPendingPriority Priority = iota + 1
 	// then primary network validators,
 	PrimaryNetworkValidatorPendingPriority
-	// then subnet validators,
+	// then permissioned subnet validators,
 	SubnetValidatorPendingPriority
-	// then subnet delegators.
-	SubnetDelegatorPendingPriority
+	// then permissionless subnet validators,
+	SubnetPermissionlessValidatorPendingPriority
+	// then permissionless subnet delegators.
+	SubnetPermissionlessDelegatorPendingPriority
 )
 
 var PendingToCurrentPriorities = []Priority{
-	PrimaryNetworkValidatorPendingPriority: PrimaryNetworkValidatorCurrentPriority,
-	PrimaryNetworkDelegatorPendingPriority: PrimaryNetworkDelegatorCurrentPriority,
-	SubnetValidatorPendingPriority:         SubnetValidatorCurrentPriority,
-	SubnetDelegatorPendingPriority:         SubnetDelegatorCurrentPriority,
+	PrimaryNetworkValidatorPendingPriority:       PrimaryNetworkValidatorCurrentPriority,
+	PrimaryNetworkDelegatorPendingPriority:       PrimaryNetworkDelegatorCurrentPriority,
+	SubnetValidatorPendingPriority:               SubnetValidatorCurrentPriority,
+	SubnetPermissionlessValidatorPendingPriority: SubnetPermissionlessValidatorCurrentPriority,
+	SubnetPermissionlessDelegatorPendingPriority: SubnetPermissionlessDelegatorCurrentPriority,
 }
 
 type Priority byte
+
+// IsRewarded returns true if stakers with this priority must be removed from
+// the current staker set with a RewardValidatorTx rather than by the
+// advancement of time.
+func (p Priority) IsRewarded() bool {
+	switch p {
+	case SubnetPermissionlessDelegatorCurrentPriority,
+		SubnetPermissionlessValidatorCurrentPriority,
+		PrimaryNetworkDelegatorCurrentPriority,
+		PrimaryNetworkValidatorCurrentPriority:
+		return true
+	default:
+		return false
+	}
+}
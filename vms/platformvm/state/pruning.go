@@ -0,0 +1,279 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/linkeddb"
+	"github.com/kukrer/savannahnode/database/prefixdb"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+)
+
+// maxPrunedHeights is the maximum number of heights pruned by a single call to
+// Prune, so that pruning a long history doesn't stall the acceptance of blocks.
+const maxPrunedHeights = 4096
+
+var (
+	blockHeightsIndexedKey     = []byte("block heights indexed")
+	blockHeightsIndexCursorKey = []byte("block heights index cursor")
+)
+
+func (s *state) GetPrunedHeight() uint64 {
+	return s.prunedHeight
+}
+
+func (s *state) Prune(height uint64) error {
+	// The last accepted block is never pruned.
+	if height >= s.currentHeight {
+		if s.currentHeight == 0 {
+			return nil
+		}
+		height = s.currentHeight - 1
+	}
+	if height <= s.prunedHeight {
+		return nil
+	}
+	if height-s.prunedHeight > maxPrunedHeights {
+		height = s.prunedHeight + maxPrunedHeights
+	}
+
+	defer s.Abort()
+	indexed, err := s.indexBlockHeights()
+	if err != nil {
+		return fmt.Errorf("failed to index block heights: %w", err)
+	}
+	if !indexed {
+		// The heights to prune may not be indexed yet. Pruning starts once all
+		// the accepted blocks are indexed.
+		return s.baseDB.Commit()
+	}
+
+	referencedTxIDs, err := s.referencedTxIDs()
+	if err != nil {
+		return fmt.Errorf("failed to find referenced txs: %w", err)
+	}
+	subnetIDs, err := s.subnetIDs()
+	if err != nil {
+		return err
+	}
+	for prunedHeight := s.prunedHeight + 1; prunedHeight <= height; prunedHeight++ {
+		if err := s.pruneHeight(prunedHeight, subnetIDs, referencedTxIDs); err != nil {
+			return fmt.Errorf("failed to prune height %d: %w", prunedHeight, err)
+		}
+	}
+	if err := database.PutUInt64(s.singletonDB, prunedHeightKey, height); err != nil {
+		return err
+	}
+	if err := s.baseDB.Commit(); err != nil {
+		return err
+	}
+	s.prunedHeight = height
+
+	// The pruned entries may have been cached.
+	s.blockCache.Flush()
+	s.txCache.Flush()
+	s.validatorDiffsCache.Flush()
+	s.publicKeyDiffsCache.Flush()
+	return nil
+}
+
+// pruneHeight removes the accepted block at [height], its txs that aren't in
+// [referencedTxIDs] and the validator set diffs of [subnetIDs] at [height].
+func (s *state) pruneHeight(height uint64, subnetIDs []ids.ID, referencedTxIDs ids.Set) error {
+	heightKey := database.PackUInt64(height)
+	blkID, err := database.GetID(s.blockIDDB, heightKey)
+	switch err {
+	case nil:
+		blk, _, err := s.GetStatelessBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("failed to get block %s: %w", blkID, err)
+		}
+		for _, tx := range blk.Txs() {
+			txID := tx.ID()
+			if referencedTxIDs.Contains(txID) {
+				continue
+			}
+			if err := s.txDB.Delete(txID[:]); err != nil {
+				return err
+			}
+		}
+		if err := s.blockDB.Delete(blkID[:]); err != nil {
+			return err
+		}
+		if err := s.blockIDDB.Delete(heightKey); err != nil {
+			return err
+		}
+	case database.ErrNotFound:
+		// The blocks before a synced state were never accepted locally.
+	default:
+		return err
+	}
+
+	for _, subnetID := range subnetIDs {
+		prefixBytes, err := genesis.Codec.Marshal(txs.Version, heightWithSubnet{
+			Height:   height,
+			SubnetID: subnetID,
+		})
+		if err != nil {
+			return err
+		}
+		rawDiffDB := prefixdb.New(prefixBytes, s.validatorDiffsDB)
+		if err := database.Clear(rawDiffDB, rawDiffDB); err != nil {
+			return err
+		}
+	}
+	publicKeyDiffDB := prefixdb.New(heightKey, s.publicKeyDiffsDB)
+	return database.Clear(publicKeyDiffDB, publicKeyDiffDB)
+}
+
+// indexBlockHeights indexes the accepted blocks that were written before blocks
+// were indexed by height, walking back from the last accepted block. At most
+// [maxPrunedHeights] blocks are indexed per call, and the walk resumes where the
+// previous call stopped. Returns true once all the blocks are indexed.
+func (s *state) indexBlockHeights() (bool, error) {
+	indexed, err := s.singletonDB.Has(blockHeightsIndexedKey)
+	if err != nil || indexed {
+		return indexed, err
+	}
+
+	blkID, err := database.GetID(s.singletonDB, blockHeightsIndexCursorKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		blkID = s.lastAccepted
+	default:
+		return false, err
+	}
+
+	for i := 0; i < maxPrunedHeights; i++ {
+		blk, _, err := s.GetStatelessBlock(blkID)
+		if err == database.ErrNotFound {
+			// The parent of a synced state wasn't accepted locally.
+			return true, s.finishIndexingBlockHeights()
+		}
+		if err != nil {
+			return false, err
+		}
+
+		height := blk.Height()
+		if err := database.PutID(s.blockIDDB, database.PackUInt64(height), blkID); err != nil {
+			return false, err
+		}
+		if height <= s.prunedHeight+1 {
+			return true, s.finishIndexingBlockHeights()
+		}
+		blkID = blk.Parent()
+	}
+	return false, database.PutID(s.singletonDB, blockHeightsIndexCursorKey, blkID)
+}
+
+func (s *state) finishIndexingBlockHeights() error {
+	if err := s.singletonDB.Delete(blockHeightsIndexCursorKey); err != nil {
+		return err
+	}
+	return s.singletonDB.Put(blockHeightsIndexedKey, nil)
+}
+
+// subnetIDs returns the IDs of the primary network and of every subnet.
+func (s *state) subnetIDs() ([]ids.ID, error) {
+	subnetIDs := []ids.ID{constants.PrimaryNetworkID}
+	subnetIt := s.subnetDB.NewIterator()
+	defer subnetIt.Release()
+	for subnetIt.Next() {
+		subnetID, err := ids.ToID(subnetIt.Key())
+		if err != nil {
+			return nil, err
+		}
+		subnetIDs = append(subnetIDs, subnetID)
+	}
+	return subnetIDs, subnetIt.Error()
+}
+
+// referencedTxIDs returns the IDs of the txs the committed state is loaded
+// from: the txs adding the current and pending stakers and topping up the
+// current validators, and the txs creating, transforming and transferring
+// subnets and creating chains.
+func (s *state) referencedTxIDs() (ids.Set, error) {
+	txIDs := ids.Set{}
+	lists := []linkeddb.LinkedDB{s.currentValidatorList}
+	for _, list := range s.syncLists() {
+		lists = append(lists, list)
+	}
+	for _, list := range lists {
+		stakerTxIDs, err := linkedDBKeys(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, txIDBytes := range stakerTxIDs {
+			txID, err := ids.ToID(txIDBytes)
+			if err != nil {
+				return nil, err
+			}
+			txIDs.Add(txID)
+		}
+	}
+
+	validatorTxIDs, err := linkedDBKeys(s.currentValidatorList)
+	if err != nil {
+		return nil, err
+	}
+	for _, txIDBytes := range validatorTxIDs {
+		validatorTxID, err := ids.ToID(txIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		topUps, err := s.GetValidatorTopUps(validatorTxID)
+		if err != nil {
+			return nil, err
+		}
+		for _, topUp := range topUps {
+			txIDs.Add(topUp.ID())
+		}
+	}
+
+	transformedSubnetIt := s.transformedSubnetDB.NewIterator()
+	defer transformedSubnetIt.Release()
+	for transformedSubnetIt.Next() {
+		txID, err := ids.ToID(transformedSubnetIt.Value())
+		if err != nil {
+			return nil, err
+		}
+		txIDs.Add(txID)
+	}
+	if err := transformedSubnetIt.Error(); err != nil {
+		return nil, err
+	}
+
+	subnetIDs, err := s.subnetIDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, subnetID := range subnetIDs {
+		if subnetID != constants.PrimaryNetworkID {
+			txIDs.Add(subnetID)
+		}
+
+		chains, err := s.GetChains(subnetID)
+		if err != nil {
+			return nil, err
+		}
+		for _, chain := range chains {
+			txIDs.Add(chain.ID())
+		}
+
+		transfers, err := s.GetSubnetOwnershipTransfers(subnetID)
+		if err != nil {
+			return nil, err
+		}
+		for _, transfer := range transfers {
+			txIDs.Add(transfer.ID())
+		}
+	}
+	return txIDs, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
+	"github.com/kukrer/savannahnode/vms/platformvm/status"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+
+	createSubnetTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		Owner: &secp256k1fx.OutputOwners{},
+	}}
+	require.NoError(createSubnetTx.Sign(txs.Codec, nil))
+	exportTx := &txs.Tx{Unsigned: &txs.ExportTx{
+		DestinationChain: ids.GenerateTestID(),
+	}}
+	require.NoError(exportTx.Sign(txs.Codec, nil))
+
+	blkTxs := [][]*txs.Tx{
+		{createSubnetTx},
+		{exportTx},
+		nil,
+	}
+	blkIDs := []ids.ID{}
+	for i, blkTxs := range blkTxs {
+		height := uint64(i + 1)
+		blk, err := blocks.NewStandardBlock(s.GetLastAccepted(), height, blkTxs)
+		require.NoError(err)
+		for _, tx := range blkTxs {
+			s.AddTx(tx, status.Committed)
+		}
+		if i == 0 {
+			s.AddSubnet(createSubnetTx)
+		}
+		s.AddStatelessBlock(blk, choices.Accepted)
+		s.SetLastAccepted(blk.ID())
+		s.SetHeight(height)
+		require.NoError(s.Commit())
+		blkIDs = append(blkIDs, blk.ID())
+
+		diffDB, err := s.(*state).getValidatorDiffDB(height, constants.PrimaryNetworkID)
+		require.NoError(err)
+		diffBytes, err := genesis.Codec.Marshal(txs.Version, &ValidatorWeightDiff{Amount: 1})
+		require.NoError(err)
+		require.NoError(diffDB.Put(initialNodeID[:], diffBytes))
+	}
+
+	// The heights of the blocks accepted before the height index are indexed
+	// before anything is pruned. Indexing resumes from its cursor, here as if
+	// a previous prune had indexed height 3.
+	require.NoError(s.(*state).blockIDDB.Delete(database.PackUInt64(1)))
+	require.NoError(s.(*state).blockIDDB.Delete(database.PackUInt64(2)))
+	require.NoError(database.PutID(s.(*state).singletonDB, blockHeightsIndexCursorKey, blkIDs[1]))
+
+	require.Zero(s.GetPrunedHeight())
+	require.NoError(s.Prune(2))
+	require.NoError(s.Prune(1))
+
+	reopened := newStateFromDB(require, db)
+	require.NoError(reopened.(*state).load())
+
+	for _, s := range []State{s, reopened} {
+		require.Equal(uint64(2), s.GetPrunedHeight())
+
+		for i, blkID := range blkIDs {
+			_, _, err := s.GetStatelessBlock(blkID)
+			if i < 2 {
+				require.ErrorIs(err, database.ErrNotFound)
+			} else {
+				require.NoError(err)
+			}
+
+			diffs, err := s.GetValidatorWeightDiffs(uint64(i+1), constants.PrimaryNetworkID)
+			require.NoError(err)
+			if i < 2 {
+				require.Empty(diffs)
+			} else {
+				require.Len(diffs, 1)
+			}
+		}
+
+		// The tx creating the subnet is still referenced by the state
+		_, _, err := s.GetTx(createSubnetTx.ID())
+		require.NoError(err)
+		_, _, err = s.GetTx(exportTx.ID())
+		require.ErrorIs(err, database.ErrNotFound)
+
+		subnets, err := s.GetSubnets()
+		require.NoError(err)
+		require.Len(subnets, 1)
+	}
+}
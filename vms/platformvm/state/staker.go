@@ -11,6 +11,8 @@ import (
 
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/validator"
 )
 
@@ -36,6 +38,7 @@ type StakerIterator interface {
 type Staker struct {
 	TxID            ids.ID
 	NodeID          ids.NodeID
+	PublicKey       *bls.PublicKey
 	SubnetID        ids.ID
 	Weight          uint64
 	StartTime       time.Time
@@ -95,6 +98,24 @@ func NewPrimaryNetworkStaker(txID ids.ID, vdr *validator.Validator) *Staker {
 	}
 }
 
+// NewPermissionlessValidatorStaker returns the staker described by [tx],
+// including the BLS public key it registered, if any.
+func NewPermissionlessValidatorStaker(txID ids.ID, tx *txs.AddPermissionlessValidatorTx) (*Staker, error) {
+	publicKey, _, err := tx.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &Staker{
+		TxID:      txID,
+		NodeID:    tx.Validator.ID(),
+		PublicKey: publicKey,
+		SubnetID:  tx.Subnet,
+		Weight:    tx.Validator.Weight(),
+		StartTime: tx.Validator.StartTime(),
+		EndTime:   tx.Validator.EndTime(),
+	}, nil
+}
+
 func NewSubnetStaker(txID ids.ID, vdr *validator.SubnetValidator) *Staker {
 	return &Staker{
 		TxID:      txID,
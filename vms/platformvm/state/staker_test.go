@@ -11,6 +11,9 @@ import (
 
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/validator"
 )
 
@@ -175,3 +178,41 @@ func TestNewSubnetStaker(t *testing.T) {
 	require.Zero(staker.NextTime)
 	require.Zero(staker.Priority)
 }
+
+func TestNewPermissionlessValidatorStaker(t *testing.T) {
+	require := require.New(t)
+	txID := ids.GenerateTestID()
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	tx := &txs.AddPermissionlessValidatorTx{
+		Validator: validator.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			Start:  0,
+			End:    1,
+			Wght:   2,
+		},
+		Subnet: constants.PrimaryNetworkID,
+		Signer: signer.NewProofOfPossession(sk),
+	}
+
+	staker, err := NewPermissionlessValidatorStaker(txID, tx)
+	require.NoError(err)
+	require.Equal(txID, staker.TxID)
+	require.Equal(tx.Validator.NodeID, staker.NodeID)
+	require.Equal(bls.PublicKeyToBytes(bls.PublicFromSecretKey(sk)), bls.PublicKeyToBytes(staker.PublicKey))
+	require.Equal(constants.PrimaryNetworkID, staker.SubnetID)
+	require.Equal(tx.Validator.Wght, staker.Weight)
+	require.Equal(tx.Validator.StartTime(), staker.StartTime)
+	require.Equal(tx.Validator.EndTime(), staker.EndTime)
+	require.Zero(staker.PotentialReward)
+	require.Zero(staker.NextTime)
+	require.Zero(staker.Priority)
+
+	// Subnet validators don't register a BLS key.
+	tx.Subnet = ids.GenerateTestID()
+	tx.Signer = &signer.Empty{}
+	staker, err = NewPermissionlessValidatorStaker(txID, tx)
+	require.NoError(err)
+	require.Nil(staker.PublicKey)
+	require.Equal(tx.Subnet, staker.SubnetID)
+}
@@ -29,6 +29,12 @@ type CurrentStakers interface {
 	// the staker set.
 	DeleteCurrentValidator(staker *Staker)
 
+	// UpdateCurrentValidator replaces the validator on the subnet and with the
+	// nodeID of [staker] by [staker]. [staker] must have the TxID of the
+	// validator it replaces. If the validator does not exist,
+	// [database.ErrNotFound] is returned.
+	UpdateCurrentValidator(staker *Staker) error
+
 	// GetCurrentDelegatorIterator returns the delegators associated with the
 	// validator on [subnetID] with [nodeID]. Delegators are sorted by their
 	// removal from current staker set.
@@ -140,6 +146,29 @@ func (v *baseStakers) DeleteValidator(staker *Staker) {
 	v.stakers.Delete(staker)
 }
 
+func (v *baseStakers) UpdateValidator(staker *Staker) error {
+	oldStaker, err := v.GetValidator(staker.SubnetID, staker.NodeID)
+	if err != nil {
+		return err
+	}
+
+	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	validator.validator = staker
+
+	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if !validatorDiff.validatorModified {
+		// Only the validator that was last written needs to be remembered.
+		validatorDiff.replacedValidator = oldStaker
+	}
+	validatorDiff.validatorModified = true
+	validatorDiff.validatorDeleted = false
+	validatorDiff.validator = staker
+
+	v.stakers.Delete(oldStaker)
+	v.stakers.ReplaceOrInsert(staker)
+	return nil
+}
+
 func (v *baseStakers) GetDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) StakerIterator {
 	subnetValidators, ok := v.validators[subnetID]
 	if !ok {
@@ -233,11 +262,36 @@ func (v *baseStakers) getOrCreateValidatorDiff(subnetID ids.ID, nodeID ids.NodeI
 	return validatorDiff
 }
 
+// weightDiff returns the change of the weight of the validator itself,
+// excluding its delegators, described by this diff.
+func (d *diffValidator) weightDiff() (*ValidatorWeightDiff, error) {
+	weightDiff := &ValidatorWeightDiff{}
+	switch {
+	case !d.validatorModified:
+	case d.replacedValidator == nil:
+		weightDiff.Decrease = d.validatorDeleted
+		weightDiff.Amount = d.validator.Weight
+	case d.validatorDeleted:
+		// The validator was updated before being removed, so the weight of
+		// the replaced validator is the one being removed.
+		weightDiff.Decrease = true
+		weightDiff.Amount = d.replacedValidator.Weight
+	default:
+		weightDiff.Amount = d.validator.Weight
+		if err := weightDiff.Add(true, d.replacedValidator.Weight); err != nil {
+			return nil, err
+		}
+	}
+	return weightDiff, nil
+}
+
 type diffStakers struct {
 	// subnetID --> nodeID --> diff for that validator
 	validatorDiffs map[ids.ID]map[ids.NodeID]*diffValidator
 	addedStakers   *btree.BTree
 	deletedStakers map[ids.ID]*Staker
+	// txID --> the validators of the parent state replaced in this diff
+	replacedStakers map[ids.ID]*Staker
 }
 
 type diffValidator struct {
@@ -245,6 +299,9 @@ type diffValidator struct {
 	// [validatorDeleted] implies [validatorModified]
 	validatorDeleted bool
 	validator        *Staker
+	// [replacedValidator] is the validator that [validator] replaced, if it
+	// was updated rather than added.
+	replacedValidator *Staker
 
 	addedDelegators   *btree.BTree
 	deletedDelegators map[ids.ID]*Staker
@@ -303,6 +360,32 @@ func (s *diffStakers) DeleteValidator(staker *Staker) {
 	s.deletedStakers[staker.TxID] = staker
 }
 
+// UpdateValidator replaces [oldStaker], which is the validator of the parent
+// state or was added by this diff, with [staker].
+func (s *diffStakers) UpdateValidator(oldStaker *Staker, staker *Staker) {
+	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorModified {
+		// [oldStaker] was added or updated in this diff, so it isn't in the
+		// parent state.
+		s.addedStakers.Delete(oldStaker)
+	} else {
+		validatorDiff.replacedValidator = oldStaker
+
+		if s.replacedStakers == nil {
+			s.replacedStakers = make(map[ids.ID]*Staker)
+		}
+		s.replacedStakers[oldStaker.TxID] = oldStaker
+	}
+	validatorDiff.validatorModified = true
+	validatorDiff.validatorDeleted = false
+	validatorDiff.validator = staker
+
+	if s.addedStakers == nil {
+		s.addedStakers = btree.New(defaultTreeDegree)
+	}
+	s.addedStakers.ReplaceOrInsert(staker)
+}
+
 func (s *diffStakers) GetDelegatorIterator(
 	parentIterator StakerIterator,
 	subnetID ids.ID,
@@ -357,7 +440,7 @@ func (s *diffStakers) DeleteDelegator(staker *Staker) {
 func (s *diffStakers) GetStakerIterator(parentIterator StakerIterator) StakerIterator {
 	return NewMaskedIterator(
 		NewMergedIterator(
-			parentIterator,
+			NewMaskedIterator(parentIterator, s.replacedStakers),
 			NewTreeIterator(s.addedStakers),
 		),
 		s.deletedStakers,
@@ -24,12 +24,14 @@ import (
 	"github.com/kukrer/savannahnode/snow/uptime"
 	"github.com/kukrer/savannahnode/snow/validators"
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
 	"github.com/kukrer/savannahnode/utils/hashing"
 	"github.com/kukrer/savannahnode/utils/math"
 	"github.com/kukrer/savannahnode/utils/wrappers"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
 	"github.com/kukrer/savannahnode/vms/platformvm/config"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
 	"github.com/kukrer/savannahnode/vms/platformvm/metrics"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
@@ -38,12 +40,18 @@ import (
 )
 
 const (
-	validatorDiffsCacheSize = 2048
-	blockCacheSize          = 2048
-	txCacheSize             = 2048
-	rewardUTXOsCacheSize    = 2048
-	chainCacheSize          = 2048
-	chainDBCacheSize        = 2048
+	validatorDiffsCacheSize    = 2048
+	blockCacheSize             = 2048
+	txCacheSize                = 2048
+	rewardUTXOsCacheSize       = 2048
+	chainCacheSize             = 2048
+	chainDBCacheSize           = 2048
+	transformedSubnetCacheSize = 64
+	supplyCacheSize            = 64
+
+	subnetOwnershipTransferCacheSize = 64
+	subnetOwnerCacheSize             = 64
+	validatorTopUpCacheSize          = 64
 )
 
 var (
@@ -51,24 +59,34 @@ var (
 
 	ErrDelegatorSubset = errors.New("delegator's time range must be a subset of the validator's time range")
 
-	blockPrefix           = []byte("block")
-	validatorsPrefix      = []byte("validators")
-	currentPrefix         = []byte("current")
-	pendingPrefix         = []byte("pending")
-	validatorPrefix       = []byte("validator")
-	delegatorPrefix       = []byte("delegator")
-	subnetValidatorPrefix = []byte("subnetValidator")
-	validatorDiffsPrefix  = []byte("validatorDiffs")
-	txPrefix              = []byte("tx")
-	rewardUTXOsPrefix     = []byte("rewardUTXOs")
-	utxoPrefix            = []byte("utxo")
-	subnetPrefix          = []byte("subnet")
-	chainPrefix           = []byte("chain")
-	singletonPrefix       = []byte("singleton")
+	blockPrefix             = []byte("block")
+	blockIDPrefix           = []byte("blockID")
+	validatorsPrefix        = []byte("validators")
+	currentPrefix           = []byte("current")
+	pendingPrefix           = []byte("pending")
+	validatorPrefix         = []byte("validator")
+	delegatorPrefix         = []byte("delegator")
+	subnetValidatorPrefix   = []byte("subnetValidator")
+	subnetDelegatorPrefix   = []byte("subnetDelegator")
+	validatorDiffsPrefix    = []byte("validatorDiffs")
+	publicKeyDiffsPrefix    = []byte("publicKeyDiffs")
+	txPrefix                = []byte("tx")
+	rewardUTXOsPrefix       = []byte("rewardUTXOs")
+	utxoPrefix              = []byte("utxo")
+	subnetPrefix            = []byte("subnet")
+	transformedSubnetPrefix = []byte("transformedSubnet")
+	ownershipTransferPrefix = []byte("ownershipTransfer")
+	subnetOwnerPrefix       = []byte("subnetOwner")
+	validatorTopUpPrefix    = []byte("validatorTopUp")
+	supplyPrefix            = []byte("supply")
+	chainPrefix             = []byte("chain")
+	singletonPrefix         = []byte("singleton")
 
 	timestampKey     = []byte("timestamp")
 	currentSupplyKey = []byte("current supply")
+	baseFeeKey       = []byte("base fee")
 	lastAcceptedKey  = []byte("last accepted")
+	prunedHeightKey  = []byte("pruned height")
 	initializedKey   = []byte("initialized")
 )
 
@@ -84,11 +102,30 @@ type Chain interface {
 	SetTimestamp(tm time.Time)
 	GetCurrentSupply() uint64
 	SetCurrentSupply(cs uint64)
+	GetBaseFee() uint64
+	SetBaseFee(baseFee uint64)
+	GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error)
+	SetSubnetCurrentSupply(subnetID ids.ID, cs uint64)
 
 	GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error)
 	AddRewardUTXO(txID ids.ID, utxo *avax.UTXO)
 	GetSubnets() ([]*txs.Tx, error)
 	AddSubnet(createSubnetTx *txs.Tx)
+	GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error)
+	AddSubnetTransformation(transformSubnetTx *txs.Tx)
+	// GetSubnetOwnershipTransfers returns the TransferSubnetOwnershipTxs of
+	// [subnetID], in the order they were accepted.
+	GetSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error)
+	AddSubnetOwnershipTransfer(transferSubnetOwnershipTx *txs.Tx)
+	// GetSubnetOwner returns the current owner of [subnetID]: the owner set
+	// by its last TransferSubnetOwnershipTx or, if its ownership was never
+	// transferred, the owner set by its CreateSubnetTx.
+	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
+	SetSubnetOwner(subnetID ids.ID, owner fx.Owner)
+	// GetValidatorTopUps returns the TopUpValidatorTxs applied to the
+	// validator added by [validatorTxID], in the order they were accepted.
+	GetValidatorTopUps(validatorTxID ids.ID) ([]*txs.Tx, error)
+	AddValidatorTopUp(validatorTxID ids.ID, topUpValidatorTx *txs.Tx)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 	AddChain(createChainTx *txs.Tx)
 	GetTx(txID ids.ID) (*txs.Tx, status.Status, error)
@@ -114,6 +151,10 @@ type State interface {
 
 	GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids.NodeID]*ValidatorWeightDiff, error)
 
+	// GetValidatorPublicKeyDiffs returns the BLS public keys of the primary
+	// network validators that were removed at [height].
+	GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.PublicKey, error)
+
 	// Return the current validator set of [subnetID].
 	ValidatorSet(subnetID ids.ID) (validators.Set, error)
 
@@ -129,6 +170,33 @@ type State interface {
 	// all pending changes to the base database.
 	CommitBatch() (database.Batch, error)
 
+	// WriteSyncRecords writes the records of the last committed state, which
+	// are served to the nodes that state sync, into [db].
+	WriteSyncRecords(db database.KeyValueWriter) error
+
+	// NewSyncSnapshot returns a snapshot of the records of the last committed
+	// state, which can be written while later states are committed.
+	NewSyncSnapshot() (SyncSnapshot, error)
+
+	// ApplySyncRecords replaces the state with the records iterated by [it] and
+	// accepts [blk], the block the records were written after.
+	ApplySyncRecords(it database.Iterator, blk blocks.Block) error
+
+	// GetPrunedHeight returns the height up to which the accepted blocks, the
+	// txs only they reference and the validator set diffs were pruned.
+	GetPrunedHeight() uint64
+
+	// Prune removes the accepted blocks, the txs only they reference and the
+	// validator set diffs up to [height]. The last accepted block is never
+	// removed and the removal may be split across multiple calls, see
+	// GetPrunedHeight. Prune must only be called when there are no uncommitted
+	// changes.
+	Prune(height uint64) error
+
+	// GetSupplyBreakdown returns where the AVAX of the P-chain went, as of the
+	// last committed state.
+	GetSupplyBreakdown() (SupplyBreakdown, error)
+
 	Close() error
 }
 
@@ -148,9 +216,12 @@ type stateBlk struct {
  * | | |-. delegator
  * | | | '-. list
  * | | |   '-- txID -> potential reward
- * | | '-. subnetValidator
+ * | | |-. subnetValidator
+ * | | | '-. list
+ * | | |   '-- txID -> potential reward or nil
+ * | | '-. subnetDelegator
  * | |   '-. list
- * | |     '-- txID -> nil
+ * | |     '-- txID -> potential reward
  * | |-. pending
  * | | |-. validator
  * | | | '-. list
@@ -158,7 +229,10 @@ type stateBlk struct {
  * | | |-. delegator
  * | | | '-. list
  * | | |   '-- txID -> nil
- * | | '-. subnetValidator
+ * | | |-. subnetValidator
+ * | | | '-. list
+ * | | |   '-- txID -> nil
+ * | | '-. subnetDelegator
  * | |   '-. list
  * | |     '-- txID -> nil
  * | '-. diffs
@@ -178,6 +252,16 @@ type stateBlk struct {
  * |-. subnets
  * | '-. list
  * |   '-- txID -> nil
+ * |-. transformedSubnets
+ * | '-- subnetID -> transformSubnetTxID
+ * |-. ownershipTransfers
+ * | '-. subnetID
+ * |   '-. list
+ * |     '-- txID -> nil
+ * |-. subnetOwners
+ * | '-- subnetID -> owner
+ * |-. supplies
+ * | '-- subnetID -> currentSupply
  * |-. chains
  * | '-. subnetID
  * |   '-. list
@@ -186,7 +270,9 @@ type stateBlk struct {
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
- *   '-- lastAcceptedKey -> lastAccepted
+ *   |-- baseFeeKey -> baseFee
+ *   |-- lastAcceptedKey -> lastAccepted
+ *   '-- prunedHeightKey -> prunedHeight
  */
 type state struct {
 	cfg     *config.Config
@@ -204,6 +290,10 @@ type state struct {
 	addedBlocks map[ids.ID]stateBlk // map of blockID -> Block
 	blockCache  cache.Cacher        // cache of blockID -> Block, if the entry is nil, it is not in the database
 	blockDB     database.Database
+	blockIDDB   database.Database // height -> blockID of the accepted blocks
+
+	// All accepted blocks with heights in [1, prunedHeight] were pruned
+	prunedHeight uint64
 
 	uptimes        map[ids.NodeID]*uptimeAndReward // nodeID -> uptimes
 	updatedUptimes map[ids.NodeID]struct{}         // nodeID -> nil
@@ -216,6 +306,8 @@ type state struct {
 	currentDelegatorList         linkeddb.LinkedDB
 	currentSubnetValidatorBaseDB database.Database
 	currentSubnetValidatorList   linkeddb.LinkedDB
+	currentSubnetDelegatorBaseDB database.Database
+	currentSubnetDelegatorList   linkeddb.LinkedDB
 	pendingValidatorsDB          database.Database
 	pendingValidatorBaseDB       database.Database
 	pendingValidatorList         linkeddb.LinkedDB
@@ -223,10 +315,15 @@ type state struct {
 	pendingDelegatorList         linkeddb.LinkedDB
 	pendingSubnetValidatorBaseDB database.Database
 	pendingSubnetValidatorList   linkeddb.LinkedDB
+	pendingSubnetDelegatorBaseDB database.Database
+	pendingSubnetDelegatorList   linkeddb.LinkedDB
 
 	validatorDiffsCache cache.Cacher // cache of heightWithSubnet -> map[ids.ShortID]*ValidatorWeightDiff
 	validatorDiffsDB    database.Database
 
+	publicKeyDiffsCache cache.Cacher // cache of height -> map[ids.NodeID]*bls.PublicKey
+	publicKeyDiffsDB    database.Database
+
 	addedTxs map[ids.ID]*txAndStatus // map of txID -> {*txs.Tx, Status}
 	txCache  cache.Cacher            // cache of txID -> {*txs.Tx, Status} if the entry is nil, it is not in the database
 	txDB     database.Database
@@ -238,12 +335,34 @@ type state struct {
 	modifiedUTXOs map[ids.ID]*avax.UTXO // map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
 	utxoDB        database.Database
 	utxoState     avax.UTXOState
+	// syncSnapshot, if not nil, tracks the UTXOs modified since it was taken
+	syncSnapshot *syncSnapshot
 
 	cachedSubnets []*txs.Tx // nil if the subnets haven't been loaded
 	addedSubnets  []*txs.Tx
 	subnetBaseDB  database.Database
 	subnetDB      linkeddb.LinkedDB
 
+	transformedSubnets     map[ids.ID]*txs.Tx // map of subnetID -> transformSubnetTx
+	transformedSubnetCache cache.Cacher       // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
+	transformedSubnetDB    database.Database
+
+	addedSubnetOwnershipTransfers map[ids.ID][]*txs.Tx // maps subnetID -> the newly accepted TransferSubnetOwnershipTxs of the subnet
+	subnetOwnershipTransferCache  cache.Cacher         // cache of subnetID -> the TransferSubnetOwnershipTxs after all local modifications []*txs.Tx
+	subnetOwnershipTransferDB     database.Database
+
+	subnetOwners     map[ids.ID]fx.Owner // map of subnetID -> owner
+	subnetOwnerCache cache.Cacher        // cache of subnetID -> owner
+	subnetOwnerDB    database.Database
+
+	addedValidatorTopUps map[ids.ID][]*txs.Tx // maps validator txID -> the newly accepted TopUpValidatorTxs of the validator
+	validatorTopUpCache  cache.Cacher         // cache of validator txID -> the TopUpValidatorTxs after all local modifications []*txs.Tx
+	validatorTopUpDB     database.Database
+
+	modifiedSubnetSupplies map[ids.ID]uint64 // map of subnetID -> current supply
+	subnetSupplyCache      cache.Cacher      // cache of subnetID -> current supply if the entry is nil, it is not in the database
+	subnetSupplyDB         database.Database
+
 	addedChains  map[ids.ID][]*txs.Tx // maps subnetID -> the newly added chains to the subnet
 	chainCache   cache.Cacher         // cache of subnetID -> the chains after all local modifications []*txs.Tx
 	chainDBCache cache.Cacher         // cache of subnetID -> linkedDB
@@ -252,6 +371,9 @@ type state struct {
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
 	currentSupply, persistedCurrentSupply uint64
+	baseFee, persistedBaseFee             uint64
+	supplyBreakdown                       SupplyBreakdown
+	supplyBreakdownModified               bool
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	singletonDB                         database.Database
@@ -358,11 +480,13 @@ func new(
 	currentValidatorBaseDB := prefixdb.New(validatorPrefix, currentValidatorsDB)
 	currentDelegatorBaseDB := prefixdb.New(delegatorPrefix, currentValidatorsDB)
 	currentSubnetValidatorBaseDB := prefixdb.New(subnetValidatorPrefix, currentValidatorsDB)
+	currentSubnetDelegatorBaseDB := prefixdb.New(subnetDelegatorPrefix, currentValidatorsDB)
 
 	pendingValidatorsDB := prefixdb.New(pendingPrefix, validatorsDB)
 	pendingValidatorBaseDB := prefixdb.New(validatorPrefix, pendingValidatorsDB)
 	pendingDelegatorBaseDB := prefixdb.New(delegatorPrefix, pendingValidatorsDB)
 	pendingSubnetValidatorBaseDB := prefixdb.New(subnetValidatorPrefix, pendingValidatorsDB)
+	pendingSubnetDelegatorBaseDB := prefixdb.New(subnetDelegatorPrefix, pendingValidatorsDB)
 
 	validatorDiffsDB := prefixdb.New(validatorDiffsPrefix, validatorsDB)
 
@@ -375,6 +499,17 @@ func new(
 		return nil, err
 	}
 
+	publicKeyDiffsDB := prefixdb.New(publicKeyDiffsPrefix, validatorsDB)
+
+	publicKeyDiffsCache, err := metercacher.New(
+		"public_key_diffs_cache",
+		metricsReg,
+		&cache.LRU{Size: validatorDiffsCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	txCache, err := metercacher.New(
 		"tx_cache",
 		metricsReg,
@@ -411,6 +546,33 @@ func new(
 		return nil, err
 	}
 
+	subnetOwnershipTransferCache, err := metercacher.New(
+		"subnet_ownership_transfer_cache",
+		metricsReg,
+		&cache.LRU{Size: subnetOwnershipTransferCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetOwnerCache, err := metercacher.New(
+		"subnet_owner_cache",
+		metricsReg,
+		&cache.LRU{Size: subnetOwnerCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorTopUpCache, err := metercacher.New(
+		"validator_top_up_cache",
+		metricsReg,
+		&cache.LRU{Size: validatorTopUpCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	chainDBCache, err := metercacher.New(
 		"chain_db_cache",
 		metricsReg,
@@ -420,6 +582,24 @@ func new(
 		return nil, err
 	}
 
+	transformedSubnetCache, err := metercacher.New(
+		"transformed_subnet_cache",
+		metricsReg,
+		&cache.LRU{Size: transformedSubnetCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetSupplyCache, err := metercacher.New(
+		"subnet_supply_cache",
+		metricsReg,
+		&cache.LRU{Size: supplyCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &state{
 		cfg:     cfg,
 		ctx:     ctx,
@@ -430,6 +610,7 @@ func new(
 		addedBlocks: make(map[ids.ID]stateBlk),
 		blockCache:  blockCache,
 		blockDB:     prefixdb.New(blockPrefix, baseDB),
+		blockIDDB:   prefixdb.New(blockIDPrefix, baseDB),
 
 		currentStakers: newBaseStakers(),
 		pendingStakers: newBaseStakers(),
@@ -445,6 +626,8 @@ func new(
 		currentDelegatorList:         linkeddb.NewDefault(currentDelegatorBaseDB),
 		currentSubnetValidatorBaseDB: currentSubnetValidatorBaseDB,
 		currentSubnetValidatorList:   linkeddb.NewDefault(currentSubnetValidatorBaseDB),
+		currentSubnetDelegatorBaseDB: currentSubnetDelegatorBaseDB,
+		currentSubnetDelegatorList:   linkeddb.NewDefault(currentSubnetDelegatorBaseDB),
 		pendingValidatorsDB:          pendingValidatorsDB,
 		pendingValidatorBaseDB:       pendingValidatorBaseDB,
 		pendingValidatorList:         linkeddb.NewDefault(pendingValidatorBaseDB),
@@ -452,8 +635,12 @@ func new(
 		pendingDelegatorList:         linkeddb.NewDefault(pendingDelegatorBaseDB),
 		pendingSubnetValidatorBaseDB: pendingSubnetValidatorBaseDB,
 		pendingSubnetValidatorList:   linkeddb.NewDefault(pendingSubnetValidatorBaseDB),
+		pendingSubnetDelegatorBaseDB: pendingSubnetDelegatorBaseDB,
+		pendingSubnetDelegatorList:   linkeddb.NewDefault(pendingSubnetDelegatorBaseDB),
 		validatorDiffsDB:             validatorDiffsDB,
 		validatorDiffsCache:          validatorDiffsCache,
+		publicKeyDiffsDB:             publicKeyDiffsDB,
+		publicKeyDiffsCache:          publicKeyDiffsCache,
 
 		addedTxs: make(map[ids.ID]*txAndStatus),
 		txDB:     prefixdb.New(txPrefix, baseDB),
@@ -470,6 +657,26 @@ func new(
 		subnetBaseDB: subnetBaseDB,
 		subnetDB:     linkeddb.NewDefault(subnetBaseDB),
 
+		transformedSubnets:     make(map[ids.ID]*txs.Tx),
+		transformedSubnetCache: transformedSubnetCache,
+		transformedSubnetDB:    prefixdb.New(transformedSubnetPrefix, baseDB),
+
+		addedSubnetOwnershipTransfers: make(map[ids.ID][]*txs.Tx),
+		subnetOwnershipTransferCache:  subnetOwnershipTransferCache,
+		subnetOwnershipTransferDB:     prefixdb.New(ownershipTransferPrefix, baseDB),
+
+		subnetOwners:     make(map[ids.ID]fx.Owner),
+		subnetOwnerCache: subnetOwnerCache,
+		subnetOwnerDB:    prefixdb.New(subnetOwnerPrefix, baseDB),
+
+		addedValidatorTopUps: make(map[ids.ID][]*txs.Tx),
+		validatorTopUpCache:  validatorTopUpCache,
+		validatorTopUpDB:     prefixdb.New(validatorTopUpPrefix, baseDB),
+
+		modifiedSubnetSupplies: make(map[ids.ID]uint64),
+		subnetSupplyCache:      subnetSupplyCache,
+		subnetSupplyDB:         prefixdb.New(supplyPrefix, baseDB),
+
 		addedChains:  make(map[ids.ID][]*txs.Tx),
 		chainDB:      prefixdb.New(chainPrefix, baseDB),
 		chainCache:   chainCache,
@@ -491,6 +698,10 @@ func (s *state) DeleteCurrentValidator(staker *Staker) {
 	s.currentStakers.DeleteValidator(staker)
 }
 
+func (s *state) UpdateCurrentValidator(staker *Staker) error {
+	return s.currentStakers.UpdateValidator(staker)
+}
+
 func (s *state) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	return s.currentStakers.GetDelegatorIterator(subnetID, nodeID), nil
 }
@@ -580,6 +791,175 @@ func (s *state) AddSubnet(createSubnetTx *txs.Tx) {
 	}
 }
 
+func (s *state) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
+	if tx, exists := s.transformedSubnets[subnetID]; exists {
+		return tx, nil
+	}
+
+	if txIntf, cached := s.transformedSubnetCache.Get(subnetID); cached {
+		if txIntf == nil {
+			return nil, database.ErrNotFound
+		}
+		return txIntf.(*txs.Tx), nil
+	}
+
+	transformSubnetTxID, err := database.GetID(s.transformedSubnetDB, subnetID[:])
+	if err == database.ErrNotFound {
+		s.transformedSubnetCache.Put(subnetID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	transformSubnetTx, _, err := s.GetTx(transformSubnetTxID)
+	if err != nil {
+		return nil, err
+	}
+	s.transformedSubnetCache.Put(subnetID, transformSubnetTx)
+	return transformSubnetTx, nil
+}
+
+func (s *state) AddSubnetTransformation(transformSubnetTxIntf *txs.Tx) {
+	transformSubnetTx := transformSubnetTxIntf.Unsigned.(*txs.TransformSubnetTx)
+	s.transformedSubnets[transformSubnetTx.Subnet] = transformSubnetTxIntf
+}
+
+func (s *state) GetSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error) {
+	if transfersIntf, cached := s.subnetOwnershipTransferCache.Get(subnetID); cached {
+		return transfersIntf.([]*txs.Tx), nil
+	}
+	transferDB := s.getSubnetOwnershipTransferDB(subnetID)
+	transferDBIt := transferDB.NewIterator()
+	defer transferDBIt.Release()
+
+	// The list iterates from the most recently added transfer
+	transfers := []*txs.Tx(nil)
+	for transferDBIt.Next() {
+		txID, err := ids.ToID(transferDBIt.Key())
+		if err != nil {
+			return nil, err
+		}
+		tx, _, err := s.GetTx(txID)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, tx)
+	}
+	if err := transferDBIt.Error(); err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(transfers)-1; i < j; i, j = i+1, j-1 {
+		transfers[i], transfers[j] = transfers[j], transfers[i]
+	}
+	transfers = append(transfers, s.addedSubnetOwnershipTransfers[subnetID]...)
+	s.subnetOwnershipTransferCache.Put(subnetID, transfers)
+	return transfers, nil
+}
+
+func (s *state) AddSubnetOwnershipTransfer(transferSubnetOwnershipTxIntf *txs.Tx) {
+	transferSubnetOwnershipTx := transferSubnetOwnershipTxIntf.Unsigned.(*txs.TransferSubnetOwnershipTx)
+	subnetID := transferSubnetOwnershipTx.Subnet
+	s.addedSubnetOwnershipTransfers[subnetID] = append(s.addedSubnetOwnershipTransfers[subnetID], transferSubnetOwnershipTxIntf)
+	if transfersIntf, cached := s.subnetOwnershipTransferCache.Get(subnetID); cached {
+		transfers := transfersIntf.([]*txs.Tx)
+		transfers = append(transfers, transferSubnetOwnershipTxIntf)
+		s.subnetOwnershipTransferCache.Put(subnetID, transfers)
+	}
+}
+
+func (s *state) getSubnetOwnershipTransferDB(subnetID ids.ID) linkeddb.LinkedDB {
+	return linkeddb.NewDefault(prefixdb.New(subnetID[:], s.subnetOwnershipTransferDB))
+}
+
+func (s *state) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	if owner, exists := s.subnetOwners[subnetID]; exists {
+		return owner, nil
+	}
+	if ownerIntf, cached := s.subnetOwnerCache.Get(subnetID); cached {
+		return ownerIntf.(fx.Owner), nil
+	}
+
+	ownerBytes, err := s.subnetOwnerDB.Get(subnetID[:])
+	switch err {
+	case nil:
+		var owner fx.Owner
+		if _, err := genesis.Codec.Unmarshal(ownerBytes, &owner); err != nil {
+			return nil, err
+		}
+		s.subnetOwnerCache.Put(subnetID, owner)
+		return owner, nil
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	// The ownership of the subnet was never transferred
+	subnetIntf, _, err := s.GetTx(subnetID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"couldn't find subnet %q: %w",
+			subnetID,
+			err,
+		)
+	}
+	subnet, ok := subnetIntf.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a subnet", subnetID)
+	}
+	s.subnetOwnerCache.Put(subnetID, subnet.Owner)
+	return subnet.Owner, nil
+}
+
+func (s *state) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	s.subnetOwners[subnetID] = owner
+}
+
+func (s *state) GetValidatorTopUps(validatorTxID ids.ID) ([]*txs.Tx, error) {
+	if topUpsIntf, cached := s.validatorTopUpCache.Get(validatorTxID); cached {
+		return topUpsIntf.([]*txs.Tx), nil
+	}
+	topUpDB := s.getValidatorTopUpDB(validatorTxID)
+	topUpDBIt := topUpDB.NewIterator()
+	defer topUpDBIt.Release()
+
+	// The list iterates from the most recently added top up
+	topUps := []*txs.Tx(nil)
+	for topUpDBIt.Next() {
+		txID, err := ids.ToID(topUpDBIt.Key())
+		if err != nil {
+			return nil, err
+		}
+		tx, _, err := s.GetTx(txID)
+		if err != nil {
+			return nil, err
+		}
+		topUps = append(topUps, tx)
+	}
+	if err := topUpDBIt.Error(); err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(topUps)-1; i < j; i, j = i+1, j-1 {
+		topUps[i], topUps[j] = topUps[j], topUps[i]
+	}
+	topUps = append(topUps, s.addedValidatorTopUps[validatorTxID]...)
+	s.validatorTopUpCache.Put(validatorTxID, topUps)
+	return topUps, nil
+}
+
+func (s *state) AddValidatorTopUp(validatorTxID ids.ID, topUpValidatorTx *txs.Tx) {
+	s.addedValidatorTopUps[validatorTxID] = append(s.addedValidatorTopUps[validatorTxID], topUpValidatorTx)
+	if topUpsIntf, cached := s.validatorTopUpCache.Get(validatorTxID); cached {
+		topUps := topUpsIntf.([]*txs.Tx)
+		topUps = append(topUps, topUpValidatorTx)
+		s.validatorTopUpCache.Put(validatorTxID, topUps)
+	}
+}
+
+func (s *state) getValidatorTopUpDB(validatorTxID ids.ID) linkeddb.LinkedDB {
+	return linkeddb.NewDefault(prefixdb.New(validatorTxID[:], s.validatorTopUpDB))
+}
+
 func (s *state) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
 	if chainsIntf, cached := s.chainCache.Get(subnetID); cached {
 		return chainsIntf.([]*txs.Tx), nil
@@ -763,9 +1143,40 @@ func (s *state) GetTimestamp() time.Time             { return s.timestamp }
 func (s *state) SetTimestamp(tm time.Time)           { s.timestamp = tm }
 func (s *state) GetCurrentSupply() uint64            { return s.currentSupply }
 func (s *state) SetCurrentSupply(cs uint64)          { s.currentSupply = cs }
+func (s *state) GetBaseFee() uint64                  { return s.baseFee }
+func (s *state) SetBaseFee(baseFee uint64)           { s.baseFee = baseFee }
 func (s *state) GetLastAccepted() ids.ID             { return s.lastAccepted }
 func (s *state) SetLastAccepted(lastAccepted ids.ID) { s.lastAccepted = lastAccepted }
 
+func (s *state) GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error) {
+	if currentSupply, exists := s.modifiedSubnetSupplies[subnetID]; exists {
+		return currentSupply, nil
+	}
+
+	if supplyIntf, cached := s.subnetSupplyCache.Get(subnetID); cached {
+		if supplyIntf == nil {
+			return 0, database.ErrNotFound
+		}
+		return supplyIntf.(uint64), nil
+	}
+
+	currentSupply, err := database.GetUInt64(s.subnetSupplyDB, subnetID[:])
+	if err == database.ErrNotFound {
+		s.subnetSupplyCache.Put(subnetID, nil)
+		return 0, database.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	s.subnetSupplyCache.Put(subnetID, currentSupply)
+	return currentSupply, nil
+}
+
+func (s *state) SetSubnetCurrentSupply(subnetID ids.ID, cs uint64) {
+	s.modifiedSubnetSupplies[subnetID] = cs
+}
+
 func (s *state) GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids.NodeID]*ValidatorWeightDiff, error) {
 	prefixStruct := heightWithSubnet{
 		Height:   height,
@@ -806,6 +1217,34 @@ func (s *state) GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids
 	return weightDiffs, diffIter.Error()
 }
 
+func (s *state) GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.PublicKey, error) {
+	if publicKeyDiffsIntf, ok := s.publicKeyDiffsCache.Get(height); ok {
+		return publicKeyDiffsIntf.(map[ids.NodeID]*bls.PublicKey), nil
+	}
+
+	rawDiffDB := prefixdb.New(database.PackUInt64(height), s.publicKeyDiffsDB)
+	diffIter := rawDiffDB.NewIterator()
+	defer diffIter.Release()
+
+	publicKeyDiffs := make(map[ids.NodeID]*bls.PublicKey)
+	for diffIter.Next() {
+		nodeID, err := ids.ToNodeID(diffIter.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		pk, err := bls.PublicKeyFromBytes(diffIter.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		publicKeyDiffs[nodeID] = pk
+	}
+
+	s.publicKeyDiffsCache.Put(height, publicKeyDiffs)
+	return publicKeyDiffs, diffIter.Error()
+}
+
 func (s *state) ValidatorSet(subnetID ids.ID) (validators.Set, error) {
 	vdrs := validators.NewSet()
 	for nodeID, validator := range s.currentStakers.validators[subnetID] {
@@ -895,6 +1334,7 @@ func (s *state) load() error {
 	errs := wrappers.Errs{}
 	errs.Add(
 		s.loadMetadata(),
+		s.loadSupplyBreakdown(),
 		s.loadCurrentValidators(),
 		s.loadPendingValidators(),
 	)
@@ -916,12 +1356,36 @@ func (s *state) loadMetadata() error {
 	s.persistedCurrentSupply = currentSupply
 	s.SetCurrentSupply(currentSupply)
 
+	// The base fee is only written once dynamic fees have been activated, so
+	// it may not exist yet.
+	baseFee, err := database.GetUInt64(s.singletonDB, baseFeeKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		baseFee = 0
+	default:
+		return err
+	}
+	s.persistedBaseFee = baseFee
+	s.SetBaseFee(baseFee)
+
 	lastAccepted, err := database.GetID(s.singletonDB, lastAcceptedKey)
 	if err != nil {
 		return err
 	}
 	s.persistedLastAccepted = lastAccepted
 	s.lastAccepted = lastAccepted
+
+	// The pruned height is only written once the state was pruned.
+	prunedHeight, err := database.GetUInt64(s.singletonDB, prunedHeightKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		prunedHeight = 0
+	default:
+		return err
+	}
+	s.prunedHeight = prunedHeight
 	return nil
 }
 
@@ -950,12 +1414,13 @@ func (s *state) loadCurrentValidators() error {
 		}
 		uptime.lastUpdated = time.Unix(int64(uptime.LastUpdated), 0)
 
-		addValidatorTx, ok := tx.Unsigned.(*txs.AddValidatorTx)
-		if !ok {
-			return fmt.Errorf("expected tx type *txs.AddValidatorTx but got %T", tx.Unsigned)
+		staker, err := newPrimaryNetworkValidatorStaker(txID, tx.Unsigned)
+		if err != nil {
+			return err
+		}
+		if err := s.applyValidatorTopUps(staker); err != nil {
+			return err
 		}
-
-		staker := NewPrimaryNetworkStaker(txID, &addValidatorTx.Validator)
 		staker.PotentialReward = uptime.PotentialReward
 		staker.NextTime = staker.EndTime
 		staker.Priority = PrimaryNetworkValidatorCurrentPriority
@@ -965,7 +1430,7 @@ func (s *state) loadCurrentValidators() error {
 
 		s.currentStakers.stakers.ReplaceOrInsert(staker)
 
-		s.uptimes[addValidatorTx.Validator.NodeID] = uptime
+		s.uptimes[staker.NodeID] = uptime
 	}
 
 	if err := validatorIt.Error(); err != nil {
@@ -1026,21 +1491,109 @@ func (s *state) loadCurrentValidators() error {
 			return err
 		}
 
-		addSubnetValidatorTx, ok := tx.Unsigned.(*txs.AddSubnetValidatorTx)
+		var staker *Staker
+		switch tx := tx.Unsigned.(type) {
+		case *txs.AddSubnetValidatorTx:
+			staker = NewSubnetStaker(txID, &tx.Validator)
+			staker.Priority = SubnetValidatorCurrentPriority
+		case *txs.AddPermissionlessValidatorTx:
+			potentialReward, err := database.ParseUInt64(subnetValidatorIt.Value())
+			if err != nil {
+				return err
+			}
+
+			staker, err = NewPermissionlessValidatorStaker(txID, tx)
+			if err != nil {
+				return err
+			}
+			staker.PotentialReward = potentialReward
+			staker.Priority = SubnetPermissionlessValidatorCurrentPriority
+		default:
+			return fmt.Errorf("expected tx type *txs.AddSubnetValidatorTx or *txs.AddPermissionlessValidatorTx but got %T", tx)
+		}
+		staker.NextTime = staker.EndTime
+
+		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+		validator.validator = staker
+
+		s.currentStakers.stakers.ReplaceOrInsert(staker)
+	}
+	if err := subnetValidatorIt.Error(); err != nil {
+		return err
+	}
+
+	subnetDelegatorIt := s.currentSubnetDelegatorList.NewIterator()
+	defer subnetDelegatorIt.Release()
+	for subnetDelegatorIt.Next() {
+		txIDBytes := subnetDelegatorIt.Key()
+		txID, err := ids.ToID(txIDBytes)
+		if err != nil {
+			return err
+		}
+		tx, _, err := s.GetTx(txID)
+		if err != nil {
+			return err
+		}
+
+		potentialRewardBytes := subnetDelegatorIt.Value()
+		potentialReward, err := database.ParseUInt64(potentialRewardBytes)
+		if err != nil {
+			return err
+		}
+
+		addDelegatorTx, ok := tx.Unsigned.(*txs.AddPermissionlessDelegatorTx)
 		if !ok {
-			return fmt.Errorf("expected tx type *txs.AddSubnetValidatorTx but got %T", tx.Unsigned)
+			return fmt.Errorf("expected tx type *txs.AddPermissionlessDelegatorTx but got %T", tx.Unsigned)
 		}
 
-		staker := NewSubnetStaker(txID, &addSubnetValidatorTx.Validator)
+		staker := NewSubnetStaker(txID, &addDelegatorTx.Validator)
+		staker.PotentialReward = potentialReward
 		staker.NextTime = staker.EndTime
-		staker.Priority = SubnetValidatorCurrentPriority
+		staker.Priority = SubnetPermissionlessDelegatorCurrentPriority
 
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
-		validator.validator = staker
+		if validator.delegators == nil {
+			validator.delegators = btree.New(defaultTreeDegree)
+		}
+		validator.delegators.ReplaceOrInsert(staker)
 
 		s.currentStakers.stakers.ReplaceOrInsert(staker)
 	}
-	return subnetValidatorIt.Error()
+	return subnetDelegatorIt.Error()
+}
+
+// newPrimaryNetworkValidatorStaker returns the staker described by [tx], which
+// must add a validator to the primary network.
+func newPrimaryNetworkValidatorStaker(txID ids.ID, tx txs.UnsignedTx) (*Staker, error) {
+	switch tx := tx.(type) {
+	case *txs.AddValidatorTx:
+		return NewPrimaryNetworkStaker(txID, &tx.Validator), nil
+	case *txs.AddPermissionlessValidatorTx:
+		return NewPermissionlessValidatorStaker(txID, tx)
+	default:
+		return nil, fmt.Errorf("expected tx type *txs.AddValidatorTx or *txs.AddPermissionlessValidatorTx but got %T", tx)
+	}
+}
+
+// applyValidatorTopUps adds the stake and the extensions of the accepted
+// TopUpValidatorTxs of [staker] to it.
+func (s *state) applyValidatorTopUps(staker *Staker) error {
+	topUps, err := s.GetValidatorTopUps(staker.TxID)
+	if err != nil {
+		return err
+	}
+	for _, topUpTx := range topUps {
+		topUp, ok := topUpTx.Unsigned.(*txs.TopUpValidatorTx)
+		if !ok {
+			return fmt.Errorf("expected tx type *txs.TopUpValidatorTx but got %T", topUpTx.Unsigned)
+		}
+		staker.Weight, err = math.Add64(staker.Weight, topUp.Wght)
+		if err != nil {
+			return err
+		}
+		staker.EndTime = topUp.EndTime()
+	}
+	return nil
 }
 
 func (s *state) loadPendingValidators() error {
@@ -1059,12 +1612,10 @@ func (s *state) loadPendingValidators() error {
 			return err
 		}
 
-		addValidatorTx, ok := tx.Unsigned.(*txs.AddValidatorTx)
-		if !ok {
-			return fmt.Errorf("expected tx type *txs.AddValidatorTx but got %T", tx.Unsigned)
+		staker, err := newPrimaryNetworkValidatorStaker(txID, tx.Unsigned)
+		if err != nil {
+			return err
 		}
-
-		staker := NewPrimaryNetworkStaker(txID, &addValidatorTx.Validator)
 		staker.NextTime = staker.StartTime
 		staker.Priority = PrimaryNetworkValidatorPendingPriority
 
@@ -1124,21 +1675,62 @@ func (s *state) loadPendingValidators() error {
 			return err
 		}
 
-		addSubnetValidatorTx, ok := tx.Unsigned.(*txs.AddSubnetValidatorTx)
+		var staker *Staker
+		switch tx := tx.Unsigned.(type) {
+		case *txs.AddSubnetValidatorTx:
+			staker = NewSubnetStaker(txID, &tx.Validator)
+			staker.Priority = SubnetValidatorPendingPriority
+		case *txs.AddPermissionlessValidatorTx:
+			staker, err = NewPermissionlessValidatorStaker(txID, tx)
+			if err != nil {
+				return err
+			}
+			staker.Priority = SubnetPermissionlessValidatorPendingPriority
+		default:
+			return fmt.Errorf("expected tx type *txs.AddSubnetValidatorTx or *txs.AddPermissionlessValidatorTx but got %T", tx)
+		}
+		staker.NextTime = staker.StartTime
+
+		validator := s.pendingStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+		validator.validator = staker
+
+		s.pendingStakers.stakers.ReplaceOrInsert(staker)
+	}
+	if err := subnetValidatorIt.Error(); err != nil {
+		return err
+	}
+
+	subnetDelegatorIt := s.pendingSubnetDelegatorList.NewIterator()
+	defer subnetDelegatorIt.Release()
+	for subnetDelegatorIt.Next() {
+		txIDBytes := subnetDelegatorIt.Key()
+		txID, err := ids.ToID(txIDBytes)
+		if err != nil {
+			return err
+		}
+		tx, _, err := s.GetTx(txID)
+		if err != nil {
+			return err
+		}
+
+		addDelegatorTx, ok := tx.Unsigned.(*txs.AddPermissionlessDelegatorTx)
 		if !ok {
-			return fmt.Errorf("expected tx type *txs.AddSubnetValidatorTx but got %T", tx.Unsigned)
+			return fmt.Errorf("expected tx type *txs.AddPermissionlessDelegatorTx but got %T", tx.Unsigned)
 		}
 
-		staker := NewSubnetStaker(txID, &addSubnetValidatorTx.Validator)
+		staker := NewSubnetStaker(txID, &addDelegatorTx.Validator)
 		staker.NextTime = staker.StartTime
-		staker.Priority = SubnetValidatorPendingPriority
+		staker.Priority = SubnetPermissionlessDelegatorPendingPriority
 
 		validator := s.pendingStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
-		validator.validator = staker
+		if validator.delegators == nil {
+			validator.delegators = btree.New(defaultTreeDegree)
+		}
+		validator.delegators.ReplaceOrInsert(staker)
 
 		s.pendingStakers.stakers.ReplaceOrInsert(staker)
 	}
-	return subnetValidatorIt.Error()
+	return subnetDelegatorIt.Error()
 }
 
 func (s *state) write(height uint64) error {
@@ -1154,8 +1746,14 @@ func (s *state) write(height uint64) error {
 		s.writeRewardUTXOs(),
 		s.writeUTXOs(),
 		s.writeSubnets(),
+		s.writeTransformedSubnets(),
+		s.writeSubnetOwnershipTransfers(),
+		s.writeSubnetOwners(),
+		s.writeValidatorTopUps(),
+		s.writeSubnetSupplies(),
 		s.writeChains(),
 		s.writeMetadata(),
+		s.writeSupplyBreakdown(),
 	)
 	return errs.Err
 }
@@ -1163,10 +1761,12 @@ func (s *state) write(height uint64) error {
 func (s *state) Close() error {
 	errs := wrappers.Errs{}
 	errs.Add(
+		s.pendingSubnetDelegatorBaseDB.Close(),
 		s.pendingSubnetValidatorBaseDB.Close(),
 		s.pendingDelegatorBaseDB.Close(),
 		s.pendingValidatorBaseDB.Close(),
 		s.pendingValidatorsDB.Close(),
+		s.currentSubnetDelegatorBaseDB.Close(),
 		s.currentSubnetValidatorBaseDB.Close(),
 		s.currentDelegatorBaseDB.Close(),
 		s.currentValidatorBaseDB.Close(),
@@ -1176,6 +1776,11 @@ func (s *state) Close() error {
 		s.rewardUTXODB.Close(),
 		s.utxoDB.Close(),
 		s.subnetBaseDB.Close(),
+		s.transformedSubnetDB.Close(),
+		s.subnetOwnershipTransferDB.Close(),
+		s.subnetOwnerDB.Close(),
+		s.validatorTopUpDB.Close(),
+		s.subnetSupplyDB.Close(),
 		s.chainDB.Close(),
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
@@ -1290,6 +1895,10 @@ func (s *state) writeBlocks() error {
 		if err = s.blockDB.Put(blkID[:], blockBytes); err != nil {
 			return fmt.Errorf("failed to write block %s: %w", blkID, err)
 		}
+		heightKey := database.PackUInt64(stBlk.Blk.Height())
+		if err = database.PutID(s.blockIDDB, heightKey, blkID); err != nil {
+			return fmt.Errorf("failed to index block %s: %w", blkID, err)
+		}
 	}
 	return nil
 }
@@ -1347,23 +1956,58 @@ func (s *state) writeCurrentPrimaryNetworkStakers(height uint64) error {
 	}
 	rawDiffDB := prefixdb.New(prefixBytes, s.validatorDiffsDB)
 	diffDB := linkeddb.NewDefault(rawDiffDB)
+	publicKeyDiffDB := prefixdb.New(database.PackUInt64(height), s.publicKeyDiffsDB)
 
 	weightDiffs := make(map[ids.NodeID]*ValidatorWeightDiff)
+	publicKeyDiffs := make(map[ids.NodeID]*bls.PublicKey)
 	for nodeID, validatorDiff := range validatorDiffs {
-		weightDiff := &ValidatorWeightDiff{}
+		weightDiff, err := validatorDiff.weightDiff()
+		if err != nil {
+			return fmt.Errorf("failed to calculate validator weight diff: %w", err)
+		}
 		if validatorDiff.validatorModified {
 			staker := validatorDiff.validator
 
-			weightDiff.Decrease = validatorDiff.validatorDeleted
-			weightDiff.Amount = staker.Weight
-
 			if validatorDiff.validatorDeleted {
 				if err := s.currentValidatorList.Delete(staker.TxID[:]); err != nil {
 					return fmt.Errorf("failed to delete current staker: %w", err)
 				}
 
+				// Record the key of the removed validator so that the
+				// validator set can be reconstructed at prior heights.
+				if staker.PublicKey != nil {
+					// Copy so value passed into [Put] doesn't get overwritten
+					// next iteration
+					nodeID := nodeID
+					pkBytes := bls.PublicKeyToBytes(staker.PublicKey)
+					if err := publicKeyDiffDB.Put(nodeID[:], pkBytes); err != nil {
+						return fmt.Errorf("failed to write public key diff: %w", err)
+					}
+					publicKeyDiffs[nodeID] = staker.PublicKey
+				}
+
 				delete(s.uptimes, nodeID)
 				delete(s.updatedUptimes, nodeID)
+
+				if err := s.deleteValidatorTopUps(staker.TxID); err != nil {
+					return fmt.Errorf("failed to delete validator top ups: %w", err)
+				}
+			} else if validatorDiff.replacedValidator != nil {
+				// The uptime of an updated validator is kept.
+				vdr, ok := s.uptimes[nodeID]
+				if !ok {
+					return fmt.Errorf("failed to find uptime of updated validator %s", nodeID)
+				}
+				vdr.PotentialReward = staker.PotentialReward
+
+				vdrBytes, err := genesis.Codec.Marshal(txs.Version, vdr)
+				if err != nil {
+					return fmt.Errorf("failed to serialize current validator: %w", err)
+				}
+
+				if err = s.currentValidatorList.Put(staker.TxID[:], vdrBytes); err != nil {
+					return fmt.Errorf("failed to write current validator to list: %w", err)
+				}
 			} else {
 				vdr := &uptimeAndReward{
 					txID:        staker.TxID,
@@ -1441,6 +2085,7 @@ func (s *state) writeCurrentPrimaryNetworkStakers(height uint64) error {
 		}
 	}
 	s.validatorDiffsCache.Put(string(prefixBytes), weightDiffs)
+	s.publicKeyDiffsCache.Put(height, publicKeyDiffs)
 
 	// TODO: Move validator set management out of the state package
 	//
@@ -1478,16 +2123,19 @@ func (s *state) writeCurrentSubnetStakers(height uint64) error {
 
 		weightDiffs := make(map[ids.NodeID]*ValidatorWeightDiff)
 		for nodeID, validatorDiff := range subnetValidatorDiffs {
-			weightDiff := &ValidatorWeightDiff{}
+			weightDiff, err := validatorDiff.weightDiff()
+			if err != nil {
+				return fmt.Errorf("failed to calculate validator weight diff: %w", err)
+			}
 			if validatorDiff.validatorModified {
 				staker := validatorDiff.validator
 
-				weightDiff.Decrease = validatorDiff.validatorDeleted
-				weightDiff.Amount = staker.Weight
-
-				if validatorDiff.validatorDeleted {
+				switch {
+				case validatorDiff.validatorDeleted:
 					err = s.currentSubnetValidatorList.Delete(staker.TxID[:])
-				} else {
+				case staker.Priority == SubnetPermissionlessValidatorCurrentPriority:
+					err = database.PutUInt64(s.currentSubnetValidatorList, staker.TxID[:], staker.PotentialReward)
+				default:
 					err = s.currentSubnetValidatorList.Put(staker.TxID[:], nil)
 				}
 				if err != nil {
@@ -1495,7 +2143,31 @@ func (s *state) writeCurrentSubnetStakers(height uint64) error {
 				}
 			}
 
-			// TODO: manage subnet delegators here
+			addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
+			for addedDelegatorIterator.Next() {
+				staker := addedDelegatorIterator.Value()
+
+				if err := weightDiff.Add(false, staker.Weight); err != nil {
+					addedDelegatorIterator.Release()
+					return fmt.Errorf("failed to increase node weight diff: %w", err)
+				}
+
+				if err := database.PutUInt64(s.currentSubnetDelegatorList, staker.TxID[:], staker.PotentialReward); err != nil {
+					addedDelegatorIterator.Release()
+					return fmt.Errorf("failed to write current subnet delegator to list: %w", err)
+				}
+			}
+			addedDelegatorIterator.Release()
+
+			for _, staker := range validatorDiff.deletedDelegators {
+				if err := weightDiff.Add(true, staker.Weight); err != nil {
+					return fmt.Errorf("failed to decrease node weight diff: %w", err)
+				}
+
+				if err := s.currentSubnetDelegatorList.Delete(staker.TxID[:]); err != nil {
+					return fmt.Errorf("failed to delete current subnet delegator: %w", err)
+				}
+			}
 
 			if weightDiff.Amount == 0 {
 				continue
@@ -1592,7 +2264,22 @@ func (s *state) writePendingSubnetStakers() error {
 				}
 			}
 
-			// TODO: manage subnet delegators here
+			addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
+			for addedDelegatorIterator.Next() {
+				staker := addedDelegatorIterator.Value()
+
+				if err := s.pendingSubnetDelegatorList.Put(staker.TxID[:], nil); err != nil {
+					addedDelegatorIterator.Release()
+					return fmt.Errorf("failed to write pending subnet delegator to list: %w", err)
+				}
+			}
+			addedDelegatorIterator.Release()
+
+			for _, staker := range validatorDiff.deletedDelegators {
+				if err := s.pendingSubnetDelegatorList.Delete(staker.TxID[:]); err != nil {
+					return fmt.Errorf("failed to delete pending subnet delegator: %w", err)
+				}
+			}
 		}
 	}
 	return nil
@@ -1621,6 +2308,11 @@ func (s *state) writeTXs() error {
 	for txID, txStatus := range s.addedTxs {
 		txID := txID
 
+		if err := s.supplyBreakdown.addTx(txStatus.tx.Unsigned, s.ctx.AVAXAssetID); err != nil {
+			return fmt.Errorf("failed to update supply breakdown: %w", err)
+		}
+		s.supplyBreakdownModified = true
+
 		stx := txBytesAndStatus{
 			Tx:     txStatus.tx.Bytes(),
 			Status: txStatus.status,
@@ -1648,6 +2340,11 @@ func (s *state) writeRewardUTXOs() error {
 		txDB := linkeddb.NewDefault(rawTxDB)
 
 		for _, utxo := range utxos {
+			if err := s.supplyBreakdown.addRewardUTXO(utxo, s.ctx.AVAXAssetID); err != nil {
+				return fmt.Errorf("failed to update supply breakdown: %w", err)
+			}
+			s.supplyBreakdownModified = true
+
 			utxoBytes, err := genesis.Codec.Marshal(txs.Version, utxo)
 			if err != nil {
 				return fmt.Errorf("failed to serialize reward UTXO: %w", err)
@@ -1664,13 +2361,30 @@ func (s *state) writeRewardUTXOs() error {
 func (s *state) writeUTXOs() error {
 	for utxoID, utxo := range s.modifiedUTXOs {
 		delete(s.modifiedUTXOs, utxoID)
+		if err := s.trackSyncSnapshotUTXO(utxoID); err != nil {
+			return fmt.Errorf("failed to track UTXO: %w", err)
+		}
 
 		if utxo == nil {
+			consumedUTXO, err := s.utxoState.GetUTXO(utxoID)
+			if err != nil {
+				return fmt.Errorf("failed to get consumed UTXO: %w", err)
+			}
+			if err := s.supplyBreakdown.updateStakeableLocked(consumedUTXO, true, s.ctx.AVAXAssetID); err != nil {
+				return fmt.Errorf("failed to update supply breakdown: %w", err)
+			}
+			s.supplyBreakdownModified = true
+
 			if err := s.utxoState.DeleteUTXO(utxoID); err != nil {
 				return fmt.Errorf("failed to delete UTXO: %w", err)
 			}
 			continue
 		}
+		if err := s.supplyBreakdown.updateStakeableLocked(utxo, false, s.ctx.AVAXAssetID); err != nil {
+			return fmt.Errorf("failed to update supply breakdown: %w", err)
+		}
+		s.supplyBreakdownModified = true
+
 		if err := s.utxoState.PutUTXO(utxo); err != nil {
 			return fmt.Errorf("failed to add UTXO: %w", err)
 		}
@@ -1690,6 +2404,100 @@ func (s *state) writeSubnets() error {
 	return nil
 }
 
+func (s *state) writeTransformedSubnets() error {
+	for subnetID, tx := range s.transformedSubnets {
+		txID := tx.ID()
+
+		delete(s.transformedSubnets, subnetID)
+		s.transformedSubnetCache.Put(subnetID, tx)
+		if err := database.PutID(s.transformedSubnetDB, subnetID[:], txID); err != nil {
+			return fmt.Errorf("failed to write transformed subnet: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeSubnetSupplies() error {
+	for subnetID, supply := range s.modifiedSubnetSupplies {
+		supply := supply
+		delete(s.modifiedSubnetSupplies, subnetID)
+		s.subnetSupplyCache.Put(subnetID, supply)
+		if err := database.PutUInt64(s.subnetSupplyDB, subnetID[:], supply); err != nil {
+			return fmt.Errorf("failed to write subnet supply: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeSubnetOwnershipTransfers() error {
+	for subnetID, transfers := range s.addedSubnetOwnershipTransfers {
+		transferDB := s.getSubnetOwnershipTransferDB(subnetID)
+		for _, transfer := range transfers {
+			txID := transfer.ID()
+			if err := transferDB.Put(txID[:], nil); err != nil {
+				return fmt.Errorf("failed to write subnet ownership transfer: %w", err)
+			}
+		}
+		delete(s.addedSubnetOwnershipTransfers, subnetID)
+	}
+	return nil
+}
+
+func (s *state) writeSubnetOwners() error {
+	for subnetID, owner := range s.subnetOwners {
+		if err := s.putSubnetOwner(subnetID, owner); err != nil {
+			return err
+		}
+		delete(s.subnetOwners, subnetID)
+	}
+	return nil
+}
+
+func (s *state) putSubnetOwner(subnetID ids.ID, owner fx.Owner) error {
+	ownerBytes, err := genesis.Codec.Marshal(txs.Version, &owner)
+	if err != nil {
+		return fmt.Errorf("failed to marshal subnet owner: %w", err)
+	}
+	s.subnetOwnerCache.Put(subnetID, owner)
+	if err := s.subnetOwnerDB.Put(subnetID[:], ownerBytes); err != nil {
+		return fmt.Errorf("failed to write subnet owner: %w", err)
+	}
+	return nil
+}
+
+func (s *state) writeValidatorTopUps() error {
+	for validatorTxID, topUps := range s.addedValidatorTopUps {
+		topUpDB := s.getValidatorTopUpDB(validatorTxID)
+		for _, topUp := range topUps {
+			txID := topUp.ID()
+			if err := topUpDB.Put(txID[:], nil); err != nil {
+				return fmt.Errorf("failed to write validator top up: %w", err)
+			}
+		}
+		delete(s.addedValidatorTopUps, validatorTxID)
+	}
+	return nil
+}
+
+// deleteValidatorTopUps removes the TopUpValidatorTxs of the removed validator
+// [validatorTxID].
+func (s *state) deleteValidatorTopUps(validatorTxID ids.ID) error {
+	delete(s.addedValidatorTopUps, validatorTxID)
+	s.validatorTopUpCache.Evict(validatorTxID)
+
+	topUpDB := prefixdb.New(validatorTxID[:], s.validatorTopUpDB)
+	keys, err := databaseKeys(topUpDB.NewIterator())
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := topUpDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *state) writeChains() error {
 	for subnetID, chains := range s.addedChains {
 		for _, chain := range chains {
@@ -1718,6 +2526,12 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedCurrentSupply = s.currentSupply
 	}
+	if s.persistedBaseFee != s.baseFee {
+		if err := database.PutUInt64(s.singletonDB, baseFeeKey, s.baseFee); err != nil {
+			return fmt.Errorf("failed to write base fee: %w", err)
+		}
+		s.persistedBaseFee = s.baseFee
+	}
 	if s.persistedLastAccepted != s.lastAccepted {
 		if err := database.PutID(s.singletonDB, lastAcceptedKey, s.lastAccepted); err != nil {
 			return fmt.Errorf("failed to write last accepted: %w", err)
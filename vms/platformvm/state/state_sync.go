@@ -0,0 +1,696 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/linkeddb"
+	"github.com/kukrer/savannahnode/database/memdb"
+	"github.com/kukrer/savannahnode/database/prefixdb"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/hashing"
+	"github.com/kukrer/savannahnode/utils/wrappers"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+)
+
+// syncDiffHeights is the number of heights, ending at the height of the synced
+// state, whose validator set diffs are synced. This allows the validator sets
+// of recent heights, which are referenced by the blocks of other chains, to be
+// served right after state sync.
+const syncDiffHeights = 1024
+
+// The key of every sync record starts with the kind of the record.
+const (
+	syncMetadataKind byte = iota
+	syncTxKind
+	syncUTXOKind
+	syncCurrentValidatorKind
+	syncCurrentDelegatorKind
+	syncCurrentSubnetValidatorKind
+	syncCurrentSubnetDelegatorKind
+	syncPendingValidatorKind
+	syncPendingDelegatorKind
+	syncPendingSubnetValidatorKind
+	syncPendingSubnetDelegatorKind
+	syncSubnetKind
+	syncTransformedSubnetKind
+	syncSubnetSupplyKind
+	syncChainKind
+	syncSubnetOwnershipTransferKind
+	syncValidatorTopUpKind
+	syncValidatorWeightDiffKind
+	syncPublicKeyDiffKind
+	syncSubnetOwnerKind
+)
+
+var (
+	errMalformedSyncRecord   = errors.New("malformed sync record")
+	errUnknownSyncRecordKind = errors.New("unknown sync record kind")
+
+	syncMetadataKeys = [][]byte{
+		timestampKey,
+		currentSupplyKey,
+		baseFeeKey,
+		lastAcceptedKey,
+		supplyBreakdownKey,
+	}
+)
+
+func syncKey(kind byte, parts ...[]byte) []byte {
+	size := 1
+	for _, part := range parts {
+		size += len(part)
+	}
+	key := make([]byte, 1, size)
+	key[0] = kind
+	for _, part := range parts {
+		key = append(key, part...)
+	}
+	return key
+}
+
+// syncLists returns the staker lists that are synced as is, by the kind of
+// their records.
+func (s *state) syncLists() map[byte]linkeddb.LinkedDB {
+	return map[byte]linkeddb.LinkedDB{
+		syncCurrentDelegatorKind:       s.currentDelegatorList,
+		syncCurrentSubnetValidatorKind: s.currentSubnetValidatorList,
+		syncCurrentSubnetDelegatorKind: s.currentSubnetDelegatorList,
+		syncPendingValidatorKind:       s.pendingValidatorList,
+		syncPendingDelegatorKind:       s.pendingDelegatorList,
+		syncPendingSubnetValidatorKind: s.pendingSubnetValidatorList,
+		syncPendingSubnetDelegatorKind: s.pendingSubnetDelegatorList,
+	}
+}
+
+// SyncSnapshot holds the records of a committed state while later states are
+// committed.
+type SyncSnapshot interface {
+	// Write writes the records of the snapshot into [db]. It is safe to call
+	// while later states are committed.
+	Write(db database.KeyValueWriter) error
+
+	// Release stops tracking the changes made to the state since the snapshot
+	// was taken. The snapshot can't be written afterwards.
+	Release()
+}
+
+// syncSnapshot holds a copy of every record other than the UTXOs, which are
+// read from the state when the snapshot is written. The UTXOs modified since
+// the snapshot was taken are written with the values they had at the time.
+type syncSnapshot struct {
+	state   *state
+	records *memdb.Database
+
+	// lock protects [utxos] and [released], which are modified when the state
+	// is committed
+	lock sync.Mutex
+	// utxos maps the IDs of the UTXOs modified since the snapshot was taken to
+	// their serialized values at the time, or nil if they didn't exist
+	utxos    map[ids.ID][]byte
+	released bool
+}
+
+func (s *syncSnapshot) Write(db database.KeyValueWriter) error {
+	recordsIt := s.records.NewIterator()
+	defer recordsIt.Release()
+	for recordsIt.Next() {
+		if err := db.Put(recordsIt.Key(), recordsIt.Value()); err != nil {
+			return err
+		}
+	}
+	if err := recordsIt.Error(); err != nil {
+		return err
+	}
+
+	utxoIt := avax.NewUTXOIterator(s.state.utxoDB)
+	defer utxoIt.Release()
+	for utxoIt.Next() {
+		utxoID, err := ids.ToID(utxoIt.Key())
+		if err != nil {
+			return err
+		}
+		// UTXOs are tracked before they are modified, so if a UTXO isn't
+		// tracked once its value is read, the value wasn't modified since the
+		// snapshot was taken.
+		value := utxoIt.Value()
+		if s.isModified(utxoID) {
+			continue
+		}
+		if err := db.Put(syncKey(syncUTXOKind, utxoID[:]), value); err != nil {
+			return err
+		}
+	}
+	if err := utxoIt.Error(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for utxoID, utxoBytes := range s.utxos {
+		if utxoBytes == nil {
+			continue
+		}
+		if err := db.Put(syncKey(syncUTXOKind, utxoID[:]), utxoBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *syncSnapshot) isModified(utxoID ids.ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, ok := s.utxos[utxoID]
+	return ok
+}
+
+func (s *syncSnapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.released = true
+	s.utxos = nil
+}
+
+// WriteSyncRecords writes the records of the last committed state into [db].
+//
+// The records only depend on the accepted blocks, so that every node writes
+// the same records at the same height. Only the txs referenced by the state are
+// included and the uptimes of the current validators, which are local to every
+// node, are replaced by full uptimes up to the chain time.
+func (s *state) WriteSyncRecords(db database.KeyValueWriter) error {
+	if err := s.writeSyncUTXOs(db); err != nil {
+		return err
+	}
+	return s.writeSyncRecordsWithoutUTXOs(db)
+}
+
+func (s *state) NewSyncSnapshot() (SyncSnapshot, error) {
+	snapshot := &syncSnapshot{
+		state:   s,
+		records: memdb.New(),
+		utxos:   make(map[ids.ID][]byte),
+	}
+	if err := s.writeSyncRecordsWithoutUTXOs(snapshot.records); err != nil {
+		return nil, err
+	}
+	s.syncSnapshot = snapshot
+	return snapshot, nil
+}
+
+// trackSyncSnapshotUTXO records the value [utxoID] had when the sync snapshot
+// was taken, before it is modified for the first time since.
+func (s *state) trackSyncSnapshotUTXO(utxoID ids.ID) error {
+	snapshot := s.syncSnapshot
+	if snapshot == nil {
+		return nil
+	}
+
+	snapshot.lock.Lock()
+	defer snapshot.lock.Unlock()
+
+	if snapshot.released {
+		s.syncSnapshot = nil
+		return nil
+	}
+	if _, ok := snapshot.utxos[utxoID]; ok {
+		return nil
+	}
+	utxoBytes, err := avax.GetUTXOBytes(s.utxoDB, utxoID)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		utxoBytes = nil
+	default:
+		return err
+	}
+	snapshot.utxos[utxoID] = utxoBytes
+	return nil
+}
+
+func (s *state) writeSyncUTXOs(db database.KeyValueWriter) error {
+	utxoIt := avax.NewUTXOIterator(s.utxoDB)
+	defer utxoIt.Release()
+	for utxoIt.Next() {
+		if err := db.Put(syncKey(syncUTXOKind, utxoIt.Key()), utxoIt.Value()); err != nil {
+			return err
+		}
+	}
+	return utxoIt.Error()
+}
+
+// writeSyncRecordsWithoutUTXOs writes the records of the last committed state,
+// other than its UTXOs, into [db]. Their number is bounded by the number of
+// stakers, subnets and chains.
+func (s *state) writeSyncRecordsWithoutUTXOs(db database.KeyValueWriter) error {
+	for _, key := range syncMetadataKeys {
+		value, err := s.singletonDB.Get(key)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := db.Put(syncKey(syncMetadataKind, key), value); err != nil {
+			return err
+		}
+	}
+
+	for _, vdr := range s.currentStakers.validators[constants.PrimaryNetworkID] {
+		staker := vdr.validator
+		if staker == nil {
+			continue
+		}
+
+		upDuration := s.timestamp.Sub(staker.StartTime)
+		if upDuration < 0 {
+			upDuration = 0
+		}
+		vdrBytes, err := genesis.Codec.Marshal(txs.Version, &uptimeAndReward{
+			UpDuration:      upDuration,
+			LastUpdated:     uint64(s.timestamp.Unix()),
+			PotentialReward: staker.PotentialReward,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to serialize current validator: %w", err)
+		}
+		if err := db.Put(syncKey(syncCurrentValidatorKind, staker.TxID[:]), vdrBytes); err != nil {
+			return err
+		}
+
+		topUps, err := s.GetValidatorTopUps(staker.TxID)
+		if err != nil {
+			return err
+		}
+		for i, topUp := range topUps {
+			topUpID := topUp.ID()
+			key := syncKey(syncValidatorTopUpKind, staker.TxID[:], database.PackUInt32(uint32(i)), topUpID[:])
+			if err := db.Put(key, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	for kind, list := range s.syncLists() {
+		if err := writeSyncList(db, kind, list); err != nil {
+			return err
+		}
+	}
+
+	subnetIDs, err := s.subnetIDs()
+	if err != nil {
+		return err
+	}
+	for _, subnetID := range subnetIDs[1:] {
+		if err := db.Put(syncKey(syncSubnetKind, subnetID[:]), nil); err != nil {
+			return err
+		}
+	}
+
+	transformedSubnetIt := s.transformedSubnetDB.NewIterator()
+	defer transformedSubnetIt.Release()
+	for transformedSubnetIt.Next() {
+		key := syncKey(syncTransformedSubnetKind, transformedSubnetIt.Key())
+		if err := db.Put(key, transformedSubnetIt.Value()); err != nil {
+			return err
+		}
+	}
+	if err := transformedSubnetIt.Error(); err != nil {
+		return err
+	}
+
+	supplyIt := s.subnetSupplyDB.NewIterator()
+	defer supplyIt.Release()
+	for supplyIt.Next() {
+		if err := db.Put(syncKey(syncSubnetSupplyKind, supplyIt.Key()), supplyIt.Value()); err != nil {
+			return err
+		}
+	}
+	if err := supplyIt.Error(); err != nil {
+		return err
+	}
+
+	ownerIt := s.subnetOwnerDB.NewIterator()
+	defer ownerIt.Release()
+	for ownerIt.Next() {
+		if err := db.Put(syncKey(syncSubnetOwnerKind, ownerIt.Key()), ownerIt.Value()); err != nil {
+			return err
+		}
+	}
+	if err := ownerIt.Error(); err != nil {
+		return err
+	}
+
+	for _, subnetID := range subnetIDs {
+		chains, err := s.GetChains(subnetID)
+		if err != nil {
+			return err
+		}
+		for _, chain := range chains {
+			chainID := chain.ID()
+			if err := db.Put(syncKey(syncChainKind, subnetID[:], chainID[:]), nil); err != nil {
+				return err
+			}
+		}
+
+		transfers, err := s.GetSubnetOwnershipTransfers(subnetID)
+		if err != nil {
+			return err
+		}
+		for i, transfer := range transfers {
+			transferID := transfer.ID()
+			key := syncKey(syncSubnetOwnershipTransferKind, subnetID[:], database.PackUInt32(uint32(i)), transferID[:])
+			if err := db.Put(key, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.writeSyncDiffs(db, subnetIDs); err != nil {
+		return err
+	}
+
+	txIDs, err := s.referencedTxIDs()
+	if err != nil {
+		return err
+	}
+	for txID := range txIDs {
+		txBytes, err := s.txDB.Get(txID[:])
+		if err != nil {
+			return fmt.Errorf("failed to get tx %s: %w", txID, err)
+		}
+		if err := db.Put(syncKey(syncTxKind, txID[:]), txBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSyncList(db database.KeyValueWriter, kind byte, list linkeddb.LinkedDB) error {
+	it := list.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		txID, err := ids.ToID(it.Key())
+		if err != nil {
+			return err
+		}
+		if err := db.Put(syncKey(kind, txID[:]), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func (s *state) writeSyncDiffs(db database.KeyValueWriter, subnetIDs []ids.ID) error {
+	lowestHeight := uint64(1)
+	if s.currentHeight > syncDiffHeights {
+		lowestHeight = s.currentHeight - syncDiffHeights + 1
+	}
+	for height := lowestHeight; height <= s.currentHeight; height++ {
+		heightBytes := database.PackUInt64(height)
+		for _, subnetID := range subnetIDs {
+			diffDB, err := s.getValidatorDiffDB(height, subnetID)
+			if err != nil {
+				return err
+			}
+			diffIt := diffDB.NewIterator()
+			for diffIt.Next() {
+				key := syncKey(syncValidatorWeightDiffKind, heightBytes, subnetID[:], diffIt.Key())
+				if err := db.Put(key, diffIt.Value()); err != nil {
+					diffIt.Release()
+					return err
+				}
+			}
+			err = diffIt.Error()
+			diffIt.Release()
+			if err != nil {
+				return err
+			}
+		}
+
+		publicKeyDiffIt := prefixdb.New(heightBytes, s.publicKeyDiffsDB).NewIterator()
+		for publicKeyDiffIt.Next() {
+			key := syncKey(syncPublicKeyDiffKind, heightBytes, publicKeyDiffIt.Key())
+			if err := db.Put(key, publicKeyDiffIt.Value()); err != nil {
+				publicKeyDiffIt.Release()
+				return err
+			}
+		}
+		err := publicKeyDiffIt.Error()
+		publicKeyDiffIt.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) getValidatorDiffDB(height uint64, subnetID ids.ID) (linkeddb.LinkedDB, error) {
+	prefixBytes, err := genesis.Codec.Marshal(txs.Version, heightWithSubnet{
+		Height:   height,
+		SubnetID: subnetID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return linkeddb.NewDefault(prefixdb.New(prefixBytes, s.validatorDiffsDB)), nil
+}
+
+// ApplySyncRecords replaces the state with the records iterated by [it], which
+// were written by WriteSyncRecords, and accepts [blk], the block the records
+// were written after. It must only be called on a state that hasn't accepted
+// any block other than its genesis.
+func (s *state) ApplySyncRecords(it database.Iterator, blk blocks.Block) error {
+	defer s.Abort()
+
+	if err := s.clearSyncedState(); err != nil {
+		return fmt.Errorf("failed to clear state: %w", err)
+	}
+
+	lists := s.syncLists()
+	lists[syncCurrentValidatorKind] = s.currentValidatorList
+	for it.Next() {
+		if err := s.applySyncRecord(lists, it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	// Only the validator set diffs of the last [syncDiffHeights] heights were
+	// synced, so the earlier heights are considered pruned.
+	prunedHeight := uint64(0)
+	if height := blk.Height(); height > syncDiffHeights {
+		prunedHeight = height - syncDiffHeights
+	}
+	if err := database.PutUInt64(s.singletonDB, prunedHeightKey, prunedHeight); err != nil {
+		return err
+	}
+
+	s.AddStatelessBlock(blk, choices.Accepted)
+	if err := s.writeBlocks(); err != nil {
+		return err
+	}
+	if err := s.baseDB.Commit(); err != nil {
+		return err
+	}
+	s.currentHeight = blk.Height()
+	return s.reload()
+}
+
+func (s *state) applySyncRecord(lists map[byte]linkeddb.LinkedDB, key, value []byte) error {
+	if len(key) == 0 {
+		return errMalformedSyncRecord
+	}
+	kind, key := key[0], key[1:]
+	if list, ok := lists[kind]; ok {
+		return list.Put(key, value)
+	}
+
+	switch kind {
+	case syncMetadataKind:
+		return s.singletonDB.Put(key, value)
+	case syncTxKind:
+		return s.txDB.Put(key, value)
+	case syncUTXOKind:
+		utxo := &avax.UTXO{}
+		if _, err := genesis.Codec.Unmarshal(value, utxo); err != nil {
+			return fmt.Errorf("failed to parse synced UTXO: %w", err)
+		}
+		return s.utxoState.PutUTXO(utxo)
+	case syncSubnetKind:
+		return s.subnetDB.Put(key, nil)
+	case syncTransformedSubnetKind:
+		return s.transformedSubnetDB.Put(key, value)
+	case syncSubnetSupplyKind:
+		return s.subnetSupplyDB.Put(key, value)
+	case syncSubnetOwnerKind:
+		return s.subnetOwnerDB.Put(key, value)
+	case syncChainKind:
+		if len(key) != 2*hashing.HashLen {
+			return errMalformedSyncRecord
+		}
+		subnetID, _ := ids.ToID(key[:hashing.HashLen])
+		return s.getChainDB(subnetID).Put(key[hashing.HashLen:], nil)
+	case syncSubnetOwnershipTransferKind:
+		if len(key) != 2*hashing.HashLen+wrappers.IntLen {
+			return errMalformedSyncRecord
+		}
+		subnetID, _ := ids.ToID(key[:hashing.HashLen])
+		return s.getSubnetOwnershipTransferDB(subnetID).Put(key[hashing.HashLen+wrappers.IntLen:], nil)
+	case syncValidatorTopUpKind:
+		if len(key) != 2*hashing.HashLen+wrappers.IntLen {
+			return errMalformedSyncRecord
+		}
+		validatorTxID, _ := ids.ToID(key[:hashing.HashLen])
+		return s.getValidatorTopUpDB(validatorTxID).Put(key[hashing.HashLen+wrappers.IntLen:], nil)
+	case syncValidatorWeightDiffKind:
+		if len(key) != wrappers.LongLen+hashing.HashLen+hashing.AddrLen {
+			return errMalformedSyncRecord
+		}
+		height := binary.BigEndian.Uint64(key)
+		subnetID, _ := ids.ToID(key[wrappers.LongLen : wrappers.LongLen+hashing.HashLen])
+		diffDB, err := s.getValidatorDiffDB(height, subnetID)
+		if err != nil {
+			return err
+		}
+		return diffDB.Put(key[wrappers.LongLen+hashing.HashLen:], value)
+	case syncPublicKeyDiffKind:
+		if len(key) != wrappers.LongLen+hashing.AddrLen {
+			return errMalformedSyncRecord
+		}
+		return prefixdb.New(key[:wrappers.LongLen], s.publicKeyDiffsDB).Put(key[wrappers.LongLen:], value)
+	default:
+		return fmt.Errorf("%w: %d", errUnknownSyncRecordKind, kind)
+	}
+}
+
+// clearSyncedState removes everything that is replaced by the sync records,
+// other than the metadata, which is overwritten.
+func (s *state) clearSyncedState() error {
+	subnetIDs, err := s.subnetIDs()
+	if err != nil {
+		return err
+	}
+
+	validatorTxIDs, err := linkedDBKeys(s.currentValidatorList)
+	if err != nil {
+		return err
+	}
+
+	toClear := []linkeddb.LinkedDB{s.currentValidatorList, s.subnetDB}
+	for _, list := range s.syncLists() {
+		toClear = append(toClear, list)
+	}
+	for _, subnetID := range subnetIDs {
+		toClear = append(toClear,
+			s.getChainDB(subnetID),
+			s.getSubnetOwnershipTransferDB(subnetID),
+		)
+	}
+	for _, txID := range validatorTxIDs {
+		validatorTxID, err := ids.ToID(txID)
+		if err != nil {
+			return err
+		}
+		toClear = append(toClear, s.getValidatorTopUpDB(validatorTxID))
+	}
+	for _, list := range toClear {
+		keys, err := linkedDBKeys(list)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := list.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, db := range []database.Database{s.transformedSubnetDB, s.subnetSupplyDB, s.subnetOwnerDB} {
+		keys, err := databaseKeys(db.NewIterator())
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := db.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	utxoIDs, err := databaseKeys(avax.NewUTXOIterator(s.utxoDB))
+	if err != nil {
+		return err
+	}
+	for _, utxoIDBytes := range utxoIDs {
+		utxoID, err := ids.ToID(utxoIDBytes)
+		if err != nil {
+			return err
+		}
+		if err := s.utxoState.DeleteUTXO(utxoID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reload drops everything cached in memory and loads the state from disk.
+func (s *state) reload() error {
+	for _, c := range []interface{ Flush() }{
+		s.blockCache,
+		s.validatorDiffsCache,
+		s.publicKeyDiffsCache,
+		s.txCache,
+		s.rewardUTXOsCache,
+		s.transformedSubnetCache,
+		s.subnetOwnershipTransferCache,
+		s.subnetOwnerCache,
+		s.validatorTopUpCache,
+		s.subnetSupplyCache,
+		s.chainCache,
+		s.chainDBCache,
+	} {
+		c.Flush()
+	}
+	s.cachedSubnets = nil
+	s.uptimes = make(map[ids.NodeID]*uptimeAndReward)
+	s.updatedUptimes = make(map[ids.NodeID]struct{})
+	return s.load()
+}
+
+func linkedDBKeys(list linkeddb.LinkedDB) ([][]byte, error) {
+	it := list.NewIterator()
+	defer it.Release()
+
+	keys := [][]byte(nil)
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys, it.Error()
+}
+
+func databaseKeys(it database.Iterator) ([][]byte, error) {
+	defer it.Release()
+
+	keys := [][]byte(nil)
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys, it.Error()
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/memdb"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/choices"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/units"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/status"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestSyncRecords(t *testing.T) {
+	require := require.New(t)
+
+	source, _ := newInitializedState(require)
+
+	createSubnetTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		Owner: &secp256k1fx.OutputOwners{},
+	}}
+	require.NoError(createSubnetTx.Sign(txs.Codec, nil))
+	subnetID := createSubnetTx.ID()
+
+	transformSubnetTx := &txs.Tx{Unsigned: &txs.TransformSubnetTx{
+		Subnet:     subnetID,
+		AssetID:    ids.GenerateTestID(),
+		SubnetAuth: &secp256k1fx.Input{},
+	}}
+	require.NoError(transformSubnetTx.Sign(txs.Codec, nil))
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: createSubnetTx.ID()},
+		Asset:  avax.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.Avax,
+		},
+	}
+
+	chainTime := initialTime.Add(time.Hour)
+	source.SetTimestamp(chainTime)
+	source.AddTx(createSubnetTx, status.Committed)
+	source.AddSubnet(createSubnetTx)
+	source.AddTx(transformSubnetTx, status.Committed)
+	source.AddSubnetTransformation(transformSubnetTx)
+	source.SetSubnetCurrentSupply(subnetID, units.Avax)
+	source.AddUTXO(utxo)
+
+	blk, err := blocks.NewCommitBlock(source.GetLastAccepted(), 1)
+	require.NoError(err)
+	source.AddStatelessBlock(blk, choices.Accepted)
+	source.SetLastAccepted(blk.ID())
+	source.SetHeight(1)
+	require.NoError(source.Commit())
+
+	records := memdb.New()
+	require.NoError(source.WriteSyncRecords(records))
+
+	// The records don't depend on the uptimes tracked by the node
+	require.NoError(source.SetUptime(initialNodeID, time.Minute, chainTime))
+	otherRecords := memdb.New()
+	require.NoError(source.WriteSyncRecords(otherRecords))
+	require.Equal(databaseContents(require, records), databaseContents(require, otherRecords))
+
+	synced, db := newInitializedState(require)
+	it := records.NewIterator()
+	defer it.Release()
+	require.NoError(synced.ApplySyncRecords(it, blk))
+
+	reopened := newStateFromDB(require, db)
+	require.NoError(reopened.(*state).load())
+
+	for _, s := range []State{synced, reopened} {
+		require.Equal(blk.ID(), s.GetLastAccepted())
+		require.Equal(chainTime.Unix(), s.GetTimestamp().Unix())
+		require.Equal(source.GetCurrentSupply(), s.GetCurrentSupply())
+
+		_, blkStatus, err := s.GetStatelessBlock(blk.ID())
+		require.NoError(err)
+		require.Equal(choices.Accepted, blkStatus)
+
+		fetchedUTXO, err := s.GetUTXO(utxo.InputID())
+		require.NoError(err)
+		require.Equal(utxo.InputID(), fetchedUTXO.InputID())
+
+		subnets, err := s.GetSubnets()
+		require.NoError(err)
+		require.Len(subnets, 1)
+		require.Equal(subnetID, subnets[0].ID())
+
+		fetchedTx, err := s.GetSubnetTransformation(subnetID)
+		require.NoError(err)
+		require.Equal(transformSubnetTx.ID(), fetchedTx.ID())
+
+		supply, err := s.GetSubnetCurrentSupply(subnetID)
+		require.NoError(err)
+		require.Equal(units.Avax, supply)
+
+		chains, err := s.GetChains(constants.PrimaryNetworkID)
+		require.NoError(err)
+		require.Len(chains, 1)
+
+		staker, err := s.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+		require.NoError(err)
+		require.Equal(units.Avax, staker.Weight)
+
+		upDuration, lastUpdated, err := s.GetUptime(initialNodeID)
+		require.NoError(err)
+		require.Equal(time.Hour, upDuration)
+		require.Equal(chainTime.Unix(), lastUpdated.Unix())
+	}
+}
+
+func TestSyncSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newInitializedState(require)
+
+	newUTXO := func() *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: initialTxID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Avax,
+			},
+		}
+	}
+	consumedUTXO := newUTXO()
+	modifiedUTXO := newUTXO()
+	s.AddUTXO(consumedUTXO)
+	s.AddUTXO(modifiedUTXO)
+	require.NoError(s.Commit())
+
+	expectedRecords := memdb.New()
+	require.NoError(s.WriteSyncRecords(expectedRecords))
+
+	snapshot, err := s.NewSyncSnapshot()
+	require.NoError(err)
+
+	// The UTXOs modified after the snapshot was taken are written with their
+	// values at the time.
+	s.DeleteUTXO(consumedUTXO.InputID())
+	s.AddUTXO(newUTXO())
+	s.DeleteUTXO(modifiedUTXO.InputID())
+	require.NoError(s.Commit())
+	s.AddUTXO(modifiedUTXO)
+	require.NoError(s.Commit())
+
+	records := memdb.New()
+	require.NoError(snapshot.Write(records))
+	require.Equal(databaseContents(require, expectedRecords), databaseContents(require, records))
+
+	// Released snapshots stop tracking the UTXOs
+	snapshot.Release()
+	s.AddUTXO(newUTXO())
+	require.NoError(s.Commit())
+	require.Nil(s.(*state).syncSnapshot)
+}
+
+func databaseContents(require *require.Assertions, db database.Database) map[string][]byte {
+	it := db.NewIterator()
+	defer it.Release()
+
+	contents := make(map[string][]byte)
+	for it.Next() {
+		contents[string(it.Key())] = it.Value()
+	}
+	require.NoError(it.Error())
+	return contents
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/kukrer/savannahnode/snow"
 	"github.com/kukrer/savannahnode/snow/validators"
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
 	"github.com/kukrer/savannahnode/utils/units"
 	"github.com/kukrer/savannahnode/utils/wrappers"
 	"github.com/kukrer/savannahnode/vms/components/avax"
@@ -26,6 +27,7 @@ import (
 	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
 	"github.com/kukrer/savannahnode/vms/platformvm/metrics"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/status"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/validator"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
@@ -253,6 +255,48 @@ func TestGetValidatorWeightDiffs(t *testing.T) {
 	}
 }
 
+func TestGetValidatorPublicKeyDiffs(t *testing.T) {
+	require := require.New(t)
+	stateIntf, _ := newInitializedState(require)
+	state := stateIntf.(*state)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	staker := &Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    ids.GenerateTestNodeID(),
+		PublicKey: pk,
+		SubnetID:  constants.PrimaryNetworkID,
+		Weight:    1,
+	}
+
+	// Adding a validator doesn't produce a public key diff.
+	state.PutCurrentValidator(staker)
+	state.SetHeight(1)
+	require.NoError(state.Commit())
+
+	publicKeyDiffs, err := state.GetValidatorPublicKeyDiffs(1)
+	require.NoError(err)
+	require.Empty(publicKeyDiffs)
+
+	// Removing a validator records its public key.
+	state.DeleteCurrentValidator(staker)
+	state.SetHeight(2)
+	require.NoError(state.Commit())
+
+	for i := 0; i < 2; i++ {
+		publicKeyDiffs, err = state.GetValidatorPublicKeyDiffs(2)
+		require.NoError(err)
+		require.Len(publicKeyDiffs, 1)
+		require.Equal(bls.PublicKeyToBytes(pk), bls.PublicKeyToBytes(publicKeyDiffs[staker.NodeID]))
+
+		// Make sure the diff is read from the database.
+		state.publicKeyDiffsCache.Flush()
+	}
+}
+
 func newInitializedState(require *require.Assertions) (State, database.Database) {
 	s, db := newUninitializedState(require)
 
@@ -486,3 +530,120 @@ func TestValidatorWeightDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestSubnetTransformationAndSupply(t *testing.T) {
+	require := require.New(t)
+	s, db := newInitializedState(require)
+
+	subnetID := ids.GenerateTestID()
+	_, err := s.GetSubnetTransformation(subnetID)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = s.GetSubnetCurrentSupply(subnetID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	transformSubnetTx := &txs.Tx{Unsigned: &txs.TransformSubnetTx{
+		Subnet:     subnetID,
+		AssetID:    ids.GenerateTestID(),
+		SubnetAuth: &secp256k1fx.Input{},
+	}}
+	require.NoError(transformSubnetTx.Sign(txs.Codec, nil))
+
+	s.AddTx(transformSubnetTx, status.Committed)
+	s.AddSubnetTransformation(transformSubnetTx)
+	s.SetSubnetCurrentSupply(subnetID, units.Avax)
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+
+	fetchedTx, err := s.GetSubnetTransformation(subnetID)
+	require.NoError(err)
+	require.Equal(transformSubnetTx.ID(), fetchedTx.ID())
+
+	supply, err := s.GetSubnetCurrentSupply(subnetID)
+	require.NoError(err)
+	require.Equal(units.Avax, supply)
+}
+
+func TestSubnetOwner(t *testing.T) {
+	require := require.New(t)
+	s, db := newInitializedState(require)
+
+	createOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	createSubnetTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			BlockchainID: ids.GenerateTestID(),
+		}},
+		Owner: createOwner,
+	}}
+	require.NoError(createSubnetTx.Sign(txs.Codec, nil))
+	subnetID := createSubnetTx.ID()
+
+	s.AddTx(createSubnetTx, status.Committed)
+	s.AddSubnet(createSubnetTx)
+	require.NoError(s.Commit())
+
+	owner, err := s.GetSubnetOwner(subnetID)
+	require.NoError(err)
+	require.Equal(createOwner, owner)
+
+	transferOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	transferTx := &txs.Tx{Unsigned: &txs.TransferSubnetOwnershipTx{
+		Subnet:     subnetID,
+		SubnetAuth: &secp256k1fx.Input{},
+		Owner:      transferOwner,
+	}}
+	require.NoError(transferTx.Sign(txs.Codec, nil))
+
+	s.AddTx(transferTx, status.Committed)
+	s.AddSubnetOwnershipTransfer(transferTx)
+	s.SetSubnetOwner(subnetID, transferOwner)
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+
+	owner, err = s.GetSubnetOwner(subnetID)
+	require.NoError(err)
+	require.Equal(transferOwner, owner)
+}
+
+func TestValidatorTopUpsDeletedWithValidator(t *testing.T) {
+	require := require.New(t)
+	s, db := newInitializedState(require)
+
+	staker, err := s.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+
+	topUpTx := &txs.Tx{Unsigned: &txs.TopUpValidatorTx{
+		NodeID:        initialNodeID,
+		End:           uint64(initialValidatorEndTime.Unix()),
+		ValidatorAuth: &secp256k1fx.Input{},
+	}}
+	require.NoError(topUpTx.Sign(txs.Codec, nil))
+
+	s.AddTx(topUpTx, status.Committed)
+	s.AddValidatorTopUp(staker.TxID, topUpTx)
+	require.NoError(s.Commit())
+
+	topUps, err := s.GetValidatorTopUps(staker.TxID)
+	require.NoError(err)
+	require.Len(topUps, 1)
+
+	s.DeleteCurrentValidator(staker)
+	require.NoError(s.Commit())
+
+	topUps, err = s.GetValidatorTopUps(staker.TxID)
+	require.NoError(err)
+	require.Empty(topUps)
+
+	s = newStateFromDB(require, db)
+
+	topUps, err = s.GetValidatorTopUps(staker.TxID)
+	require.NoError(err)
+	require.Empty(topUps)
+}
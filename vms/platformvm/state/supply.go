@@ -0,0 +1,375 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/genesis"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
+	"github.com/kukrer/savannahnode/vms/platformvm/status"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+)
+
+var supplyBreakdownKey = []byte("supply breakdown")
+
+// SupplyBreakdown describes where the AVAX of the P-chain went
+type SupplyBreakdown struct {
+	// RewardsMinted is the AVAX minted to reward the primary network stakers
+	RewardsMinted uint64 `serialize:"true"`
+	// BurnedFees is the AVAX burned by the P-chain txs
+	BurnedFees uint64 `serialize:"true"`
+	// StakeableLocks are the AVAX held in stakeable.LockOut and
+	// stakeable.VestingOut UTXOs by the time they unlock at, sorted by
+	// locktime
+	StakeableLocks []stakeable.Tranche `serialize:"true"`
+	// AtomicFlows are the AVAX moved between the P-chain and each chain it
+	// exchanged AVAX with, sorted by chain ID
+	AtomicFlows []AtomicFlow `serialize:"true"`
+
+	// StakeableLocked is the AVAX of [StakeableLocks] that is still locked at
+	// the chain timestamp. It is derived rather than persisted, as it
+	// decreases as the locks expire.
+	StakeableLocked uint64
+
+	// ValidatorStake and DelegatorStake are the AVAX staked by the current
+	// primary network validators and delegators. They are derived from the
+	// current stakers rather than persisted.
+	ValidatorStake uint64
+	DelegatorStake uint64
+}
+
+// AtomicFlow is the AVAX moved between the P-chain and [ChainID] through
+// shared memory
+type AtomicFlow struct {
+	ChainID ids.ID `serialize:"true"`
+	// Exported is the AVAX the P-chain exported to [ChainID]
+	Exported uint64 `serialize:"true"`
+	// Imported is the AVAX the P-chain imported from [ChainID]
+	Imported uint64 `serialize:"true"`
+}
+
+func (s *state) GetSupplyBreakdown() (SupplyBreakdown, error) {
+	breakdown := s.supplyBreakdown
+	breakdown.AtomicFlows = append([]AtomicFlow(nil), breakdown.AtomicFlows...)
+	breakdown.StakeableLocks = append([]stakeable.Tranche(nil), breakdown.StakeableLocks...)
+	breakdown.StakeableLocked = stakeable.LockedAmount(breakdown.StakeableLocks, uint64(s.GetTimestamp().Unix()))
+	for _, vdr := range s.currentStakers.validators[constants.PrimaryNetworkID] {
+		if vdr.validator != nil {
+			stake, err := math.Add64(breakdown.ValidatorStake, vdr.validator.Weight)
+			if err != nil {
+				return SupplyBreakdown{}, err
+			}
+			breakdown.ValidatorStake = stake
+		}
+
+		delegatorIt := NewTreeIterator(vdr.delegators)
+		for delegatorIt.Next() {
+			stake, err := math.Add64(breakdown.DelegatorStake, delegatorIt.Value().Weight)
+			if err != nil {
+				delegatorIt.Release()
+				return SupplyBreakdown{}, err
+			}
+			breakdown.DelegatorStake = stake
+		}
+		delegatorIt.Release()
+	}
+	return breakdown, nil
+}
+
+// atomicFlow returns the AVAX moved between the P-chain and [chainID]
+func (b *SupplyBreakdown) atomicFlow(chainID ids.ID) *AtomicFlow {
+	i := 0
+	for ; i < len(b.AtomicFlows); i++ {
+		flowChainID := b.AtomicFlows[i].ChainID
+		if flowChainID == chainID {
+			return &b.AtomicFlows[i]
+		}
+		if bytes.Compare(chainID[:], flowChainID[:]) < 0 {
+			break
+		}
+	}
+	b.AtomicFlows = append(b.AtomicFlows, AtomicFlow{})
+	copy(b.AtomicFlows[i+1:], b.AtomicFlows[i:])
+	b.AtomicFlows[i] = AtomicFlow{ChainID: chainID}
+	return &b.AtomicFlows[i]
+}
+
+// addTx adds the AVAX burned, imported and exported by [tx] to the breakdown
+func (b *SupplyBreakdown) addTx(tx txs.UnsignedTx, avaxAssetID ids.ID) error {
+	var (
+		ins      []*avax.TransferableInput
+		outs     = [][]*avax.TransferableOutput{tx.Outputs()}
+		imported uint64
+		exported uint64
+		err      error
+	)
+	if tx, ok := tx.(interface {
+		Inputs() []*avax.TransferableInput
+	}); ok {
+		ins = tx.Inputs()
+	}
+	switch tx := tx.(type) {
+	case *txs.AddValidatorTx:
+		outs = append(outs, tx.Stake)
+	case *txs.AddDelegatorTx:
+		outs = append(outs, tx.Stake)
+	case *txs.AddPermissionlessValidatorTx:
+		outs = append(outs, tx.StakeOuts)
+	case *txs.AddPermissionlessDelegatorTx:
+		outs = append(outs, tx.StakeOuts)
+	case *txs.TopUpValidatorTx:
+		outs = append(outs, tx.Stake)
+	case *txs.ImportTx:
+		imported, err = avaxInputAmount(tx.ImportedInputs, avaxAssetID)
+		if err != nil {
+			return err
+		}
+		flow := b.atomicFlow(tx.SourceChain)
+		flow.Imported, err = math.Add64(flow.Imported, imported)
+		if err != nil {
+			return err
+		}
+	case *txs.ExportTx:
+		exported, err = avaxOutputAmount(tx.ExportedOutputs, avaxAssetID)
+		if err != nil {
+			return err
+		}
+		flow := b.atomicFlow(tx.DestinationChain)
+		flow.Exported, err = math.Add64(flow.Exported, exported)
+		if err != nil {
+			return err
+		}
+	}
+
+	consumed, err := avaxInputAmount(ins, avaxAssetID)
+	if err != nil {
+		return err
+	}
+	consumed, err = math.Add64(consumed, imported)
+	if err != nil {
+		return err
+	}
+	produced := exported
+	for _, outs := range outs {
+		amount, err := avaxOutputAmount(outs, avaxAssetID)
+		if err != nil {
+			return err
+		}
+		produced, err = math.Add64(produced, amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Only the genesis txs produce AVAX without consuming it, and they don't
+	// burn any.
+	if consumed <= produced {
+		return nil
+	}
+	b.BurnedFees, err = math.Add64(b.BurnedFees, consumed-produced)
+	return err
+}
+
+// addRewardUTXO adds the AVAX minted by the reward [utxo] to the breakdown
+func (b *SupplyBreakdown) addRewardUTXO(utxo *avax.UTXO, avaxAssetID ids.ID) error {
+	if utxo.AssetID() != avaxAssetID {
+		return nil
+	}
+	out, ok := utxo.Out.(avax.Amounter)
+	if !ok {
+		return nil
+	}
+	var err error
+	b.RewardsMinted, err = math.Add64(b.RewardsMinted, out.Amount())
+	return err
+}
+
+// stakeableLock returns the AVAX locked until [locktime]
+func (b *SupplyBreakdown) stakeableLock(locktime uint64) *stakeable.Tranche {
+	i := 0
+	for ; i < len(b.StakeableLocks); i++ {
+		lockLocktime := b.StakeableLocks[i].Locktime
+		if lockLocktime == locktime {
+			return &b.StakeableLocks[i]
+		}
+		if locktime < lockLocktime {
+			break
+		}
+	}
+	b.StakeableLocks = append(b.StakeableLocks, stakeable.Tranche{})
+	copy(b.StakeableLocks[i+1:], b.StakeableLocks[i:])
+	b.StakeableLocks[i] = stakeable.Tranche{Locktime: locktime}
+	return &b.StakeableLocks[i]
+}
+
+// updateStakeableLocked adds the AVAX locked by the produced [utxo] to the
+// breakdown, or removes it if the [utxo] is consumed
+func (b *SupplyBreakdown) updateStakeableLocked(utxo *avax.UTXO, consumed bool, avaxAssetID ids.ID) error {
+	if utxo.AssetID() != avaxAssetID {
+		return nil
+	}
+	var locks []stakeable.Tranche
+	switch out := utxo.Out.(type) {
+	case *stakeable.LockOut:
+		locks = []stakeable.Tranche{{
+			Locktime: out.Locktime,
+			Amount:   out.Amount(),
+		}}
+	case *stakeable.VestingOut:
+		locks = out.Tranches
+	default:
+		return nil
+	}
+
+	for _, lock := range locks {
+		breakdownLock := b.stakeableLock(lock.Locktime)
+		var err error
+		if consumed {
+			breakdownLock.Amount, err = math.Sub64(breakdownLock.Amount, lock.Amount)
+		} else {
+			breakdownLock.Amount, err = math.Add64(breakdownLock.Amount, lock.Amount)
+		}
+		if err != nil {
+			return err
+		}
+		if breakdownLock.Amount == 0 {
+			b.removeStakeableLock(lock.Locktime)
+		}
+	}
+	return nil
+}
+
+// removeStakeableLock removes the emptied lock until [locktime]
+func (b *SupplyBreakdown) removeStakeableLock(locktime uint64) {
+	for i, lock := range b.StakeableLocks {
+		if lock.Locktime == locktime {
+			b.StakeableLocks = append(b.StakeableLocks[:i], b.StakeableLocks[i+1:]...)
+			return
+		}
+	}
+}
+
+func avaxInputAmount(ins []*avax.TransferableInput, avaxAssetID ids.ID) (uint64, error) {
+	amount := uint64(0)
+	for _, in := range ins {
+		if in.AssetID() != avaxAssetID {
+			continue
+		}
+		var err error
+		amount, err = math.Add64(amount, in.In.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return amount, nil
+}
+
+func avaxOutputAmount(outs []*avax.TransferableOutput, avaxAssetID ids.ID) (uint64, error) {
+	amount := uint64(0)
+	for _, out := range outs {
+		if out.AssetID() != avaxAssetID {
+			continue
+		}
+		var err error
+		amount, err = math.Add64(amount, out.Out.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return amount, nil
+}
+
+// loadSupplyBreakdown loads the supply breakdown. Databases created before the
+// breakdown was tracked are indexed once, from the txs and UTXOs they hold.
+func (s *state) loadSupplyBreakdown() error {
+	breakdownBytes, err := s.singletonDB.Get(supplyBreakdownKey)
+	switch err {
+	case nil:
+		s.supplyBreakdown = SupplyBreakdown{}
+		_, err := genesis.Codec.Unmarshal(breakdownBytes, &s.supplyBreakdown)
+		return err
+	case database.ErrNotFound:
+		if err := s.indexSupplyBreakdown(); err != nil {
+			return fmt.Errorf("failed to index supply breakdown: %w", err)
+		}
+		s.supplyBreakdownModified = true
+		return nil
+	default:
+		return err
+	}
+}
+
+func (s *state) indexSupplyBreakdown() error {
+	avaxAssetID := s.ctx.AVAXAssetID
+	s.supplyBreakdown = SupplyBreakdown{}
+
+	txIt := s.txDB.NewIterator()
+	defer txIt.Release()
+	for txIt.Next() {
+		stx := txBytesAndStatus{}
+		if _, err := genesis.Codec.Unmarshal(txIt.Value(), &stx); err != nil {
+			return err
+		}
+		tx, err := txs.Parse(genesis.Codec, stx.Tx)
+		if err != nil {
+			return err
+		}
+		if err := s.supplyBreakdown.addTx(tx.Unsigned, avaxAssetID); err != nil {
+			return err
+		}
+
+		rewardTx, ok := tx.Unsigned.(*txs.RewardValidatorTx)
+		if !ok || stx.Status != status.Committed {
+			continue
+		}
+		rewardUTXOs, err := s.GetRewardUTXOs(rewardTx.TxID)
+		if err != nil {
+			return err
+		}
+		for _, utxo := range rewardUTXOs {
+			if err := s.supplyBreakdown.addRewardUTXO(utxo, avaxAssetID); err != nil {
+				return err
+			}
+		}
+	}
+	if err := txIt.Error(); err != nil {
+		return err
+	}
+
+	utxoIt := avax.NewUTXOIterator(s.utxoDB)
+	defer utxoIt.Release()
+	for utxoIt.Next() {
+		utxo := &avax.UTXO{}
+		if _, err := txs.Codec.Unmarshal(utxoIt.Value(), utxo); err != nil {
+			return err
+		}
+		if err := s.supplyBreakdown.updateStakeableLocked(utxo, false, avaxAssetID); err != nil {
+			return err
+		}
+	}
+	return utxoIt.Error()
+}
+
+func (s *state) writeSupplyBreakdown() error {
+	if !s.supplyBreakdownModified {
+		return nil
+	}
+	s.supplyBreakdownModified = false
+
+	breakdownBytes, err := genesis.Codec.Marshal(txs.Version, &s.supplyBreakdown)
+	if err != nil {
+		return fmt.Errorf("failed to serialize supply breakdown: %w", err)
+	}
+	if err := s.singletonDB.Put(supplyBreakdownKey, breakdownBytes); err != nil {
+		return fmt.Errorf("failed to write supply breakdown: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/units"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
+	"github.com/kukrer/savannahnode/vms/platformvm/status"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestSupplyBreakdown(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+	require.NoError(s.Commit())
+
+	// The test context uses the empty ID as the AVAX asset ID
+	avaxAssetID := ids.Empty
+	avaxIn := func(amount uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: avaxAssetID},
+			In:     &secp256k1fx.TransferInput{Amt: amount},
+		}
+	}
+	avaxOut := func(amount uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+	now := s.GetTimestamp()
+	lockedUTXO := func(amount uint64) *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: avaxAssetID},
+			Out: &stakeable.LockOut{
+				Locktime:        uint64(now.Add(time.Hour).Unix()),
+				TransferableOut: &secp256k1fx.TransferOutput{Amt: amount},
+			},
+		}
+	}
+	// The first tranche already unlocked at the chain timestamp
+	vestingUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: avaxAssetID},
+		Out: &stakeable.VestingOut{
+			Tranches: []stakeable.Tranche{
+				{
+					Locktime: uint64(now.Unix()) - 1,
+					Amount:   2,
+				},
+				{
+					Locktime: uint64(now.Add(2 * time.Hour).Unix()),
+					Amount:   4,
+				},
+			},
+			TransferableOut: &secp256k1fx.TransferOutput{Amt: 6},
+		},
+	}
+
+	breakdown, err := s.GetSupplyBreakdown()
+	require.NoError(err)
+	require.Equal(SupplyBreakdown{
+		// The genesis validator doesn't burn its stake
+		ValidatorStake: units.Avax,
+	}, breakdown)
+
+	chainID := ids.GenerateTestID()
+	importTx := &txs.Tx{Unsigned: &txs.ImportTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Outs: []*avax.TransferableOutput{avaxOut(9)},
+		}},
+		SourceChain:    chainID,
+		ImportedInputs: []*avax.TransferableInput{avaxIn(10)},
+	}}
+	require.NoError(importTx.Sign(txs.Codec, nil))
+	exportTx := &txs.Tx{Unsigned: &txs.ExportTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins:  []*avax.TransferableInput{avaxIn(7)},
+			Outs: []*avax.TransferableOutput{avaxOut(1)},
+		}},
+		DestinationChain: chainID,
+		ExportedOutputs:  []*avax.TransferableOutput{avaxOut(5)},
+	}}
+	require.NoError(exportTx.Sign(txs.Codec, nil))
+	stakerTxID := ids.GenerateTestID()
+	rewardTx := &txs.Tx{Unsigned: &txs.RewardValidatorTx{
+		TxID: stakerTxID,
+	}}
+	require.NoError(rewardTx.Sign(txs.Codec, nil))
+	consumedUTXO := lockedUTXO(5)
+	keptUTXO := lockedUTXO(6)
+
+	s.AddTx(importTx, status.Committed)
+	s.AddTx(exportTx, status.Committed)
+	s.AddTx(rewardTx, status.Committed)
+	s.AddRewardUTXO(stakerTxID, &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: stakerTxID},
+		Asset:  avax.Asset{ID: avaxAssetID},
+		Out:    &secp256k1fx.TransferOutput{Amt: 3},
+	})
+	s.AddUTXO(consumedUTXO)
+	s.AddUTXO(keptUTXO)
+	s.AddUTXO(vestingUTXO)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	s.DeleteUTXO(consumedUTXO.InputID())
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	expectedBreakdown := SupplyBreakdown{
+		RewardsMinted: 3,
+		BurnedFees:    2,
+		StakeableLocks: []stakeable.Tranche{
+			{
+				Locktime: uint64(now.Unix()) - 1,
+				Amount:   2,
+			},
+			{
+				Locktime: uint64(now.Add(time.Hour).Unix()),
+				Amount:   6,
+			},
+			{
+				Locktime: uint64(now.Add(2 * time.Hour).Unix()),
+				Amount:   4,
+			},
+		},
+		StakeableLocked: 10,
+		AtomicFlows: []AtomicFlow{{
+			ChainID:  chainID,
+			Exported: 5,
+			Imported: 10,
+		}},
+		ValidatorStake: units.Avax,
+	}
+	breakdown, err = s.GetSupplyBreakdown()
+	require.NoError(err)
+	require.Equal(expectedBreakdown, breakdown)
+
+	// The breakdown is persisted
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).load())
+	breakdown, err = s.GetSupplyBreakdown()
+	require.NoError(err)
+	require.Equal(expectedBreakdown, breakdown)
+
+	// The breakdown of a database that doesn't track it is indexed
+	require.NoError(s.(*state).singletonDB.Delete(supplyBreakdownKey))
+	require.NoError(s.Commit())
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).load())
+	breakdown, err = s.GetSupplyBreakdown()
+	require.NoError(err)
+	require.Equal(expectedBreakdown, breakdown)
+
+	// The locks that expire as the chain advances are no longer locked
+	s.SetTimestamp(now.Add(time.Hour))
+	breakdown, err = s.GetSupplyBreakdown()
+	require.NoError(err)
+	require.EqualValues(4, breakdown.StakeableLocked)
+}
+
+func TestSupplyBreakdownAtomicFlowsSorted(t *testing.T) {
+	require := require.New(t)
+
+	chainIDs := []ids.ID{{2}, {0}, {1}, {2}}
+	breakdown := SupplyBreakdown{}
+	for _, chainID := range chainIDs {
+		breakdown.atomicFlow(chainID).Exported++
+	}
+	require.Equal([]AtomicFlow{
+		{ChainID: ids.ID{0}, Exported: 1},
+		{ChainID: ids.ID{1}, Exported: 1},
+		{ChainID: ids.ID{2}, Exported: 2},
+	}, breakdown.AtomicFlows)
+}
+
+func TestSupplyBreakdownStakeableLocksSorted(t *testing.T) {
+	require := require.New(t)
+
+	lockedUTXO := func(locktime uint64) *avax.UTXO {
+		return &avax.UTXO{
+			Out: &stakeable.LockOut{
+				Locktime:        locktime,
+				TransferableOut: &secp256k1fx.TransferOutput{Amt: 1},
+			},
+		}
+	}
+
+	breakdown := SupplyBreakdown{}
+	for _, locktime := range []uint64{2, 0, 1, 2} {
+		require.NoError(breakdown.updateStakeableLocked(lockedUTXO(locktime), false, ids.Empty))
+	}
+	require.Equal([]stakeable.Tranche{
+		{Locktime: 0, Amount: 1},
+		{Locktime: 1, Amount: 1},
+		{Locktime: 2, Amount: 2},
+	}, breakdown.StakeableLocks)
+
+	// The locks that no longer hold any AVAX are dropped
+	require.NoError(breakdown.updateStakeableLocked(lockedUTXO(1), true, ids.Empty))
+	require.Equal([]stakeable.Tranche{
+		{Locktime: 0, Amount: 1},
+		{Locktime: 2, Amount: 2},
+	}, breakdown.StakeableLocks)
+
+	// Consuming more than is locked is an error
+	require.Error(breakdown.updateStakeableLocked(lockedUTXO(1), true, ids.Empty))
+}
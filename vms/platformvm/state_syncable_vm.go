@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/snow/engine/snowman/block"
+	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/message"
+	"github.com/kukrer/savannahnode/vms/platformvm/statesync"
+)
+
+var (
+	_ block.StateSummary = &stateSummary{}
+
+	errSummaryHeightMismatch = errors.New("summary height doesn't match its block")
+)
+
+// stateSummary implements block.StateSummary for the state summaries of the
+// platform chain.
+type stateSummary struct {
+	summary *statesync.Summary
+	vm      *VM
+}
+
+func (s *stateSummary) ID() ids.ID     { return s.summary.ID() }
+func (s *stateSummary) Height() uint64 { return s.summary.Height }
+func (s *stateSummary) Bytes() []byte  { return s.summary.Bytes() }
+
+func (s *stateSummary) Accept() (bool, error) {
+	return s.vm.acceptStateSummary(s.summary)
+}
+
+func (vm *VM) StateSyncEnabled() (bool, error) {
+	return vm.stateSyncer != nil, nil
+}
+
+func (vm *VM) GetOngoingSyncStateSummary() (block.StateSummary, error) {
+	if vm.stateSyncer == nil {
+		return nil, database.ErrNotFound
+	}
+
+	summary, err := vm.stateSyncer.OngoingSummary()
+	if err != nil {
+		return nil, err // includes database.ErrNotFound case
+	}
+	return &stateSummary{summary: summary, vm: vm}, nil
+}
+
+func (vm *VM) GetLastStateSummary() (block.StateSummary, error) {
+	summary, err := vm.stateSyncServer.LastSummary()
+	if err != nil {
+		return nil, err // includes database.ErrNotFound case
+	}
+	return &stateSummary{summary: summary, vm: vm}, nil
+}
+
+// Note: ParseStateSummary doesn't depend on the state, so that summaries can be
+// parsed by a node without any accepted block.
+func (vm *VM) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error) {
+	summary, err := statesync.ParseSummary(summaryBytes)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := blocks.Parse(blocks.Codec, summary.Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse summary block: %w", err)
+	}
+	if blk.Height() != summary.Height {
+		return nil, fmt.Errorf("%w: %d != %d", errSummaryHeightMismatch, summary.Height, blk.Height())
+	}
+	return &stateSummary{summary: summary, vm: vm}, nil
+}
+
+func (vm *VM) GetStateSummary(height uint64) (block.StateSummary, error) {
+	summary, err := vm.stateSyncServer.Summary(height)
+	if err != nil {
+		return nil, err // includes database.ErrNotFound case
+	}
+	return &stateSummary{summary: summary, vm: vm}, nil
+}
+
+// acceptStateSummary starts syncing [summary]. The state is only synced if no
+// block other than the genesis block was accepted.
+func (vm *VM) acceptStateSummary(summary *statesync.Summary) (bool, error) {
+	if vm.stateSyncer == nil {
+		return false, nil
+	}
+
+	height, err := vm.GetCurrentHeight()
+	if err != nil {
+		return false, err
+	}
+	if height != 0 {
+		vm.ctx.Log.Info("skipping state sync",
+			zap.String("reason", "blocks were already accepted"),
+			zap.Uint64("height", height),
+			zap.Uint64("summaryHeight", summary.Height),
+		)
+		return false, nil
+	}
+	return true, vm.stateSyncer.Start(summary)
+}
+
+// onStateSyncDone applies the fetched records of [summary], unless they
+// couldn't be fetched, and notifies the engine that state sync is done. If the
+// records weren't applied, the chain is bootstrapped from the genesis block.
+func (vm *VM) onStateSyncDone(summary *statesync.Summary, err error) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if vm.stateSyncer.Closed() {
+		return
+	}
+
+	if err == nil {
+		err = vm.applyStateSummary(summary)
+	}
+	if err != nil {
+		vm.ctx.Log.Error("failed to state sync",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Error(err),
+		)
+	}
+
+	select {
+	case vm.toEngine <- common.StateSyncDone:
+	default:
+		vm.ctx.Log.Debug("dropping message to consensus engine")
+	}
+}
+
+// applyStateSummary replaces the state with the fetched records of [summary].
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) applyStateSummary(summary *statesync.Summary) error {
+	blk, err := blocks.Parse(blocks.Codec, summary.Block)
+	if err != nil {
+		return fmt.Errorf("failed to parse summary block: %w", err)
+	}
+
+	it := vm.stateSyncRecords.NewIterator()
+	err = vm.state.ApplySyncRecords(it, blk)
+	it.Release()
+	if err != nil {
+		return fmt.Errorf("failed to apply state records: %w", err)
+	}
+
+	blkID := blk.ID()
+	vm.manager.SetLastAccepted(blkID)
+	vm.recentlyAccepted.Add(blkID)
+	vm.validatorSetCaches = make(map[ids.ID]cache.Cacher)
+	if err := vm.updateValidators(); err != nil {
+		return fmt.Errorf("failed to update validator sets: %w", err)
+	}
+	if err := vm.initBlockchains(); err != nil {
+		return fmt.Errorf("failed to initialize blockchains: %w", err)
+	}
+	if err := vm.SetPreference(blkID); err != nil {
+		return err
+	}
+
+	// The synced records are the snapshot of [summary], which can be served
+	// to other nodes right away.
+	if vm.stateSyncServerEnabled {
+		if err := vm.stateSyncServer.SetLastSummary(summary); err != nil {
+			return err
+		}
+	} else if err := database.Clear(vm.stateSyncRecords, vm.stateSyncRecords); err != nil {
+		return err
+	}
+	if err := vm.stateSyncer.Finish(); err != nil {
+		return err
+	}
+
+	vm.ctx.Log.Info("state synced",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Stringer("blkID", blkID),
+		zap.Uint64("height", summary.Height),
+	)
+	return nil
+}
+
+func (vm *VM) AppRequest(nodeID ids.NodeID, requestID uint32, _ time.Time, msgBytes []byte) error {
+	return vm.handleStateSyncMessage(nodeID, requestID, msgBytes)
+}
+
+func (vm *VM) AppResponse(nodeID ids.NodeID, requestID uint32, msgBytes []byte) error {
+	return vm.handleStateSyncMessage(nodeID, requestID, msgBytes)
+}
+
+func (vm *VM) AppRequestFailed(nodeID ids.NodeID, requestID uint32) error {
+	if vm.stateSyncer == nil {
+		return nil
+	}
+	return vm.stateSyncer.AppRequestFailed(nodeID, requestID)
+}
+
+// handleStateSyncMessage handles the requests and responses of state sync, the
+// only requests sent by this VM. They are handled without [vm.ctx.Lock], as
+// the state sync server and syncer are synchronized independently.
+func (vm *VM) handleStateSyncMessage(nodeID ids.NodeID, requestID uint32, msgBytes []byte) error {
+	msg, err := message.Parse(msgBytes)
+	if err != nil {
+		vm.ctx.Log.Debug("dropping app message",
+			zap.String("reason", "failed to parse message"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	return msg.Handle(vm.stateSyncMsgHandler, nodeID, requestID)
+}
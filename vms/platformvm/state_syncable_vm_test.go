@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/platformvm/statesync"
+)
+
+func TestStateSyncDisabled(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	enabled, err := vm.StateSyncEnabled()
+	require.NoError(err)
+	require.False(enabled)
+
+	_, err = vm.GetOngoingSyncStateSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = vm.GetLastStateSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestApplyStateSummary(t *testing.T) {
+	require := require.New(t)
+
+	serverVM, _, _ := defaultVMWithChainConfig([]byte(`{"state-sync-server-enabled":true}`))
+	serverVM.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(serverVM.Shutdown())
+		serverVM.ctx.Lock.Unlock()
+	}()
+
+	syncVM, _, _ := defaultVMWithChainConfig([]byte(`{"state-sync-enabled":true}`))
+	syncVM.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(syncVM.Shutdown())
+		syncVM.ctx.Lock.Unlock()
+	}()
+
+	enabled, err := syncVM.StateSyncEnabled()
+	require.NoError(err)
+	require.True(enabled)
+
+	// Only the server accepts the creation of a subnet
+	tx, err := serverVM.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	require.NoError(serverVM.Builder.AddUnverifiedTx(tx))
+	blk, err := serverVM.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.NoError(blk.Accept())
+
+	require.NoError(serverVM.state.WriteSyncRecords(syncVM.stateSyncRecords))
+	it := syncVM.stateSyncRecords.NewIterator()
+	root, err := statesync.ComputeRoot(it)
+	it.Release()
+	require.NoError(err)
+
+	summary, err := statesync.NewSummary(blk.Height(), blk.Bytes(), root)
+	require.NoError(err)
+	stateSummary, err := syncVM.ParseStateSummary(summary.Bytes())
+	require.NoError(err)
+	require.Equal(blk.Height(), stateSummary.Height())
+
+	require.NoError(syncVM.applyStateSummary(summary))
+
+	lastAccepted, err := syncVM.LastAccepted()
+	require.NoError(err)
+	require.Equal(blk.ID(), lastAccepted)
+	height, err := syncVM.GetCurrentHeight()
+	require.NoError(err)
+	require.Equal(blk.Height(), height)
+
+	_, _, err = syncVM.state.GetTx(tx.ID())
+	require.NoError(err)
+	serverSubnets, err := serverVM.state.GetSubnets()
+	require.NoError(err)
+	syncedSubnets, err := syncVM.state.GetSubnets()
+	require.NoError(err)
+	require.Len(syncedSubnets, len(serverSubnets))
+
+	// The synced block is the last accepted block
+	syncedBlk, err := syncVM.GetBlock(blk.ID())
+	require.NoError(err)
+	require.Equal(blk.Height(), syncedBlk.Height())
+
+	// A VM that already accepted blocks doesn't state sync
+	accepted, err := stateSummary.Accept()
+	require.NoError(err)
+	require.False(accepted)
+}
@@ -0,0 +1,23 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"math"
+
+	"github.com/kukrer/savannahnode/codec"
+	"github.com/kukrer/savannahnode/codec/linearcodec"
+)
+
+const codecVersion = 0
+
+var c codec.Manager
+
+func init() {
+	lc := linearcodec.NewCustomMaxLength(math.MaxUint32)
+	c = codec.NewManager(math.MaxInt32)
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/vms/platformvm/message"
+)
+
+var _ message.Handler = &handler{}
+
+type handler struct {
+	message.NoopHandler
+
+	server *Server
+	syncer *Syncer
+}
+
+// NewHandler returns the handler of the state sync messages. [syncer] may be
+// nil if the node doesn't state sync.
+func NewHandler(log logging.Logger, server *Server, syncer *Syncer) message.Handler {
+	return &handler{
+		NoopHandler: message.NoopHandler{Log: log},
+		server:      server,
+		syncer:      syncer,
+	}
+}
+
+func (h *handler) HandleStateRequest(nodeID ids.NodeID, requestID uint32, msg *message.StateRequest) error {
+	return h.server.HandleStateRequest(nodeID, requestID, msg)
+}
+
+func (h *handler) HandleStateResponse(nodeID ids.NodeID, requestID uint32, msg *message.StateResponse) error {
+	if h.syncer == nil {
+		return h.NoopHandler.HandleStateResponse(nodeID, requestID, msg)
+	}
+	return h.syncer.HandleStateResponse(nodeID, requestID, msg)
+}
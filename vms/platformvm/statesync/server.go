@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/utils/units"
+	"github.com/kukrer/savannahnode/utils/wrappers"
+	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/message"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
+
+	blockexecutor "github.com/kukrer/savannahnode/vms/platformvm/blocks/executor"
+)
+
+const (
+	// SummaryFrequency is the number of heights between two state summaries.
+	// A summary is taken after the state of the first block with a height
+	// that is a multiple of SummaryFrequency is committed, so that every node
+	// takes the same summaries.
+	SummaryFrequency = 1024
+
+	// maxResponseSize is the maximum size of the records in a StateResponse,
+	// well below the maximum size of a message.
+	maxResponseSize = 256 * units.KiB
+)
+
+var (
+	_ blockexecutor.CommitListener = &Server{}
+
+	lastSummaryKey = []byte("lastSummary")
+)
+
+// Server takes the state summaries of the platform chain and serves their
+// records to the nodes that state sync.
+type Server struct {
+	log       logging.Logger
+	appSender common.AppSender
+	state     state.State
+
+	// records holds the records of [lastSummary]
+	records database.Database
+	// metadata holds [lastSummary]
+	metadata database.Database
+
+	// snapshotting is used to wait for the summary that is taken in the
+	// background
+	snapshotting sync.WaitGroup
+
+	// lock protects [lastSummary], as requests are handled concurrently with
+	// the summaries being taken
+	lock sync.RWMutex
+	// lastSummary is nil if no summary was taken yet, or while a summary is
+	// being taken
+	lastSummary *Summary
+}
+
+func NewServer(
+	log logging.Logger,
+	appSender common.AppSender,
+	s state.State,
+	records database.Database,
+	metadata database.Database,
+) (*Server, error) {
+	server := &Server{
+		log:       log,
+		appSender: appSender,
+		state:     s,
+		records:   records,
+		metadata:  metadata,
+	}
+
+	summaryBytes, err := metadata.Get(lastSummaryKey)
+	switch err {
+	case nil:
+		server.lastSummary, err = ParseSummary(summaryBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse last summary: %w", err)
+		}
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+	return server, nil
+}
+
+// StateCommitted takes a state summary if a multiple of SummaryFrequency is in
+// (prevHeight, blk.Height()]. Only the records that aren't UTXOs are copied
+// before returning. The UTXOs are copied, and the summary is written, in the
+// background.
+func (s *Server) StateCommitted(blk blocks.Block, prevHeight uint64) error {
+	height := blk.Height()
+	if height/SummaryFrequency == prevHeight/SummaryFrequency {
+		return nil
+	}
+
+	// Summaries are taken one at a time, in order of their heights.
+	s.snapshotting.Wait()
+
+	snapshot, err := s.state.NewSyncSnapshot()
+	if err != nil {
+		return fmt.Errorf("failed to snapshot state records: %w", err)
+	}
+
+	s.snapshotting.Add(1)
+	go func() {
+		defer s.snapshotting.Done()
+		defer snapshot.Release()
+
+		if err := s.takeSummary(height, blk.Bytes(), snapshot); err != nil {
+			s.log.Error("failed to take state summary",
+				zap.Uint64("height", height),
+				zap.Error(err),
+			)
+		}
+	}()
+	return nil
+}
+
+// Shutdown waits for the summary being taken, if any.
+func (s *Server) Shutdown() {
+	s.snapshotting.Wait()
+}
+
+func (s *Server) takeSummary(height uint64, blkBytes []byte, snapshot state.SyncSnapshot) error {
+	// The last summary is removed first, so that a partially written snapshot
+	// is never served, including after a restart.
+	s.lock.Lock()
+	s.lastSummary = nil
+	s.lock.Unlock()
+	if err := s.metadata.Delete(lastSummaryKey); err != nil {
+		return err
+	}
+	if err := database.Clear(s.records, s.records); err != nil {
+		return fmt.Errorf("failed to clear state records: %w", err)
+	}
+	if err := snapshot.Write(s.records); err != nil {
+		return fmt.Errorf("failed to write state records: %w", err)
+	}
+
+	it := s.records.NewIterator()
+	root, err := ComputeRoot(it)
+	it.Release()
+	if err != nil {
+		return fmt.Errorf("failed to compute state root: %w", err)
+	}
+
+	summary, err := NewSummary(height, blkBytes, root)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.metadata.Put(lastSummaryKey, summary.Bytes()); err != nil {
+		return err
+	}
+	s.lastSummary = summary
+
+	s.log.Info("took state summary",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Uint64("height", height),
+		zap.Stringer("root", root),
+	)
+	return nil
+}
+
+// SetLastSummary marks [summary] as the summary of the records, after they were
+// fetched by state sync.
+func (s *Server) SetLastSummary(summary *Summary) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.metadata.Put(lastSummaryKey, summary.Bytes()); err != nil {
+		return err
+	}
+	s.lastSummary = summary
+	return nil
+}
+
+// LastSummary returns the last summary taken.
+//
+// Returns database.ErrNotFound if no summary was taken.
+func (s *Server) LastSummary() (*Summary, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.lastSummary == nil {
+		return nil, database.ErrNotFound
+	}
+	return s.lastSummary, nil
+}
+
+// Summary returns the summary taken at [height].
+//
+// Returns database.ErrNotFound if the summary at [height] isn't served.
+func (s *Server) Summary(height uint64) (*Summary, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.lastSummary == nil || s.lastSummary.Height != height {
+		return nil, database.ErrNotFound
+	}
+	return s.lastSummary, nil
+}
+
+func (s *Server) HandleStateRequest(nodeID ids.NodeID, requestID uint32, msg *message.StateRequest) error {
+	s.lock.RLock()
+	response, err := s.readRecords(msg)
+	s.lock.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	responseBytes, err := message.Build(response)
+	if err != nil {
+		return fmt.Errorf("failed to build StateResponse message: %w", err)
+	}
+	return s.appSender.SendAppResponse(nodeID, requestID, responseBytes)
+}
+
+// readRecords assumes [s.lock] is held.
+func (s *Server) readRecords(msg *message.StateRequest) (*message.StateResponse, error) {
+	if s.lastSummary == nil || s.lastSummary.Height != msg.Height {
+		return &message.StateResponse{Unavailable: true}, nil
+	}
+
+	it := s.records.NewIteratorWithStart(msg.Start)
+	defer it.Release()
+
+	response := &message.StateResponse{}
+	size := 0
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		recordSize := 2*wrappers.IntLen + len(key) + len(value)
+		if len(response.Keys) > 0 && size+recordSize > maxResponseSize {
+			response.More = true
+			break
+		}
+		size += recordSize
+
+		// The iterator may reuse the returned slices.
+		response.Keys = append(response.Keys, append([]byte{}, key...))
+		response.Values = append(response.Values, append([]byte{}, value...))
+	}
+	return response, it.Error()
+}
@@ -0,0 +1,353 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/database/memdb"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/utils/units"
+	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/message"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
+)
+
+// testRecords are large enough to be served in multiple responses
+func testRecords() map[string][]byte {
+	records := make(map[string][]byte)
+	for i := 0; i < 64; i++ {
+		records[string([]byte{byte(i), 'k'})] = make([]byte, 16*units.KiB)
+	}
+	records[string([]byte{0})] = nil
+	return records
+}
+
+// testSnapshot writes [records]
+type testSnapshot map[string][]byte
+
+func (s testSnapshot) Write(db database.KeyValueWriter) error {
+	for key, value := range s {
+		if err := db.Put([]byte(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (testSnapshot) Release() {}
+
+func newTestServer(t *testing.T, ctrl *gomock.Controller, records map[string][]byte) (*Server, *common.SenderTest) {
+	s := state.NewMockState(ctrl)
+	s.EXPECT().NewSyncSnapshot().Return(testSnapshot(records), nil).AnyTimes()
+
+	sender := &common.SenderTest{T: t}
+	server, err := NewServer(logging.NoLog{}, sender, s, memdb.New(), memdb.New())
+	require.NoError(t, err)
+	return server, sender
+}
+
+func TestSummary(t *testing.T) {
+	require := require.New(t)
+
+	summary, err := NewSummary(1024, []byte{1, 2, 3}, ids.GenerateTestID())
+	require.NoError(err)
+
+	parsedSummary, err := ParseSummary(summary.Bytes())
+	require.NoError(err)
+	require.Equal(summary.ID(), parsedSummary.ID())
+	require.Equal(summary.Height, parsedSummary.Height)
+	require.Equal(summary.Block, parsedSummary.Block)
+	require.Equal(summary.Root, parsedSummary.Root)
+}
+
+func TestServerStateCommitted(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server, _ := newTestServer(t, ctrl, testRecords())
+
+	blk, err := blocks.NewCommitBlock(ids.GenerateTestID(), SummaryFrequency-1)
+	require.NoError(err)
+	require.NoError(server.StateCommitted(blk, SummaryFrequency-3))
+	_, err = server.LastSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// An option block commits the state of its parent as well
+	blk, err = blocks.NewCommitBlock(ids.GenerateTestID(), SummaryFrequency+1)
+	require.NoError(err)
+	require.NoError(server.StateCommitted(blk, SummaryFrequency-1))
+	// The summary is taken in the background
+	server.Shutdown()
+
+	summary, err := server.LastSummary()
+	require.NoError(err)
+	require.Equal(blk.Height(), summary.Height)
+	require.Equal(blk.Bytes(), summary.Block)
+
+	it := server.records.NewIterator()
+	defer it.Release()
+	root, err := ComputeRoot(it)
+	require.NoError(err)
+	require.Equal(root, summary.Root)
+
+	_, err = server.Summary(blk.Height() - 1)
+	require.ErrorIs(err, database.ErrNotFound)
+	fetchedSummary, err := server.Summary(blk.Height())
+	require.NoError(err)
+	require.Equal(summary.ID(), fetchedSummary.ID())
+
+	// The summary is kept across restarts
+	restartedServer, err := NewServer(logging.NoLog{}, &common.SenderTest{}, nil, server.records, server.metadata)
+	require.NoError(err)
+	restartedSummary, err := restartedServer.LastSummary()
+	require.NoError(err)
+	require.Equal(summary.ID(), restartedSummary.ID())
+}
+
+func TestSync(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	records := testRecords()
+	server, serverSender := newTestServer(t, ctrl, records)
+	emptyServer, emptyServerSender := newTestServer(t, ctrl, records)
+
+	blk, err := blocks.NewCommitBlock(ids.GenerateTestID(), SummaryFrequency)
+	require.NoError(err)
+	require.NoError(server.StateCommitted(blk, SummaryFrequency-1))
+	server.Shutdown()
+	summary, err := server.LastSummary()
+	require.NoError(err)
+
+	type result struct {
+		summary *Summary
+		err     error
+	}
+	done := make(chan result, 1)
+	syncerSender := &common.SenderTest{T: t}
+	syncer := NewSyncer(
+		logging.NoLog{},
+		syncerSender,
+		memdb.New(),
+		memdb.New(),
+		func(summary *Summary, err error) {
+			done <- result{summary: summary, err: err}
+		},
+	)
+	defer syncer.Shutdown()
+
+	serverID := ids.GenerateTestNodeID()
+	emptyServerID := ids.GenerateTestNodeID()
+	servers := map[ids.NodeID]*Server{
+		serverID:      server,
+		emptyServerID: emptyServer,
+	}
+	requests := map[ids.NodeID]int{}
+	syncerSender.SendAppRequestF = func(nodeIDs ids.NodeIDSet, requestID uint32, msgBytes []byte) error {
+		nodeID, _ := nodeIDs.Peek()
+		requests[nodeID]++
+
+		msg, err := message.Parse(msgBytes)
+		require.NoError(err)
+		return servers[nodeID].HandleStateRequest(ids.EmptyNodeID, requestID, msg.(*message.StateRequest))
+	}
+	for nodeID, sender := range map[ids.NodeID]*common.SenderTest{
+		serverID:      serverSender,
+		emptyServerID: emptyServerSender,
+	} {
+		nodeID := nodeID
+		sender.SendAppResponseF = func(_ ids.NodeID, requestID uint32, msgBytes []byte) error {
+			msg, err := message.Parse(msgBytes)
+			require.NoError(err)
+			return syncer.HandleStateResponse(nodeID, requestID, msg.(*message.StateResponse))
+		}
+	}
+
+	syncer.Connected(emptyServerID)
+	syncer.Connected(serverID)
+	require.NoError(syncer.Start(summary))
+
+	ongoingSummary, err := syncer.OngoingSummary()
+	require.NoError(err)
+	require.Equal(summary.ID(), ongoingSummary.ID())
+
+	select {
+	case res := <-done:
+		require.NoError(res.err)
+		require.Equal(summary.ID(), res.summary.ID())
+	case <-time.After(10 * time.Second):
+		require.FailNow("state sync didn't finish")
+	}
+
+	require.LessOrEqual(requests[emptyServerID], 1)
+	require.Greater(requests[serverID], 1)
+
+	fetchedRecords := make(map[string][]byte)
+	it := syncer.records.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		fetchedRecords[string(it.Key())] = it.Value()
+	}
+	require.NoError(it.Error())
+	require.Len(fetchedRecords, len(records))
+
+	require.NoError(syncer.Finish())
+	_, err = syncer.OngoingSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestVerifyResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		start       []byte
+		response    *message.StateResponse
+		expectedErr error
+	}{
+		{
+			name:        "failed",
+			expectedErr: errRequestFailed,
+		},
+		{
+			name:        "unavailable",
+			response:    &message.StateResponse{Unavailable: true},
+			expectedErr: errSummaryUnavailable,
+		},
+		{
+			name: "mismatched records",
+			response: &message.StateResponse{
+				Keys: [][]byte{{1}},
+			},
+			expectedErr: errMismatchedRecords,
+		},
+		{
+			name:        "empty partial response",
+			response:    &message.StateResponse{More: true},
+			expectedErr: errEmptyResponse,
+		},
+		{
+			name:  "before start",
+			start: []byte{2},
+			response: &message.StateResponse{
+				Keys:   [][]byte{{1}},
+				Values: [][]byte{nil},
+			},
+			expectedErr: errUnsortedRecords,
+		},
+		{
+			name: "duplicated key",
+			response: &message.StateResponse{
+				Keys:   [][]byte{{1}, {1}},
+				Values: [][]byte{nil, nil},
+			},
+			expectedErr: errUnsortedRecords,
+		},
+		{
+			name:  "valid",
+			start: []byte{1},
+			response: &message.StateResponse{
+				Keys:   [][]byte{{1}, {1, 0}, {2}},
+				Values: [][]byte{nil, nil, nil},
+				More:   true,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifyResponse(test.start, test.response)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSyncDropsFaultyPeer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	records := testRecords()
+	faultyRecords := testRecords()
+	faultyRecords[string([]byte{0})] = []byte{1}
+	server, serverSender := newTestServer(t, ctrl, records)
+	faultyServer, faultyServerSender := newTestServer(t, ctrl, faultyRecords)
+
+	blk, err := blocks.NewCommitBlock(ids.GenerateTestID(), SummaryFrequency)
+	require.NoError(err)
+	require.NoError(server.StateCommitted(blk, SummaryFrequency-1))
+	require.NoError(faultyServer.StateCommitted(blk, SummaryFrequency-1))
+	server.Shutdown()
+	faultyServer.Shutdown()
+	summary, err := server.LastSummary()
+	require.NoError(err)
+
+	done := make(chan error, 1)
+	syncerSender := &common.SenderTest{T: t}
+	syncer := NewSyncer(
+		logging.NoLog{},
+		syncerSender,
+		memdb.New(),
+		memdb.New(),
+		func(_ *Summary, err error) {
+			done <- err
+		},
+	)
+	defer syncer.Shutdown()
+
+	serverID := ids.GenerateTestNodeID()
+	faultyServerID := ids.GenerateTestNodeID()
+	servers := map[ids.NodeID]*Server{
+		serverID:       server,
+		faultyServerID: faultyServer,
+	}
+	syncerSender.SendAppRequestF = func(nodeIDs ids.NodeIDSet, requestID uint32, msgBytes []byte) error {
+		nodeID, _ := nodeIDs.Peek()
+		msg, err := message.Parse(msgBytes)
+		require.NoError(err)
+		return servers[nodeID].HandleStateRequest(ids.EmptyNodeID, requestID, msg.(*message.StateRequest))
+	}
+	for nodeID, sender := range map[ids.NodeID]*common.SenderTest{
+		serverID:       serverSender,
+		faultyServerID: faultyServerSender,
+	} {
+		nodeID := nodeID
+		sender.SendAppResponseF = func(_ ids.NodeID, requestID uint32, msgBytes []byte) error {
+			msg, err := message.Parse(msgBytes)
+			require.NoError(err)
+			return syncer.HandleStateResponse(nodeID, requestID, msg.(*message.StateResponse))
+		}
+	}
+
+	// The faulty peer serves every record, so it's dropped once the records
+	// don't match the summary
+	syncer.Connected(faultyServerID)
+	require.NoError(syncer.Start(summary))
+	require.Eventually(func() bool {
+		syncer.lock.Lock()
+		defer syncer.lock.Unlock()
+		return syncer.faulty.Contains(faultyServerID)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The records are fetched again from the other peer
+	syncer.Connected(serverID)
+	select {
+	case err := <-done:
+		require.NoError(err)
+	case <-time.After(10 * time.Second):
+		require.FailNow("state sync didn't finish")
+	}
+
+	value, err := syncer.records.Get([]byte{0})
+	require.NoError(err)
+	require.Empty(value)
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/hashing"
+)
+
+var errWrongCodecVersion = errors.New("wrong codec version")
+
+// Summary commits to the state of the platform chain after accepting the block
+// at [Height].
+type Summary struct {
+	Height uint64 `serialize:"true"`
+	// Block is the last accepted block of the state
+	Block []byte `serialize:"true"`
+	// Root commits to every record of the state, see ComputeRoot
+	Root ids.ID `serialize:"true"`
+
+	id    ids.ID
+	bytes []byte
+}
+
+func (s *Summary) ID() ids.ID    { return s.id }
+func (s *Summary) Bytes() []byte { return s.bytes }
+
+func NewSummary(height uint64, block []byte, root ids.ID) (*Summary, error) {
+	summary := &Summary{
+		Height: height,
+		Block:  block,
+		Root:   root,
+	}
+	bytes, err := c.Marshal(codecVersion, summary)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal summary: %w", err)
+	}
+	summary.id = hashing.ComputeHash256Array(bytes)
+	summary.bytes = bytes
+	return summary, nil
+}
+
+func ParseSummary(bytes []byte) (*Summary, error) {
+	summary := &Summary{
+		id:    hashing.ComputeHash256Array(bytes),
+		bytes: bytes,
+	}
+	version, err := c.Unmarshal(bytes, summary)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal summary: %w", err)
+	}
+	if version != codecVersion {
+		return nil, errWrongCodecVersion
+	}
+	return summary, nil
+}
+
+// ComputeRoot returns the hash of the records iterated by [it], in order of
+// their keys. Every key and value is prefixed with its length, so that distinct
+// sets of records never hash the same bytes.
+func ComputeRoot(it database.Iterator) (ids.ID, error) {
+	hasher := sha256.New()
+	for it.Next() {
+		for _, b := range [][]byte{it.Key(), it.Value()} {
+			_, _ = hasher.Write(database.PackUInt32(uint32(len(b))))
+			_, _ = hasher.Write(b)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return ids.Empty, err
+	}
+
+	var root ids.ID
+	copy(root[:], hasher.Sum(nil))
+	return root, nil
+}
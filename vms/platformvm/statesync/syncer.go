@@ -0,0 +1,441 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/engine/common"
+	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/vms/platformvm/message"
+)
+
+// retryFrequency is how long the syncer waits before asking the peers that
+// didn't serve the synced summary again.
+const retryFrequency = 5 * time.Second
+
+var (
+	ongoingSummaryKey = []byte("ongoingSummary")
+	progressKey       = []byte("progress")
+
+	errClosed             = errors.New("syncer closed")
+	errRequestFailed      = errors.New("request failed")
+	errSummaryUnavailable = errors.New("summary unavailable")
+	errEmptyResponse      = errors.New("no records in a partial response")
+	errMismatchedRecords  = errors.New("mismatched number of keys and values")
+	errUnsortedRecords    = errors.New("records aren't sorted")
+)
+
+// OnDone is called once the records of [summary] were fetched, or with the
+// error that prevented them from being fetched.
+type OnDone func(summary *Summary, err error)
+
+// Syncer fetches the records of a state summary from the connected peers.
+type Syncer struct {
+	log       logging.Logger
+	appSender common.AppSender
+
+	// records holds the fetched records
+	records database.Database
+	// metadata holds the ongoing summary and the key of the next record to
+	// fetch
+	metadata database.Database
+	onDone   OnDone
+
+	lock  sync.Mutex
+	peers ids.NodeIDSet
+	// unavailable are the peers that didn't serve the ongoing summary
+	unavailable ids.NodeIDSet
+	// faulty are the peers that served records that don't match the root of
+	// the ongoing summary. They aren't asked for records again.
+	faulty ids.NodeIDSet
+	// lastPeer is the peer that served the last records
+	lastPeer ids.NodeID
+
+	// the outstanding request, if [pending]
+	pending       bool
+	requestID     uint32
+	requestNodeID ids.NodeID
+
+	// responses receives the response to the outstanding request, which is
+	// nil if the request failed
+	responses chan *message.StateResponse
+	// peerConnected is notified when a peer connects
+	peerConnected chan struct{}
+	closed        chan struct{}
+	closeOnce     sync.Once
+}
+
+func NewSyncer(
+	log logging.Logger,
+	appSender common.AppSender,
+	records database.Database,
+	metadata database.Database,
+	onDone OnDone,
+) *Syncer {
+	return &Syncer{
+		log:           log,
+		appSender:     appSender,
+		records:       records,
+		metadata:      metadata,
+		onDone:        onDone,
+		peers:         ids.NodeIDSet{},
+		unavailable:   ids.NodeIDSet{},
+		faulty:        ids.NodeIDSet{},
+		responses:     make(chan *message.StateResponse, 1),
+		peerConnected: make(chan struct{}, 1),
+		closed:        make(chan struct{}),
+	}
+}
+
+// OngoingSummary returns the summary being synced.
+//
+// Returns database.ErrNotFound if no summary is being synced.
+func (s *Syncer) OngoingSummary() (*Summary, error) {
+	summaryBytes, err := s.metadata.Get(ongoingSummaryKey)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSummary(summaryBytes)
+}
+
+// Start fetches the records of [summary] in the background. If [summary] is
+// the ongoing summary, the records fetched before are kept.
+func (s *Syncer) Start(summary *Summary) error {
+	ongoingSummary, err := s.OngoingSummary()
+	switch {
+	case err == nil && ongoingSummary.ID() == summary.ID():
+		s.log.Info("resuming state sync",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Uint64("height", summary.Height),
+		)
+	case err == nil || err == database.ErrNotFound:
+		s.log.Info("starting state sync",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Uint64("height", summary.Height),
+		)
+		if err := database.Clear(s.records, s.records); err != nil {
+			return fmt.Errorf("failed to clear state records: %w", err)
+		}
+		if err := s.metadata.Delete(progressKey); err != nil {
+			return err
+		}
+		if err := s.metadata.Put(ongoingSummaryKey, summary.Bytes()); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	go s.sync(summary)
+	return nil
+}
+
+// Finish forgets the ongoing summary once its records were applied.
+func (s *Syncer) Finish() error {
+	if err := s.metadata.Delete(progressKey); err != nil {
+		return err
+	}
+	return s.metadata.Delete(ongoingSummaryKey)
+}
+
+// Shutdown stops fetching records. OnDone isn't called after Shutdown returns.
+func (s *Syncer) Shutdown() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+// Closed returns true if Shutdown was called.
+func (s *Syncer) Closed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Syncer) Connected(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.peers.Add(nodeID)
+	select {
+	case s.peerConnected <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Syncer) Disconnected(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.peers.Remove(nodeID)
+}
+
+func (s *Syncer) HandleStateResponse(nodeID ids.NodeID, requestID uint32, msg *message.StateResponse) error {
+	s.respond(nodeID, requestID, msg)
+	return nil
+}
+
+func (s *Syncer) AppRequestFailed(nodeID ids.NodeID, requestID uint32) error {
+	s.respond(nodeID, requestID, nil)
+	return nil
+}
+
+func (s *Syncer) respond(nodeID ids.NodeID, requestID uint32, msg *message.StateResponse) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.pending || s.requestID != requestID || s.requestNodeID != nodeID {
+		s.log.Debug("dropping unexpected state response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return
+	}
+	s.pending = false
+	s.responses <- msg
+}
+
+func (s *Syncer) sync(summary *Summary) {
+	err := s.fetch(summary)
+	if err == errClosed {
+		return
+	}
+	if err != nil {
+		s.log.Error("state sync failed",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Error(err),
+		)
+	}
+	s.onDone(summary, err)
+}
+
+func (s *Syncer) fetch(summary *Summary) error {
+	start, err := s.metadata.Get(progressKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+
+	// servers are the peers that served the records fetched so far. The
+	// peers that served the records fetched before a restart are unknown.
+	var (
+		servers      = ids.NodeIDSet{}
+		serversKnown = start == nil
+	)
+	for {
+		nodeID, err := s.waitForPeer()
+		if err != nil {
+			return err
+		}
+		response, err := s.request(nodeID, &message.StateRequest{
+			Height: summary.Height,
+			Start:  start,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := verifyResponse(start, response); err != nil {
+			s.log.Debug("peer didn't serve state records",
+				zap.Stringer("nodeID", nodeID),
+				zap.Error(err),
+			)
+			s.markUnavailable(nodeID)
+			continue
+		}
+		s.markServed(nodeID)
+		servers.Add(nodeID)
+
+		batch := s.records.NewBatch()
+		for i, key := range response.Keys {
+			if err := batch.Put(key, response.Values[i]); err != nil {
+				return err
+			}
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		if response.More {
+			// The next record has the smallest key greater than the last
+			// fetched key.
+			lastKey := response.Keys[len(response.Keys)-1]
+			start = append(append(make([]byte, 0, len(lastKey)+1), lastKey...), 0)
+			if err := s.metadata.Put(progressKey, start); err != nil {
+				return err
+			}
+			continue
+		}
+
+		it := s.records.NewIterator()
+		root, err := ComputeRoot(it)
+		it.Release()
+		if err != nil {
+			return err
+		}
+		if root == summary.Root {
+			s.log.Info("fetched state records",
+				zap.Stringer("summaryID", summary.ID()),
+				zap.Stringer("root", root),
+			)
+			return nil
+		}
+
+		// As the peer that served the last records is asked first, the
+		// records are usually served by a single peer. Every peer that served
+		// some of them is dropped, as the faulty records can't be attributed
+		// to one of them. Every record is then fetched again from the other
+		// peers.
+		s.log.Warn("fetched state records don't match the summary",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Stringer("expectedRoot", summary.Root),
+			zap.Stringer("root", root),
+			zap.Stringer("nodeIDs", servers),
+			zap.Bool("nodeIDsKnown", serversKnown),
+		)
+		if serversKnown {
+			s.markFaulty(servers)
+		}
+		servers.Clear()
+		serversKnown = true
+		if err := database.Clear(s.records, s.records); err != nil {
+			return err
+		}
+		if err := s.metadata.Delete(progressKey); err != nil {
+			return err
+		}
+		start = nil
+	}
+}
+
+// verifyResponse returns nil if [response] contains sorted records starting at
+// [start].
+func verifyResponse(start []byte, response *message.StateResponse) error {
+	switch {
+	case response == nil:
+		return errRequestFailed
+	case response.Unavailable:
+		return errSummaryUnavailable
+	case len(response.Keys) != len(response.Values):
+		return errMismatchedRecords
+	case response.More && len(response.Keys) == 0:
+		return errEmptyResponse
+	}
+
+	for i, key := range response.Keys {
+		if i == 0 && bytes.Compare(key, start) < 0 {
+			return errUnsortedRecords
+		}
+		if i > 0 && bytes.Compare(key, response.Keys[i-1]) <= 0 {
+			return errUnsortedRecords
+		}
+	}
+	return nil
+}
+
+// waitForPeer returns the peer to request records from, preferring the peer
+// that served the last records. Faulty peers are never returned.
+func (s *Syncer) waitForPeer() (ids.NodeID, error) {
+	for {
+		s.lock.Lock()
+		if s.peers.Contains(s.lastPeer) && !s.unavailable.Contains(s.lastPeer) && !s.faulty.Contains(s.lastPeer) {
+			nodeID := s.lastPeer
+			s.lock.Unlock()
+			return nodeID, nil
+		}
+		allUnavailable := false
+		for nodeID := range s.peers {
+			if s.faulty.Contains(nodeID) {
+				continue
+			}
+			if !s.unavailable.Contains(nodeID) {
+				s.lock.Unlock()
+				return nodeID, nil
+			}
+			allUnavailable = true
+		}
+		s.lock.Unlock()
+
+		if !allUnavailable {
+			select {
+			case <-s.peerConnected:
+			case <-s.closed:
+				return ids.EmptyNodeID, errClosed
+			}
+			continue
+		}
+
+		// Every connected peer was asked, so they are asked again after a
+		// while, as they may take the summary in the meantime.
+		select {
+		case <-time.After(retryFrequency):
+		case <-s.closed:
+			return ids.EmptyNodeID, errClosed
+		}
+		s.lock.Lock()
+		s.unavailable.Clear()
+		s.lock.Unlock()
+	}
+}
+
+// request sends [msg] to [nodeID] and returns its response, which is nil if the
+// request failed.
+func (s *Syncer) request(nodeID ids.NodeID, msg *message.StateRequest) (*message.StateResponse, error) {
+	msgBytes, err := message.Build(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build StateRequest message: %w", err)
+	}
+
+	s.lock.Lock()
+	s.requestID++
+	requestID := s.requestID
+	s.requestNodeID = nodeID
+	s.pending = true
+	s.lock.Unlock()
+
+	nodeIDs := ids.NewNodeIDSet(1)
+	nodeIDs.Add(nodeID)
+	if err := s.appSender.SendAppRequest(nodeIDs, requestID, msgBytes); err != nil {
+		return nil, err
+	}
+
+	select {
+	case response := <-s.responses:
+		return response, nil
+	case <-s.closed:
+		return nil, errClosed
+	}
+}
+
+func (s *Syncer) markUnavailable(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.unavailable.Add(nodeID)
+}
+
+func (s *Syncer) markFaulty(nodeIDs ids.NodeIDSet) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.faulty.Union(nodeIDs)
+}
+
+func (s *Syncer) markServed(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.lastPeer = nodeID
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+)
+
+var (
+	_ txs.Visitor = &txFlow{}
+
+	errUnexpectedTxFlow = errors.New("tx doesn't move any funds")
+)
+
+// txFlow collects the funds a tx moves. [ins] are spent from the P-chain UTXO
+// set, [importedIns] are spent from the shared memory of [sourceChain] and
+// [outs] are every output funded by the inputs, whether they are added to the
+// UTXO set, locked for staking or exported.
+type txFlow struct {
+	ins         []*avax.TransferableInput
+	importedIns []*avax.TransferableInput
+	sourceChain ids.ID
+	outs        []*avax.TransferableOutput
+}
+
+func (*txFlow) AdvanceTimeTx(*txs.AdvanceTimeTx) error         { return errUnexpectedTxFlow }
+func (*txFlow) RewardValidatorTx(*txs.RewardValidatorTx) error { return errUnexpectedTxFlow }
+
+func (f *txFlow) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.Stake...)
+	return nil
+}
+
+func (f *txFlow) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.Stake...)
+	return nil
+}
+
+func (f *txFlow) CreateChainTx(tx *txs.CreateChainTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) ImportTx(tx *txs.ImportTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.importedIns = tx.ImportedInputs
+	f.sourceChain = tx.SourceChain
+	return nil
+}
+
+func (f *txFlow) ExportTx(tx *txs.ExportTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.ExportedOutputs...)
+	return nil
+}
+
+func (f *txFlow) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) TopUpValidatorTx(tx *txs.TopUpValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.Stake...)
+	return nil
+}
+
+func (f *txFlow) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (f *txFlow) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (f *txFlow) baseTx(tx *txs.BaseTx) {
+	f.ins = tx.Ins
+	f.outs = append(f.outs, tx.Outs...)
+}
+
+// burned returns the amount of [assetID] consumed by the tx that isn't sent to
+// any of its outputs.
+func (f *txFlow) burned(assetID ids.ID) (uint64, error) {
+	consumed := uint64(0)
+	for _, ins := range [][]*avax.TransferableInput{f.ins, f.importedIns} {
+		for _, in := range ins {
+			if in.AssetID() != assetID {
+				continue
+			}
+			var err error
+			consumed, err = math.Add64(consumed, in.Input().Amount())
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	produced := uint64(0)
+	for _, out := range f.outs {
+		if out.AssetID() != assetID {
+			continue
+		}
+		var err error
+		produced, err = math.Add64(produced, out.Output().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return math.Sub64(consumed, produced)
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
+	"github.com/kukrer/savannahnode/vms/platformvm/validator"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	_ UnsignedTx             = &AddPermissionlessDelegatorTx{}
+	_ StakerTx               = &AddPermissionlessDelegatorTx{}
+	_ secp256k1fx.UnsignedTx = &AddPermissionlessDelegatorTx{}
+)
+
+// AddPermissionlessDelegatorTx is an unsigned addPermissionlessDelegatorTx
+// that delegates stake to a validator of a permissionless subnet.
+type AddPermissionlessDelegatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Describes the validator
+	Validator validator.SubnetValidator `serialize:"true" json:"validator"`
+	// Where to send staked tokens when done validating
+	StakeOuts []*avax.TransferableOutput `serialize:"true" json:"stake"`
+	// Where to send staking rewards when done validating
+	DelegationRewardsOwner fx.Owner `serialize:"true" json:"rewardsOwner"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [AddPermissionlessDelegatorTx]. Also sets the [ctx] to the given [vm.ctx] so
+// that the addresses can be json marshalled into human readable format
+func (tx *AddPermissionlessDelegatorTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	for _, out := range tx.StakeOuts {
+		out.FxID = secp256k1fx.ID
+		out.InitCtx(ctx)
+	}
+	tx.DelegationRewardsOwner.InitCtx(ctx)
+}
+
+// StartTime of this delegator
+func (tx *AddPermissionlessDelegatorTx) StartTime() time.Time {
+	return tx.Validator.StartTime()
+}
+
+// EndTime of this delegator
+func (tx *AddPermissionlessDelegatorTx) EndTime() time.Time {
+	return tx.Validator.EndTime()
+}
+
+// Weight of this delegator
+func (tx *AddPermissionlessDelegatorTx) Weight() uint64 {
+	return tx.Validator.Weight()
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddPermissionlessDelegatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case len(tx.StakeOuts) == 0: // Ensure there is provided stake
+		return errNoStake
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(&tx.Validator, tx.DelegationRewardsOwner); err != nil {
+		return fmt.Errorf("failed to verify validator or rewards owner: %w", err)
+	}
+
+	totalStakeWeight, err := verifyStakeOuts(tx.StakeOuts)
+	if err != nil {
+		return err
+	}
+	if totalStakeWeight != tx.Validator.Wght {
+		return fmt.Errorf("%w, delegator weight %d total stake weight %d",
+			errDelegatorWeightMismatch,
+			tx.Validator.Wght,
+			totalStakeWeight,
+		)
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *AddPermissionlessDelegatorTx) Visit(visitor Visitor) error {
+	return visitor.AddPermissionlessDelegatorTx(tx)
+}
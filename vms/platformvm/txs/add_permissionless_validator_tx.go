@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
+	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
+	"github.com/kukrer/savannahnode/vms/platformvm/validator"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	_ UnsignedTx             = &AddPermissionlessValidatorTx{}
+	_ StakerTx               = &AddPermissionlessValidatorTx{}
+	_ secp256k1fx.UnsignedTx = &AddPermissionlessValidatorTx{}
+
+	errNoStake             = errors.New("no stake")
+	errMultipleStakeAssets = errors.New("stake outputs must all be the same asset")
+	errInvalidSigner       = errors.New("invalid signer")
+)
+
+// AddPermissionlessValidatorTx is an unsigned addPermissionlessValidatorTx
+// that adds a validator to the primary network or to a permissionless subnet.
+type AddPermissionlessValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Describes the validator
+	Validator validator.Validator `serialize:"true" json:"validator"`
+	// ID of the subnet this validator is validating
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// If the [Subnet] is the primary network, [Signer] is the BLS key and proof
+	// of possession for this validator.
+	// Note: We do not enforce that the BLS key is unique across all validators.
+	//       This means that validators can share a key if they so choose.
+	//       However, a NodeID does uniquely map to a BLS key.
+	// Note: If the [Subnet] is not the primary network, [Signer] must be
+	//       [signer.Empty].
+	Signer signer.Signer `serialize:"true" json:"signer"`
+	// Where to send staked tokens when done validating
+	StakeOuts []*avax.TransferableOutput `serialize:"true" json:"stake"`
+	// Where to send validation rewards when done validating
+	ValidatorRewardsOwner fx.Owner `serialize:"true" json:"validationRewardsOwner"`
+	// Where to send delegation rewards when done validating
+	DelegatorRewardsOwner fx.Owner `serialize:"true" json:"delegationRewardsOwner"`
+	// Fee this validator charges delegators as a percentage, times 10,000
+	// For example, if this validator has DelegationShares=300,000 then they
+	// take 30% of rewards from delegators
+	DelegationShares uint32 `serialize:"true" json:"shares"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [AddPermissionlessValidatorTx]. Also sets the [ctx] to the given [vm.ctx] so
+// that the addresses can be json marshalled into human readable format
+func (tx *AddPermissionlessValidatorTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	for _, out := range tx.StakeOuts {
+		out.FxID = secp256k1fx.ID
+		out.InitCtx(ctx)
+	}
+	tx.ValidatorRewardsOwner.InitCtx(ctx)
+	tx.DelegatorRewardsOwner.InitCtx(ctx)
+}
+
+// StartTime of this validator
+func (tx *AddPermissionlessValidatorTx) StartTime() time.Time {
+	return tx.Validator.StartTime()
+}
+
+// EndTime of this validator
+func (tx *AddPermissionlessValidatorTx) EndTime() time.Time {
+	return tx.Validator.EndTime()
+}
+
+// Weight of this validator
+func (tx *AddPermissionlessValidatorTx) Weight() uint64 {
+	return tx.Validator.Weight()
+}
+
+// PublicKey returns the BLS public key registered by this validator and true
+// if a key was registered. The signer is verified, so this may be called on
+// txs that were parsed but not syntactically verified.
+func (tx *AddPermissionlessValidatorTx) PublicKey() (*bls.PublicKey, bool, error) {
+	if err := tx.Signer.Verify(); err != nil {
+		return nil, false, err
+	}
+	key := tx.Signer.Key()
+	return key, key != nil, nil
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddPermissionlessValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case len(tx.StakeOuts) == 0: // Ensure there is provided stake
+		return errNoStake
+	case tx.Signer == nil:
+		return errInvalidSigner
+	case tx.DelegationShares > reward.PercentDenominator: // Ensure delegators shares are in the allowed amount
+		return errTooManyShares
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := verify.All(&tx.Validator, tx.Signer, tx.ValidatorRewardsOwner, tx.DelegatorRewardsOwner); err != nil {
+		return fmt.Errorf("failed to verify validator, signer, or rewards owners: %w", err)
+	}
+
+	hasKey := tx.Signer.Key() != nil
+	isPrimaryNetwork := tx.Subnet == constants.PrimaryNetworkID
+	if hasKey != isPrimaryNetwork {
+		return fmt.Errorf(
+			"%w: hasKey=%v != isPrimaryNetwork=%v",
+			errInvalidSigner,
+			hasKey,
+			isPrimaryNetwork,
+		)
+	}
+
+	totalStakeWeight, err := verifyStakeOuts(tx.StakeOuts)
+	if err != nil {
+		return err
+	}
+	if totalStakeWeight != tx.Validator.Wght {
+		return fmt.Errorf("validator weight %d is not equal to total stake weight %d", tx.Validator.Wght, totalStakeWeight)
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *AddPermissionlessValidatorTx) Visit(visitor Visitor) error {
+	return visitor.AddPermissionlessValidatorTx(tx)
+}
+
+// verifyStakeOuts verifies that [stakeOuts] are well-formed, sorted and all
+// denominated in the same asset. It returns the total amount staked.
+func verifyStakeOuts(stakeOuts []*avax.TransferableOutput) (uint64, error) {
+	var (
+		stakedAssetID    ids.ID
+		totalStakeWeight uint64
+	)
+	for i, out := range stakeOuts {
+		if err := out.Verify(); err != nil {
+			return 0, fmt.Errorf("failed to verify output: %w", err)
+		}
+		newWeight, err := math.Add64(totalStakeWeight, out.Output().Amount())
+		if err != nil {
+			return 0, err
+		}
+		totalStakeWeight = newWeight
+
+		assetID := out.AssetID()
+		if i == 0 {
+			stakedAssetID = assetID
+		} else if assetID != stakedAssetID {
+			return 0, fmt.Errorf("%w: %q and %q", errMultipleStakeAssets, stakedAssetID, assetID)
+		}
+	}
+
+	if !avax.IsSortedTransferableOutputs(stakeOuts, Codec) {
+		return 0, errOutputsNotSorted
+	}
+	return totalStakeWeight, nil
+}
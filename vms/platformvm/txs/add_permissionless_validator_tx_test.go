@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+	"github.com/kukrer/savannahnode/utils/timer/mockable"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
+	"github.com/kukrer/savannahnode/vms/platformvm/validator"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestAddPermissionlessValidatorTxSyntacticVerify(t *testing.T) {
+	require := require.New(t)
+	clk := mockable.Clock{}
+	ctx := snow.DefaultContextTest()
+	signers := [][]*crypto.PrivateKeySECP256K1R{preFundedKeys}
+
+	var (
+		stx                          *Tx
+		addPermissionlessValidatorTx *AddPermissionlessValidatorTx
+		err                          error
+	)
+
+	// Case : signed tx is nil
+	require.ErrorIs(stx.SyntacticVerify(ctx), errNilSignedTx)
+
+	// Case : unsigned tx is nil
+	require.ErrorIs(addPermissionlessValidatorTx.SyntacticVerify(ctx), ErrNilTx)
+
+	validatorWeight := uint64(2022)
+	subnetID := ids.ID{'s', 'u', 'b', 'n', 'e', 't', 'I', 'D'}
+	stakedAssetID := ids.ID{'s', 't', 'a', 'k', 'e', 'd'}
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+	}
+	inputs := []*avax.TransferableInput{{
+		UTXOID: avax.UTXOID{
+			TxID:        ids.ID{'t', 'x', 'I', 'D'},
+			OutputIndex: 2,
+		},
+		Asset: avax.Asset{ID: stakedAssetID},
+		In: &secp256k1fx.TransferInput{
+			Amt:   validatorWeight,
+			Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+	}}
+	stakeOuts := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: stakedAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          validatorWeight,
+			OutputOwners: *owner,
+		},
+	}}
+	addPermissionlessValidatorTx = &AddPermissionlessValidatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    ctx.NetworkID,
+			BlockchainID: ctx.ChainID,
+			Ins:          inputs,
+			Memo:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		}},
+		Validator: validator.Validator{
+			NodeID: ctx.NodeID,
+			Start:  uint64(clk.Time().Unix()),
+			End:    uint64(clk.Time().Add(time.Hour).Unix()),
+			Wght:   validatorWeight,
+		},
+		Subnet:                subnetID,
+		Signer:                &signer.Empty{},
+		StakeOuts:             stakeOuts,
+		ValidatorRewardsOwner: owner,
+		DelegatorRewardsOwner: owner,
+		DelegationShares:      reward.PercentDenominator,
+	}
+
+	// Case: valid tx
+	stx, err = NewSigned(addPermissionlessValidatorTx, Codec, signers)
+	require.NoError(err)
+	require.NoError(stx.SyntacticVerify(ctx))
+
+	// Case: Missing signer
+	addPermissionlessValidatorTx.SyntacticallyVerified = false
+	addPermissionlessValidatorTx.Signer = nil
+	err = addPermissionlessValidatorTx.SyntacticVerify(ctx)
+	require.ErrorIs(err, errInvalidSigner)
+	addPermissionlessValidatorTx.Signer = &signer.Empty{}
+
+	// Case: Subnet validator with a BLS key
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	addPermissionlessValidatorTx.SyntacticallyVerified = false
+	addPermissionlessValidatorTx.Signer = signer.NewProofOfPossession(sk)
+	stx, err = NewSigned(addPermissionlessValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.ErrorIs(err, errInvalidSigner)
+
+	// Case: Primary network validator with a BLS key
+	addPermissionlessValidatorTx.SyntacticallyVerified = false
+	addPermissionlessValidatorTx.Subnet = constants.PrimaryNetworkID
+	stx, err = NewSigned(addPermissionlessValidatorTx, Codec, signers)
+	require.NoError(err)
+	require.NoError(stx.SyntacticVerify(ctx))
+
+	// Case: Primary network validator without a BLS key
+	addPermissionlessValidatorTx.SyntacticallyVerified = false
+	addPermissionlessValidatorTx.Signer = &signer.Empty{}
+	stx, err = NewSigned(addPermissionlessValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.ErrorIs(err, errInvalidSigner)
+	addPermissionlessValidatorTx.Subnet = subnetID
+
+	// Case: Wrong network ID
+	addPermissionlessValidatorTx.SyntacticallyVerified = false
+	addPermissionlessValidatorTx.NetworkID++
+	stx, err = NewSigned(addPermissionlessValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+	addPermissionlessValidatorTx.NetworkID--
+
+	// Case: No stake
+	addPermissionlessValidatorTx.SyntacticallyVerified = false
+	addPermissionlessValidatorTx.StakeOuts = nil
+	stx, err = NewSigned(addPermissionlessValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.ErrorIs(err, errNoStake)
+	addPermissionlessValidatorTx.StakeOuts = stakeOuts
+
+	// Case: Too many shares
+	addPermissionlessValidatorTx.SyntacticallyVerified = false
+	addPermissionlessValidatorTx.DelegationShares++
+	stx, err = NewSigned(addPermissionlessValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.ErrorIs(err, errTooManyShares)
+	addPermissionlessValidatorTx.DelegationShares--
+
+	// Case: Weight doesn't match the stake
+	addPermissionlessValidatorTx.SyntacticallyVerified = false
+	addPermissionlessValidatorTx.Validator.Wght++
+	stx, err = NewSigned(addPermissionlessValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+	addPermissionlessValidatorTx.Validator.Wght--
+
+	// Case: Stake outputs of multiple assets
+	addPermissionlessValidatorTx.SyntacticallyVerified = false
+	addPermissionlessValidatorTx.Validator.Wght = 2 * validatorWeight
+	addPermissionlessValidatorTx.StakeOuts = []*avax.TransferableOutput{
+		stakeOuts[0],
+		{
+			Asset: avax.Asset{ID: ids.ID{'o', 't', 'h', 'e', 'r'}},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          validatorWeight,
+				OutputOwners: *owner,
+			},
+		},
+	}
+	stx, err = NewSigned(addPermissionlessValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.ErrorIs(err, errMultipleStakeAssets)
+}
@@ -45,6 +45,10 @@ func (tx *BaseTx) InputIDs() ids.Set {
 
 func (tx *BaseTx) Outputs() []*avax.TransferableOutput { return tx.Outs }
 
+// Inputs returns the inputs that consume UTXOs of the P-chain. The inputs
+// imported from other chains aren't included.
+func (tx *BaseTx) Inputs() []*avax.TransferableInput { return tx.Ins }
+
 // InitCtx sets the FxID fields in the inputs and outputs of this [BaseTx]. Also
 // sets the [ctx] to the given [vm.ctx] so that the addresses can be json
 // marshalled into human readable format
@@ -10,11 +10,13 @@ import (
 
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/constants"
 	"github.com/kukrer/savannahnode/utils/crypto"
 	"github.com/kukrer/savannahnode/utils/math"
 	"github.com/kukrer/savannahnode/utils/timer/mockable"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/platformvm/config"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
 	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
@@ -23,13 +25,21 @@ import (
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
 
-// Max number of items allowed in a page
-const MaxPageSize = 1024
+const (
+	// Max number of items allowed in a page
+	MaxPageSize = 1024
+
+	// Max number of times a tx is rebuilt while searching for the dynamic fee
+	// it must burn
+	maxDynamicFeeIterations = 8
+)
 
 var (
 	_ Builder = &builder{}
 
-	errNoFunds = errors.New("no spendable funds were found")
+	errNoFunds                = errors.New("no spendable funds were found")
+	errCantAuthorize          = errors.New("provided keys can't authorize the tx")
+	errDynamicFeeNotConverged = errors.New("couldn't find a dynamic fee that covers the tx's complexity")
 )
 
 type Builder interface {
@@ -92,6 +102,43 @@ type DecisionTxBuilder interface {
 		keys []*crypto.PrivateKeySECP256K1R,
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
+
+	// nodeID: ID of the node to remove from the subnet
+	// subnetID: ID of the subnet the validator is being removed from
+	// keys: keys to use for removing the validator
+	// changeAddr: address to send change to, if there is any
+	NewRemoveSubnetValidatorTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		keys []*crypto.PrivateKeySECP256K1R,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
+	// subnetID: ID of the subnet whose ownership is transferred
+	// threshold: [threshold] of [ownerAddrs] needed to manage the subnet
+	// ownerAddrs: new control addresses of the subnet
+	// keys: keys to use for authorizing the transfer
+	// changeAddr: address to send change to, if there is any
+	NewTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		threshold uint32,
+		ownerAddrs []ids.ShortID,
+		keys []*crypto.PrivateKeySECP256K1R,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
+	// nodeID: ID of the primary network validator to top up
+	// endTime: unix time the validator will stop validating
+	// stakeAmount: amount of stake added to the validator
+	// keys: keys to use for funding the stake and authorizing the top up
+	// changeAddr: address to send change to, if there is any
+	NewTopUpValidatorTx(
+		nodeID ids.NodeID,
+		endTime uint64,
+		stakeAmount uint64,
+		keys []*crypto.PrivateKeySECP256K1R,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
 }
 
 type ProposalTxBuilder interface {
@@ -193,6 +240,18 @@ func (b *builder) NewImportTx(
 	to ids.ShortID,
 	keys []*crypto.PrivateKeySECP256K1R,
 	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newImportTx(from, to, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newImportTx(
+	from ids.ID,
+	to ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
 ) (*txs.Tx, error) {
 	kc := secp256k1fx.NewKeychain(keys...)
 
@@ -233,23 +292,27 @@ func (b *builder) NewImportTx(
 		return nil, errNoFunds // No imported UTXOs were spendable
 	}
 
+	fee, err := math.Add64(b.cfg.TxFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
 	importedAVAX := importedAmounts[b.ctx.AVAXAssetID]
 
 	ins := []*avax.TransferableInput{}
 	outs := []*avax.TransferableOutput{}
 	switch {
-	case importedAVAX < b.cfg.TxFee: // imported amount goes toward paying tx fee
+	case importedAVAX < fee: // imported amount goes toward paying tx fee
 		var baseSigners [][]*crypto.PrivateKeySECP256K1R
-		ins, outs, _, baseSigners, err = b.Spend(keys, 0, b.cfg.TxFee-importedAVAX, changeAddr)
+		ins, outs, _, baseSigners, err = b.Spend(keys, 0, fee-importedAVAX, changeAddr)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 		}
 		signers = append(baseSigners, signers...)
 		delete(importedAmounts, b.ctx.AVAXAssetID)
-	case importedAVAX == b.cfg.TxFee:
+	case importedAVAX == fee:
 		delete(importedAmounts, b.ctx.AVAXAssetID)
 	default:
-		importedAmounts[b.ctx.AVAXAssetID] -= b.cfg.TxFee
+		importedAmounts[b.ctx.AVAXAssetID] -= fee
 	}
 
 	for assetID, amount := range importedAmounts {
@@ -294,9 +357,26 @@ func (b *builder) NewExportTx(
 	keys []*crypto.PrivateKeySECP256K1R,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	toBurn, err := math.Add64(amount, b.cfg.TxFee)
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newExportTx(amount, chainID, to, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newExportTx(
+	amount uint64,
+	chainID ids.ID,
+	to ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
+) (*txs.Tx, error) {
+	fee, err := math.Add64(b.cfg.TxFee, dynamicFee)
 	if err != nil {
-		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, b.cfg.TxFee)
+		return nil, err
+	}
+	toBurn, err := math.Add64(amount, fee)
+	if err != nil {
+		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, fee)
 	}
 	ins, outs, _, signers, err := b.Spend(keys, 0, toBurn, changeAddr)
 	if err != nil {
@@ -339,10 +419,29 @@ func (b *builder) NewCreateChainTx(
 	chainName string,
 	keys []*crypto.PrivateKeySECP256K1R,
 	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newCreateChainTx(subnetID, genesisData, vmID, fxIDs, chainName, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newCreateChainTx(
+	subnetID ids.ID,
+	genesisData []byte,
+	vmID ids.ID,
+	fxIDs []ids.ID,
+	chainName string,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
 ) (*txs.Tx, error) {
 	timestamp := b.state.GetTimestamp()
 	createBlockchainTxFee := b.cfg.GetCreateBlockchainTxFee(timestamp)
-	ins, outs, _, signers, err := b.Spend(keys, 0, createBlockchainTxFee, changeAddr)
+	fee, err := math.Add64(createBlockchainTxFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(keys, 0, fee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -383,10 +482,26 @@ func (b *builder) NewCreateSubnetTx(
 	ownerAddrs []ids.ShortID,
 	keys []*crypto.PrivateKeySECP256K1R,
 	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newCreateSubnetTx(threshold, ownerAddrs, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newCreateSubnetTx(
+	threshold uint32,
+	ownerAddrs []ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
 ) (*txs.Tx, error) {
 	timestamp := b.state.GetTimestamp()
 	createSubnetTxFee := b.cfg.GetCreateSubnetTxFee(timestamp)
-	ins, outs, _, signers, err := b.Spend(keys, 0, createSubnetTxFee, changeAddr)
+	fee, err := math.Add64(createSubnetTxFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(keys, 0, fee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -424,7 +539,27 @@ func (b *builder) NewAddValidatorTx(
 	keys []*crypto.PrivateKeySECP256K1R,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(keys, stakeAmount, b.cfg.AddStakerTxFee, changeAddr)
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newAddValidatorTx(stakeAmount, startTime, endTime, nodeID, rewardAddress, shares, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newAddValidatorTx(
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	rewardAddress ids.ShortID,
+	shares uint32,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
+) (*txs.Tx, error) {
+	fee, err := math.Add64(b.cfg.AddStakerTxFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(keys, stakeAmount, fee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -466,7 +601,26 @@ func (b *builder) NewAddDelegatorTx(
 	keys []*crypto.PrivateKeySECP256K1R,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(keys, stakeAmount, b.cfg.AddStakerTxFee, changeAddr)
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newAddDelegatorTx(stakeAmount, startTime, endTime, nodeID, rewardAddress, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newAddDelegatorTx(
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	rewardAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
+) (*txs.Tx, error) {
+	fee, err := math.Add64(b.cfg.AddStakerTxFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(keys, stakeAmount, fee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -507,7 +661,26 @@ func (b *builder) NewAddSubnetValidatorTx(
 	keys []*crypto.PrivateKeySECP256K1R,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(keys, 0, b.cfg.TxFee, changeAddr)
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newAddSubnetValidatorTx(weight, startTime, endTime, nodeID, subnetID, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newAddSubnetValidatorTx(
+	weight,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
+) (*txs.Tx, error) {
+	fee, err := math.Add64(b.cfg.TxFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(keys, 0, fee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -544,6 +717,243 @@ func (b *builder) NewAddSubnetValidatorTx(
 	return tx, tx.SyntacticVerify(b.ctx)
 }
 
+func (b *builder) NewRemoveSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newRemoveSubnetValidatorTx(nodeID, subnetID, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newRemoveSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
+) (*txs.Tx, error) {
+	fee, err := math.Add64(b.cfg.TxFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(keys, 0, fee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &txs.RemoveSubnetValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	threshold uint32,
+	ownerAddrs []ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newTransferSubnetOwnershipTx(subnetID, threshold, ownerAddrs, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	threshold uint32,
+	ownerAddrs []ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
+) (*txs.Tx, error) {
+	fee, err := math.Add64(b.cfg.TxFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(keys, 0, fee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Sort control addresses
+	ids.SortShortIDs(ownerAddrs)
+
+	// Create the tx
+	utx := &txs.TransferSubnetOwnershipTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner: &secp256k1fx.OutputOwners{
+			Threshold: threshold,
+			Addrs:     ownerAddrs,
+		},
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewTopUpValidatorTx(
+	nodeID ids.NodeID,
+	endTime uint64,
+	stakeAmount uint64,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.withDynamicFee(func(dynamicFee uint64) (*txs.Tx, error) {
+		return b.newTopUpValidatorTx(nodeID, endTime, stakeAmount, keys, changeAddr, dynamicFee)
+	})
+}
+
+func (b *builder) newTopUpValidatorTx(
+	nodeID ids.NodeID,
+	endTime uint64,
+	stakeAmount uint64,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+	dynamicFee uint64,
+) (*txs.Tx, error) {
+	fee, err := math.Add64(b.cfg.AddStakerTxFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(keys, stakeAmount, fee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	validatorAuth, validatorSigners, err := b.authorizeValidator(nodeID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's validator restrictions: %w", err)
+	}
+	signers = append(signers, validatorSigners)
+
+	// Create the tx
+	utx := &txs.TopUpValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         unstakedOuts,
+		}},
+		NodeID:        nodeID,
+		End:           endTime,
+		Wght:          stakeAmount,
+		Stake:         stakedOuts,
+		ValidatorAuth: validatorAuth,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+// authorizeValidator proves, with [keys], ownership of the rewards owner of
+// the current primary network validator [nodeID].
+func (b *builder) authorizeValidator(
+	nodeID ids.NodeID,
+	keys []*crypto.PrivateKeySECP256K1R,
+) (*secp256k1fx.Input, []*crypto.PrivateKeySECP256K1R, error) {
+	vdr, err := b.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch validator %s: %w", nodeID, err)
+	}
+	vdrTx, _, err := b.state.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch validator tx %s: %w", vdr.TxID, err)
+	}
+
+	var rewardsOwner fx.Owner
+	switch uVdrTx := vdrTx.Unsigned.(type) {
+	case *txs.AddValidatorTx:
+		rewardsOwner = uVdrTx.RewardsOwner
+	case *txs.AddPermissionlessValidatorTx:
+		rewardsOwner = uVdrTx.ValidatorRewardsOwner
+	default:
+		return nil, nil, fmt.Errorf("unexpected validator tx type %T", vdrTx.Unsigned)
+	}
+	owner, ok := rewardsOwner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", rewardsOwner)
+	}
+
+	kc := secp256k1fx.NewKeychain(keys...)
+	now := uint64(b.clk.Time().Unix())
+	indices, signers, matches := kc.Match(owner, now)
+	if !matches {
+		return nil, nil, errCantAuthorize
+	}
+	return &secp256k1fx.Input{SigIndices: indices}, signers, nil
+}
+
+// withDynamicFee builds a tx with [build]. Once dynamic fees are activated,
+// the tx is rebuilt, burning more AVAX, until the amount it burns in addition
+// to its fixed fee covers its complexity-based fee.
+func (b *builder) withDynamicFee(build func(dynamicFee uint64) (*txs.Tx, error)) (*txs.Tx, error) {
+	if !b.cfg.IsDynamicFeesActivated(b.state.GetTimestamp()) {
+		return build(0)
+	}
+
+	baseFee := b.state.GetBaseFee()
+	dynamicFee := uint64(0)
+	for i := 0; i < maxDynamicFeeIterations; i++ {
+		tx, err := build(dynamicFee)
+		if err != nil {
+			return nil, err
+		}
+		complexity, err := fees.TxComplexity(tx)
+		if err != nil {
+			return nil, err
+		}
+		requiredFee, err := b.cfg.DynamicFees.Fee(complexity, baseFee)
+		if err != nil {
+			return nil, err
+		}
+		if requiredFee <= dynamicFee {
+			return tx, nil
+		}
+		dynamicFee = requiredFee
+	}
+	return nil, errDynamicFeeNotConverged
+}
+
 func (b *builder) NewAdvanceTimeTx(timestamp time.Time) (*txs.Tx, error) {
 	utx := &txs.AdvanceTimeTx{Time: uint64(timestamp.Unix())}
 	tx, err := txs.NewSigned(utx, txs.Codec, nil)
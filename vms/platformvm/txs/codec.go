@@ -7,6 +7,8 @@ import (
 	"github.com/kukrer/savannahnode/codec"
 	"github.com/kukrer/savannahnode/codec/linearcodec"
 	"github.com/kukrer/savannahnode/utils/wrappers"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
 	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
@@ -67,6 +69,34 @@ func RegisterUnsignedTxsTypes(targetCodec codec.Registry) error {
 
 		targetCodec.RegisterType(&stakeable.LockIn{}),
 		targetCodec.RegisterType(&stakeable.LockOut{}),
+
+		targetCodec.RegisterType(&RemoveSubnetValidatorTx{}),
+		targetCodec.RegisterType(&TransformSubnetTx{}),
+		targetCodec.RegisterType(&AddPermissionlessValidatorTx{}),
+		targetCodec.RegisterType(&AddPermissionlessDelegatorTx{}),
+
+		targetCodec.RegisterType(&signer.Empty{}),
+		targetCodec.RegisterType(&signer.ProofOfPossession{}),
+
+		targetCodec.RegisterType(&TransferSubnetOwnershipTx{}),
+
+		targetCodec.RegisterType(&stakeable.VestingIn{}),
+		targetCodec.RegisterType(&stakeable.VestingOut{}),
+
+		targetCodec.RegisterType(&TopUpValidatorTx{}),
+
+		// The htlcfx types are registered at htlcfx.FirstTypeID, which is also
+		// where the AVM registers them. This ensures that HTLC utxos can be
+		// exchanged through shared memory.
+		targetCodec.RegisterType(&htlcfx.TransferInput{}),
+		targetCodec.RegisterType(&htlcfx.TransferOutput{}),
+		targetCodec.RegisterType(&htlcfx.Credential{}),
+
+		// The weighted owners types are registered at
+		// secp256k1fx.WeightedFirstTypeID, which is also where the AVM
+		// registers them.
+		targetCodec.RegisterType(&secp256k1fx.WeightedTransferOutput{}),
+		targetCodec.RegisterType(&secp256k1fx.WeightedOutputOwners{}),
 	)
 	return errs.Err
 }
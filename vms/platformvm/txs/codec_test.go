@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/hashing"
+	"github.com/kukrer/savannahnode/utils/wrappers"
+	"github.com/kukrer/savannahnode/vms/avm/fxs"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
+	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/propertyfx"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+
+	avmtxs "github.com/kukrer/savannahnode/vms/avm/txs"
+)
+
+// Ensure that HTLC utxos are serialized identically by the X-chain and the
+// P-chain, so that they can be exchanged through shared memory.
+func TestHTLCFxCodecAlignment(t *testing.T) {
+	require := require.New(t)
+
+	avmParser, err := avmtxs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+		&htlcfx.Fx{},
+	})
+	require.NoError(err)
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        ids.GenerateTestID(),
+			OutputIndex: 1,
+		},
+		Asset: avax.Asset{ID: ids.GenerateTestID()},
+		Out: &htlcfx.TransferOutput{
+			Amt:      1,
+			Hash:     hashing.ComputeHash256Array([]byte("secret")),
+			Deadline: 1,
+			Recipient: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+			Sender: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}
+
+	avmBytes, err := avmParser.Codec().Marshal(Version, utxo)
+	require.NoError(err)
+	platformBytes, err := Codec.Marshal(Version, utxo)
+	require.NoError(err)
+	require.Equal(avmBytes, platformBytes)
+
+	// The output is prefixed by the type ID of htlcfx.TransferOutput
+	p := wrappers.Packer{Bytes: platformBytes, Offset: wrappers.ShortLen + 2*hashing.HashLen + wrappers.IntLen}
+	require.EqualValues(htlcfx.FirstTypeID+1, p.UnpackInt())
+
+	parsedUTXO := &avax.UTXO{}
+	_, err = Codec.Unmarshal(avmBytes, parsedUTXO)
+	require.NoError(err)
+	require.Equal(utxo.Out, parsedUTXO.Out)
+}
+
+// Ensure that weighted secp256k1fx utxos are serialized identically by the
+// X-chain, with or without the htlcfx, and the P-chain.
+func TestWeightedOwnersCodecAlignment(t *testing.T) {
+	require := require.New(t)
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        ids.GenerateTestID(),
+			OutputIndex: 1,
+		},
+		Asset: avax.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.WeightedTransferOutput{
+			Amt: 1,
+			WeightedOutputOwners: secp256k1fx.WeightedOutputOwners{
+				Threshold: 3,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				Weights:   []uint64{3},
+			},
+		},
+	}
+
+	platformBytes, err := Codec.Marshal(Version, utxo)
+	require.NoError(err)
+
+	// The output is prefixed by the type ID of
+	// secp256k1fx.WeightedTransferOutput
+	p := wrappers.Packer{Bytes: platformBytes, Offset: wrappers.ShortLen + 2*hashing.HashLen + wrappers.IntLen}
+	require.EqualValues(secp256k1fx.WeightedFirstTypeID, p.UnpackInt())
+
+	for _, fxList := range [][]fxs.Fx{
+		{
+			&secp256k1fx.Fx{},
+			&nftfx.Fx{},
+			&propertyfx.Fx{},
+		},
+		{
+			&secp256k1fx.Fx{},
+			&nftfx.Fx{},
+			&propertyfx.Fx{},
+			&htlcfx.Fx{},
+		},
+	} {
+		avmParser, err := avmtxs.NewParser(fxList)
+		require.NoError(err)
+
+		avmBytes, err := avmParser.Codec().Marshal(Version, utxo)
+		require.NoError(err)
+		require.Equal(platformBytes, avmBytes)
+
+		parsedUTXO := &avax.UTXO{}
+		_, err = avmParser.Codec().Unmarshal(platformBytes, parsedUTXO)
+		require.NoError(err)
+		require.Equal(utxo.Out, parsedUTXO.Out)
+	}
+}
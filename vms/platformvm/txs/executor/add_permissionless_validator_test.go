@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/platformvm/validator"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+// newPrimaryValidatorTx returns a signed AddPermissionlessValidatorTx that
+// adds [nodeID] to the primary network with the provided [signer].
+func newPrimaryValidatorTx(
+	env *environment,
+	nodeID ids.NodeID,
+	signer signer.Signer,
+) (*txs.Tx, error) {
+	keys := []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]}
+	startTime := defaultValidateStartTime.Add(time.Second)
+	weight := env.config.MinValidatorStake
+	ins, unstakedOuts, stakedOuts, signers, err := env.utxosHandler.Spend(
+		keys,
+		weight,
+		env.config.AddStakerTxFee,
+		ids.ShortEmpty,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+	}
+	utx := &txs.AddPermissionlessValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+			Ins:          ins,
+			Outs:         unstakedOuts,
+		}},
+		Validator: validator.Validator{
+			NodeID: nodeID,
+			Start:  uint64(startTime.Unix()),
+			End:    uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+			Wght:   weight,
+		},
+		Subnet:                constants.PrimaryNetworkID,
+		Signer:                signer,
+		StakeOuts:             stakedOuts,
+		ValidatorRewardsOwner: owner,
+		DelegatorRewardsOwner: owner,
+		DelegationShares:      reward.PercentDenominator,
+	}
+	return txs.NewSigned(utx, txs.Codec, signers)
+}
+
+func TestAddPermissionlessPrimaryValidatorTxExecute(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	tx, err := newPrimaryValidatorTx(env, nodeID, signer.NewProofOfPossession(sk))
+	require.NoError(err)
+
+	executor := ProposalTxExecutor{
+		Backend:       &env.backend,
+		ParentID:      lastAcceptedID,
+		StateVersions: env,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&executor))
+
+	staker, err := executor.OnCommit.GetPendingValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.Equal(state.PrimaryNetworkValidatorPendingPriority, staker.Priority)
+	require.Equal(
+		bls.PublicKeyToBytes(bls.PublicFromSecretKey(sk)),
+		bls.PublicKeyToBytes(staker.PublicKey),
+	)
+}
+
+func TestAddPermissionlessPrimaryValidatorTxWithoutKey(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	tx, err := newPrimaryValidatorTx(env, ids.GenerateTestNodeID(), &signer.Empty{})
+	require.NoError(err)
+
+	executor := ProposalTxExecutor{
+		Backend:       &env.backend,
+		ParentID:      lastAcceptedID,
+		StateVersions: env,
+		Tx:            tx,
+	}
+	require.Error(tx.Unsigned.Visit(&executor))
+}
+
+func TestAddPermissionlessPrimaryValidatorTxBeforeBaobab(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	env.config.BaobabTime = defaultGenesisTime.Add(time.Hour)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	tx, err := newPrimaryValidatorTx(env, ids.GenerateTestNodeID(), signer.NewProofOfPossession(sk))
+	require.NoError(err)
+
+	executor := ProposalTxExecutor{
+		Backend:       &env.backend,
+		ParentID:      lastAcceptedID,
+		StateVersions: env,
+		Tx:            tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errIssuedBeforeBaobab)
+
+	verifier := MempoolTxVerifier{
+		Backend:       &env.backend,
+		ParentID:      lastAcceptedID,
+		StateVersions: env,
+		Tx:            tx,
+	}
+	err = tx.Unsigned.Visit(&verifier)
+	require.ErrorIs(err, errIssuedBeforeBaobab)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/constants"
 	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/platformvm/config"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/status"
@@ -165,6 +166,76 @@ func TestAdvanceTimeTxUpdatePrimaryNetworkStakers(t *testing.T) {
 	require.True(env.config.Validators.Contains(constants.PrimaryNetworkID, nodeID))
 }
 
+// Ensure the potential reward of a staker is calculated with the reward config
+// in effect at its start time.
+func TestAdvanceTimeTxStakingUpgrade(t *testing.T) {
+	pendingValidatorStartTime := defaultGenesisTime.Add(1 * time.Second)
+	pendingValidatorEndTime := pendingValidatorStartTime.Add(defaultMinStakingDuration)
+
+	tests := []struct {
+		name        string
+		upgradeTime time.Time
+		upgraded    bool
+	}{
+		{
+			name:        "staker starts before upgrade",
+			upgradeTime: pendingValidatorStartTime.Add(time.Second),
+			upgraded:    false,
+		},
+		{
+			name:        "staker starts at upgrade",
+			upgradeTime: pendingValidatorStartTime,
+			upgraded:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			env := newEnvironment()
+			env.ctx.Lock.Lock()
+			defer func() {
+				require.NoError(shutdownEnvironment(env))
+			}()
+
+			upgradedConfig := env.config.GetStakingConfig(defaultGenesisTime)
+			upgradedConfig.RewardConfig.MaxConsumptionRate = upgradedConfig.RewardConfig.MinConsumptionRate
+			env.config.StakingUpgrades = []config.StakingUpgrade{{
+				Time:          test.upgradeTime,
+				StakingConfig: upgradedConfig,
+			}}
+
+			nodeID := ids.GenerateTestNodeID()
+			_, err := addPendingValidator(env, pendingValidatorStartTime, pendingValidatorEndTime, nodeID, []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]})
+			require.NoError(err)
+
+			tx, err := env.txBuilder.NewAdvanceTimeTx(pendingValidatorStartTime)
+			require.NoError(err)
+
+			executor := ProposalTxExecutor{
+				Backend:       &env.backend,
+				ParentID:      lastAcceptedID,
+				StateVersions: env,
+				Tx:            tx,
+			}
+			require.NoError(tx.Unsigned.Visit(&executor))
+
+			validatorStaker, err := executor.OnCommit.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+			require.NoError(err)
+
+			expectedReward := uint64(1370) // See rewards tests to explain why 1370
+			if test.upgraded {
+				expectedReward = reward.NewCalculator(upgradedConfig.RewardConfig).Calculate(
+					defaultMinStakingDuration,
+					env.config.MinValidatorStake,
+					env.state.GetCurrentSupply(),
+				)
+				require.Less(expectedReward, uint64(1370))
+			}
+			require.Equal(expectedReward, validatorStaker.PotentialReward)
+		})
+	}
+}
+
 // Ensure semantic verification updates the current and pending staker sets correctly.
 // Namely, it should add pending stakers whose start time is at or before the timestamp.
 // It will not remove primary network stakers; that happens in rewardTxs.
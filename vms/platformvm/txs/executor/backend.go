@@ -10,7 +10,6 @@ import (
 	"github.com/kukrer/savannahnode/utils/timer/mockable"
 	"github.com/kukrer/savannahnode/vms/platformvm/config"
 	"github.com/kukrer/savannahnode/vms/platformvm/fx"
-	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 	"github.com/kukrer/savannahnode/vms/platformvm/utxo"
 )
 
@@ -21,6 +20,5 @@ type Backend struct {
 	Fx           fx.Fx
 	FlowChecker  utxo.Verifier
 	Uptimes      uptime.Manager
-	Rewards      reward.Calculator
 	Bootstrapped *utils.AtomicBool
 }
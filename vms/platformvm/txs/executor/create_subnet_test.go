@@ -12,6 +12,7 @@ import (
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/units"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
@@ -86,3 +87,149 @@ func TestCreateSubnetTxAP3FeeChange(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateSubnetTxDynamicFees(t *testing.T) {
+	dynamicFeesTime := defaultGenesisTime.Add(time.Hour)
+	baseFee := uint64(5)
+	tests := []struct {
+		name         string
+		time         time.Time
+		underpay     bool
+		expectsError bool
+	}{
+		{
+			name:         "pre-fork - fixed fee only",
+			time:         defaultGenesisTime,
+			underpay:     true,
+			expectsError: false,
+		},
+		{
+			name:         "post-fork - incorrectly priced",
+			time:         dynamicFeesTime,
+			underpay:     true,
+			expectsError: true,
+		},
+		{
+			name:         "post-fork - correctly priced",
+			time:         dynamicFeesTime,
+			underpay:     false,
+			expectsError: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			env := newEnvironment()
+			env.config.DynamicFeesTime = dynamicFeesTime
+			env.config.DynamicFees = fees.Config{
+				BytesWeight:      1,
+				InputsWeight:     1_000,
+				SignaturesWeight: 1_000,
+			}
+			env.ctx.Lock.Lock()
+			defer func() {
+				require.NoError(shutdownEnvironment(env))
+			}()
+
+			buildTx := func(fee uint64) *txs.Tx {
+				ins, outs, _, signers, err := env.utxosHandler.Spend(preFundedKeys, 0, fee, ids.ShortEmpty)
+				require.NoError(err)
+
+				utx := &txs.CreateSubnetTx{
+					BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+						NetworkID:    env.ctx.NetworkID,
+						BlockchainID: env.ctx.ChainID,
+						Ins:          ins,
+						Outs:         outs,
+					}},
+					Owner: &secp256k1fx.OutputOwners{},
+				}
+				tx := &txs.Tx{Unsigned: utx}
+				require.NoError(tx.Sign(txs.Codec, signers))
+				return tx
+			}
+
+			// The complexity of the tx doesn't depend on the amount burned, as
+			// long as a UTXO is consumed, so the dynamic fee can be calculated
+			// from a tx that burns a single nAVAX.
+			complexity, err := fees.TxComplexity(buildTx(1))
+			require.NoError(err)
+			dynamicFee, err := env.config.DynamicFees.Fee(complexity, baseFee)
+			require.NoError(err)
+			require.NotZero(dynamicFee)
+
+			fee := dynamicFee
+			if test.underpay {
+				fee--
+			}
+			tx := buildTx(fee)
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			stateDiff.SetTimestamp(test.time)
+			stateDiff.SetBaseFee(baseFee)
+
+			executor := StandardTxExecutor{
+				Backend: &env.backend,
+				State:   stateDiff,
+				Tx:      tx,
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.Equal(test.expectsError, err != nil)
+		})
+	}
+}
+
+func TestCreateSubnetTxWeightedOwnerBeforeBaobab(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.config.BaobabTime = defaultGenesisTime.Add(time.Hour)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	fee := env.config.GetCreateSubnetTxFee(env.state.GetTimestamp())
+	ins, outs, _, signers, err := env.utxosHandler.Spend(preFundedKeys, 0, fee, ids.ShortEmpty)
+	require.NoError(err)
+
+	utx := &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Owner: &secp256k1fx.WeightedOutputOwners{},
+	}
+	tx := &txs.Tx{Unsigned: utx}
+	require.NoError(tx.Sign(txs.Codec, signers))
+
+	for _, test := range []struct {
+		time        time.Time
+		expectedErr error
+	}{
+		{
+			time:        defaultGenesisTime,
+			expectedErr: errIssuedBeforeBaobab,
+		},
+		{
+			time: env.config.BaobabTime,
+		},
+	} {
+		stateDiff, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+		stateDiff.SetTimestamp(test.time)
+
+		executor := StandardTxExecutor{
+			Backend: &env.backend,
+			State:   stateDiff,
+			Tx:      tx,
+		}
+		err = tx.Unsigned.Visit(&executor)
+		require.ErrorIs(err, test.expectedErr)
+	}
+}
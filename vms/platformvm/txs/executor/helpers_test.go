@@ -139,7 +139,7 @@ func newEnvironment() *environment {
 
 	atomicUTXOs := avax.NewAtomicUTXOManager(ctx.SharedMemory, txs.Codec)
 	uptimes := uptime.NewManager(baseState)
-	utxoHandler := utxo.NewHandler(ctx, &clk, baseState, fx)
+	utxoHandler := utxo.NewHandler(ctx, &config, &clk, baseState, fx)
 
 	txBuilder := builder.New(
 		ctx,
@@ -159,7 +159,6 @@ func newEnvironment() *environment {
 		Fx:           fx,
 		FlowChecker:  utxoHandler,
 		Uptimes:      uptimes,
-		Rewards:      rewards,
 	}
 
 	env := &environment{
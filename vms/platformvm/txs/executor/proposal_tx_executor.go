@@ -14,10 +14,13 @@ import (
 	"github.com/kukrer/savannahnode/utils/math"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/utxo"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
 
 const (
@@ -48,6 +51,11 @@ var (
 	errWrongTxType               = errors.New("wrong transaction type")
 	errInvalidID                 = errors.New("invalid ID")
 	errEmptyNodeID               = errors.New("validator nodeID cannot be empty")
+	errNotPermissionlessSubnet   = errors.New("subnet is not permissionless")
+	errWrongStakedAssetID        = errors.New("incorrect staked assetID")
+	errNotPermissionlessStaker   = errors.New("validator is not a permissionless staker")
+	errIssuedBeforeBaobab        = errors.New("tx can only be issued after the baobab upgrade")
+	errIsPermissionlessSubnet    = errors.New("subnet is permissionless")
 )
 
 type ProposalTxExecutor struct {
@@ -61,12 +69,30 @@ type ProposalTxExecutor struct {
 	OnCommit      state.Diff
 	OnAbort       state.Diff
 	PrefersCommit bool
+	// Uptime is the uptime, as measured by this node, of the staker removed by
+	// a RewardValidatorTx
+	Uptime float64
 }
 
 func (*ProposalTxExecutor) CreateChainTx(*txs.CreateChainTx) error   { return errWrongTxType }
 func (*ProposalTxExecutor) CreateSubnetTx(*txs.CreateSubnetTx) error { return errWrongTxType }
 func (*ProposalTxExecutor) ImportTx(*txs.ImportTx) error             { return errWrongTxType }
 func (*ProposalTxExecutor) ExportTx(*txs.ExportTx) error             { return errWrongTxType }
+func (*ProposalTxExecutor) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) TransformSubnetTx(*txs.TransformSubnetTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) TopUpValidatorTx(*txs.TopUpValidatorTx) error {
+	return errWrongTxType
+}
 
 func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	// Verify the tx is well-formed
@@ -74,27 +100,28 @@ func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 		return err
 	}
 
+	stakingConfig := e.Config.GetStakingConfig(tx.StartTime())
 	switch {
-	case tx.Validator.Wght < e.Config.MinValidatorStake:
+	case tx.Validator.Wght < stakingConfig.MinValidatorStake:
 		// Ensure validator is staking at least the minimum amount
 		return errWeightTooSmall
 
-	case tx.Validator.Wght > e.Config.MaxValidatorStake:
+	case tx.Validator.Wght > stakingConfig.MaxValidatorStake:
 		// Ensure validator isn't staking too much
 		return errWeightTooLarge
 
-	case tx.Shares < e.Config.MinDelegationFee:
+	case tx.Shares < stakingConfig.MinDelegationFee:
 		// Ensure the validator fee is at least the minimum amount
 		return errInsufficientDelegationFee
 	}
 
 	duration := tx.Validator.Duration()
 	switch {
-	case duration < e.Config.MinStakeDuration:
+	case duration < stakingConfig.MinStakeDuration:
 		// Ensure staking length is not too short
 		return errStakeTooShort
 
-	case duration > e.Config.MaxStakeDuration:
+	case duration > stakingConfig.MaxStakeDuration:
 		// Ensure staking length is not too long
 		return errStakeTooLong
 	}
@@ -103,6 +130,9 @@ func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	if !ok {
 		return state.ErrMissingParentState
 	}
+	if err := verifyOwnerActivated(e.Backend, parentState, tx.RewardsOwner); err != nil {
+		return err
+	}
 
 	currentTimestamp := parentState.GetTimestamp()
 
@@ -144,6 +174,10 @@ func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 		}
 
 		// Verify the flowcheck
+		fee, err := getTxFee(e.Backend, parentState, e.Tx, e.Config.AddStakerTxFee)
+		if err != nil {
+			return err
+		}
 		if err := e.FlowChecker.VerifySpend(
 			tx,
 			parentState,
@@ -151,8 +185,9 @@ func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 			outs,
 			e.Tx.Creds,
 			map[ids.ID]uint64{
-				e.Ctx.AVAXAssetID: e.Config.AddStakerTxFee,
+				e.Ctx.AVAXAssetID: fee,
 			},
+			parentState.GetTimestamp(),
 		); err != nil {
 			return fmt.Errorf("failed verifySpend: %w", err)
 		}
@@ -207,13 +242,14 @@ func (e *ProposalTxExecutor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx)
 		return err
 	}
 
+	stakingConfig := e.Config.GetStakingConfig(tx.StartTime())
 	duration := tx.Validator.Duration()
 	switch {
-	case duration < e.Config.MinStakeDuration:
+	case duration < stakingConfig.MinStakeDuration:
 		// Ensure staking length is not too short
 		return errStakeTooShort
 
-	case duration > e.Config.MaxStakeDuration:
+	case duration > stakingConfig.MaxStakeDuration:
 		// Ensure staking length is not too long
 		return errStakeTooLong
 
@@ -227,6 +263,11 @@ func (e *ProposalTxExecutor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx)
 		return state.ErrMissingParentState
 	}
 
+	// The validators of a permissionless subnet can't be added by its owner
+	if err := verifyPermissionedSubnet(parentState, tx.Validator.Subnet); err != nil {
+		return err
+	}
+
 	if e.Bootstrapped.GetValue() {
 		currentTimestamp := parentState.GetTimestamp()
 		// Ensure the proposed validator starts after the current timestamp
@@ -273,28 +314,20 @@ func (e *ProposalTxExecutor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx)
 		baseTxCreds := e.Tx.Creds[:baseTxCredsLen]
 		subnetCred := e.Tx.Creds[baseTxCredsLen]
 
-		subnetIntf, _, err := parentState.GetTx(tx.Validator.Subnet)
+		subnetOwner, err := parentState.GetSubnetOwner(tx.Validator.Subnet)
 		if err != nil {
-			return fmt.Errorf(
-				"couldn't find subnet %q: %w",
-				tx.Validator.Subnet,
-				err,
-			)
-		}
-
-		subnet, ok := subnetIntf.Unsigned.(*txs.CreateSubnetTx)
-		if !ok {
-			return fmt.Errorf(
-				"%s is not a subnet",
-				tx.Validator.Subnet,
-			)
+			return err
 		}
 
-		if err := e.Fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnet.Owner); err != nil {
+		if err := e.Fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
 			return err
 		}
 
 		// Verify the flowcheck
+		fee, err := getTxFee(e.Backend, parentState, e.Tx, e.Config.TxFee)
+		if err != nil {
+			return err
+		}
 		if err := e.FlowChecker.VerifySpend(
 			tx,
 			parentState,
@@ -302,8 +335,9 @@ func (e *ProposalTxExecutor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx)
 			tx.Outs,
 			baseTxCreds,
 			map[ids.ID]uint64{
-				e.Ctx.AVAXAssetID: e.Config.TxFee,
+				e.Ctx.AVAXAssetID: fee,
 			},
+			parentState.GetTimestamp(),
 		); err != nil {
 			return err
 		}
@@ -358,17 +392,18 @@ func (e *ProposalTxExecutor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 		return err
 	}
 
+	stakingConfig := e.Config.GetStakingConfig(tx.StartTime())
 	duration := tx.Validator.Duration()
 	switch {
-	case duration < e.Config.MinStakeDuration:
+	case duration < stakingConfig.MinStakeDuration:
 		// Ensure staking length is not too short
 		return errStakeTooShort
 
-	case duration > e.Config.MaxStakeDuration:
+	case duration > stakingConfig.MaxStakeDuration:
 		// Ensure staking length is not too long
 		return errStakeTooLong
 
-	case tx.Validator.Wght < e.Config.MinDelegatorStake:
+	case tx.Validator.Wght < stakingConfig.MinDelegatorStake:
 		// Ensure validator is staking at least the minimum amount
 		return errWeightTooSmall
 	}
@@ -381,6 +416,9 @@ func (e *ProposalTxExecutor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 	if !ok {
 		return state.ErrMissingParentState
 	}
+	if err := verifyOwnerActivated(e.Backend, parentState, tx.RewardsOwner); err != nil {
+		return err
+	}
 
 	txID := e.Tx.ID()
 
@@ -394,31 +432,359 @@ func (e *ProposalTxExecutor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 		validatorStartTime := tx.StartTime()
 		if !currentTimestamp.Before(validatorStartTime) {
 			return fmt.Errorf(
-				"chain timestamp (%s) not before validator's start time (%s)",
+				"chain timestamp (%s) not before validator's start time (%s)",
+				currentTimestamp,
+				validatorStartTime,
+			)
+		}
+
+		primaryNetworkValidator, err := GetValidator(parentState, constants.PrimaryNetworkID, tx.Validator.NodeID)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to fetch the primary network validator for %s: %w",
+				tx.Validator.NodeID,
+				err,
+			)
+		}
+
+		maximumWeight, err := math.Mul64(MaxValidatorWeightFactor, primaryNetworkValidator.Weight)
+		if err != nil {
+			return errStakeOverflow
+		}
+
+		if !currentTimestamp.Before(e.Config.ApricotPhase3Time) {
+			maximumWeight = math.Min64(maximumWeight, stakingConfig.MaxValidatorStake)
+		}
+
+		canDelegate, err := canDelegate(parentState, primaryNetworkValidator, maximumWeight, newStaker)
+		if err != nil {
+			return err
+		}
+		if !canDelegate {
+			return errOverDelegated
+		}
+
+		// Verify the flowcheck
+		fee, err := getTxFee(e.Backend, parentState, e.Tx, e.Config.AddStakerTxFee)
+		if err != nil {
+			return err
+		}
+		if err := e.FlowChecker.VerifySpend(
+			tx,
+			parentState,
+			tx.Ins,
+			outs,
+			e.Tx.Creds,
+			map[ids.ID]uint64{
+				e.Ctx.AVAXAssetID: fee,
+			},
+			parentState.GetTimestamp(),
+		); err != nil {
+			return fmt.Errorf("failed verifySpend: %w", err)
+		}
+
+		// Make sure the tx doesn't start too far in the future. This is done
+		// last to allow the verifier visitor to explicitly check for this
+		// error.
+		maxStartTime := currentTimestamp.Add(MaxFutureStartTime)
+		if validatorStartTime.After(maxStartTime) {
+			return errFutureStakeTime
+		}
+	}
+
+	// Set up the state if this tx is committed
+	onCommit, err := state.NewDiff(e.ParentID, e.StateVersions)
+	if err != nil {
+		return err
+	}
+	e.OnCommit = onCommit
+
+	// Consume the UTXOS
+	utxo.Consume(e.OnCommit, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.OnCommit, txID, tx.Outs)
+
+	e.OnCommit.PutPendingDelegator(newStaker)
+
+	// Set up the state if this tx is aborted
+	onAbort, err := state.NewDiff(e.ParentID, e.StateVersions)
+	if err != nil {
+		return err
+	}
+	e.OnAbort = onAbort
+
+	// Consume the UTXOS
+	utxo.Consume(e.OnAbort, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.OnAbort, txID, outs)
+
+	e.PrefersCommit = tx.StartTime().After(e.Clk.Time())
+	return nil
+}
+
+func (e *ProposalTxExecutor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	// Verify the tx is well-formed
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	parentState, ok := e.StateVersions.GetState(e.ParentID)
+	if !ok {
+		return state.ErrMissingParentState
+	}
+
+	if err := verifyBaobabActivated(e.Backend, parentState); err != nil {
+		return err
+	}
+
+	rules, err := getValidatorRules(e.Backend, parentState, tx.Subnet, tx.StartTime())
+	if err != nil {
+		return err
+	}
+
+	duration := tx.Validator.Duration()
+	switch {
+	case tx.Validator.Wght < rules.minValidatorStake:
+		// Ensure validator is staking at least the minimum amount
+		return errWeightTooSmall
+
+	case tx.Validator.Wght > rules.maxValidatorStake:
+		// Ensure validator isn't staking too much
+		return errWeightTooLarge
+
+	case tx.DelegationShares < rules.minDelegationFee:
+		// Ensure the validator fee is at least the minimum amount
+		return errInsufficientDelegationFee
+
+	case duration < rules.minStakeDuration:
+		// Ensure staking length is not too short
+		return errStakeTooShort
+
+	case duration > rules.maxStakeDuration:
+		// Ensure staking length is not too long
+		return errStakeTooLong
+
+	case tx.Validator.NodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	}
+
+	for _, out := range tx.StakeOuts {
+		if assetID := out.AssetID(); assetID != rules.assetID {
+			return fmt.Errorf(
+				"%w: %s != %s",
+				errWrongStakedAssetID,
+				assetID,
+				rules.assetID,
+			)
+		}
+	}
+
+	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
+	copy(outs, tx.Outs)
+	copy(outs[len(tx.Outs):], tx.StakeOuts)
+
+	if e.Bootstrapped.GetValue() {
+		currentTimestamp := parentState.GetTimestamp()
+		// Ensure the proposed validator starts after the current time
+		startTime := tx.StartTime()
+		if !currentTimestamp.Before(startTime) {
+			return fmt.Errorf(
+				"validator's start time (%s) at or before current timestamp (%s)",
+				startTime,
+				currentTimestamp,
+			)
+		}
+
+		_, err := GetValidator(parentState, tx.Subnet, tx.Validator.NodeID)
+		if err == nil {
+			return fmt.Errorf(
+				"attempted to issue duplicate validation for %s on subnet %s",
+				tx.Validator.NodeID,
+				tx.Subnet,
+			)
+		}
+		if err != database.ErrNotFound {
+			return fmt.Errorf(
+				"failed to find whether %s is a validator of subnet %s: %w",
+				tx.Validator.NodeID,
+				tx.Subnet,
+				err,
+			)
+		}
+
+		if tx.Subnet != constants.PrimaryNetworkID {
+			primaryNetworkValidator, err := GetValidator(parentState, constants.PrimaryNetworkID, tx.Validator.NodeID)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to fetch the primary network validator for %s: %w",
+					tx.Validator.NodeID,
+					err,
+				)
+			}
+
+			// Ensure that the period this validator validates the specified
+			// subnet is a subset of the time they validate the primary network.
+			if !tx.Validator.BoundedBy(primaryNetworkValidator.StartTime, primaryNetworkValidator.EndTime) {
+				return errValidatorSubset
+			}
+		}
+
+		// Verify the flowcheck
+		fee, err := getTxFee(e.Backend, parentState, e.Tx, rules.fee)
+		if err != nil {
+			return err
+		}
+		if err := e.FlowChecker.VerifySpend(
+			tx,
+			parentState,
+			tx.Ins,
+			outs,
+			e.Tx.Creds,
+			map[ids.ID]uint64{
+				e.Ctx.AVAXAssetID: fee,
+			},
+			parentState.GetTimestamp(),
+		); err != nil {
+			return fmt.Errorf("failed verifySpend: %w", err)
+		}
+
+		// Make sure the tx doesn't start too far in the future. This is done
+		// last to allow the verifier visitor to explicitly check for this
+		// error.
+		maxStartTime := currentTimestamp.Add(MaxFutureStartTime)
+		if startTime.After(maxStartTime) {
+			return errFutureStakeTime
+		}
+	}
+
+	txID := e.Tx.ID()
+
+	// Set up the state if this tx is committed
+	onCommit, err := state.NewDiff(e.ParentID, e.StateVersions)
+	if err != nil {
+		return err
+	}
+	e.OnCommit = onCommit
+
+	// Consume the UTXOS
+	utxo.Consume(e.OnCommit, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.OnCommit, txID, tx.Outs)
+
+	newStaker, err := state.NewPermissionlessValidatorStaker(txID, tx)
+	if err != nil {
+		return err
+	}
+	newStaker.NextTime = newStaker.StartTime
+	newStaker.Priority = rules.pendingPriority
+	e.OnCommit.PutPendingValidator(newStaker)
+
+	// Set up the state if this tx is aborted
+	onAbort, err := state.NewDiff(e.ParentID, e.StateVersions)
+	if err != nil {
+		return err
+	}
+	e.OnAbort = onAbort
+
+	// Consume the UTXOS
+	utxo.Consume(e.OnAbort, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.OnAbort, txID, outs)
+
+	e.PrefersCommit = tx.StartTime().After(e.Clk.Time())
+	return nil
+}
+
+func (e *ProposalTxExecutor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	// Verify the tx is well-formed
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	parentState, ok := e.StateVersions.GetState(e.ParentID)
+	if !ok {
+		return state.ErrMissingParentState
+	}
+
+	if err := verifyBaobabActivated(e.Backend, parentState); err != nil {
+		return err
+	}
+
+	transformation, err := GetTransformSubnetTx(parentState, tx.Validator.Subnet)
+	if err != nil {
+		return err
+	}
+
+	duration := tx.Validator.Duration()
+	switch {
+	case duration < time.Duration(transformation.MinStakeDuration)*time.Second:
+		// Ensure staking length is not too short
+		return errStakeTooShort
+
+	case duration > time.Duration(transformation.MaxStakeDuration)*time.Second:
+		// Ensure staking length is not too long
+		return errStakeTooLong
+
+	case tx.Validator.Wght < transformation.MinDelegatorStake:
+		// Ensure delegator is staking at least the minimum amount
+		return errWeightTooSmall
+	}
+
+	for _, out := range tx.StakeOuts {
+		if assetID := out.AssetID(); assetID != transformation.AssetID {
+			return fmt.Errorf(
+				"%w: %s != %s",
+				errWrongStakedAssetID,
+				assetID,
+				transformation.AssetID,
+			)
+		}
+	}
+
+	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
+	copy(outs, tx.Outs)
+	copy(outs[len(tx.Outs):], tx.StakeOuts)
+
+	txID := e.Tx.ID()
+
+	newStaker := state.NewSubnetStaker(txID, &tx.Validator)
+	newStaker.NextTime = newStaker.StartTime
+	newStaker.Priority = state.SubnetPermissionlessDelegatorPendingPriority
+
+	if e.Bootstrapped.GetValue() {
+		currentTimestamp := parentState.GetTimestamp()
+		// Ensure the proposed delegator starts after the current timestamp
+		startTime := tx.StartTime()
+		if !currentTimestamp.Before(startTime) {
+			return fmt.Errorf(
+				"chain timestamp (%s) not before delegator's start time (%s)",
 				currentTimestamp,
-				validatorStartTime,
+				startTime,
 			)
 		}
 
-		primaryNetworkValidator, err := GetValidator(parentState, constants.PrimaryNetworkID, tx.Validator.NodeID)
+		validator, err := GetValidator(parentState, tx.Validator.Subnet, tx.Validator.NodeID)
 		if err != nil {
 			return fmt.Errorf(
-				"failed to fetch the primary network validator for %s: %w",
+				"failed to fetch the subnet validator for %s: %w",
 				tx.Validator.NodeID,
 				err,
 			)
 		}
 
-		maximumWeight, err := math.Mul64(MaxValidatorWeightFactor, primaryNetworkValidator.Weight)
-		if err != nil {
-			return errStakeOverflow
+		// Permissioned subnet validators can't be delegated to.
+		if validator.Priority != state.SubnetPermissionlessValidatorCurrentPriority &&
+			validator.Priority != state.SubnetPermissionlessValidatorPendingPriority {
+			return errNotPermissionlessStaker
 		}
 
-		if !currentTimestamp.Before(e.Config.ApricotPhase3Time) {
-			maximumWeight = math.Min64(maximumWeight, e.Config.MaxValidatorStake)
+		maximumWeight, err := math.Mul64(uint64(transformation.MaxValidatorWeightFactor), validator.Weight)
+		if err != nil {
+			return errStakeOverflow
 		}
+		maximumWeight = math.Min64(maximumWeight, transformation.MaxValidatorStake)
 
-		canDelegate, err := canDelegate(parentState, primaryNetworkValidator, maximumWeight, newStaker)
+		canDelegate, err := canDelegate(parentState, validator, maximumWeight, newStaker)
 		if err != nil {
 			return err
 		}
@@ -427,6 +793,10 @@ func (e *ProposalTxExecutor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 		}
 
 		// Verify the flowcheck
+		fee, err := getTxFee(e.Backend, parentState, e.Tx, e.Config.TxFee)
+		if err != nil {
+			return err
+		}
 		if err := e.FlowChecker.VerifySpend(
 			tx,
 			parentState,
@@ -434,8 +804,9 @@ func (e *ProposalTxExecutor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 			outs,
 			e.Tx.Creds,
 			map[ids.ID]uint64{
-				e.Ctx.AVAXAssetID: e.Config.AddStakerTxFee,
+				e.Ctx.AVAXAssetID: fee,
 			},
+			parentState.GetTimestamp(),
 		); err != nil {
 			return fmt.Errorf("failed verifySpend: %w", err)
 		}
@@ -444,7 +815,7 @@ func (e *ProposalTxExecutor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 		// last to allow the verifier visitor to explicitly check for this
 		// error.
 		maxStartTime := currentTimestamp.Add(MaxFutureStartTime)
-		if validatorStartTime.After(maxStartTime) {
+		if startTime.After(maxStartTime) {
 			return errFutureStakeTime
 		}
 	}
@@ -533,6 +904,7 @@ func (e *ProposalTxExecutor) AdvanceTimeTx(tx *txs.AdvanceTimeTx) error {
 
 	var (
 		currentSupply             = parentState.GetCurrentSupply()
+		subnetSupplies            = make(map[ids.ID]uint64)
 		currentValidatorsToAdd    []*state.Staker
 		pendingValidatorsToRemove []*state.Staker
 		currentDelegatorsToAdd    []*state.Staker
@@ -553,7 +925,8 @@ func (e *ProposalTxExecutor) AdvanceTimeTx(tx *txs.AdvanceTimeTx) error {
 
 		switch stakerToRemove.Priority {
 		case state.PrimaryNetworkDelegatorPendingPriority:
-			potentialReward := e.Rewards.Calculate(
+			rewards := e.Config.GetRewardCalculator(stakerToRemove.StartTime)
+			potentialReward := rewards.Calculate(
 				stakerToRemove.EndTime.Sub(stakerToRemove.StartTime),
 				stakerToRemove.Weight,
 				currentSupply,
@@ -569,7 +942,8 @@ func (e *ProposalTxExecutor) AdvanceTimeTx(tx *txs.AdvanceTimeTx) error {
 			currentDelegatorsToAdd = append(currentDelegatorsToAdd, &stakerToAdd)
 			pendingDelegatorsToRemove = append(pendingDelegatorsToRemove, stakerToRemove)
 		case state.PrimaryNetworkValidatorPendingPriority:
-			potentialReward := e.Rewards.Calculate(
+			rewards := e.Config.GetRewardCalculator(stakerToRemove.StartTime)
+			potentialReward := rewards.Calculate(
 				stakerToRemove.EndTime.Sub(stakerToRemove.StartTime),
 				stakerToRemove.Weight,
 				currentSupply,
@@ -592,6 +966,22 @@ func (e *ProposalTxExecutor) AdvanceTimeTx(tx *txs.AdvanceTimeTx) error {
 
 			currentValidatorsToAdd = append(currentValidatorsToAdd, &stakerToAdd)
 			pendingValidatorsToRemove = append(pendingValidatorsToRemove, stakerToRemove)
+		case state.SubnetPermissionlessValidatorPendingPriority, state.SubnetPermissionlessDelegatorPendingPriority:
+			potentialReward, err := e.calculateSubnetPotentialReward(parentState, stakerToRemove, subnetSupplies)
+			if err != nil {
+				pendingStakerIterator.Release()
+				return err
+			}
+
+			stakerToAdd.PotentialReward = potentialReward
+
+			if stakerToRemove.Priority == state.SubnetPermissionlessValidatorPendingPriority {
+				currentValidatorsToAdd = append(currentValidatorsToAdd, &stakerToAdd)
+				pendingValidatorsToRemove = append(pendingValidatorsToRemove, stakerToRemove)
+			} else {
+				currentDelegatorsToAdd = append(currentDelegatorsToAdd, &stakerToAdd)
+				pendingDelegatorsToRemove = append(pendingDelegatorsToRemove, stakerToRemove)
+			}
 		default:
 			pendingStakerIterator.Release()
 			return fmt.Errorf("expected staker priority got %d", stakerToRemove.Priority)
@@ -611,11 +1001,9 @@ func (e *ProposalTxExecutor) AdvanceTimeTx(tx *txs.AdvanceTimeTx) error {
 			break
 		}
 
-		priority := stakerToRemove.Priority
-		if priority == state.PrimaryNetworkDelegatorCurrentPriority ||
-			priority == state.PrimaryNetworkValidatorCurrentPriority {
-			// Primary network stakers are removed by the RewardValidatorTx, not
-			// an AdvanceTimeTx.
+		if stakerToRemove.Priority.IsRewarded() {
+			// Rewarded stakers are removed by the RewardValidatorTx, not an
+			// AdvanceTimeTx.
 			break
 		}
 
@@ -630,6 +1018,9 @@ func (e *ProposalTxExecutor) AdvanceTimeTx(tx *txs.AdvanceTimeTx) error {
 
 	e.OnCommit.SetTimestamp(txTimestamp)
 	e.OnCommit.SetCurrentSupply(currentSupply)
+	for subnetID, subnetSupply := range subnetSupplies {
+		e.OnCommit.SetSubnetCurrentSupply(subnetID, subnetSupply)
+	}
 
 	for _, currentValidatorToAdd := range currentValidatorsToAdd {
 		e.OnCommit.PutCurrentValidator(currentValidatorToAdd)
@@ -716,16 +1107,29 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 	}
 
 	// If the reward is aborted, then the current supply should be decreased.
-	currentSupply := e.OnAbort.GetCurrentSupply()
-	newSupply, err := math.Sub64(currentSupply, stakerToRemove.PotentialReward)
-	if err != nil {
-		return err
+	if stakerToRemove.SubnetID == constants.PrimaryNetworkID {
+		currentSupply := e.OnAbort.GetCurrentSupply()
+		newSupply, err := math.Sub64(currentSupply, stakerToRemove.PotentialReward)
+		if err != nil {
+			return err
+		}
+		e.OnAbort.SetCurrentSupply(newSupply)
+	} else {
+		currentSupply, err := e.OnAbort.GetSubnetCurrentSupply(stakerToRemove.SubnetID)
+		if err != nil {
+			return err
+		}
+		newSupply, err := math.Sub64(currentSupply, stakerToRemove.PotentialReward)
+		if err != nil {
+			return err
+		}
+		e.OnAbort.SetSubnetCurrentSupply(stakerToRemove.SubnetID, newSupply)
 	}
-	e.OnAbort.SetCurrentSupply(newSupply)
 
 	var (
-		nodeID    ids.NodeID
-		startTime time.Time
+		nodeID            ids.NodeID
+		startTime         time.Time
+		uptimeRequirement float64
 	)
 	switch uStakerTx := stakerTx.Unsigned.(type) {
 	case *txs.AddValidatorTx:
@@ -745,6 +1149,9 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 			e.OnCommit.AddUTXO(utxo)
 			e.OnAbort.AddUTXO(utxo)
 		}
+		if err := e.refundTopUps(parentState, tx.TxID); err != nil {
+			return err
+		}
 
 		// Provide the reward here
 		if stakerToRemove.PotentialReward > 0 {
@@ -773,6 +1180,7 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		// Handle reward preferences
 		nodeID = uStakerTx.Validator.ID()
 		startTime = uStakerTx.StartTime()
+		uptimeRequirement = e.Config.GetStakingConfig(startTime).UptimePercentage
 	case *txs.AddDelegatorTx:
 		e.OnCommit.DeleteCurrentDelegator(stakerToRemove)
 		e.OnAbort.DeleteCurrentDelegator(stakerToRemove)
@@ -811,20 +1219,26 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 			)
 		}
 
-		vdrTx, ok := vdrTxIntf.Unsigned.(*txs.AddValidatorTx)
-		if !ok {
+		// The validator may have been added by either an AddValidatorTx or a
+		// primary network AddPermissionlessValidatorTx.
+		var (
+			vdrShares       uint32
+			vdrRewardsOwner fx.Owner
+		)
+		switch vdrTx := vdrTxIntf.Unsigned.(type) {
+		case *txs.AddValidatorTx:
+			vdrShares = vdrTx.Shares
+			vdrRewardsOwner = vdrTx.RewardsOwner
+		case *txs.AddPermissionlessValidatorTx:
+			vdrShares = vdrTx.DelegationShares
+			vdrRewardsOwner = vdrTx.DelegatorRewardsOwner
+		default:
 			return errWrongTxType
 		}
 
 		// Calculate split of reward between delegator/delegatee
 		// The delegator gives stake to the validatee
-		delegatorShares := reward.PercentDenominator - uint64(vdrTx.Shares)                               // parentTx.Shares <= reward.PercentDenominator so no underflow
-		delegatorReward := delegatorShares * (stakerToRemove.PotentialReward / reward.PercentDenominator) // delegatorShares <= reward.PercentDenominator so no overflow
-		// Delay rounding as long as possible for small numbers
-		if optimisticReward, err := math.Mul64(delegatorShares, stakerToRemove.PotentialReward); err == nil {
-			delegatorReward = optimisticReward / reward.PercentDenominator
-		}
-		delegateeReward := stakerToRemove.PotentialReward - delegatorReward // delegatorReward <= reward so no underflow
+		delegatorReward, delegateeReward := reward.Split(stakerToRemove.PotentialReward, vdrShares)
 
 		offset := 0
 
@@ -855,7 +1269,7 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 
 		// Reward the delegatee here
 		if delegateeReward > 0 {
-			outIntf, err := e.Fx.CreateOutput(delegateeReward, vdrTx.RewardsOwner)
+			outIntf, err := e.Fx.CreateOutput(delegateeReward, vdrRewardsOwner)
 			if err != nil {
 				return fmt.Errorf("failed to create output: %w", err)
 			}
@@ -877,7 +1291,173 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		}
 
 		nodeID = uStakerTx.Validator.ID()
+		startTime = vdrStaker.StartTime
+		uptimeRequirement = e.Config.GetStakingConfig(startTime).UptimePercentage
+	case *txs.AddPermissionlessValidatorTx:
+		e.OnCommit.DeleteCurrentValidator(stakerToRemove)
+		e.OnAbort.DeleteCurrentValidator(stakerToRemove)
+
+		// Primary network validators are rewarded in AVAX while permissionless
+		// subnet validators are rewarded in the subnet's staking asset.
+		rewardAssetID := e.Ctx.AVAXAssetID
+		uptimeRequirement = e.Config.GetStakingConfig(uStakerTx.StartTime()).UptimePercentage
+		if uStakerTx.Subnet != constants.PrimaryNetworkID {
+			transformation, err := GetTransformSubnetTx(parentState, uStakerTx.Subnet)
+			if err != nil {
+				return err
+			}
+			rewardAssetID = transformation.AssetID
+			uptimeRequirement = float64(transformation.UptimeRequirement) / reward.PercentDenominator
+		}
+
+		// Refund the stake here
+		for i, out := range uStakerTx.StakeOuts {
+			utxo := &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID:        tx.TxID,
+					OutputIndex: uint32(len(uStakerTx.Outs) + i),
+				},
+				Asset: out.Asset,
+				Out:   out.Output(),
+			}
+			e.OnCommit.AddUTXO(utxo)
+			e.OnAbort.AddUTXO(utxo)
+		}
+		if err := e.refundTopUps(parentState, tx.TxID); err != nil {
+			return err
+		}
+
+		// Provide the reward here
+		if stakerToRemove.PotentialReward > 0 {
+			outIntf, err := e.Fx.CreateOutput(stakerToRemove.PotentialReward, uStakerTx.ValidatorRewardsOwner)
+			if err != nil {
+				return fmt.Errorf("failed to create output: %w", err)
+			}
+			out, ok := outIntf.(verify.State)
+			if !ok {
+				return errInvalidState
+			}
+
+			utxo := &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID:        tx.TxID,
+					OutputIndex: uint32(len(uStakerTx.Outs) + len(uStakerTx.StakeOuts)),
+				},
+				Asset: avax.Asset{ID: rewardAssetID},
+				Out:   out,
+			}
+
+			e.OnCommit.AddUTXO(utxo)
+			e.OnCommit.AddRewardUTXO(tx.TxID, utxo)
+		}
+
+		// Handle reward preferences
+		nodeID = uStakerTx.Validator.NodeID
+		startTime = uStakerTx.StartTime()
+	case *txs.AddPermissionlessDelegatorTx:
+		e.OnCommit.DeleteCurrentDelegator(stakerToRemove)
+		e.OnAbort.DeleteCurrentDelegator(stakerToRemove)
+
+		transformation, err := GetTransformSubnetTx(parentState, uStakerTx.Validator.Subnet)
+		if err != nil {
+			return err
+		}
+
+		// Refund the stake here
+		for i, out := range uStakerTx.StakeOuts {
+			utxo := &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID:        tx.TxID,
+					OutputIndex: uint32(len(uStakerTx.Outs) + i),
+				},
+				Asset: out.Asset,
+				Out:   out.Output(),
+			}
+			e.OnCommit.AddUTXO(utxo)
+			e.OnAbort.AddUTXO(utxo)
+		}
+
+		// We're removing a delegator, so we need to fetch the validator they
+		// are delegated to.
+		vdrStaker, err := parentState.GetCurrentValidator(uStakerTx.Validator.Subnet, uStakerTx.Validator.NodeID)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to get whether %s is a validator: %w",
+				uStakerTx.Validator.NodeID,
+				err,
+			)
+		}
+
+		vdrTxIntf, _, err := parentState.GetTx(vdrStaker.TxID)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to get whether %s is a validator: %w",
+				uStakerTx.Validator.NodeID,
+				err,
+			)
+		}
+
+		vdrTx, ok := vdrTxIntf.Unsigned.(*txs.AddPermissionlessValidatorTx)
+		if !ok {
+			return errWrongTxType
+		}
+
+		// Calculate split of reward between delegator/delegatee
+		delegatorReward, delegateeReward := reward.Split(stakerToRemove.PotentialReward, vdrTx.DelegationShares)
+
+		offset := 0
+
+		// Reward the delegator here
+		if delegatorReward > 0 {
+			outIntf, err := e.Fx.CreateOutput(delegatorReward, uStakerTx.DelegationRewardsOwner)
+			if err != nil {
+				return fmt.Errorf("failed to create output: %w", err)
+			}
+			out, ok := outIntf.(verify.State)
+			if !ok {
+				return errInvalidState
+			}
+			utxo := &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID:        tx.TxID,
+					OutputIndex: uint32(len(uStakerTx.Outs) + len(uStakerTx.StakeOuts)),
+				},
+				Asset: avax.Asset{ID: transformation.AssetID},
+				Out:   out,
+			}
+
+			e.OnCommit.AddUTXO(utxo)
+			e.OnCommit.AddRewardUTXO(tx.TxID, utxo)
+
+			offset++
+		}
+
+		// Reward the delegatee here
+		if delegateeReward > 0 {
+			outIntf, err := e.Fx.CreateOutput(delegateeReward, vdrTx.DelegatorRewardsOwner)
+			if err != nil {
+				return fmt.Errorf("failed to create output: %w", err)
+			}
+			out, ok := outIntf.(verify.State)
+			if !ok {
+				return errInvalidState
+			}
+			utxo := &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID:        tx.TxID,
+					OutputIndex: uint32(len(uStakerTx.Outs) + len(uStakerTx.StakeOuts) + offset),
+				},
+				Asset: avax.Asset{ID: transformation.AssetID},
+				Out:   out,
+			}
+
+			e.OnCommit.AddUTXO(utxo)
+			e.OnCommit.AddRewardUTXO(tx.TxID, utxo)
+		}
+
+		nodeID = uStakerTx.Validator.NodeID
 		startTime = vdrTx.StartTime()
+		uptimeRequirement = float64(transformation.UptimeRequirement) / reward.PercentDenominator
 	default:
 		return errShouldBeDSValidator
 	}
@@ -887,10 +1467,77 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		return fmt.Errorf("failed to calculate uptime: %w", err)
 	}
 
-	e.PrefersCommit = uptime >= e.Config.UptimePercentage
+	e.Uptime = uptime
+	e.PrefersCommit = uptime >= uptimeRequirement
+	return nil
+}
+
+// refundTopUps returns the stake that was added to the validator added by
+// [validatorTxID] by TopUpValidatorTxs. Like the rest of the stake, it is
+// returned whether or not the validator is rewarded.
+func (e *ProposalTxExecutor) refundTopUps(chainState state.Chain, validatorTxID ids.ID) error {
+	topUps, err := chainState.GetValidatorTopUps(validatorTxID)
+	if err != nil {
+		return fmt.Errorf("failed to get top ups of %s: %w", validatorTxID, err)
+	}
+	for _, topUpTx := range topUps {
+		topUp, ok := topUpTx.Unsigned.(*txs.TopUpValidatorTx)
+		if !ok {
+			return errWrongTxType
+		}
+		topUpTxID := topUpTx.ID()
+		for i, out := range topUp.Stake {
+			utxo := &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID:        topUpTxID,
+					OutputIndex: uint32(len(topUp.Outs) + i),
+				},
+				Asset: out.Asset,
+				Out:   out.Output(),
+			}
+			e.OnCommit.AddUTXO(utxo)
+			e.OnAbort.AddUTXO(utxo)
+		}
+	}
 	return nil
 }
 
+// calculateSubnetPotentialReward returns the reward [staker] would receive
+// for staking on its permissionless subnet. [subnetSupplies] caches the
+// current supply of each subnet and is updated to include the reward.
+func (e *ProposalTxExecutor) calculateSubnetPotentialReward(
+	parentState state.Chain,
+	staker *state.Staker,
+	subnetSupplies map[ids.ID]uint64,
+) (uint64, error) {
+	transformation, err := GetTransformSubnetTx(parentState, staker.SubnetID)
+	if err != nil {
+		return 0, err
+	}
+
+	currentSupply, ok := subnetSupplies[staker.SubnetID]
+	if !ok {
+		currentSupply, err = parentState.GetSubnetCurrentSupply(staker.SubnetID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	primaryNetworkConfig := e.Config.GetStakingConfig(staker.StartTime).RewardConfig
+	rewards := reward.NewCalculator(transformation.RewardConfig(primaryNetworkConfig))
+	potentialReward := rewards.Calculate(
+		staker.EndTime.Sub(staker.StartTime),
+		staker.Weight,
+		currentSupply,
+	)
+	currentSupply, err = math.Add64(currentSupply, potentialReward)
+	if err != nil {
+		return 0, err
+	}
+	subnetSupplies[staker.SubnetID] = currentSupply
+	return potentialReward, nil
+}
+
 // GetNextStakerChangeTime returns the next time a staker will be either added
 // or removed to/from the current validator set.
 func GetNextStakerChangeTime(state state.Chain) (time.Time, error) {
@@ -925,6 +1572,128 @@ func GetNextStakerChangeTime(state state.Chain) (time.Time, error) {
 	}
 }
 
+// validatorRules are the staking parameters a validator of a subnet must
+// respect.
+type validatorRules struct {
+	assetID           ids.ID
+	minValidatorStake uint64
+	maxValidatorStake uint64
+	minStakeDuration  time.Duration
+	maxStakeDuration  time.Duration
+	minDelegationFee  uint32
+	fee               uint64
+	pendingPriority   state.Priority
+}
+
+// getValidatorRules returns the staking parameters of a validator of
+// [subnetID] starting at [startTime]. The primary network is configured by the
+// node while permissionless subnets are configured by their TransformSubnetTx.
+func getValidatorRules(
+	backend *Backend,
+	chainState state.Chain,
+	subnetID ids.ID,
+	startTime time.Time,
+) (*validatorRules, error) {
+	if subnetID == constants.PrimaryNetworkID {
+		stakingConfig := backend.Config.GetStakingConfig(startTime)
+		return &validatorRules{
+			assetID:           backend.Ctx.AVAXAssetID,
+			minValidatorStake: stakingConfig.MinValidatorStake,
+			maxValidatorStake: stakingConfig.MaxValidatorStake,
+			minStakeDuration:  stakingConfig.MinStakeDuration,
+			maxStakeDuration:  stakingConfig.MaxStakeDuration,
+			minDelegationFee:  stakingConfig.MinDelegationFee,
+			fee:               backend.Config.AddStakerTxFee,
+			pendingPriority:   state.PrimaryNetworkValidatorPendingPriority,
+		}, nil
+	}
+
+	transformation, err := GetTransformSubnetTx(chainState, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	return &validatorRules{
+		assetID:           transformation.AssetID,
+		minValidatorStake: transformation.MinValidatorStake,
+		maxValidatorStake: transformation.MaxValidatorStake,
+		minStakeDuration:  time.Duration(transformation.MinStakeDuration) * time.Second,
+		maxStakeDuration:  time.Duration(transformation.MaxStakeDuration) * time.Second,
+		minDelegationFee:  transformation.MinDelegationFee,
+		fee:               backend.Config.TxFee,
+		pendingPriority:   state.SubnetPermissionlessValidatorPendingPriority,
+	}, nil
+}
+
+// verifyBaobabActivated returns an error if the Baobab network upgrade isn't
+// activated as of the timestamp of [chainState]
+func verifyBaobabActivated(backend *Backend, chainState state.Chain) error {
+	if !backend.Config.IsBaobabActivated(chainState.GetTimestamp()) {
+		return errIssuedBeforeBaobab
+	}
+	return nil
+}
+
+// verifyOwnerActivated returns an error if [owner] is of a type that is only
+// supported once the Baobab network upgrade is activated, and it isn't
+// activated as of the timestamp of [chainState]
+func verifyOwnerActivated(backend *Backend, chainState state.Chain, owner fx.Owner) error {
+	if _, ok := owner.(*secp256k1fx.WeightedOutputOwners); !ok {
+		return nil
+	}
+	return verifyBaobabActivated(backend, chainState)
+}
+
+// verifyPermissionedSubnet returns an error if [subnetID] was transformed into
+// a permissionless subnet
+func verifyPermissionedSubnet(chainState state.Chain, subnetID ids.ID) error {
+	_, err := chainState.GetSubnetTransformation(subnetID)
+	if err == nil {
+		return fmt.Errorf("%w: %s", errIsPermissionlessSubnet, subnetID)
+	}
+	if err != database.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+// getTxFee returns the amount of AVAX that [tx] must burn when it is executed
+// on top of [chainState]. [fixedFee] is the fee charged for the tx's type. Once
+// dynamic fees are activated, a fee based on the complexity of [tx] and the
+// current base fee must be burned in addition to [fixedFee].
+func getTxFee(backend *Backend, chainState state.Chain, tx *txs.Tx, fixedFee uint64) (uint64, error) {
+	if !backend.Config.IsDynamicFeesActivated(chainState.GetTimestamp()) {
+		return fixedFee, nil
+	}
+
+	complexity, err := fees.TxComplexity(tx)
+	if err != nil {
+		return 0, err
+	}
+	dynamicFee, err := backend.Config.DynamicFees.Fee(complexity, chainState.GetBaseFee())
+	if err != nil {
+		return 0, err
+	}
+	return math.Add64(fixedFee, dynamicFee)
+}
+
+// GetTransformSubnetTx returns the transformation of [subnetID]. An error is
+// returned if the subnet hasn't been transformed into a permissionless subnet.
+func GetTransformSubnetTx(chainState state.Chain, subnetID ids.ID) (*txs.TransformSubnetTx, error) {
+	transformSubnetTxIntf, err := chainState.GetSubnetTransformation(subnetID)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%w: %s", errNotPermissionlessSubnet, subnetID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	transformSubnetTx, ok := transformSubnetTxIntf.Unsigned.(*txs.TransformSubnetTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+	return transformSubnetTx, nil
+}
+
 // GetValidator returns information about the given validator, which may be a
 // current validator or pending validator.
 func GetValidator(state state.Chain, subnetID ids.ID, nodeID ids.NodeID) (*state.Staker, error) {
@@ -14,6 +14,7 @@ import (
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/crypto"
 	"github.com/kukrer/savannahnode/utils/hashing"
+	"github.com/kukrer/savannahnode/vms/platformvm/config"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/status"
@@ -953,6 +954,66 @@ func TestAddValidatorTxExecute(t *testing.T) {
 	}
 }
 
+func TestAddValidatorTxStakingUpgrade(t *testing.T) {
+	startTime := defaultGenesisTime.Add(1 * time.Second)
+
+	tests := []struct {
+		name        string
+		upgradeTime time.Time
+		expectedErr error
+	}{
+		{
+			name:        "starts before upgrade",
+			upgradeTime: startTime.Add(time.Second),
+			expectedErr: nil,
+		},
+		{
+			name:        "starts at upgrade",
+			upgradeTime: startTime,
+			expectedErr: errStakeTooShort,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			env := newEnvironment()
+			env.ctx.Lock.Lock()
+			defer func() {
+				require.NoError(shutdownEnvironment(env))
+			}()
+
+			upgradedConfig := env.config.GetStakingConfig(defaultGenesisTime)
+			upgradedConfig.MinStakeDuration = defaultMinStakingDuration + time.Hour
+			env.config.StakingUpgrades = []config.StakingUpgrade{{
+				Time:          test.upgradeTime,
+				StakingConfig: upgradedConfig,
+			}}
+
+			nodeID := ids.GenerateTestNodeID()
+			tx, err := env.txBuilder.NewAddValidatorTx(
+				env.config.MinValidatorStake,
+				uint64(startTime.Unix()),
+				uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+				nodeID,
+				ids.ShortID(nodeID),
+				reward.PercentDenominator,
+				[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+				ids.ShortEmpty, // change addr
+			)
+			require.NoError(err)
+
+			executor := ProposalTxExecutor{
+				Backend:       &env.backend,
+				ParentID:      lastAcceptedID,
+				StateVersions: env,
+				Tx:            tx,
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
 func TestBlueberryAddValidatorTxEmptyNodeID(t *testing.T) {
 	env := newEnvironment()
 	env.ctx.Lock.Lock()
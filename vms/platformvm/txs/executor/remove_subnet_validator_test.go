@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
+	"github.com/kukrer/savannahnode/vms/platformvm/status"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+)
+
+func TestRemoveSubnetValidatorTxExecute(t *testing.T) {
+	tests := []struct {
+		name    string
+		current bool
+	}{
+		{
+			name:    "remove current validator",
+			current: true,
+		},
+		{
+			name:    "remove pending validator",
+			current: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			env := newEnvironment()
+			env.ctx.Lock.Lock()
+			defer func() {
+				require.NoError(shutdownEnvironment(env))
+			}()
+			env.config.WhitelistedSubnets.Add(testSubnet1.ID())
+
+			nodeID := ids.NodeID(preFundedKeys[0].PublicKey().Address())
+			startTime := defaultValidateStartTime
+			if !test.current {
+				startTime = startTime.Add(defaultMinStakingDuration)
+			}
+			endTime := startTime.Add(defaultMinStakingDuration)
+
+			addTx, err := env.txBuilder.NewAddSubnetValidatorTx(
+				1,                        // Weight
+				uint64(startTime.Unix()), // Start time
+				uint64(endTime.Unix()),   // End time
+				nodeID,                   // Node ID
+				testSubnet1.ID(),         // Subnet ID
+				[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0], preFundedKeys[1]},
+				ids.ShortEmpty,
+			)
+			require.NoError(err)
+
+			staker := state.NewSubnetStaker(addTx.ID(), &addTx.Unsigned.(*txs.AddSubnetValidatorTx).Validator)
+			if test.current {
+				staker.NextTime = staker.EndTime
+				staker.Priority = state.SubnetValidatorCurrentPriority
+				env.state.PutCurrentValidator(staker)
+			} else {
+				staker.NextTime = staker.StartTime
+				staker.Priority = state.SubnetValidatorPendingPriority
+				env.state.PutPendingValidator(staker)
+			}
+			env.state.AddTx(addTx, status.Committed)
+			env.state.SetHeight(1)
+			require.NoError(env.state.Commit())
+
+			tx, err := env.txBuilder.NewRemoveSubnetValidatorTx(
+				nodeID,
+				testSubnet1.ID(),
+				[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0], preFundedKeys[1]},
+				ids.ShortEmpty,
+			)
+			require.NoError(err)
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			executor := StandardTxExecutor{
+				Backend: &env.backend,
+				State:   stateDiff,
+				Tx:      tx,
+			}
+			require.NoError(tx.Unsigned.Visit(&executor))
+
+			_, err = stateDiff.GetCurrentValidator(testSubnet1.ID(), nodeID)
+			require.ErrorIs(err, database.ErrNotFound)
+			_, err = stateDiff.GetPendingValidator(testSubnet1.ID(), nodeID)
+			require.ErrorIs(err, database.ErrNotFound)
+		})
+	}
+}
+
+func TestRemoveSubnetValidatorTxNotValidator(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	tx, err := env.txBuilder.NewRemoveSubnetValidatorTx(
+		ids.GenerateTestNodeID(),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0], preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errNotValidator)
+}
+
+func TestRemoveSubnetValidatorTxPrimaryNetwork(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	// The primary network can't be used as the subnet of a
+	// RemoveSubnetValidatorTx, so the tx must be rejected syntactically.
+	_, err := env.txBuilder.NewRemoveSubnetValidatorTx(
+		ids.NodeID(preFundedKeys[0].PublicKey().Address()),
+		constants.PrimaryNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		ids.ShortEmpty,
+	)
+	require.Error(err)
+}
+
+func TestRemoveSubnetValidatorTxPermissionlessStaker(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	nodeID := ids.GenerateTestNodeID()
+	env.state.PutCurrentValidator(&state.Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    nodeID,
+		SubnetID:  testSubnet1.ID(),
+		Weight:    1,
+		StartTime: defaultValidateStartTime,
+		EndTime:   defaultValidateEndTime,
+		NextTime:  defaultValidateEndTime,
+		Priority:  state.SubnetPermissionlessValidatorCurrentPriority,
+	})
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	tx, err := env.txBuilder.NewRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0], preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errRemovePermissionlessStaker)
+}
+
+func TestRemoveSubnetValidatorTxBeforeBaobab(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	env.config.BaobabTime = defaultGenesisTime.Add(time.Hour)
+
+	tx, err := env.txBuilder.NewRemoveSubnetValidatorTx(
+		ids.GenerateTestNodeID(),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0], preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errIssuedBeforeBaobab)
+
+	verifier := MempoolTxVerifier{
+		Backend:       &env.backend,
+		ParentID:      lastAcceptedID,
+		StateVersions: env,
+		Tx:            tx,
+	}
+	err = tx.Unsigned.Visit(&verifier)
+	require.ErrorIs(err, errIssuedBeforeBaobab)
+}
@@ -6,12 +6,17 @@ package executor
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kukrer/savannahnode/chains/atomic"
 	"github.com/kukrer/savannahnode/database"
 	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/math"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
+	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/utxo"
@@ -21,6 +26,15 @@ var (
 	_ txs.Visitor = &StandardTxExecutor{}
 
 	errCustomAssetBeforeBlueberry = errors.New("custom assets can only be imported after the blueberry upgrade")
+	errNotValidator               = errors.New("isn't a current or pending validator")
+	errRemovePrimaryNetworkStaker = errors.New("can't remove a primary network staker")
+	errRemovePermissionlessStaker = errors.New("can't remove a permissionless subnet staker")
+	errMaxStakeDurationTooLarge   = errors.New("max stake duration must be less than or equal to the global max stake duration")
+	errSubnetAlreadyTransformed   = errors.New("subnet was already transformed")
+	errNotCurrentValidator        = errors.New("isn't a current validator")
+	errStakingPeriodEnded         = errors.New("validator's staking period has ended")
+	errEndTimeDecreased           = errors.New("validator's end time can't be decreased")
+	errNoStakeIncrease            = errors.New("validator's stake or staking period must be increased")
 )
 
 type StandardTxExecutor struct {
@@ -42,6 +56,13 @@ func (*StandardTxExecutor) AddSubnetValidatorTx(*txs.AddSubnetValidatorTx) error
 func (*StandardTxExecutor) AddDelegatorTx(*txs.AddDelegatorTx) error       { return errWrongTxType }
 func (*StandardTxExecutor) AdvanceTimeTx(*txs.AdvanceTimeTx) error         { return errWrongTxType }
 func (*StandardTxExecutor) RewardValidatorTx(*txs.RewardValidatorTx) error { return errWrongTxType }
+func (*StandardTxExecutor) AddPermissionlessValidatorTx(*txs.AddPermissionlessValidatorTx) error {
+	return errWrongTxType
+}
+
+func (*StandardTxExecutor) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDelegatorTx) error {
+	return errWrongTxType
+}
 
 func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
@@ -62,6 +83,10 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 	// Verify the flowcheck
 	timestamp := e.State.GetTimestamp()
 	createBlockchainTxFee := e.Config.GetCreateBlockchainTxFee(timestamp)
+	fee, err := getTxFee(e.Backend, e.State, e.Tx, createBlockchainTxFee)
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -69,27 +94,20 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			e.Ctx.AVAXAssetID: createBlockchainTxFee,
+			e.Ctx.AVAXAssetID: fee,
 		},
+		e.State.GetTimestamp(),
 	); err != nil {
 		return err
 	}
 
-	subnetIntf, _, err := e.State.GetTx(tx.SubnetID)
-	if err == database.ErrNotFound {
-		return fmt.Errorf("%s isn't a known subnet", tx.SubnetID)
-	}
+	subnetOwner, err := e.State.GetSubnetOwner(tx.SubnetID)
 	if err != nil {
 		return err
 	}
 
-	subnet, ok := subnetIntf.Unsigned.(*txs.CreateSubnetTx)
-	if !ok {
-		return fmt.Errorf("%s isn't a subnet", tx.SubnetID)
-	}
-
 	// Verify that this chain is authorized by the subnet
-	if err := e.Fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnet.Owner); err != nil {
+	if err := e.Fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
 		return err
 	}
 
@@ -113,10 +131,17 @@ func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
 		return err
 	}
+	if err := verifyOwnerActivated(e.Backend, e.State, tx.Owner); err != nil {
+		return err
+	}
 
 	// Verify the flowcheck
 	timestamp := e.State.GetTimestamp()
 	createSubnetTxFee := e.Config.GetCreateSubnetTxFee(timestamp)
+	fee, err := getTxFee(e.Backend, e.State, e.Tx, createSubnetTxFee)
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -124,8 +149,9 @@ func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 		tx.Outs,
 		e.Tx.Creds,
 		map[ids.ID]uint64{
-			e.Ctx.AVAXAssetID: createSubnetTxFee,
+			e.Ctx.AVAXAssetID: fee,
 		},
+		e.State.GetTimestamp(),
 	); err != nil {
 		return err
 	}
@@ -199,6 +225,10 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 		copy(ins, tx.Ins)
 		copy(ins[len(tx.Ins):], tx.ImportedInputs)
 
+		fee, err := getTxFee(e.Backend, e.State, e.Tx, e.Config.TxFee)
+		if err != nil {
+			return err
+		}
 		if err := e.FlowChecker.VerifySpendUTXOs(
 			tx,
 			utxos,
@@ -206,8 +236,9 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 			tx.Outs,
 			e.Tx.Creds,
 			map[ids.ID]uint64{
-				e.Ctx.AVAXAssetID: e.Config.TxFee,
+				e.Ctx.AVAXAssetID: fee,
 			},
+			e.State.GetTimestamp(),
 		); err != nil {
 			return err
 		}
@@ -244,6 +275,10 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 	}
 
 	// Verify the flowcheck
+	fee, err := getTxFee(e.Backend, e.State, e.Tx, e.Config.TxFee)
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -251,8 +286,9 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 		outs,
 		e.Tx.Creds,
 		map[ids.ID]uint64{
-			e.Ctx.AVAXAssetID: e.Config.TxFee,
+			e.Ctx.AVAXAssetID: fee,
 		},
+		e.State.GetTimestamp(),
 	); err != nil {
 		return fmt.Errorf("failed verifySpend: %w", err)
 	}
@@ -297,3 +333,418 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 	}
 	return nil
 }
+
+func (e *StandardTxExecutor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	if err := verifyBaobabActivated(e.Backend, e.State); err != nil {
+		return err
+	}
+
+	isCurrentValidator := true
+	vdr, err := e.State.GetCurrentValidator(tx.Subnet, tx.NodeID)
+	if err == database.ErrNotFound {
+		vdr, err = e.State.GetPendingValidator(tx.Subnet, tx.NodeID)
+		isCurrentValidator = false
+	}
+	if err != nil {
+		// It isn't a current or pending validator.
+		return fmt.Errorf(
+			"%s %w of %s: %v",
+			tx.NodeID,
+			errNotValidator,
+			tx.Subnet,
+			err,
+		)
+	}
+
+	// The current and pending priorities overlap, so the priority of [vdr]
+	// must be compared with the priorities of the set it was read from.
+	permissionedPriority := state.SubnetValidatorPendingPriority
+	permissionlessPriority := state.SubnetPermissionlessValidatorPendingPriority
+	if isCurrentValidator {
+		permissionedPriority = state.SubnetValidatorCurrentPriority
+		permissionlessPriority = state.SubnetPermissionlessValidatorCurrentPriority
+	}
+	switch vdr.Priority {
+	case permissionedPriority:
+	case permissionlessPriority:
+		return errRemovePermissionlessStaker
+	default:
+		return errRemovePrimaryNetworkStaker
+	}
+
+	// The validators of a permissionless subnet can't be removed by its owner
+	if err := verifyPermissionedSubnet(e.State, tx.Subnet); err != nil {
+		return err
+	}
+
+	if e.Bootstrapped.GetValue() {
+		// Make sure this transaction has at least one credential for the subnet
+		// authorization.
+		if len(e.Tx.Creds) == 0 {
+			return errWrongNumberOfCredentials
+		}
+
+		// Select the credentials for each purpose
+		baseTxCredsLen := len(e.Tx.Creds) - 1
+		baseTxCreds := e.Tx.Creds[:baseTxCredsLen]
+		subnetCred := e.Tx.Creds[baseTxCredsLen]
+
+		subnetOwner, err := e.State.GetSubnetOwner(tx.Subnet)
+		if err != nil {
+			return err
+		}
+
+		// Verify that this validator removal is authorized by the subnet
+		if err := e.Fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
+			return err
+		}
+
+		// Verify the flowcheck
+		fee, err := getTxFee(e.Backend, e.State, e.Tx, e.Config.TxFee)
+		if err != nil {
+			return err
+		}
+		if err := e.FlowChecker.VerifySpend(
+			tx,
+			e.State,
+			tx.Ins,
+			tx.Outs,
+			baseTxCreds,
+			map[ids.ID]uint64{
+				e.Ctx.AVAXAssetID: fee,
+			},
+			e.State.GetTimestamp(),
+		); err != nil {
+			return err
+		}
+	}
+
+	if isCurrentValidator {
+		e.State.DeleteCurrentValidator(vdr)
+	} else {
+		e.State.DeletePendingValidator(vdr)
+	}
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+func (e *StandardTxExecutor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	if err := verifyBaobabActivated(e.Backend, e.State); err != nil {
+		return err
+	}
+
+	// Note: math.MaxUint32 * time.Second < math.MaxInt64 so this can never
+	// overflow.
+	maxStakeDuration := e.Config.GetStakingConfig(e.State.GetTimestamp()).MaxStakeDuration
+	if time.Duration(tx.MaxStakeDuration)*time.Second > maxStakeDuration {
+		return errMaxStakeDurationTooLarge
+	}
+
+	// Make sure this transaction has at least one credential for the subnet
+	// authorization.
+	if len(e.Tx.Creds) == 0 {
+		return errWrongNumberOfCredentials
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(e.Tx.Creds) - 1
+	baseTxCreds := e.Tx.Creds[:baseTxCredsLen]
+	subnetCred := e.Tx.Creds[baseTxCredsLen]
+
+	subnetOwner, err := e.State.GetSubnetOwner(tx.Subnet)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.State.GetSubnetTransformation(tx.Subnet)
+	if err == nil {
+		return fmt.Errorf("%w: %s", errSubnetAlreadyTransformed, tx.Subnet)
+	}
+	if err != database.ErrNotFound {
+		return err
+	}
+
+	// Verify that this transformation is authorized by the subnet
+	if err := e.Fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
+		return err
+	}
+
+	// The tokens that will be minted as staking rewards must be burned up
+	// front. Transforming a subnet costs as much as creating one.
+	timestamp := e.State.GetTimestamp()
+	transformSubnetTxFee := e.Config.GetCreateSubnetTxFee(timestamp)
+	fee, err := getTxFee(e.Backend, e.State, e.Tx, transformSubnetTxFee)
+	if err != nil {
+		return err
+	}
+	totalRewardAmount := tx.MaximumSupply - tx.InitialSupply
+	if err := e.FlowChecker.VerifySpend(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			e.Ctx.AVAXAssetID: fee,
+			tx.AssetID:        totalRewardAmount,
+		},
+		e.State.GetTimestamp(),
+	); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.State, txID, tx.Outs)
+	// Transform the new subnet in the database
+	e.State.AddSubnetTransformation(e.Tx)
+	e.State.SetSubnetCurrentSupply(tx.Subnet, tx.InitialSupply)
+	return nil
+}
+
+func (e *StandardTxExecutor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+	if err := verifyBaobabActivated(e.Backend, e.State); err != nil {
+		return err
+	}
+
+	// Make sure this transaction has at least one credential for the subnet
+	// authorization.
+	if len(e.Tx.Creds) == 0 {
+		return errWrongNumberOfCredentials
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(e.Tx.Creds) - 1
+	baseTxCreds := e.Tx.Creds[:baseTxCredsLen]
+	subnetCred := e.Tx.Creds[baseTxCredsLen]
+
+	subnetOwner, err := e.State.GetSubnetOwner(tx.Subnet)
+	if err != nil {
+		return err
+	}
+
+	// Verify that this transfer is authorized by the current subnet owner
+	if err := e.Fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	fee, err := getTxFee(e.Backend, e.State, e.Tx, e.Config.TxFee)
+	if err != nil {
+		return err
+	}
+	if err := e.FlowChecker.VerifySpend(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			e.Ctx.AVAXAssetID: fee,
+		},
+		e.State.GetTimestamp(),
+	); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.State, txID, tx.Outs)
+	// Record the new subnet owner
+	e.State.AddSubnetOwnershipTransfer(e.Tx)
+	e.State.SetSubnetOwner(tx.Subnet, tx.Owner)
+	return nil
+}
+
+func (e *StandardTxExecutor) TopUpValidatorTx(tx *txs.TopUpValidatorTx) error {
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+	if err := verifyBaobabActivated(e.Backend, e.State); err != nil {
+		return err
+	}
+
+	vdr, err := e.State.GetCurrentValidator(constants.PrimaryNetworkID, tx.NodeID)
+	if err != nil {
+		return fmt.Errorf(
+			"%s %w of %s: %v",
+			tx.NodeID,
+			errNotCurrentValidator,
+			constants.PrimaryNetworkID,
+			err,
+		)
+	}
+
+	vdrTx, _, err := e.State.GetTx(vdr.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get validator tx %s: %w", vdr.TxID, err)
+	}
+
+	// The validator may have been added by either an AddValidatorTx or a
+	// primary network AddPermissionlessValidatorTx.
+	var rewardsOwner fx.Owner
+	switch uVdrTx := vdrTx.Unsigned.(type) {
+	case *txs.AddValidatorTx:
+		rewardsOwner = uVdrTx.RewardsOwner
+	case *txs.AddPermissionlessValidatorTx:
+		rewardsOwner = uVdrTx.ValidatorRewardsOwner
+	default:
+		return errWrongTxType
+	}
+
+	// The validator remains subject to the staking parameters in effect at its
+	// start time.
+	stakingConfig := e.Config.GetStakingConfig(vdr.StartTime)
+	currentTimestamp := e.State.GetTimestamp()
+	newEndTime := tx.EndTime()
+	switch {
+	case !currentTimestamp.Before(vdr.EndTime):
+		// The validator is waiting to be rewarded
+		return errStakingPeriodEnded
+
+	case newEndTime.Before(vdr.EndTime):
+		return errEndTimeDecreased
+
+	case tx.Wght == 0 && newEndTime.Equal(vdr.EndTime):
+		return errNoStakeIncrease
+
+	case newEndTime.Sub(vdr.StartTime) > stakingConfig.MaxStakeDuration:
+		// Ensure staking length is not too long
+		return errStakeTooLong
+	}
+
+	newWeight, err := math.Add64(vdr.Weight, tx.Wght)
+	if err != nil {
+		return errStakeOverflow
+	}
+	if newWeight > stakingConfig.MaxValidatorStake {
+		// Ensure validator isn't staking too much
+		return errWeightTooLarge
+	}
+
+	newVdr := *vdr
+	newVdr.Weight = newWeight
+	newVdr.EndTime = newEndTime
+	newVdr.NextTime = newEndTime
+
+	// Ensure the validator isn't staking too much once the weight of its
+	// delegators is included. Delegators can't stake past the current end time
+	// of the validator.
+	maxWeight, err := GetMaxWeight(e.State, &newVdr, currentTimestamp, vdr.EndTime)
+	if err != nil {
+		return err
+	}
+	if maxWeight > stakingConfig.MaxValidatorStake {
+		return errWeightTooLarge
+	}
+
+	// Make sure this transaction has at least one credential for the
+	// validator authorization.
+	if len(e.Tx.Creds) == 0 {
+		return errWrongNumberOfCredentials
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(e.Tx.Creds) - 1
+	baseTxCreds := e.Tx.Creds[:baseTxCredsLen]
+	validatorCred := e.Tx.Creds[baseTxCredsLen]
+
+	// Verify that this top up is authorized by the rewards owner of the
+	// validator
+	if err := e.Fx.VerifyPermission(tx, tx.ValidatorAuth, validatorCred, rewardsOwner); err != nil {
+		return err
+	}
+
+	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.Stake))
+	copy(outs, tx.Outs)
+	copy(outs[len(tx.Outs):], tx.Stake)
+
+	// Verify the flowcheck
+	fee, err := getTxFee(e.Backend, e.State, e.Tx, e.Config.AddStakerTxFee)
+	if err != nil {
+		return err
+	}
+	if err := e.FlowChecker.VerifySpend(
+		tx,
+		e.State,
+		tx.Ins,
+		outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			e.Ctx.AVAXAssetID: fee,
+		},
+		e.State.GetTimestamp(),
+	); err != nil {
+		return err
+	}
+
+	// The added stake is rewarded for the rest of the staking period and the
+	// previous stake is rewarded for the extension of the staking period.
+	rewards := reward.NewCalculator(stakingConfig.RewardConfig)
+	currentSupply := e.State.GetCurrentSupply()
+	addedReward := uint64(0)
+	if tx.Wght > 0 {
+		addedReward = rewards.Calculate(
+			newEndTime.Sub(currentTimestamp),
+			tx.Wght,
+			currentSupply,
+		)
+		currentSupply, err = math.Add64(currentSupply, addedReward)
+		if err != nil {
+			return err
+		}
+	}
+	if extension := newEndTime.Sub(vdr.EndTime); extension > 0 {
+		extensionReward := rewards.Calculate(
+			extension,
+			vdr.Weight,
+			currentSupply,
+		)
+		currentSupply, err = math.Add64(currentSupply, extensionReward)
+		if err != nil {
+			return err
+		}
+		addedReward += extensionReward
+	}
+	newVdr.PotentialReward, err = math.Add64(vdr.PotentialReward, addedReward)
+	if err != nil {
+		return err
+	}
+
+	if err := e.State.UpdateCurrentValidator(&newVdr); err != nil {
+		return err
+	}
+	e.State.AddValidatorTopUp(vdr.TxID, e.Tx)
+	e.State.SetCurrentSupply(currentSupply)
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.State, txID, tx.Outs)
+	return nil
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/database"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
+	"github.com/kukrer/savannahnode/vms/platformvm/status"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestTopUpValidatorTxExecute(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	// Genesis validators are rewarded to the address of their staking key
+	vdrKey := preFundedKeys[0]
+	nodeID := ids.NodeID(vdrKey.PublicKey().Address())
+
+	oldVdr, err := env.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+
+	newEndTime := oldVdr.EndTime.Add(defaultMinStakingDuration)
+	tx, err := env.txBuilder.NewTopUpValidatorTx(
+		nodeID,
+		uint64(newEndTime.Unix()),
+		env.config.MinValidatorStake,
+		[]*crypto.PrivateKeySECP256K1R{vdrKey},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&executor))
+
+	newVdr, err := stateDiff.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.Equal(oldVdr.TxID, newVdr.TxID)
+	require.Equal(oldVdr.Weight+env.config.MinValidatorStake, newVdr.Weight)
+	require.Equal(newEndTime, newVdr.EndTime)
+	require.Equal(newEndTime, newVdr.NextTime)
+	require.Greater(newVdr.PotentialReward, oldVdr.PotentialReward)
+	require.Greater(stateDiff.GetCurrentSupply(), env.state.GetCurrentSupply())
+
+	stateDiff.AddTx(tx, status.Committed)
+	stateDiff.Apply(env.state)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	committedVdr, err := env.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.Equal(newVdr.Weight, committedVdr.Weight)
+	require.Equal(newVdr.EndTime, committedVdr.EndTime)
+	require.Equal(newVdr.PotentialReward, committedVdr.PotentialReward)
+
+	topUps, err := env.state.GetValidatorTopUps(oldVdr.TxID)
+	require.NoError(err)
+	require.Len(topUps, 1)
+	require.Equal(tx.ID(), topUps[0].ID())
+}
+
+func TestTopUpValidatorTxInvalid(t *testing.T) {
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(t, shutdownEnvironment(env))
+	}()
+
+	vdrKey := preFundedKeys[0]
+	nodeID := ids.NodeID(vdrKey.PublicKey().Address())
+	vdrEndTime := uint64(defaultValidateEndTime.Unix())
+
+	tests := []struct {
+		name        string
+		nodeID      ids.NodeID
+		endTime     uint64
+		stakeAmount uint64
+		keys        []*crypto.PrivateKeySECP256K1R
+		expectedErr error
+	}{
+		{
+			name:        "end time decreased",
+			nodeID:      nodeID,
+			endTime:     vdrEndTime - 1,
+			stakeAmount: env.config.MinValidatorStake,
+			keys:        []*crypto.PrivateKeySECP256K1R{vdrKey},
+			expectedErr: errEndTimeDecreased,
+		},
+		{
+			name:        "no increase",
+			nodeID:      nodeID,
+			endTime:     vdrEndTime,
+			stakeAmount: 0,
+			keys:        []*crypto.PrivateKeySECP256K1R{vdrKey},
+			expectedErr: errNoStakeIncrease,
+		},
+		{
+			name:        "staking period too long",
+			nodeID:      nodeID,
+			endTime:     uint64(defaultValidateStartTime.Add(defaultMaxStakingDuration).Unix()) + 1,
+			stakeAmount: 0,
+			keys:        []*crypto.PrivateKeySECP256K1R{vdrKey},
+			expectedErr: errStakeTooLong,
+		},
+		{
+			name:        "stake too large",
+			nodeID:      nodeID,
+			endTime:     vdrEndTime,
+			stakeAmount: env.config.MaxValidatorStake,
+			keys:        []*crypto.PrivateKeySECP256K1R{vdrKey},
+			expectedErr: errWeightTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tx, err := env.txBuilder.NewTopUpValidatorTx(
+				test.nodeID,
+				test.endTime,
+				test.stakeAmount,
+				test.keys,
+				ids.ShortEmpty,
+			)
+			require.NoError(err)
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			executor := StandardTxExecutor{
+				Backend: &env.backend,
+				State:   stateDiff,
+				Tx:      tx,
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestTopUpValidatorTxUnauthorized(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	vdrKey := preFundedKeys[0]
+	nodeID := ids.NodeID(vdrKey.PublicKey().Address())
+
+	// The builder refuses to authorize the top up without the rewards owner
+	_, err := env.txBuilder.NewTopUpValidatorTx(
+		nodeID,
+		uint64(defaultValidateEndTime.Add(defaultMinStakingDuration).Unix()),
+		0,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.Error(err)
+
+	tx, err := env.txBuilder.NewTopUpValidatorTx(
+		nodeID,
+		uint64(defaultValidateEndTime.Add(defaultMinStakingDuration).Unix()),
+		0,
+		[]*crypto.PrivateKeySECP256K1R{vdrKey},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	// Drop the validator authorization signature
+	vdrCred := tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential)
+	vdrCred.Sigs = nil
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	require.Error(tx.Unsigned.Visit(&executor))
+}
+
+func TestTopUpValidatorTxNotValidator(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	// The builder can't find the rewards owner of a node that isn't
+	// validating
+	_, err := env.txBuilder.NewTopUpValidatorTx(
+		ids.GenerateTestNodeID(),
+		uint64(defaultValidateEndTime.Unix()),
+		env.config.MinValidatorStake,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		ids.ShortEmpty,
+	)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestTopUpValidatorTxBeforeBaobab(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	env.config.BaobabTime = defaultGenesisTime.Add(time.Hour)
+
+	vdrKey := preFundedKeys[0]
+	nodeID := ids.NodeID(vdrKey.PublicKey().Address())
+
+	vdr, err := env.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+
+	tx, err := env.txBuilder.NewTopUpValidatorTx(
+		nodeID,
+		uint64(vdr.EndTime.Add(defaultMinStakingDuration).Unix()),
+		env.config.MinValidatorStake,
+		[]*crypto.PrivateKeySECP256K1R{vdrKey},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errIssuedBeforeBaobab)
+
+	verifier := MempoolTxVerifier{
+		Backend:       &env.backend,
+		ParentID:      lastAcceptedID,
+		StateVersions: env,
+		Tx:            tx,
+	}
+	err = tx.Unsigned.Visit(&verifier)
+	require.ErrorIs(err, errIssuedBeforeBaobab)
+}
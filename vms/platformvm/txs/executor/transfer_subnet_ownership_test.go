@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
+	"github.com/kukrer/savannahnode/vms/platformvm/status"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestTransferSubnetOwnershipTxExecute(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	newOwnerKey := preFundedKeys[4]
+	newOwnerAddr := newOwnerKey.PublicKey().Address()
+
+	tx, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{newOwnerAddr},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&executor))
+
+	owner, err := stateDiff.GetSubnetOwner(testSubnet1.ID())
+	require.NoError(err)
+	require.Equal(&secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{newOwnerAddr},
+	}, owner)
+
+	stateDiff.AddTx(tx, status.Committed)
+	stateDiff.Apply(env.state)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	transfers, err := env.state.GetSubnetOwnershipTransfers(testSubnet1.ID())
+	require.NoError(err)
+	require.Len(transfers, 1)
+	require.Equal(tx.ID(), transfers[0].ID())
+
+	// The previous owner can no longer manage the subnet
+	_, err = env.txBuilder.NewTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{newOwnerAddr},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.Error(err)
+
+	// The new owner can
+	_, err = env.txBuilder.NewTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		2,
+		[]ids.ShortID{
+			testSubnet1ControlKeys[0].PublicKey().Address(),
+			testSubnet1ControlKeys[1].PublicKey().Address(),
+		},
+		[]*crypto.PrivateKeySECP256K1R{newOwnerKey},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+}
+
+func TestTransferSubnetOwnershipTxUnauthorized(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	tx, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{preFundedKeys[4].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	// Drop one of the subnet authorization signatures
+	subnetCred := tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential)
+	subnetCred.Sigs = subnetCred.Sigs[:1]
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	require.Error(tx.Unsigned.Visit(&executor))
+}
+
+func TestTransferSubnetOwnershipTxBeforeBaobab(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	env.config.BaobabTime = defaultGenesisTime.Add(time.Hour)
+
+	tx, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{preFundedKeys[4].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errIssuedBeforeBaobab)
+
+	verifier := MempoolTxVerifier{
+		Backend:       &env.backend,
+		ParentID:      lastAcceptedID,
+		StateVersions: env,
+		Tx:            tx,
+	}
+	err = tx.Unsigned.Visit(&verifier)
+	require.ErrorIs(err, errIssuedBeforeBaobab)
+}
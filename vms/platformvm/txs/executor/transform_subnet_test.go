@@ -0,0 +1,276 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/state"
+	"github.com/kukrer/savannahnode/vms/platformvm/status"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+)
+
+// newTransformSubnetTx returns a signed TransformSubnetTx of [subnetID] that
+// doesn't burn any of [assetID].
+func newTransformSubnetTx(
+	env *environment,
+	subnetID ids.ID,
+	assetID ids.ID,
+	maxStakeDuration uint32,
+) (*txs.Tx, error) {
+	keys := []*crypto.PrivateKeySECP256K1R{preFundedKeys[0], preFundedKeys[1]}
+	fee := env.config.GetCreateSubnetTxFee(env.state.GetTimestamp())
+	ins, outs, _, signers, err := env.utxosHandler.Spend(keys, 0, fee, ids.ShortEmpty)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, subnetSigners, err := env.utxosHandler.Authorize(env.state, subnetID, keys)
+	if err != nil {
+		return nil, err
+	}
+	signers = append(signers, subnetSigners)
+
+	utx := &txs.TransformSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:                   subnetID,
+		AssetID:                  assetID,
+		InitialSupply:            1000,
+		MaximumSupply:            1000,
+		MinConsumptionRate:       0,
+		MaxConsumptionRate:       reward.PercentDenominator,
+		MinValidatorStake:        1,
+		MaxValidatorStake:        1000,
+		MinStakeDuration:         1,
+		MaxStakeDuration:         maxStakeDuration,
+		MinDelegationFee:         0,
+		MinDelegatorStake:        1,
+		MaxValidatorWeightFactor: 5,
+		UptimeRequirement:        reward.PercentDenominator,
+		SubnetAuth:               subnetAuth,
+	}
+	return txs.NewSigned(utx, txs.Codec, signers)
+}
+
+func TestTransformSubnetTxExecute(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	assetID := ids.GenerateTestID()
+	tx, err := newTransformSubnetTx(
+		env,
+		testSubnet1.ID(),
+		assetID,
+		uint32(defaultMaxStakingDuration.Seconds()),
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&executor))
+
+	transformSubnetTx, err := GetTransformSubnetTx(stateDiff, testSubnet1.ID())
+	require.NoError(err)
+	require.Equal(assetID, transformSubnetTx.AssetID)
+
+	supply, err := stateDiff.GetSubnetCurrentSupply(testSubnet1.ID())
+	require.NoError(err)
+	require.EqualValues(1000, supply)
+
+	// The subnet can't be transformed twice.
+	secondTx, err := newTransformSubnetTx(
+		env,
+		testSubnet1.ID(),
+		ids.GenerateTestID(),
+		uint32(defaultMaxStakingDuration.Seconds()),
+	)
+	require.NoError(err)
+
+	executor = StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      secondTx,
+	}
+	err = secondTx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errSubnetAlreadyTransformed)
+}
+
+func TestTransformSubnetTxMaxStakeDurationTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	tx, err := newTransformSubnetTx(
+		env,
+		testSubnet1.ID(),
+		ids.GenerateTestID(),
+		uint32(defaultMaxStakingDuration.Seconds())+1,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errMaxStakeDurationTooLarge)
+}
+
+func TestGetTransformSubnetTxNotTransformed(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	_, err := GetTransformSubnetTx(env.state, testSubnet1.ID())
+	require.ErrorIs(err, errNotPermissionlessSubnet)
+}
+
+func TestTransformSubnetTxBeforeBaobab(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	env.config.BaobabTime = defaultGenesisTime.Add(time.Hour)
+
+	tx, err := newTransformSubnetTx(
+		env,
+		testSubnet1.ID(),
+		ids.GenerateTestID(),
+		uint32(defaultMaxStakingDuration.Seconds()),
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errIssuedBeforeBaobab)
+}
+
+func TestTransformedSubnetRejectsPermissionedValidators(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	env.config.WhitelistedSubnets.Add(testSubnet1.ID())
+
+	// Add a permissioned validator before the subnet is transformed
+	nodeID := ids.NodeID(preFundedKeys[0].PublicKey().Address())
+	startTime := defaultValidateStartTime.Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	addTx, err := env.txBuilder.NewAddSubnetValidatorTx(
+		1,                        // Weight
+		uint64(startTime.Unix()), // Start time
+		uint64(endTime.Unix()),   // End time
+		nodeID,                   // Node ID
+		testSubnet1.ID(),         // Subnet ID
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0], preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	staker := state.NewSubnetStaker(addTx.ID(), &addTx.Unsigned.(*txs.AddSubnetValidatorTx).Validator)
+	staker.NextTime = staker.StartTime
+	staker.Priority = state.SubnetValidatorPendingPriority
+	env.state.PutPendingValidator(staker)
+	env.state.AddTx(addTx, status.Committed)
+
+	transformTx, err := newTransformSubnetTx(
+		env,
+		testSubnet1.ID(),
+		ids.GenerateTestID(),
+		uint32(defaultMaxStakingDuration.Seconds()),
+	)
+	require.NoError(err)
+	env.state.AddSubnetTransformation(transformTx)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	// The owner of the subnet can no longer add validators
+	secondAddTx, err := env.txBuilder.NewAddSubnetValidatorTx(
+		1,                        // Weight
+		uint64(startTime.Unix()), // Start time
+		uint64(endTime.Unix()),   // End time
+		ids.NodeID(preFundedKeys[1].PublicKey().Address()),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0], preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	proposalExecutor := ProposalTxExecutor{
+		Backend:       &env.backend,
+		ParentID:      lastAcceptedID,
+		StateVersions: env,
+		Tx:            secondAddTx,
+	}
+	err = secondAddTx.Unsigned.Visit(&proposalExecutor)
+	require.ErrorIs(err, errIsPermissionlessSubnet)
+
+	// Nor remove them
+	removeTx, err := env.txBuilder.NewRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0], preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	standardExecutor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      removeTx,
+	}
+	err = removeTx.Unsigned.Visit(&standardExecutor)
+	require.ErrorIs(err, errIsPermissionlessSubnet)
+}
@@ -51,6 +51,58 @@ func (v *MempoolTxVerifier) ExportTx(tx *txs.ExportTx) error {
 	return v.standardTx(tx)
 }
 
+func (v *MempoolTxVerifier) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	if err := v.verifyBaobabActivated(); err != nil {
+		return err
+	}
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	if err := v.verifyBaobabActivated(); err != nil {
+		return err
+	}
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	if err := v.verifyBaobabActivated(); err != nil {
+		return err
+	}
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) TopUpValidatorTx(tx *txs.TopUpValidatorTx) error {
+	if err := v.verifyBaobabActivated(); err != nil {
+		return err
+	}
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	if err := v.verifyBaobabActivated(); err != nil {
+		return err
+	}
+	return v.proposalTx(tx)
+}
+
+func (v *MempoolTxVerifier) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	if err := v.verifyBaobabActivated(); err != nil {
+		return err
+	}
+	return v.proposalTx(tx)
+}
+
+// verifyBaobabActivated returns an error if the Baobab network upgrade isn't
+// activated on top of the parent state
+func (v *MempoolTxVerifier) verifyBaobabActivated() error {
+	parentState, ok := v.StateVersions.GetState(v.ParentID)
+	if !ok {
+		return state.ErrMissingParentState
+	}
+	return verifyBaobabActivated(v.Backend, parentState)
+}
+
 func (v *MempoolTxVerifier) proposalTx(tx txs.StakerTx) error {
 	startTime := tx.StartTime()
 	maxLocalStartTime := v.Clk.Time().Add(MaxFutureStartTime)
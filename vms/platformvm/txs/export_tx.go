@@ -63,7 +63,8 @@ func (tx *ExportTx) SyntacticVerify(ctx *snow.Context) error {
 		if err := out.Verify(); err != nil {
 			return fmt.Errorf("output failed verification: %w", err)
 		}
-		if _, ok := out.Output().(*stakeable.LockOut); ok {
+		switch out.Output().(type) {
+		case *stakeable.LockOut, *stakeable.VestingOut:
 			return ErrWrongLocktime
 		}
 	}
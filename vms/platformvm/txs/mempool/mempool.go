@@ -6,11 +6,13 @@ package mempool
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/kukrer/savannahnode/cache"
 	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/timer/mockable"
 	"github.com/kukrer/savannahnode/utils/units"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs/txheap"
@@ -74,6 +76,15 @@ type Mempool interface {
 	// reissued.
 	MarkDropped(txID ids.ID, reason string)
 	GetDropReason(txID ids.ID) (string, bool)
+
+	// List returns the txs in the mempool, ordered from the oldest to the
+	// newest.
+	List() []*txs.Tx
+	// GetAddedTime returns the time [txID] was added to the mempool.
+	GetAddedTime(txID ids.ID) (time.Time, bool)
+	// Evict removes [txID] from the mempool. Returns nil if [txID] isn't in
+	// the mempool.
+	Evict(txID ids.ID) *txs.Tx
 }
 
 // Transactions from clients that have not yet been put into blocks and added to
@@ -95,6 +106,11 @@ type mempool struct {
 
 	consumedUTXOs ids.Set
 
+	// unissuedTxs contains both the decision and the proposal txs, ordered
+	// from the oldest to the newest
+	unissuedTxs txheap.AgeHeap
+	clock       *mockable.Clock
+
 	blkTimer BlockTimer
 }
 
@@ -112,8 +128,9 @@ func NewMempool(
 		return nil, err
 	}
 
+	clock := &mockable.Clock{}
 	unissuedDecisionTxs, err := txheap.NewWithMetrics(
-		txheap.NewByAge(),
+		txheap.NewByAge(clock),
 		fmt.Sprintf("%s_decision_txs", namespace),
 		registerer,
 	)
@@ -148,6 +165,8 @@ func NewMempool(
 		unknownTxs:           unknownTxs,
 		droppedTxIDs:         &cache.LRU{Size: droppedTxIDsCacheSize},
 		consumedUTXOs:        ids.NewSet(initialConsumedUTXOsSize),
+		unissuedTxs:          txheap.NewByAge(clock),
+		clock:                clock,
 		dropIncoming:         false, // enable tx adding by default
 		blkTimer:             blkTimer,
 	}, nil
@@ -278,10 +297,31 @@ func (m *mempool) GetDropReason(txID ids.ID) (string, bool) {
 	return reason.(string), true
 }
 
+func (m *mempool) List() []*txs.Tx {
+	return m.unissuedTxs.List()
+}
+
+func (m *mempool) GetAddedTime(txID ids.ID) (time.Time, bool) {
+	return m.unissuedTxs.GetAddedTime(txID)
+}
+
+func (m *mempool) Evict(txID ids.ID) *txs.Tx {
+	tx := m.unissuedDecisionTxs.Remove(txID)
+	if tx == nil {
+		tx = m.unissuedProposalTxs.Remove(txID)
+	}
+	if tx != nil {
+		m.deregister(tx)
+	}
+	return tx
+}
+
 func (m *mempool) register(tx *txs.Tx) {
 	txBytes := tx.Bytes()
 	m.bytesAvailable -= len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
+
+	m.unissuedTxs.Add(tx)
 }
 
 func (m *mempool) deregister(tx *txs.Tx) {
@@ -291,6 +331,8 @@ func (m *mempool) deregister(tx *txs.Tx) {
 
 	inputs := tx.Unsigned.InputIDs()
 	m.consumedUTXOs.Difference(inputs)
+
+	m.unissuedTxs.Remove(tx.ID())
 }
 
 type mempoolIssuer struct {
@@ -340,3 +382,33 @@ func (i *mempoolIssuer) ExportTx(tx *txs.ExportTx) error {
 	i.m.AddDecisionTx(i.tx)
 	return nil
 }
+
+func (i *mempoolIssuer) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	i.m.AddDecisionTx(i.tx)
+	return nil
+}
+
+func (i *mempoolIssuer) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	i.m.AddDecisionTx(i.tx)
+	return nil
+}
+
+func (i *mempoolIssuer) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	i.m.AddProposalTx(i.tx)
+	return nil
+}
+
+func (i *mempoolIssuer) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	i.m.AddProposalTx(i.tx)
+	return nil
+}
+
+func (i *mempoolIssuer) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	i.m.AddDecisionTx(i.tx)
+	return nil
+}
+
+func (i *mempoolIssuer) TopUpValidatorTx(tx *txs.TopUpValidatorTx) error {
+	i.m.AddDecisionTx(i.tx)
+	return nil
+}
@@ -6,6 +6,7 @@ package mempool
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -84,3 +85,68 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	err = mpool.Add(tx)
 	require.NoError(err, "should have added tx to mempool")
 }
+
+func TestMempoolListAndEvict(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &dummyBlkTimer{})
+	require.NoError(err)
+
+	now := time.Unix(1607133207, 0)
+	mpool.(*mempool).clock.Set(now)
+
+	tx0, err := createTestDecisionTx(0)
+	require.NoError(err)
+	tx1, err := createTestDecisionTx(1)
+	require.NoError(err)
+
+	require.NoError(mpool.Add(tx1))
+	mpool.(*mempool).clock.Set(now.Add(time.Second))
+	require.NoError(mpool.Add(tx0))
+
+	require.Equal([]*txs.Tx{tx1, tx0}, mpool.List())
+
+	addedTime, ok := mpool.GetAddedTime(tx1.ID())
+	require.True(ok)
+	require.Equal(now, addedTime)
+	addedTime, ok = mpool.GetAddedTime(tx0.ID())
+	require.True(ok)
+	require.Equal(now.Add(time.Second), addedTime)
+
+	bytesAvailable := mpool.(*mempool).bytesAvailable
+	require.Equal(tx1, mpool.Evict(tx1.ID()))
+	require.Nil(mpool.Evict(tx1.ID()))
+	require.False(mpool.Has(tx1.ID()))
+	require.Equal([]*txs.Tx{tx0}, mpool.List())
+	require.Equal(bytesAvailable+len(tx1.Bytes()), mpool.(*mempool).bytesAvailable)
+
+	_, ok = mpool.GetAddedTime(tx1.ID())
+	require.False(ok)
+
+	// The inputs of an evicted tx are no longer considered consumed
+	require.NoError(mpool.Add(tx1))
+	require.Equal([]*txs.Tx{tx0, tx1}, mpool.List())
+}
+
+func createTestDecisionTx(index uint32) (*txs.Tx, error) {
+	utx := &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    10,
+			BlockchainID: ids.Empty.Prefix(1),
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{
+					TxID:        ids.ID{'t', 'x', 'I', 'D'},
+					OutputIndex: index,
+				},
+				Asset: avax.Asset{ID: ids.ID{'a', 's', 's', 'e', 'r', 't'}},
+				In: &secp256k1fx.TransferInput{
+					Amt:   uint64(5678),
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			}},
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	}
+	return txs.NewSigned(utx, txs.Codec, nil)
+}
@@ -9,6 +9,7 @@ import (
 	txs "github.com/kukrer/savannahnode/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockMempool is a mock of Mempool interface
@@ -96,6 +97,20 @@ func (mr *MockMempoolMockRecorder) EnableAdding() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAdding", reflect.TypeOf((*MockMempool)(nil).EnableAdding))
 }
 
+// Evict mocks base method
+func (m *MockMempool) Evict(arg0 ids.ID) *txs.Tx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evict", arg0)
+	ret0, _ := ret[0].(*txs.Tx)
+	return ret0
+}
+
+// Evict indicates an expected call of Evict
+func (mr *MockMempoolMockRecorder) Evict(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evict", reflect.TypeOf((*MockMempool)(nil).Evict), arg0)
+}
+
 // Get mocks base method
 func (m *MockMempool) Get(arg0 ids.ID) *txs.Tx {
 	m.ctrl.T.Helper()
@@ -110,6 +125,21 @@ func (mr *MockMempoolMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMempool)(nil).Get), arg0)
 }
 
+// GetAddedTime mocks base method
+func (m *MockMempool) GetAddedTime(arg0 ids.ID) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddedTime", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetAddedTime indicates an expected call of GetAddedTime
+func (mr *MockMempoolMockRecorder) GetAddedTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddedTime", reflect.TypeOf((*MockMempool)(nil).GetAddedTime), arg0)
+}
+
 // GetDropReason mocks base method
 func (m *MockMempool) GetDropReason(arg0 ids.ID) (string, bool) {
 	m.ctrl.T.Helper()
@@ -167,6 +197,20 @@ func (mr *MockMempoolMockRecorder) HasProposalTx() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasProposalTx", reflect.TypeOf((*MockMempool)(nil).HasProposalTx))
 }
 
+// List mocks base method
+func (m *MockMempool) List() []*txs.Tx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*txs.Tx)
+	return ret0
+}
+
+// List indicates an expected call of List
+func (mr *MockMempoolMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMempool)(nil).List))
+}
+
 // MarkDropped mocks base method
 func (m *MockMempool) MarkDropped(arg0 ids.ID, arg1 string) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	_ UnsignedTx             = &RemoveSubnetValidatorTx{}
+	_ secp256k1fx.UnsignedTx = &RemoveSubnetValidatorTx{}
+
+	errRemovePrimaryNetworkValidator = errors.New("can't remove primary network validator with RemoveSubnetValidatorTx")
+)
+
+// RemoveSubnetValidatorTx is an unsigned tx that removes a validator from a
+// subnet before its end time.
+type RemoveSubnetValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node to remove from the subnet
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// The subnet to remove the node from
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Auth that will be allowing this validator to be removed from the subnet
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *RemoveSubnetValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errRemovePrimaryNetworkValidator
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *RemoveSubnetValidatorTx) Visit(visitor Visitor) error {
+	return visitor.RemoveSubnetValidatorTx(tx)
+}
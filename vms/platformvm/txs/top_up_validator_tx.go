@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	_ UnsignedTx             = &TopUpValidatorTx{}
+	_ secp256k1fx.UnsignedTx = &TopUpValidatorTx{}
+
+	errEmptyNodeID = errors.New("validator nodeID cannot be empty")
+)
+
+// TopUpValidatorTx is an unsigned tx that adds stake to and/or extends the
+// staking period of a current primary network validator. It must be authorized
+// by the rewards owner of the validator.
+type TopUpValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the node validating the primary network
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// Unix time the validator will stop validating. Must not be before the
+	// current end time of the validator.
+	End uint64 `serialize:"true" json:"end"`
+	// Amount of stake added to the validator
+	Wght uint64 `serialize:"true" json:"weight"`
+	// Where to send the added stake when done validating
+	Stake []*avax.TransferableOutput `serialize:"true" json:"stake"`
+	// Proves that the issuer controls the rewards owner of the validator
+	ValidatorAuth verify.Verifiable `serialize:"true" json:"validatorAuthorization"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [TopUpValidatorTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *TopUpValidatorTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	for _, out := range tx.Stake {
+		out.FxID = secp256k1fx.ID
+		out.InitCtx(ctx)
+	}
+}
+
+// EndTime the validator will stop validating at
+func (tx *TopUpValidatorTx) EndTime() time.Time {
+	return time.Unix(int64(tx.End), 0)
+}
+
+// Weight added to the validator
+func (tx *TopUpValidatorTx) Weight() uint64 {
+	return tx.Wght
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *TopUpValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.NodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := tx.ValidatorAuth.Verify(); err != nil {
+		return fmt.Errorf("failed to verify validator authorization: %w", err)
+	}
+
+	totalStakeWeight := uint64(0)
+	for _, out := range tx.Stake {
+		if err := out.Verify(); err != nil {
+			return fmt.Errorf("failed to verify output: %w", err)
+		}
+		newWeight, err := math.Add64(totalStakeWeight, out.Output().Amount())
+		if err != nil {
+			return err
+		}
+		totalStakeWeight = newWeight
+
+		assetID := out.AssetID()
+		if assetID != ctx.AVAXAssetID {
+			return fmt.Errorf("stake output must be AVAX but is %q", assetID)
+		}
+	}
+
+	switch {
+	case !avax.IsSortedTransferableOutputs(tx.Stake, Codec):
+		return errOutputsNotSorted
+	case totalStakeWeight != tx.Wght:
+		return fmt.Errorf("added weight %d is not equal to total stake weight %d", tx.Wght, totalStakeWeight)
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *TopUpValidatorTx) Visit(visitor Visitor) error {
+	return visitor.TopUpValidatorTx(tx)
+}
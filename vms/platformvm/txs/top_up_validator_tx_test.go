@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestTopUpValidatorTxSyntacticVerify(t *testing.T) {
+	require := require.New(t)
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.ID{'a', 'v', 'a', 'x'}
+	signers := [][]*crypto.PrivateKeySECP256K1R{preFundedKeys}
+
+	var (
+		stx              *Tx
+		topUpValidatorTx *TopUpValidatorTx
+		err              error
+	)
+
+	// Case : signed tx is nil
+	require.ErrorIs(stx.SyntacticVerify(ctx), errNilSignedTx)
+
+	// Case : unsigned tx is nil
+	require.ErrorIs(topUpValidatorTx.SyntacticVerify(ctx), ErrNilTx)
+
+	nodeID := ids.GenerateTestNodeID()
+	inputs := []*avax.TransferableInput{{
+		UTXOID: avax.UTXOID{
+			TxID:        ids.ID{'t', 'x', 'I', 'D'},
+			OutputIndex: 2,
+		},
+		Asset: avax.Asset{ID: ctx.AVAXAssetID},
+		In: &secp256k1fx.TransferInput{
+			Amt:   uint64(5678),
+			Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+	}}
+	outputs := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: uint64(1234),
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+			},
+		},
+	}}
+	stakes := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: uint64(4000),
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+			},
+		},
+	}}
+	validatorAuth := &secp256k1fx.Input{
+		SigIndices: []uint32{0, 1},
+	}
+	topUpValidatorTx = &TopUpValidatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    ctx.NetworkID,
+			BlockchainID: ctx.ChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		}},
+		NodeID:        nodeID,
+		End:           1000,
+		Wght:          4000,
+		Stake:         stakes,
+		ValidatorAuth: validatorAuth,
+	}
+
+	// Case: valid tx
+	stx, err = NewSigned(topUpValidatorTx, Codec, signers)
+	require.NoError(err)
+	require.NoError(stx.SyntacticVerify(ctx))
+
+	// Case: Wrong network ID
+	topUpValidatorTx.SyntacticallyVerified = false
+	topUpValidatorTx.NetworkID++
+	stx, err = NewSigned(topUpValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+	topUpValidatorTx.NetworkID--
+
+	// Case: Empty node ID
+	topUpValidatorTx.SyntacticallyVerified = false
+	topUpValidatorTx.NodeID = ids.EmptyNodeID
+	stx, err = NewSigned(topUpValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.ErrorIs(err, errEmptyNodeID)
+	topUpValidatorTx.NodeID = nodeID
+
+	// Case: Weight doesn't match the stake
+	topUpValidatorTx.SyntacticallyVerified = false
+	topUpValidatorTx.Wght++
+	stx, err = NewSigned(topUpValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+	topUpValidatorTx.Wght--
+
+	// Case: Stake isn't AVAX
+	topUpValidatorTx.SyntacticallyVerified = false
+	stakes[0].Asset.ID = ids.ID{'a', 's', 's', 'e', 't'}
+	stx, err = NewSigned(topUpValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+	stakes[0].Asset.ID = ctx.AVAXAssetID
+
+	// Case: Extension only
+	topUpValidatorTx.SyntacticallyVerified = false
+	topUpValidatorTx.Wght = 0
+	topUpValidatorTx.Stake = nil
+	stx, err = NewSigned(topUpValidatorTx, Codec, signers)
+	require.NoError(err)
+	require.NoError(stx.SyntacticVerify(ctx))
+	topUpValidatorTx.Wght = 4000
+	topUpValidatorTx.Stake = stakes
+
+	// Case: Validator auth indices not unique
+	topUpValidatorTx.SyntacticallyVerified = false
+	validatorAuth.SigIndices[0] = validatorAuth.SigIndices[1]
+	stx, err = NewSigned(topUpValidatorTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+}
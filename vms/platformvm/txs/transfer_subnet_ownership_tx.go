@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	_ UnsignedTx             = &TransferSubnetOwnershipTx{}
+	_ secp256k1fx.UnsignedTx = &TransferSubnetOwnershipTx{}
+
+	errTransferPrimaryNetworkOwnership = errors.New("can't transfer ownership of the primary network")
+)
+
+// TransferSubnetOwnershipTx is an unsigned tx that replaces the owner of a
+// subnet. It must be authorized by the current owner.
+type TransferSubnetOwnershipTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet this tx is modifying
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Proves that the issuer has the right to modify the subnet
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+	// Who is now authorized to manage this subnet
+	Owner fx.Owner `serialize:"true" json:"newOwner"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [TransferSubnetOwnershipTx]. Also sets the [ctx] to the given [vm.ctx] so
+// that the addresses can be json marshalled into human readable format
+func (tx *TransferSubnetOwnershipTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.Owner.InitCtx(ctx)
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *TransferSubnetOwnershipTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errTransferPrimaryNetworkOwnership
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(tx.SubnetAuth, tx.Owner); err != nil {
+		return err
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *TransferSubnetOwnershipTx) Visit(visitor Visitor) error {
+	return visitor.TransferSubnetOwnershipTx(tx)
+}
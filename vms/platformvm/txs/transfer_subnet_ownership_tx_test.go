@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestTransferSubnetOwnershipTxSyntacticVerify(t *testing.T) {
+	require := require.New(t)
+	ctx := snow.DefaultContextTest()
+	signers := [][]*crypto.PrivateKeySECP256K1R{preFundedKeys}
+
+	var (
+		stx                       *Tx
+		transferSubnetOwnershipTx *TransferSubnetOwnershipTx
+		err                       error
+	)
+
+	// Case : signed tx is nil
+	require.ErrorIs(stx.SyntacticVerify(ctx), errNilSignedTx)
+
+	// Case : unsigned tx is nil
+	require.ErrorIs(transferSubnetOwnershipTx.SyntacticVerify(ctx), ErrNilTx)
+
+	subnetID := ids.ID{'s', 'u', 'b', 'n', 'e', 't', 'I', 'D'}
+	inputs := []*avax.TransferableInput{{
+		UTXOID: avax.UTXOID{
+			TxID:        ids.ID{'t', 'x', 'I', 'D'},
+			OutputIndex: 2,
+		},
+		Asset: avax.Asset{ID: ids.ID{'a', 's', 's', 'e', 't'}},
+		In: &secp256k1fx.TransferInput{
+			Amt:   uint64(5678),
+			Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+	}}
+	outputs := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: ids.ID{'a', 's', 's', 'e', 't'}},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: uint64(1234),
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+			},
+		},
+	}}
+	subnetAuth := &secp256k1fx.Input{
+		SigIndices: []uint32{0, 1},
+	}
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{preFundedKeys[1].PublicKey().Address()},
+	}
+	transferSubnetOwnershipTx = &TransferSubnetOwnershipTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    ctx.NetworkID,
+			BlockchainID: ctx.ChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner:      owner,
+	}
+
+	// Case: valid tx
+	stx, err = NewSigned(transferSubnetOwnershipTx, Codec, signers)
+	require.NoError(err)
+	require.NoError(stx.SyntacticVerify(ctx))
+
+	// Case: Wrong network ID
+	transferSubnetOwnershipTx.SyntacticallyVerified = false
+	transferSubnetOwnershipTx.NetworkID++
+	stx, err = NewSigned(transferSubnetOwnershipTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+	transferSubnetOwnershipTx.NetworkID--
+
+	// Case: Primary network subnet
+	transferSubnetOwnershipTx.SyntacticallyVerified = false
+	transferSubnetOwnershipTx.Subnet = constants.PrimaryNetworkID
+	stx, err = NewSigned(transferSubnetOwnershipTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.ErrorIs(err, errTransferPrimaryNetworkOwnership)
+	transferSubnetOwnershipTx.Subnet = subnetID
+
+	// Case: Invalid new owner
+	transferSubnetOwnershipTx.SyntacticallyVerified = false
+	owner.Threshold = 2
+	stx, err = NewSigned(transferSubnetOwnershipTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+	owner.Threshold = 1
+
+	// Case: Subnet auth indices not unique
+	transferSubnetOwnershipTx.SyntacticallyVerified = false
+	input := transferSubnetOwnershipTx.SubnetAuth.(*secp256k1fx.Input)
+	input.SigIndices[0] = input.SigIndices[1]
+	stx, err = NewSigned(transferSubnetOwnershipTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+}
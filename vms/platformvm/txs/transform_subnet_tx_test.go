@@ -0,0 +1,230 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestTransformSubnetTxSyntacticVerify(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+	signers := [][]*crypto.PrivateKeySECP256K1R{preFundedKeys}
+
+	newValidTx := func() *TransformSubnetTx {
+		return &TransformSubnetTx{
+			BaseTx: BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    ctx.NetworkID,
+				BlockchainID: ctx.ChainID,
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID:        ids.ID{'t', 'x', 'I', 'D'},
+						OutputIndex: 2,
+					},
+					Asset: avax.Asset{ID: ctx.AVAXAssetID},
+					In: &secp256k1fx.TransferInput{
+						Amt:   uint64(5678),
+						Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+					},
+				}},
+			}},
+			Subnet:                   ids.ID{'s', 'u', 'b', 'n', 'e', 't', 'I', 'D'},
+			AssetID:                  ids.ID{'a', 's', 's', 'e', 't'},
+			InitialSupply:            10,
+			MaximumSupply:            100,
+			MinConsumptionRate:       0,
+			MaxConsumptionRate:       reward.PercentDenominator,
+			MinValidatorStake:        2,
+			MaxValidatorStake:        10,
+			MinStakeDuration:         1,
+			MaxStakeDuration:         2,
+			MinDelegationFee:         0,
+			MinDelegatorStake:        1,
+			MaxValidatorWeightFactor: 5,
+			UptimeRequirement:        reward.PercentDenominator,
+			SubnetAuth: &secp256k1fx.Input{
+				SigIndices: []uint32{0, 1},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(tx *TransformSubnetTx)
+		expectedErr error
+	}{
+		{
+			name:        "valid tx",
+			mutate:      func(*TransformSubnetTx) {},
+			expectedErr: nil,
+		},
+		{
+			name: "primary network",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.Subnet = constants.PrimaryNetworkID
+			},
+			expectedErr: errCantTransformPrimaryNetwork,
+		},
+		{
+			name: "empty assetID",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.AssetID = ids.Empty
+			},
+			expectedErr: errEmptyAssetID,
+		},
+		{
+			name: "AVAX assetID",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.AssetID = ctx.AVAXAssetID
+			},
+			expectedErr: errAssetIDCantBeAVAX,
+		},
+		{
+			name: "zero initial supply",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.InitialSupply = 0
+			},
+			expectedErr: errInitialSupplyZero,
+		},
+		{
+			name: "initial supply above maximum supply",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.InitialSupply = tx.MaximumSupply + 1
+			},
+			expectedErr: errInitialSupplyGreaterThanMaxSupply,
+		},
+		{
+			name: "min consumption rate above max consumption rate",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MinConsumptionRate = 2
+				tx.MaxConsumptionRate = 1
+			},
+			expectedErr: errMinConsumptionRateTooLarge,
+		},
+		{
+			name: "max consumption rate above percent denominator",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MaxConsumptionRate = reward.PercentDenominator + 1
+			},
+			expectedErr: errMaxConsumptionRateTooLarge,
+		},
+		{
+			name: "zero min validator stake",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MinValidatorStake = 0
+			},
+			expectedErr: errMinValidatorStakeZero,
+		},
+		{
+			name: "min validator stake above initial supply",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MinValidatorStake = tx.InitialSupply + 1
+			},
+			expectedErr: errMinValidatorStakeAboveSupply,
+		},
+		{
+			name: "min validator stake above max validator stake",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MaxValidatorStake = tx.MinValidatorStake - 1
+			},
+			expectedErr: errMinValidatorStakeAboveMax,
+		},
+		{
+			name: "max validator stake above maximum supply",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MaxValidatorStake = tx.MaximumSupply + 1
+			},
+			expectedErr: errMaxValidatorStakeTooLarge,
+		},
+		{
+			name: "zero min stake duration",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MinStakeDuration = 0
+			},
+			expectedErr: errMinStakeDurationZero,
+		},
+		{
+			name: "min stake duration above max stake duration",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MinStakeDuration = tx.MaxStakeDuration + 1
+			},
+			expectedErr: errMinStakeDurationTooLarge,
+		},
+		{
+			name: "min delegation fee above percent denominator",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MinDelegationFee = reward.PercentDenominator + 1
+			},
+			expectedErr: errMinDelegationFeeTooLarge,
+		},
+		{
+			name: "zero min delegator stake",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MinDelegatorStake = 0
+			},
+			expectedErr: errMinDelegatorStakeZero,
+		},
+		{
+			name: "zero max validator weight factor",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.MaxValidatorWeightFactor = 0
+			},
+			expectedErr: errMaxValidatorWeightFactorZero,
+		},
+		{
+			name: "uptime requirement above percent denominator",
+			mutate: func(tx *TransformSubnetTx) {
+				tx.UptimeRequirement = reward.PercentDenominator + 1
+			},
+			expectedErr: errUptimeRequirementTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			utx := newValidTx()
+			test.mutate(utx)
+
+			stx, err := NewSigned(utx, Codec, signers)
+			require.NoError(err)
+
+			err = stx.SyntacticVerify(ctx)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestTransformSubnetTxRewardConfig(t *testing.T) {
+	require := require.New(t)
+
+	tx := &TransformSubnetTx{
+		MaximumSupply:      1000,
+		MinConsumptionRate: 1,
+		MaxConsumptionRate: 2,
+	}
+	primaryNetworkConfig := reward.Config{
+		MaxConsumptionRate: 10,
+		MinConsumptionRate: 5,
+		MintingPeriod:      365 * 24 * 60 * 60,
+		SupplyCap:          10000,
+	}
+
+	require.Equal(reward.Config{
+		MaxConsumptionRate: 2,
+		MinConsumptionRate: 1,
+		MintingPeriod:      primaryNetworkConfig.MintingPeriod,
+		SupplyCap:          1000,
+	}, tx.RewardConfig(primaryNetworkConfig))
+}
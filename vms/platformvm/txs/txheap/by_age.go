@@ -3,14 +3,34 @@
 
 package txheap
 
-var _ Heap = &byAge{}
+import (
+	"sort"
+	"time"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/timer/mockable"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+)
+
+var _ AgeHeap = &byAge{}
+
+type AgeHeap interface {
+	Heap
+
+	// GetAddedTime returns the time [txID] was added to the heap
+	GetAddedTime(txID ids.ID) (time.Time, bool)
+}
 
 type byAge struct {
 	txHeap
+
+	clock *mockable.Clock
 }
 
-func NewByAge() Heap {
-	h := &byAge{}
+// NewByAge returns a heap of txs ordered from the oldest to the newest. The
+// time a tx is added to the heap is read from [clock].
+func NewByAge(clock *mockable.Clock) AgeHeap {
+	h := &byAge{clock: clock}
 	h.initialize(h)
 	return h
 }
@@ -18,3 +38,34 @@ func NewByAge() Heap {
 func (h *byAge) Less(i, j int) bool {
 	return h.txs[i].age < h.txs[j].age
 }
+
+func (h *byAge) Push(x interface{}) {
+	numTxs := len(h.txs)
+	h.txHeap.Push(x)
+	if len(h.txs) > numTxs {
+		h.txs[numTxs].addedTime = h.clock.Time()
+	}
+}
+
+// List returns the txs in the heap, ordered from the oldest to the newest
+func (h *byAge) List() []*txs.Tx {
+	heapTxs := make([]*heapTx, len(h.txs))
+	copy(heapTxs, h.txs)
+	sort.Slice(heapTxs, func(i, j int) bool {
+		return heapTxs[i].age < heapTxs[j].age
+	})
+
+	res := make([]*txs.Tx, len(heapTxs))
+	for i, htx := range heapTxs {
+		res[i] = htx.tx
+	}
+	return res
+}
+
+func (h *byAge) GetAddedTime(txID ids.ID) (time.Time, bool) {
+	index, exists := h.txIDToIndex[txID]
+	if !exists {
+		return time.Time{}, false
+	}
+	return h.txs[index].addedTime, true
+}
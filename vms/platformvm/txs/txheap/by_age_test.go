@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txheap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/timer/mockable"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestByAge(t *testing.T) {
+	require := require.New(t)
+
+	clock := &mockable.Clock{}
+	txHeap := NewByAge(clock)
+
+	baseTime := time.Unix(1607133207, 0)
+
+	heapTxs := make([]*txs.Tx, 3)
+	for i := range heapTxs {
+		utx := &txs.CreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{{byte(i)}},
+			},
+		}
+		tx := &txs.Tx{Unsigned: utx}
+		require.NoError(tx.Sign(txs.Codec, nil))
+		heapTxs[i] = tx
+
+		clock.Set(baseTime.Add(time.Duration(i) * time.Second))
+		txHeap.Add(tx)
+	}
+	require.Equal(heapTxs[0], txHeap.Peek())
+	require.Equal(heapTxs, txHeap.List())
+
+	addedTime, ok := txHeap.GetAddedTime(heapTxs[1].ID())
+	require.True(ok)
+	require.Equal(baseTime.Add(time.Second), addedTime)
+
+	// A removed tx is re-added as the newest tx
+	require.Equal(heapTxs[0], txHeap.Remove(heapTxs[0].ID()))
+	_, ok = txHeap.GetAddedTime(heapTxs[0].ID())
+	require.False(ok)
+
+	txHeap.Add(heapTxs[0])
+	require.Equal([]*txs.Tx{heapTxs[1], heapTxs[2], heapTxs[0]}, txHeap.List())
+	require.Equal(heapTxs[1], txHeap.Peek())
+}
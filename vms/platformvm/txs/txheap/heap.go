@@ -5,6 +5,7 @@ package txheap
 
 import (
 	"container/heap"
+	"time"
 
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
@@ -26,6 +27,8 @@ type heapTx struct {
 	tx    *txs.Tx
 	index int
 	age   int
+	// addedTime is only tracked by heaps ordered by age
+	addedTime time.Time
 }
 
 type txHeap struct {
@@ -14,4 +14,10 @@ type Visitor interface {
 	ExportTx(*ExportTx) error
 	AdvanceTimeTx(*AdvanceTimeTx) error
 	RewardValidatorTx(*RewardValidatorTx) error
+	RemoveSubnetValidatorTx(*RemoveSubnetValidatorTx) error
+	TransformSubnetTx(*TransformSubnetTx) error
+	AddPermissionlessValidatorTx(*AddPermissionlessValidatorTx) error
+	AddPermissionlessDelegatorTx(*AddPermissionlessDelegatorTx) error
+	TransferSubnetOwnershipTx(*TransferSubnetOwnershipTx) error
+	TopUpValidatorTx(*TopUpValidatorTx) error
 }
@@ -6,6 +6,7 @@ package utxo
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -17,6 +18,8 @@ import (
 	"github.com/kukrer/savannahnode/utils/timer/mockable"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
+	"github.com/kukrer/savannahnode/vms/platformvm/config"
 	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
@@ -29,6 +32,8 @@ var (
 
 	errCantSign                     = errors.New("can't sign")
 	errLockedFundsNotMarkedAsLocked = errors.New("locked funds not marked as locked")
+	errWrongVestingSchedule         = errors.New("input vesting schedule doesn't match the consumed UTXO")
+	errUnsupportedBeforeBaobab      = errors.New("unsupported before the baobab upgrade")
 )
 
 // Removes the UTXOs consumed by [ins] from the UTXO set
@@ -106,6 +111,7 @@ type Verifier interface {
 	// [unlockedProduced] is the map of assets that were produced and their
 	// amounts.
 	// The [ins] must have at least [unlockedProduced] than the [outs].
+	// [chainTime] is the timestamp of the chain [tx] is executed on.
 	//
 	// Precondition: [tx] has already been syntactically verified.
 	//
@@ -117,6 +123,7 @@ type Verifier interface {
 		outs []*avax.TransferableOutput,
 		creds []verify.Verifiable,
 		unlockedProduced map[ids.ID]uint64,
+		chainTime time.Time,
 	) error
 
 	// Verify that [tx] is semantically valid.
@@ -126,6 +133,7 @@ type Verifier interface {
 	// [unlockedProduced] is the map of assets that were produced and their
 	// amounts.
 	// The [ins] must have at least [unlockedProduced] more than the [outs].
+	// [chainTime] is the timestamp of the chain [tx] is executed on.
 	//
 	// Precondition: [tx] has already been syntactically verified.
 	//
@@ -137,6 +145,7 @@ type Verifier interface {
 		outs []*avax.TransferableOutput,
 		creds []verify.Verifiable,
 		unlockedProduced map[ids.ID]uint64,
+		chainTime time.Time,
 	) error
 }
 
@@ -147,12 +156,14 @@ type Handler interface {
 
 func NewHandler(
 	ctx *snow.Context,
+	cfg *config.Config,
 	clk *mockable.Clock,
 	utxoReader avax.UTXOReader,
 	fx fx.Fx,
 ) Handler {
 	return &handler{
 		ctx:         ctx,
+		cfg:         cfg,
 		clk:         clk,
 		utxosReader: utxoReader,
 		fx:          fx,
@@ -161,6 +172,7 @@ func NewHandler(
 
 type handler struct {
 	ctx         *snow.Context
+	cfg         *config.Config
 	clk         *mockable.Clock
 	utxosReader avax.UTXOReader
 	fx          fx.Fx
@@ -199,6 +211,10 @@ func (h *handler) Spend(
 
 	// Amount of AVAX that has been staked
 	amountStaked := uint64(0)
+	// Amount of AVAX that has been burned
+	amountBurned := uint64(0)
+	// Vesting UTXOs that were consumed while consuming locked UTXOs
+	consumedVesting := ids.Set{}
 
 	// Consume locked UTXOs
 	for _, utxo := range utxos {
@@ -212,6 +228,111 @@ func (h *handler) Spend(
 			continue // We only care about staking AVAX, so ignore other assets
 		}
 
+		if out, ok := utxo.Out.(*stakeable.VestingOut); ok {
+			lockedTranches := stakeable.LockedTranches(out.Tranches, now)
+			if len(lockedTranches) == 0 {
+				// This output is fully vested, so it will be handled during the
+				// next iteration of the UTXO set
+				continue
+			}
+
+			inner, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
+			if !ok {
+				// We only know how to clone secp256k1 outputs for now
+				continue
+			}
+
+			inIntf, inSigners, err := kc.Spend(out.TransferableOut, now)
+			if err != nil {
+				// We couldn't spend the output, so move on to the next one
+				continue
+			}
+			in, ok := inIntf.(avax.TransferableIn)
+			if !ok { // should never happen
+				h.ctx.Log.Warn("wrong input type",
+					zap.String("expectedType", "FUEL.TransferableIn"),
+					zap.String("actualType", fmt.Sprintf("%T", inIntf)),
+				)
+				continue
+			}
+
+			lockedValue := stakeable.LockedAmount(lockedTranches, now)
+			unlockedValue := in.Amount() - lockedValue
+
+			// Stake the tranches that unlock last
+			amountToStake := math.Min64(
+				amount-amountStaked, // Amount we still need to stake
+				lockedValue,         // Amount available to stake
+			)
+			amountStaked += amountToStake
+			lockedValue -= amountToStake
+			remainingTranches, stakedTranches := stakeable.SplitTranches(lockedTranches, amountToStake)
+
+			// Burn the part of the output that already vested
+			amountToBurn := math.Min64(
+				fee-amountBurned, // Amount we still need to burn
+				unlockedValue,    // Amount available to burn
+			)
+			amountBurned += amountToBurn
+			unlockedValue -= amountToBurn
+
+			// Add the input to the consumed inputs
+			ins = append(ins, &avax.TransferableInput{
+				UTXOID: utxo.UTXOID,
+				Asset:  avax.Asset{ID: h.ctx.AVAXAssetID},
+				In: &stakeable.VestingIn{
+					Tranches:       out.Tranches,
+					TransferableIn: in,
+				},
+			})
+
+			// Add the output to the staked outputs
+			stakedOuts = append(stakedOuts, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: stakedTranches,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt:          amountToStake,
+						OutputOwners: inner.OutputOwners,
+					},
+				},
+			})
+
+			if lockedValue > 0 {
+				// The tranches that weren't staked keep vesting
+				returnedOuts = append(returnedOuts, &avax.TransferableOutput{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					Out: &stakeable.VestingOut{
+						Tranches: remainingTranches,
+						TransferableOut: &secp256k1fx.TransferOutput{
+							Amt:          lockedValue,
+							OutputOwners: inner.OutputOwners,
+						},
+					},
+				})
+			}
+
+			if unlockedValue > 0 {
+				// The vested value that wasn't burned is returned
+				returnedOuts = append(returnedOuts, &avax.TransferableOutput{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: unlockedValue,
+						OutputOwners: secp256k1fx.OutputOwners{
+							Locktime:  0,
+							Threshold: 1,
+							Addrs:     []ids.ShortID{changeAddr},
+						},
+					},
+				})
+			}
+
+			// Add the signers needed for this input to the set of signers
+			signers = append(signers, inSigners)
+			consumedVesting.Add(utxo.InputID())
+			continue
+		}
+
 		out, ok := utxo.Out.(*stakeable.LockOut)
 		if !ok {
 			// This output isn't locked, so it will be handled during the next
@@ -296,9 +417,6 @@ func (h *handler) Spend(
 		signers = append(signers, inSigners)
 	}
 
-	// Amount of AVAX that has been burned
-	amountBurned := uint64(0)
-
 	for _, utxo := range utxos {
 		// If we have consumed more AVAX than we are trying to stake, and we
 		// have burned more AVAX then we need to, then we have no need to
@@ -312,8 +430,9 @@ func (h *handler) Spend(
 		}
 
 		out := utxo.Out
-		inner, ok := out.(*stakeable.LockOut)
-		if ok {
+		var vestingOut *stakeable.VestingOut
+		switch inner := out.(type) {
+		case *stakeable.LockOut:
 			if inner.Locktime > now {
 				// This output is currently locked, so this output can't be
 				// burned. Additionally, it may have already been consumed
@@ -321,6 +440,17 @@ func (h *handler) Spend(
 				continue
 			}
 			out = inner.TransferableOut
+		case *stakeable.VestingOut:
+			if consumedVesting.Contains(utxo.InputID()) {
+				// This output was already consumed above
+				continue
+			}
+			if stakeable.LockedAmount(inner.Tranches, now) == inner.Amount() {
+				// None of this output vested yet, so it can't be burned
+				continue
+			}
+			vestingOut = inner
+			out = inner.TransferableOut
 		}
 
 		inIntf, inSigners, err := kc.Spend(out, now)
@@ -338,6 +468,36 @@ func (h *handler) Spend(
 		// The remaining value is initially the full value of the input
 		remainingValue := in.Amount()
 
+		if vestingOut != nil {
+			lockedTranches := stakeable.LockedTranches(vestingOut.Tranches, now)
+			if len(lockedTranches) > 0 {
+				inner, ok := vestingOut.TransferableOut.(*secp256k1fx.TransferOutput)
+				if !ok {
+					// We only know how to clone secp256k1 outputs for now
+					continue
+				}
+
+				// Only the vested value can be spent. The tranches that are
+				// still locked keep vesting.
+				lockedValue := stakeable.LockedAmount(lockedTranches, now)
+				remainingValue -= lockedValue
+				returnedOuts = append(returnedOuts, &avax.TransferableOutput{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					Out: &stakeable.VestingOut{
+						Tranches: lockedTranches,
+						TransferableOut: &secp256k1fx.TransferOutput{
+							Amt:          lockedValue,
+							OutputOwners: inner.OutputOwners,
+						},
+					},
+				})
+				in = &stakeable.VestingIn{
+					Tranches:       vestingOut.Tranches,
+					TransferableIn: in,
+				}
+			}
+		}
+
 		// Burn any value that should be burned
 		amountToBurn := math.Min64(
 			fee-amountBurned, // Amount we still need to burn
@@ -417,23 +577,19 @@ func (h *handler) Authorize(
 	[]*crypto.PrivateKeySECP256K1R, // Keys that prove ownership
 	error,
 ) {
-	subnetTx, _, err := state.GetTx(subnetID)
+	subnetOwner, err := state.GetSubnetOwner(subnetID)
 	if err != nil {
 		return nil, nil, fmt.Errorf(
-			"failed to fetch subnet %s: %w",
+			"failed to fetch owner of subnet %s: %w",
 			subnetID,
 			err,
 		)
 	}
-	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
-	if !ok {
-		return nil, nil, fmt.Errorf("expected tx type *txs.CreateSubnetTx but got %T", subnetTx.Unsigned)
-	}
 
 	// Make sure the owners of the subnet match the provided keys
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+	owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
 	if !ok {
-		return nil, nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnet.Owner)
+		return nil, nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnetOwner)
 	}
 
 	// Add the keys to a keychain
@@ -458,6 +614,7 @@ func (h *handler) VerifySpend(
 	outs []*avax.TransferableOutput,
 	creds []verify.Verifiable,
 	unlockedProduced map[ids.ID]uint64,
+	chainTime time.Time,
 ) error {
 	utxos := make([]*avax.UTXO, len(ins))
 	for index, input := range ins {
@@ -472,7 +629,7 @@ func (h *handler) VerifySpend(
 		utxos[index] = utxo
 	}
 
-	return h.VerifySpendUTXOs(tx, utxos, ins, outs, creds, unlockedProduced)
+	return h.VerifySpendUTXOs(tx, utxos, ins, outs, creds, unlockedProduced, chainTime)
 }
 
 func (h *handler) VerifySpendUTXOs(
@@ -482,6 +639,7 @@ func (h *handler) VerifySpendUTXOs(
 	outs []*avax.TransferableOutput,
 	creds []verify.Verifiable,
 	unlockedProduced map[ids.ID]uint64,
+	chainTime time.Time,
 ) error {
 	if len(ins) != len(creds) {
 		return fmt.Errorf(
@@ -502,10 +660,22 @@ func (h *handler) VerifySpendUTXOs(
 			return err
 		}
 	}
+	if !h.cfg.IsBaobabActivated(chainTime) {
+		if err := verifyPreBaobabTypes(utxos, ins, outs); err != nil {
+			return err
+		}
+	}
 
 	// Time this transaction is being verified
 	now := uint64(h.clk.Time().Unix())
 
+	// The HTLC deadlines are checked against the chain time, so that the
+	// result doesn't depend on when the tx is verified
+	timestampedTx := &htlcfx.TimestampedTx{
+		UnsignedTx: tx,
+		Timestamp:  uint64(chainTime.Unix()),
+	}
+
 	// Track the amount of unlocked transfers
 	// assetID -> amount
 	unlockedConsumed := make(map[ids.ID]uint64)
@@ -531,17 +701,34 @@ func (h *handler) VerifySpendUTXOs(
 
 		out := utxo.Out
 		locktime := uint64(0)
+		// [tranches] is the vesting schedule of this UTXO, if applicable
+		var tranches []stakeable.Tranche
 		// Set [locktime] to this UTXO's locktime, if applicable
-		if inner, ok := out.(*stakeable.LockOut); ok {
+		switch inner := out.(type) {
+		case *stakeable.LockOut:
 			out = inner.TransferableOut
 			locktime = inner.Locktime
+		case *stakeable.VestingOut:
+			out = inner.TransferableOut
+			tranches = inner.Tranches
 		}
 
 		in := input.In
-		// The UTXO says it's locked until [locktime], but this input, which
-		// consumes it, is not locked even though [locktime] hasn't passed. This
-		// is invalid.
-		if inner, ok := in.(*stakeable.LockIn); now < locktime && !ok {
+		if tranches != nil {
+			// The UTXO vests over time. If some of it is still locked, the
+			// input must repeat its vesting schedule.
+			if inner, ok := in.(*stakeable.VestingIn); ok {
+				if !equalTranches(inner.Tranches, tranches) {
+					return fmt.Errorf("%w: input %d", errWrongVestingSchedule, index)
+				}
+				in = inner.TransferableIn
+			} else if stakeable.LockedAmount(tranches, now) > 0 {
+				return errLockedFundsNotMarkedAsLocked
+			}
+		} else if inner, ok := in.(*stakeable.LockIn); now < locktime && !ok {
+			// The UTXO says it's locked until [locktime], but this input, which
+			// consumes it, is not locked even though [locktime] hasn't passed.
+			// This is invalid.
 			return errLockedFundsNotMarkedAsLocked
 		} else if ok {
 			if inner.Locktime != locktime {
@@ -552,12 +739,42 @@ func (h *handler) VerifySpendUTXOs(
 		}
 
 		// Verify that this tx's credentials allow [in] to be spent
-		if err := h.fx.VerifyTransfer(tx, in, creds[index], out); err != nil {
+		if err := h.fx.VerifyTransfer(timestampedTx, in, creds[index], out); err != nil {
 			return fmt.Errorf("failed to verify transfer: %w", err)
 		}
 
 		amount := in.Amount()
 
+		if tranches != nil {
+			// The tranches that already unlocked are consumed as unlocked
+			// funds. Each tranche that is still locked is consumed as if it
+			// were a separate output locked until the tranche's locktime.
+			lockedTranches := stakeable.LockedTranches(tranches, now)
+			newUnlockedConsumed, err := math.Add64(
+				unlockedConsumed[realAssetID],
+				amount-stakeable.LockedAmount(tranches, now),
+			)
+			if err != nil {
+				return err
+			}
+			unlockedConsumed[realAssetID] = newUnlockedConsumed
+
+			if len(lockedTranches) == 0 {
+				continue
+			}
+			ownerID, err := getOwnerID(out)
+			if err != nil {
+				return err
+			}
+			for _, tranche := range lockedTranches {
+				err := addLocked(lockedConsumed, realAssetID, tranche.Locktime, ownerID, tranche.Amount)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
 		if now >= locktime {
 			newUnlockedConsumed, err := math.Add64(unlockedConsumed[realAssetID], amount)
 			if err != nil {
@@ -567,31 +784,13 @@ func (h *handler) VerifySpendUTXOs(
 			continue
 		}
 
-		owned, ok := out.(fx.Owned)
-		if !ok {
-			return fmt.Errorf("expected fx.Owned but got %T", out)
-		}
-		owner := owned.Owners()
-		ownerBytes, err := txs.Codec.Marshal(txs.Version, owner)
+		ownerID, err := getOwnerID(out)
 		if err != nil {
-			return fmt.Errorf("couldn't marshal owner: %w", err)
-		}
-		lockedConsumedAsset, ok := lockedConsumed[realAssetID]
-		if !ok {
-			lockedConsumedAsset = make(map[uint64]map[ids.ID]uint64)
-			lockedConsumed[realAssetID] = lockedConsumedAsset
-		}
-		ownerID := hashing.ComputeHash256Array(ownerBytes)
-		owners, ok := lockedConsumedAsset[locktime]
-		if !ok {
-			owners = make(map[ids.ID]uint64)
-			lockedConsumedAsset[locktime] = owners
+			return err
 		}
-		newAmount, err := math.Add64(owners[ownerID], amount)
-		if err != nil {
+		if err := addLocked(lockedConsumed, realAssetID, locktime, ownerID, amount); err != nil {
 			return err
 		}
-		owners[ownerID] = newAmount
 	}
 
 	for _, out := range outs {
@@ -600,9 +799,24 @@ func (h *handler) VerifySpendUTXOs(
 		output := out.Output()
 		locktime := uint64(0)
 		// Set [locktime] to this output's locktime, if applicable
-		if inner, ok := output.(*stakeable.LockOut); ok {
+		switch inner := output.(type) {
+		case *stakeable.LockOut:
 			output = inner.TransferableOut
 			locktime = inner.Locktime
+		case *stakeable.VestingOut:
+			// Each tranche is produced as if it were a separate output locked
+			// until the tranche's locktime.
+			ownerID, err := getOwnerID(inner.TransferableOut)
+			if err != nil {
+				return err
+			}
+			for _, tranche := range inner.Tranches {
+				err := addLocked(lockedProduced, assetID, tranche.Locktime, ownerID, tranche.Amount)
+				if err != nil {
+					return err
+				}
+			}
+			continue
 		}
 
 		amount := output.Amount()
@@ -616,31 +830,13 @@ func (h *handler) VerifySpendUTXOs(
 			continue
 		}
 
-		owned, ok := output.(fx.Owned)
-		if !ok {
-			return fmt.Errorf("expected fx.Owned but got %T", out)
-		}
-		owner := owned.Owners()
-		ownerBytes, err := txs.Codec.Marshal(txs.Version, owner)
+		ownerID, err := getOwnerID(output)
 		if err != nil {
-			return fmt.Errorf("couldn't marshal owner: %w", err)
-		}
-		lockedProducedAsset, ok := lockedProduced[assetID]
-		if !ok {
-			lockedProducedAsset = make(map[uint64]map[ids.ID]uint64)
-			lockedProduced[assetID] = lockedProducedAsset
-		}
-		ownerID := hashing.ComputeHash256Array(ownerBytes)
-		owners, ok := lockedProducedAsset[locktime]
-		if !ok {
-			owners = make(map[ids.ID]uint64)
-			lockedProducedAsset[locktime] = owners
+			return err
 		}
-		newAmount, err := math.Add64(owners[ownerID], amount)
-		if err != nil {
+		if err := addLocked(lockedProduced, assetID, locktime, ownerID, amount); err != nil {
 			return err
 		}
-		owners[ownerID] = newAmount
 	}
 
 	// Make sure that for each assetID and locktime, tokens produced <= tokens consumed
@@ -683,3 +879,96 @@ func (h *handler) VerifySpendUTXOs(
 	}
 	return nil
 }
+
+// verifyPreBaobabTypes returns an error if [utxos], [ins] or [outs] use a type
+// that is only supported after the Baobab upgrade.
+func verifyPreBaobabTypes(
+	utxos []*avax.UTXO,
+	ins []*avax.TransferableInput,
+	outs []*avax.TransferableOutput,
+) error {
+	for _, utxo := range utxos {
+		if err := verifyPreBaobabType(utxo.Out); err != nil {
+			return err
+		}
+	}
+	for _, in := range ins {
+		if err := verifyPreBaobabType(in.In); err != nil {
+			return err
+		}
+	}
+	for _, out := range outs {
+		if err := verifyPreBaobabType(out.Out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyPreBaobabType(t interface{}) error {
+	switch t := t.(type) {
+	case *stakeable.LockIn:
+		return verifyPreBaobabType(t.TransferableIn)
+	case *stakeable.LockOut:
+		return verifyPreBaobabType(t.TransferableOut)
+	case *stakeable.VestingIn, *stakeable.VestingOut,
+		*htlcfx.TransferInput, *htlcfx.TransferOutput,
+		*secp256k1fx.WeightedTransferOutput, *secp256k1fx.WeightedOutputOwners:
+		return fmt.Errorf("%w: %T", errUnsupportedBeforeBaobab, t)
+	default:
+		return nil
+	}
+}
+
+// getOwnerID returns the hash of the owners of [out]
+func getOwnerID(out interface{}) (ids.ID, error) {
+	owned, ok := out.(fx.Owned)
+	if !ok {
+		return ids.Empty, fmt.Errorf("expected fx.Owned but got %T", out)
+	}
+	owner := owned.Owners()
+	ownerBytes, err := txs.Codec.Marshal(txs.Version, owner)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("couldn't marshal owner: %w", err)
+	}
+	return hashing.ComputeHash256Array(ownerBytes), nil
+}
+
+// addLocked adds [amount] of [assetID], locked until [locktime] and owned by
+// [ownerID], to [locked]
+func addLocked(
+	locked map[ids.ID]map[uint64]map[ids.ID]uint64,
+	assetID ids.ID,
+	locktime uint64,
+	ownerID ids.ID,
+	amount uint64,
+) error {
+	lockedAsset, ok := locked[assetID]
+	if !ok {
+		lockedAsset = make(map[uint64]map[ids.ID]uint64)
+		locked[assetID] = lockedAsset
+	}
+	owners, ok := lockedAsset[locktime]
+	if !ok {
+		owners = make(map[ids.ID]uint64)
+		lockedAsset[locktime] = owners
+	}
+	newAmount, err := math.Add64(owners[ownerID], amount)
+	if err != nil {
+		return err
+	}
+	owners[ownerID] = newAmount
+	return nil
+}
+
+func equalTranches(a, b []stakeable.Tranche) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, tranche := range a {
+		if tranche != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -8,13 +8,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/kukrer/savannahnode/database/memdb"
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow"
 	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/utils/hashing"
 	"github.com/kukrer/savannahnode/utils/timer/mockable"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
+	"github.com/kukrer/savannahnode/vms/platformvm/config"
 	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
@@ -42,6 +47,7 @@ func TestVerifySpendUTXOs(t *testing.T) {
 
 	h := &handler{
 		ctx: snow.DefaultContextTest(),
+		cfg: &config.Config{},
 		clk: &mockable.Clock{},
 		utxosReader: avax.NewUTXOState(
 			memdb.New(),
@@ -60,6 +66,22 @@ func TestVerifySpendUTXOs(t *testing.T) {
 
 	customAssetID := ids.GenerateTestID()
 
+	// 1 of the 6 vesting tokens vested, the others are still locked
+	vestingTranches := []stakeable.Tranche{
+		{
+			Locktime: uint64(now.Unix()) - 1,
+			Amount:   1,
+		},
+		{
+			Locktime: uint64(now.Unix()) + 1,
+			Amount:   2,
+		},
+		{
+			Locktime: uint64(now.Unix()) + 2,
+			Amount:   3,
+		},
+	}
+
 	// Note that setting [chainTimestamp] also set's the handler's clock.
 	// Adjust input/output locktimes accordingly.
 	tests := []struct {
@@ -1088,6 +1110,275 @@ func TestVerifySpendUTXOs(t *testing.T) {
 			producedAmounts: make(map[ids.ID]uint64),
 			shouldErr:       false,
 		},
+		{
+			description: "attempt to consume vesting output as unlocked",
+			utxos: []*avax.UTXO{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: vestingTranches,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 6,
+					},
+				},
+			}},
+			ins: []*avax.TransferableInput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt: 6,
+				},
+			}},
+			outs: []*avax.TransferableOutput{},
+			creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+			},
+			producedAmounts: map[ids.ID]uint64{},
+			shouldErr:       true,
+		},
+		{
+			description: "attempt to modify vesting schedule",
+			utxos: []*avax.UTXO{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: vestingTranches,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 6,
+					},
+				},
+			}},
+			ins: []*avax.TransferableInput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				In: &stakeable.VestingIn{
+					Tranches: []stakeable.Tranche{{
+						Locktime: uint64(now.Unix()),
+						Amount:   6,
+					}},
+					TransferableIn: &secp256k1fx.TransferInput{
+						Amt: 6,
+					},
+				},
+			}},
+			outs: []*avax.TransferableOutput{},
+			creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+			},
+			producedAmounts: map[ids.ID]uint64{},
+			shouldErr:       true,
+		},
+		{
+			description: "vesting input, vested fee, vesting output",
+			utxos: []*avax.UTXO{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: vestingTranches,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 6,
+					},
+				},
+			}},
+			ins: []*avax.TransferableInput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				In: &stakeable.VestingIn{
+					Tranches: vestingTranches,
+					TransferableIn: &secp256k1fx.TransferInput{
+						Amt: 6,
+					},
+				},
+			}},
+			outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: vestingTranches[1:],
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 5,
+					},
+				},
+			}},
+			creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+			},
+			producedAmounts: map[ids.ID]uint64{
+				h.ctx.AVAXAssetID: 1,
+			},
+			shouldErr: false,
+		},
+		{
+			description: "vesting input, fee paid with unvested funds",
+			utxos: []*avax.UTXO{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: vestingTranches,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 6,
+					},
+				},
+			}},
+			ins: []*avax.TransferableInput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				In: &stakeable.VestingIn{
+					Tranches: vestingTranches,
+					TransferableIn: &secp256k1fx.TransferInput{
+						Amt: 6,
+					},
+				},
+			}},
+			outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: []stakeable.Tranche{
+						{
+							Locktime: uint64(now.Unix()) + 1,
+							Amount:   1,
+						},
+						{
+							Locktime: uint64(now.Unix()) + 2,
+							Amount:   3,
+						},
+					},
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 4,
+					},
+				},
+			}},
+			creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+			},
+			producedAmounts: map[ids.ID]uint64{
+				h.ctx.AVAXAssetID: 2,
+			},
+			shouldErr: true,
+		},
+		{
+			description: "vesting input, attempt to unlock tranche early",
+			utxos: []*avax.UTXO{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: vestingTranches,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 6,
+					},
+				},
+			}},
+			ins: []*avax.TransferableInput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				In: &stakeable.VestingIn{
+					Tranches: vestingTranches,
+					TransferableIn: &secp256k1fx.TransferInput{
+						Amt: 6,
+					},
+				},
+			}},
+			outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: []stakeable.Tranche{
+						{
+							Locktime: uint64(now.Unix()) + 1,
+							Amount:   5,
+						},
+					},
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 5,
+					},
+				},
+			}},
+			creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+			},
+			producedAmounts: map[ids.ID]uint64{},
+			shouldErr:       true,
+		},
+		{
+			description: "vesting input, tranches split into locked outputs",
+			utxos: []*avax.UTXO{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: vestingTranches,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 6,
+					},
+				},
+			}},
+			ins: []*avax.TransferableInput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				In: &stakeable.VestingIn{
+					Tranches: vestingTranches,
+					TransferableIn: &secp256k1fx.TransferInput{
+						Amt: 6,
+					},
+				},
+			}},
+			outs: []*avax.TransferableOutput{
+				{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: 1,
+					},
+				},
+				{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					Out: &stakeable.LockOut{
+						Locktime: uint64(now.Unix()) + 1,
+						TransferableOut: &secp256k1fx.TransferOutput{
+							Amt: 2,
+						},
+					},
+				},
+				{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					Out: &stakeable.LockOut{
+						Locktime: uint64(now.Unix()) + 2,
+						TransferableOut: &secp256k1fx.TransferOutput{
+							Amt: 3,
+						},
+					},
+				},
+			},
+			creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+			},
+			producedAmounts: map[ids.ID]uint64{},
+			shouldErr:       false,
+		},
+		{
+			description: "fully vested input consumed as unlocked",
+			utxos: []*avax.UTXO{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.VestingOut{
+					Tranches: []stakeable.Tranche{
+						{
+							Locktime: uint64(now.Unix()) - 2,
+							Amount:   1,
+						},
+						{
+							Locktime: uint64(now.Unix()),
+							Amount:   2,
+						},
+					},
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 3,
+					},
+				},
+			}},
+			ins: []*avax.TransferableInput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt: 3,
+				},
+			}},
+			outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 2,
+				},
+			}},
+			creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+			},
+			producedAmounts: map[ids.ID]uint64{
+				h.ctx.AVAXAssetID: 1,
+			},
+			shouldErr: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -1101,6 +1392,7 @@ func TestVerifySpendUTXOs(t *testing.T) {
 				test.outs,
 				test.creds,
 				test.producedAmounts,
+				now,
 			)
 
 			if err == nil && test.shouldErr {
@@ -1111,3 +1403,336 @@ func TestVerifySpendUTXOs(t *testing.T) {
 		})
 	}
 }
+
+func TestSpendVesting(t *testing.T) {
+	require := require.New(t)
+
+	fx := &secp256k1fx.Fx{}
+	require.NoError(fx.InitializeVM(&secp256k1fx.TestVM{}))
+
+	now := time.Unix(1607133207, 0)
+	clk := &mockable.Clock{}
+	clk.Set(now)
+
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+
+	keyIntf, err := (&crypto.FactorySECP256K1R{}).NewPrivateKey()
+	require.NoError(err)
+	key := keyIntf.(*crypto.PrivateKeySECP256K1R)
+	addr := key.PublicKey().Address()
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+
+	// 1000 of the 6000 vesting tokens vested, the others are still locked
+	tranches := []stakeable.Tranche{
+		{
+			Locktime: uint64(now.Unix()) - 1,
+			Amount:   1000,
+		},
+		{
+			Locktime: uint64(now.Unix()) + 1,
+			Amount:   2000,
+		},
+		{
+			Locktime: uint64(now.Unix()) + 2,
+			Amount:   3000,
+		},
+	}
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: ctx.AVAXAssetID},
+		Out: &stakeable.VestingOut{
+			Tranches: tranches,
+			TransferableOut: &secp256k1fx.TransferOutput{
+				Amt:          6000,
+				OutputOwners: owners,
+			},
+		},
+	}
+	utxoState := avax.NewUTXOState(memdb.New(), txs.Codec)
+	require.NoError(utxoState.PutUTXO(utxo))
+
+	h := &handler{
+		ctx:         ctx,
+		cfg:         &config.Config{},
+		clk:         clk,
+		utxosReader: utxoState,
+		fx:          fx,
+	}
+
+	unsignedTx := dummyUnsignedTx{
+		BaseTx: txs.BaseTx{},
+	}
+	unsignedTx.Initialize([]byte{0})
+
+	verifySpend := func(ins []*avax.TransferableInput, outs []*avax.TransferableOutput, signers [][]*crypto.PrivateKeySECP256K1R, fee uint64) {
+		creds := make([]verify.Verifiable, len(signers))
+		for i, inputSigners := range signers {
+			creds[i] = &secp256k1fx.Credential{
+				Sigs: make([][crypto.SECP256K1RSigLen]byte, len(inputSigners)),
+			}
+		}
+		require.NoError(h.VerifySpendUTXOs(
+			&unsignedTx,
+			[]*avax.UTXO{utxo},
+			ins,
+			outs,
+			creds,
+			map[ids.ID]uint64{ctx.AVAXAssetID: fee},
+			now,
+		))
+	}
+
+	// Staking uses the tranches that vest last. The vested tokens pay the fee.
+	ins, returnedOuts, stakedOuts, signers, err := h.Spend(
+		[]*crypto.PrivateKeySECP256K1R{key},
+		4000,
+		500,
+		addr,
+	)
+	require.NoError(err)
+	require.Len(ins, 1)
+	require.IsType(&stakeable.VestingIn{}, ins[0].In)
+	require.Len(stakedOuts, 1)
+	stakedOut := stakedOuts[0].Out.(*stakeable.VestingOut)
+	require.EqualValues(4000, stakedOut.Amount())
+	require.Equal([]stakeable.Tranche{
+		{
+			Locktime: uint64(now.Unix()) + 1,
+			Amount:   1000,
+		},
+		{
+			Locktime: uint64(now.Unix()) + 2,
+			Amount:   3000,
+		},
+	}, stakedOut.Tranches)
+	require.Len(returnedOuts, 2)
+	verifySpend(ins, append(returnedOuts, stakedOuts...), signers, 500)
+
+	// Only the vested tokens can be burned
+	ins, returnedOuts, stakedOuts, signers, err = h.Spend(
+		[]*crypto.PrivateKeySECP256K1R{key},
+		0,
+		500,
+		addr,
+	)
+	require.NoError(err)
+	require.Empty(stakedOuts)
+	require.Len(returnedOuts, 2)
+	verifySpend(ins, returnedOuts, signers, 500)
+
+	_, _, _, _, err = h.Spend(
+		[]*crypto.PrivateKeySECP256K1R{key},
+		0,
+		1001,
+		addr,
+	)
+	require.Error(err)
+}
+
+func TestVerifySpendHTLCUsesChainTime(t *testing.T) {
+	require := require.New(t)
+
+	fx := &htlcfx.Fx{}
+	require.NoError(fx.InitializeVM(&secp256k1fx.TestVM{}))
+
+	deadline := time.Unix(1607133207, 0)
+	// The local clock is past the deadline, which must not matter
+	clk := &mockable.Clock{}
+	clk.Set(deadline.Add(time.Hour))
+
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+
+	preimage := []byte("secret")
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: ctx.AVAXAssetID},
+		Out: &htlcfx.TransferOutput{
+			Amt:      1000,
+			Hash:     hashing.ComputeHash256Array(preimage),
+			Deadline: uint64(deadline.Unix()),
+			Recipient: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+			Sender: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}
+	ins := []*avax.TransferableInput{{
+		UTXOID: utxo.UTXOID,
+		Asset:  utxo.Asset,
+		In: &htlcfx.TransferInput{
+			Amt:      1000,
+			Preimage: preimage,
+			Input:    secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+	}}
+	creds := []verify.Verifiable{
+		&htlcfx.Credential{
+			Credential: secp256k1fx.Credential{
+				Sigs: make([][crypto.SECP256K1RSigLen]byte, 1),
+			},
+		},
+	}
+
+	h := &handler{
+		ctx: ctx,
+		cfg: &config.Config{},
+		clk: clk,
+		fx:  fx,
+	}
+
+	unsignedTx := dummyUnsignedTx{
+		BaseTx: txs.BaseTx{},
+	}
+	unsignedTx.Initialize([]byte{0})
+
+	verifySpend := func(chainTime time.Time) error {
+		return h.VerifySpendUTXOs(
+			&unsignedTx,
+			[]*avax.UTXO{utxo},
+			ins,
+			nil,
+			creds,
+			map[ids.ID]uint64{ctx.AVAXAssetID: 1000},
+			chainTime,
+		)
+	}
+
+	require.NoError(verifySpend(deadline.Add(-time.Second)))
+	require.Error(verifySpend(deadline))
+}
+
+func TestVerifySpendVestingBeforeBaobab(t *testing.T) {
+	require := require.New(t)
+
+	fx := &secp256k1fx.Fx{}
+	require.NoError(fx.InitializeVM(&secp256k1fx.TestVM{}))
+
+	baobabTime := time.Unix(1607133207, 0)
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          1000,
+			OutputOwners: owners,
+		},
+	}
+	ins := []*avax.TransferableInput{{
+		UTXOID: utxo.UTXOID,
+		Asset:  utxo.Asset,
+		In: &secp256k1fx.TransferInput{
+			Amt:   1000,
+			Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+	}}
+	outs := []*avax.TransferableOutput{{
+		Asset: utxo.Asset,
+		Out: &stakeable.VestingOut{
+			Tranches: []stakeable.Tranche{{
+				Locktime: uint64(baobabTime.Add(time.Hour).Unix()),
+				Amount:   1000,
+			}},
+			TransferableOut: &secp256k1fx.TransferOutput{
+				Amt:          1000,
+				OutputOwners: owners,
+			},
+		},
+	}}
+	creds := []verify.Verifiable{
+		&secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, 1),
+		},
+	}
+
+	clk := &mockable.Clock{}
+	clk.Set(baobabTime)
+	h := &handler{
+		ctx: ctx,
+		cfg: &config.Config{BaobabTime: baobabTime},
+		clk: clk,
+		fx:  fx,
+	}
+
+	unsignedTx := dummyUnsignedTx{
+		BaseTx: txs.BaseTx{},
+	}
+	unsignedTx.Initialize([]byte{0})
+
+	verifySpend := func(chainTime time.Time) error {
+		return h.VerifySpendUTXOs(
+			&unsignedTx,
+			[]*avax.UTXO{utxo},
+			ins,
+			outs,
+			creds,
+			map[ids.ID]uint64{},
+			chainTime,
+		)
+	}
+
+	err := verifySpend(baobabTime.Add(-time.Second))
+	require.ErrorIs(err, errUnsupportedBeforeBaobab)
+	require.NoError(verifySpend(baobabTime))
+}
+
+func TestVerifyPreBaobabTypesHTLC(t *testing.T) {
+	require := require.New(t)
+
+	htlcUTXO := &avax.UTXO{
+		Out: &htlcfx.TransferOutput{},
+	}
+	htlcIn := &avax.TransferableInput{
+		In: &htlcfx.TransferInput{},
+	}
+	lockedHTLCOut := &avax.TransferableOutput{
+		Out: &stakeable.LockOut{
+			TransferableOut: &htlcfx.TransferOutput{},
+		},
+	}
+
+	err := verifyPreBaobabTypes([]*avax.UTXO{htlcUTXO}, nil, nil)
+	require.ErrorIs(err, errUnsupportedBeforeBaobab)
+	err = verifyPreBaobabTypes(nil, []*avax.TransferableInput{htlcIn}, nil)
+	require.ErrorIs(err, errUnsupportedBeforeBaobab)
+	err = verifyPreBaobabTypes(nil, nil, []*avax.TransferableOutput{lockedHTLCOut})
+	require.ErrorIs(err, errUnsupportedBeforeBaobab)
+
+	require.NoError(verifyPreBaobabTypes(
+		[]*avax.UTXO{{Out: &secp256k1fx.TransferOutput{}}},
+		[]*avax.TransferableInput{{In: &secp256k1fx.TransferInput{}}},
+		[]*avax.TransferableOutput{{Out: &stakeable.LockOut{
+			TransferableOut: &secp256k1fx.TransferOutput{},
+		}}},
+	))
+}
+
+func TestVerifyPreBaobabTypesWeighted(t *testing.T) {
+	require := require.New(t)
+
+	err := verifyPreBaobabTypes(nil, nil, []*avax.TransferableOutput{{
+		Out: &secp256k1fx.WeightedTransferOutput{},
+	}})
+	require.ErrorIs(err, errUnsupportedBeforeBaobab)
+
+	err = verifyPreBaobabTypes([]*avax.UTXO{{
+		Out: &stakeable.LockOut{
+			TransferableOut: &secp256k1fx.WeightedTransferOutput{},
+		},
+	}}, nil, nil)
+	require.ErrorIs(err, errUnsupportedBeforeBaobab)
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"time"
 
+	stdjson "encoding/json"
+
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -19,6 +21,8 @@ import (
 	"github.com/kukrer/savannahnode/codec/linearcodec"
 	"github.com/kukrer/savannahnode/database"
 	"github.com/kukrer/savannahnode/database/manager"
+	"github.com/kukrer/savannahnode/database/prefixdb"
+	"github.com/kukrer/savannahnode/database/versiondb"
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow"
 	"github.com/kukrer/savannahnode/snow/consensus/snowman"
@@ -28,6 +32,7 @@ import (
 	"github.com/kukrer/savannahnode/snow/validators"
 	"github.com/kukrer/savannahnode/utils"
 	"github.com/kukrer/savannahnode/utils/constants"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
 	"github.com/kukrer/savannahnode/utils/json"
 	"github.com/kukrer/savannahnode/utils/logging"
 	"github.com/kukrer/savannahnode/utils/math"
@@ -36,12 +41,18 @@ import (
 	"github.com/kukrer/savannahnode/utils/wrappers"
 	"github.com/kukrer/savannahnode/version"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/components/index"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/platformvm/api"
 	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
+	"github.com/kukrer/savannahnode/vms/platformvm/config"
 	"github.com/kukrer/savannahnode/vms/platformvm/fx"
+	"github.com/kukrer/savannahnode/vms/platformvm/message"
 	"github.com/kukrer/savannahnode/vms/platformvm/metrics"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakinghistory"
 	"github.com/kukrer/savannahnode/vms/platformvm/state"
+	"github.com/kukrer/savannahnode/vms/platformvm/statesync"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs/mempool"
 	"github.com/kukrer/savannahnode/vms/platformvm/utxo"
@@ -60,12 +71,19 @@ const (
 )
 
 var (
-	_ block.ChainVM    = &VM{}
-	_ secp256k1fx.VM   = &VM{}
-	_ validators.State = &VM{}
+	_ block.ChainVM         = &VM{}
+	_ block.StateSyncableVM = &VM{}
+	_ secp256k1fx.VM        = &VM{}
+	_ validators.State      = &VM{}
+
+	stakingHistoryPrefix    = []byte("stakingHistory")
+	addressTxsPrefix        = []byte("addressTxs")
+	stateSyncRecordsPrefix  = []byte("stateSyncRecords")
+	stateSyncMetadataPrefix = []byte("stateSyncMetadata")
 
 	errWrongCacheType      = errors.New("unexpectedly cached type")
 	errMissingValidatorSet = errors.New("missing validator set")
+	errNotBlockchain       = errors.New("tx is not a blockchain creation tx")
 )
 
 type VM struct {
@@ -103,6 +121,27 @@ type VM struct {
 	txBuilder         txbuilder.Builder
 	txExecutorBackend *txexecutor.Backend
 	manager           blockexecutor.Manager
+
+	// indexDB holds the indexes of the accepted blocks. Its changes are
+	// written along with the state when a block is accepted.
+	indexDB           *versiondb.Database
+	stakingHistory    stakinghistory.Indexer
+	addressTxsIndexer index.AddressTxsIndexer
+
+	toEngine chan<- common.Message
+
+	// stateSyncRecords holds the records of the last state summary, or of the
+	// summary being synced
+	stateSyncRecords       database.Database
+	stateSyncServer        *statesync.Server
+	stateSyncServerEnabled bool
+	// stateSyncer is nil if state sync is disabled
+	stateSyncer         *statesync.Syncer
+	stateSyncMsgHandler message.Handler
+
+	// pruningRetention is the number of heights that aren't pruned, or 0 if
+	// pruning is disabled
+	pruningRetention uint64
 }
 
 // Initialize this blockchain.
@@ -119,6 +158,16 @@ func (vm *VM) Initialize(
 ) error {
 	ctx.Log.Verbo("initializing platform chain")
 
+	chainConfig := config.ChainConfig{}
+	if len(configBytes) > 0 {
+		if err := stdjson.Unmarshal(configBytes, &chainConfig); err != nil {
+			return err
+		}
+		ctx.Log.Info("VM config initialized",
+			zap.Reflect("config", chainConfig),
+		)
+	}
+
 	registerer := prometheus.NewRegistry()
 	if err := ctx.Metrics.Register(registerer); err != nil {
 		return err
@@ -133,9 +182,10 @@ func (vm *VM) Initialize(
 
 	vm.ctx = ctx
 	vm.dbManager = dbManager
+	vm.toEngine = toEngine
 
 	vm.codecRegistry = linearcodec.NewDefault()
-	vm.fx = &secp256k1fx.Fx{}
+	vm.fx = &htlcfx.Fx{}
 	if err := vm.fx.Initialize(vm); err != nil {
 		return err
 	}
@@ -164,7 +214,7 @@ func (vm *VM) Initialize(
 	}
 
 	vm.atomicUtxosManager = avax.NewAtomicUTXOManager(ctx.SharedMemory, txs.Codec)
-	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.state, vm.fx)
+	utxoHandler := utxo.NewHandler(vm.ctx, &vm.Config, &vm.clock, vm.state, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &ctx.Lock, vm.uptimeManager)
 
@@ -185,7 +235,6 @@ func (vm *VM) Initialize(
 		Fx:           vm.fx,
 		FlowChecker:  utxoHandler,
 		Uptimes:      vm.uptimeManager,
-		Rewards:      rewards,
 		Bootstrapped: &vm.bootstrapped,
 	}
 
@@ -196,12 +245,99 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
 
+	vm.indexDB = versiondb.New(vm.dbManager.Current().Database)
+
+	// use no op impl when disabled in config
+	if chainConfig.IndexStakingHistory {
+		vm.ctx.Log.Info("staking history indexing is enabled")
+		vm.stakingHistory = stakinghistory.NewIndexer(
+			prefixdb.New(stakingHistoryPrefix, vm.indexDB),
+		)
+	} else {
+		vm.ctx.Log.Info("staking history indexing is disabled")
+		vm.stakingHistory = stakinghistory.NewNoIndexer()
+	}
+
+	// Only an enabled address indexer is passed to the block manager, so
+	// that accepting blocks doesn't look up the affected UTXOs needlessly.
+	var blkAddressTxsIndexer index.AddressTxsIndexer
+	addressTxsDB := prefixdb.New(addressTxsPrefix, vm.indexDB)
+	if chainConfig.IndexTransactions {
+		vm.ctx.Log.Info("address transaction indexing is enabled")
+		vm.addressTxsIndexer, err = index.NewIndexer(addressTxsDB, vm.ctx.Log, "", registerer, chainConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize address transaction indexer: %w", err)
+		}
+		blkAddressTxsIndexer = vm.addressTxsIndexer
+	} else {
+		vm.ctx.Log.Info("address transaction indexing is disabled")
+		vm.addressTxsIndexer, err = index.NewNoIndexer(addressTxsDB, chainConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize disabled indexer: %w", err)
+		}
+	}
+	if err := vm.indexDB.Commit(); err != nil {
+		return fmt.Errorf("failed to commit indexer status: %w", err)
+	}
+
+	vm.stateSyncRecords = prefixdb.New(stateSyncRecordsPrefix, vm.dbManager.Current().Database)
+	stateSyncMetadata := prefixdb.New(stateSyncMetadataPrefix, vm.dbManager.Current().Database)
+	vm.stateSyncServer, err = statesync.NewServer(
+		vm.ctx.Log,
+		appSender,
+		vm.state,
+		vm.stateSyncRecords,
+		stateSyncMetadata,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize state sync server: %w", err)
+	}
+
+	vm.stateSyncServerEnabled = chainConfig.StateSyncServerEnabled
+	if vm.stateSyncServerEnabled {
+		vm.ctx.Log.Info("state sync server is enabled")
+	}
+	if chainConfig.PruningEnabled {
+		vm.pruningRetention = chainConfig.PruningRetention
+		if vm.pruningRetention == 0 {
+			vm.pruningRetention = defaultPruningRetention
+		}
+		if vm.pruningRetention < minPruningRetention {
+			return fmt.Errorf("%w: %d < %d", errPruningRetentionTooLow, vm.pruningRetention, minPruningRetention)
+		}
+		vm.ctx.Log.Info("pruning is enabled",
+			zap.Uint64("retention", vm.pruningRetention),
+		)
+	}
+
+	// The VM only listens to the state commits if it takes state summaries or
+	// prunes.
+	var commitListener blockexecutor.CommitListener
+	if vm.stateSyncServerEnabled || vm.pruningRetention != 0 {
+		commitListener = vm
+	}
+	if chainConfig.StateSyncEnabled {
+		vm.ctx.Log.Info("state sync is enabled")
+		vm.stateSyncer = statesync.NewSyncer(
+			vm.ctx.Log,
+			appSender,
+			vm.stateSyncRecords,
+			stateSyncMetadata,
+			vm.onStateSyncDone,
+		)
+	}
+	vm.stateSyncMsgHandler = statesync.NewHandler(vm.ctx.Log, vm.stateSyncServer, vm.stateSyncer)
+
 	vm.manager = blockexecutor.NewManager(
 		mempool,
 		vm.metrics,
 		vm.state,
 		vm.txExecutorBackend,
 		vm.recentlyAccepted,
+		vm.stakingHistory,
+		blkAddressTxsIndexer,
+		vm.indexDB,
+		commitListener,
 	)
 	vm.Builder = blockbuilder.New(
 		mempool,
@@ -331,6 +467,10 @@ func (vm *VM) Shutdown() error {
 	}
 
 	vm.Builder.Shutdown()
+	vm.stateSyncServer.Shutdown()
+	if vm.stateSyncer != nil {
+		vm.stateSyncer.Shutdown()
+	}
 
 	if vm.bootstrapped.GetValue() {
 		primaryValidatorSet, exist := vm.Validators.GetValidators(constants.PrimaryNetworkID)
@@ -408,11 +548,27 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		return nil, err
 	}
 
-	return map[string]*common.HTTPHandler{
+	handlers := map[string]*common.HTTPHandler{
 		"": {
 			Handler: server,
 		},
-	}, nil
+	}
+	if !vm.AdminAPIEnabled {
+		return handlers, nil
+	}
+
+	adminServer := rpc.NewServer()
+	adminServer.RegisterCodec(json.NewCodec(), "application/json")
+	adminServer.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	adminServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
+	adminServer.RegisterAfterFunc(vm.metrics.AfterRequest)
+	if err := adminServer.RegisterService(&AdminService{vm: vm}, "platform"); err != nil {
+		return nil, err
+	}
+	handlers["/admin"] = &common.HTTPHandler{
+		Handler: adminServer,
+	}
+	return handlers, nil
 }
 
 // CreateStaticHandlers returns a map where:
@@ -435,19 +591,42 @@ func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
 }
 
 func (vm *VM) Connected(vdrID ids.NodeID, _ *version.Application) error {
+	if vm.stateSyncer != nil {
+		vm.stateSyncer.Connected(vdrID)
+	}
 	return vm.uptimeManager.Connect(vdrID)
 }
 
 func (vm *VM) Disconnected(vdrID ids.NodeID) error {
+	if vm.stateSyncer != nil {
+		vm.stateSyncer.Disconnected(vdrID)
+	}
 	if err := vm.uptimeManager.Disconnect(vdrID); err != nil {
 		return err
 	}
 	return vm.state.Commit()
 }
 
+// GetSubnetID returns the subnetID that validates the provided chain.
+func (vm *VM) GetSubnetID(chainID ids.ID) (ids.ID, error) {
+	if chainID == vm.ctx.ChainID {
+		return constants.PrimaryNetworkID, nil
+	}
+
+	chainTx, _, err := vm.state.GetTx(chainID)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("problem retrieving blockchain %q: %w", chainID, err)
+	}
+	chain, ok := chainTx.Unsigned.(*txs.CreateChainTx)
+	if !ok {
+		return ids.Empty, fmt.Errorf("%q is not a blockchain: %w", chainID, errNotBlockchain)
+	}
+	return chain.SubnetID, nil
+}
+
 // GetValidatorSet returns the validator set at the specified height for the
 // provided subnetID.
-func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]uint64, error) {
+func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
 	validatorSetsCache, exists := vm.validatorSetCaches[subnetID]
 	if !exists {
 		validatorSetsCache = &cache.LRU{Size: validatorSetsCacheSize}
@@ -458,7 +637,7 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]ui
 	}
 
 	if validatorSetIntf, ok := validatorSetsCache.Get(height); ok {
-		validatorSet, ok := validatorSetIntf.(map[ids.NodeID]uint64)
+		validatorSet, ok := validatorSetIntf.(map[ids.NodeID]*validators.GetValidatorOutput)
 		if !ok {
 			return nil, errWrongCacheType
 		}
@@ -473,6 +652,11 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]ui
 	if lastAcceptedHeight < height {
 		return nil, database.ErrNotFound
 	}
+	// The validator set at [height] is built from the diffs of the heights
+	// after it.
+	if prunedHeight := vm.state.GetPrunedHeight(); height < prunedHeight {
+		return nil, fmt.Errorf("%w: %d < %d", errPrunedHeight, height, prunedHeight)
+	}
 
 	// get the start time to track metrics
 	startTime := vm.Clock().Time()
@@ -488,6 +672,10 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]ui
 		vdrSet[vdr.ID()] = vdr.Weight()
 	}
 
+	// publicKeys holds the keys of validators that were removed after
+	// [height]. These keys take precedence over the current keys, as the
+	// validator may have re-registered with a different key.
+	publicKeys := make(map[ids.NodeID]*bls.PublicKey)
 	for i := lastAcceptedHeight; i > height; i-- {
 		diffs, err := vm.state.GetValidatorWeightDiffs(i, subnetID)
 		if err != nil {
@@ -516,16 +704,44 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]ui
 				vdrSet[nodeID] = newWeight
 			}
 		}
+
+		pkDiffs, err := vm.state.GetValidatorPublicKeyDiffs(i)
+		if err != nil {
+			return nil, err
+		}
+		for nodeID, pk := range pkDiffs {
+			publicKeys[nodeID] = pk
+		}
+	}
+
+	vdrOutputs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(vdrSet))
+	for nodeID, weight := range vdrSet {
+		pk, ok := publicKeys[nodeID]
+		if !ok {
+			staker, err := vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+			switch err {
+			case nil:
+				pk = staker.PublicKey
+			case database.ErrNotFound:
+			default:
+				return nil, err
+			}
+		}
+		vdrOutputs[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: pk,
+			Weight:    weight,
+		}
 	}
 
 	// cache the validator set
-	validatorSetsCache.Put(height, vdrSet)
+	validatorSetsCache.Put(height, vdrOutputs)
 
 	endTime := vm.Clock().Time()
 	vm.metrics.IncValidatorSetsCreated()
 	vm.metrics.AddValidatorSetsDuration(endTime.Sub(startTime))
 	vm.metrics.AddValidatorSetsHeightDiff(lastAcceptedHeight - height)
-	return vdrSet, nil
+	return vdrOutputs, nil
 }
 
 // GetMinimumHeight returns the height of the most recent block beyond the
@@ -1113,16 +1113,31 @@ func TestValidatorSetAtCacheOverwriteRegression(t *testing.T) {
 	require.NoError(err)
 	require.EqualValues(1, currentHeight)
 
-	expectedValidators1 := map[ids.NodeID]uint64{
-		nodeID0: defaultWeight,
-		nodeID1: defaultWeight,
-		nodeID2: defaultWeight,
-		nodeID3: defaultWeight,
-		nodeID4: defaultWeight,
+	expectedValidators1 := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID0: {
+			NodeID: nodeID0,
+			Weight: defaultWeight,
+		},
+		nodeID1: {
+			NodeID: nodeID1,
+			Weight: defaultWeight,
+		},
+		nodeID2: {
+			NodeID: nodeID2,
+			Weight: defaultWeight,
+		},
+		nodeID3: {
+			NodeID: nodeID3,
+			Weight: defaultWeight,
+		},
+		nodeID4: {
+			NodeID: nodeID4,
+			Weight: defaultWeight,
+		},
 	}
-	validators, err := vm.GetValidatorSet(1, constants.PrimaryNetworkID)
+	vdrSet, err := vm.GetValidatorSet(1, constants.PrimaryNetworkID)
 	require.NoError(err)
-	require.Equal(expectedValidators1, validators)
+	require.Equal(expectedValidators1, vdrSet)
 
 	newValidatorStartTime0 := defaultGenesisTime.Add(txexecutor.SyncBound).Add(1 * time.Second)
 	newValidatorEndTime0 := newValidatorStartTime0.Add(defaultMaxStakingDuration)
@@ -1164,9 +1179,9 @@ func TestValidatorSetAtCacheOverwriteRegression(t *testing.T) {
 	require.EqualValues(3, currentHeight)
 
 	for i := uint64(1); i <= 3; i++ {
-		validators, err = vm.GetValidatorSet(i, constants.PrimaryNetworkID)
+		vdrSet, err = vm.GetValidatorSet(i, constants.PrimaryNetworkID)
 		require.NoError(err)
-		require.Equal(expectedValidators1, validators)
+		require.Equal(expectedValidators1, vdrSet)
 	}
 
 	// Create the tx that moves the first new validator from the pending
@@ -1198,22 +1213,40 @@ func TestValidatorSetAtCacheOverwriteRegression(t *testing.T) {
 	require.EqualValues(5, currentHeight)
 
 	for i := uint64(1); i <= 4; i++ {
-		validators, err = vm.GetValidatorSet(i, constants.PrimaryNetworkID)
+		vdrSet, err = vm.GetValidatorSet(i, constants.PrimaryNetworkID)
 		require.NoError(err)
-		require.Equal(expectedValidators1, validators)
+		require.Equal(expectedValidators1, vdrSet)
 	}
 
-	expectedValidators2 := map[ids.NodeID]uint64{
-		nodeID0: defaultWeight,
-		nodeID1: defaultWeight,
-		nodeID2: defaultWeight,
-		nodeID3: defaultWeight,
-		nodeID4: defaultWeight,
-		nodeID5: vm.MaxValidatorStake,
+	expectedValidators2 := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID0: {
+			NodeID: nodeID0,
+			Weight: defaultWeight,
+		},
+		nodeID1: {
+			NodeID: nodeID1,
+			Weight: defaultWeight,
+		},
+		nodeID2: {
+			NodeID: nodeID2,
+			Weight: defaultWeight,
+		},
+		nodeID3: {
+			NodeID: nodeID3,
+			Weight: defaultWeight,
+		},
+		nodeID4: {
+			NodeID: nodeID4,
+			Weight: defaultWeight,
+		},
+		nodeID5: {
+			NodeID: nodeID5,
+			Weight: vm.MaxValidatorStake,
+		},
 	}
-	validators, err = vm.GetValidatorSet(5, constants.PrimaryNetworkID)
+	vdrSet, err = vm.GetValidatorSet(5, constants.PrimaryNetworkID)
 	require.NoError(err)
-	require.Equal(expectedValidators2, validators)
+	require.Equal(expectedValidators2, vdrSet)
 }
 
 func TestAddDelegatorTxAddBeforeRemove(t *testing.T) {
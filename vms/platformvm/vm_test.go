@@ -51,9 +51,11 @@ import (
 	"github.com/kukrer/savannahnode/vms/platformvm/api"
 	"github.com/kukrer/savannahnode/vms/platformvm/blocks"
 	"github.com/kukrer/savannahnode/vms/platformvm/config"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
 	"github.com/kukrer/savannahnode/vms/platformvm/reward"
 	"github.com/kukrer/savannahnode/vms/platformvm/status"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/platformvm/utxo"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 
 	smcon "github.com/kukrer/savannahnode/snow/consensus/snowman"
@@ -61,6 +63,7 @@ import (
 	snowgetter "github.com/kukrer/savannahnode/snow/engine/snowman/getter"
 	timetracker "github.com/kukrer/savannahnode/snow/networking/tracker"
 	blockexecutor "github.com/kukrer/savannahnode/vms/platformvm/blocks/executor"
+	txbuilder "github.com/kukrer/savannahnode/vms/platformvm/txs/builder"
 	txexecutor "github.com/kukrer/savannahnode/vms/platformvm/txs/executor"
 )
 
@@ -312,6 +315,11 @@ func BuildGenesisTestWithArgs(t *testing.T, args *api.BuildGenesisArgs) (*api.Bu
 }
 
 func defaultVM() (*VM, database.Database, *mutableSharedMemory) {
+	return defaultVMWithChainConfig(nil)
+}
+
+// defaultVMWithChainConfig is defaultVM with the chain config [configBytes]
+func defaultVMWithChainConfig(configBytes []byte) (*VM, database.Database, *mutableSharedMemory) {
 	vm := &VM{Factory: Factory{
 		Config: config.Config{
 			Chains:                 chains.MockManager{},
@@ -352,7 +360,7 @@ func defaultVM() (*VM, database.Database, *mutableSharedMemory) {
 	appSender.CantSendAppGossip = true
 	appSender.SendAppGossipF = func([]byte) error { return nil }
 
-	if err := vm.Initialize(ctx, chainDBManager, genesisBytes, nil, nil, msgChan, nil, appSender); err != nil {
+	if err := vm.Initialize(ctx, chainDBManager, genesisBytes, nil, configBytes, msgChan, nil, appSender); err != nil {
 		panic(err)
 	}
 	if err := vm.SetState(snow.NormalOp); err != nil {
@@ -1268,6 +1276,62 @@ func TestCreateChain(t *testing.T) {
 // 2) Add a validator to the subnet's pending validator set
 // 3) Advance timestamp to validator's start time (moving the validator from pending to current)
 // 4) Advance timestamp to validator's end time (removing validator from current)
+func TestDynamicFees(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	initialBaseFee := uint64(10)
+	vm.Config.DynamicFees = fees.Config{
+		BytesWeight:              1,
+		InputsWeight:             1_000,
+		SignaturesWeight:         1_000,
+		MinBaseFee:               1,
+		TargetGasPerBlock:        1,
+		BaseFeeChangeDenominator: 8,
+	}
+	vm.state.SetBaseFee(initialBaseFee)
+
+	owners := []ids.ShortID{keys[0].PublicKey().Address()}
+	payer := []*crypto.PrivateKeySECP256K1R{keys[0]}
+	changeAddr := keys[0].PublicKey().Address()
+
+	// [vm.txBuilder] was created before the dynamic fees were configured, so
+	// it only burns the fixed fee.
+	underpricedTx, err := vm.txBuilder.NewCreateSubnetTx(1, owners, payer, changeAddr)
+	require.NoError(err)
+	require.Error(vm.Builder.AddUnverifiedTx(underpricedTx))
+
+	vm.txBuilder = txbuilder.New(
+		vm.ctx,
+		vm.Config,
+		&vm.clock,
+		vm.fx,
+		vm.state,
+		vm.atomicUtxosManager,
+		utxo.NewHandler(vm.ctx, &vm.Config, &vm.clock, vm.state, vm.fx),
+	)
+	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(1, owners, payer, changeAddr)
+	require.NoError(err)
+	require.NoError(vm.Builder.AddUnverifiedTx(createSubnetTx))
+
+	blk, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.NoError(blk.Accept())
+
+	_, txStatus, err := vm.state.GetTx(createSubnetTx.ID())
+	require.NoError(err)
+	require.Equal(status.Committed, txStatus)
+
+	// The block consumed more than the target gas, so the base fee increased.
+	require.Greater(vm.state.GetBaseFee(), initialBaseFee)
+}
+
 func TestCreateSubnet(t *testing.T) {
 	require := require.New(t)
 	vm, _, _ := defaultVM()
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/validators"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+)
+
+var errNoSignatures = errors.New("no signatures collected")
+
+// SignatureGetter fetches the signature of a Warp message from a peer.
+type SignatureGetter interface {
+	// GetSignature attempts to fetch the BLS signature of [msg] from
+	// [nodeID]. It blocks until the signature is received, the request fails,
+	// or [ctx] is cancelled.
+	GetSignature(ctx context.Context, nodeID ids.NodeID, msg *UnsignedMessage) (*bls.Signature, error)
+}
+
+// Aggregator collects signatures of Warp messages from the validators of a
+// subnet until a quorum of stake has signed.
+type Aggregator struct {
+	subnetID ids.ID
+	client   SignatureGetter
+	state    validators.State
+}
+
+// NewAggregator returns an Aggregator of signatures from the validators of
+// [subnetID].
+func NewAggregator(
+	subnetID ids.ID,
+	state validators.State,
+	client SignatureGetter,
+) *Aggregator {
+	return &Aggregator{
+		subnetID: subnetID,
+		client:   client,
+		state:    state,
+	}
+}
+
+type signatureFetchResult struct {
+	sig    *bls.Signature
+	index  int
+	weight uint64
+}
+
+// AggregateSignatures requests signatures of [unsignedMessage] from the
+// validators of the subnet at [pChainHeight]. It returns once signatures
+// representing at least [quorumNum]/[quorumDen] of the subnet's weight have
+// been collected, or fails if that is no longer possible.
+func (a *Aggregator) AggregateSignatures(
+	ctx context.Context,
+	unsignedMessage *UnsignedMessage,
+	pChainHeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*Message, error) {
+	vdrs, totalWeight, err := GetCanonicalValidatorSet(a.state, pChainHeight, a.subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// [possibleWeight] is the weight that could still sign the message.
+	// Validators without a BLS key can never sign.
+	possibleWeight, err := SumWeight(vdrs)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyWeight(possibleWeight, totalWeight, quorumNum, quorumDen); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan *signatureFetchResult, len(vdrs))
+	for i, vdr := range vdrs {
+		go func(i int, vdr *Validator) {
+			results <- a.fetchSignature(ctx, i, vdr, unsignedMessage)
+		}(i, vdr)
+	}
+
+	var (
+		signatures    = make([]*bls.Signature, 0, len(vdrs))
+		signerIndices = NewBitSet()
+		signedWeight  uint64
+	)
+	for range vdrs {
+		result := <-results
+		if result.sig == nil {
+			// Fail early if the quorum can no longer be reached.
+			possibleWeight -= result.weight
+			if err := VerifyWeight(possibleWeight, totalWeight, quorumNum, quorumDen); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		signatures = append(signatures, result.sig)
+		signerIndices.Add(result.index)
+		signedWeight += result.weight
+		if VerifyWeight(signedWeight, totalWeight, quorumNum, quorumDen) == nil {
+			break
+		}
+	}
+
+	if err := VerifyWeight(signedWeight, totalWeight, quorumNum, quorumDen); err != nil {
+		return nil, err
+	}
+	if len(signatures) == 0 {
+		return nil, errNoSignatures
+	}
+
+	aggSig, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+	bitSetSignature := &BitSetSignature{
+		Signers: signerIndices.Bytes(),
+	}
+	copy(bitSetSignature.Signature[:], bls.SignatureToBytes(aggSig))
+	return NewMessage(unsignedMessage, bitSetSignature)
+}
+
+// fetchSignature requests the signature of [msg] from the nodes backing
+// [vdr]. Only signatures that are valid for [vdr]'s public key are returned.
+func (a *Aggregator) fetchSignature(
+	ctx context.Context,
+	index int,
+	vdr *Validator,
+	msg *UnsignedMessage,
+) *signatureFetchResult {
+	result := &signatureFetchResult{
+		index:  index,
+		weight: vdr.Weight,
+	}
+	for _, nodeID := range vdr.NodeIDs {
+		sig, err := a.client.GetSignature(ctx, nodeID, msg)
+		if err != nil {
+			continue
+		}
+		if !bls.Verify(vdr.PublicKey, sig, msg.Bytes()) {
+			continue
+		}
+		result.sig = sig
+		return result
+	}
+	return result
+}
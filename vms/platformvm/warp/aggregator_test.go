@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+)
+
+var errTestOffline = errors.New("offline")
+
+var _ SignatureGetter = &testSignatureGetter{}
+
+type testSignatureGetter struct {
+	sks map[ids.NodeID]*bls.SecretKey
+}
+
+func (g *testSignatureGetter) GetSignature(_ context.Context, nodeID ids.NodeID, msg *UnsignedMessage) (*bls.Signature, error) {
+	sk, ok := g.sks[nodeID]
+	if !ok {
+		return nil, errTestOffline
+	}
+	return bls.Sign(sk, msg.Bytes()), nil
+}
+
+func TestAggregatorAggregateSignatures(t *testing.T) {
+	sourceChainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	vdrs := newTestValidators(t, 10, 10, 10)
+	state := newTestState(t, sourceChainID, subnetID, vdrs)
+
+	unsignedMsg, err := NewUnsignedMessage(sourceChainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		online      []int
+		quorumNum   uint64
+		quorumDen   uint64
+		expectedErr error
+	}{
+		{
+			name:      "all online",
+			online:    []int{0, 1, 2},
+			quorumNum: 1,
+			quorumDen: 1,
+		},
+		{
+			name:      "partially online",
+			online:    []int{1, 2},
+			quorumNum: 2,
+			quorumDen: 3,
+		},
+		{
+			name:        "insufficient online",
+			online:      []int{2},
+			quorumNum:   1,
+			quorumDen:   2,
+			expectedErr: ErrInsufficientWeight,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			getter := &testSignatureGetter{
+				sks: make(map[ids.NodeID]*bls.SecretKey),
+			}
+			for _, i := range test.online {
+				getter.sks[vdrs[i].nodeID] = vdrs[i].sk
+			}
+
+			aggregator := NewAggregator(subnetID, state, getter)
+			msg, err := aggregator.AggregateSignatures(
+				context.Background(),
+				unsignedMsg,
+				0,
+				test.quorumNum,
+				test.quorumDen,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			require.NoError(msg.Signature.Verify(&msg.UnsignedMessage, state, 0, test.quorumNum, test.quorumDen))
+
+			parsedMsg, err := ParseMessage(msg.Bytes())
+			require.NoError(err)
+			require.NoError(parsedMsg.Signature.Verify(&parsedMsg.UnsignedMessage, state, 0, test.quorumNum, test.quorumDen))
+		})
+	}
+}
+
+func TestAggregatorRejectsInvalidSignatures(t *testing.T) {
+	require := require.New(t)
+
+	sourceChainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	vdrs := newTestValidators(t, 50, 50)
+	state := newTestState(t, sourceChainID, subnetID, vdrs)
+
+	unsignedMsg, err := NewUnsignedMessage(sourceChainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	// The second validator responds with a signature from the wrong key.
+	getter := &testSignatureGetter{
+		sks: map[ids.NodeID]*bls.SecretKey{
+			vdrs[0].nodeID: vdrs[0].sk,
+			vdrs[1].nodeID: vdrs[0].sk,
+		},
+	}
+
+	aggregator := NewAggregator(subnetID, state, getter)
+	_, err = aggregator.AggregateSignatures(context.Background(), unsignedMsg, 0, 1, 1)
+	require.ErrorIs(err, ErrInsufficientWeight)
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math/big"
+)
+
+// BitSet is a set of non-negative integers backed by a big.Int. Bit i of the
+// serialized form is set if i is in the set.
+type BitSet struct {
+	bits *big.Int
+}
+
+// NewBitSet returns a new BitSet with the provided bits set.
+func NewBitSet(bits ...int) BitSet {
+	b := BitSet{bits: new(big.Int)}
+	for _, bit := range bits {
+		b.Add(bit)
+	}
+	return b
+}
+
+// BitSetFromBytes returns the BitSet whose big-endian representation is
+// [bytes].
+func BitSetFromBytes(bytes []byte) BitSet {
+	return BitSet{bits: new(big.Int).SetBytes(bytes)}
+}
+
+// Add [i] to the set.
+func (b BitSet) Add(i int) { b.bits.SetBit(b.bits, i, 1) }
+
+// Contains returns true if [i] was previously added to the set.
+func (b BitSet) Contains(i int) bool { return b.bits.Bit(i) == 1 }
+
+// Len returns the number of elements in the set.
+func (b BitSet) Len() int {
+	count := 0
+	for _, word := range b.bits.Bits() {
+		for ; word != 0; word &= word - 1 {
+			count++
+		}
+	}
+	return count
+}
+
+// BitLen returns the number of bits required to represent the set, which is
+// one more than the largest element in the set.
+func (b BitSet) BitLen() int { return b.bits.BitLen() }
+
+// Bytes returns the minimal big-endian representation of the set.
+func (b BitSet) Bytes() []byte { return b.bits.Bytes() }
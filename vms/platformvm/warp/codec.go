@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/kukrer/savannahnode/codec"
+	"github.com/kukrer/savannahnode/codec/linearcodec"
+	"github.com/kukrer/savannahnode/utils/units"
+	"github.com/kukrer/savannahnode/utils/wrappers"
+)
+
+const (
+	codecVersion   uint16 = 0
+	maxMessageSize        = 256 * units.KiB
+	maxSliceLen           = maxMessageSize
+)
+
+// Codec does serialization and deserialization for Warp messages
+var c codec.Manager
+
+func init() {
+	c = codec.NewManager(maxMessageSize)
+	lc := linearcodec.NewCustomMaxLength(maxSliceLen)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		lc.RegisterType(&BitSetSignature{}),
+		c.RegisterCodec(codecVersion, lc),
+	)
+	if errs.Errored() {
+		panic(errs.Err)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+)
+
+func TestMessage(t *testing.T) {
+	require := require.New(t)
+
+	unsignedMsg, err := NewUnsignedMessage(
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	msg, err := NewMessage(
+		unsignedMsg,
+		&BitSetSignature{
+			Signers:   []byte{1, 2, 3},
+			Signature: [bls.SignatureLen]byte{4, 5, 6},
+		},
+	)
+	require.NoError(err)
+
+	msgBytes := msg.Bytes()
+	msg2, err := ParseMessage(msgBytes)
+	require.NoError(err)
+	require.Equal(msg, msg2)
+}
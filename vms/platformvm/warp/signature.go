@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kukrer/savannahnode/snow/validators"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+)
+
+var (
+	_ Signature = &BitSetSignature{}
+
+	ErrInvalidBitSet      = errors.New("bitset is invalid")
+	ErrInsufficientWeight = errors.New("signature weight is insufficient")
+	ErrInvalidSignature   = errors.New("signature is invalid")
+	ErrParseSignature     = errors.New("failed to parse signature")
+	ErrInvalidQuorum      = errors.New("quorum is invalid")
+)
+
+type Signature interface {
+	// Verify that this signature was signed by at least [quorumNum]/[quorumDen]
+	// of the validators of [msg.SourceChainID] at [pChainHeight].
+	//
+	// Invariant: [msg] is correctly initialized.
+	Verify(
+		msg *UnsignedMessage,
+		pChainState validators.State,
+		pChainHeight uint64,
+		quorumNum uint64,
+		quorumDen uint64,
+	) error
+}
+
+// BitSetSignature is an aggregated BLS signature of a Warp message. Bit i of
+// [Signers] is set if the i-th canonical validator contributed to
+// [Signature].
+type BitSetSignature struct {
+	// Signers is a big-endian byte slice encoding which validators signed this
+	// message.
+	Signers   []byte                 `serialize:"true"`
+	Signature [bls.SignatureLen]byte `serialize:"true"`
+}
+
+func (s *BitSetSignature) Verify(
+	msg *UnsignedMessage,
+	pChainState validators.State,
+	pChainHeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) error {
+	subnetID, err := pChainState.GetSubnetID(msg.SourceChainID)
+	if err != nil {
+		return err
+	}
+
+	vdrs, totalWeight, err := GetCanonicalValidatorSet(pChainState, pChainHeight, subnetID)
+	if err != nil {
+		return err
+	}
+
+	// Parse signer bit vector
+	//
+	// We assert that the length of [signerIndices.Bytes()] is equal to
+	// [len(s.Signers)] to ensure that [s.Signers] does not have any unnecessary
+	// zero-padding to represent the [BitSet].
+	signerIndices := BitSetFromBytes(s.Signers)
+	if !bytes.Equal(signerIndices.Bytes(), s.Signers) {
+		return ErrInvalidBitSet
+	}
+
+	// Get the validators that (allegedly) signed the message.
+	signers, err := FilterValidators(signerIndices, vdrs)
+	if err != nil {
+		return err
+	}
+
+	// Because [signers] is a subset of [vdrs], this can never error.
+	sigWeight, _ := SumWeight(signers)
+
+	// Make sure the signature's weight is sufficient.
+	if err := VerifyWeight(sigWeight, totalWeight, quorumNum, quorumDen); err != nil {
+		return err
+	}
+
+	// Parse the aggregate signature
+	aggSig, err := bls.SignatureFromBytes(s.Signature[:])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrParseSignature, err)
+	}
+
+	// Create the aggregate public key
+	aggPubKey, err := AggregatePublicKeys(signers)
+	if err != nil {
+		return err
+	}
+
+	// Verify the signature
+	if !bls.Verify(aggPubKey, aggSig, msg.Bytes()) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyWeight returns [nil] if [sigWeight] is at least [quorumNum]/[quorumDen]
+// of [totalWeight].
+// If [sigWeight >= totalWeight * quorumNum / quorumDen] then return [nil]
+func VerifyWeight(
+	sigWeight uint64,
+	totalWeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) error {
+	if quorumDen == 0 || quorumNum > quorumDen {
+		return fmt.Errorf("%w: %d/%d", ErrInvalidQuorum, quorumNum, quorumDen)
+	}
+
+	// Verifies that quorumNum * totalWeight <= quorumDen * sigWeight
+	scaledTotalWeight := new(big.Int).SetUint64(totalWeight)
+	scaledTotalWeight.Mul(scaledTotalWeight, new(big.Int).SetUint64(quorumNum))
+	scaledSigWeight := new(big.Int).SetUint64(sigWeight)
+	scaledSigWeight.Mul(scaledSigWeight, new(big.Int).SetUint64(quorumDen))
+	if scaledTotalWeight.Cmp(scaledSigWeight) == 1 {
+		return fmt.Errorf(
+			"%w: %d*%d > %d*%d",
+			ErrInsufficientWeight,
+			quorumNum,
+			totalWeight,
+			quorumDen,
+			sigWeight,
+		)
+	}
+	return nil
+}
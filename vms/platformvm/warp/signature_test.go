@@ -0,0 +1,284 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/validators"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+)
+
+type testValidator struct {
+	nodeID ids.NodeID
+	sk     *bls.SecretKey
+	vdr    *Validator
+}
+
+// newTestValidators returns validators with BLS keys and the provided
+// [weights], sorted in canonical order.
+func newTestValidators(t *testing.T, weights ...uint64) []*testValidator {
+	vdrs := make([]*testValidator, len(weights))
+	for i, weight := range weights {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+
+		nodeID := ids.GenerateTestNodeID()
+		pk := bls.PublicFromSecretKey(sk)
+		vdrs[i] = &testValidator{
+			nodeID: nodeID,
+			sk:     sk,
+			vdr: &Validator{
+				PublicKey:      pk,
+				PublicKeyBytes: bls.PublicKeyToBytes(pk),
+				Weight:         weight,
+				NodeIDs:        []ids.NodeID{nodeID},
+			},
+		}
+	}
+	sort.Slice(vdrs, func(i, j int) bool {
+		return bytes.Compare(vdrs[i].vdr.PublicKeyBytes, vdrs[j].vdr.PublicKeyBytes) < 0
+	})
+	return vdrs
+}
+
+func newTestState(
+	t *testing.T,
+	sourceChainID ids.ID,
+	subnetID ids.ID,
+	vdrs []*testValidator,
+) *validators.TestState {
+	return &validators.TestState{
+		T: t,
+		GetSubnetIDF: func(chainID ids.ID) (ids.ID, error) {
+			require.Equal(t, sourceChainID, chainID)
+			return subnetID, nil
+		},
+		GetValidatorSetF: func(_ uint64, requestedSubnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			require.Equal(t, subnetID, requestedSubnetID)
+			vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(vdrs))
+			for _, vdr := range vdrs {
+				vdrSet[vdr.nodeID] = &validators.GetValidatorOutput{
+					NodeID:    vdr.nodeID,
+					PublicKey: vdr.vdr.PublicKey,
+					Weight:    vdr.vdr.Weight,
+				}
+			}
+			return vdrSet, nil
+		},
+	}
+}
+
+func newBitSetSignature(
+	t *testing.T,
+	msg *UnsignedMessage,
+	vdrs []*testValidator,
+	signers ...int,
+) *BitSetSignature {
+	sigs := make([]*bls.Signature, len(signers))
+	for i, signer := range signers {
+		sigs[i] = bls.Sign(vdrs[signer].sk, msg.Bytes())
+	}
+	aggSig, err := bls.AggregateSignatures(sigs)
+	require.NoError(t, err)
+
+	sig := &BitSetSignature{
+		Signers: NewBitSet(signers...).Bytes(),
+	}
+	copy(sig.Signature[:], bls.SignatureToBytes(aggSig))
+	return sig
+}
+
+func TestBitSetSignatureVerify(t *testing.T) {
+	sourceChainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	vdrs := newTestValidators(t, 10, 10, 10)
+	state := newTestState(t, sourceChainID, subnetID, vdrs)
+
+	msg, err := NewUnsignedMessage(sourceChainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		sig         func() *BitSetSignature
+		quorumNum   uint64
+		quorumDen   uint64
+		expectedErr error
+	}{
+		{
+			name: "all signers",
+			sig: func() *BitSetSignature {
+				return newBitSetSignature(t, msg, vdrs, 0, 1, 2)
+			},
+			quorumNum: 1,
+			quorumDen: 1,
+		},
+		{
+			name: "sufficient weight",
+			sig: func() *BitSetSignature {
+				return newBitSetSignature(t, msg, vdrs, 0, 1)
+			},
+			quorumNum: 2,
+			quorumDen: 3,
+		},
+		{
+			name: "insufficient weight",
+			sig: func() *BitSetSignature {
+				return newBitSetSignature(t, msg, vdrs, 0)
+			},
+			quorumNum:   1,
+			quorumDen:   1,
+			expectedErr: ErrInsufficientWeight,
+		},
+		{
+			name: "invalid quorum",
+			sig: func() *BitSetSignature {
+				return newBitSetSignature(t, msg, vdrs, 0, 1, 2)
+			},
+			quorumNum:   2,
+			quorumDen:   1,
+			expectedErr: ErrInvalidQuorum,
+		},
+		{
+			name: "padded bitset",
+			sig: func() *BitSetSignature {
+				sig := newBitSetSignature(t, msg, vdrs, 0, 1, 2)
+				sig.Signers = append([]byte{0}, sig.Signers...)
+				return sig
+			},
+			quorumNum:   1,
+			quorumDen:   1,
+			expectedErr: ErrInvalidBitSet,
+		},
+		{
+			name: "unknown validator",
+			sig: func() *BitSetSignature {
+				sig := newBitSetSignature(t, msg, vdrs, 0, 1, 2)
+				sig.Signers = NewBitSet(0, 1, 2, 3).Bytes()
+				return sig
+			},
+			quorumNum:   1,
+			quorumDen:   1,
+			expectedErr: ErrUnknownValidator,
+		},
+		{
+			name: "wrong signers",
+			sig: func() *BitSetSignature {
+				sig := newBitSetSignature(t, msg, vdrs, 0, 1)
+				sig.Signers = NewBitSet(0, 2).Bytes()
+				return sig
+			},
+			quorumNum:   1,
+			quorumDen:   2,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name: "unparsable signature",
+			sig: func() *BitSetSignature {
+				return &BitSetSignature{
+					Signers: NewBitSet(0, 1, 2).Bytes(),
+				}
+			},
+			quorumNum:   1,
+			quorumDen:   1,
+			expectedErr: ErrParseSignature,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.sig().Verify(msg, state, 0, test.quorumNum, test.quorumDen)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestGetCanonicalValidatorSetMergesKeys(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	state := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID0: {
+					NodeID:    nodeID0,
+					PublicKey: pk,
+					Weight:    1,
+				},
+				nodeID1: {
+					NodeID:    nodeID1,
+					PublicKey: pk,
+					Weight:    2,
+				},
+				nodeID2: {
+					NodeID: nodeID2,
+					Weight: 4,
+				},
+			}, nil
+		},
+	}
+
+	vdrs, totalWeight, err := GetCanonicalValidatorSet(state, 0, ids.GenerateTestID())
+	require.NoError(err)
+	require.EqualValues(7, totalWeight)
+	require.Len(vdrs, 1)
+	require.EqualValues(3, vdrs[0].Weight)
+	require.ElementsMatch([]ids.NodeID{nodeID0, nodeID1}, vdrs[0].NodeIDs)
+}
+
+func TestVerifyWeight(t *testing.T) {
+	tests := []struct {
+		name        string
+		sigWeight   uint64
+		totalWeight uint64
+		quorumNum   uint64
+		quorumDen   uint64
+		expectedErr error
+	}{
+		{
+			name:        "exact quorum",
+			sigWeight:   67,
+			totalWeight: 100,
+			quorumNum:   67,
+			quorumDen:   100,
+		},
+		{
+			name:        "below quorum",
+			sigWeight:   66,
+			totalWeight: 100,
+			quorumNum:   67,
+			quorumDen:   100,
+			expectedErr: ErrInsufficientWeight,
+		},
+		{
+			name:        "large weights",
+			sigWeight:   1 << 63,
+			totalWeight: 1 << 63,
+			quorumNum:   1 << 63,
+			quorumDen:   1 << 63,
+		},
+		{
+			name:        "zero denominator",
+			quorumDen:   0,
+			expectedErr: ErrInvalidQuorum,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyWeight(test.sigWeight, test.totalWeight, test.quorumNum, test.quorumDen)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+
+	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+)
+
+const signatureCacheSize = 1024
+
+var (
+	_ Signer = &signer{}
+
+	ErrWrongSourceChainID = errors.New("wrong SourceChainID")
+	ErrUnknownMessage     = errors.New("unknown message")
+)
+
+// Signer signs Warp messages on behalf of a chain using the BLS key of this
+// node.
+type Signer interface {
+	// Sign returns the signature of [msg] and records it so that it can be
+	// served to peers.
+	//
+	// Returns an error if [msg.SourceChainID] isn't the chain this signer was
+	// created for.
+	Sign(msg *UnsignedMessage) ([]byte, error)
+
+	// GetSignature returns the signature of a message that was previously
+	// signed with [Sign].
+	//
+	// Returns [ErrUnknownMessage] if the message isn't known.
+	GetSignature(msgID ids.ID) ([]byte, error)
+}
+
+// NewSigner returns a Signer that only signs messages sent from [chainID].
+func NewSigner(sk *bls.SecretKey, chainID ids.ID) Signer {
+	return &signer{
+		sk:         sk,
+		chainID:    chainID,
+		signatures: &cache.LRU{Size: signatureCacheSize},
+	}
+}
+
+type signer struct {
+	sk      *bls.SecretKey
+	chainID ids.ID
+
+	// signatures caches msgID -> signature bytes
+	signatures cache.Cacher
+}
+
+func (s *signer) Sign(msg *UnsignedMessage) ([]byte, error) {
+	if msg.SourceChainID != s.chainID {
+		return nil, ErrWrongSourceChainID
+	}
+
+	sig := bls.Sign(s.sk, msg.Bytes())
+	sigBytes := bls.SignatureToBytes(sig)
+	s.signatures.Put(msg.ID(), sigBytes)
+	return sigBytes, nil
+}
+
+func (s *signer) GetSignature(msgID ids.ID) ([]byte, error) {
+	sigIntf, ok := s.signatures.Get(msgID)
+	if !ok {
+		return nil, ErrUnknownMessage
+	}
+	return sigIntf.([]byte), nil
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+)
+
+func TestSigner(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	chainID := ids.GenerateTestID()
+	s := NewSigner(sk, chainID)
+
+	msg, err := NewUnsignedMessage(chainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	_, err = s.GetSignature(msg.ID())
+	require.ErrorIs(err, ErrUnknownMessage)
+
+	sigBytes, err := s.Sign(msg)
+	require.NoError(err)
+
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	require.NoError(err)
+	require.True(bls.Verify(pk, sig, msg.Bytes()))
+
+	storedSigBytes, err := s.GetSignature(msg.ID())
+	require.NoError(err)
+	require.Equal(sigBytes, storedSigBytes)
+}
+
+func TestSignerWrongChainID(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	s := NewSigner(sk, ids.GenerateTestID())
+
+	msg, err := NewUnsignedMessage(ids.GenerateTestID(), ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	_, err = s.Sign(msg)
+	require.ErrorIs(err, ErrWrongSourceChainID)
+}
@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+)
+
+func TestUnsignedMessage(t *testing.T) {
+	require := require.New(t)
+
+	msg, err := NewUnsignedMessage(
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	msgBytes := msg.Bytes()
+	msg2, err := ParseUnsignedMessage(msgBytes)
+	require.NoError(err)
+	require.Equal(msg, msg2)
+	require.Equal(msg.ID(), msg2.ID())
+}
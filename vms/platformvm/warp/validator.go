@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow/validators"
+	"github.com/kukrer/savannahnode/utils/crypto/bls"
+	"github.com/kukrer/savannahnode/utils/math"
+)
+
+var (
+	_ sort.Interface = validatorsSlice{}
+
+	ErrUnknownValidator = errors.New("unknown validator")
+	ErrWeightOverflow   = errors.New("weight overflowed")
+)
+
+// Validator is a canonical validator that is able to sign Warp messages. If
+// multiple nodes registered the same BLS public key, their weights are merged
+// into a single canonical validator.
+type Validator struct {
+	PublicKey      *bls.PublicKey
+	PublicKeyBytes []byte
+	Weight         uint64
+	NodeIDs        []ids.NodeID
+}
+
+type validatorsSlice []*Validator
+
+func (v validatorsSlice) Len() int { return len(v) }
+
+func (v validatorsSlice) Less(i, j int) bool {
+	return bytes.Compare(v[i].PublicKeyBytes, v[j].PublicKeyBytes) < 0
+}
+
+func (v validatorsSlice) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+
+// GetCanonicalValidatorSet returns the validator set of [subnetID] at
+// [pChainHeight] in a canonical ordering. Also returns the total weight on
+// [subnetID], including the weight of validators without a BLS key.
+func GetCanonicalValidatorSet(
+	pChainState validators.State,
+	pChainHeight uint64,
+	subnetID ids.ID,
+) ([]*Validator, uint64, error) {
+	vdrSet, err := pChainState.GetValidatorSet(pChainHeight, subnetID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch validator set (P-Chain Height: %d, SubnetID: %s): %w", pChainHeight, subnetID, err)
+	}
+
+	var (
+		vdrs        = make(map[string]*Validator, len(vdrSet))
+		totalWeight uint64
+	)
+	for _, vdr := range vdrSet {
+		totalWeight, err = math.Add64(totalWeight, vdr.Weight)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %s", ErrWeightOverflow, err)
+		}
+
+		if vdr.PublicKey == nil {
+			continue
+		}
+
+		pkBytes := bls.PublicKeyToBytes(vdr.PublicKey)
+		uniqueVdr, ok := vdrs[string(pkBytes)]
+		if !ok {
+			uniqueVdr = &Validator{
+				PublicKey:      vdr.PublicKey,
+				PublicKeyBytes: pkBytes,
+			}
+			vdrs[string(pkBytes)] = uniqueVdr
+		}
+
+		// Can't overflow because [totalWeight] didn't overflow
+		uniqueVdr.Weight += vdr.Weight
+		uniqueVdr.NodeIDs = append(uniqueVdr.NodeIDs, vdr.NodeID)
+	}
+
+	// Sort validators by public key
+	vdrList := make(validatorsSlice, 0, len(vdrs))
+	for _, vdr := range vdrs {
+		vdrList = append(vdrList, vdr)
+	}
+	sort.Sort(vdrList)
+	return vdrList, totalWeight, nil
+}
+
+// FilterValidators returns the validators in [vdrs] whose bit is set to 1 in
+// [indices].
+//
+// Returns an error if [indices] references an unknown validator.
+func FilterValidators(
+	indices BitSet,
+	vdrs []*Validator,
+) ([]*Validator, error) {
+	// Verify that all alleged signers exist
+	if indices.BitLen() > len(vdrs) {
+		return nil, fmt.Errorf(
+			"%w: NumIndices (%d) >= NumFilteredValidators (%d)",
+			ErrUnknownValidator,
+			indices.BitLen()-1, // -1 to convert from length to index
+			len(vdrs),
+		)
+	}
+
+	filteredVdrs := make([]*Validator, 0, len(vdrs))
+	for i, vdr := range vdrs {
+		if !indices.Contains(i) {
+			continue
+		}
+
+		filteredVdrs = append(filteredVdrs, vdr)
+	}
+	return filteredVdrs, nil
+}
+
+// SumWeight returns the total weight of the provided validators.
+func SumWeight(vdrs []*Validator) (uint64, error) {
+	var (
+		weight uint64
+		err    error
+	)
+	for _, vdr := range vdrs {
+		weight, err = math.Add64(weight, vdr.Weight)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", ErrWeightOverflow, err)
+		}
+	}
+	return weight, nil
+}
+
+// AggregatePublicKeys returns the public key of the provided validators.
+//
+// Invariant: All of the public keys in [vdrs] are valid.
+func AggregatePublicKeys(vdrs []*Validator) (*bls.PublicKey, error) {
+	pks := make([]*bls.PublicKey, len(vdrs))
+	for i, vdr := range vdrs {
+		pks[i] = vdr.PublicKey
+	}
+	return bls.AggregatePublicKeys(pks)
+}
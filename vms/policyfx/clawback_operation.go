@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"errors"
+
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	errNilClawbackOperation = errors.New("nil clawback operation")
+	errNoClawbackOutputs    = errors.New("clawback operation has no outputs")
+)
+
+// ClawbackOperation recovers frozen UTXOs of the asset into [Outputs]. It
+// consumes the policy output of the asset, which it produces again, along with
+// the recovered UTXOs.
+type ClawbackOperation struct {
+	Input        secp256k1fx.Input             `serialize:"true" json:"input"`
+	PolicyOutput PolicyOutput                  `serialize:"true" json:"policyOutput"`
+	Outputs      []*secp256k1fx.TransferOutput `serialize:"true" json:"outputs"`
+}
+
+func (op *ClawbackOperation) InitCtx(ctx *snow.Context) {
+	op.PolicyOutput.OutputOwners.InitCtx(ctx)
+	for _, out := range op.Outputs {
+		out.OutputOwners.InitCtx(ctx)
+	}
+}
+
+func (op *ClawbackOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *ClawbackOperation) Outs() []verify.State {
+	outs := make([]verify.State, 0, len(op.Outputs)+1)
+	outs = append(outs, &op.PolicyOutput)
+	for _, out := range op.Outputs {
+		outs = append(outs, out)
+	}
+	return outs
+}
+
+func (op *ClawbackOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilClawbackOperation
+	case len(op.Outputs) == 0:
+		return errNoClawbackOutputs
+	}
+
+	if err := verify.All(&op.Input, &op.PolicyOutput); err != nil {
+		return err
+	}
+	for _, out := range op.Outputs {
+		if err := out.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestClawbackOperationVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		op          *ClawbackOperation
+		expectedErr error
+	}{
+		{
+			name:        "nil",
+			op:          nil,
+			expectedErr: errNilClawbackOperation,
+		},
+		{
+			name: "no outputs",
+			op: &ClawbackOperation{
+				PolicyOutput: *newTestPolicyOutput(true),
+			},
+			expectedErr: errNoClawbackOutputs,
+		},
+		{
+			name: "invalid policy output",
+			op: &ClawbackOperation{
+				Outputs: []*secp256k1fx.TransferOutput{newTestTransferOutput(1)},
+			},
+			expectedErr: errNoAuthority,
+		},
+		{
+			name: "valid",
+			op: &ClawbackOperation{
+				Input:        secp256k1fx.Input{SigIndices: []uint32{0}},
+				PolicyOutput: *newTestPolicyOutput(true),
+				Outputs:      []*secp256k1fx.TransferOutput{newTestTransferOutput(1)},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.op.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestClawbackOperationOuts(t *testing.T) {
+	require := require.New(t)
+
+	op := &ClawbackOperation{
+		PolicyOutput: *newTestPolicyOutput(true),
+		Outputs: []*secp256k1fx.TransferOutput{
+			newTestTransferOutput(1),
+			newTestTransferOutput(2),
+		},
+	}
+	outs := op.Outs()
+	require.Len(outs, 3)
+	require.Equal(&op.PolicyOutput, outs[0])
+	require.Equal(op.Outputs[0], outs[1])
+	require.Equal(op.Outputs[1], outs[2])
+}
@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/vms/components/verify"
+)
+
+func TestCredentialState(t *testing.T) {
+	intf := interface{}(&Credential{})
+	_, ok := intf.(verify.State)
+	require.False(t, ok, "shouldn't be marked as state")
+}
@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/vms"
+)
+
+var (
+	_ vms.Factory = &Factory{}
+
+	// ID that this Fx uses when labeled
+	ID = ids.ID{'p', 'o', 'l', 'i', 'c', 'y', 'f', 'x'}
+)
+
+type Factory struct{}
+
+func (f *Factory) New(*snow.Context) (interface{}, error) { return &Fx{}, nil }
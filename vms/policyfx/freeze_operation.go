@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"errors"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	errNilFreezeOperation   = errors.New("nil freeze operation")
+	errNoFreezeTargets      = errors.New("freeze operation has no utxos nor addresses")
+	errAddrsNotSortedUnique = errors.New("addresses not sorted and unique")
+	errWrongOutputType      = errors.New("wrong output type")
+)
+
+// FreezeOperation freezes, or unfreezes if [Freeze] isn't set, the UTXOs it
+// consumes and the funds owned by the provided addresses. Along with the
+// policy output of the asset, it consumes the targeted UTXOs and produces them
+// again unchanged as [Outputs], so that it conflicts with any other tx
+// spending them.
+type FreezeOperation struct {
+	Input        secp256k1fx.Input `serialize:"true" json:"input"`
+	Freeze       bool              `serialize:"true" json:"freeze"`
+	Addrs        []ids.ShortID     `serialize:"true" json:"addresses"`
+	PolicyOutput PolicyOutput      `serialize:"true" json:"policyOutput"`
+	Outputs      []verify.State    `serialize:"true" json:"outputs"`
+}
+
+func (op *FreezeOperation) InitCtx(ctx *snow.Context) {
+	op.PolicyOutput.OutputOwners.InitCtx(ctx)
+	for _, out := range op.Outputs {
+		if out, ok := out.(snow.ContextInitializable); ok {
+			out.InitCtx(ctx)
+		}
+	}
+}
+
+func (op *FreezeOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *FreezeOperation) Outs() []verify.State {
+	outs := make([]verify.State, 0, len(op.Outputs)+1)
+	outs = append(outs, &op.PolicyOutput)
+	return append(outs, op.Outputs...)
+}
+
+func (op *FreezeOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilFreezeOperation
+	case len(op.Outputs) == 0 && len(op.Addrs) == 0:
+		return errNoFreezeTargets
+	case !ids.IsSortedAndUniqueShortIDs(op.Addrs):
+		return errAddrsNotSortedUnique
+	}
+
+	if err := verify.All(&op.Input, &op.PolicyOutput); err != nil {
+		return err
+	}
+	for _, out := range op.Outputs {
+		switch out.(type) {
+		case *secp256k1fx.TransferOutput, *secp256k1fx.WeightedTransferOutput:
+		default:
+			return errWrongOutputType
+		}
+		if err := out.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
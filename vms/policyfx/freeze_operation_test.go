@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestFreezeOperationVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		op          *FreezeOperation
+		shouldErr   bool
+		expectedErr error
+	}{
+		{
+			name:        "nil",
+			op:          nil,
+			expectedErr: errNilFreezeOperation,
+		},
+		{
+			name: "no targets",
+			op: &FreezeOperation{
+				Freeze:       true,
+				PolicyOutput: *newTestPolicyOutput(false),
+			},
+			expectedErr: errNoFreezeTargets,
+		},
+		{
+			name: "unsupported output",
+			op: &FreezeOperation{
+				Freeze:       true,
+				PolicyOutput: *newTestPolicyOutput(false),
+				Outputs:      []verify.State{newTestPolicyOutput(false)},
+			},
+			expectedErr: errWrongOutputType,
+		},
+		{
+			name: "invalid output",
+			op: &FreezeOperation{
+				Freeze:       true,
+				PolicyOutput: *newTestPolicyOutput(false),
+				Outputs:      []verify.State{newTestTransferOutput(0)},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "duplicated addresses",
+			op: &FreezeOperation{
+				Freeze:       true,
+				Addrs:        []ids.ShortID{otherAddr, otherAddr},
+				PolicyOutput: *newTestPolicyOutput(false),
+			},
+			expectedErr: errAddrsNotSortedUnique,
+		},
+		{
+			name: "invalid policy output",
+			op: &FreezeOperation{
+				Freeze:  true,
+				Addrs:   []ids.ShortID{otherAddr},
+				Outputs: []verify.State{newTestTransferOutput(1)},
+			},
+			expectedErr: errNoAuthority,
+		},
+		{
+			name: "valid",
+			op: &FreezeOperation{
+				Input:        secp256k1fx.Input{SigIndices: []uint32{0}},
+				Freeze:       true,
+				Addrs:        []ids.ShortID{otherAddr},
+				PolicyOutput: *newTestPolicyOutput(false),
+				Outputs:      []verify.State{newTestTransferOutput(1)},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.op.Verify()
+			if test.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestFreezeOperationOuts(t *testing.T) {
+	require := require.New(t)
+
+	op := &FreezeOperation{
+		PolicyOutput: *newTestPolicyOutput(false),
+		Outputs:      []verify.State{newTestTransferOutput(1)},
+	}
+	outs := op.Outs()
+	require.Len(outs, 2)
+	require.Equal(&op.PolicyOutput, outs[0])
+	require.Equal(op.Outputs[0], outs[1])
+}
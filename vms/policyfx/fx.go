@@ -0,0 +1,241 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/utils/wrappers"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+const (
+	// FirstTypeID is the type ID of the first type registered by this fx. The
+	// types of this fx are registered after the weighted owners types of the
+	// secp256k1fx, so that they don't depend on which other fxs are enabled.
+	FirstTypeID = 38
+
+	defaultCacheSize = 256
+)
+
+var (
+	errWrongTxType           = errors.New("wrong tx type")
+	errWrongUTXOType         = errors.New("wrong utxo type")
+	errWrongOperationType    = errors.New("wrong operation type")
+	errWrongCredentialType   = errors.New("wrong credential type")
+	errWrongNumberOfUTXOs    = errors.New("wrong number of UTXOs for the operation")
+	errWrongPolicyOutput     = errors.New("wrong policy output provided")
+	errNoPolicyOutput        = errors.New("operation doesn't consume the policy output")
+	errNothingToRecover      = errors.New("clawback operation doesn't consume any funds")
+	errClawbackDisabled      = errors.New("policy doesn't allow clawbacks")
+	errWrongClawbackAmount   = errors.New("clawback outputs don't match the recovered funds")
+	errMultiplePolicyOutputs = errors.New("operation consumes multiple policy outputs")
+	errWrongFreezeOutput     = errors.New("freeze outputs don't match the targeted UTXOs")
+	errCantTransfer          = errors.New("cant transfer with this fx")
+)
+
+// Fx describes the asset policy feature extension. It allows the freeze
+// authority of an asset to freeze, unfreeze and recover funds of the asset.
+type Fx struct{ secp256k1fx.Fx }
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing policy fx")
+
+	fx.SECPFactory = crypto.FactorySECP256K1R{
+		Cache: cache.LRU{Size: defaultCacheSize},
+	}
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&PolicyOutput{}),
+		c.RegisterType(&FreezeOperation{}),
+		c.RegisterType(&ClawbackOperation{}),
+		c.RegisterType(&Credential{}),
+	)
+	return errs.Err
+}
+
+// FirstTypeID returns the type ID the types of this fx must be registered at
+func (*Fx) FirstTypeID() uint32 { return FirstTypeID }
+
+func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.UnsignedTx)
+	if !ok {
+		return errWrongTxType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+
+	switch op := opIntf.(type) {
+	case *FreezeOperation:
+		return fx.VerifyFreezeOperation(tx, op, cred, utxosIntf)
+	case *ClawbackOperation:
+		return fx.VerifyClawbackOperation(tx, op, cred, utxosIntf)
+	default:
+		return errWrongOperationType
+	}
+}
+
+// VerifyFreezeOperation ensures that the freeze authority of the asset assents
+// to the freeze operation, and that the operation produces again exactly the
+// UTXOs it targets. Whether the targeted UTXOs may be consumed is verified by
+// the VM.
+func (fx *Fx) VerifyFreezeOperation(tx secp256k1fx.UnsignedTx, op *FreezeOperation, cred *Credential, utxosIntf []interface{}) error {
+	if err := verify.All(op, cred); err != nil {
+		return err
+	}
+	if len(utxosIntf) != len(op.Outputs)+1 {
+		return errWrongNumberOfUTXOs
+	}
+
+	var (
+		policy  *PolicyOutput
+		targets = 0
+	)
+	for _, utxoIntf := range utxosIntf {
+		if utxo, ok := utxoIntf.(*PolicyOutput); ok {
+			if policy != nil {
+				return errMultiplePolicyOutputs
+			}
+			policy = utxo
+			continue
+		}
+		if targets == len(op.Outputs) {
+			return errNoPolicyOutput
+		}
+		if !equalOutputs(utxoIntf, op.Outputs[targets]) {
+			return fmt.Errorf("%w: output %d", errWrongFreezeOutput, targets)
+		}
+		targets++
+	}
+	if policy == nil {
+		return errNoPolicyOutput
+	}
+
+	if err := policy.Verify(); err != nil {
+		return err
+	}
+	if !policy.Equals(&op.PolicyOutput) {
+		return errWrongPolicyOutput
+	}
+	return fx.VerifyCredentials(tx, &op.Input, &cred.Credential, &policy.OutputOwners)
+}
+
+// VerifyClawbackOperation ensures that the freeze authority of the asset
+// assents to the clawback operation, and that the outputs of the operation
+// hold exactly the recovered funds. Whether the recovered funds are frozen is
+// verified by the VM.
+func (fx *Fx) VerifyClawbackOperation(tx secp256k1fx.UnsignedTx, op *ClawbackOperation, cred *Credential, utxosIntf []interface{}) error {
+	if err := verify.All(op, cred); err != nil {
+		return err
+	}
+
+	var (
+		policy    *PolicyOutput
+		recovered uint64
+		err       error
+	)
+	for _, utxoIntf := range utxosIntf {
+		switch utxo := utxoIntf.(type) {
+		case *PolicyOutput:
+			if policy != nil {
+				return errMultiplePolicyOutputs
+			}
+			policy = utxo
+		case *secp256k1fx.TransferOutput:
+			if err := utxo.Verify(); err != nil {
+				return err
+			}
+			recovered, err = math.Add64(recovered, utxo.Amt)
+			if err != nil {
+				return err
+			}
+		case *secp256k1fx.WeightedTransferOutput:
+			if err := utxo.Verify(); err != nil {
+				return err
+			}
+			recovered, err = math.Add64(recovered, utxo.Amt)
+			if err != nil {
+				return err
+			}
+		default:
+			return errWrongUTXOType
+		}
+	}
+	switch {
+	case policy == nil:
+		return errNoPolicyOutput
+	case len(utxosIntf) == 1:
+		return errNothingToRecover
+	}
+
+	if err := policy.Verify(); err != nil {
+		return err
+	}
+	switch {
+	case !policy.Clawback:
+		return errClawbackDisabled
+	case !policy.Equals(&op.PolicyOutput):
+		return errWrongPolicyOutput
+	}
+
+	produced := uint64(0)
+	for _, out := range op.Outputs {
+		produced, err = math.Add64(produced, out.Amt)
+		if err != nil {
+			return err
+		}
+	}
+	if produced != recovered {
+		return fmt.Errorf("%w: produced %d but recovered %d", errWrongClawbackAmount, produced, recovered)
+	}
+	return fx.VerifyCredentials(tx, &op.Input, &cred.Credential, &policy.OutputOwners)
+}
+
+func (fx *Fx) VerifyTransfer(_, _, _, _ interface{}) error { return errCantTransfer }
+
+// equalOutputs returns true if the UTXO output [utxo] is the same output as
+// [out]
+func equalOutputs(utxo interface{}, out verify.State) bool {
+	switch utxo := utxo.(type) {
+	case *secp256k1fx.TransferOutput:
+		out, ok := out.(*secp256k1fx.TransferOutput)
+		return ok && utxo.Amt == out.Amt && utxo.OutputOwners.Equals(&out.OutputOwners)
+	case *secp256k1fx.WeightedTransferOutput:
+		out, ok := out.(*secp256k1fx.WeightedTransferOutput)
+		if !ok ||
+			utxo.Amt != out.Amt ||
+			utxo.Locktime != out.Locktime ||
+			utxo.Threshold != out.Threshold ||
+			len(utxo.Addrs) != len(out.Addrs) ||
+			len(utxo.Weights) != len(out.Weights) {
+			return false
+		}
+		for i, addr := range utxo.Addrs {
+			if addr != out.Addrs[i] {
+				return false
+			}
+		}
+		for i, weight := range utxo.Weights {
+			if weight != out.Weights[i] {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
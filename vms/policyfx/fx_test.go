@@ -0,0 +1,372 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/codec/linearcodec"
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/utils/crypto"
+	"github.com/kukrer/savannahnode/utils/hashing"
+	"github.com/kukrer/savannahnode/utils/logging"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	sigBytes = [crypto.SECP256K1RSigLen]byte{
+		0x0e, 0x33, 0x4e, 0xbc, 0x67, 0xa7, 0x3f, 0xe8,
+		0x24, 0x33, 0xac, 0xa3, 0x47, 0x88, 0xa6, 0x3d,
+		0x58, 0xe5, 0x8e, 0xf0, 0x3a, 0xd5, 0x84, 0xf1,
+		0xbc, 0xa3, 0xb2, 0xd2, 0x5d, 0x51, 0xd6, 0x9b,
+		0x0f, 0x28, 0x5d, 0xcd, 0x3f, 0x71, 0x17, 0x0a,
+		0xf9, 0xbf, 0x2d, 0xb1, 0x10, 0x26, 0x5c, 0xe9,
+		0xdc, 0xc3, 0x9d, 0x7a, 0x01, 0x50, 0x9d, 0xe8,
+		0x35, 0xbd, 0xcb, 0x29, 0x3a, 0xd1, 0x49, 0x32,
+		0x00,
+	}
+	addr = [hashing.AddrLen]byte{
+		0x01, 0x5c, 0xce, 0x6c, 0x55, 0xd6, 0xb5, 0x09,
+		0x84, 0x5c, 0x8c, 0x4e, 0x30, 0xbe, 0xd9, 0x8d,
+		0x39, 0x1a, 0xe7, 0xf0,
+	}
+	otherAddr = ids.ShortID{1}
+)
+
+func newTestFx(t *testing.T) *Fx {
+	vm := &secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := &Fx{}
+	require.NoError(t, fx.Initialize(vm))
+	require.NoError(t, fx.Bootstrapped())
+	return fx
+}
+
+// newTestPolicyOutput returns a policy whose freeze authority is [addr]
+func newTestPolicyOutput(clawback bool) *PolicyOutput {
+	return &PolicyOutput{
+		Clawback: clawback,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		},
+	}
+}
+
+func newTestTransferOutput(amt uint64) *secp256k1fx.TransferOutput {
+	return &secp256k1fx.TransferOutput{
+		Amt: amt,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{otherAddr},
+		},
+	}
+}
+
+func newTestCredential() *Credential {
+	return &Credential{Credential: secp256k1fx.Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{sigBytes},
+	}}
+}
+
+func TestFxInitialize(t *testing.T) {
+	newTestFx(t)
+}
+
+func TestFxInitializeInvalid(t *testing.T) {
+	fx := Fx{}
+	require.Error(t, fx.Initialize(nil))
+}
+
+func TestFxVerifyFreezeOperation(t *testing.T) {
+	newOp := func(policy *PolicyOutput) *FreezeOperation {
+		return &FreezeOperation{
+			Input:        secp256k1fx.Input{SigIndices: []uint32{0}},
+			Freeze:       true,
+			PolicyOutput: *policy,
+			Outputs:      []verify.State{newTestTransferOutput(1)},
+		}
+	}
+	otherAuthority := newTestPolicyOutput(false)
+	otherAuthority.Addrs = []ids.ShortID{otherAddr}
+
+	tests := []struct {
+		name  string
+		tx    interface{}
+		op    interface{}
+		cred  interface{}
+		utxos []interface{}
+		// shouldErr is set when the error is returned by the secp256k1fx,
+		// whose errors aren't exported
+		shouldErr   bool
+		expectedErr error
+	}{
+		{
+			name:  "valid",
+			tx:    &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:    newOp(newTestPolicyOutput(false)),
+			cred:  newTestCredential(),
+			utxos: []interface{}{newTestTransferOutput(1), newTestPolicyOutput(false)},
+		},
+		{
+			name:        "wrong tx type",
+			tx:          nil,
+			op:          newOp(newTestPolicyOutput(false)),
+			cred:        newTestCredential(),
+			utxos:       []interface{}{newTestPolicyOutput(false), newTestTransferOutput(1)},
+			expectedErr: errWrongTxType,
+		},
+		{
+			name:        "wrong credential type",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(newTestPolicyOutput(false)),
+			cred:        &secp256k1fx.Credential{},
+			utxos:       []interface{}{newTestPolicyOutput(false), newTestTransferOutput(1)},
+			expectedErr: errWrongCredentialType,
+		},
+		{
+			name:        "wrong operation type",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          &secp256k1fx.MintOperation{},
+			cred:        newTestCredential(),
+			utxos:       []interface{}{newTestPolicyOutput(false), newTestTransferOutput(1)},
+			expectedErr: errWrongOperationType,
+		},
+		{
+			name:        "wrong number of utxos",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(newTestPolicyOutput(false)),
+			cred:        newTestCredential(),
+			utxos:       []interface{}{newTestPolicyOutput(false)},
+			expectedErr: errWrongNumberOfUTXOs,
+		},
+		{
+			name:        "no policy output",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(newTestPolicyOutput(false)),
+			cred:        newTestCredential(),
+			utxos:       []interface{}{newTestTransferOutput(1), newTestTransferOutput(1)},
+			expectedErr: errNoPolicyOutput,
+		},
+		{
+			name:        "multiple policy outputs",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(newTestPolicyOutput(false)),
+			cred:        newTestCredential(),
+			utxos:       []interface{}{newTestPolicyOutput(false), newTestPolicyOutput(false)},
+			expectedErr: errMultiplePolicyOutputs,
+		},
+		{
+			name:        "reissued output changed",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(newTestPolicyOutput(false)),
+			cred:        newTestCredential(),
+			utxos:       []interface{}{newTestPolicyOutput(false), newTestTransferOutput(2)},
+			expectedErr: errWrongFreezeOutput,
+		},
+		{
+			name:        "policy changed",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(newTestPolicyOutput(true)),
+			cred:        newTestCredential(),
+			utxos:       []interface{}{newTestPolicyOutput(false), newTestTransferOutput(1)},
+			expectedErr: errWrongPolicyOutput,
+		},
+		{
+			name:      "not signed by the authority",
+			tx:        &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:        newOp(otherAuthority),
+			cred:      newTestCredential(),
+			utxos:     []interface{}{otherAuthority, newTestTransferOutput(1)},
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fx := newTestFx(t)
+			err := fx.VerifyOperation(test.tx, test.op, test.cred, test.utxos)
+			if test.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestFxVerifyClawbackOperation(t *testing.T) {
+	newOp := func(policy *PolicyOutput, amts ...uint64) *ClawbackOperation {
+		op := &ClawbackOperation{
+			Input:        secp256k1fx.Input{SigIndices: []uint32{0}},
+			PolicyOutput: *policy,
+		}
+		for _, amt := range amts {
+			op.Outputs = append(op.Outputs, newTestTransferOutput(amt))
+		}
+		return op
+	}
+
+	tests := []struct {
+		name        string
+		op          *ClawbackOperation
+		utxos       []interface{}
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			op:   newOp(newTestPolicyOutput(true), 1, 2),
+			utxos: []interface{}{
+				newTestTransferOutput(2),
+				newTestPolicyOutput(true),
+				newTestTransferOutput(1),
+			},
+		},
+		{
+			name: "no policy output",
+			op:   newOp(newTestPolicyOutput(true), 1),
+			utxos: []interface{}{
+				newTestTransferOutput(1),
+			},
+			expectedErr: errNoPolicyOutput,
+		},
+		{
+			name: "multiple policy outputs",
+			op:   newOp(newTestPolicyOutput(true), 1),
+			utxos: []interface{}{
+				newTestPolicyOutput(true),
+				newTestPolicyOutput(true),
+				newTestTransferOutput(1),
+			},
+			expectedErr: errMultiplePolicyOutputs,
+		},
+		{
+			name: "nothing to recover",
+			op:   newOp(newTestPolicyOutput(true), 1),
+			utxos: []interface{}{
+				newTestPolicyOutput(true),
+			},
+			expectedErr: errNothingToRecover,
+		},
+		{
+			name: "wrong utxo type",
+			op:   newOp(newTestPolicyOutput(true), 1),
+			utxos: []interface{}{
+				newTestPolicyOutput(true),
+				&secp256k1fx.MintOutput{},
+			},
+			expectedErr: errWrongUTXOType,
+		},
+		{
+			name: "clawback disabled",
+			op:   newOp(newTestPolicyOutput(false), 1),
+			utxos: []interface{}{
+				newTestPolicyOutput(false),
+				newTestTransferOutput(1),
+			},
+			expectedErr: errClawbackDisabled,
+		},
+		{
+			name: "policy changed",
+			op:   newOp(newTestPolicyOutput(false), 1),
+			utxos: []interface{}{
+				newTestPolicyOutput(true),
+				newTestTransferOutput(1),
+			},
+			expectedErr: errWrongPolicyOutput,
+		},
+		{
+			name: "wrong amount",
+			op:   newOp(newTestPolicyOutput(true), 2),
+			utxos: []interface{}{
+				newTestPolicyOutput(true),
+				newTestTransferOutput(1),
+			},
+			expectedErr: errWrongClawbackAmount,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fx := newTestFx(t)
+			tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+			err := fx.VerifyOperation(tx, test.op, newTestCredential(), test.utxos)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestFxVerifyTransfer(t *testing.T) {
+	fx := newTestFx(t)
+	require.ErrorIs(t, fx.VerifyTransfer(nil, nil, nil, nil), errCantTransfer)
+}
+
+func TestEqualOutputs(t *testing.T) {
+	newWeightedOutput := func(weight uint64) *secp256k1fx.WeightedTransferOutput {
+		return &secp256k1fx.WeightedTransferOutput{
+			Amt: 1,
+			WeightedOutputOwners: secp256k1fx.WeightedOutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{otherAddr},
+				Weights:   []uint64{weight},
+			},
+		}
+	}
+	otherOwner := newTestTransferOutput(1)
+	otherOwner.Addrs = []ids.ShortID{addr}
+
+	tests := []struct {
+		name     string
+		utxo     interface{}
+		out      verify.State
+		expected bool
+	}{
+		{
+			name:     "same transfer output",
+			utxo:     newTestTransferOutput(1),
+			out:      newTestTransferOutput(1),
+			expected: true,
+		},
+		{
+			name: "different amount",
+			utxo: newTestTransferOutput(1),
+			out:  newTestTransferOutput(2),
+		},
+		{
+			name: "different owners",
+			utxo: newTestTransferOutput(1),
+			out:  otherOwner,
+		},
+		{
+			name:     "same weighted output",
+			utxo:     newWeightedOutput(1),
+			out:      newWeightedOutput(1),
+			expected: true,
+		},
+		{
+			name: "different weights",
+			utxo: newWeightedOutput(1),
+			out:  newWeightedOutput(2),
+		},
+		{
+			name: "different types",
+			utxo: newWeightedOutput(1),
+			out:  newTestTransferOutput(1),
+		},
+		{
+			name: "unsupported type",
+			utxo: newTestPolicyOutput(false),
+			out:  newTestPolicyOutput(false),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, equalOutputs(test.utxo, test.out))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var (
+	errNilOutput   = errors.New("nil output")
+	errNoAuthority = errors.New("policy has no freeze authority")
+
+	_ verify.State = &PolicyOutput{}
+)
+
+// PolicyOutput designates the freeze authority of an asset. The authority can
+// freeze and unfreeze funds of the asset and, if [Clawback] is set, recover
+// frozen funds.
+type PolicyOutput struct {
+	Clawback bool `serialize:"true" json:"clawback"`
+
+	secp256k1fx.OutputOwners `serialize:"true"`
+}
+
+// MarshalJSON marshals Clawback and the embedded OutputOwners struct into a
+// JSON readable format
+func (out *PolicyOutput) MarshalJSON() ([]byte, error) {
+	result, err := out.OutputOwners.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	result["clawback"] = out.Clawback
+	return json.Marshal(result)
+}
+
+// Equals returns true if the provided policy designates the same authority
+func (out *PolicyOutput) Equals(other *PolicyOutput) bool {
+	return out.Clawback == other.Clawback && out.OutputOwners.Equals(&other.OutputOwners)
+}
+
+func (out *PolicyOutput) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	case out.Threshold == 0:
+		return errNoAuthority
+	default:
+		return out.OutputOwners.Verify()
+	}
+}
+
+func (out *PolicyOutput) VerifyState() error { return out.Verify() }
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policyfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+func TestPolicyOutputVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		out  *PolicyOutput
+		// shouldErr is set when the error is returned by the secp256k1fx,
+		// whose errors aren't exported
+		shouldErr   bool
+		expectedErr error
+	}{
+		{
+			name:        "nil",
+			out:         nil,
+			expectedErr: errNilOutput,
+		},
+		{
+			name:        "no authority",
+			out:         &PolicyOutput{},
+			expectedErr: errNoAuthority,
+		},
+		{
+			name: "unspendable authority",
+			out: &PolicyOutput{OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr},
+			}},
+			shouldErr: true,
+		},
+		{
+			name: "valid",
+			out: &PolicyOutput{
+				Clawback: true,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.out.Verify()
+			if test.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestPolicyOutputEquals(t *testing.T) {
+	require := require.New(t)
+
+	out := newTestPolicyOutput(true)
+	require.True(out.Equals(newTestPolicyOutput(true)))
+	require.False(out.Equals(newTestPolicyOutput(false)))
+
+	other := newTestPolicyOutput(true)
+	other.Addrs = []ids.ShortID{otherAddr}
+	require.False(out.Equals(other))
+}
+
+func TestPolicyOutputState(t *testing.T) {
+	intf := interface{}(&PolicyOutput{})
+	_, ok := intf.(verify.State)
+	require.True(t, ok)
+}
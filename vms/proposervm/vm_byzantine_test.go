@@ -14,6 +14,7 @@ import (
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/snow/choices"
 	"github.com/kukrer/savannahnode/snow/consensus/snowman"
+	"github.com/kukrer/savannahnode/snow/validators"
 	"github.com/kukrer/savannahnode/vms/proposervm/block"
 	"github.com/kukrer/savannahnode/vms/proposervm/proposer"
 )
@@ -625,9 +626,12 @@ func TestGetBlock_MutatedSignature(t *testing.T) {
 	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0)
 
 	// Make sure that we will be sampled to perform the proposals.
-	valState.GetValidatorSetF = func(height uint64, subnetID ids.ID) (map[ids.NodeID]uint64, error) {
-		res := make(map[ids.NodeID]uint64)
-		res[proVM.ctx.NodeID] = uint64(10)
+	valState.GetValidatorSetF = func(height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+		res := make(map[ids.NodeID]*validators.GetValidatorOutput)
+		res[proVM.ctx.NodeID] = &validators.GetValidatorOutput{
+			NodeID: proVM.ctx.NodeID,
+			Weight: 10,
+		}
 		return res, nil
 	}
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/kukrer/savannahnode/cache"
+	"github.com/kukrer/savannahnode/codec"
 	"github.com/kukrer/savannahnode/utils/crypto"
 	"github.com/kukrer/savannahnode/utils/hashing"
 	"github.com/kukrer/savannahnode/utils/wrappers"
@@ -16,6 +17,12 @@ import (
 
 const (
 	defaultCacheSize = 256
+
+	// WeightedFirstTypeID is the type ID of the first weighted owners type.
+	// The weighted owners types were added after the other fxs were deployed,
+	// so they are registered at a fixed type ID after the types of all the
+	// fxs, rather than with the other types of this fx.
+	WeightedFirstTypeID = 36
 )
 
 var (
@@ -33,6 +40,8 @@ var (
 	errTooFewSigners                  = errors.New("input has less signers than expected")
 	errInputOutputIndexOutOfBounds    = errors.New("input referenced a nonexistent address in the output")
 	errInputCredentialSignersMismatch = errors.New("input expected a different number of signers than provided in the credential")
+	errInsufficientWeight             = errors.New("input signers have less weight than the threshold")
+	errUnnecessarySigner              = errors.New("input has a signer that isn't needed to reach the threshold")
 )
 
 // Fx describes the secp256k1 feature extension
@@ -65,6 +74,17 @@ func (fx *Fx) Initialize(vmIntf interface{}) error {
 	return errs.Err
 }
 
+// RegisterWeightedTypes registers the weighted owners types. They must be
+// registered at WeightedFirstTypeID.
+func RegisterWeightedTypes(c codec.Registry) error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&WeightedTransferOutput{}),
+		c.RegisterType(&WeightedOutputOwners{}),
+	)
+	return errs.Err
+}
+
 func (fx *Fx) InitializeVM(vmIntf interface{}) error {
 	vm, ok := vmIntf.(VM)
 	if !ok {
@@ -92,14 +112,20 @@ func (fx *Fx) VerifyPermission(txIntf, inIntf, credIntf, ownerIntf interface{})
 	if !ok {
 		return errWrongCredentialType
 	}
-	owner, ok := ownerIntf.(*OutputOwners)
-	if !ok {
+	switch owner := ownerIntf.(type) {
+	case *OutputOwners:
+		if err := verify.All(in, cred, owner); err != nil {
+			return err
+		}
+		return fx.VerifyCredentials(tx, in, cred, owner)
+	case *WeightedOutputOwners:
+		if err := verify.All(in, cred, owner); err != nil {
+			return err
+		}
+		return fx.VerifyWeightedCredentials(tx, in, cred, owner)
+	default:
 		return errWrongOwnerType
 	}
-	if err := verify.All(in, cred, owner); err != nil {
-		return err
-	}
-	return fx.VerifyCredentials(tx, in, cred, owner)
 }
 
 func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []interface{}) error {
@@ -148,22 +174,30 @@ func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) err
 	if !ok {
 		return errWrongCredentialType
 	}
-	out, ok := utxoIntf.(*TransferOutput)
-	if !ok {
-		return errWrongUTXOType
-	}
-	return fx.VerifySpend(tx, in, cred, out)
+	return fx.VerifySpend(tx, in, cred, utxoIntf)
 }
 
-// VerifySpend ensures that the utxo can be sent to any address
-func (fx *Fx) VerifySpend(utx UnsignedTx, in *TransferInput, cred *Credential, utxo *TransferOutput) error {
-	if err := verify.All(utxo, in, cred); err != nil {
-		return err
-	} else if utxo.Amt != in.Amt {
-		return fmt.Errorf("utxo amount and input amount should be same but are %d and %d", utxo.Amt, in.Amt)
+// VerifySpend ensures that the utxo, which must be a TransferOutput or a
+// WeightedTransferOutput, can be sent to any address
+func (fx *Fx) VerifySpend(utx UnsignedTx, in *TransferInput, cred *Credential, utxoIntf interface{}) error {
+	switch utxo := utxoIntf.(type) {
+	case *TransferOutput:
+		if err := verify.All(utxo, in, cred); err != nil {
+			return err
+		} else if utxo.Amt != in.Amt {
+			return fmt.Errorf("utxo amount and input amount should be same but are %d and %d", utxo.Amt, in.Amt)
+		}
+		return fx.VerifyCredentials(utx, &in.Input, cred, &utxo.OutputOwners)
+	case *WeightedTransferOutput:
+		if err := verify.All(utxo, in, cred); err != nil {
+			return err
+		} else if utxo.Amt != in.Amt {
+			return fmt.Errorf("utxo amount and input amount should be same but are %d and %d", utxo.Amt, in.Amt)
+		}
+		return fx.VerifyWeightedCredentials(utx, &in.Input, cred, &utxo.WeightedOutputOwners)
+	default:
+		return errWrongUTXOType
 	}
-
-	return fx.VerifyCredentials(utx, &in.Input, cred, &utxo.OutputOwners)
 }
 
 // VerifyCredentials ensures that the output can be spent by the input with the
@@ -206,18 +240,83 @@ func (fx *Fx) VerifyCredentials(utx UnsignedTx, in *Input, cred *Credential, out
 	return nil
 }
 
+// VerifyWeightedCredentials ensures that the output can be spent by the input
+// with the credential. The signers must reach the threshold of the output, and
+// each signer must be needed to reach it. A nil return values means the output
+// can be spent.
+func (fx *Fx) VerifyWeightedCredentials(utx UnsignedTx, in *Input, cred *Credential, out *WeightedOutputOwners) error {
+	numSigs := len(in.SigIndices)
+	switch {
+	case out.Locktime > fx.VM.Clock().Unix():
+		return errTimelocked
+	case numSigs != len(cred.Sigs):
+		return errInputCredentialSignersMismatch
+	}
+
+	// The weights can't overflow because the total weight of the output was
+	// verified, and the signature indices are unique.
+	weight := uint64(0)
+	minWeight := uint64(0)
+	for i, index := range in.SigIndices {
+		// Make sure the input references an address that exists
+		if index >= uint32(len(out.Addrs)) {
+			return errInputOutputIndexOutOfBounds
+		}
+		signerWeight := out.Weights[index]
+		weight += signerWeight
+		if i == 0 || signerWeight < minWeight {
+			minWeight = signerWeight
+		}
+	}
+	switch {
+	case weight < out.Threshold:
+		return errInsufficientWeight
+	case numSigs > 0 && weight-minWeight >= out.Threshold:
+		return errUnnecessarySigner
+	case !fx.bootstrapped: // disable signature verification during bootstrapping
+		return nil
+	}
+
+	txHash := hashing.ComputeHash256(utx.Bytes())
+	for i, index := range in.SigIndices {
+		// Make sure each signature in the signature list is from an owner of
+		// the output being consumed
+		sig := cred.Sigs[i]
+		pk, err := fx.SECPFactory.RecoverHashPublicKey(txHash, sig[:])
+		if err != nil {
+			return err
+		}
+		if expectedAddress := out.Addrs[index]; expectedAddress != pk.Address() {
+			return fmt.Errorf("expected signature from %s but got from %s",
+				expectedAddress,
+				pk.Address())
+		}
+	}
+
+	return nil
+}
+
 // CreateOutput creates a new output with the provided control group worth
 // the specified amount
 func (fx *Fx) CreateOutput(amount uint64, ownerIntf interface{}) (interface{}, error) {
-	owner, ok := ownerIntf.(*OutputOwners)
-	if !ok {
+	switch owner := ownerIntf.(type) {
+	case *OutputOwners:
+		if err := owner.Verify(); err != nil {
+			return nil, err
+		}
+		return &TransferOutput{
+			Amt:          amount,
+			OutputOwners: *owner,
+		}, nil
+	case *WeightedOutputOwners:
+		if err := owner.Verify(); err != nil {
+			return nil, err
+		}
+		return &WeightedTransferOutput{
+			Amt:                  amount,
+			WeightedOutputOwners: *owner,
+		}, nil
+	default:
 		return nil, errWrongOwnerType
 	}
-	if err := owner.Verify(); err != nil {
-		return nil, err
-	}
-	return &TransferOutput{
-		Amt:          amount,
-		OutputOwners: *owner,
-	}, nil
 }
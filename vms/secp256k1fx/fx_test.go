@@ -1044,3 +1044,209 @@ func TestVerifyPermission(t *testing.T) {
 		}
 	}
 }
+
+func TestFxVerifyWeightedTransfer(t *testing.T) {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	date := time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC)
+	vm.CLK.Set(date)
+	fx := Fx{}
+	require.NoError(t, fx.Initialize(&vm))
+	require.NoError(t, fx.Bootstrapping())
+	require.NoError(t, fx.Bootstrapped())
+
+	// [addr] has weight 2 and [addr2] has weight 1
+	newOut := func(locktime uint64, threshold uint64) *WeightedTransferOutput {
+		out := &WeightedTransferOutput{
+			Amt: 1,
+			WeightedOutputOwners: WeightedOutputOwners{
+				Locktime:  locktime,
+				Threshold: threshold,
+				Addrs:     []ids.ShortID{addr, addr2},
+				Weights:   []uint64{2, 1},
+			},
+		}
+		out.Sort()
+		return out
+	}
+	addrIndex, addr2Index := uint32(0), uint32(1)
+	if newOut(0, 1).Addrs[0] != addr {
+		addrIndex, addr2Index = addr2Index, addrIndex
+	}
+	newIn := func(amt uint64, signers ...ids.ShortID) (*TransferInput, *Credential) {
+		in := &TransferInput{Amt: amt}
+		cred := &Credential{}
+		// Signers are expected to be provided in the order of their indices
+		if len(signers) == 2 && addr2Index < addrIndex {
+			signers[0], signers[1] = signers[1], signers[0]
+		}
+		for _, signer := range signers {
+			if signer == addr {
+				in.SigIndices = append(in.SigIndices, addrIndex)
+				cred.Sigs = append(cred.Sigs, sigBytes)
+			} else {
+				in.SigIndices = append(in.SigIndices, addr2Index)
+				cred.Sigs = append(cred.Sigs, sig2Bytes)
+			}
+		}
+		return in, cred
+	}
+
+	tests := []struct {
+		description string
+		out         *WeightedTransferOutput
+		signers     []ids.ShortID
+		amt         uint64
+		shouldErr   bool
+		expectedErr error
+	}{
+		{
+			description: "heavy signer reaches threshold",
+			out:         newOut(0, 2),
+			signers:     []ids.ShortID{addr},
+			amt:         1,
+		},
+		{
+			description: "light signer doesn't reach threshold",
+			out:         newOut(0, 2),
+			signers:     []ids.ShortID{addr2},
+			amt:         1,
+			shouldErr:   true,
+			expectedErr: errInsufficientWeight,
+		},
+		{
+			description: "unnecessary signer",
+			out:         newOut(0, 2),
+			signers:     []ids.ShortID{addr, addr2},
+			amt:         1,
+			shouldErr:   true,
+			expectedErr: errUnnecessarySigner,
+		},
+		{
+			description: "both signers reach threshold",
+			out:         newOut(0, 3),
+			signers:     []ids.ShortID{addr, addr2},
+			amt:         1,
+		},
+		{
+			description: "heavy signer doesn't reach threshold",
+			out:         newOut(0, 3),
+			signers:     []ids.ShortID{addr},
+			amt:         1,
+			shouldErr:   true,
+			expectedErr: errInsufficientWeight,
+		},
+		{
+			description: "timelocked",
+			out:         newOut(uint64(date.Add(time.Second).Unix()), 2),
+			signers:     []ids.ShortID{addr},
+			amt:         1,
+			shouldErr:   true,
+			expectedErr: errTimelocked,
+		},
+		{
+			description: "wrong amount",
+			out:         newOut(0, 2),
+			signers:     []ids.ShortID{addr},
+			amt:         2,
+			shouldErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			require := require.New(t)
+			tx := &TestTx{UnsignedBytes: txBytes}
+			in, cred := newIn(test.amt, test.signers...)
+
+			err := fx.VerifyTransfer(tx, in, cred, test.out)
+			if !test.shouldErr {
+				require.NoError(err)
+				return
+			}
+			require.Error(err)
+			if test.expectedErr != nil {
+				require.ErrorIs(err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestFxVerifyWeightedTransferWrongSigner(t *testing.T) {
+	require := require.New(t)
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := Fx{}
+	require.NoError(fx.Initialize(&vm))
+	require.NoError(fx.Bootstrapping())
+	require.NoError(fx.Bootstrapped())
+	tx := &TestTx{UnsignedBytes: txBytes}
+	out := &WeightedTransferOutput{
+		Amt: 1,
+		WeightedOutputOwners: WeightedOutputOwners{
+			Threshold: 2,
+			Addrs:     []ids.ShortID{ids.ShortEmpty},
+			Weights:   []uint64{2},
+		},
+	}
+	in := &TransferInput{
+		Amt: 1,
+		Input: Input{
+			SigIndices: []uint32{0},
+		},
+	}
+	cred := &Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sigBytes,
+		},
+	}
+
+	require.Error(fx.VerifyTransfer(tx, in, cred, out))
+
+	in.SigIndices = []uint32{1}
+	require.ErrorIs(fx.VerifyTransfer(tx, in, cred, out), errInputOutputIndexOutOfBounds)
+}
+
+func TestVerifyWeightedPermission(t *testing.T) {
+	require := require.New(t)
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := Fx{}
+	require.NoError(fx.Initialize(&vm))
+	require.NoError(fx.Bootstrapping())
+	require.NoError(fx.Bootstrapped())
+	tx := &TestTx{UnsignedBytes: txBytes}
+	owners := &WeightedOutputOwners{
+		Threshold: 2,
+		Addrs:     []ids.ShortID{addr},
+		Weights:   []uint64{2},
+	}
+	in := &Input{SigIndices: []uint32{0}}
+	cred := &Credential{Sigs: [][crypto.SECP256K1RSigLen]byte{sigBytes}}
+	require.NoError(fx.VerifyPermission(tx, in, cred, owners))
+
+	owners.Threshold = 3
+	require.ErrorIs(fx.VerifyPermission(tx, in, cred, owners), errOutputUnspendable)
+}
+
+func TestFxCreateWeightedOutput(t *testing.T) {
+	require := require.New(t)
+	fx := Fx{}
+	owners := &WeightedOutputOwners{
+		Threshold: 2,
+		Addrs:     []ids.ShortID{addr},
+		Weights:   []uint64{2},
+	}
+	outIntf, err := fx.CreateOutput(1, owners)
+	require.NoError(err)
+	out, ok := outIntf.(*WeightedTransferOutput)
+	require.True(ok)
+	require.Equal(uint64(1), out.Amount())
+	require.Equal(owners, out.Owners())
+}
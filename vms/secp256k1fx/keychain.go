@@ -6,6 +6,7 @@ package secp256k1fx
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/kukrer/savannahnode/ids"
@@ -91,6 +92,16 @@ func (kc *Keychain) Spend(out verify.Verifiable, time uint64) (verify.Verifiable
 			}, keys, nil
 		}
 		return nil, nil, errCantSpend
+	case *WeightedTransferOutput:
+		if sigIndices, keys, able := kc.MatchWeighted(&out.WeightedOutputOwners, time); able {
+			return &TransferInput{
+				Amt: out.Amt,
+				Input: Input{
+					SigIndices: sigIndices,
+				},
+			}, keys, nil
+		}
+		return nil, nil, errCantSpend
 	}
 	return nil, nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
 }
@@ -111,6 +122,43 @@ func (kc *Keychain) Match(owners *OutputOwners, time uint64) ([]uint32, []*crypt
 	return sigs, keys, uint32(len(keys)) == owners.Threshold
 }
 
+// MatchWeighted attempts to match a list of addresses whose weights reach the
+// provided threshold. The heaviest keys are used first, so that none of the
+// returned keys is unnecessary. The returned indices are sorted.
+func (kc *Keychain) MatchWeighted(owners *WeightedOutputOwners, time uint64) ([]uint32, []*crypto.PrivateKeySECP256K1R, bool) {
+	if time < owners.Locktime || len(owners.Weights) != len(owners.Addrs) {
+		return nil, nil, false
+	}
+
+	candidates := make([]uint32, 0, len(owners.Addrs))
+	for i, addr := range owners.Addrs {
+		if _, exists := kc.Get(addr); exists {
+			candidates = append(candidates, uint32(i))
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return owners.Weights[candidates[i]] > owners.Weights[candidates[j]]
+	})
+
+	weight := uint64(0)
+	numSigners := 0
+	for numSigners < len(candidates) && weight < owners.Threshold {
+		weight += owners.Weights[candidates[numSigners]]
+		numSigners++
+	}
+	if weight < owners.Threshold {
+		return nil, nil, false
+	}
+
+	sigs := candidates[:numSigners]
+	sort.Slice(sigs, func(i, j int) bool { return sigs[i] < sigs[j] })
+	keys := make([]*crypto.PrivateKeySECP256K1R, numSigners)
+	for i, index := range sigs {
+		keys[i], _ = kc.Get(owners.Addrs[index])
+	}
+	return sigs, keys, true
+}
+
 // PrefixedString returns the key chain as a string representation with [prefix]
 // added before every line.
 func (kc *Keychain) PrefixedString(prefix string) string {
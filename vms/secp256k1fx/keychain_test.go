@@ -222,6 +222,70 @@ func TestKeychainSpendTransfer(t *testing.T) {
 	require.Equal(sks[2].PublicKey().Address(), keys[1].PublicKey().Address())
 }
 
+func TestKeychainSpendWeightedTransfer(t *testing.T) {
+	require := require.New(t)
+	kc := NewKeychain()
+
+	sks := []*crypto.PrivateKeySECP256K1R{}
+	for _, keyStr := range keys {
+		skBytes, err := formatting.Decode(formatting.HexNC, keyStr)
+		require.NoError(err)
+
+		skIntf, err := kc.factory.ToPrivateKey(skBytes)
+		require.NoError(err)
+		sk, ok := skIntf.(*crypto.PrivateKeySECP256K1R)
+		require.True(ok, "Factory should have returned secp256k1r private key")
+		sks = append(sks, sk)
+	}
+
+	transfer := WeightedTransferOutput{
+		Amt: 12345,
+		WeightedOutputOwners: WeightedOutputOwners{
+			Locktime:  54321,
+			Threshold: 3,
+			Addrs: []ids.ShortID{
+				sks[0].PublicKey().Address(),
+				sks[1].PublicKey().Address(),
+				sks[2].PublicKey().Address(),
+			},
+			Weights: []uint64{2, 1, 1},
+		},
+	}
+	transfer.Sort()
+	require.NoError(transfer.Verify())
+
+	kc.Add(sks[1])
+	kc.Add(sks[2])
+
+	_, _, err := kc.Spend(&transfer, 54321)
+	require.ErrorIs(err, errCantSpend)
+
+	kc.Add(sks[0])
+
+	_, _, err = kc.Spend(&transfer, 4321)
+	require.ErrorIs(err, errCantSpend)
+
+	vinput, keys, err := kc.Spend(&transfer, 54321)
+	require.NoError(err)
+
+	input, ok := vinput.(*TransferInput)
+	require.True(ok)
+	require.NoError(input.Verify())
+	require.Equal(uint64(12345), input.Amount())
+	require.Len(input.SigIndices, 2)
+	require.Len(keys, 2)
+
+	weight := uint64(0)
+	signers := ids.ShortSet{}
+	for i, index := range input.SigIndices {
+		require.Equal(transfer.Addrs[index], keys[i].PublicKey().Address())
+		weight += transfer.Weights[index]
+		signers.Add(transfer.Addrs[index])
+	}
+	require.Equal(uint64(3), weight)
+	require.True(signers.Contains(sks[0].PublicKey().Address()))
+}
+
 func TestKeychainString(t *testing.T) {
 	require := require.New(t)
 	kc := NewKeychain()
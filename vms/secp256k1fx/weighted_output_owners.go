@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/snow"
+	"github.com/kukrer/savannahnode/utils/math"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+)
+
+var (
+	errWeightsAddrsMismatch = errors.New("number of weights and addresses differ")
+	errZeroWeight           = errors.New("address has no weight")
+
+	_ verify.State = &WeightedOutputOwners{}
+)
+
+// WeightedOutputOwners is an owner whose addresses each carry a weight. The
+// owner assents once the total weight of the signing addresses reaches the
+// threshold.
+type WeightedOutputOwners struct {
+	Locktime  uint64        `serialize:"true" json:"locktime"`
+	Threshold uint64        `serialize:"true" json:"threshold"`
+	Addrs     []ids.ShortID `serialize:"true" json:"addresses"`
+	// Weights[i] is the weight of Addrs[i]
+	Weights []uint64 `serialize:"true" json:"weights"`
+	// ctx is used in MarshalJSON to convert Addrs into human readable
+	// format with ChainID and NetworkID. Unexported because we don't use
+	// it outside this object.
+	ctx *snow.Context `serialize:"false"`
+}
+
+// InitCtx assigns the WeightedOutputOwners.ctx object to given [ctx] object
+// Must be called at least once for MarshalJSON to work successfully
+func (out *WeightedOutputOwners) InitCtx(ctx *snow.Context) {
+	out.ctx = ctx
+}
+
+// MarshalJSON marshals WeightedOutputOwners as JSON with human readable
+// addresses. WeightedOutputOwners.InitCtx must be called before marshalling
+// this or one of the parent objects to json.
+func (out *WeightedOutputOwners) MarshalJSON() ([]byte, error) {
+	result, err := out.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
+
+// Fields returns JSON keys in a map that can be used with marshal JSON
+// to serialise WeightedOutputOwners struct
+func (out *WeightedOutputOwners) Fields() (map[string]interface{}, error) {
+	addrsLen := len(out.Addrs)
+
+	// we need out.ctx to do this, if its absent, throw error
+	if addrsLen > 0 && out.ctx == nil {
+		return nil, errMarshal
+	}
+
+	addresses := make([]string, addrsLen)
+	for i, addr := range out.Addrs {
+		fAddr, err := formatAddress(out.ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		addresses[i] = fAddr
+	}
+	result := map[string]interface{}{
+		"locktime":  out.Locktime,
+		"threshold": out.Threshold,
+		"addresses": addresses,
+		"weights":   out.Weights,
+	}
+
+	return result, nil
+}
+
+// Addresses returns the addresses that manage this output
+func (out *WeightedOutputOwners) Addresses() [][]byte {
+	addrs := make([][]byte, len(out.Addrs))
+	for i, addr := range out.Addrs {
+		addrs[i] = addr.Bytes()
+	}
+	return addrs
+}
+
+// AddressesSet returns addresses as a set
+func (out *WeightedOutputOwners) AddressesSet() ids.ShortSet {
+	set := ids.NewShortSet(len(out.Addrs))
+	set.Add(out.Addrs...)
+	return set
+}
+
+// TotalWeight returns the sum of the weights of all the addresses
+func (out *WeightedOutputOwners) TotalWeight() (uint64, error) {
+	total := uint64(0)
+	for _, weight := range out.Weights {
+		newTotal, err := math.Add64(total, weight)
+		if err != nil {
+			return 0, err
+		}
+		total = newTotal
+	}
+	return total, nil
+}
+
+func (out *WeightedOutputOwners) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	case len(out.Weights) != len(out.Addrs):
+		return errWeightsAddrsMismatch
+	case out.Threshold == 0 && len(out.Addrs) > 0:
+		return errOutputUnoptimized
+	case !ids.IsSortedAndUniqueShortIDs(out.Addrs):
+		return errAddrsNotSortedUnique
+	}
+
+	for _, weight := range out.Weights {
+		if weight == 0 {
+			return errZeroWeight
+		}
+	}
+	totalWeight, err := out.TotalWeight()
+	if err != nil {
+		return err
+	}
+	if out.Threshold > totalWeight {
+		return errOutputUnspendable
+	}
+	return nil
+}
+
+func (out *WeightedOutputOwners) VerifyState() error { return out.Verify() }
+
+// Sort sorts the addresses, keeping each weight with its address
+func (out *WeightedOutputOwners) Sort() { sort.Sort(innerSortWeightedAddrs{owners: out}) }
+
+type innerSortWeightedAddrs struct{ owners *WeightedOutputOwners }
+
+func (s innerSortWeightedAddrs) Less(i, j int) bool {
+	return bytes.Compare(s.owners.Addrs[i][:], s.owners.Addrs[j][:]) == -1
+}
+func (s innerSortWeightedAddrs) Len() int { return len(s.owners.Addrs) }
+func (s innerSortWeightedAddrs) Swap(i, j int) {
+	s.owners.Addrs[j], s.owners.Addrs[i] = s.owners.Addrs[i], s.owners.Addrs[j]
+	s.owners.Weights[j], s.owners.Weights[i] = s.owners.Weights[i], s.owners.Weights[j]
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kukrer/savannahnode/ids"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+)
+
+func TestWeightedOutputOwnersVerify(t *testing.T) {
+	addr0 := ids.ShortID{0}
+	addr1 := ids.ShortID{1}
+
+	tests := []struct {
+		description string
+		owners      *WeightedOutputOwners
+		shouldErr   bool
+		expectedErr error
+	}{
+		{
+			description: "nil",
+			owners:      nil,
+			expectedErr: errNilOutput,
+		},
+		{
+			description: "valid",
+			owners: &WeightedOutputOwners{
+				Threshold: 3,
+				Addrs:     []ids.ShortID{addr0, addr1},
+				Weights:   []uint64{2, 1},
+			},
+		},
+		{
+			description: "no addresses",
+			owners:      &WeightedOutputOwners{},
+		},
+		{
+			description: "weights and addresses mismatch",
+			owners: &WeightedOutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr0, addr1},
+				Weights:   []uint64{1},
+			},
+			expectedErr: errWeightsAddrsMismatch,
+		},
+		{
+			description: "unoptimized",
+			owners: &WeightedOutputOwners{
+				Threshold: 0,
+				Addrs:     []ids.ShortID{addr0},
+				Weights:   []uint64{1},
+			},
+			expectedErr: errOutputUnoptimized,
+		},
+		{
+			description: "unsorted addresses",
+			owners: &WeightedOutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr1, addr0},
+				Weights:   []uint64{1, 1},
+			},
+			expectedErr: errAddrsNotSortedUnique,
+		},
+		{
+			description: "zero weight",
+			owners: &WeightedOutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr0, addr1},
+				Weights:   []uint64{1, 0},
+			},
+			expectedErr: errZeroWeight,
+		},
+		{
+			description: "unspendable",
+			owners: &WeightedOutputOwners{
+				Threshold: 4,
+				Addrs:     []ids.ShortID{addr0, addr1},
+				Weights:   []uint64{2, 1},
+			},
+			expectedErr: errOutputUnspendable,
+		},
+		{
+			description: "weight overflow",
+			owners: &WeightedOutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr0, addr1},
+				Weights:   []uint64{math.MaxUint64, 1},
+			},
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			require := require.New(t)
+			err := test.owners.Verify()
+			if test.shouldErr {
+				require.Error(err)
+				return
+			}
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestWeightedOutputOwnersSort(t *testing.T) {
+	require := require.New(t)
+	owners := WeightedOutputOwners{
+		Threshold: 3,
+		Addrs:     []ids.ShortID{{2}, {0}, {1}},
+		Weights:   []uint64{3, 1, 2},
+	}
+	require.ErrorIs(owners.Verify(), errAddrsNotSortedUnique)
+
+	owners.Sort()
+	require.NoError(owners.Verify())
+	require.Equal([]ids.ShortID{{0}, {1}, {2}}, owners.Addrs)
+	require.Equal([]uint64{1, 2, 3}, owners.Weights)
+}
+
+func TestWeightedOutputOwnersMarshalJSONRequiresCtx(t *testing.T) {
+	require := require.New(t)
+	owners := WeightedOutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{{0}},
+		Weights:   []uint64{1},
+	}
+	_, err := owners.MarshalJSON()
+	require.ErrorIs(err, errMarshal)
+}
+
+func TestWeightedTransferOutputState(t *testing.T) {
+	require := require.New(t)
+	intf := interface{}(&WeightedTransferOutput{})
+	_, ok := intf.(verify.State)
+	require.True(ok)
+}
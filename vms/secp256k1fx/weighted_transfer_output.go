@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"encoding/json"
+
+	"github.com/kukrer/savannahnode/vms/components/verify"
+)
+
+var _ verify.State = &WeightedTransferOutput{}
+
+// WeightedTransferOutput is a TransferOutput owned by a WeightedOutputOwners.
+// It is spent with a TransferInput.
+type WeightedTransferOutput struct {
+	Amt uint64 `serialize:"true" json:"amount"`
+
+	WeightedOutputOwners `serialize:"true"`
+}
+
+// MarshalJSON marshals Amt and the embedded WeightedOutputOwners struct into
+// a JSON readable format
+func (out *WeightedTransferOutput) MarshalJSON() ([]byte, error) {
+	result, err := out.WeightedOutputOwners.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	result["amount"] = out.Amt
+	return json.Marshal(result)
+}
+
+// Amount returns the quantity of the asset this output consumes
+func (out *WeightedTransferOutput) Amount() uint64 { return out.Amt }
+
+func (out *WeightedTransferOutput) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	case out.Amt == 0:
+		return errNoValueOutput
+	default:
+		return out.WeightedOutputOwners.Verify()
+	}
+}
+
+func (out *WeightedTransferOutput) VerifyState() error { return out.Verify() }
+
+func (out *WeightedTransferOutput) Owners() interface{} { return &out.WeightedOutputOwners }
@@ -4,6 +4,7 @@
 package p
 
 import (
+	"fmt"
 	"sync"
 
 	stdcontext "context"
@@ -12,6 +13,7 @@ import (
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/utils/constants"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 )
 
@@ -41,13 +43,19 @@ type backend struct {
 	txsLock sync.RWMutex
 	// txID -> tx
 	txs map[ids.ID]*txs.Tx
+	// subnetID -> owner set by the last accepted ownership transfer
+	subnetOwners map[ids.ID]fx.Owner
+	// nodeID -> rewards owner of the last accepted primary network validator
+	validatorOwners map[ids.NodeID]fx.Owner
 }
 
 func NewBackend(ctx Context, utxos ChainUTXOs, txs map[ids.ID]*txs.Tx) Backend {
 	return &backend{
-		Context:    ctx,
-		ChainUTXOs: utxos,
-		txs:        txs,
+		Context:         ctx,
+		ChainUTXOs:      utxos,
+		txs:             txs,
+		subnetOwners:    make(map[ids.ID]fx.Owner),
+		validatorOwners: make(map[ids.NodeID]fx.Owner),
 	}
 }
 
@@ -103,3 +111,66 @@ func (b *backend) GetTx(_ stdcontext.Context, txID ids.ID) (*txs.Tx, error) {
 	}
 	return tx, nil
 }
+
+func (b *backend) GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (fx.Owner, error) {
+	b.txsLock.RLock()
+	owner, exists := b.subnetOwners[subnetID]
+	b.txsLock.RUnlock()
+	if exists {
+		return owner, nil
+	}
+
+	subnetTx, err := b.GetTx(ctx, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch subnet %q: %w",
+			subnetID,
+			err,
+		)
+	}
+	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+	return subnet.Owner, nil
+}
+
+func (b *backend) setSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	b.txsLock.Lock()
+	defer b.txsLock.Unlock()
+
+	b.subnetOwners[subnetID] = owner
+}
+
+func (b *backend) GetValidatorOwner(_ stdcontext.Context, nodeID ids.NodeID) (fx.Owner, error) {
+	b.txsLock.RLock()
+	defer b.txsLock.RUnlock()
+
+	if owner, exists := b.validatorOwners[nodeID]; exists {
+		return owner, nil
+	}
+	for _, tx := range b.txs {
+		switch utx := tx.Unsigned.(type) {
+		case *txs.AddValidatorTx:
+			if utx.Validator.NodeID == nodeID {
+				return utx.RewardsOwner, nil
+			}
+		case *txs.AddPermissionlessValidatorTx:
+			if utx.Subnet == constants.PrimaryNetworkID && utx.Validator.NodeID == nodeID {
+				return utx.ValidatorRewardsOwner, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf(
+		"failed to fetch validator %s: %w",
+		nodeID,
+		database.ErrNotFound,
+	)
+}
+
+func (b *backend) setValidatorOwner(nodeID ids.NodeID, owner fx.Owner) {
+	b.txsLock.Lock()
+	defer b.txsLock.Unlock()
+
+	b.validatorOwners[nodeID] = owner
+}
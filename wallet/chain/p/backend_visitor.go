@@ -25,6 +25,7 @@ func (*backendVisitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error         { return
 func (*backendVisitor) RewardValidatorTx(*txs.RewardValidatorTx) error { return errUnsupportedTxType }
 
 func (b *backendVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	b.b.setValidatorOwner(tx.Validator.NodeID, tx.RewardsOwner)
 	return b.baseTx(&tx.BaseTx)
 }
 
@@ -32,6 +33,34 @@ func (b *backendVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) erro
 	return b.baseTx(&tx.BaseTx)
 }
 
+func (b *backendVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	b.b.setSubnetOwner(tx.Subnet, tx.Owner)
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) TopUpValidatorTx(tx *txs.TopUpValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	if tx.Subnet == constants.PrimaryNetworkID {
+		b.b.setValidatorOwner(tx.Validator.NodeID, tx.ValidatorRewardsOwner)
+	}
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (b *backendVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 	return b.baseTx(&tx.BaseTx)
 }
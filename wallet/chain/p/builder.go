@@ -13,6 +13,8 @@ import (
 	"github.com/kukrer/savannahnode/utils/constants"
 	"github.com/kukrer/savannahnode/utils/math"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
 	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/validator"
@@ -20,12 +22,17 @@ import (
 	"github.com/kukrer/savannahnode/wallet/subnet/primary/common"
 )
 
+// maxDynamicFeeIterations is the maximum number of times a tx is rebuilt while
+// searching for the dynamic fee it must burn.
+const maxDynamicFeeIterations = 8
+
 var (
 	errNoChangeAddress           = errors.New("no possible change address")
 	errWrongTxType               = errors.New("wrong tx type")
 	errUnknownOwnerType          = errors.New("unknown owner type")
 	errInsufficientAuthorization = errors.New("insufficient authorization")
 	errInsufficientFunds         = errors.New("insufficient funds")
+	errDynamicFeeNotConverged    = errors.New("couldn't find a dynamic fee that covers the tx's complexity")
 
 	_ Builder = &builder{}
 )
@@ -84,6 +91,42 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.AddSubnetValidatorTx, error)
 
+	// NewRemoveSubnetValidatorTx removes [nodeID] from the validator
+	// set [subnetID].
+	//
+	// - [nodeID] is the node to remove from the subnet's validator set.
+	// - [subnetID] is the subnet the validator is being removed from.
+	NewRemoveSubnetValidatorTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		options ...common.Option,
+	) (*txs.RemoveSubnetValidatorTx, error)
+
+	// NewTransferSubnetOwnershipTx changes the owner of the named subnet.
+	//
+	// - [subnetID] specifies the subnet to be modified.
+	// - [owner] specifies who has the ability to create new chains and add new
+	//   validators to the subnet.
+	NewTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.TransferSubnetOwnershipTx, error)
+
+	// NewTopUpValidatorTx adds stake to and/or extends the staking period of a
+	// current validator of the primary network.
+	//
+	// - [nodeID] specifies the validator to be modified.
+	// - [endTime] specifies the new end of the staking period. It must not be
+	//   before the current end of the staking period.
+	// - [stakeAmount] specifies the amount of AVAX added to the stake.
+	NewTopUpValidatorTx(
+		nodeID ids.NodeID,
+		endTime uint64,
+		stakeAmount uint64,
+		options ...common.Option,
+	) (*txs.TopUpValidatorTx, error)
+
 	// NewAddDelegatorTx creates a new delegator to a validator on the primary
 	// network.
 	//
@@ -144,6 +187,95 @@ type Builder interface {
 		outputs []*avax.TransferableOutput,
 		options ...common.Option,
 	) (*txs.ExportTx, error)
+
+	// NewTransformSubnetTx converts the subnet into a permissionless subnet.
+	//
+	// - [subnetID] specifies the subnet to transform.
+	// - [assetID] specifies the asset to use to reward stakers on the subnet.
+	// - [initialSupply] is the amount of [assetID] that will be in circulation
+	//   after this transaction is accepted.
+	// - [maxSupply] is the maximum total amount of [assetID] that should
+	//   ever exist.
+	// - [minConsumptionRate] is the rate that a staker will receive rewards
+	//   if they stake with a duration of 0.
+	// - [maxConsumptionRate] is the maximum rate that staking rewards should
+	//   be consumed from the reward pool per year.
+	// - [minValidatorStake] is the minimum amount of funds required to become
+	//   a validator.
+	// - [maxValidatorStake] is the maximum amount of funds a single validator
+	//   can be allocated, including delegated funds.
+	// - [minStakeDuration] is the minimum number of seconds a staker can stake
+	//   for.
+	// - [maxStakeDuration] is the maximum number of seconds a staker can stake
+	//   for.
+	// - [minDelegationFee] is the minimum percentage a validator must charge a
+	//   delegator for delegating.
+	// - [minDelegatorStake] is the minimum amount of funds required to become
+	//   a delegator.
+	// - [maxValidatorWeightFactor] is the factor which calculates the maximum
+	//   amount of delegation a validator can receive. A value of 1 effectively
+	//   disables delegation.
+	// - [uptimeRequirement] is the minimum percentage a validator must be
+	//   online and responsive to receive a reward.
+	NewTransformSubnetTx(
+		subnetID ids.ID,
+		assetID ids.ID,
+		initialSupply uint64,
+		maxSupply uint64,
+		minConsumptionRate uint64,
+		maxConsumptionRate uint64,
+		minValidatorStake uint64,
+		maxValidatorStake uint64,
+		minStakeDuration uint32,
+		maxStakeDuration uint32,
+		minDelegationFee uint32,
+		minDelegatorStake uint64,
+		maxValidatorWeightFactor byte,
+		uptimeRequirement uint32,
+		options ...common.Option,
+	) (*txs.TransformSubnetTx, error)
+
+	// NewAddPermissionlessValidatorTx creates a new validator of the primary
+	// network or of the specified permissionless subnet.
+	//
+	// - [vdr] specifies all the details of the validation period such as the
+	//   subnetID, startTime, endTime, stake weight, and nodeID.
+	// - [signer] if the subnetID is the primary network, this is the BLS key
+	//   and proof of possession for this validator. Otherwise, this must be
+	//   [signer.Empty].
+	// - [assetID] specifies the asset to stake.
+	// - [validationRewardsOwner] specifies the owner of all the rewards this
+	//   validator earns for its validation period.
+	// - [delegationRewardsOwner] specifies the owner of all the rewards this
+	//   validator earns for delegations during its validation period.
+	// - [shares] specifies the fraction (out of 1,000,000) that this validator
+	//   will take from delegation rewards. If 1,000,000 is provided, 100% of
+	//   the delegation reward will be sent to the validator's
+	//   [delegationRewardsOwner].
+	NewAddPermissionlessValidatorTx(
+		vdr *validator.SubnetValidator,
+		signer signer.Signer,
+		assetID ids.ID,
+		validationRewardsOwner *secp256k1fx.OutputOwners,
+		delegationRewardsOwner *secp256k1fx.OutputOwners,
+		shares uint32,
+		options ...common.Option,
+	) (*txs.AddPermissionlessValidatorTx, error)
+
+	// NewAddPermissionlessDelegatorTx creates a new delegator of the specified
+	// permissionless subnet.
+	//
+	// - [vdr] specifies all the details of the delegation period such as the
+	//   subnetID, startTime, endTime, stake weight, and nodeID.
+	// - [assetID] specifies the asset to stake.
+	// - [rewardsOwner] specifies the owner of all the rewards this delegator
+	//   earns during its delegation period.
+	NewAddPermissionlessDelegatorTx(
+		vdr *validator.SubnetValidator,
+		assetID ids.ID,
+		rewardsOwner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.AddPermissionlessDelegatorTx, error)
 }
 
 // BuilderBackend specifies the required information needed to build unsigned
@@ -152,6 +284,8 @@ type BuilderBackend interface {
 	Context
 	UTXOs(ctx stdcontext.Context, sourceChainID ids.ID) ([]*avax.UTXO, error)
 	GetTx(ctx stdcontext.Context, txID ids.ID) (*txs.Tx, error)
+	GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (fx.Owner, error)
+	GetValidatorOwner(ctx stdcontext.Context, nodeID ids.NodeID) (fx.Owner, error)
 }
 
 type builder struct {
@@ -190,6 +324,20 @@ func (b *builder) GetImportableBalance(
 func (b *builder) NewBaseTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
+) (*txs.CreateSubnetTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newBaseTx(outputs, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.CreateSubnetTx), nil
+}
+
+func (b *builder) newBaseTx(
+	outputs []*avax.TransferableOutput,
+	dynamicFee uint64,
+	options ...common.Option,
 ) (*txs.CreateSubnetTx, error) {
 	toBurn := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): b.backend.CreateSubnetTxFee(),
@@ -202,6 +350,9 @@ func (b *builder) NewBaseTx(
 		}
 		toBurn[assetID] = amountToBurn
 	}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
 	toStake := map[ids.ID]uint64{}
 
 	ops := common.NewOptions(options)
@@ -229,8 +380,27 @@ func (b *builder) NewAddValidatorTx(
 	rewardsOwner *secp256k1fx.OutputOwners,
 	shares uint32,
 	options ...common.Option,
+) (*txs.AddValidatorTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newAddValidatorTx(vdr, rewardsOwner, shares, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.AddValidatorTx), nil
+}
+
+func (b *builder) newAddValidatorTx(
+	vdr *validator.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	dynamicFee uint64,
+	options ...common.Option,
 ) (*txs.AddValidatorTx, error) {
 	toBurn := map[ids.ID]uint64{}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
 	toStake := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): vdr.Wght,
 	}
@@ -259,10 +429,27 @@ func (b *builder) NewAddValidatorTx(
 func (b *builder) NewAddSubnetValidatorTx(
 	vdr *validator.SubnetValidator,
 	options ...common.Option,
+) (*txs.AddSubnetValidatorTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newAddSubnetValidatorTx(vdr, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.AddSubnetValidatorTx), nil
+}
+
+func (b *builder) newAddSubnetValidatorTx(
+	vdr *validator.SubnetValidator,
+	dynamicFee uint64,
+	options ...common.Option,
 ) (*txs.AddSubnetValidatorTx, error) {
 	toBurn := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): b.backend.CreateSubnetTxFee(),
 	}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
 	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
 	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
@@ -288,12 +475,192 @@ func (b *builder) NewAddSubnetValidatorTx(
 	}, nil
 }
 
+func (b *builder) NewRemoveSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	options ...common.Option,
+) (*txs.RemoveSubnetValidatorTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newRemoveSubnetValidatorTx(nodeID, subnetID, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.RemoveSubnetValidatorTx), nil
+}
+
+func (b *builder) newRemoveSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	dynamicFee uint64,
+	options ...common.Option,
+) (*txs.RemoveSubnetValidatorTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
+	}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txs.RemoveSubnetValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+	}, nil
+}
+
+func (b *builder) NewTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.TransferSubnetOwnershipTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newTransferSubnetOwnershipTx(subnetID, owner, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.TransferSubnetOwnershipTx), nil
+}
+
+func (b *builder) newTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	dynamicFee uint64,
+	options ...common.Option,
+) (*txs.TransferSubnetOwnershipTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
+	}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	ids.SortShortIDs(owner.Addrs)
+	return &txs.TransferSubnetOwnershipTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner:      owner,
+	}, nil
+}
+
+func (b *builder) NewTopUpValidatorTx(
+	nodeID ids.NodeID,
+	endTime uint64,
+	stakeAmount uint64,
+	options ...common.Option,
+) (*txs.TopUpValidatorTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newTopUpValidatorTx(nodeID, endTime, stakeAmount, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.TopUpValidatorTx), nil
+}
+
+func (b *builder) newTopUpValidatorTx(
+	nodeID ids.NodeID,
+	endTime uint64,
+	stakeAmount uint64,
+	dynamicFee uint64,
+	options ...common.Option,
+) (*txs.TopUpValidatorTx, error) {
+	toBurn := map[ids.ID]uint64{}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
+	toStake := map[ids.ID]uint64{}
+	if stakeAmount > 0 {
+		toStake[b.backend.AVAXAssetID()] = stakeAmount
+	}
+	ops := common.NewOptions(options)
+	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorAuth, err := b.authorizeValidator(nodeID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txs.TopUpValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         baseOutputs,
+			Memo:         ops.Memo(),
+		}},
+		NodeID:        nodeID,
+		End:           endTime,
+		Wght:          stakeAmount,
+		Stake:         stakeOutputs,
+		ValidatorAuth: validatorAuth,
+	}, nil
+}
+
 func (b *builder) NewAddDelegatorTx(
 	vdr *validator.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
 	options ...common.Option,
+) (*txs.AddDelegatorTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newAddDelegatorTx(vdr, rewardsOwner, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.AddDelegatorTx), nil
+}
+
+func (b *builder) newAddDelegatorTx(
+	vdr *validator.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	dynamicFee uint64,
+	options ...common.Option,
 ) (*txs.AddDelegatorTx, error) {
 	toBurn := map[ids.ID]uint64{}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
 	toStake := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): vdr.Wght,
 	}
@@ -325,10 +692,31 @@ func (b *builder) NewCreateChainTx(
 	fxIDs []ids.ID,
 	chainName string,
 	options ...common.Option,
+) (*txs.CreateChainTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newCreateChainTx(subnetID, genesis, vmID, fxIDs, chainName, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.CreateChainTx), nil
+}
+
+func (b *builder) newCreateChainTx(
+	subnetID ids.ID,
+	genesis []byte,
+	vmID ids.ID,
+	fxIDs []ids.ID,
+	chainName string,
+	dynamicFee uint64,
+	options ...common.Option,
 ) (*txs.CreateChainTx, error) {
 	toBurn := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): b.backend.CreateSubnetTxFee(),
 	}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
 	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
 	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
@@ -362,10 +750,27 @@ func (b *builder) NewCreateChainTx(
 func (b *builder) NewCreateSubnetTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
+) (*txs.CreateSubnetTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newCreateSubnetTx(owner, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.CreateSubnetTx), nil
+}
+
+func (b *builder) newCreateSubnetTx(
+	owner *secp256k1fx.OutputOwners,
+	dynamicFee uint64,
+	options ...common.Option,
 ) (*txs.CreateSubnetTx, error) {
 	toBurn := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): b.backend.CreateSubnetTxFee(),
 	}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
 	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
 	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
@@ -390,6 +795,21 @@ func (b *builder) NewImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
 	options ...common.Option,
+) (*txs.ImportTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newImportTx(sourceChainID, to, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.ImportTx), nil
+}
+
+func (b *builder) newImportTx(
+	sourceChainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	dynamicFee uint64,
+	options ...common.Option,
 ) (*txs.ImportTx, error) {
 	ops := common.NewOptions(options)
 	utxos, err := b.backend.UTXOs(ops.Context(), sourceChainID)
@@ -439,6 +859,11 @@ func (b *builder) NewImportTx(
 	}
 	avax.SortTransferableInputs(importedInputs) // sort imported inputs
 
+	txFee, err = math.Add64(txFee, dynamicFee)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(importedInputs) == 0 {
 		return nil, fmt.Errorf(
 			"%w: no UTXOs available to import",
@@ -496,6 +921,21 @@ func (b *builder) NewExportTx(
 	chainID ids.ID,
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
+) (*txs.ExportTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newExportTx(chainID, outputs, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.ExportTx), nil
+}
+
+func (b *builder) newExportTx(
+	chainID ids.ID,
+	outputs []*avax.TransferableOutput,
+	dynamicFee uint64,
+	options ...common.Option,
 ) (*txs.ExportTx, error) {
 	toBurn := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
@@ -509,6 +949,9 @@ func (b *builder) NewExportTx(
 		toBurn[assetID] = amountToBurn
 	}
 
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
 	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
 	inputs, changeOutputs, _, err := b.spend(toBurn, toStake, ops)
@@ -530,6 +973,254 @@ func (b *builder) NewExportTx(
 	}, nil
 }
 
+func (b *builder) NewTransformSubnetTx(
+	subnetID ids.ID,
+	assetID ids.ID,
+	initialSupply uint64,
+	maxSupply uint64,
+	minConsumptionRate uint64,
+	maxConsumptionRate uint64,
+	minValidatorStake uint64,
+	maxValidatorStake uint64,
+	minStakeDuration uint32,
+	maxStakeDuration uint32,
+	minDelegationFee uint32,
+	minDelegatorStake uint64,
+	maxValidatorWeightFactor byte,
+	uptimeRequirement uint32,
+	options ...common.Option,
+) (*txs.TransformSubnetTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newTransformSubnetTx(subnetID, assetID, initialSupply, maxSupply, minConsumptionRate, maxConsumptionRate, minValidatorStake, maxValidatorStake, minStakeDuration, maxStakeDuration, minDelegationFee, minDelegatorStake, maxValidatorWeightFactor, uptimeRequirement, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.TransformSubnetTx), nil
+}
+
+func (b *builder) newTransformSubnetTx(
+	subnetID ids.ID,
+	assetID ids.ID,
+	initialSupply uint64,
+	maxSupply uint64,
+	minConsumptionRate uint64,
+	maxConsumptionRate uint64,
+	minValidatorStake uint64,
+	maxValidatorStake uint64,
+	minStakeDuration uint32,
+	maxStakeDuration uint32,
+	minDelegationFee uint32,
+	minDelegatorStake uint64,
+	maxValidatorWeightFactor byte,
+	uptimeRequirement uint32,
+	dynamicFee uint64,
+	options ...common.Option,
+) (*txs.TransformSubnetTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): b.backend.CreateSubnetTxFee(),
+		assetID:                 maxSupply - initialSupply,
+	}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txs.TransformSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		Subnet:                   subnetID,
+		AssetID:                  assetID,
+		InitialSupply:            initialSupply,
+		MaximumSupply:            maxSupply,
+		MinConsumptionRate:       minConsumptionRate,
+		MaxConsumptionRate:       maxConsumptionRate,
+		MinValidatorStake:        minValidatorStake,
+		MaxValidatorStake:        maxValidatorStake,
+		MinStakeDuration:         minStakeDuration,
+		MaxStakeDuration:         maxStakeDuration,
+		MinDelegationFee:         minDelegationFee,
+		MinDelegatorStake:        minDelegatorStake,
+		MaxValidatorWeightFactor: maxValidatorWeightFactor,
+		UptimeRequirement:        uptimeRequirement,
+		SubnetAuth:               subnetAuth,
+	}, nil
+}
+
+func (b *builder) NewAddPermissionlessValidatorTx(
+	vdr *validator.SubnetValidator,
+	signer signer.Signer,
+	assetID ids.ID,
+	validationRewardsOwner *secp256k1fx.OutputOwners,
+	delegationRewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	options ...common.Option,
+) (*txs.AddPermissionlessValidatorTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newAddPermissionlessValidatorTx(vdr, signer, assetID, validationRewardsOwner, delegationRewardsOwner, shares, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.AddPermissionlessValidatorTx), nil
+}
+
+func (b *builder) newAddPermissionlessValidatorTx(
+	vdr *validator.SubnetValidator,
+	signer signer.Signer,
+	assetID ids.ID,
+	validationRewardsOwner *secp256k1fx.OutputOwners,
+	delegationRewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	dynamicFee uint64,
+	options ...common.Option,
+) (*txs.AddPermissionlessValidatorTx, error) {
+	toBurn := map[ids.ID]uint64{}
+	if vdr.Subnet != constants.PrimaryNetworkID {
+		toBurn[b.backend.AVAXAssetID()] = b.backend.BaseTxFee()
+	}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
+	toStake := map[ids.ID]uint64{
+		assetID: vdr.Wght,
+	}
+	ops := common.NewOptions(options)
+	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	ids.SortShortIDs(validationRewardsOwner.Addrs)
+	ids.SortShortIDs(delegationRewardsOwner.Addrs)
+	return &txs.AddPermissionlessValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         baseOutputs,
+			Memo:         ops.Memo(),
+		}},
+		Validator:             vdr.Validator,
+		Subnet:                vdr.Subnet,
+		Signer:                signer,
+		StakeOuts:             stakeOutputs,
+		ValidatorRewardsOwner: validationRewardsOwner,
+		DelegatorRewardsOwner: delegationRewardsOwner,
+		DelegationShares:      shares,
+	}, nil
+}
+
+func (b *builder) NewAddPermissionlessDelegatorTx(
+	vdr *validator.SubnetValidator,
+	assetID ids.ID,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.AddPermissionlessDelegatorTx, error) {
+	utx, err := b.withDynamicFee(func(dynamicFee uint64) (txs.UnsignedTx, error) {
+		return b.newAddPermissionlessDelegatorTx(vdr, assetID, rewardsOwner, dynamicFee, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utx.(*txs.AddPermissionlessDelegatorTx), nil
+}
+
+func (b *builder) newAddPermissionlessDelegatorTx(
+	vdr *validator.SubnetValidator,
+	assetID ids.ID,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	dynamicFee uint64,
+	options ...common.Option,
+) (*txs.AddPermissionlessDelegatorTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
+	}
+	if err := b.addDynamicFee(toBurn, dynamicFee); err != nil {
+		return nil, err
+	}
+	toStake := map[ids.ID]uint64{
+		assetID: vdr.Wght,
+	}
+	ops := common.NewOptions(options)
+	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	ids.SortShortIDs(rewardsOwner.Addrs)
+	return &txs.AddPermissionlessDelegatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         baseOutputs,
+			Memo:         ops.Memo(),
+		}},
+		Validator:              *vdr,
+		StakeOuts:              stakeOutputs,
+		DelegationRewardsOwner: rewardsOwner,
+	}, nil
+}
+
+// withDynamicFee builds an unsigned tx with [build], increasing the amount of
+// AVAX it burns until it covers the complexity-based fee of the tx.
+func (b *builder) withDynamicFee(build func(dynamicFee uint64) (txs.UnsignedTx, error)) (txs.UnsignedTx, error) {
+	var (
+		feeConfig  = b.backend.DynamicFeeConfig()
+		baseFee    = b.backend.BaseFee()
+		dynamicFee uint64
+	)
+	for i := 0; i < maxDynamicFeeIterations; i++ {
+		utx, err := build(dynamicFee)
+		if err != nil {
+			return nil, err
+		}
+		complexity, err := estimateComplexity(utx)
+		if err != nil {
+			return nil, err
+		}
+		requiredFee, err := feeConfig.Fee(complexity, baseFee)
+		if err != nil {
+			return nil, err
+		}
+		if requiredFee <= dynamicFee {
+			return utx, nil
+		}
+		dynamicFee = requiredFee
+	}
+	return nil, errDynamicFeeNotConverged
+}
+
+// addDynamicFee adds [dynamicFee] to the amount of AVAX in [toBurn].
+func (b *builder) addDynamicFee(toBurn map[ids.ID]uint64, dynamicFee uint64) error {
+	if dynamicFee == 0 {
+		return nil
+	}
+	avaxAssetID := b.backend.AVAXAssetID()
+	amountToBurn, err := math.Add64(toBurn[avaxAssetID], dynamicFee)
+	if err != nil {
+		return err
+	}
+	toBurn[avaxAssetID] = amountToBurn
+	return nil
+}
+
 func (b *builder) getBalance(
 	chainID ids.ID,
 	options *common.Options,
@@ -549,13 +1240,21 @@ func (b *builder) getBalance(
 	// Iterate over the UTXOs
 	for _, utxo := range utxos {
 		outIntf := utxo.Out
-		if lockedOut, ok := outIntf.(*stakeable.LockOut); ok {
+		switch lockedOut := outIntf.(type) {
+		case *stakeable.LockOut:
 			if !options.AllowStakeableLocked() && lockedOut.Locktime > minIssuanceTime {
 				// This output is currently locked, so this output can't be
 				// burned.
 				continue
 			}
 			outIntf = lockedOut.TransferableOut
+		case *stakeable.VestingOut:
+			if !options.AllowStakeableLocked() && lockedOut.LockedAmount(minIssuanceTime) > 0 {
+				// This output hasn't fully vested, so this output can't be
+				// burned.
+				continue
+			}
+			outIntf = lockedOut.TransferableOut
 		}
 
 		out, ok := outIntf.(*secp256k1fx.TransferOutput)
@@ -712,13 +1411,21 @@ func (b *builder) spend(
 		}
 
 		outIntf := utxo.Out
-		if lockedOut, ok := outIntf.(*stakeable.LockOut); ok {
+		switch lockedOut := outIntf.(type) {
+		case *stakeable.LockOut:
 			if lockedOut.Locktime > minIssuanceTime {
 				// This output is currently locked, so this output can't be
 				// burned.
 				continue
 			}
 			outIntf = lockedOut.TransferableOut
+		case *stakeable.VestingOut:
+			if lockedOut.LockedAmount(minIssuanceTime) > 0 {
+				// This output hasn't fully vested, so this output can't be
+				// burned.
+				continue
+			}
+			outIntf = lockedOut.TransferableOut
 		}
 
 		out, ok := outIntf.(*secp256k1fx.TransferOutput)
@@ -807,20 +1514,23 @@ func (b *builder) spend(
 }
 
 func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*secp256k1fx.Input, error) {
-	subnetTx, err := b.backend.GetTx(options.Context(), subnetID)
+	subnetOwner, err := b.backend.GetSubnetOwner(options.Context(), subnetID)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to fetch subnet %q: %w",
-			subnetID,
-			err,
-		)
+		return nil, err
 	}
-	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
-	if !ok {
-		return nil, errWrongTxType
+	return b.authorizeOwner(subnetOwner, options)
+}
+
+func (b *builder) authorizeValidator(nodeID ids.NodeID, options *common.Options) (*secp256k1fx.Input, error) {
+	validatorOwner, err := b.backend.GetValidatorOwner(options.Context(), nodeID)
+	if err != nil {
+		return nil, err
 	}
+	return b.authorizeOwner(validatorOwner, options)
+}
 
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+func (b *builder) authorizeOwner(authOwner fx.Owner, options *common.Options) (*secp256k1fx.Input, error) {
+	owner, ok := authOwner.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, errUnknownOwnerType
 	}
@@ -829,7 +1539,7 @@ func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*se
 	minIssuanceTime := options.MinIssuanceTime()
 	inputSigIndices, ok := common.MatchOwners(owner, addrs, minIssuanceTime)
 	if !ok {
-		// We can't authorize the subnet
+		// We can't authorize the owner
 		return nil, errInsufficientAuthorization
 	}
 	return &secp256k1fx.Input{
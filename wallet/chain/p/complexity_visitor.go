@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p
+
+import (
+	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
+	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
+	"github.com/kukrer/savannahnode/vms/platformvm/txs"
+	"github.com/kukrer/savannahnode/vms/secp256k1fx"
+)
+
+var _ txs.Visitor = &complexityVisitor{}
+
+// estimateComplexity returns the complexity [utx] will have once it has been
+// signed.
+func estimateComplexity(utx txs.UnsignedTx) (fees.Complexity, error) {
+	unsignedBytes, err := txs.Codec.Marshal(txs.Version, &utx)
+	if err != nil {
+		return fees.Complexity{}, err
+	}
+
+	visitor := &complexityVisitor{}
+	if err := utx.Visit(visitor); err != nil {
+		return fees.Complexity{}, err
+	}
+	return fees.Complexity{
+		Bytes:      uint64(len(unsignedBytes)),
+		Inputs:     uint64(utx.InputIDs().Len()),
+		Signatures: visitor.signatures,
+	}, nil
+}
+
+// complexityVisitor counts the number of signatures the signer will produce
+// for a tx.
+type complexityVisitor struct {
+	signatures uint64
+}
+
+func (*complexityVisitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error { return errUnsupportedTxType }
+func (*complexityVisitor) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return errUnsupportedTxType
+}
+
+func (c *complexityVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	return c.addInputs(tx.Ins)
+}
+
+func (c *complexityVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	if err := c.addInputs(tx.Ins); err != nil {
+		return err
+	}
+	return c.addSubnetAuth(tx.SubnetAuth)
+}
+
+func (c *complexityVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	if err := c.addInputs(tx.Ins); err != nil {
+		return err
+	}
+	return c.addSubnetAuth(tx.SubnetAuth)
+}
+
+func (c *complexityVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	if err := c.addInputs(tx.Ins); err != nil {
+		return err
+	}
+	return c.addSubnetAuth(tx.SubnetAuth)
+}
+
+func (c *complexityVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	if err := c.addInputs(tx.Ins); err != nil {
+		return err
+	}
+	return c.addSubnetAuth(tx.SubnetAuth)
+}
+
+func (c *complexityVisitor) TopUpValidatorTx(tx *txs.TopUpValidatorTx) error {
+	if err := c.addInputs(tx.Ins); err != nil {
+		return err
+	}
+	return c.addSubnetAuth(tx.ValidatorAuth)
+}
+
+func (c *complexityVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	return c.addInputs(tx.Ins)
+}
+
+func (c *complexityVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	return c.addInputs(tx.Ins)
+}
+
+func (c *complexityVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	return c.addInputs(tx.Ins)
+}
+
+func (c *complexityVisitor) CreateChainTx(tx *txs.CreateChainTx) error {
+	if err := c.addInputs(tx.Ins); err != nil {
+		return err
+	}
+	return c.addSubnetAuth(tx.SubnetAuth)
+}
+
+func (c *complexityVisitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return c.addInputs(tx.Ins)
+}
+
+func (c *complexityVisitor) ImportTx(tx *txs.ImportTx) error {
+	if err := c.addInputs(tx.Ins); err != nil {
+		return err
+	}
+	return c.addInputs(tx.ImportedInputs)
+}
+
+func (c *complexityVisitor) ExportTx(tx *txs.ExportTx) error {
+	return c.addInputs(tx.Ins)
+}
+
+func (c *complexityVisitor) addInputs(ins []*avax.TransferableInput) error {
+	for _, transferInput := range ins {
+		inIntf := transferInput.In
+		switch stakeableIn := inIntf.(type) {
+		case *stakeable.LockIn:
+			inIntf = stakeableIn.TransferableIn
+		case *stakeable.VestingIn:
+			inIntf = stakeableIn.TransferableIn
+		}
+
+		switch input := inIntf.(type) {
+		case *secp256k1fx.TransferInput:
+			c.signatures += uint64(len(input.SigIndices))
+		case *htlcfx.TransferInput:
+			c.signatures += uint64(len(input.SigIndices))
+		default:
+			return errUnknownInputType
+		}
+	}
+	return nil
+}
+
+func (c *complexityVisitor) addSubnetAuth(subnetAuth verify.Verifiable) error {
+	input, ok := subnetAuth.(*secp256k1fx.Input)
+	if !ok {
+		return errUnknownSubnetAuthType
+	}
+	c.signatures += uint64(len(input.SigIndices))
+	return nil
+}
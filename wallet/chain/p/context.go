@@ -9,6 +9,8 @@ import (
 	"github.com/kukrer/savannahnode/api/info"
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/vms/avm"
+	"github.com/kukrer/savannahnode/vms/platformvm"
+	"github.com/kukrer/savannahnode/vms/platformvm/fees"
 )
 
 var _ Context = &context{}
@@ -19,6 +21,12 @@ type Context interface {
 	BaseTxFee() uint64
 	CreateSubnetTxFee() uint64
 	CreateBlockchainTxFee() uint64
+	// DynamicFeeConfig returns the config of the complexity-based fees. If
+	// dynamic fees aren't active, the zero config is returned.
+	DynamicFeeConfig() fees.Config
+	// BaseFee returns the price, in nAVAX per unit of gas, of the
+	// complexity-based fees at the time the context was created.
+	BaseFee() uint64
 }
 
 type context struct {
@@ -27,18 +35,22 @@ type context struct {
 	baseTxFee             uint64
 	createSubnetTxFee     uint64
 	createBlockchainTxFee uint64
+	dynamicFeeConfig      fees.Config
+	baseFee               uint64
 }
 
 func NewContextFromURI(ctx stdcontext.Context, uri string) (Context, error) {
 	infoClient := info.NewClient(uri)
 	xChainClient := avm.NewClient(uri, "X")
-	return NewContextFromClients(ctx, infoClient, xChainClient)
+	pChainClient := platformvm.NewClient(uri)
+	return NewContextFromClients(ctx, infoClient, xChainClient, pChainClient)
 }
 
 func NewContextFromClients(
 	ctx stdcontext.Context,
 	infoClient info.Client,
 	xChainClient avm.Client,
+	pChainClient platformvm.Client,
 ) (Context, error) {
 	networkID, err := infoClient.GetNetworkID(ctx)
 	if err != nil {
@@ -55,12 +67,24 @@ func NewContextFromClients(
 		return nil, err
 	}
 
+	feeState, err := pChainClient.GetFeeState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var dynamicFeeConfig fees.Config
+	if feeState.Active {
+		dynamicFeeConfig = feeState.Config
+	}
+
 	return NewContext(
 		networkID,
 		asset.AssetID,
 		uint64(txFees.TxFee),
 		uint64(txFees.CreateSubnetTxFee),
 		uint64(txFees.CreateBlockchainTxFee),
+		dynamicFeeConfig,
+		uint64(feeState.BaseFee),
 	), nil
 }
 
@@ -70,6 +94,8 @@ func NewContext(
 	baseTxFee uint64,
 	createSubnetTxFee uint64,
 	createBlockchainTxFee uint64,
+	dynamicFeeConfig fees.Config,
+	baseFee uint64,
 ) Context {
 	return &context{
 		networkID:             networkID,
@@ -77,6 +103,8 @@ func NewContext(
 		baseTxFee:             baseTxFee,
 		createSubnetTxFee:     createSubnetTxFee,
 		createBlockchainTxFee: createBlockchainTxFee,
+		dynamicFeeConfig:      dynamicFeeConfig,
+		baseFee:               baseFee,
 	}
 }
 
@@ -85,3 +113,5 @@ func (c *context) AVAXAssetID() ids.ID           { return c.avaxAssetID }
 func (c *context) BaseTxFee() uint64             { return c.baseTxFee }
 func (c *context) CreateSubnetTxFee() uint64     { return c.createSubnetTxFee }
 func (c *context) CreateBlockchainTxFee() uint64 { return c.createBlockchainTxFee }
+func (c *context) DynamicFeeConfig() fees.Config { return c.dynamicFeeConfig }
+func (c *context) BaseFee() uint64               { return c.baseFee }
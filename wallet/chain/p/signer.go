@@ -8,11 +8,12 @@ import (
 
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
 
-var _ Signer = &signer{}
+var _ Signer = &txSigner{}
 
 type Signer interface {
 	SignUnsigned(ctx stdcontext.Context, tx txs.UnsignedTx) (*txs.Tx, error)
@@ -22,26 +23,28 @@ type Signer interface {
 type SignerBackend interface {
 	GetUTXO(ctx stdcontext.Context, chainID, utxoID ids.ID) (*avax.UTXO, error)
 	GetTx(ctx stdcontext.Context, txID ids.ID) (*txs.Tx, error)
+	GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (fx.Owner, error)
+	GetValidatorOwner(ctx stdcontext.Context, nodeID ids.NodeID) (fx.Owner, error)
 }
 
-type signer struct {
+type txSigner struct {
 	kc      *secp256k1fx.Keychain
 	backend SignerBackend
 }
 
 func NewSigner(kc *secp256k1fx.Keychain, backend SignerBackend) Signer {
-	return &signer{
+	return &txSigner{
 		kc:      kc,
 		backend: backend,
 	}
 }
 
-func (s *signer) SignUnsigned(ctx stdcontext.Context, utx txs.UnsignedTx) (*txs.Tx, error) {
+func (s *txSigner) SignUnsigned(ctx stdcontext.Context, utx txs.UnsignedTx) (*txs.Tx, error) {
 	tx := &txs.Tx{Unsigned: utx}
 	return tx, s.Sign(ctx, tx)
 }
 
-func (s *signer) Sign(ctx stdcontext.Context, tx *txs.Tx) error {
+func (s *txSigner) Sign(ctx stdcontext.Context, tx *txs.Tx) error {
 	return tx.Unsigned.Visit(&signerVisitor{
 		kc:      s.kc,
 		backend: s.backend,
@@ -16,6 +16,7 @@ import (
 	"github.com/kukrer/savannahnode/utils/hashing"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/platformvm/fx"
 	"github.com/kukrer/savannahnode/vms/platformvm/stakeable"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
@@ -66,6 +67,78 @@ func (s *signerVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error
 	return s.sign(s.tx, txSigners)
 }
 
+func (s *signerVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return s.sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return s.sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return s.sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) TopUpValidatorTx(tx *txs.TopUpValidatorTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	validatorOwner, err := s.backend.GetValidatorOwner(s.ctx, tx.NodeID)
+	if err != nil {
+		return err
+	}
+	validatorAuthSigners, err := s.getAuthSigners(validatorOwner, tx.ValidatorAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, validatorAuthSigners)
+	return s.sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return s.sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return s.sign(s.tx, txSigners)
+}
+
 func (s *signerVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
@@ -119,7 +192,15 @@ func (s *signerVisitor) ExportTx(tx *txs.ExportTx) error {
 func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*avax.TransferableInput) ([][]*crypto.PrivateKeySECP256K1R, error) {
 	txSigners := make([][]*crypto.PrivateKeySECP256K1R, len(ins))
 	for credIndex, transferInput := range ins {
-		input, ok := transferInput.In.(*secp256k1fx.TransferInput)
+		inIntf := transferInput.In
+		switch stakeableIn := inIntf.(type) {
+		case *stakeable.LockIn:
+			inIntf = stakeableIn.TransferableIn
+		case *stakeable.VestingIn:
+			inIntf = stakeableIn.TransferableIn
+		}
+
+		input, ok := inIntf.(*secp256k1fx.TransferInput)
 		if !ok {
 			return nil, errUnknownInputType
 		}
@@ -139,21 +220,29 @@ func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*avax.Transferabl
 		}
 
 		outIntf := utxo.Out
-		if stakeableOut, ok := outIntf.(*stakeable.LockOut); ok {
+		switch stakeableOut := outIntf.(type) {
+		case *stakeable.LockOut:
+			outIntf = stakeableOut.TransferableOut
+		case *stakeable.VestingOut:
 			outIntf = stakeableOut.TransferableOut
 		}
 
-		out, ok := outIntf.(*secp256k1fx.TransferOutput)
-		if !ok {
+		var addrs []ids.ShortID
+		switch out := outIntf.(type) {
+		case *secp256k1fx.TransferOutput:
+			addrs = out.Addrs
+		case *secp256k1fx.WeightedTransferOutput:
+			addrs = out.Addrs
+		default:
 			return nil, errUnknownOutputType
 		}
 
 		for sigIndex, addrIndex := range input.SigIndices {
-			if addrIndex >= uint32(len(out.Addrs)) {
+			if addrIndex >= uint32(len(addrs)) {
 				return nil, errInvalidUTXOSigIndex
 			}
 
-			addr := out.Addrs[addrIndex]
+			addr := addrs[addrIndex]
 			key, ok := s.kc.Get(addr)
 			if !ok {
 				// If we don't have access to the key, then we can't sign this
@@ -167,31 +256,26 @@ func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*avax.Transferabl
 }
 
 func (s *signerVisitor) getSubnetSigners(subnetID ids.ID, subnetAuth verify.Verifiable) ([]*crypto.PrivateKeySECP256K1R, error) {
-	subnetInput, ok := subnetAuth.(*secp256k1fx.Input)
-	if !ok {
-		return nil, errUnknownSubnetAuthType
-	}
-
-	subnetTx, err := s.backend.GetTx(s.ctx, subnetID)
+	subnetOwner, err := s.backend.GetSubnetOwner(s.ctx, subnetID)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to fetch subnet %q: %w",
-			subnetID,
-			err,
-		)
+		return nil, err
 	}
-	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
+	return s.getAuthSigners(subnetOwner, subnetAuth)
+}
+
+func (s *signerVisitor) getAuthSigners(authOwner fx.Owner, auth verify.Verifiable) ([]*crypto.PrivateKeySECP256K1R, error) {
+	input, ok := auth.(*secp256k1fx.Input)
 	if !ok {
-		return nil, errWrongTxType
+		return nil, errUnknownSubnetAuthType
 	}
 
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+	owner, ok := authOwner.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, errUnknownOwnerType
 	}
 
-	authSigners := make([]*crypto.PrivateKeySECP256K1R, len(subnetInput.SigIndices))
-	for sigIndex, addrIndex := range subnetInput.SigIndices {
+	authSigners := make([]*crypto.PrivateKeySECP256K1R, len(input.SigIndices))
+	for sigIndex, addrIndex := range input.SigIndices {
 		if addrIndex >= uint32(len(owner.Addrs)) {
 			return nil, errInvalidUTXOSigIndex
 		}
@@ -6,6 +6,7 @@ package p
 import (
 	"github.com/kukrer/savannahnode/ids"
 	"github.com/kukrer/savannahnode/vms/components/avax"
+	"github.com/kukrer/savannahnode/vms/platformvm/signer"
 	"github.com/kukrer/savannahnode/vms/platformvm/txs"
 	"github.com/kukrer/savannahnode/vms/platformvm/validator"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
@@ -70,6 +71,44 @@ func (w *walletWithOptions) IssueAddSubnetValidatorTx(
 	)
 }
 
+func (w *walletWithOptions) IssueRemoveSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueRemoveSubnetValidatorTx(
+		nodeID,
+		subnetID,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueTransferSubnetOwnershipTx(
+		subnetID,
+		owner,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueTopUpValidatorTx(
+	nodeID ids.NodeID,
+	endTime uint64,
+	stakeAmount uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueTopUpValidatorTx(
+		nodeID,
+		endTime,
+		stakeAmount,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueAddDelegatorTx(
 	vdr *validator.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -134,6 +173,76 @@ func (w *walletWithOptions) IssueExportTx(
 	)
 }
 
+func (w *walletWithOptions) IssueTransformSubnetTx(
+	subnetID ids.ID,
+	assetID ids.ID,
+	initialSupply uint64,
+	maxSupply uint64,
+	minConsumptionRate uint64,
+	maxConsumptionRate uint64,
+	minValidatorStake uint64,
+	maxValidatorStake uint64,
+	minStakeDuration uint32,
+	maxStakeDuration uint32,
+	minDelegationFee uint32,
+	minDelegatorStake uint64,
+	maxValidatorWeightFactor byte,
+	uptimeRequirement uint32,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueTransformSubnetTx(
+		subnetID,
+		assetID,
+		initialSupply,
+		maxSupply,
+		minConsumptionRate,
+		maxConsumptionRate,
+		minValidatorStake,
+		maxValidatorStake,
+		minStakeDuration,
+		maxStakeDuration,
+		minDelegationFee,
+		minDelegatorStake,
+		maxValidatorWeightFactor,
+		uptimeRequirement,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueAddPermissionlessValidatorTx(
+	vdr *validator.SubnetValidator,
+	signer signer.Signer,
+	assetID ids.ID,
+	validationRewardsOwner *secp256k1fx.OutputOwners,
+	delegationRewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueAddPermissionlessValidatorTx(
+		vdr,
+		signer,
+		assetID,
+		validationRewardsOwner,
+		delegationRewardsOwner,
+		shares,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueAddPermissionlessDelegatorTx(
+	vdr *validator.SubnetValidator,
+	assetID ids.ID,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueAddPermissionlessDelegatorTx(
+		vdr,
+		assetID,
+		rewardsOwner,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueUnsignedTx(
 	utx txs.UnsignedTx,
 	options ...common.Option,
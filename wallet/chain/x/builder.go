@@ -14,6 +14,7 @@ import (
 	"github.com/kukrer/savannahnode/vms/avm/txs"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
@@ -23,6 +24,10 @@ import (
 var (
 	errNoChangeAddress   = errors.New("no possible change address")
 	errInsufficientFunds = errors.New("insufficient funds")
+	errUnknownHTLC       = errors.New("unknown HTLC")
+	errHTLCExpired       = errors.New("HTLC can't be claimed after its deadline")
+	errHTLCNotExpired    = errors.New("HTLC can't be refunded before its deadline")
+	errCantSpendHTLC     = errors.New("HTLC can't be spent by the provided addresses")
 
 	_ Builder = &builder{}
 )
@@ -144,6 +149,22 @@ type Builder interface {
 		outputs []*avax.TransferableOutput,
 		options ...common.Option,
 	) (*txs.ExportTx, error)
+
+	// NewSpendHTLCTx creates a transaction that spends an HTLC output and
+	// sends its funds to [to]. If the HTLC holds AVAX, the tx fee is paid
+	// with its funds when possible.
+	//
+	// - [utxoID] specifies the UTXO of the HTLC output to spend.
+	// - [preimage] specifies the preimage of the hash of the HTLC. If
+	//   provided, the HTLC is claimed by its recipient before its deadline.
+	//   Otherwise, the HTLC is refunded to its sender after its deadline.
+	// - [to] specifies where to send the funds of the HTLC to.
+	NewSpendHTLCTx(
+		utxoID ids.ID,
+		preimage []byte,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.BaseTx, error)
 }
 
 // BuilderBackend specifies the required information needed to build unsigned
@@ -484,6 +505,109 @@ func (b *builder) NewExportTx(
 	}, nil
 }
 
+func (b *builder) NewSpendHTLCTx(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	ops := common.NewOptions(options)
+	utxos, err := b.backend.UTXOs(ops.Context(), b.backend.BlockchainID())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		utxo *avax.UTXO
+		out  *htlcfx.TransferOutput
+	)
+	for _, u := range utxos {
+		if u.InputID() != utxoID {
+			continue
+		}
+		htlcOut, ok := u.Out.(*htlcfx.TransferOutput)
+		if !ok {
+			break
+		}
+		utxo = u
+		out = htlcOut
+		break
+	}
+	if utxo == nil {
+		return nil, fmt.Errorf("%w: %s", errUnknownHTLC, utxoID)
+	}
+
+	minIssuanceTime := ops.MinIssuanceTime()
+	owners := &out.Sender
+	isClaim := len(preimage) > 0
+	switch {
+	case isClaim && minIssuanceTime >= out.Deadline:
+		return nil, errHTLCExpired
+	case isClaim:
+		owners = &out.Recipient
+	case minIssuanceTime < out.Deadline:
+		return nil, errHTLCNotExpired
+	}
+
+	inputSigIndices, ok := common.MatchOwners(owners, ops.Addresses(b.addrs), minIssuanceTime)
+	if !ok {
+		return nil, errCantSpendHTLC
+	}
+	htlcInput := &avax.TransferableInput{
+		UTXOID: utxo.UTXOID,
+		Asset:  utxo.Asset,
+		In: &htlcfx.TransferInput{
+			Amt:      out.Amt,
+			Preimage: preimage,
+			Input: secp256k1fx.Input{
+				SigIndices: inputSigIndices,
+			},
+		},
+	}
+
+	var (
+		avaxAssetID = b.backend.AVAXAssetID()
+		txFee       = b.backend.BaseTxFee()
+		assetID     = utxo.AssetID()
+		amount      = out.Amt
+		toBurn      = map[ids.ID]uint64{}
+	)
+	switch {
+	case assetID != avaxAssetID:
+		toBurn[avaxAssetID] = txFee
+	case amount > txFee: // the funds of the HTLC pay the tx fee
+		amount -= txFee
+	default:
+		toBurn[avaxAssetID] = txFee - amount
+		amount = 0
+	}
+
+	inputs, outputs, err := b.spend(toBurn, ops)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	inputs = append(inputs, htlcInput)
+	if amount > 0 {
+		outputs = append(outputs, &avax.TransferableOutput{
+			Asset: utxo.Asset,
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: *to,
+			},
+		})
+	}
+
+	avax.SortTransferableInputs(inputs)
+	avax.SortTransferableOutputs(outputs, Parser.Codec())
+	return &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    b.backend.NetworkID(),
+		BlockchainID: b.backend.BlockchainID(),
+		Ins:          inputs,
+		Outs:         outputs,
+		Memo:         ops.Memo(),
+	}}, nil
+}
+
 func (b *builder) getBalance(
 	chainID ids.ID,
 	options *common.Options,
@@ -156,3 +156,17 @@ func (b *builderWithOptions) NewExportTx(
 		common.UnionOptions(b.options, options)...,
 	)
 }
+
+func (b *builderWithOptions) NewSpendHTLCTx(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return b.Builder.NewSpendHTLCTx(
+		utxoID,
+		preimage,
+		to,
+		common.UnionOptions(b.options, options)...,
+	)
+}
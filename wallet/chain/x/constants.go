@@ -6,7 +6,9 @@ package x
 import (
 	"github.com/kukrer/savannahnode/vms/avm/fxs"
 	"github.com/kukrer/savannahnode/vms/avm/txs"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
@@ -15,6 +17,8 @@ const (
 	SECP256K1FxIndex = 0
 	NFTFxIndex       = 1
 	PropertyFxIndex  = 2
+	HTLCFxIndex      = 3
+	PolicyFxIndex    = 4
 )
 
 // Parser to support serialization and deserialization
@@ -26,6 +30,8 @@ func init() {
 		&secp256k1fx.Fx{},
 		&nftfx.Fx{},
 		&propertyfx.Fx{},
+		&htlcfx.Fx{},
+		&policyfx.Fx{},
 	})
 	if err != nil {
 		panic(err)
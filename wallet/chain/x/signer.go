@@ -17,7 +17,9 @@ import (
 	"github.com/kukrer/savannahnode/vms/avm/txs"
 	"github.com/kukrer/savannahnode/vms/components/avax"
 	"github.com/kukrer/savannahnode/vms/components/verify"
+	"github.com/kukrer/savannahnode/vms/htlcfx"
 	"github.com/kukrer/savannahnode/vms/nftfx"
+	"github.com/kukrer/savannahnode/vms/policyfx"
 	"github.com/kukrer/savannahnode/vms/propertyfx"
 	"github.com/kukrer/savannahnode/vms/secp256k1fx"
 )
@@ -136,9 +138,15 @@ func (s *signer) getSigners(ctx stdcontext.Context, sourceChainID ids.ID, ins []
 	txCreds := make([]verify.Verifiable, len(ins))
 	txSigners := make([][]*crypto.PrivateKeySECP256K1R, len(ins))
 	for credIndex, transferInput := range ins {
-		txCreds[credIndex] = &secp256k1fx.Credential{}
-		input, ok := transferInput.In.(*secp256k1fx.TransferInput)
-		if !ok {
+		var input *secp256k1fx.Input
+		switch in := transferInput.In.(type) {
+		case *secp256k1fx.TransferInput:
+			txCreds[credIndex] = &secp256k1fx.Credential{}
+			input = &in.Input
+		case *htlcfx.TransferInput:
+			txCreds[credIndex] = &htlcfx.Credential{}
+			input = &in.Input
+		default:
 			return nil, nil, errUnknownInputType
 		}
 
@@ -156,24 +164,26 @@ func (s *signer) getSigners(ctx stdcontext.Context, sourceChainID ids.ID, ins []
 			return nil, nil, err
 		}
 
-		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
-		if !ok {
+		var addrs []ids.ShortID
+		switch out := utxo.Out.(type) {
+		case *secp256k1fx.TransferOutput:
+			addrs = out.Addrs
+		case *secp256k1fx.WeightedTransferOutput:
+			addrs = out.Addrs
+		case *htlcfx.TransferOutput:
+			// An HTLC is signed by its recipient when claimed with the
+			// preimage of its hash, and by its sender when refunded.
+			if in, ok := transferInput.In.(*htlcfx.TransferInput); ok && in.IsClaim() {
+				addrs = out.Recipient.Addrs
+			} else {
+				addrs = out.Sender.Addrs
+			}
+		default:
 			return nil, nil, errUnknownOutputType
 		}
 
-		for sigIndex, addrIndex := range input.SigIndices {
-			if addrIndex >= uint32(len(out.Addrs)) {
-				return nil, nil, errInvalidUTXOSigIndex
-			}
-
-			addr := out.Addrs[addrIndex]
-			key, ok := s.kc.Get(addr)
-			if !ok {
-				// If we don't have access to the key, then we can't sign this
-				// transaction. However, we can attempt to partially sign it.
-				continue
-			}
-			inputSigners[sigIndex] = key
+		if err := s.getInputSigners(input, addrs, inputSigners); err != nil {
+			return nil, nil, err
 		}
 	}
 	return txCreds, txSigners, nil
@@ -183,7 +193,12 @@ func (s *signer) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.ID, ops
 	txCreds := make([]verify.Verifiable, len(ops))
 	txSigners := make([][]*crypto.PrivateKeySECP256K1R, len(ops))
 	for credIndex, op := range ops {
-		var input *secp256k1fx.Input
+		var (
+			input *secp256k1fx.Input
+			// policy operations carry the policy they consume, so their
+			// signers are known without fetching the consumed UTXOs
+			policyAddrs []ids.ShortID
+		)
 		switch op := op.Op.(type) {
 		case *secp256k1fx.MintOperation:
 			txCreds[credIndex] = &secp256k1fx.Credential{}
@@ -200,6 +215,14 @@ func (s *signer) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.ID, ops
 		case *propertyfx.BurnOperation:
 			txCreds[credIndex] = &propertyfx.Credential{}
 			input = &op.Input
+		case *policyfx.FreezeOperation:
+			txCreds[credIndex] = &policyfx.Credential{}
+			input = &op.Input
+			policyAddrs = op.PolicyOutput.Addrs
+		case *policyfx.ClawbackOperation:
+			txCreds[credIndex] = &policyfx.Credential{}
+			input = &op.Input
+			policyAddrs = op.PolicyOutput.Addrs
 		default:
 			return nil, nil, errUnknownOpType
 		}
@@ -207,6 +230,13 @@ func (s *signer) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.ID, ops
 		inputSigners := make([]*crypto.PrivateKeySECP256K1R, len(input.SigIndices))
 		txSigners[credIndex] = inputSigners
 
+		if policyAddrs != nil {
+			if err := s.getInputSigners(input, policyAddrs, inputSigners); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
 		if len(op.UTXOIDs) != 1 {
 			return nil, nil, errInvalidNumUTXOsInOp
 		}
@@ -237,24 +267,33 @@ func (s *signer) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.ID, ops
 			return nil, nil, errUnknownOutputType
 		}
 
-		for sigIndex, addrIndex := range input.SigIndices {
-			if addrIndex >= uint32(len(addrs)) {
-				return nil, nil, errInvalidUTXOSigIndex
-			}
-
-			addr := addrs[addrIndex]
-			key, ok := s.kc.Get(addr)
-			if !ok {
-				// If we don't have access to the key, then we can't sign this
-				// transaction. However, we can attempt to partially sign it.
-				continue
-			}
-			inputSigners[sigIndex] = key
+		if err := s.getInputSigners(input, addrs, inputSigners); err != nil {
+			return nil, nil, err
 		}
 	}
 	return txCreds, txSigners, nil
 }
 
+// getInputSigners populates [inputSigners] with the keys of [addrs] referenced by
+// the signature indices of [input].
+func (s *signer) getInputSigners(input *secp256k1fx.Input, addrs []ids.ShortID, inputSigners []*crypto.PrivateKeySECP256K1R) error {
+	for sigIndex, addrIndex := range input.SigIndices {
+		if addrIndex >= uint32(len(addrs)) {
+			return errInvalidUTXOSigIndex
+		}
+
+		addr := addrs[addrIndex]
+		key, ok := s.kc.Get(addr)
+		if !ok {
+			// If we don't have access to the key, then we can't sign this
+			// transaction. However, we can attempt to partially sign it.
+			continue
+		}
+		inputSigners[sigIndex] = key
+	}
+	return nil
+}
+
 func (s *signer) sign(tx *txs.Tx, creds []verify.Verifiable, txSigners [][]*crypto.PrivateKeySECP256K1R) error {
 	codec := Parser.Codec()
 	unsignedBytes, err := codec.Marshal(txs.CodecVersion, &tx.Unsigned)
@@ -288,6 +327,10 @@ func (s *signer) sign(tx *txs.Tx, creds []verify.Verifiable, txSigners [][]*cryp
 			cred = &credImpl.Credential
 		case *propertyfx.Credential:
 			cred = &credImpl.Credential
+		case *htlcfx.Credential:
+			cred = &credImpl.Credential
+		case *policyfx.Credential:
+			cred = &credImpl.Credential
 		default:
 			return errUnknownCredentialType
 		}
@@ -131,6 +131,21 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueSpendHTLCTx creates, signs, and issues a transaction that spends an
+	// HTLC output and sends its funds to [to].
+	//
+	// - [utxoID] specifies the UTXO of the HTLC output to spend.
+	// - [preimage] specifies the preimage of the hash of the HTLC. If
+	//   provided, the HTLC is claimed by its recipient before its deadline.
+	//   Otherwise, the HTLC is refunded to its sender after its deadline.
+	// - [to] specifies where to send the funds of the HTLC to.
+	IssueSpendHTLCTx(
+		utxoID ids.ID,
+		preimage []byte,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueUnsignedTx signs and issues the unsigned tx.
 	IssueUnsignedTx(
 		utx txs.UnsignedTx,
@@ -276,6 +291,19 @@ func (w *wallet) IssueExportTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueSpendHTLCTx(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewSpendHTLCTx(utxoID, preimage, to, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueUnsignedTx(
 	utx txs.UnsignedTx,
 	options ...common.Option,
@@ -142,6 +142,20 @@ func (w *walletWithOptions) IssueExportTx(
 	)
 }
 
+func (w *walletWithOptions) IssueSpendHTLCTx(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueSpendHTLCTx(
+		utxoID,
+		preimage,
+		to,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueUnsignedTx(
 	utx txs.UnsignedTx,
 	options ...common.Option,
@@ -51,8 +51,9 @@ type UTXOClient interface {
 func FetchState(ctx context.Context, uri string, addrs ids.ShortSet) (p.Context, x.Context, UTXOs, error) {
 	infoClient := info.NewClient(uri)
 	xClient := avm.NewClient(uri, "X")
+	pClient := platformvm.NewClient(uri)
 
-	pCTX, err := p.NewContextFromClients(ctx, infoClient, xClient)
+	pCTX, err := p.NewContextFromClients(ctx, infoClient, xClient, pClient)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -71,7 +72,7 @@ func FetchState(ctx context.Context, uri string, addrs ids.ShortSet) (p.Context,
 	}{
 		{
 			id:     constants.PlatformChainID,
-			client: platformvm.NewClient(uri),
+			client: pClient,
 			codec:  txs.Codec,
 		},
 		{